@@ -150,6 +150,14 @@ type state struct {
 	// bakeryClient holds the client that will be used to
 	// authorize macaroon based login requests.
 	bakeryClient *httpbakery.Client
+
+	// idToken holds the OpenID Connect ID token to present
+	// when logging in as an external user.
+	idToken string
+
+	// oidcLogin is called to obtain a new ID token when
+	// the controller requires one.
+	oidcLogin func(issuer, clientID string) (string, error)
 }
 
 // RedirectError is returned from Open when the controller
@@ -253,6 +261,8 @@ func open(
 		tlsConfig:    dialResult.tlsConfig,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
+		idToken:      info.IDToken,
+		oidcLogin:    opts.OIDCLogin,
 	}
 	if !info.SkipLogin {
 		if err := st.Login(info.Tag, info.Password, info.Nonce, info.Macaroons); err != nil {
@@ -352,6 +352,78 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeEndpoints changes the juju-managed firewall to expose any ports
+// that were also explicitly marked by units as open, only to the spaces
+// and CIDRs given for each of the application's endpoints. The settings
+// are merged into any given before; the empty endpoint name stands for
+// all endpoints, and an endpoint with no spaces or CIDRs is exposed to
+// everyone.
+func (c *Client) ExposeEndpoints(application string, exposed map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("exposing endpoints to specific spaces or CIDRs on this controller")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposed,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
+// SetEndpointBindings binds the given endpoints of an application to
+// spaces. Endpoints not mentioned keep their current bindings; the empty
+// endpoint name stands for the application's default binding.
+func (c *Client) SetEndpointBindings(application string, bindings map[string]string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("changing endpoint bindings on this controller")
+	}
+	params := params.ApplicationSetEndpointBindings{
+		ApplicationName:  application,
+		EndpointBindings: bindings,
+	}
+	return c.facade.FacadeCall("SetEndpointBindings", params, nil)
+}
+
+// UpdateApplicationSeries changes the series that new machines are
+// provisioned with for the application's units. Unless force is true,
+// the application's charm must support the series.
+func (c *Client) UpdateApplicationSeries(application, series string, force bool) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("changing the series of an application on this controller")
+	}
+	params := params.ApplicationUpdateSeries{
+		ApplicationName: application,
+		Series:          series,
+		Force:           force,
+	}
+	return c.facade.FacadeCall("UpdateApplicationSeries", params, nil)
+}
+
+// UpdateResourceTags merges the given tags into those set on the
+// application's cloud resources. A tag with an empty value is removed.
+func (c *Client) UpdateResourceTags(application string, resourceTags map[string]string) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("application resource tags on this controller")
+	}
+	params := params.ApplicationUpdateResourceTags{
+		ApplicationName: application,
+		ResourceTags:    resourceTags,
+	}
+	return c.facade.FacadeCall("UpdateResourceTags", params, nil)
+}
+
+// SetAntiAffinity sets whether the application's units must be kept
+// on different hosts.
+func (c *Client) SetAntiAffinity(application string, antiAffinity bool) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("application anti-affinity on this controller")
+	}
+	params := params.ApplicationSetAntiAffinity{
+		ApplicationName: application,
+		AntiAffinity:    antiAffinity,
+	}
+	return c.facade.FacadeCall("SetAntiAffinity", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
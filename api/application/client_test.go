@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -332,3 +333,173 @@ func (s *applicationSuite) TestDestroyUnitsInvalidIds(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	exposed := map[string]params.ExposedEndpoint{
+		"db": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	}
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "Expose")
+			c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+				ApplicationName:  "mysql",
+				ExposedEndpoints: exposed,
+			})
+			return nil
+		},
+		version: 5,
+	}
+	err := application.NewClient(apiCaller).ExposeEndpoints("mysql", exposed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		version: 4,
+	}
+	err := application.NewClient(apiCaller).ExposeEndpoints("mysql", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetEndpointBindings(c *gc.C) {
+	var called bool
+	bindings := map[string]string{"db": "internal"}
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetEndpointBindings")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetEndpointBindings{
+				ApplicationName:  "mysql",
+				EndpointBindings: bindings,
+			})
+			return nil
+		},
+		version: 6,
+	}
+	err := application.NewClient(apiCaller).SetEndpointBindings("mysql", bindings)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetEndpointBindingsNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		version: 5,
+	}
+	err := application.NewClient(apiCaller).SetEndpointBindings("mysql", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestUpdateApplicationSeries(c *gc.C) {
+	var called bool
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "UpdateApplicationSeries")
+			c.Assert(a, jc.DeepEquals, params.ApplicationUpdateSeries{
+				ApplicationName: "mysql",
+				Series:          "xenial",
+				Force:           true,
+			})
+			return nil
+		},
+		version: 7,
+	}
+	err := application.NewClient(apiCaller).UpdateApplicationSeries("mysql", "xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestUpdateApplicationSeriesNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		version: 6,
+	}
+	err := application.NewClient(apiCaller).UpdateApplicationSeries("mysql", "xenial", false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestUpdateResourceTags(c *gc.C) {
+	var called bool
+	resourceTags := map[string]string{"team": "db", "cost-centre": ""}
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "UpdateResourceTags")
+			c.Assert(a, jc.DeepEquals, params.ApplicationUpdateResourceTags{
+				ApplicationName: "mysql",
+				ResourceTags:    resourceTags,
+			})
+			return nil
+		},
+		version: 8,
+	}
+	err := application.NewClient(apiCaller).UpdateResourceTags("mysql", resourceTags)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestUpdateResourceTagsNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		version: 7,
+	}
+	err := application.NewClient(apiCaller).UpdateResourceTags("mysql", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestSetAntiAffinity(c *gc.C) {
+	var called bool
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetAntiAffinity")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetAntiAffinity{
+				ApplicationName: "mysql",
+				AntiAffinity:    true,
+			})
+			return nil
+		},
+		version: 9,
+	}
+	err := application.NewClient(apiCaller).SetAntiAffinity("mysql", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetAntiAffinityNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		version: 8,
+	}
+	err := application.NewClient(apiCaller).SetAntiAffinity("mysql", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
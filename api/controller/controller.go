@@ -148,6 +148,19 @@ func (c *Client) RemoveBlocks() error {
 	return c.facade.FacadeCall("RemoveBlocks", args, nil)
 }
 
+// ConfigSet changes the controller configuration values given in
+// values, and resets those named in remove to their defaults.
+func (c *Client) ConfigSet(values map[string]interface{}, remove ...string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("changing controller configuration by this controller")
+	}
+	args := params.ControllerConfigSet{
+		Config: values,
+		Remove: remove,
+	}
+	return errors.Trace(c.facade.FacadeCall("ConfigSet", args, nil))
+}
+
 // WatchAllModels returns an AllWatcher, from which you can request
 // the Next collection of Deltas (for all models).
 func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
@@ -254,27 +267,12 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
-	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
-	}
-
-	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	specArg, err := makeMigrationSpecArg(spec)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return "", errors.Trace(err)
 	}
-
 	args := params.InitiateMigrationArgs{
-		Specs: []params.MigrationSpec{{
-			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
-			TargetInfo: params.MigrationTargetInfo{
-				ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
-				Addrs:         spec.TargetAddrs,
-				CACert:        spec.TargetCACert,
-				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
-				Password:      spec.TargetPassword,
-				Macaroons:     string(macsJSON),
-			},
-		}},
+		Specs: []params.MigrationSpec{specArg},
 	}
 	response := params.InitiateMigrationResults{}
 	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
@@ -290,6 +288,58 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	return result.MigrationId, nil
 }
 
+// MigrationPrechecks runs the source and target prechecks for a
+// migration of the specified model without starting it, returning
+// every problem which would prevent the migration. An empty result
+// means the migration is expected to succeed.
+func (c *Client) MigrationPrechecks(spec MigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("migration prechecks by this controller")
+	}
+	specArg, err := makeMigrationSpecArg(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{specArg},
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("MigrationPrechecks", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Blockers, nil
+}
+
+func makeMigrationSpecArg(spec MigrationSpec) (params.MigrationSpec, error) {
+	if err := spec.Validate(); err != nil {
+		return params.MigrationSpec{}, errors.Annotatef(err, "client-side validation failed")
+	}
+
+	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	if err != nil {
+		return params.MigrationSpec{}, errors.Annotatef(err, "client-side validation failed")
+	}
+
+	return params.MigrationSpec{
+		ModelTag: names.NewModelTag(spec.ModelUUID).String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
+			Addrs:         spec.TargetAddrs,
+			CACert:        spec.TargetCACert,
+			AuthTag:       names.NewUserTag(spec.TargetUser).String(),
+			Password:      spec.TargetPassword,
+			Macaroons:     string(macsJSON),
+		},
+	}, nil
+}
+
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
 	if len(macs) == 0 {
 		return "", nil
@@ -118,6 +118,83 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Blockers: []string{"source: cleanup needed"},
+				}},
+			}
+			return nil
+		},
+		version: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	blockers, err := client.MigrationPrechecks(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{"source: cleanup needed"})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationPrechecks", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationPrechecksError(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+		version: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationPrechecksNotSupported(c *gc.C) {
+	client, stub := makeClient(params.InitiateMigrationResults{})
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "migration prechecks by this controller not supported")
+	stub.CheckNoCalls(c)
+}
+
+func (s *Suite) TestConfigSet(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+		version: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{"api-allow": "10.0.0.0/8"}, "auditing-enabled")
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ConfigSet", []interface{}{params.ControllerConfigSet{
+			Config: map[string]interface{}{"api-allow": "10.0.0.0/8"},
+			Remove: []string{"auditing-enabled"},
+		}}},
+	})
+}
+
+func (s *Suite) TestConfigSetNotSupported(c *gc.C) {
+	client, stub := makeClient(params.InitiateMigrationResults{})
+	err := client.ConfigSet(map[string]interface{}{"api-allow": "10.0.0.0/8"})
+	c.Check(err, gc.ErrorMatches, "changing controller configuration by this controller not supported")
+	stub.CheckNoCalls(c)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -202,6 +279,15 @@ func makeClient(results params.InitiateMigrationResults) (
 	return client, &stub
 }
 
+type versionedAPICaller struct {
+	apitesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
 func makeSpec() controller.MigrationSpec {
 	mac, err := macaroon.New([]byte("secret"), "id", "location")
 	if err != nil {
@@ -17,7 +17,7 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  4,
+	"Application":                  9,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
@@ -28,18 +28,19 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       1,
 	"Cloud":                        1,
-	"Controller":                   3,
+	"Controller":                   5,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
+	"Firewaller":                   7,
 	"HighAvailability":             2,
+	"HostFirewaller":               1,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
-	"InstancePoller":               3,
+	"InstancePoller":               4,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -47,8 +48,8 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               3,
-	"MachineUndertaker":            1,
+	"MachineManager":               9,
+	"MachineUndertaker":            2,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
@@ -58,14 +59,14 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              5,
 	"ModelConfig":                  1,
-	"ModelManager":                 2,
+	"ModelManager":                 4,
 	"NotifyWatcher":                1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  3,
+	"Provisioner":                  5,
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
 	"RelationUnitsWatcher":         1,
@@ -73,8 +74,10 @@ var facadeVersions = map[string]int{
 	"RemoteRelations":              1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
+	"ResourceTagger":               1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Roles":                        1,
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    2,
@@ -85,9 +88,10 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       5,
+	"Uniter":                       8,
 	"Upgrader":                     1,
-	"UserManager":                  1,
+	"UpgradeSeries":                1,
+	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
 }
 
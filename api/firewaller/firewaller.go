@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/common/cloudspec"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -118,6 +119,84 @@ func (st *State) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the subnets in the model.
+func (st *State) WatchSubnets() (watcher.StringsWatcher, error) {
+	if st.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("watching subnets by this controller")
+	}
+	var result params.StringsWatchResult
+	if err := st.facade.FacadeCall("WatchSubnets", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchMachinesProvisioning returns a NotifyWatcher that notifies
+// when machines in the model are provisioned.
+func (st *State) WatchMachinesProvisioning() (watcher.NotifyWatcher, error) {
+	if st.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("watching machine provisioning by this controller")
+	}
+	var result params.NotifyWatchResult
+	if err := st.facade.FacadeCall("WatchMachinesProvisioning", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchModelFirewallRules returns a NotifyWatcher that notifies of
+// changes to the firewall rules that apply to the whole model.
+func (st *State) WatchModelFirewallRules() (watcher.NotifyWatcher, error) {
+	if st.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("model firewall rules by this controller")
+	}
+	var result params.NotifyWatchResult
+	if err := st.facade.FacadeCall("WatchModelFirewallRules", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// ModelFirewallRules returns the firewall rules that apply to the
+// whole model, such as SSH access to its machines.
+func (st *State) ModelFirewallRules() ([]network.IngressRule, error) {
+	if st.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("model firewall rules by this controller")
+	}
+	var result params.IngressRulesResult
+	if err := st.facade.FacadeCall("ModelFirewallRules", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	rules := make([]network.IngressRule, len(result.Rules))
+	for i, rule := range result.Rules {
+		portRange := rule.PortRange.NetworkPortRange()
+		ingressRule, err := network.NewIngressRule(
+			portRange.Protocol, portRange.FromPort, portRange.ToPort, rule.SourceCIDRs...,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules[i] = ingressRule
+	}
+	return rules, nil
+}
+
 // Relation provides access to methods of a state.Relation through the
 // facade.
 func (st *State) Relation(tag names.RelationTag) (*Relation, error) {
@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -111,6 +112,28 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	ports, err := m.OpenedPortEndpoints(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[network.PortRange]names.UnitTag, len(ports))
+	for portRange, opener := range ports {
+		result[portRange] = opener.Unit
+	}
+	return result, nil
+}
+
+// PortOpener identifies the unit that opened a port range, and the
+// endpoint it opened the range for. An empty Endpoint means the range
+// is open on all of the unit's endpoints.
+type PortOpener struct {
+	Unit     names.UnitTag
+	Endpoint string
+}
+
+// OpenedPortEndpoints returns the unit and endpoint that opened each
+// port range on the machine for the subnet matching given subnetTag.
+func (m *Machine) OpenedPortEndpoints(subnetTag names.SubnetTag) (map[network.PortRange]PortOpener, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +156,48 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]PortOpener)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[ports.PortRange.NetworkPortRange()] = PortOpener{
+			Unit:     unitTag,
+			Endpoint: ports.Endpoint,
+		}
 	}
 	return endResult, nil
 }
+
+// EgressRules returns the egress rules allowed by the units on the
+// machine. It returns a NotSupported error if the controller cannot
+// report egress rules.
+func (m *Machine) EgressRules() ([]network.EgressRule, error) {
+	if m.st.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("egress rules by this controller")
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("GetMachineEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	rules := make([]network.EgressRule, len(result.Rules))
+	for i, rule := range result.Rules {
+		rules[i] = network.EgressRule{
+			PortRange:        rule.PortRange.NetworkPortRange(),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return rules, nil
+}
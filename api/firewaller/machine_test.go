@@ -134,3 +134,32 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
 	})
 }
+
+func (s *machineSuite) TestOpenedPortEndpoints(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	err := s.units[0].OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPort("tcp", 1234)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.apiMachine.OpenedPortEndpoints(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.PortOpener{
+		network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}:     {Unit: unitTag, Endpoint: "url"},
+		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {Unit: unitTag},
+	})
+}
+
+func (s *machineSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.apiMachine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = s.units[0].OpenEgress("tcp", 443, 443, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.apiMachine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the client-side API facade used
+// by the hostfirewaller worker.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+// Facade provides access to the HostFirewaller API facade.
+type Facade struct {
+	*common.ModelWatcher
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side HostFirewaller facade.
+func NewFacade(caller base.APICaller) *Facade {
+	facadeCaller := base.NewFacadeCaller(caller, "HostFirewaller")
+	return &Facade{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		caller:       facadeCaller,
+	}
+}
+
+// WatchIngressRules returns a NotifyWatcher that triggers when the
+// ingress rules of the given machine may have changed.
+func (f *Facade) WatchIngressRules(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.NotifyWatchResults
+	if err := f.caller.FacadeCall("WatchIngressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// IngressRules returns the ingress rules that the firewall of the
+// given machine should allow.
+func (f *Facade) IngressRules(tag names.MachineTag) ([]network.IngressRule, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.IngressRulesResults
+	if err := f.caller.FacadeCall("IngressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	rules := make([]network.IngressRule, len(result.Rules))
+	for i, rule := range result.Rules {
+		var err error
+		rules[i], err = network.NewIngressRule(
+			rule.PortRange.Protocol,
+			rule.PortRange.FromPort,
+			rule.PortRange.ToPort,
+			rule.SourceCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return rules, nil
+}
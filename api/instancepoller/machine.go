@@ -96,6 +96,42 @@ func (m *Machine) IsManual() (bool, error) {
 	return result.Result, nil
 }
 
+// IsPreemptible returns whether the machine runs on a spot or
+// preemptible instance, which the cloud may terminate at any time.
+func (m *Machine) IsPreemptible() (bool, error) {
+	var results params.BoolResults
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.tag.String()},
+	}}
+	err := m.facade.FacadeCall("ArePreemptible", args, &results)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		err := errors.Errorf("expected 1 result, got %d", len(results.Results))
+		return false, err
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// ReplaceInstance forgets the machine's terminated instance, so that
+// the provisioner starts a replacement instance for the machine.
+func (m *Machine) ReplaceInstance() error {
+	var result params.ErrorResults
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.tag.String()},
+	}}
+	err := m.facade.FacadeCall("ReplaceInstances", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // InstanceId returns the machine's instance id.
 func (m *Machine) InstanceId() (instance.Id, error) {
 	var results params.StringResults
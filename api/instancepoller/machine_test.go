@@ -72,6 +72,19 @@ var machineErrorTests = []struct {
 		return err
 	},
 	resultsRef: params.BoolResults{},
+}, {
+	method: "IsPreemptible",
+	wrapper: func(m *instancepoller.Machine) error {
+		_, err := m.IsPreemptible()
+		return err
+	},
+	resultsRef: params.BoolResults{},
+}, {
+	method: "ReplaceInstance",
+	wrapper: func(m *instancepoller.Machine) error {
+		return m.ReplaceInstance()
+	},
+	resultsRef: params.ErrorResults{},
 }, {
 	method: "InstanceId",
 	wrapper: func(m *instancepoller.Machine) error {
@@ -188,6 +201,29 @@ func (s *MachineSuite) TestIsManualSuccess(c *gc.C) {
 	c.Check(apiCaller.CallCount, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestIsPreemptibleSuccess(c *gc.C) {
+	results := params.BoolResults{
+		Results: []params.BoolResult{{Result: true}},
+	}
+	apiCaller := successAPICaller(c, "ArePreemptible", entitiesArgs, results)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, params.Alive)
+	isPreemptible, err := machine.IsPreemptible()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(isPreemptible, jc.IsTrue)
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
+func (s *MachineSuite) TestReplaceInstanceSuccess(c *gc.C) {
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	}
+	apiCaller := successAPICaller(c, "ReplaceInstances", entitiesArgs, results)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, params.Alive)
+	err := machine.ReplaceInstance()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
 func (s *MachineSuite) TestInstanceIdSuccess(c *gc.C) {
 	results := params.StringResults{
 		Results: []params.StringResult{{Result: "i-foo"}},
@@ -75,6 +75,11 @@ type Info struct {
 	// authenticate with the API server.
 	Macaroons []macaroon.Slice `yaml:",omitempty"`
 
+	// IDToken holds an OpenID Connect ID token that may be used
+	// to authenticate an external user with the API server. It is
+	// only used if Tag is nil.
+	IDToken string `yaml:",omitempty"`
+
 	// Nonce holds the nonce used when provisioning the machine. Used
 	// only by the machine agent.
 	Nonce string `yaml:",omitempty"`
@@ -112,6 +117,9 @@ func (info *Info) Validate() error {
 		if len(info.Macaroons) > 0 {
 			return errors.NotValidf("specifying Macaroons and SkipLogin")
 		}
+		if info.IDToken != "" {
+			return errors.NotValidf("specifying IDToken and SkipLogin")
+		}
 	}
 	return nil
 }
@@ -138,6 +146,12 @@ type DialOpts struct {
 	// the HTTP client is ignored.
 	BakeryClient *httpbakery.Client
 
+	// OIDCLogin, if non-nil, is called when the controller requires
+	// an OpenID Connect ID token to log in an external user. It is
+	// passed the issuer URL of the provider and the client ID to
+	// obtain the token for, and should return the ID token.
+	OIDCLogin func(issuer, clientID string) (string, error)
+
 	// InsecureSkipVerify skips TLS certificate verification
 	// when connecting to the controller. This should only
 	// be used in tests, or when verification cannot be
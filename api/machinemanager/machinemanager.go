@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 )
 
 const machineManagerFacade = "MachineManager"
@@ -49,6 +50,45 @@ func (client *Client) ForceDestroyMachines(machines ...string) ([]params.Destroy
 	return client.destroyMachines("ForceDestroyMachine", machines)
 }
 
+// DestroyMachinesWithParams removes a given set of machines, forcibly
+// removing their units if force is true. If keepInstance is true, the
+// cloud instances of the machines are left running.
+func (client *Client) DestroyMachinesWithParams(force, keepInstance bool, machines ...string) ([]params.DestroyMachineResult, error) {
+	if client.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("destroying machines with parameters")
+	}
+	args := params.DestroyMachinesParams{
+		Force:        force,
+		KeepInstance: keepInstance,
+		MachineTags:  make([]string, 0, len(machines)),
+	}
+	allResults := make([]params.DestroyMachineResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.MachineTags = append(args.MachineTags, names.NewMachineTag(machineId).String())
+	}
+	if len(args.MachineTags) > 0 {
+		var result params.DestroyMachineResults
+		if err := client.facade.FacadeCall("DestroyMachineWithParams", args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.MachineTags) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.MachineTags), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
+
 func (client *Client) destroyMachines(method string, machines []string) ([]params.DestroyMachineResult, error) {
 	args := params.Entities{
 		Entities: make([]params.Entity, 0, len(machines)),
@@ -81,3 +121,145 @@ func (client *Client) destroyMachines(method string, machines []string) ([]param
 	}
 	return allResults, nil
 }
+
+// LinkLayerDevices returns the link-layer devices of the given
+// machines, along with their addresses.
+func (client *Client) LinkLayerDevices(machines ...string) ([]params.LinkLayerDevicesResult, error) {
+	if client.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("showing link-layer devices")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machines)),
+	}
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			return nil, errors.NotValidf("machine ID %q", machineId)
+		}
+		args.Entities[i].Tag = names.NewMachineTag(machineId).String()
+	}
+	var results params.LinkLayerDevicesResults
+	if err := client.facade.FacadeCall("LinkLayerDevices", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(machines) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(machines), n)
+	}
+	return results.Results, nil
+}
+
+// UpgradeSeriesPrepare locks the given machine for an in-place upgrade
+// to the given series, and starts preparing its units for the upgrade.
+func (client *Client) UpgradeSeriesPrepare(machineId, series string) error {
+	return client.upgradeSeries("UpgradeSeriesPrepare", machineId, series)
+}
+
+// UpgradeSeriesComplete records that the operating system of the given
+// machine has been upgraded, so that its units can complete the series
+// upgrade.
+func (client *Client) UpgradeSeriesComplete(machineId string) error {
+	return client.upgradeSeries("UpgradeSeriesComplete", machineId, "")
+}
+
+// UpgradeSeriesAbort cancels the series upgrade of the given machine
+// and unlocks it. The upgrade can only be aborted while the units on
+// the machine are preparing for it.
+func (client *Client) UpgradeSeriesAbort(machineId string) error {
+	if client.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("aborting machine series upgrades")
+	}
+	return client.upgradeSeries("UpgradeSeriesAbort", machineId, "")
+}
+
+func (client *Client) upgradeSeries(method, machineId, series string) error {
+	if client.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("upgrading machine series")
+	}
+	if !names.IsValidMachine(machineId) {
+		return errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewMachineTag(machineId).String()},
+			Series: series,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// MaintainMachine puts the machine with the given ID into maintenance,
+// or takes it out again. If evacuate is true, the stateless units on a
+// machine entering maintenance are replaced by units on other machines.
+func (client *Client) MaintainMachine(machineId string, maintenance, evacuate bool) (params.MaintainMachineResult, error) {
+	if client.BestAPIVersion() < 6 {
+		return params.MaintainMachineResult{}, errors.NotSupportedf("machine maintenance")
+	}
+	if !names.IsValidMachine(machineId) {
+		return params.MaintainMachineResult{}, errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.MaintainMachineArgs{
+		Args: []params.MaintainMachineArg{{
+			Entity:      params.Entity{Tag: names.NewMachineTag(machineId).String()},
+			Maintenance: maintenance,
+			Evacuate:    evacuate,
+		}},
+	}
+	var results params.MaintainMachineResults
+	if err := client.facade.FacadeCall("MaintainMachines", args, &results); err != nil {
+		return params.MaintainMachineResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.MaintainMachineResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result, result.Error
+	}
+	return result, nil
+}
+
+// AdoptableInstance returns the provider's addresses for the cloud
+// instance with the given ID, if it can be adopted into the model.
+func (client *Client) AdoptableInstance(instanceId string) ([]network.Address, error) {
+	if client.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("adopting instances")
+	}
+	args := params.AdoptableInstancesArgs{
+		InstanceIds: []string{instanceId},
+	}
+	var results params.AdoptableInstanceResults
+	if err := client.facade.FacadeCall("AdoptableInstances", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return params.NetworkAddresses(result.Addresses...), nil
+}
+
+// AdoptInstances records existing cloud instances as machines, with
+// the supplied parameters. Each set of parameters must hold the
+// provider ID of the instance and the nonce its agent will use.
+func (client *Client) AdoptInstances(machineParams []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	if client.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("adopting instances")
+	}
+	args := params.AddMachines{
+		MachineParams: machineParams,
+	}
+	var results params.AddMachinesResults
+	if err := client.facade.FacadeCall("AdoptInstances", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Machines) != len(machineParams) {
+		return nil, errors.Errorf("expected %d result, got %d", len(machineParams), len(results.Machines))
+	}
+	return results.Machines, nil
+}
@@ -4,15 +4,16 @@
 package machinemanager_test
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -180,3 +181,305 @@ func (s *MachinemanagerSuite) TestDestroyMachinesInvalidIds(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
+	expectedResults := []params.DestroyMachineResult{{
+		Error: &params.Error{Message: `machine ID "!" not valid`},
+	}, {
+		Info: &params.DestroyMachineInfo{},
+	}}
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "DestroyMachineWithParams")
+			c.Check(a, jc.DeepEquals, params.DestroyMachinesParams{
+				MachineTags:  []string{"machine-0"},
+				Force:        true,
+				KeepInstance: true,
+			})
+			out := response.(*params.DestroyMachineResults)
+			*out = params.DestroyMachineResults{expectedResults[1:]}
+			return nil
+		},
+		version: 7,
+	})
+	results, err := client.DestroyMachinesWithParams(true, true, "!", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *MachinemanagerSuite) TestDestroyMachinesWithParamsNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		version: 6,
+	})
+	_, err := client.DestroyMachinesWithParams(false, true, "0")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MachinemanagerSuite) TestLinkLayerDevices(c *gc.C) {
+	expectedResults := []params.LinkLayerDevicesResult{{
+		Devices: []params.LinkLayerDevice{{Name: "eth0", Type: "ethernet"}},
+	}, {
+		Error: &params.Error{Message: "boom"},
+	}}
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "LinkLayerDevices")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1-lxd-2"}},
+			})
+			out := response.(*params.LinkLayerDevicesResults)
+			*out = params.LinkLayerDevicesResults{expectedResults}
+			return nil
+		},
+		version: 4,
+	})
+	results, err := client.LinkLayerDevices("0", "1/lxd/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *MachinemanagerSuite) TestLinkLayerDevicesInvalidId(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		version: 4,
+	})
+	_, err := client.LinkLayerDevices("!")
+	c.Assert(err, gc.ErrorMatches, `machine ID "!" not valid`)
+}
+
+func (s *MachinemanagerSuite) TestLinkLayerDevicesNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call")
+		return nil
+	})
+	_, err := client.LinkLayerDevices("0")
+	c.Assert(err, gc.ErrorMatches, "showing link-layer devices not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "UpgradeSeriesPrepare")
+			c.Check(a, jc.DeepEquals, params.UpdateSeriesArgs{
+				Args: []params.UpdateSeriesArg{{
+					Entity: params.Entity{Tag: "machine-0"},
+					Series: "xenial",
+				}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "boom"},
+			}}}
+			return nil
+		},
+		version: 5,
+	})
+	err := client.UpgradeSeriesPrepare("0", "xenial")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "UpgradeSeriesComplete")
+			c.Check(a, jc.DeepEquals, params.UpdateSeriesArgs{
+				Args: []params.UpdateSeriesArg{{Entity: params.Entity{Tag: "machine-1"}}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+		version: 5,
+	})
+	err := client.UpgradeSeriesComplete("1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesAbort(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "UpgradeSeriesAbort")
+			c.Check(a, jc.DeepEquals, params.UpdateSeriesArgs{
+				Args: []params.UpdateSeriesArg{{Entity: params.Entity{Tag: "machine-1"}}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+		version: 9,
+	})
+	err := client.UpgradeSeriesAbort("1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesAbortNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		version: 8,
+	})
+	err := client.UpgradeSeriesAbort("1")
+	c.Assert(err, gc.ErrorMatches, "aborting machine series upgrades not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		version: 4,
+	})
+	err := client.UpgradeSeriesPrepare("0", "xenial")
+	c.Assert(err, gc.ErrorMatches, "upgrading machine series not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *MachinemanagerSuite) TestMaintainMachine(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "MaintainMachines")
+			c.Check(a, jc.DeepEquals, params.MaintainMachineArgs{
+				Args: []params.MaintainMachineArg{{
+					Entity:      params.Entity{Tag: "machine-0"},
+					Maintenance: true,
+					Evacuate:    true,
+				}},
+			})
+			out := response.(*params.MaintainMachineResults)
+			*out = params.MaintainMachineResults{Results: []params.MaintainMachineResult{{
+				EvacuatedUnits: map[string]string{"unit-foo-0": "unit-foo-1"},
+			}}}
+			return nil
+		},
+		version: 6,
+	})
+	result, err := client.MaintainMachine("0", true, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MaintainMachineResult{
+		EvacuatedUnits: map[string]string{"unit-foo-0": "unit-foo-1"},
+	})
+}
+
+func (s *MachinemanagerSuite) TestMaintainMachineError(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			out := response.(*params.MaintainMachineResults)
+			*out = params.MaintainMachineResults{Results: []params.MaintainMachineResult{{
+				Error: &params.Error{Message: "boom"},
+			}}}
+			return nil
+		},
+		version: 6,
+	})
+	_, err := client.MaintainMachine("0", false, false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestMaintainMachineNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		version: 5,
+	})
+	_, err := client.MaintainMachine("0", true, false)
+	c.Assert(err, gc.ErrorMatches, "machine maintenance not supported")
+}
+
+func (s *MachinemanagerSuite) TestAdoptableInstance(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "AdoptableInstances")
+			c.Check(a, jc.DeepEquals, params.AdoptableInstancesArgs{
+				InstanceIds: []string{"i-1"},
+			})
+			out := response.(*params.AdoptableInstanceResults)
+			*out = params.AdoptableInstanceResults{Results: []params.AdoptableInstanceResult{{
+				Addresses: params.FromNetworkAddresses(network.NewAddress("54.0.0.1")),
+			}}}
+			return nil
+		},
+		version: 8,
+	})
+	addrs, err := client.AdoptableInstance("i-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{network.NewAddress("54.0.0.1")})
+}
+
+func (s *MachinemanagerSuite) TestAdoptableInstanceError(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			out := response.(*params.AdoptableInstanceResults)
+			*out = params.AdoptableInstanceResults{Results: []params.AdoptableInstanceResult{{
+				Error: &params.Error{Message: `instance "i-1" not found`, Code: params.CodeNotFound},
+			}}}
+			return nil
+		},
+		version: 8,
+	})
+	_, err := client.AdoptableInstance("i-1")
+	c.Assert(err, gc.ErrorMatches, `instance "i-1" not found`)
+}
+
+func (s *MachinemanagerSuite) TestAdoptInstances(c *gc.C) {
+	machineParams := []params.AddMachineParams{{
+		InstanceId: "i-1",
+		Nonce:      "i-1:nonce",
+		Series:     "xenial",
+	}}
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "AdoptInstances")
+			c.Check(a, jc.DeepEquals, params.AddMachines{MachineParams: machineParams})
+			out := response.(*params.AddMachinesResults)
+			*out = params.AddMachinesResults{Machines: []params.AddMachinesResult{{Machine: "3"}}}
+			return nil
+		},
+		version: 8,
+	})
+	results, err := client.AdoptInstances(machineParams)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.AddMachinesResult{{Machine: "3"}})
+}
+
+func (s *MachinemanagerSuite) TestAdoptInstancesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		version: 7,
+	})
+	_, err := client.AdoptableInstance("i-1")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.AdoptInstances([]params.AddMachineParams{{InstanceId: "i-1"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
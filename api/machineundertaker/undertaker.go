@@ -89,6 +89,29 @@ func (api *API) GetProviderInterfaceInfo(machine names.MachineTag) ([]network.Pr
 	return infos, nil
 }
 
+// KeepInstance returns whether the machine's cloud instance is left
+// running when the machine is removed. Controllers that do not support
+// the call never keep instances.
+func (api *API) KeepInstance(machine names.MachineTag) (bool, error) {
+	if api.facade.BestAPIVersion() < 2 {
+		return false, nil
+	}
+	var results params.BoolResults
+	args := wrapEntities(machine)
+	err := api.facade.FacadeCall("KeepInstance", &args, &results)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return false, errors.Errorf("expected one result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
 // CompleteRemoval finishes the removal of the machine in the database
 // after any provider resources are cleaned up.
 func (api *API) CompleteRemoval(machine names.MachineTag) error {
@@ -219,6 +219,35 @@ func (*undertakerSuite) TestGetInfo_BadMachine(c *gc.C) {
 	c.Assert(results, gc.IsNil)
 }
 
+func (*undertakerSuite) TestKeepInstance(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "MachineUndertaker")
+		c.Check(request, gc.Equals, "KeepInstance")
+		c.Check(arg, gc.DeepEquals, wrapEntities("machine-0-lxd-1"))
+		c.Assert(result, gc.FitsTypeOf, &params.BoolResults{})
+		*result.(*params.BoolResults) = params.BoolResults{
+			Results: []params.BoolResult{{Result: true}},
+		}
+		return nil
+	}
+	api, err := machineundertaker.NewAPI(versionedAPICaller{testing.APICallerFunc(caller), 2}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	keep, err := api.KeepInstance(names.NewMachineTag("0/lxd/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keep, jc.IsTrue)
+}
+
+func (*undertakerSuite) TestKeepInstanceOldController(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call")
+		return nil
+	}
+	api := makeAPI(c, caller)
+	keep, err := api.KeepInstance(names.NewMachineTag("0/lxd/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keep, jc.IsFalse)
+}
+
 func (*undertakerSuite) TestCompleteRemoval(c *gc.C) {
 	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
 		c.Check(facade, gc.Equals, "MachineUndertaker")
@@ -335,6 +364,15 @@ func wrapEntitiesResults(tags ...string) *params.EntitiesResults {
 	}
 }
 
+type versionedAPICaller struct {
+	testing.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
 type fakeAPICaller struct {
 	base.APICaller
 	hasModelTag bool
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/watcher"
 )
@@ -182,11 +183,31 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 		return empty, errors.Trace(err)
 	}
 
+	roleGrants, err := convertRoleGrants(serialized.RoleGrants)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	userGroups, err := convertUserGroups(serialized.UserGroups)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	appGrants, err := convertApplicationGrants(serialized.ApplicationGrants)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
 	return migration.SerializedModel{
-		Bytes:     serialized.Bytes,
-		Charms:    serialized.Charms,
-		Tools:     tools,
-		Resources: resources,
+		Bytes:      serialized.Bytes,
+		Charms:     serialized.Charms,
+		Tools:      tools,
+		Resources:  resources,
+		RoleGrants: roleGrants,
+		UserGroups: userGroups,
+
+		ApplicationGrants: appGrants,
+		Extras:            serialized.Extras,
 	}, nil
 }
 
@@ -293,6 +314,62 @@ func groupTagIds(tagStrs []string) ([]string, []string, error) {
 	return machines, units, nil
 }
 
+func convertRoleGrants(in []params.SerializedRoleGrant) ([]migration.RoleGrant, error) {
+	var out []migration.RoleGrant
+	for _, grant := range in {
+		user, err := names.ParseUserTag(grant.UserTag)
+		if err != nil {
+			return nil, errors.Annotate(err, "role grant")
+		}
+		role := permission.Role{Name: grant.Role.Name}
+		for _, capability := range grant.Role.Capabilities {
+			role.Capabilities = append(role.Capabilities, permission.Capability(capability))
+		}
+		out = append(out, migration.RoleGrant{User: user, Role: role})
+	}
+	return out, nil
+}
+
+func convertUserGroups(in []params.SerializedUserGroup) ([]migration.UserGroup, error) {
+	var out []migration.UserGroup
+	for _, group := range in {
+		members := make([]names.UserTag, len(group.Members))
+		for i, member := range group.Members {
+			user, err := names.ParseUserTag(member)
+			if err != nil {
+				return nil, errors.Annotatef(err, "group %q", group.Name)
+			}
+			members[i] = user
+		}
+		out = append(out, migration.UserGroup{
+			Name:      group.Name,
+			CreatedBy: group.CreatedBy,
+			Members:   members,
+		})
+	}
+	return out, nil
+}
+
+func convertApplicationGrants(in []params.SerializedApplicationGrant) ([]migration.ApplicationGrant, error) {
+	var out []migration.ApplicationGrant
+	for _, grant := range in {
+		app, err := names.ParseApplicationTag(grant.ApplicationTag)
+		if err != nil {
+			return nil, errors.Annotate(err, "application grant")
+		}
+		user, err := names.ParseUserTag(grant.UserTag)
+		if err != nil {
+			return nil, errors.Annotate(err, "application grant")
+		}
+		out = append(out, migration.ApplicationGrant{
+			Application: app.Id(),
+			User:        user,
+			Access:      permission.Access(grant.Access),
+		})
+	}
+	return out, nil
+}
+
 func convertResources(in []params.SerializedModelResource) ([]migration.SerializedModelResource, error) {
 	if len(in) == 0 {
 		return nil, nil
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/watcher"
 )
@@ -271,6 +272,21 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 					},
 				},
 			}},
+			RoleGrants: []params.SerializedRoleGrant{{
+				UserTag: "user-bob",
+				Role:    params.Role{Name: "operator", Capabilities: []string{"actions"}},
+			}},
+			UserGroups: []params.SerializedUserGroup{{
+				Name:      "ops",
+				CreatedBy: "admin",
+				Members:   []string{"user-bob"},
+			}},
+			ApplicationGrants: []params.SerializedApplicationGrant{{
+				ApplicationTag: "application-mysql",
+				UserTag:        "user-bob",
+				Access:         "read",
+			}},
+			Extras: []byte("extras"),
 		}
 		return nil
 	})
@@ -341,6 +357,24 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 				},
 			},
 		}},
+		RoleGrants: []migration.RoleGrant{{
+			User: names.NewUserTag("bob"),
+			Role: permission.Role{
+				Name:         "operator",
+				Capabilities: []permission.Capability{permission.ActionsCapability},
+			},
+		}},
+		UserGroups: []migration.UserGroup{{
+			Name:      "ops",
+			CreatedBy: "admin",
+			Members:   []names.UserTag{names.NewUserTag("bob")},
+		}},
+		ApplicationGrants: []migration.ApplicationGrant{{
+			Application: "mysql",
+			User:        names.NewUserTag("bob"),
+			Access:      permission.ReadAccess,
+		}},
+		Extras: []byte("extras"),
 	})
 }
 
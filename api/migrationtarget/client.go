@@ -43,15 +43,52 @@ type Client struct {
 	httpClientFactory func() (*httprequest.Client, error)
 }
 
+// Prechecks checks that the target controller is able to accept the
+// model, returning the first problem found.
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := modelInfoToParams(model)
+	return c.caller.FacadeCall("Prechecks", args, nil)
+}
+
+// PrecheckBlockers checks that the target controller is able to
+// accept the model, returning every problem found. Target controllers
+// which don't support reporting all problems only report the first.
+func (c *Client) PrecheckBlockers(model coremigration.ModelInfo) ([]string, error) {
+	args := modelInfoToParams(model)
+	if c.caller.BestAPIVersion() < 2 {
+		if err := c.caller.FacadeCall("Prechecks", args, nil); err != nil {
+			if params.IsCodeNotImplemented(err) {
+				return nil, errors.Trace(err)
+			}
+			return []string{err.Error()}, nil
+		}
+		return nil, nil
+	}
+	var result params.StringsResult
+	if err := c.caller.FacadeCall("PrecheckBlockers", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+func modelInfoToParams(model coremigration.ModelInfo) params.MigrationModelInfo {
+	var userTags []string
+	for _, user := range model.Users {
+		userTags = append(userTags, user.String())
+	}
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
+		CloudName:              model.Cloud,
+		CloudRegion:            model.CloudRegion,
+		UserTags:               userTags,
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
 // Import takes a serialized model and imports it into the target
@@ -61,6 +98,93 @@ func (c *Client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// ImportRoleGrants grants roles to users on a model previously
+// imported into the target controller.
+func (c *Client) ImportRoleGrants(modelUUID string, grants []coremigration.RoleGrant) error {
+	if c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("transferring role grants to this target controller")
+	}
+	args := params.ImportRoleGrantsArgs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Grants:   make([]params.SerializedRoleGrant, len(grants)),
+	}
+	for i, grant := range grants {
+		role := params.Role{Name: grant.Role.Name}
+		for _, capability := range grant.Role.Capabilities {
+			role.Capabilities = append(role.Capabilities, string(capability))
+		}
+		args.Grants[i] = params.SerializedRoleGrant{
+			UserTag: grant.User.String(),
+			Role:    role,
+		}
+	}
+	return c.caller.FacadeCall("ImportRoleGrants", args, nil)
+}
+
+// ImportUserGroups ensures that the groups of users with access to a
+// model previously imported into the target controller exist there,
+// along with their members.
+func (c *Client) ImportUserGroups(modelUUID string, groups []coremigration.UserGroup) error {
+	if c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("transferring user groups to this target controller")
+	}
+	args := params.ImportUserGroupsArgs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Groups:   make([]params.SerializedUserGroup, len(groups)),
+	}
+	for i, group := range groups {
+		members := make([]string, len(group.Members))
+		for j, member := range group.Members {
+			members[j] = member.String()
+		}
+		args.Groups[i] = params.SerializedUserGroup{
+			Name:      group.Name,
+			CreatedBy: group.CreatedBy,
+			Members:   members,
+		}
+	}
+	return c.caller.FacadeCall("ImportUserGroups", args, nil)
+}
+
+// ImportApplicationGrants grants users access to the applications of
+// a model previously imported into the target controller.
+func (c *Client) ImportApplicationGrants(modelUUID string, grants []coremigration.ApplicationGrant) error {
+	if c.caller.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("transferring application grants to this target controller")
+	}
+	args := params.ImportApplicationGrantsArgs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Grants:   make([]params.SerializedApplicationGrant, len(grants)),
+	}
+	for i, grant := range grants {
+		args.Grants[i] = params.SerializedApplicationGrant{
+			ApplicationTag: names.NewApplicationTag(grant.Application).String(),
+			UserTag:        grant.User.String(),
+			Access:         string(grant.Access),
+		}
+	}
+	return c.caller.FacadeCall("ImportApplicationGrants", args, nil)
+}
+
+// SupportsExtras reports whether the target controller can import
+// the parts of a model that the model description has no place for.
+func (c *Client) SupportsExtras() bool {
+	return c.caller.BestAPIVersion() >= 5
+}
+
+// ImportExtras applies the parts of a model previously imported into
+// the target controller that the model description has no place for.
+func (c *Client) ImportExtras(modelUUID string, extras []byte) error {
+	if c.caller.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("transferring model extras to this target controller")
+	}
+	args := params.ImportModelExtrasArgs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Extras:   extras,
+	}
+	return c.caller.FacadeCall("ImportExtras", args, nil)
+}
+
 // Abort removes all data relating to a previously imported model.
 func (c *Client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
@@ -77,6 +78,60 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestPrecheckBlockers(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.StringsResult)) = params.StringsResult{
+				Result: []string{"machine 0 is dying", "user bob not found in target controller"},
+			}
+			return nil
+		},
+		version: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	blockers, err := client.PrecheckBlockers(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+		Cloud:        "aws",
+		CloudRegion:  "us-east-1",
+		Users:        []names.UserTag{ownerTag, names.NewUserTag("bob")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{
+		"machine 0 is dying", "user bob not found in target controller",
+	})
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		OwnerTag:     ownerTag.String(),
+		AgentVersion: vers,
+		CloudName:    "aws",
+		CloudRegion:  "us-east-1",
+		UserTags:     []string{"user-owner", "user-bob"},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.PrecheckBlockers", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestPrecheckBlockersFallsBackToPrechecks(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	blockers, err := client.PrecheckBlockers(coremigration.ModelInfo{UUID: "uuid"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []string{"boom"})
+	c.Assert(stub.Calls(), gc.HasLen, 1)
+	c.Assert(stub.Calls()[0].FuncName, gc.Equals, "MigrationTarget.Prechecks")
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -89,6 +144,167 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestImportRoleGrants(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		version: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	err := client.ImportRoleGrants("uuid", []coremigration.RoleGrant{{
+		User: names.NewUserTag("bob"),
+		Role: permission.Role{
+			Name:         "operator",
+			Capabilities: []permission.Capability{permission.ActionsCapability},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportRoleGrants", []interface{}{"", params.ImportRoleGrantsArgs{
+			ModelTag: names.NewModelTag("uuid").String(),
+			Grants: []params.SerializedRoleGrant{{
+				UserTag: "user-bob",
+				Role:    params.Role{Name: "operator", Capabilities: []string{"actions"}},
+			}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestImportRoleGrantsNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.ImportRoleGrants("uuid", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportUserGroups(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		version: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	err := client.ImportUserGroups("uuid", []coremigration.UserGroup{{
+		Name:      "ops",
+		CreatedBy: "admin",
+		Members:   []names.UserTag{names.NewUserTag("bob")},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportUserGroups", []interface{}{"", params.ImportUserGroupsArgs{
+			ModelTag: names.NewModelTag("uuid").String(),
+			Groups: []params.SerializedUserGroup{{
+				Name:      "ops",
+				CreatedBy: "admin",
+				Members:   []string{"user-bob"},
+			}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestImportUserGroupsNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		version: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	err := client.ImportUserGroups("uuid", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportApplicationGrants(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		version: 4,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	err := client.ImportApplicationGrants("uuid", []coremigration.ApplicationGrant{{
+		Application: "mysql",
+		User:        names.NewUserTag("bob"),
+		Access:      permission.WriteAccess,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportApplicationGrants", []interface{}{"", params.ImportApplicationGrantsArgs{
+			ModelTag: names.NewModelTag("uuid").String(),
+			Grants: []params.SerializedApplicationGrant{{
+				ApplicationTag: "application-mysql",
+				UserTag:        "user-bob",
+				Access:         "write",
+			}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestImportApplicationGrantsNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		version: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	err := client.ImportApplicationGrants("uuid", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportExtras(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		version: 5,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	err := client.ImportExtras("uuid", []byte("extras"))
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportExtras", []interface{}{"", params.ImportModelExtrasArgs{
+			ModelTag: names.NewModelTag("uuid").String(),
+			Extras:   []byte("extras"),
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestImportExtrasNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		version: 4,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	err := client.ImportExtras("uuid", []byte("extras"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -342,3 +558,12 @@ func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
 	d.body = string(body)
 	return d.response, nil
 }
+
+type versionedAPICaller struct {
+	apitesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
@@ -4,6 +4,7 @@
 package modelmanager_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -190,3 +191,56 @@ func (s *accessSuite) TestInvalidResultCount(c *gc.C) {
 	err := client.GrantModel("bob", "write", someModelUUID, someModelUUID)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 0")
 }
+
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *accessSuite) TestGrantApplication(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "ModifyApplicationAccess")
+			c.Check(a, jc.DeepEquals, params.ModifyApplicationAccessRequest{
+				Changes: []params.ModifyApplicationAccess{{
+					UserTag:        "user-bob",
+					Action:         params.GrantModelAccess,
+					Access:         params.ModelWriteAccess,
+					ModelTag:       someModelTag,
+					ApplicationTag: "application-mysql",
+				}, {
+					UserTag:        "user-bob",
+					Action:         params.GrantModelAccess,
+					Access:         params.ModelWriteAccess,
+					ModelTag:       someModelTag,
+					ApplicationTag: "application-wordpress",
+				}},
+			})
+			resp := assertResponse(c, result)
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{}, {}}}
+			return nil
+		},
+		version: 3,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantApplication("bob", "write", someModelUUID, "mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *accessSuite) TestRevokeApplicationNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 2,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.RevokeApplication("bob", "write", someModelUUID, "mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -257,6 +257,32 @@ func (c *Client) DestroyModel(tag names.ModelTag) error {
 	return nil
 }
 
+// DestroyModelKeepingInstances puts the specified model into a "dying"
+// state, as DestroyModel does, but leaves the cloud instances of the
+// model's machines running.
+func (c *Client) DestroyModelKeepingInstances(tag names.ModelTag) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("keeping instances when destroying a model on this controller")
+	}
+	var results params.ErrorResults
+	args := params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag:      tag.String(),
+			KeepInstances: true,
+		}},
+	}
+	if err := c.facade.FacadeCall("DestroyModelsWithParams", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // GrantModel grants a user access to the specified models.
 func (c *Client) GrantModel(user, access string, modelUUIDs ...string) error {
 	return c.modifyModelUser(params.GrantModelAccess, user, access, modelUUIDs)
@@ -310,6 +336,61 @@ func (c *Client) modifyModelUser(action params.ModelAction, user, access string,
 	return result.Combine()
 }
 
+// GrantApplication grants a user access to the specified applications
+// in a model.
+func (c *Client) GrantApplication(user, access, modelUUID string, applications ...string) error {
+	return c.modifyApplicationUser(params.GrantModelAccess, user, access, modelUUID, applications)
+}
+
+// RevokeApplication revokes a user's access to the specified applications
+// in a model.
+func (c *Client) RevokeApplication(user, access, modelUUID string, applications ...string) error {
+	return c.modifyApplicationUser(params.RevokeModelAccess, user, access, modelUUID, applications)
+}
+
+func (c *Client) modifyApplicationUser(action params.ModelAction, user, access, modelUUID string, applications []string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("application access on this controller")
+	}
+	var args params.ModifyApplicationAccessRequest
+
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	userTag := names.NewUserTag(user)
+
+	appAccess := permission.Access(access)
+	if err := permission.ValidateApplicationAccess(appAccess); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.Errorf("invalid model: %q", modelUUID)
+	}
+	modelTag := names.NewModelTag(modelUUID)
+	for _, app := range applications {
+		if !names.IsValidApplication(app) {
+			return errors.Errorf("invalid application: %q", app)
+		}
+		args.Changes = append(args.Changes, params.ModifyApplicationAccess{
+			UserTag:        userTag.String(),
+			Action:         action,
+			Access:         params.UserAccessPermission(appAccess),
+			ModelTag:       modelTag.String(),
+			ApplicationTag: names.NewApplicationTag(app).String(),
+		})
+	}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyApplicationAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
+
 // ModelDefaults returns the default values for various sources used when
 // creating a new model.
 func (c *Client) ModelDefaults() (config.ModelDefaultAttributes, error) {
@@ -118,6 +118,32 @@ func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestDestroyModelKeepingInstances(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	defer modelManager.Close()
+	var called bool
+	modelmanager.PatchFacadeCall(&s.CleanupSuite, modelManager,
+		func(req string, args interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "DestroyModelsWithParams")
+			c.Assert(args, jc.DeepEquals, params.DestroyModelsParams{
+				Models: []params.DestroyModelParams{{
+					ModelTag:      testing.ModelTag.String(),
+					KeepInstances: true,
+				}},
+			})
+			results := resp.(*params.ErrorResults)
+			*results = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		})
+
+	err := modelManager.DestroyModelKeepingInstances(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
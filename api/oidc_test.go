@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+var _ = gc.Suite(&oidcSuite{})
+
+// oidcSuite tests logging in to the API with
+// OpenID Connect ID tokens.
+type oidcSuite struct {
+	apitesting.OIDCSuite
+}
+
+func (s *oidcSuite) SetUpTest(c *gc.C) {
+	s.OIDCSuite.SetUpTest(c)
+	s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		User: "bob@example.com",
+	})
+	_, err := s.State.AddControllerUser(state.UserAccessSpec{
+		User:      names.NewUserTag("bob@example.com"),
+		CreatedBy: s.AdminUserTag(c),
+		Access:    permission.LoginAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oidcSuite) TestOpenWithIDToken(c *gc.C) {
+	info := s.APIInfo(c)
+	info.IDToken = s.IDToken("bob@example.com")
+	conn, err := api.Open(info, api.DialOpts{
+		OIDCLogin: func(string, string) (string, error) {
+			return "", errors.New("unexpected call to OIDCLogin")
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	c.Assert(conn.AuthTag(), gc.Equals, names.NewUserTag("bob@example.com"))
+}
+
+func (s *oidcSuite) TestOpenObtainsIDToken(c *gc.C) {
+	var issuer, clientID string
+	conn, err := api.Open(s.APIInfo(c), api.DialOpts{
+		OIDCLogin: func(i, cid string) (string, error) {
+			issuer, clientID = i, cid
+			return s.IDToken("bob@example.com"), nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	c.Assert(conn.AuthTag(), gc.Equals, names.NewUserTag("bob@example.com"))
+	c.Assert(issuer, gc.Equals, s.Issuer.URL())
+	c.Assert(clientID, gc.Equals, apitesting.OIDCClientID)
+}
+
+func (s *oidcSuite) TestOpenReplacesExpiredIDToken(c *gc.C) {
+	info := s.APIInfo(c)
+	info.IDToken = s.Issuer.Token(map[string]interface{}{
+		"email": "bob@example.com",
+		"exp":   0,
+	})
+	called := 0
+	conn, err := api.Open(info, api.DialOpts{
+		OIDCLogin: func(string, string) (string, error) {
+			called++
+			return s.IDToken("bob@example.com"), nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	c.Assert(called, gc.Equals, 1)
+}
+
+func (s *oidcSuite) TestOpenWithoutOIDCLogin(c *gc.C) {
+	_, err := api.Open(s.APIInfo(c), api.DialOpts{})
+	c.Assert(err, gc.ErrorMatches, `OpenID Connect login required: no ID token provided`)
+}
+
+func (s *oidcSuite) TestOpenOIDCLoginError(c *gc.C) {
+	_, err := api.Open(s.APIInfo(c), api.DialOpts{
+		OIDCLogin: func(string, string) (string, error) {
+			return "", errors.New("user cancelled")
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot obtain OpenID Connect ID token: user cancelled`)
+}
+
+func (s *oidcSuite) TestOpenWithInvalidObtainedToken(c *gc.C) {
+	_, err := api.Open(s.APIInfo(c), api.DialOpts{
+		OIDCLogin: func(string, string) (string, error) {
+			return s.Issuer.Token(map[string]interface{}{
+				"email": "bob@example.com",
+				"aud":   "someone-else",
+			}), nil
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `login with ID token failed: ID token not issued to client "juju"`)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/tools"
 	"github.com/juju/juju/watcher"
@@ -86,6 +87,24 @@ func (st *State) WatchMachineErrorRetry() (watcher.NotifyWatcher, error) {
 	return w, nil
 }
 
+// WatchEndpointBindings returns a NotifyWatcher that notifies when the
+// endpoint bindings of any application in the model change.
+func (st *State) WatchEndpointBindings() (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("watching endpoint bindings by this controller")
+	}
+	var result params.NotifyWatchResult
+	err := st.facade.FacadeCall("WatchEndpointBindings", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // StateAddresses returns the list of addresses used to connect to the state.
 func (st *State) StateAddresses() ([]string, error) {
 	var result params.StringsResult
@@ -132,6 +151,24 @@ func (st *State) MachinesWithTransientErrors() ([]*Machine, []params.StatusResul
 	return machines, results.Results, nil
 }
 
+// KeptInstances returns the ids of the cloud instances that were left
+// running when their machines were removed from the model.
+func (st *State) KeptInstances() ([]instance.Id, error) {
+	var result params.StringsResult
+	err := st.facade.FacadeCall("KeptInstances", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	ids := make([]instance.Id, len(result.Result))
+	for i, id := range result.Result {
+		ids[i] = instance.Id(id)
+	}
+	return ids, nil
+}
+
 // FindTools returns al ist of tools matching the specified version number and
 // series, and, arch. If arch is blank, a default will be used.
 func (st *State) FindTools(v version.Number, series string, arch string) (tools.List, error) {
@@ -180,6 +217,17 @@ func (st *State) GetContainerInterfaceInfo(containerTag names.MachineTag) ([]net
 	return st.prepareOrGetContainerInterfaceInfo(containerTag, false)
 }
 
+// PrepareAddedContainerInterfaceInfo gives a provisioned container devices
+// for the spaces it has since been required to join, allocating their
+// addresses, and returns information to configure networking for the
+// added devices only.
+func (st *State) PrepareAddedContainerInterfaceInfo(containerTag names.MachineTag) ([]network.InterfaceInfo, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("adding container interfaces by this controller")
+	}
+	return st.containerInterfaceInfo("PrepareAddedContainerInterfaceInfo", containerTag)
+}
+
 // prepareOrGetContainerInterfaceInfo returns the necessary information to
 // configure network interfaces of a container with allocated static
 // IP addresses.
@@ -190,16 +238,23 @@ func (st *State) GetContainerInterfaceInfo(containerTag names.MachineTag) ([]net
 func (st *State) prepareOrGetContainerInterfaceInfo(
 	containerTag names.MachineTag, allocateNewAddress bool) (
 	[]network.InterfaceInfo, error) {
-	var result params.MachineNetworkConfigResults
-	args := params.Entities{
-		Entities: []params.Entity{{Tag: containerTag.String()}},
-	}
 	methodName := ""
 	if allocateNewAddress {
 		methodName = "PrepareContainerInterfaceInfo"
 	} else {
 		methodName = "GetContainerInterfaceInfo"
 	}
+	return st.containerInterfaceInfo(methodName, containerTag)
+}
+
+// containerInterfaceInfo calls the given facade method, which returns
+// the network config of a container, and returns the config as
+// network.InterfaceInfo.
+func (st *State) containerInterfaceInfo(methodName string, containerTag names.MachineTag) ([]network.InterfaceInfo, error) {
+	var result params.MachineNetworkConfigResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: containerTag.String()}},
+	}
 	if err := st.facade.FacadeCall(methodName, args, &result); err != nil {
 		return nil, err
 	}
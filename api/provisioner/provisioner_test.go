@@ -357,6 +357,42 @@ func (s *provisionerSuite) TestSeries(c *gc.C) {
 	c.Assert(series, gc.Equals, "quantal")
 }
 
+func (s *provisionerSuite) TestKeepInstance(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	apiMachine, err := s.provisioner.Machine(machine.Tag().(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+	keep, err := apiMachine.KeepInstance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keep, jc.IsFalse)
+
+	err = machine.SetKeepInstance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	keep, err = apiMachine.KeepInstance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keep, jc.IsTrue)
+}
+
+func (s *provisionerSuite) TestKeptInstances(c *gc.C) {
+	ids, err := s.provisioner.KeptInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, gc.HasLen, 0)
+
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("i-kept", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetKeepInstance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.MarkForRemoval()
+	c.Assert(err, jc.ErrorIsNil)
+	ids, err = s.provisioner.KeptInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"i-kept"})
+}
+
 func (s *provisionerSuite) TestDistributionGroup(c *gc.C) {
 	apiMachine, err := s.provisioner.Machine(s.machine.Tag().(names.MachineTag))
 	c.Assert(err, jc.ErrorIsNil)
@@ -545,6 +581,24 @@ func (s *provisionerSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchEndpointBindings(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.provisioner.WatchEndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = app.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *provisionerSuite) TestStateAddresses(c *gc.C) {
 	err := s.machine.SetProviderAddresses(network.NewAddress("0.1.2.3"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -793,6 +847,14 @@ func (s *prepareContainerSuite) TestPrepareContainerInterfaceInfoNoValues(c *gc.
 	c.Check(networkInfo, jc.DeepEquals, []network.InterfaceInfo{})
 }
 
+func (s *prepareContainerSuite) TestPrepareAddedContainerInterfaceInfoNotSupported(c *gc.C) {
+	apicaller := s.apiForPrepareContainer(nil, nil)
+	st := provisioner.NewState(apicaller)
+	_, err := st.PrepareAddedContainerInterfaceInfo(names.NewMachineTag("machine-0/lxd/0"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.CheckNoCalls(c)
+}
+
 func (s *prepareContainerSuite) TestPrepareContainerInterfaceInfoSingleNIC(c *gc.C) {
 	apicaller := s.apiForPrepareContainer([]params.NetworkConfig{{
 		DeviceIndex:         1,
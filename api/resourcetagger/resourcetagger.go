@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// NewWatcherFunc exists to let us test WatchResourceTags.
+type NewWatcherFunc func(base.APICaller, params.NotifyWatchResult) watcher.NotifyWatcher
+
+// API provides access to the resource tagger API facade.
+type API struct {
+	facade     base.FacadeCaller
+	modelTag   names.ModelTag
+	newWatcher NewWatcherFunc
+}
+
+// NewAPI creates a new client-side resource tagger facade.
+func NewAPI(caller base.APICaller, newWatcher NewWatcherFunc) (*API, error) {
+	modelTag, ok := caller.ModelTag()
+	if !ok {
+		return nil, errors.New("resource tagger client requires a model API connection")
+	}
+	api := API{
+		facade:     base.NewFacadeCaller(caller, "ResourceTagger"),
+		modelTag:   modelTag,
+		newWatcher: newWatcher,
+	}
+	return &api, nil
+}
+
+// ModelResourceTags returns the tags that should be set on the
+// model's provisioned instances and volumes, and on the resources
+// shared by the whole model.
+func (api *API) ModelResourceTags() (params.ModelResourceTags, error) {
+	var results params.ModelResourceTagsResults
+	args := wrapEntities(api.modelTag)
+	err := api.facade.FacadeCall("ModelResourceTags", &args, &results)
+	if err != nil {
+		return params.ModelResourceTags{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ModelResourceTags{}, errors.Errorf("expected one result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ModelResourceTags{}, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// WatchResourceTags registers to be notified when the tags of the
+// model's cloud resources may have changed.
+func (api *API) WatchResourceTags() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := wrapEntities(api.modelTag)
+	err := api.facade.FacadeCall("WatchResourceTags", &args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected one result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newWatcher(api.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+func wrapEntities(tag names.Tag) params.Entities {
+	return params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+}
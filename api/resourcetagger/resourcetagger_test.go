@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+)
+
+type resourceTaggerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&resourceTaggerSuite{})
+
+func (s *resourceTaggerSuite) TestRequiresModelConnection(c *gc.C) {
+	api, err := resourcetagger.NewAPI(&fakeAPICaller{hasModelTag: false}, nil)
+	c.Assert(err, gc.ErrorMatches, "resource tagger client requires a model API connection")
+	c.Assert(api, gc.IsNil)
+	api, err = resourcetagger.NewAPI(&fakeAPICaller{hasModelTag: true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.NotNil)
+}
+
+func (s *resourceTaggerSuite) TestModelResourceTags(c *gc.C) {
+	expect := params.ModelResourceTags{
+		ModelTags: map[string]string{"team": "ops"},
+		Instances: []params.ResourceTags{{
+			ProviderId: "i-1",
+			Tags:       map[string]string{"team": "web"},
+		}},
+		Volumes: []params.ResourceTags{{
+			ProviderId: "vol-1",
+			Tags:       map[string]string{"team": "db"},
+		}},
+	}
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ResourceTagger")
+		c.Check(request, gc.Equals, "ModelResourceTags")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(arg, gc.DeepEquals, wrapEntities(coretesting.ModelTag.String()))
+		c.Assert(result, gc.FitsTypeOf, &params.ModelResourceTagsResults{})
+		*result.(*params.ModelResourceTagsResults) = params.ModelResourceTagsResults{
+			Results: []params.ModelResourceTagsResult{{Result: expect}},
+		}
+		return nil
+	}
+	api := makeAPI(c, caller)
+	result, err := api.ModelResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expect)
+}
+
+func (s *resourceTaggerSuite) TestModelResourceTags_Error(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("restless year")
+	}
+	api := makeAPI(c, caller)
+	_, err := api.ModelResourceTags()
+	c.Assert(err, gc.ErrorMatches, "restless year")
+}
+
+func (s *resourceTaggerSuite) TestModelResourceTags_ErrorResult(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(result, gc.FitsTypeOf, &params.ModelResourceTagsResults{})
+		*result.(*params.ModelResourceTagsResults) = params.ModelResourceTagsResults{
+			Results: []params.ModelResourceTagsResult{{
+				Error: &params.Error{Message: "blammo"},
+			}},
+		}
+		return nil
+	}
+	api := makeAPI(c, caller)
+	_, err := api.ModelResourceTags()
+	c.Assert(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *resourceTaggerSuite) TestModelResourceTags_TooMany(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(result, gc.FitsTypeOf, &params.ModelResourceTagsResults{})
+		*result.(*params.ModelResourceTagsResults) = params.ModelResourceTagsResults{
+			Results: []params.ModelResourceTagsResult{{}, {}},
+		}
+		return nil
+	}
+	api := makeAPI(c, caller)
+	_, err := api.ModelResourceTags()
+	c.Assert(err, gc.ErrorMatches, "expected one result, got 2")
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTags_ErrorInWatcher(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchResourceTags")
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*result.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "blammo"},
+			}},
+		}
+		return nil
+	}
+	api := makeAPI(c, caller)
+	w, err := api.WatchResourceTags()
+	c.Check(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTags_Success(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ResourceTagger")
+		c.Check(request, gc.Equals, "WatchResourceTags")
+		c.Check(arg, gc.DeepEquals, wrapEntities(coretesting.ModelTag.String()))
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*result.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				NotifyWatcherId: "2",
+			}},
+		}
+		return nil
+	}
+	expectWatcher := &struct{ watcher.NotifyWatcher }{}
+	newWatcher := func(wcaller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(wcaller, gc.NotNil) // not comparable
+		c.Check(result, gc.DeepEquals, params.NotifyWatchResult{
+			NotifyWatcherId: "2",
+		})
+		return expectWatcher
+	}
+
+	api, err := resourcetagger.NewAPI(testing.APICallerFunc(caller), newWatcher)
+	c.Check(err, jc.ErrorIsNil)
+	w, err := api.WatchResourceTags()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, expectWatcher)
+}
+
+func makeAPI(c *gc.C, caller testing.APICallerFunc) *resourcetagger.API {
+	api, err := resourcetagger.NewAPI(caller, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func wrapEntities(tags ...string) *params.Entities {
+	entities := make([]params.Entity, len(tags))
+	for i := range tags {
+		entities[i].Tag = tags[i]
+	}
+	return &params.Entities{Entities: entities}
+}
+
+type fakeAPICaller struct {
+	base.APICaller
+	hasModelTag bool
+}
+
+func (c *fakeAPICaller) ModelTag() (names.ModelTag, bool) {
+	return names.ModelTag{}, c.hasModelTag
+}
+
+func (c *fakeAPICaller) BestFacadeVersion(string) int {
+	return 0
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package roles provides the client for the Roles facade, used to
+// define roles and to grant them to users of a model.
+package roles
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// Client provides access to the Roles facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Roles")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddRole defines a new role in the controller.
+func (c *Client) AddRole(role permission.Role) error {
+	arg := params.Role{Name: role.Name}
+	for _, capability := range role.Capabilities {
+		arg.Capabilities = append(arg.Capabilities, string(capability))
+	}
+	args := params.AddRoles{Roles: []params.Role{arg}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddRoles", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// ListRoles returns the roles defined in the controller.
+func (c *Client) ListRoles() ([]permission.Role, error) {
+	var result params.ListRolesResults
+	if err := c.facade.FacadeCall("ListRoles", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	roles := make([]permission.Role, len(result.Roles))
+	for i, role := range result.Roles {
+		roles[i].Name = role.Name
+		for _, capability := range role.Capabilities {
+			roles[i].Capabilities = append(roles[i].Capabilities, permission.Capability(capability))
+		}
+	}
+	return roles, nil
+}
+
+// GrantRole grants a role to a user on the model.
+func (c *Client) GrantRole(user names.UserTag, role string) error {
+	return c.changeRole("GrantRoles", user, role)
+}
+
+// RevokeRole revokes a role from a user on the model.
+func (c *Client) RevokeRole(user names.UserTag, role string) error {
+	return c.changeRole("RevokeRoles", user, role)
+}
+
+func (c *Client) changeRole(method string, user names.UserTag, role string) error {
+	args := params.RoleGrants{
+		Grants: []params.RoleGrant{{UserTag: user.String(), Role: role}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package roles_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/roles"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+type rolesSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&rolesSuite{})
+
+func (s *rolesSuite) TestAddRole(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Roles")
+			c.Check(request, gc.Equals, "AddRoles")
+			c.Check(a, jc.DeepEquals, params.AddRoles{
+				Roles: []params.Role{{Name: "operator", Capabilities: []string{"actions", "ssh"}}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+	)
+	client := roles.NewClient(apiCaller)
+	err := client.AddRole(permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{permission.ActionsCapability, permission.SSHCapability},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *rolesSuite) TestListRoles(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Roles")
+			c.Check(request, gc.Equals, "ListRoles")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListRolesResults{})
+			*(result.(*params.ListRolesResults)) = params.ListRolesResults{
+				Roles: []params.Role{{Name: "operator", Capabilities: []string{"actions"}}},
+			}
+			return nil
+		},
+	)
+	client := roles.NewClient(apiCaller)
+	result, err := client.ListRoles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []permission.Role{{
+		Name:         "operator",
+		Capabilities: []permission.Capability{permission.ActionsCapability},
+	}})
+}
+
+func (s *rolesSuite) TestGrantRole(c *gc.C) {
+	s.assertChangeRole(c, "GrantRoles", (*roles.Client).GrantRole)
+}
+
+func (s *rolesSuite) TestRevokeRole(c *gc.C) {
+	s.assertChangeRole(c, "RevokeRoles", (*roles.Client).RevokeRole)
+}
+
+func (s *rolesSuite) assertChangeRole(
+	c *gc.C, method string, change func(*roles.Client, names.UserTag, string) error,
+) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Roles")
+			c.Check(request, gc.Equals, method)
+			c.Check(a, jc.DeepEquals, params.RoleGrants{
+				Grants: []params.RoleGrant{{UserTag: "user-bob", Role: "operator"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	)
+	err := change(roles.NewClient(apiCaller), names.NewUserTag("bob"), "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
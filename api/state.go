@@ -51,6 +51,9 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 		request.UserData = string(debug.Stack())
 	}
 
+	if tag == nil {
+		request.IDToken = st.idToken
+	}
 	if password == "" {
 		// Add any macaroons from the cookie jar that might work for
 		// authenticating the login request.
@@ -112,6 +115,28 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 			return errors.Errorf("login with discharged macaroons failed: %s", result.DischargeRequiredReason)
 		}
 	}
+	if result.OIDCLoginRequired != nil {
+		// The controller requires an OpenID Connect ID token.
+		// We obtain one and retry the login request with it.
+		required := result.OIDCLoginRequired
+		if st.oidcLogin == nil {
+			return errors.Errorf("OpenID Connect login required: %s", required.Reason)
+		}
+		idToken, err := st.oidcLogin(required.Issuer, required.ClientID)
+		if err != nil {
+			return errors.Annotate(err, "cannot obtain OpenID Connect ID token")
+		}
+		st.idToken = idToken
+		request.IDToken = idToken
+		result = params.LoginResult{} // zero result
+		err = st.APICall("Admin", 3, "", "Login", request, &result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if result.OIDCLoginRequired != nil {
+			return errors.Errorf("login with ID token failed: %s", result.OIDCLoginRequired.Reason)
+		}
+	}
 
 	var controllerAccess string
 	var modelAccess string
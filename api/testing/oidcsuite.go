@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/controller"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/oidc/oidctest"
+)
+
+// OIDCClientID is the client ID that OIDCSuite configures
+// the controller to accept ID tokens for.
+const OIDCClientID = "juju"
+
+// OIDCSuite wraps a JujuConnSuite with OpenID Connect authentication
+// enabled, using a local stand-in for the provider.
+type OIDCSuite struct {
+	jujutesting.JujuConnSuite
+
+	// Issuer holds the OpenID Connect provider
+	// that the controller trusts.
+	Issuer *oidctest.Issuer
+}
+
+func (s *OIDCSuite) SetUpTest(c *gc.C) {
+	s.Issuer = oidctest.NewIssuer(OIDCClientID)
+	s.JujuConnSuite.ControllerConfigAttrs = map[string]interface{}{
+		controller.OIDCIssuerURL: s.Issuer.URL(),
+		controller.OIDCClientID:  OIDCClientID,
+		// The issuer is served over HTTP, so we
+		// provide its keys rather than fetch them.
+		controller.OIDCKeySet: s.Issuer.JWKS(),
+	}
+	s.JujuConnSuite.SetUpTest(c)
+}
+
+func (s *OIDCSuite) TearDownTest(c *gc.C) {
+	s.Issuer.Close()
+	s.JujuConnSuite.TearDownTest(c)
+}
+
+// IDToken returns an ID token issued by s.Issuer asserting
+// the given email address and group memberships.
+func (s *OIDCSuite) IDToken(email string, groups ...string) string {
+	claims := map[string]interface{}{"email": email}
+	if len(groups) > 0 {
+		claims["groups"] = groups
+	}
+	return s.Issuer.Token(claims)
+}
+
+// APIInfo returns API connection info suitable for
+// connecting to the API as an external user.
+func (s *OIDCSuite) APIInfo(c *gc.C) *api.Info {
+	info := s.JujuConnSuite.APIInfo(c)
+	info.Tag = nil
+	info.Password = ""
+	return info
+}
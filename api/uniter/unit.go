@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
 )
@@ -448,6 +449,29 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// OpenPortsOnEndpoint sets the policy of the port range with protocol
+// to be opened for the named endpoint only.
+func (u *Unit) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	if u.st.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("opening ports on endpoints")
+	}
+	var result params.ErrorResults
+	args := params.EntitiesPortRanges{
+		Entities: []params.EntityPortRange{{
+			Tag:      u.tag.String(),
+			Protocol: protocol,
+			FromPort: fromPort,
+			ToPort:   toPort,
+			Endpoint: endpoint,
+		}},
+	}
+	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // ClosePorts sets the policy of the port range with protocol to be
 // closed.
 func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
@@ -467,6 +491,38 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// OpenEgress allows outgoing traffic from the unit's machine to the
+// given egress rule.
+func (u *Unit) OpenEgress(rule network.EgressRule) error {
+	return u.changeEgress("OpenEgress", rule)
+}
+
+// CloseEgress removes an egress rule previously added with OpenEgress.
+func (u *Unit) CloseEgress(rule network.EgressRule) error {
+	return u.changeEgress("CloseEgress", rule)
+}
+
+func (u *Unit) changeEgress(method string, rule network.EgressRule) error {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("egress rules by this controller")
+	}
+	var result params.ErrorResults
+	args := params.EntitiesEgressRules{
+		Entities: []params.EntityEgressRule{{
+			Tag: u.tag.String(),
+			Rule: params.EgressRule{
+				PortRange:        params.FromNetworkPortRange(rule.PortRange),
+				DestinationCIDRs: rule.DestinationCIDRs,
+			},
+		}},
+	}
+	err := u.st.facade.FacadeCall(method, args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 var ErrNoCharmURLSet = errors.New("unit has no charm url set")
 
 // CharmURL returns the charm URL this unit is currently using.
@@ -402,6 +402,42 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenPortsOnEndpoint(c *gc.C) {
+	err := s.apiUnit.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineID, err := s.wordpressUnit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineID)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.DeepEquals, []state.PortRange{{
+		UnitName: s.wordpressUnit.Name(),
+		FromPort: 80,
+		ToPort:   80,
+		Protocol: "tcp",
+		Endpoint: "url",
+	}})
+}
+
+func (s *unitSuite) TestOpenCloseEgress(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")
+	err := s.apiUnit.OpenEgress(rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	egress, err := s.wordpressUnit.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, jc.DeepEquals, []network.EgressRule{rule})
+
+	err = s.apiUnit.CloseEgress(rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	egress, err = s.wordpressUnit.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, gc.HasLen, 0)
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
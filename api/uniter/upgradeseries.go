@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
+	"github.com/juju/juju/watcher"
+)
+
+// UpgradeSeriesStatus returns how far through the series upgrade of its
+// machine the unit has got. It returns upgradeseries.NotStarted if the
+// machine is not being upgraded, or if the controller does not support
+// series upgrades.
+func (u *Unit) UpgradeSeriesStatus() (upgradeseries.Status, error) {
+	if u.st.facade.BestAPIVersion() < 7 {
+		return upgradeseries.NotStarted, nil
+	}
+	var results params.UpgradeSeriesStatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UpgradeSeriesUnitStatus", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return upgradeseries.Status(result.Status), nil
+}
+
+// SetUpgradeSeriesStatus records how far through the series upgrade of
+// its machine the unit has got.
+func (u *Unit) SetUpgradeSeriesStatus(status upgradeseries.Status) error {
+	if u.st.facade.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("series upgrades by this controller")
+	}
+	var result params.ErrorResults
+	args := params.UpgradeSeriesStatusParams{
+		Params: []params.UpgradeSeriesStatusParam{{
+			Entity: params.Entity{Tag: u.tag.String()},
+			Status: string(status),
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetUpgradeSeriesUnitStatus", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// WatchUpgradeSeriesNotifications returns a watcher for observing
+// changes to the series upgrade of the unit's machine. The unit must
+// be assigned to a machine before this method is called.
+func (u *Unit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("series upgrades by this controller")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchUpgradeSeriesNotifications", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/core/upgradeseries"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+type upgradeSeriesSuite struct {
+	uniterSuite
+
+	// The wordpress charm only supports a single series, so the
+	// upgrade is exercised with a unit of a multi-series charm.
+	machine *state.Machine
+	unit    *state.Unit
+	apiUnit *uniter.Unit
+}
+
+var _ = gc.Suite(&upgradeSeriesSuite{})
+
+func (s *upgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.uniterSuite.SetUpTest(c)
+
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series: "trusty",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "metered-multi-series",
+		URL:  "cs:metered-multi-series-1",
+	})
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:   "multi-series",
+		Series: "trusty",
+		Charm:  ch,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	var password string
+	s.unit, password = s.Factory.MakeUnitReturningPassword(c, &factory.UnitParams{
+		Machine:     s.machine,
+		Application: app,
+	})
+	st := s.OpenAPIAs(c, s.unit.Tag(), password)
+	uniterState, err := st.Uniter()
+	c.Assert(err, jc.ErrorIsNil)
+	s.apiUnit, err = uniterState.Unit(s.unit.Tag().(names.UnitTag))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *upgradeSeriesSuite) TestUpgradeSeriesStatus(c *gc.C) {
+	status, err := s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, upgradeseries.NotStarted)
+
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, upgradeseries.PrepareStarted)
+
+	err = s.apiUnit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	stateStatus, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stateStatus, gc.Equals, upgradeseries.PrepareCompleted)
+}
+
+func (s *upgradeSeriesSuite) TestSetUpgradeSeriesStatusNotLocked(c *gc.C) {
+	err := s.apiUnit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, gc.ErrorMatches, `series upgrade lock for machine "\d+" not found`)
+}
+
+func (s *upgradeSeriesSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	w, err := s.apiUnit.WatchUpgradeSeriesNotifications()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries implements the client-side API facade used by
+// the seriesupgrader worker.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
+	"github.com/juju/juju/watcher"
+)
+
+// Client provides access to the UpgradeSeries API facade, on behalf of
+// a single machine.
+type Client struct {
+	facade base.FacadeCaller
+	tag    names.MachineTag
+}
+
+// NewClient creates a new client-side UpgradeSeries facade for the
+// machine with the given tag.
+func NewClient(caller base.APICaller, tag names.MachineTag) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "UpgradeSeries"),
+		tag:    tag,
+	}
+}
+
+func (c *Client) entities() params.Entities {
+	return params.Entities{Entities: []params.Entity{{Tag: c.tag.String()}}}
+}
+
+// MachineStatus returns how far through its series upgrade the machine
+// has got.
+func (c *Client) MachineStatus() (upgradeseries.Status, error) {
+	var results params.UpgradeSeriesStatusResults
+	if err := c.facade.FacadeCall("MachineStatus", c.entities(), &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return upgradeseries.Status(results.Results[0].Status), nil
+}
+
+// SetMachineStatus records how far through its series upgrade the
+// machine has got.
+func (c *Client) SetMachineStatus(status upgradeseries.Status) error {
+	args := params.UpgradeSeriesStatusParams{
+		Params: []params.UpgradeSeriesStatusParam{{
+			Entity: params.Entity{Tag: c.tag.String()},
+			Status: string(status),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetMachineStatus", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// TargetSeries returns the series the machine is being upgraded to.
+func (c *Client) TargetSeries() (string, error) {
+	var results params.StringResults
+	if err := c.facade.FacadeCall("TargetSeries", c.entities(), &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
+
+// UnitStatuses returns how far through the machine's series upgrade
+// each unit deployed to it has got, keyed by unit name.
+func (c *Client) UnitStatuses() (map[string]upgradeseries.Status, error) {
+	var results params.UpgradeSeriesUnitStatusesResults
+	if err := c.facade.FacadeCall("UnitStatuses", c.entities(), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]upgradeseries.Status, len(results.Results[0].Statuses))
+	for unitName, status := range results.Results[0].Statuses {
+		statuses[unitName] = upgradeseries.Status(status)
+	}
+	return statuses, nil
+}
+
+// FinishUpgradeSeries unlocks the machine once its series upgrade is
+// complete.
+func (c *Client) FinishUpgradeSeries() error {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("FinishUpgradeSeries", c.entities(), &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchUpgradeSeriesNotifications returns a watcher that notifies of
+// changes to the machine's series upgrade.
+func (c *Client) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchUpgradeSeriesNotifications", c.entities(), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
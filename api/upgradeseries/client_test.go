@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/apiserver/params"
+	coreupgradeseries "github.com/juju/juju/core/upgradeseries"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+var machineArgs = params.Entities{Entities: []params.Entity{{Tag: "machine-1"}}}
+
+func newClient(c *gc.C, request string, args interface{}, result interface{}) *upgradeseries.Client {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, actual string,
+		a, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "UpgradeSeries")
+		c.Check(id, gc.Equals, "")
+		c.Check(actual, gc.Equals, request)
+		c.Check(a, jc.DeepEquals, args)
+		switch response := response.(type) {
+		case *params.UpgradeSeriesStatusResults:
+			*response = result.(params.UpgradeSeriesStatusResults)
+		case *params.StringResults:
+			*response = result.(params.StringResults)
+		case *params.UpgradeSeriesUnitStatusesResults:
+			*response = result.(params.UpgradeSeriesUnitStatusesResults)
+		case *params.ErrorResults:
+			*response = result.(params.ErrorResults)
+		default:
+			c.Fatalf("unexpected response type %T", response)
+		}
+		return nil
+	})
+	return upgradeseries.NewClient(apiCaller, names.NewMachineTag("1"))
+}
+
+func (s *clientSuite) TestMachineStatus(c *gc.C) {
+	client := newClient(c, "MachineStatus", machineArgs, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{{Status: "prepare started"}},
+	})
+	status, err := client.MachineStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, coreupgradeseries.PrepareStarted)
+}
+
+func (s *clientSuite) TestSetMachineStatus(c *gc.C) {
+	args := params.UpgradeSeriesStatusParams{
+		Params: []params.UpgradeSeriesStatusParam{{
+			Entity: params.Entity{Tag: "machine-1"},
+			Status: "prepare completed",
+		}},
+	}
+	client := newClient(c, "SetMachineStatus", args, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+	})
+	err := client.SetMachineStatus(coreupgradeseries.PrepareCompleted)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestTargetSeries(c *gc.C) {
+	client := newClient(c, "TargetSeries", machineArgs, params.StringResults{
+		Results: []params.StringResult{{Result: "xenial"}},
+	})
+	series, err := client.TargetSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(series, gc.Equals, "xenial")
+}
+
+func (s *clientSuite) TestUnitStatuses(c *gc.C) {
+	client := newClient(c, "UnitStatuses", machineArgs, params.UpgradeSeriesUnitStatusesResults{
+		Results: []params.UpgradeSeriesUnitStatusesResult{{
+			Statuses: map[string]string{"mysql/0": "prepare completed"},
+		}},
+	})
+	statuses, err := client.UnitStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses, jc.DeepEquals, map[string]coreupgradeseries.Status{
+		"mysql/0": coreupgradeseries.PrepareCompleted,
+	})
+}
+
+func (s *clientSuite) TestFinishUpgradeSeries(c *gc.C) {
+	client := newClient(c, "FinishUpgradeSeries", machineArgs, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	err := client.FinishUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestResultError(c *gc.C) {
+	client := newClient(c, "TargetSeries", machineArgs, params.StringResults{
+		Results: []params.StringResult{{Error: &params.Error{Message: "not locked"}}},
+	})
+	_, err := client.TargetSeries()
+	c.Assert(err, gc.ErrorMatches, "not locked")
+}
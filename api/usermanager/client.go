@@ -180,3 +180,62 @@ func (c *Client) SetPassword(username, password string) error {
 	}
 	return results.OneError()
 }
+
+// AddGroup adds a group of users to the controller.
+func (c *Client) AddGroup(name string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("groups of users on this controller")
+	}
+	args := params.AddGroups{
+		Groups: []params.AddGroup{{Name: name}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddGroups", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AddGroupMembers adds the named users to a group.
+func (c *Client) AddGroupMembers(group string, usernames ...string) error {
+	return c.groupMembersCall("AddGroupMembers", group, usernames)
+}
+
+// RemoveGroupMembers removes the named users from a group.
+func (c *Client) RemoveGroupMembers(group string, usernames ...string) error {
+	return c.groupMembersCall("RemoveGroupMembers", group, usernames)
+}
+
+func (c *Client) groupMembersCall(methodCall, group string, usernames []string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("groups of users on this controller")
+	}
+	members := make([]string, len(usernames))
+	for i, username := range usernames {
+		if !names.IsValidUser(username) {
+			return errors.Errorf("%q is not a valid username", username)
+		}
+		members[i] = names.NewUserTag(username).String()
+	}
+	args := params.GroupMembersArgs{
+		Args: []params.GroupMembers{{Group: group, Members: members}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(methodCall, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GroupInfo returns information about all of
+// the groups of users in the controller.
+func (c *Client) GroupInfo() ([]params.GroupInfo, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("groups of users on this controller")
+	}
+	var results params.GroupInfoResults
+	if err := c.facade.FacadeCall("GroupInfo", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
@@ -215,3 +215,45 @@ func (s *usermanagerSuite) TestSetUserPasswordBadName(c *gc.C) {
 	err := s.usermanager.SetPassword("not!good", "new-password")
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
+
+func (s *usermanagerSuite) TestAddGroup(c *gc.C) {
+	err := s.usermanager.AddGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+
+	group, err := s.State.UserGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.CreatedBy(), gc.Equals, s.AdminUserTag(c).Name())
+}
+
+func (s *usermanagerSuite) TestAddExistingGroup(c *gc.C) {
+	_, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.usermanager.AddGroup("ops")
+	c.Assert(err, gc.ErrorMatches, `group "ops" already exists`)
+}
+
+func (s *usermanagerSuite) TestAddRemoveGroupMembers(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := s.usermanager.AddGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.usermanager.AddGroupMembers("ops", "bob", "mary@external")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.usermanager.GroupInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, gc.HasLen, 1)
+	c.Assert(info[0].Name, gc.Equals, "ops")
+	c.Assert(info[0].Members, jc.DeepEquals, []string{"bob", "mary@external"})
+
+	err = s.usermanager.RemoveGroupMembers("ops", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = s.usermanager.GroupInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info[0].Members, jc.DeepEquals, []string{"mary@external"})
+}
+
+func (s *usermanagerSuite) TestAddGroupMembersBadName(c *gc.C) {
+	err := s.usermanager.AddGroupMembers("ops", "not!good")
+	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
+}
@@ -58,6 +58,42 @@ func (a *ActionAPI) checkCanWrite() error {
 	return nil
 }
 
+func (a *ActionAPI) checkCanRunActions() error {
+	canRun, err := a.authorizer.HasCapability(permission.ActionsCapability, a.state.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRun {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkCanRunActionsOn returns an error if the authenticated user may
+// not run actions on the receiver. Users without the actions capability
+// on the model may still run actions on units of applications they have
+// been granted admin access to.
+func (a *ActionAPI) checkCanRunActionsOn(receiver names.Tag) error {
+	unitTag, ok := receiver.(names.UnitTag)
+	if !ok {
+		return a.checkCanRunActions()
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	canRun, err := common.HasApplicationCapability(
+		a.authorizer, permission.ActionsCapability, a.state.ModelTag(), names.NewApplicationTag(appName),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRun {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (a *ActionAPI) checkCanAdmin() error {
 	canAdmin, err := a.authorizer.HasPermission(permission.AdminAccess, a.state.ModelTag())
 	if err != nil {
@@ -156,10 +192,6 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // enqueued Action, or an error if there was a problem enqueueing the
 // Action.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
-	if err := a.checkCanWrite(); err != nil {
-		return params.ActionResults{}, errors.Trace(err)
-	}
-
 	if err := a.check.ChangeAllowed(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
@@ -173,6 +205,10 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		if err := a.checkCanRunActionsOn(receiver.Tag()); err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
 		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
@@ -230,10 +266,6 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 
 // Cancel attempts to cancel enqueued Actions from running.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
-	if err := a.checkCanWrite(); err != nil {
-		return params.ActionResults{}, errors.Trace(err)
-	}
-
 	if err := a.check.ChangeAllowed(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
@@ -258,12 +290,16 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		receiverTag, err := names.ActionReceiverTag(result.Receiver())
+		if err := a.checkCanRunActionsOn(receiverTag); err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
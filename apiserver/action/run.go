@@ -10,8 +10,10 @@ import (
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
@@ -41,10 +43,45 @@ func getAllUnitNames(st *state.State, units, services []string) (result []names.
 	return result, nil
 }
 
+// checkCanRun returns an error if the authenticated user may not run
+// commands on all of the targets in the given parameters. Model admins
+// may run commands anywhere; otherwise only units and applications the
+// user has been granted admin access to may be targeted.
+func (a *ActionAPI) checkCanRun(run params.RunParams) error {
+	err := a.checkCanAdmin()
+	if errors.Cause(err) != common.ErrPerm {
+		return err
+	}
+	if len(run.Machines) > 0 || len(run.Units)+len(run.Applications) == 0 {
+		return common.ErrPerm
+	}
+	appNames := set.NewStrings(run.Applications...)
+	for _, unitName := range run.Units {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		appNames.Add(appName)
+	}
+	for _, appName := range appNames.SortedValues() {
+		if !names.IsValidApplication(appName) {
+			return errors.NotValidf("application name %q", appName)
+		}
+		canAdmin, err := a.authorizer.HasPermission(permission.AdminAccess, names.NewApplicationTag(appName))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !canAdmin {
+			return common.ErrPerm
+		}
+	}
+	return nil
+}
+
 // Run the commands specified on the machines identified through the
 // list of machines, units and services.
 func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err error) {
-	if err := a.checkCanAdmin(); err != nil {
+	if err := a.checkCanRun(run); err != nil {
 		return results, err
 	}
 	if err := a.check.ChangeAllowed(); err != nil {
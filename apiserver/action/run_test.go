@@ -261,6 +261,29 @@ func (s *runSuite) TestRunRequiresAdmin(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *runSuite) TestRunApplicationAdmin(c *gc.C) {
+	_, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: s.AddTestingCharm(c, "dummy")})
+	c.Assert(err, jc.ErrorIsNil)
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin-application-magic"),
+	}
+	client, err := action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.Run(params.RunParams{Applications: []string{"magic"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, run := range []params.RunParams{
+		{},
+		{Applications: []string{"other"}},
+		{Units: []string{"other/0"}},
+		{Applications: []string{"magic"}, Machines: []string{"0"}},
+	} {
+		_, err = client.Run(run)
+		c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+	}
+}
+
 func (s *runSuite) TestRunOnAllMachinesRequiresAdmin(c *gc.C) {
 	alpha := names.NewUserTag("alpha@bravo")
 	auth := apiservertesting.FakeAuthorizer{
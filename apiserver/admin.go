@@ -109,6 +109,17 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			logger.Infof("login failed with discharge-required error: %v", err)
 			return loginResult, nil
 		}
+		if err, ok := errors.Cause(err).(*common.OIDCLoginRequiredError); ok {
+			loginResult := params.LoginResult{
+				OIDCLoginRequired: &params.OIDCLoginInfo{
+					Issuer:   err.Issuer,
+					ClientID: err.ClientID,
+					Reason:   err.Error(),
+				},
+			}
+			logger.Infof("login failed with OpenID Connect login required: %v", err)
+			return loginResult, nil
+		}
 		if a.maintenanceInProgress() {
 			// An upgrade, restore or similar operation is in
 			// progress. It is possible for logins to fail until this
@@ -159,7 +170,11 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	// Send back user info if user
 	if isUser {
 		userTag := entity.Tag().(names.UserTag)
-		maybeUserInfo, err = a.checkUserPermissions(userTag, controllerOnlyLogin)
+		var groups []names.UserTag
+		if member, ok := entity.(authentication.GroupMember); ok {
+			groups = member.Groups()
+		}
+		maybeUserInfo, err = a.checkUserPermissions(userTag, groups, controllerOnlyLogin)
 		if err != nil {
 			return fail, errors.Trace(err)
 		}
@@ -218,7 +233,8 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	return loginResult, nil
 }
 
-func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin bool) (*params.AuthUserInfo, error) {
+func (a *admin) checkUserPermissions(userTag names.UserTag, groups []names.UserTag, controllerOnlyLogin bool) (*params.AuthUserInfo, error) {
+	userPermission := common.GroupAccessGetter(a.root.state.UserPermission, userTag, groups)
 
 	modelAccess := permission.NoAccess
 
@@ -238,8 +254,8 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 	}
 
 	controllerAccess := permission.NoAccess
-	if controllerUser, err := state.ControllerAccess(a.root.state, userTag); err == nil {
-		controllerAccess = controllerUser.Access
+	if access, err := userPermission(userTag, a.root.state.ControllerTag()); err == nil {
+		controllerAccess = access
 	} else if errors.IsNotFound(err) {
 		controllerAccess = everyoneGroupAccess
 	} else {
@@ -252,7 +268,7 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 		// admin.
 
 		var err error
-		modelAccess, err = a.root.state.UserPermission(userTag, a.root.state.ModelTag())
+		modelAccess, err = userPermission(userTag, a.root.state.ModelTag())
 		if err != nil && controllerAccess != permission.SuperuserAccess {
 			return nil, errors.Wrap(err, common.ErrPerm)
 		}
@@ -415,12 +431,63 @@ type modelUserEntityFinder struct {
 }
 
 // FindEntity implements authentication.EntityFinder.FindEntity.
+// Users are found along with the groups they are members of in
+// the controller.
 func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
+	return f.FindEntityWithGroups(tag, nil)
+}
+
+// FindEntityWithGroups implements authentication.GroupEntityFinder.
+// The given groups are taken into account along with those the user
+// is a member of in the controller, and a user that has not been
+// granted access to the model or controller themselves is found if
+// any of their groups have been.
+func (f modelUserEntityFinder) FindEntityWithGroups(tag names.Tag, groups []names.UserTag) (state.Entity, error) {
 	utag, ok := tag.(names.UserTag)
 	if !ok {
 		return f.st.FindEntity(tag)
 	}
+	if permission.IsGroup(utag) {
+		// Groups hold access on behalf of their
+		// members, but nobody may log in as one.
+		return nil, errors.NotFoundf("user %q", utag.Id())
+	}
+	memberOf, err := f.st.UserGroupsFor(utag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups = append(memberOf, groups...)
 
+	u, err := f.findUser(utag)
+	if errors.IsNotFound(err) {
+		for _, group := range groups {
+			_, gerr := f.findUser(group)
+			if errors.IsNotFound(gerr) {
+				continue
+			} else if gerr != nil {
+				return nil, errors.Annotatef(gerr, "obtaining access for group %q", group.Id())
+			}
+			u, err = &modelUserEntity{st: f.st, tag: utag}, nil
+			break
+		}
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if u.user == nil && utag.IsLocal() {
+		user, err := f.st.User(utag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		u.user = user
+	}
+	u.groups = groups
+	return u, nil
+}
+
+// findUser returns the entity for the given user, which must have been
+// granted access to the model or controller.
+func (f modelUserEntityFinder) findUser(utag names.UserTag) (*modelUserEntity, error) {
 	modelUser, err := f.st.UserAccess(utag, f.st.ModelTag())
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
@@ -437,7 +504,7 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 		// ControllerUser when logging in from an external user that has not been granted
 		// permissions on the controller but there are permissions for the special
 		// everyone group.
-		if permission.IsEmptyUserAccess(controllerUser) && !utag.IsLocal() {
+		if permission.IsEmptyUserAccess(controllerUser) && !utag.IsLocal() && !permission.IsGroup(utag) {
 			everyoneTag := names.NewUserTag(common.EveryoneTagName)
 			controllerUser, err = f.st.UserAccess(everyoneTag, f.st.ControllerTag())
 			if err != nil && !errors.IsNotFound(err) {
@@ -449,22 +516,15 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 		}
 	}
 
-	u := &modelUserEntity{
+	return &modelUserEntity{
 		st:        f.st,
 		modelUser: modelUser,
 		tag:       utag,
-	}
-	if utag.IsLocal() {
-		user, err := f.st.User(utag)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		u.user = user
-	}
-	return u, nil
+	}, nil
 }
 
 var _ loginEntity = &modelUserEntity{}
+var _ authentication.GroupMember = &modelUserEntity{}
 
 // modelUserEntity encapsulates an model user
 // and, if the user is local, the local state user
@@ -477,6 +537,7 @@ type modelUserEntity struct {
 	modelUser permission.UserAccess
 	user      *state.User
 	tag       names.Tag
+	groups    []names.UserTag
 }
 
 // Refresh implements state.Authenticator.Refresh.
@@ -509,6 +570,11 @@ func (u *modelUserEntity) Tag() names.Tag {
 	return u.tag
 }
 
+// Groups implements authentication.GroupMember.
+func (u *modelUserEntity) Groups() []names.UserTag {
+	return u.groups
+}
+
 // LastLogin implements loginEntity.LastLogin.
 func (u *modelUserEntity) LastLogin() (time.Time, error) {
 	// The last connection for the model takes precedence over
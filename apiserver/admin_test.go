@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/controller"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	jujucontroller "github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
@@ -1081,6 +1082,139 @@ func (s *macaroonLoginSuite) TestUnknownUserLogin(c *gc.C) {
 	c.Assert(client, gc.Equals, nil)
 }
 
+var _ = gc.Suite(&oidcLoginSuite{})
+
+type oidcLoginSuite struct {
+	apitesting.OIDCSuite
+}
+
+func (s *oidcLoginSuite) login(c *gc.C, info *api.Info, request params.LoginRequest) (params.LoginResult, error) {
+	info.SkipLogin = true
+	client, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	var result params.LoginResult
+	err = client.APICall("Admin", 3, "", "Login", &request, &result)
+	return result, err
+}
+
+func (s *oidcLoginSuite) grantGroup(c *gc.C, group string, modelAccess, controllerAccess permission.Access) {
+	s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		User:   group + "@oidc-group",
+		Access: modelAccess,
+	})
+	_, err := s.State.AddControllerUser(state.UserAccessSpec{
+		User:      names.NewUserTag(group + "@oidc-group"),
+		CreatedBy: s.AdminUserTag(c),
+		Access:    controllerAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oidcLoginSuite) TestLoginRequiresIDToken(c *gc.C) {
+	result, err := s.login(c, s.APIInfo(c), params.LoginRequest{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OIDCLoginRequired, jc.DeepEquals, &params.OIDCLoginInfo{
+		Issuer:   s.Issuer.URL(),
+		ClientID: apitesting.OIDCClientID,
+		Reason:   "no ID token provided",
+	})
+	c.Assert(result.UserInfo, gc.IsNil)
+}
+
+func (s *oidcLoginSuite) TestLoginExpiredIDToken(c *gc.C) {
+	token := s.Issuer.Token(map[string]interface{}{
+		"email": "bob@example.com",
+		"exp":   time.Now().Add(-time.Minute).Unix(),
+	})
+	result, err := s.login(c, s.APIInfo(c), params.LoginRequest{IDToken: token})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OIDCLoginRequired, gc.NotNil)
+	c.Assert(result.OIDCLoginRequired.Reason, gc.Equals, "ID token has expired")
+}
+
+func (s *oidcLoginSuite) TestLoginWithIDToken(c *gc.C) {
+	s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		User:   "bob@example.com",
+		Access: permission.WriteAccess,
+	})
+	_, err := s.State.AddControllerUser(state.UserAccessSpec{
+		User:      names.NewUserTag("bob@example.com"),
+		CreatedBy: s.AdminUserTag(c),
+		Access:    permission.LoginAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.login(c, s.APIInfo(c), params.LoginRequest{
+		IDToken: s.IDToken("bob@example.com"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserInfo, gc.NotNil)
+	c.Check(result.UserInfo.Identity, gc.Equals, "user-bob@example.com")
+	c.Check(result.UserInfo.ControllerAccess, gc.Equals, "login")
+	c.Check(result.UserInfo.ModelAccess, gc.Equals, "write")
+}
+
+func (s *oidcLoginSuite) TestLoginAfterKeySetChange(c *gc.C) {
+	s.grantGroup(c, "ops", permission.WriteAccess, permission.LoginAccess)
+	_, err := s.login(c, s.APIInfo(c), params.LoginRequest{
+		IDToken: s.IDToken("bob@example.com", "ops"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Tokens signed with a new key are accepted once the
+	// controller is configured with the new key set.
+	s.Issuer.RotateKey()
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		jujucontroller.OIDCKeySet: s.Issuer.JWKS(),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.login(c, s.APIInfo(c), params.LoginRequest{
+		IDToken: s.IDToken("bob@example.com", "ops"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oidcLoginSuite) TestLoginThroughGroup(c *gc.C) {
+	s.grantGroup(c, "ops", permission.WriteAccess, permission.LoginAccess)
+	s.grantGroup(c, "auditors", permission.ReadAccess, permission.AddModelAccess)
+
+	result, err := s.login(c, s.APIInfo(c), params.LoginRequest{
+		IDToken: s.IDToken("bob@example.com", "auditors", "ops"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserInfo, gc.NotNil)
+	c.Check(result.UserInfo.Identity, gc.Equals, "user-bob@example.com")
+	c.Check(result.UserInfo.ControllerAccess, gc.Equals, "add-model")
+	c.Check(result.UserInfo.ModelAccess, gc.Equals, "write")
+}
+
+func (s *oidcLoginSuite) TestGroupAccessHonouredByFacades(c *gc.C) {
+	s.grantGroup(c, "ops", permission.ReadAccess, permission.LoginAccess)
+	info := s.APIInfo(c)
+	info.IDToken = s.IDToken("bob@example.com", "ops")
+	conn, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	c.Assert(conn.AuthTag(), gc.Equals, names.NewUserTag("bob@example.com"))
+
+	// Read access through the group is enough to see the model...
+	_, err = conn.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// ...but not to change it.
+	err = conn.Client().SetModelConstraints(constraints.MustParse("mem=4G"))
+	assertPermissionDenied(c, err)
+}
+
+func (s *oidcLoginSuite) TestLoginWithoutGroupAccess(c *gc.C) {
+	s.grantGroup(c, "ops", permission.ReadAccess, permission.LoginAccess)
+	_, err := s.login(c, s.APIInfo(c), params.LoginRequest{
+		IDToken: s.IDToken("bob@example.com", "dev"),
+	})
+	assertInvalidEntityPassword(c, err)
+}
+
 func assertInvalidEntityPassword(c *gc.C, err error) {
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: "invalid entity name or password",
@@ -33,6 +33,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/firewaller"
 	"github.com/juju/juju/apiserver/highavailability" // ModelUser Write
+	"github.com/juju/juju/apiserver/hostfirewaller"
 	"github.com/juju/juju/apiserver/hostkeyreporter"
 	"github.com/juju/juju/apiserver/imagemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/imagemetadata"
@@ -66,8 +67,10 @@ import (
 	"github.com/juju/juju/apiserver/remoterelations"
 	"github.com/juju/juju/apiserver/resources"
 	"github.com/juju/juju/apiserver/resourceshookcontext"
+	"github.com/juju/juju/apiserver/resourcetagger"
 	"github.com/juju/juju/apiserver/resumer"
 	"github.com/juju/juju/apiserver/retrystrategy"
+	"github.com/juju/juju/apiserver/roles"
 	"github.com/juju/juju/apiserver/singular"
 	"github.com/juju/juju/apiserver/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/sshclient" // ModelUser Write
@@ -79,6 +82,7 @@ import (
 	"github.com/juju/juju/apiserver/unitassigner"
 	"github.com/juju/juju/apiserver/uniter"
 	"github.com/juju/juju/apiserver/upgrader"
+	"github.com/juju/juju/apiserver/upgradeseries"
 	"github.com/juju/juju/apiserver/usermanager"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
@@ -121,6 +125,11 @@ func AllFacades() *facade.Registry {
 	reg("Application", 2, application.NewFacade)
 	reg("Application", 3, application.NewFacade)
 	reg("Application", 4, application.NewFacade)
+	reg("Application", 5, application.NewFacade) // v5 adds expose settings for endpoints.
+	reg("Application", 6, application.NewFacade) // v6 adds SetEndpointBindings.
+	reg("Application", 7, application.NewFacade) // v7 adds UpdateApplicationSeries.
+	reg("Application", 8, application.NewFacade) // v8 adds UpdateResourceTags.
+	reg("Application", 9, application.NewFacade) // v9 adds SetAntiAffinity.
 
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
@@ -132,15 +141,23 @@ func AllFacades() *facade.Registry {
 	reg("Client", 1, client.NewFacade)
 	reg("Cloud", 1, cloud.NewFacade)
 	reg("Controller", 3, controller.NewControllerAPI)
+	reg("Controller", 4, controller.NewControllerAPI) // v4 adds MigrationPrechecks.
+	reg("Controller", 5, controller.NewControllerAPI) // v5 adds ConfigSet.
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiscoverSpaces", 2, discoverspaces.NewAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
 	reg("Firewaller", 3, firewaller.NewFirewallerAPI)
+	reg("Firewaller", 4, firewaller.NewFirewallerAPI) // v4 adds GetExposeInfo.
+	reg("Firewaller", 5, firewaller.NewFirewallerAPI) // v5 adds WatchModelFirewallRules and ModelFirewallRules.
+	reg("Firewaller", 6, firewaller.NewFirewallerAPI) // v6 adds GetMachineEgressRules.
+	reg("Firewaller", 7, firewaller.NewFirewallerAPI) // v7 adds WatchSubnets, WatchMachinesProvisioning and the endpoints of opened ports.
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
+	reg("HostFirewaller", 1, hostfirewaller.NewHostFirewallerAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 2, imagemetadata.NewAPI)
 	reg("InstancePoller", 3, instancepoller.NewFacade)
+	reg("InstancePoller", 4, instancepoller.NewFacade) // Version 4 adds ArePreemptible and ReplaceInstances.
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
@@ -151,8 +168,15 @@ func AllFacades() *facade.Registry {
 
 	reg("MachineManager", 2, machinemanager.NewMachineManagerAPI)
 	reg("MachineManager", 3, machinemanager.NewMachineManagerAPI) // Version 3 adds DestroyMachine and ForceDestroyMachine.
+	reg("MachineManager", 4, machinemanager.NewMachineManagerAPI) // Version 4 adds LinkLayerDevices.
+	reg("MachineManager", 5, machinemanager.NewMachineManagerAPI) // Version 5 adds UpgradeSeriesPrepare and UpgradeSeriesComplete.
+	reg("MachineManager", 6, machinemanager.NewMachineManagerAPI) // Version 6 adds MaintainMachines.
+	reg("MachineManager", 7, machinemanager.NewMachineManagerAPI) // Version 7 adds DestroyMachineWithParams.
+	reg("MachineManager", 8, machinemanager.NewMachineManagerAPI) // Version 8 adds AdoptableInstances and AdoptInstances.
+	reg("MachineManager", 9, machinemanager.NewMachineManagerAPI) // Version 9 adds UpgradeSeriesAbort.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
+	reg("MachineUndertaker", 2, machineundertaker.NewFacade) // Version 2 adds KeepInstance.
 	reg("Machiner", 1, machine.NewMachinerAPI)
 
 	reg("MeterStatus", 1, meterstatus.NewMeterStatusAPI)
@@ -164,9 +188,15 @@ func AllFacades() *facade.Registry {
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 2, migrationtarget.NewFacade) // v2 adds PrecheckBlockers and ImportRoleGrants.
+	reg("MigrationTarget", 3, migrationtarget.NewFacade) // v3 adds ImportUserGroups.
+	reg("MigrationTarget", 4, migrationtarget.NewFacade) // v4 adds ImportApplicationGrants.
+	reg("MigrationTarget", 5, migrationtarget.NewFacade) // v5 adds ImportExtras.
 
 	reg("ModelConfig", 1, modelconfig.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacade)
+	reg("ModelManager", 3, modelmanager.NewFacade) // v3 adds ModifyApplicationAccess.
+	reg("ModelManager", 4, modelmanager.NewFacade) // v4 adds DestroyModelsWithParams.
 
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
@@ -177,6 +207,8 @@ func AllFacades() *facade.Registry {
 
 	reg("Pinger", 1, NewPinger)
 	reg("Provisioner", 3, provisioner.NewProvisionerAPI)
+	reg("Provisioner", 4, provisioner.NewProvisionerAPI) // Version 4 adds KeepInstance.
+	reg("Provisioner", 5, provisioner.NewProvisionerAPI) // Version 5 adds WatchEndpointBindings and PrepareAddedContainerInterfaceInfo.
 	reg("ProxyUpdater", 1, proxyupdater.NewAPI)
 	reg("Reboot", 2, reboot.NewRebootAPI)
 
@@ -187,8 +219,10 @@ func AllFacades() *facade.Registry {
 		reflect.TypeOf(&resourceshookcontext.UnitFacade{}),
 	)
 
+	reg("ResourceTagger", 1, resourcetagger.NewFacade)
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("Roles", 1, roles.NewFacade)
 	reg("Singular", 1, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -204,9 +238,14 @@ func AllFacades() *facade.Registry {
 
 	reg("Uniter", 4, uniter.NewUniterAPI)
 	reg("Uniter", 5, uniter.NewUniterAPI)
+	reg("Uniter", 6, uniter.NewUniterAPI) // v6 adds OpenEgress and CloseEgress.
+	reg("Uniter", 7, uniter.NewUniterAPI) // v7 adds the series upgrade methods.
+	reg("Uniter", 8, uniter.NewUniterAPI) // v8 opens ports on endpoints.
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
+	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
+	reg("UserManager", 2, usermanager.NewUserManagerAPI) // v2 adds groups of users.
 
 	if featureflag.Enabled(feature.CrossModelRelations) {
 		reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
@@ -9,6 +9,7 @@ package application
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -124,11 +125,49 @@ func (api *API) checkCanWrite() error {
 	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
 }
 
+// checkCapability returns an error if the authenticated user may not
+// exercise the capability on the model, either through its access
+// level or through a granted role.
+func (api *API) checkCapability(capability permission.Capability) error {
+	allowed, err := api.authorizer.HasCapability(capability, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkApplicationCapability returns an error if the authenticated
+// user may not exercise the capability on the named application.
+func (api *API) checkApplicationCapability(capability permission.Capability, appName string) error {
+	allowed, err := common.HasApplicationCapability(
+		api.authorizer, capability, api.backend.ModelTag(), names.NewApplicationTag(appName),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkCanRelate returns an error if the authenticated user may not
+// relate every application named by the given endpoints.
+func (api *API) checkCanRelate(endpoints []string) error {
+	for _, ep := range endpoints {
+		appName := strings.SplitN(ep, ":", 2)[0]
+		if err := api.checkApplicationCapability(permission.RelateCapability, appName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
-	if err := api.checkCanWrite(); err != nil {
-		return params.ErrorResults{}, errors.Trace(err)
-	}
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Creds)),
 	}
@@ -136,6 +175,10 @@ func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (
 		return result, nil
 	}
 	for i, a := range args.Creds {
+		if err := api.checkApplicationCapability(permission.ConfigCapability, a.ApplicationName); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		application, err := api.backend.Application(a.ApplicationName)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(err)
@@ -152,7 +195,7 @@ func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (
 // Deploy fetches the charms from the charm store and deploys them
 // using the specified placement directives.
 func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCapability(permission.DeployCapability); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
 	result := params.ErrorResults{
@@ -284,10 +327,17 @@ func parseSettingsCompatible(charmConfig *charm.Config, settings map[string]stri
 // minimum number of units, settings and constraints.
 // All parameters in params.ApplicationUpdate except the application name are optional.
 func (api *API) Update(args params.ApplicationUpdate) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.ConfigCapability, args.ApplicationName); err != nil {
 		return err
 	}
-	if !args.ForceCharmURL {
+	// Changing the charm needs the same capability as SetCharm,
+	// and only a forced charm change may bypass the block.
+	if args.CharmURL != "" {
+		if err := api.checkApplicationCapability(permission.DeployCapability, args.ApplicationName); err != nil {
+			return err
+		}
+	}
+	if !args.ForceCharmURL || args.CharmURL == "" {
 		if err := api.check.ChangeAllowed(); err != nil {
 			return errors.Trace(err)
 		}
@@ -341,7 +391,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 
 // SetCharm sets the charm for a given for the application.
 func (api *API) SetCharm(args params.ApplicationSetCharm) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.DeployCapability, args.ApplicationName); err != nil {
 		return err
 	}
 	// when forced units in error, don't block
@@ -500,7 +550,7 @@ func (api *API) GetCharmURL(args params.ApplicationGet) (params.StringResult, er
 // It does not unset values that are set to an empty string.
 // Unset should be used for that.
 func (api *API) Set(p params.ApplicationSet) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.ConfigCapability, p.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -526,7 +576,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 
 // Unset implements the server side of Client.Unset.
 func (api *API) Unset(p params.ApplicationUnset) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.ConfigCapability, p.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -566,9 +616,11 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If expose settings
+// are given for the application's endpoints, the ports are exposed
+// only to the spaces and CIDRs they name.
 func (api *API) Expose(args params.ApplicationExpose) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.ExposeCapability, args.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -578,13 +630,23 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	exposed := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for name, ep := range args.ExposedEndpoints {
+		exposed[name] = state.ExposedEndpoint{
+			ExposeToSpaces: ep.ExposeToSpaces,
+			ExposeToCIDRs:  ep.ExposeToCIDRs,
+		}
+	}
+	return app.MergeExposeSettings(exposed)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Unexpose(args params.ApplicationUnexpose) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.ExposeCapability, args.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -597,6 +659,73 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	return app.ClearExposed()
 }
 
+// SetEndpointBindings binds the given endpoints of an application
+// to spaces, leaving the bindings of other endpoints unchanged.
+func (api *API) SetEndpointBindings(args params.ApplicationSetEndpointBindings) error {
+	if err := api.checkApplicationCapability(permission.ConfigCapability, args.ApplicationName); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetEndpointBindings(args.EndpointBindings)
+}
+
+// UpdateApplicationSeries changes the series new machines are
+// provisioned with for the application's units.
+func (api *API) UpdateApplicationSeries(args params.ApplicationUpdateSeries) error {
+	if err := api.checkApplicationCapability(permission.DeployCapability, args.ApplicationName); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Series == "" {
+		return errors.BadRequestf("series not specified")
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.UpdateApplicationSeries(args.Series, args.Force)
+}
+
+// UpdateResourceTags updates the tags set on the application's cloud
+// resources. Tags with an empty value are removed.
+func (api *API) UpdateResourceTags(args params.ApplicationUpdateResourceTags) error {
+	if err := api.checkApplicationCapability(permission.ConfigCapability, args.ApplicationName); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.UpdateResourceTags(args.ResourceTags)
+}
+
+// SetAntiAffinity sets whether the application's units must be kept on
+// different hosts. Units that already share a host are not moved.
+func (api *API) SetAntiAffinity(args params.ApplicationSetAntiAffinity) error {
+	if err := api.checkApplicationCapability(permission.ConfigCapability, args.ApplicationName); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetAntiAffinity(args.AntiAffinity)
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*state.Unit, error) {
 	application, err := backend.Application(args.ApplicationName)
@@ -611,7 +740,7 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*s
 
 // AddUnits adds a given number of units to an application.
 func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.DeployCapability, args.ApplicationName); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -664,9 +793,6 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 
 // DestroyUnit removes a given set of application units.
 func (api *API) DestroyUnit(args params.Entities) (params.DestroyUnitResults, error) {
-	if err := api.checkCanWrite(); err != nil {
-		return params.DestroyUnitResults{}, err
-	}
 	if err := api.check.RemoveAllowed(); err != nil {
 		return params.DestroyUnitResults{}, errors.Trace(err)
 	}
@@ -676,6 +802,13 @@ func (api *API) DestroyUnit(args params.Entities) (params.DestroyUnitResults, er
 			return nil, err
 		}
 		name := unitTag.Id()
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := api.checkApplicationCapability(permission.RemoveCapability, appName); err != nil {
+			return nil, err
+		}
 		unit, err := api.backend.Unit(name)
 		if errors.IsNotFound(err) {
 			return nil, errors.Errorf("unit %q does not exist", name)
@@ -737,9 +870,6 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 
 // DestroyApplication removes a given set of applications.
 func (api *API) DestroyApplication(args params.Entities) (params.DestroyApplicationResults, error) {
-	if err := api.checkCanWrite(); err != nil {
-		return params.DestroyApplicationResults{}, err
-	}
 	if err := api.check.RemoveAllowed(); err != nil {
 		return params.DestroyApplicationResults{}, errors.Trace(err)
 	}
@@ -755,6 +885,9 @@ func (api *API) DestroyApplication(args params.Entities) (params.DestroyApplicat
 		if err != nil {
 			return nil, err
 		}
+		if err := api.checkApplicationCapability(permission.RemoveCapability, tag.Id()); err != nil {
+			return nil, err
+		}
 		var info params.DestroyApplicationInfo
 		if err := destroyRemoteApp(tag.Id()); !errors.IsNotFound(err) {
 			return &info, err
@@ -827,7 +960,7 @@ func (api *API) GetConstraints(args params.GetApplicationConstraints) (params.Ge
 
 // SetConstraints sets the constraints for a given application.
 func (api *API) SetConstraints(args params.SetConstraints) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkApplicationCapability(permission.ConfigCapability, args.ApplicationName); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -892,9 +1025,9 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 		isRemote = true
 	}
 	// If it's not a remote relation to another model then
-	// the user needs write access to the model.
+	// the user needs to be able to relate applications in the model.
 	if !isRemote {
-		if err := api.checkCanWrite(); err != nil {
+		if err := api.checkCanRelate(endpoints); err != nil {
 			return params.AddRelationResults{}, errors.Trace(err)
 		}
 	}
@@ -1398,7 +1531,7 @@ func (api *API) consumeOne(possibleURL, alias string) (string, error) {
 
 // DestroyRelation removes the relation between the specified endpoints.
 func (api *API) DestroyRelation(args params.DestroyRelation) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanRelate(args.Endpoints); err != nil {
 		return err
 	}
 	if err := api.check.RemoveAllowed(); err != nil {
@@ -1857,7 +1857,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1869,6 +1869,269 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeEndpoints(c *gc.C) {
+	charm := s.AddTestingCharm(c, "wordpress")
+	app := s.AddTestingService(c, "wordpress", charm)
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"url": {
+				ExposeToSpaces: []string{"admin"},
+				ExposeToCIDRs:  []string{"10.0.0.0/8"},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"url": {
+			ExposeToSpaces: []string{"admin"},
+			ExposeToCIDRs:  []string{"10.0.0.0/8"},
+		},
+	})
+}
+
+func (s *applicationSuite) TestApplicationExposeApplicationAccess(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	allowed := s.AddTestingService(c, "allowed", charm)
+	s.AddTestingService(c, "denied", charm)
+
+	s.authorizer.Tag = names.NewUserTag("write-application-allowed")
+	err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: "allowed"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = allowed.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowed.IsExposed(), jc.IsTrue)
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: "denied"})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationSuite) TestApplicationSetEndpointBindings(c *gc.C) {
+	charm := s.AddTestingCharm(c, "wordpress")
+	app := s.AddTestingService(c, "wordpress", charm)
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName:  "wordpress",
+		EndpointBindings: map[string]string{"db": "admin"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["db"], gc.Equals, "admin")
+	c.Assert(bindings["url"], gc.Equals, "")
+}
+
+func (s *applicationSuite) TestApplicationSetEndpointBindingsUnknownSpace(c *gc.C) {
+	charm := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingService(c, "wordpress", charm)
+
+	err := s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName:  "wordpress",
+		EndpointBindings: map[string]string{"db": "missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*unknown space "missing" not valid`)
+}
+
+func (s *applicationSuite) TestApplicationSetEndpointBindingsApplicationAccess(c *gc.C) {
+	charm := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingService(c, "allowed", charm)
+	s.AddTestingService(c, "denied", charm)
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.authorizer.Tag = names.NewUserTag("write-application-allowed")
+	err = s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName:  "allowed",
+		EndpointBindings: map[string]string{"db": "admin"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName:  "denied",
+		EndpointBindings: map[string]string{"db": "admin"},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationSuite) deployMultiSeries(c *gc.C, appName, series string) {
+	curl, _ := s.UploadCharmMultiSeries(c, "~who/multi-series", "multi-series")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmURL:        curl.String(),
+			ApplicationName: appName,
+			Series:          series,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *applicationSuite) TestApplicationUpdateSeries(c *gc.C) {
+	s.deployMultiSeries(c, "application", "precise")
+
+	err := s.applicationAPI.UpdateApplicationSeries(params.ApplicationUpdateSeries{
+		ApplicationName: "application",
+		Series:          "trusty",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "trusty")
+}
+
+func (s *applicationSuite) TestApplicationUpdateSeriesUnsupported(c *gc.C) {
+	s.deployMultiSeries(c, "application", "precise")
+
+	err := s.applicationAPI.UpdateApplicationSeries(params.ApplicationUpdateSeries{
+		ApplicationName: "application",
+		Series:          "xenial",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update series of application "application" to "xenial": only these series are supported: precise, trusty`)
+
+	err = s.applicationAPI.UpdateApplicationSeries(params.ApplicationUpdateSeries{
+		ApplicationName: "application",
+		Series:          "xenial",
+		Force:           true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestApplicationUpdateSeriesNoSeries(c *gc.C) {
+	err := s.applicationAPI.UpdateApplicationSeries(params.ApplicationUpdateSeries{
+		ApplicationName: "application",
+	})
+	c.Assert(err, gc.ErrorMatches, "series not specified")
+}
+
+func (s *applicationSuite) TestApplicationUpdateSeriesApplicationAccess(c *gc.C) {
+	s.deployMultiSeries(c, "allowed", "precise")
+	s.deployMultiSeries(c, "denied", "precise")
+
+	s.authorizer.Tag = names.NewUserTag("write-application-allowed")
+	err := s.applicationAPI.UpdateApplicationSeries(params.ApplicationUpdateSeries{
+		ApplicationName: "allowed",
+		Series:          "trusty",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.UpdateApplicationSeries(params.ApplicationUpdateSeries{
+		ApplicationName: "denied",
+		Series:          "trusty",
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationSuite) TestApplicationUpdateResourceTags(c *gc.C) {
+	s.AddTestingService(c, "application", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.UpdateResourceTags(params.ApplicationUpdateResourceTags{
+		ApplicationName: "application",
+		ResourceTags:    map[string]string{"team": "db", "cost-centre": "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.applicationAPI.UpdateResourceTags(params.ApplicationUpdateResourceTags{
+		ApplicationName: "application",
+		ResourceTags:    map[string]string{"cost-centre": ""},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.Get(params.ApplicationGet{ApplicationName: "application"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.ResourceTags, jc.DeepEquals, map[string]string{"team": "db"})
+}
+
+func (s *applicationSuite) TestApplicationUpdateResourceTagsReserved(c *gc.C) {
+	s.AddTestingService(c, "application", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.UpdateResourceTags(params.ApplicationUpdateResourceTags{
+		ApplicationName: "application",
+		ResourceTags:    map[string]string{"juju-model-uuid": "foo"},
+	})
+	c.Assert(err, gc.ErrorMatches, `tag "juju-model-uuid" with reserved prefix "juju-" not valid`)
+}
+
+func (s *applicationSuite) TestApplicationUpdateResourceTagsApplicationAccess(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "allowed", charm)
+	s.AddTestingService(c, "denied", charm)
+
+	s.authorizer.Tag = names.NewUserTag("write-application-allowed")
+	err := s.applicationAPI.UpdateResourceTags(params.ApplicationUpdateResourceTags{
+		ApplicationName: "allowed",
+		ResourceTags:    map[string]string{"team": "db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.UpdateResourceTags(params.ApplicationUpdateResourceTags{
+		ApplicationName: "denied",
+		ResourceTags:    map[string]string{"team": "db"},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationSuite) TestApplicationSetAntiAffinity(c *gc.C) {
+	s.AddTestingService(c, "application", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.SetAntiAffinity(params.ApplicationSetAntiAffinity{
+		ApplicationName: "application",
+		AntiAffinity:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.Get(params.ApplicationGet{ApplicationName: "application"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.AntiAffinity, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestApplicationSetAntiAffinityApplicationAccess(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "allowed", charm)
+	s.AddTestingService(c, "denied", charm)
+
+	s.authorizer.Tag = names.NewUserTag("write-application-allowed")
+	err := s.applicationAPI.SetAntiAffinity(params.ApplicationSetAntiAffinity{
+		ApplicationName: "allowed",
+		AntiAffinity:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.SetAntiAffinity(params.ApplicationSetAntiAffinity{
+		ApplicationName: "denied",
+		AntiAffinity:    true,
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationSuite) TestDestroyApplicationApplicationAccess(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "allowed", charm)
+	s.AddTestingService(c, "denied", charm)
+
+	s.authorizer.Tag = names.NewUserTag("write-application-allowed")
+	results, err := s.applicationAPI.DestroyApplication(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-allowed"},
+			{Tag: "application-denied"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -1909,7 +2172,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1924,7 +2187,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/juju"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -81,6 +82,10 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	s.blockChecker = mockBlockChecker{}
+	s.api = s.newAPI(c)
+}
+
+func (s *ApplicationSuite) newAPI(c *gc.C) *application.API {
 	resources := common.NewResources()
 	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
 	api, err := application.NewAPI(
@@ -98,7 +103,7 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = api
+	return api
 }
 
 func (s *ApplicationSuite) TestSetCharmStorageConstraints(c *gc.C) {
@@ -252,6 +257,37 @@ func (s *ApplicationSuite) TestDestroyUnit(c *gc.C) {
 	}})
 }
 
+func (s *ApplicationSuite) TestUpdateCharmNeedsDeployCapability(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:          names.NewUserTag("operator"),
+		Capabilities: []permission.Capability{permission.ConfigCapability},
+	}
+	api := s.newAPI(c)
+	err := api.Update(params.ApplicationUpdate{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		ForceCharmURL:   true,
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.blockChecker.CheckNoCalls(c)
+	s.application.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestUpdateForceWithoutCharmIsBlocked(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:          names.NewUserTag("operator"),
+		Capabilities: []permission.Capability{permission.ConfigCapability},
+	}
+	api := s.newAPI(c)
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	err := api.Update(params.ApplicationUpdate{
+		ApplicationName: "postgresql",
+		ForceCharmURL:   true,
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
 type mockBackend struct {
 	application.Backend
 	testing.Stub
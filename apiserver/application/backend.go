@@ -54,6 +54,7 @@ type BlockChecker interface {
 type Application interface {
 	AddUnit() (*state.Unit, error)
 	AllUnits() ([]Unit, error)
+	AntiAffinity() bool
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
 	Channel() csparams.Channel
@@ -63,13 +64,19 @@ type Application interface {
 	Destroy() error
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	ResourceTags() (map[string]string, bool)
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
 	Series() string
+	SetAntiAffinity(bool) error
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEndpointBindings(map[string]string) error
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	UpdateApplicationSeries(string, bool) error
 	UpdateConfigSettings(charm.Settings) error
+	UpdateResourceTags(map[string]string) error
 }
 
 // Charm defines a subset of the functionality provided by the
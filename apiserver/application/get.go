@@ -35,12 +35,16 @@ func (api *API) Get(args params.ApplicationGet) (params.ApplicationGetResults, e
 			return params.ApplicationGetResults{}, err
 		}
 	}
+	resourceTags, _ := app.ResourceTags()
 	return params.ApplicationGetResults{
 		Application: args.ApplicationName,
 		Charm:       charm.Meta().Name,
 		Config:      configInfo,
 		Constraints: constraints,
 		Series:      app.Series(),
+
+		ResourceTags: resourceTags,
+		AntiAffinity: app.AntiAffinity(),
 	}, nil
 }
 
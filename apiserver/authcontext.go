@@ -20,6 +20,8 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/oidc"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/bakerystorage"
 )
@@ -54,6 +56,11 @@ type authContext struct {
 	macaroonAuthOnce   sync.Once
 	_macaroonAuth      *authentication.ExternalMacaroonAuthenticator
 	_macaroonAuthError error
+
+	// oidcAuthMutex guards the fields below it.
+	oidcAuthMutex   sync.Mutex
+	_oidcAuth       *authentication.OIDCAuthenticator
+	_oidcAuthConfig oidcAuthConfig
 }
 
 // newAuthContext creates a new authentication context for st.
@@ -148,6 +155,17 @@ func (a authenticator) Authenticate(
 	tag names.Tag,
 	req params.LoginRequest,
 ) (state.Entity, error) {
+	if tag == nil && req.IDToken != "" {
+		// An external user is presenting an OpenID Connect ID token.
+		auth, err := a.ctxt.oidcAuth()
+		if errors.Cause(err) == errOIDCAuthNotConfigured {
+			err = errors.Trace(common.ErrNoCreds)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return auth.Authenticate(entityFinder, tag, req)
+	}
 	auth, err := a.authenticatorForTag(tag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -161,6 +179,11 @@ func (a authenticator) authenticatorForTag(tag names.Tag) (authentication.Entity
 	if tag == nil {
 		auth, err := a.ctxt.externalMacaroonAuth()
 		if errors.Cause(err) == errMacaroonAuthNotConfigured {
+			// Fall back to OpenID Connect, which will ask
+			// the client to obtain an ID token.
+			auth, err = a.ctxt.oidcAuth()
+		}
+		if errors.Cause(err) == errOIDCAuthNotConfigured {
 			err = errors.Trace(common.ErrNoCreds)
 		}
 		if err != nil {
@@ -250,6 +273,84 @@ func newExternalMacaroonAuth(st *state.State) (*authentication.ExternalMacaroonA
 	return &auth, nil
 }
 
+// oidcAuth returns an authenticator that can authenticate logins for
+// external users presenting OpenID Connect ID tokens. The
+// authenticator is replaced when the OpenID Connect controller
+// config changes; failures to create it are not remembered.
+func (ctxt *authContext) oidcAuth() (authentication.EntityAuthenticator, error) {
+	controllerCfg, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
+	cfg := newOIDCAuthConfig(controllerCfg)
+	if cfg.issuer == "" {
+		return nil, errOIDCAuthNotConfigured
+	}
+	ctxt.oidcAuthMutex.Lock()
+	defer ctxt.oidcAuthMutex.Unlock()
+	if ctxt._oidcAuth != nil && ctxt._oidcAuthConfig == cfg {
+		return ctxt._oidcAuth, nil
+	}
+	auth, err := newOIDCAuth(controllerCfg, ctxt.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctxt._oidcAuth, ctxt._oidcAuthConfig = auth, cfg
+	return auth, nil
+}
+
+var errOIDCAuthNotConfigured = errors.New("OpenID Connect authentication is not configured")
+
+// oidcAuthConfig holds the controller config that an OpenID
+// Connect authenticator is created from.
+type oidcAuthConfig struct {
+	issuer        string
+	clientID      string
+	keySet        string
+	usernameClaim string
+	groupsClaim   string
+}
+
+func newOIDCAuthConfig(controllerCfg controller.Config) oidcAuthConfig {
+	keySet, _ := controllerCfg[controller.OIDCKeySet].(string)
+	return oidcAuthConfig{
+		issuer:        controllerCfg.OIDCIssuerURL(),
+		clientID:      controllerCfg.OIDCClientID(),
+		keySet:        keySet,
+		usernameClaim: controllerCfg.OIDCUsernameClaim(),
+		groupsClaim:   controllerCfg.OIDCGroupsClaim(),
+	}
+}
+
+// newOIDCAuth returns an authenticator that can authenticate logins
+// for external users presenting OpenID Connect ID tokens. This is
+// just a helper function for authCtxt.oidcAuth.
+func newOIDCAuth(controllerCfg controller.Config, clock clock.Clock) (*authentication.OIDCAuthenticator, error) {
+	issuer := controllerCfg.OIDCIssuerURL()
+	var keys oidc.KeySource
+	if keySet := controllerCfg.OIDCKeySet(); keySet != nil {
+		keys = keySet
+	} else {
+		// No key set supplied - retrieve it from the provider,
+		// fetching it again as the provider rotates its keys.
+		metadata, err := oidc.Discover(http.DefaultClient, issuer)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		keys = oidc.NewRemoteKeySet(http.DefaultClient, metadata.JWKSURI, clock)
+	}
+	return &authentication.OIDCAuthenticator{
+		Verifier: &oidc.Verifier{
+			Issuer:   issuer,
+			ClientID: controllerCfg.OIDCClientID(),
+			Keys:     keys,
+			Clock:    clock,
+		},
+		UsernameClaim: controllerCfg.OIDCUsernameClaim(),
+		GroupsClaim:   controllerCfg.OIDCGroupsClaim(),
+	}, nil
+}
+
 // newBakeryService creates a new bakery.Service.
 func newBakeryService(
 	st *state.State,
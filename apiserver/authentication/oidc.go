@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/oidc"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// GroupEntityFinder is implemented by entity finders that can take
+// the groups a user belongs to into account when finding them, so
+// that users whose only access is through a group can log in.
+type GroupEntityFinder interface {
+	EntityFinder
+	FindEntityWithGroups(tag names.Tag, groups []names.UserTag) (state.Entity, error)
+}
+
+// GroupMember is implemented by authenticated entities
+// that are members of groups.
+type GroupMember interface {
+	// Groups returns the tags that stand for the
+	// groups the entity is a member of.
+	Groups() []names.UserTag
+}
+
+// OIDCAuthenticator performs authentication for external users presenting
+// an OpenID Connect ID token. If no token is presented, or the token is not
+// valid, it returns a *common.OIDCLoginRequiredError describing the provider
+// a new token should be obtained from.
+type OIDCAuthenticator struct {
+	// Verifier verifies the ID tokens presented at login.
+	Verifier *oidc.Verifier
+
+	// UsernameClaim names the claim holding the user name.
+	UsernameClaim string
+
+	// GroupsClaim names the claim holding the user's groups.
+	GroupsClaim string
+}
+
+var _ EntityAuthenticator = (*OIDCAuthenticator)(nil)
+
+// Authenticate implements EntityAuthenticator.
+func (a *OIDCAuthenticator) Authenticate(entityFinder EntityFinder, _ names.Tag, req params.LoginRequest) (state.Entity, error) {
+	if req.IDToken == "" {
+		return nil, a.loginRequiredError(nil)
+	}
+	claims, err := a.Verifier.Verify(req.IDToken)
+	if err != nil {
+		logger.Debugf("rejecting ID token: %v", err)
+		return nil, a.loginRequiredError(err)
+	}
+	tag, err := a.userTag(claims)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups := a.groupTags(claims)
+
+	var entity state.Entity
+	if groupFinder, ok := entityFinder.(GroupEntityFinder); ok {
+		entity, err = groupFinder.FindEntityWithGroups(tag, groups)
+	} else {
+		entity, err = entityFinder.FindEntity(tag)
+	}
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entity, nil
+}
+
+func (a *OIDCAuthenticator) loginRequiredError(cause error) error {
+	return &common.OIDCLoginRequiredError{
+		Cause:    cause,
+		Issuer:   a.Verifier.Issuer,
+		ClientID: a.Verifier.ClientID,
+	}
+}
+
+// userTag returns the tag of the external user identified by the
+// given claims. As for macaroon-based logins, a name without a domain
+// is given the "external" domain.
+func (a *OIDCAuthenticator) userTag(claims oidc.Claims) (names.UserTag, error) {
+	username := claims.String(a.UsernameClaim)
+	if username == "" {
+		return names.UserTag{}, errors.Errorf("ID token has no %q claim", a.UsernameClaim)
+	}
+	if a.UsernameClaim == "email" {
+		// Only trust addresses the provider has verified,
+		// when it tells us whether it has.
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return names.UserTag{}, errors.Errorf("email address %q has not been verified", username)
+		}
+	}
+	if names.IsValidUserName(username) {
+		return names.NewLocalUserTag(username).WithDomain("external"), nil
+	}
+	if !names.IsValidUser(username) {
+		return names.UserTag{}, errors.Errorf("%q is an invalid user name", username)
+	}
+	tag := names.NewUserTag(username)
+	if tag.IsLocal() {
+		return names.UserTag{}, errors.Errorf("OpenID Connect provider has provided ostensibly local name %q", username)
+	}
+	if permission.IsGroup(tag) {
+		return names.UserTag{}, errors.Errorf("OpenID Connect provider has provided group name %q", username)
+	}
+	return tag, nil
+}
+
+// groupTags returns the tags standing for the groups asserted in the
+// given claims. Groups whose names cannot be used in a tag are ignored.
+func (a *OIDCAuthenticator) groupTags(claims oidc.Claims) []names.UserTag {
+	var groups []names.UserTag
+	for _, group := range claims.Strings(a.GroupsClaim) {
+		if !names.IsValidUserName(group) {
+			logger.Debugf("ignoring group %q with invalid name", group)
+			continue
+		}
+		groups = append(groups, names.NewLocalUserTag(group).WithDomain(permission.OIDCGroupDomain))
+	}
+	return groups
+}
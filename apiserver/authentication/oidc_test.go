@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/oidc"
+	"github.com/juju/juju/oidc/oidctest"
+	"github.com/juju/juju/state"
+)
+
+type oidcAuthenticatorSuite struct {
+	testing.IsolationSuite
+	issuer        *oidctest.Issuer
+	authenticator *authentication.OIDCAuthenticator
+}
+
+var _ = gc.Suite(&oidcAuthenticatorSuite{})
+
+func (s *oidcAuthenticatorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.issuer = oidctest.NewIssuer("juju")
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+	keys, err := oidc.ParseKeySet([]byte(s.issuer.JWKS()))
+	c.Assert(err, jc.ErrorIsNil)
+	s.authenticator = &authentication.OIDCAuthenticator{
+		Verifier: &oidc.Verifier{
+			Issuer:   s.issuer.URL(),
+			ClientID: "juju",
+			Keys:     keys,
+			Clock:    clock.WallClock,
+		},
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+	}
+}
+
+func (s *oidcAuthenticatorSuite) TestNoTokenRequiresLogin(c *gc.C) {
+	_, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{})
+	loginErr, ok := errors.Cause(err).(*common.OIDCLoginRequiredError)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(loginErr.Issuer, gc.Equals, s.issuer.URL())
+	c.Assert(loginErr.ClientID, gc.Equals, "juju")
+}
+
+func (s *oidcAuthenticatorSuite) TestInvalidTokenRequiresLogin(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{
+		"email": "bob@example.com",
+		"aud":   "other",
+	})
+	_, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, gc.ErrorMatches, `ID token not issued to client "juju"`)
+	_, ok := errors.Cause(err).(*common.OIDCLoginRequiredError)
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticate(c *gc.C) {
+	finder := &groupEntityFinder{}
+	token := s.issuer.Token(map[string]interface{}{
+		"email":  "bob@example.com",
+		"groups": []string{"ops", "not/valid", "dev"},
+	})
+	entity, err := s.authenticator.Authenticate(finder, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("bob@example.com"))
+	c.Assert(finder.groups, jc.DeepEquals, []names.UserTag{
+		names.NewUserTag("ops@oidc-group"),
+		names.NewUserTag("dev@oidc-group"),
+	})
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateNameWithoutDomain(c *gc.C) {
+	s.authenticator.UsernameClaim = "preferred_username"
+	token := s.issuer.Token(map[string]interface{}{
+		"preferred_username": "bob",
+	})
+	entity, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("bob@external"))
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateLocalNameRejected(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{
+		"email": "bob@local",
+	})
+	_, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, gc.ErrorMatches, `OpenID Connect provider has provided ostensibly local name "bob@local"`)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateGroupNameRejected(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{
+		"email": "ops@group",
+	})
+	_, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, gc.ErrorMatches, `OpenID Connect provider has provided group name "ops@group"`)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateUnverifiedEmail(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{
+		"email":          "bob@example.com",
+		"email_verified": false,
+	})
+	_, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, gc.ErrorMatches, `email address "bob@example.com" has not been verified`)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateMissingClaim(c *gc.C) {
+	token := s.issuer.Token(nil)
+	_, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, gc.ErrorMatches, `ID token has no "email" claim`)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateUnknownUser(c *gc.C) {
+	finder := &groupEntityFinder{err: errors.NotFoundf("model or controller user")}
+	token := s.issuer.Token(map[string]interface{}{"email": "bob@example.com"})
+	_, err := s.authenticator.Authenticate(finder, nil, params.LoginRequest{IDToken: token})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+}
+
+// groupEntityFinder implements authentication.GroupEntityFinder,
+// recording the groups it is asked to take into account.
+type groupEntityFinder struct {
+	groups []names.UserTag
+	err    error
+}
+
+func (f *groupEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
+	return f.FindEntityWithGroups(tag, nil)
+}
+
+func (f *groupEntityFinder) FindEntityWithGroups(tag names.Tag, groups []names.UserTag) (state.Entity, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.groups = groups
+	return &simpleEntity{tag}, nil
+}
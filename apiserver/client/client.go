@@ -83,6 +83,22 @@ func (c *Client) checkCanRead() error {
 	return nil
 }
 
+// checkCanWriteApplication returns an error if the authenticated user
+// has neither write access to the model nor to the named application.
+func (c *Client) checkCanWriteApplication(appName string) error {
+	if err := c.checkCanWrite(); errors.Cause(err) != common.ErrPerm {
+		return err
+	}
+	canWrite, err := c.api.auth.HasPermission(permission.WriteAccess, names.NewApplicationTag(appName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (c *Client) checkCanWrite() error {
 	isAdmin, err := c.api.auth.HasPermission(permission.SuperuserAccess, c.api.stateAccessor.ControllerTag())
 	if err != nil {
@@ -185,7 +201,11 @@ func (c *Client) WatchAll() (params.AllWatcherId, error) {
 
 // Resolved implements the server side of Client.Resolved.
 func (c *Client) Resolved(p params.Resolved) error {
-	if err := c.checkCanWrite(); err != nil {
+	appName, err := names.UnitApplication(p.UnitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.checkCanWriteApplication(appName); err != nil {
 		return err
 	}
 	if err := c.check.ChangeAllowed(); err != nil {
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/status"
@@ -199,6 +200,10 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		}
 	}
 
+	if err := c.filterApplicationAccess(&context); err != nil {
+		return noStatus, errors.Annotate(err, "could not filter applications by access")
+	}
+
 	logger.Debugf("Applications: %v", context.applications)
 	logger.Debugf("Remote applications: %v", context.remoteApplications)
 
@@ -308,6 +313,61 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}, nil
 }
 
+// filterApplicationAccess restricts the status context to the
+// applications the authenticated user has been granted access to, along
+// with the machines hosting their units. Users with write access to the
+// model, and read-only users without any application grants, see the
+// whole model.
+func (c *Client) filterApplicationAccess(context *statusContext) error {
+	if err := c.checkCanWrite(); errors.Cause(err) != common.ErrPerm {
+		return errors.Trace(err)
+	}
+	visible := make(set.Strings)
+	for appName := range context.applications {
+		canRead, err := c.api.auth.HasPermission(permission.ReadAccess, names.NewApplicationTag(appName))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if canRead {
+			visible.Add(appName)
+		}
+	}
+	if visible.IsEmpty() || visible.Size() == len(context.applications) {
+		return nil
+	}
+
+	matchedMachines := make(set.Strings)
+	for appName := range context.applications {
+		if visible.Contains(appName) {
+			for _, unit := range context.units[appName] {
+				if machineId, err := unit.AssignedMachineId(); err == nil {
+					matchedMachines.Add(machineId)
+				}
+			}
+			continue
+		}
+		delete(context.applications, appName)
+		delete(context.units, appName)
+		delete(context.relations, appName)
+	}
+	for id, machineList := range context.machines {
+		// The host machine is always kept alongside any matched
+		// containers, as it heads the list.
+		matched := []*state.Machine{machineList[0]}
+		for _, m := range machineList[1:] {
+			if matchedMachines.Contains(m.Id()) {
+				matched = append(matched, m)
+			}
+		}
+		if len(matched) == 1 && !matchedMachines.Contains(id) {
+			delete(context.machines, id)
+			continue
+		}
+		context.machines[id] = matched
+	}
+	return nil
+}
+
 // newToolsVersionAvailable will return a string representing a tools
 // version only if the latest check is newer than current tools.
 func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
@@ -361,6 +421,10 @@ type statusContext struct {
 	// this machine.
 	machines map[string][]*state.Machine
 
+	// machinesByID: machine id -> machine, for all the machines
+	// in machines. It is built on first use by machineByID.
+	machinesByID map[string]*state.Machine
+
 	// ipAddresses: machine id -> list of ip.addresses
 	ipAddresses map[string][]*state.Address
 
@@ -633,6 +697,7 @@ func makeMachineStatus(
 	status.Jobs = paramsJobsFromJobs(machine.Jobs())
 	status.WantsVote = machine.WantsVote()
 	status.HasVote = machine.HasVote()
+	status.Maintenance = machine.InMaintenance()
 	sInfo, err := machine.InstanceStatus()
 	populateStatusFromStatusInfoAndErr(&status.InstanceStatus, sInfo, err)
 	instid, err := machine.InstanceId()
@@ -817,6 +882,15 @@ func (context *statusContext) processApplication(application *state.Application)
 		Exposed: application.IsExposed(),
 		Life:    processLife(application),
 	}
+	if exposedEndpoints := application.ExposedEndpoints(); len(exposedEndpoints) > 0 {
+		processedStatus.ExposedEndpoints = make(map[string]params.ExposedEndpoint)
+		for name, ep := range exposedEndpoints {
+			processedStatus.ExposedEndpoints[name] = params.ExposedEndpoint{
+				ExposeToSpaces: ep.ExposeToSpaces,
+				ExposeToCIDRs:  ep.ExposeToCIDRs,
+			}
+		}
+	}
 
 	if latestCharm, ok := context.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
 		if latestCharm.Revision() > applicationCharm.URL().Revision {
@@ -831,7 +905,7 @@ func (context *statusContext) processApplication(application *state.Application)
 	}
 	units := context.units[application.Name()]
 	if application.IsPrincipal() {
-		processedStatus.Units = context.processUnits(units, applicationCharm.URL().String())
+		processedStatus.Units = context.processUnits(units, applicationCharm.URL().String(), application.Series())
 	}
 	applicationStatus, err := application.Status()
 	if err != nil {
@@ -932,15 +1006,15 @@ func (context *statusContext) processUnitMeterStatuses(units map[string]*state.U
 	return nil
 }
 
-func (context *statusContext) processUnits(units map[string]*state.Unit, applicationCharm string) map[string]params.UnitStatus {
+func (context *statusContext) processUnits(units map[string]*state.Unit, applicationCharm, applicationSeries string) map[string]params.UnitStatus {
 	unitsMap := make(map[string]params.UnitStatus)
 	for _, unit := range units {
-		unitsMap[unit.Name()] = context.processUnit(unit, applicationCharm)
+		unitsMap[unit.Name()] = context.processUnit(unit, applicationCharm, applicationSeries)
 	}
 	return unitsMap
 }
 
-func (context *statusContext) processUnit(unit *state.Unit, applicationCharm string) params.UnitStatus {
+func (context *statusContext) processUnit(unit *state.Unit, applicationCharm, applicationSeries string) params.UnitStatus {
 	var result params.UnitStatus
 	addr, err := unit.PublicAddress()
 	if err != nil {
@@ -956,6 +1030,11 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	}
 	if unit.IsPrincipal() {
 		result.Machine, _ = unit.AssignedMachineId()
+		// Units deployed before the application's series was changed
+		// keep running the series of their machines.
+		if machine := context.machineByID(result.Machine); machine != nil && machine.Series() != applicationSeries {
+			result.Series = machine.Series()
+		}
 	}
 	curl, _ := unit.CharmURL()
 	if applicationCharm != "" && curl != nil && curl.String() != applicationCharm {
@@ -976,7 +1055,7 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 			subUnit := context.unitByName(name)
 			// subUnit may be nil if subordinate was filtered out.
 			if subUnit != nil {
-				result.Subordinates[name] = context.processUnit(subUnit, applicationCharm)
+				result.Subordinates[name] = context.processUnit(subUnit, applicationCharm, applicationSeries)
 			}
 		}
 	}
@@ -986,6 +1065,20 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	return result
 }
 
+// machineByID returns the machine with the given id, or nil if it was
+// not fetched.
+func (context *statusContext) machineByID(id string) *state.Machine {
+	if context.machinesByID == nil {
+		context.machinesByID = make(map[string]*state.Machine)
+		for _, machines := range context.machines {
+			for _, machine := range machines {
+				context.machinesByID[machine.Id()] = machine
+			}
+		}
+	}
+	return context.machinesByID[id]
+}
+
 func (context *statusContext) unitByName(name string) *state.Unit {
 	applicationName := strings.Split(name, "/")[0]
 	return context.units[applicationName][name]
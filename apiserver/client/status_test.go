@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
 )
@@ -73,6 +74,75 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusUnitMixedSeries(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "multi-series",
+		URL:  "cs:multi-series-1",
+	})
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:   "multi-series",
+		Series: "precise",
+		Charm:  ch,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "precise"})
+	u := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: machine})
+	err = app.UpdateApplicationSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, ok := status.Applications[app.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(appStatus.Series, gc.Equals, "trusty")
+	unit, ok := appStatus.Units[u.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(unit.Series, gc.Equals, "precise")
+}
+
+func (s *statusSuite) TestFullStatusApplicationAccess(c *gc.C) {
+	visible := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "visible"})
+	hidden := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "hidden"})
+	u := s.Factory.MakeUnit(c, &factory.UnitParams{Application: visible})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: hidden})
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "ro-password",
+		Access:   permission.ReadAccess,
+	})
+	err := s.State.CreateApplicationAccess(visible.ApplicationTag(), user.UserTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	roClient := s.OpenAPIAs(c, user.UserTag(), "ro-password").Client()
+	defer roClient.Close()
+	status, err := roClient.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 1)
+	_, ok := status.Applications["visible"]
+	c.Assert(ok, jc.IsTrue)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 1)
+	_, ok = status.Machines[machineId]
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *statusSuite) TestFullStatusReadOnlyWithoutApplicationAccess(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	s.Factory.MakeUnit(c, nil)
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "ro-password",
+		Access:   permission.ReadAccess,
+	})
+	roClient := s.OpenAPIAs(c, user.UserTag(), "ro-password").Client()
+	defer roClient.Close()
+	status, err := roClient.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 2)
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -77,6 +77,22 @@ func IsDischargeRequiredError(err error) bool {
 	return ok
 }
 
+// OIDCLoginRequiredError is the error returned when an external user
+// must present a new OpenID Connect ID token to complete authentication.
+type OIDCLoginRequiredError struct {
+	Cause    error
+	Issuer   string
+	ClientID string
+}
+
+// Error implements the error interface.
+func (e *OIDCLoginRequiredError) Error() string {
+	if e.Cause == nil {
+		return "no ID token provided"
+	}
+	return e.Cause.Error()
+}
+
 // IsUpgradeInProgress returns true if this error is caused
 // by an upgrade in progress.
 func IsUpgradeInProgressError(err error) bool {
@@ -33,7 +33,7 @@ var sendMetrics = func(st metricsender.ModelBackend) error {
 // have been done. If the model is a controller hosting other
 // models, they will also be destroyed.
 func DestroyModelIncludingHosted(st ModelManagerBackend, systemTag names.ModelTag) error {
-	return destroyModel(st, systemTag, true)
+	return destroyModel(st, systemTag, true, false)
 }
 
 // DestroyModel sets the environment to dying. Cleanup jobs then destroy
@@ -42,10 +42,18 @@ func DestroyModelIncludingHosted(st ModelManagerBackend, systemTag names.ModelTa
 // have been done. An error will be returned if this model is a
 // controller hosting other model.
 func DestroyModel(st ModelManagerBackend, modelTag names.ModelTag) error {
-	return destroyModel(st, modelTag, false)
+	return destroyModel(st, modelTag, false, false)
 }
 
-func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHostedModels bool) error {
+// DestroyModelKeepingInstances sets the model to dying, as DestroyModel
+// does, but the cloud instances of the model's machines are left running
+// when the machines are removed, and the model's cloud resources are not
+// torn down.
+func DestroyModelKeepingInstances(st ModelManagerBackend, modelTag names.ModelTag) error {
+	return destroyModel(st, modelTag, false, true)
+}
+
+func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHostedModels, keepInstances bool) error {
 	var err error
 	if modelTag != st.ModelTag() {
 		if st, err = st.ForModel(modelTag); err != nil {
@@ -98,6 +106,10 @@ func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHosted
 		if err := model.DestroyIncludingHosted(); err != nil {
 			return err
 		}
+	} else if keepInstances {
+		if err = model.DestroyKeepingInstances(); err != nil {
+			return errors.Trace(err)
+		}
 	} else {
 		if err = model.Destroy(); err != nil {
 			return errors.Trace(err)
@@ -54,6 +54,10 @@ type ModelManagerBackend interface {
 	ControllerTag() names.ControllerTag
 	Export() (description.Model, error)
 	SetUserAccess(subject names.UserTag, target names.Tag, access permission.Access) (permission.UserAccess, error)
+	GetApplicationAccess(app names.ApplicationTag, user names.UserTag) (permission.Access, error)
+	CreateApplicationAccess(app names.ApplicationTag, user names.UserTag, access permission.Access) error
+	UpdateApplicationAccess(app names.ApplicationTag, user names.UserTag, access permission.Access) error
+	RemoveApplicationAccess(app names.ApplicationTag, user names.UserTag) error
 	SetModelMeterStatus(string, string) error
 	LastModelConnection(user names.UserTag) (time.Time, error)
 	LatestMigration() (state.ModelMigration, error)
@@ -83,6 +87,7 @@ type Model interface {
 	CloudRegion() string
 	Users() ([]permission.UserAccess, error)
 	Destroy() error
+	DestroyKeepingInstances() error
 	DestroyIncludingHosted() error
 	SLALevel() string
 	SLAOwner() string
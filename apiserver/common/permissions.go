@@ -31,6 +31,8 @@ func HasPermission(
 		validate = permission.ValidateModelAccess
 	case names.ApplicationOfferTagKind:
 		validate = permission.ValidateOfferAccess
+	case names.ApplicationTagKind:
+		validate = permission.ValidateApplicationAccess
 	default:
 		return false, nil
 	}
@@ -56,7 +58,9 @@ func HasPermission(
 	modelPermission := userAccess.EqualOrGreaterModelAccessThan(requestedPermission) && target.Kind() == names.ModelTagKind
 	controllerPermission := userAccess.EqualOrGreaterControllerAccessThan(requestedPermission) && target.Kind() == names.ControllerTagKind
 	offerPermission := userAccess.EqualOrGreaterOfferAccessThan(requestedPermission) && target.Kind() == names.ApplicationOfferTagKind
-	if !controllerPermission && !modelPermission && !offerPermission {
+	// Application access levels are ordered in the same way as model ones.
+	applicationPermission := userAccess.EqualOrGreaterModelAccessThan(requestedPermission) && target.Kind() == names.ApplicationTagKind
+	if !controllerPermission && !modelPermission && !offerPermission && !applicationPermission {
 		return false, nil
 	}
 	return true, nil
@@ -87,6 +91,85 @@ func GetPermission(accessGetter userAccessFunc, userTag names.UserTag, target na
 	return userAccess, nil
 }
 
+// GroupAccessGetter returns an access getter that reports, for the given
+// user, the greatest of the access granted to them directly and that
+// granted to any of the groups they are a member of. Access for any
+// other user is looked up with accessGetter unchanged.
+func GroupAccessGetter(
+	accessGetter func(names.UserTag, names.Tag) (permission.Access, error),
+	user names.UserTag, groups []names.UserTag,
+) func(names.UserTag, names.Tag) (permission.Access, error) {
+	if len(groups) == 0 {
+		return accessGetter
+	}
+	return func(subject names.UserTag, target names.Tag) (permission.Access, error) {
+		access, err := accessGetter(subject, target)
+		if subject != user {
+			return access, err
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return permission.NoAccess, errors.Trace(err)
+		}
+		found := err == nil
+		for _, group := range groups {
+			groupAccess, gerr := accessGetter(group, target)
+			if errors.IsNotFound(gerr) {
+				continue
+			} else if gerr != nil {
+				return permission.NoAccess, errors.Annotatef(gerr, "obtaining access for group %q", group.Id())
+			}
+			if !found || greaterAccess(target, groupAccess, access) {
+				access = groupAccess
+			}
+			found = true
+		}
+		if !found {
+			return permission.NoAccess, errors.Trace(err)
+		}
+		return access, nil
+	}
+}
+
+// GroupRolesGetter returns a roles getter that reports, for the given
+// user, the roles granted to them directly together with those granted
+// to any of the groups they are a member of. Roles for any other user
+// are looked up with rolesGetter unchanged.
+func GroupRolesGetter(
+	rolesGetter func(names.UserTag, names.Tag) ([]permission.Role, error),
+	user names.UserTag, groups []names.UserTag,
+) func(names.UserTag, names.Tag) ([]permission.Role, error) {
+	if len(groups) == 0 {
+		return rolesGetter
+	}
+	return func(subject names.UserTag, target names.Tag) ([]permission.Role, error) {
+		roles, err := rolesGetter(subject, target)
+		if err != nil || subject != user {
+			return roles, err
+		}
+		for _, group := range groups {
+			groupRoles, err := rolesGetter(group, target)
+			if err != nil {
+				return nil, errors.Annotatef(err, "obtaining roles for group %q", group.Id())
+			}
+			roles = append(roles, groupRoles...)
+		}
+		return roles, nil
+	}
+}
+
+// greaterAccess reports whether access a is greater than access b
+// on the given target, whose kind determines how access is ordered.
+func greaterAccess(target names.Tag, a, b permission.Access) bool {
+	switch target.Kind() {
+	case names.ControllerTagKind:
+		return a.GreaterControllerAccessThan(b)
+	case names.ApplicationOfferTagKind:
+		return a.GreaterOfferAccessThan(b)
+	default:
+		return a.GreaterModelAccessThan(b)
+	}
+}
+
 // HasModelAdmin reports whether or not a user has admin access to the specified model.
 // A user has model access if they are the model owner, if they are a controller superuser,
 // or if they have been explicitly granted admin access to the model.
@@ -106,3 +189,47 @@ func HasModelAdmin(
 	}
 	return authorizer.HasPermission(permission.AdminAccess, model.ModelTag())
 }
+
+type userRolesFunc func(names.UserTag, names.Tag) ([]permission.Role, error)
+
+// HasCapability returns true if the specified user may exercise the
+// capability on target, either because their access level implies it
+// or because they have been granted a role conferring it.
+func HasCapability(
+	accessGetter userAccessFunc, rolesGetter userRolesFunc, utag names.Tag,
+	capability permission.Capability, target names.Tag,
+) (bool, error) {
+	if ok, err := HasPermission(accessGetter, utag, capability.ImpliedBy(), target); err != nil || ok {
+		return ok, err
+	}
+	userTag, ok := utag.(names.UserTag)
+	if !ok || target.Kind() != names.ModelTagKind {
+		return false, nil
+	}
+	roles, err := rolesGetter(userTag, target)
+	if err != nil {
+		return false, errors.Annotatef(err, "while obtaining %s roles", target.Kind())
+	}
+	for _, role := range roles {
+		if role.HasCapability(capability) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasApplicationCapability reports whether the authenticated user may
+// exercise the capability on an application in the model, either
+// because they may exercise it on the whole model or because they
+// have been granted sufficient access to the application itself.
+func HasApplicationCapability(
+	authorizer facade.Authorizer,
+	capability permission.Capability,
+	modelTag names.ModelTag,
+	application names.ApplicationTag,
+) (bool, error) {
+	if ok, err := authorizer.HasCapability(capability, modelTag); err != nil || ok {
+		return ok, err
+	}
+	return authorizer.HasPermission(capability.ImpliedBy(), application)
+}
@@ -130,6 +130,30 @@ func (r *PermissionSuite) TestHasPermission(c *gc.C) {
 			access:           permission.AddModelAccess,
 			expected:         false,
 		},
+		{
+			title:            "user has lesser application permissions than required",
+			userGetterAccess: permission.ReadAccess,
+			user:             names.NewUserTag("validuser"),
+			target:           names.NewApplicationTag("mysql"),
+			access:           permission.WriteAccess,
+			expected:         false,
+		},
+		{
+			title:            "user has greater application permission than required",
+			userGetterAccess: permission.AdminAccess,
+			user:             names.NewUserTag("validuser"),
+			target:           names.NewApplicationTag("mysql"),
+			access:           permission.WriteAccess,
+			expected:         true,
+		},
+		{
+			title:            "user requests offer permission on application",
+			userGetterAccess: permission.AdminAccess,
+			user:             names.NewUserTag("validuser"),
+			target:           names.NewApplicationTag("mysql"),
+			access:           permission.ConsumeAccess,
+			expected:         false,
+		},
 	}
 	for i, t := range testCases {
 		userGetter := &fakeUserAccess{
@@ -221,3 +245,188 @@ func (r *PermissionSuite) TestEveryoneAtExternal(c *gc.C) {
 		c.Assert(hasPermission, gc.Equals, t.expected)
 	}
 }
+
+func (r *PermissionSuite) TestHasCapability(c *gc.C) {
+	operator := permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{permission.ActionsCapability},
+	}
+	testCases := []struct {
+		title            string
+		userGetterAccess permission.Access
+		roles            []permission.Role
+		target           names.Tag
+		capability       permission.Capability
+		expected         bool
+	}{
+		{
+			title:            "write access implies capability",
+			userGetterAccess: permission.WriteAccess,
+			target:           names.NewModelTag("beef1beef2-0000-0000-000011112222"),
+			capability:       permission.ActionsCapability,
+			expected:         true,
+		},
+		{
+			title:            "write access does not imply ssh",
+			userGetterAccess: permission.WriteAccess,
+			target:           names.NewModelTag("beef1beef2-0000-0000-000011112222"),
+			capability:       permission.SSHCapability,
+			expected:         false,
+		},
+		{
+			title:            "role confers capability",
+			userGetterAccess: permission.ReadAccess,
+			roles:            []permission.Role{operator},
+			target:           names.NewModelTag("beef1beef2-0000-0000-000011112222"),
+			capability:       permission.ActionsCapability,
+			expected:         true,
+		},
+		{
+			title:            "role does not confer capability",
+			userGetterAccess: permission.ReadAccess,
+			roles:            []permission.Role{operator},
+			target:           names.NewModelTag("beef1beef2-0000-0000-000011112222"),
+			capability:       permission.DeployCapability,
+			expected:         false,
+		},
+		{
+			title:            "roles only apply to models",
+			userGetterAccess: permission.LoginAccess,
+			roles:            []permission.Role{operator},
+			target:           names.NewControllerTag("beef1beef2-0000-0000-000011112222"),
+			capability:       permission.ActionsCapability,
+			expected:         false,
+		},
+	}
+	for i, t := range testCases {
+		c.Logf("HasCapability test n %d: %s", i, t.title)
+		userGetter := &fakeUserAccess{access: t.userGetterAccess}
+		rolesGetter := func(names.UserTag, names.Tag) ([]permission.Role, error) {
+			return t.roles, nil
+		}
+		ok, err := common.HasCapability(
+			userGetter.call, rolesGetter, names.NewUserTag("validuser"), t.capability, t.target,
+		)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ok, gc.Equals, t.expected)
+	}
+}
+
+func (r *PermissionSuite) TestHasCapabilityRolesError(c *gc.C) {
+	userGetter := &fakeUserAccess{access: permission.ReadAccess}
+	rolesGetter := func(names.UserTag, names.Tag) ([]permission.Role, error) {
+		return nil, errors.New("boom")
+	}
+	ok, err := common.HasCapability(
+		userGetter.call, rolesGetter, names.NewUserTag("validuser"),
+		permission.DeployCapability, names.NewModelTag("beef1beef2-0000-0000-000011112222"),
+	)
+	c.Assert(err, gc.ErrorMatches, "while obtaining model roles: boom")
+	c.Assert(ok, jc.IsFalse)
+}
+
+type fakeGroupUserAccess map[string]permission.Access
+
+func (f fakeGroupUserAccess) call(subject names.UserTag, object names.Tag) (permission.Access, error) {
+	if access, ok := f[subject.Id()]; ok {
+		return access, nil
+	}
+	return permission.NoAccess, errors.NotFoundf("access for %q", subject.Id())
+}
+
+func (r *PermissionSuite) TestGroupAccessGetter(c *gc.C) {
+	user := names.NewUserTag("bob@example.com")
+	groups := []names.UserTag{
+		names.NewUserTag("ops@oidc-group"),
+		names.NewUserTag("dev@oidc-group"),
+	}
+	model := names.NewModelTag("beef1beef2-0000-0000-000011112222")
+	controller := names.NewControllerTag("beef1beef2-0000-0000-000011112222")
+	testCases := []struct {
+		title    string
+		access   fakeGroupUserAccess
+		target   names.Tag
+		expected permission.Access
+		err      string
+	}{{
+		title:    "group access greater than user access",
+		access:   fakeGroupUserAccess{"bob@example.com": permission.ReadAccess, "dev@oidc-group": permission.WriteAccess},
+		target:   model,
+		expected: permission.WriteAccess,
+	}, {
+		title:    "user access greater than group access",
+		access:   fakeGroupUserAccess{"bob@example.com": permission.AdminAccess, "ops@oidc-group": permission.WriteAccess},
+		target:   model,
+		expected: permission.AdminAccess,
+	}, {
+		title:    "access only through groups",
+		access:   fakeGroupUserAccess{"ops@oidc-group": permission.ReadAccess, "dev@oidc-group": permission.AdminAccess},
+		target:   model,
+		expected: permission.AdminAccess,
+	}, {
+		title:    "controller access ordering",
+		access:   fakeGroupUserAccess{"ops@oidc-group": permission.SuperuserAccess, "dev@oidc-group": permission.LoginAccess},
+		target:   controller,
+		expected: permission.SuperuserAccess,
+	}, {
+		title:  "no access at all",
+		access: fakeGroupUserAccess{},
+		target: model,
+		err:    `access for "bob@example.com" not found`,
+	}}
+	for i, t := range testCases {
+		c.Logf("GroupAccessGetter test n %d: %s", i, t.title)
+		getter := common.GroupAccessGetter(t.access.call, user, groups)
+		access, err := getter(user, t.target)
+		if t.err != "" {
+			c.Assert(err, gc.ErrorMatches, t.err)
+			c.Assert(err, jc.Satisfies, errors.IsNotFound)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(access, gc.Equals, t.expected)
+	}
+}
+
+func (r *PermissionSuite) TestGroupAccessGetterOtherUser(c *gc.C) {
+	access := fakeGroupUserAccess{"ops@oidc-group": permission.AdminAccess}
+	getter := common.GroupAccessGetter(
+		access.call, names.NewUserTag("bob@example.com"), []names.UserTag{names.NewUserTag("ops@oidc-group")},
+	)
+	_, err := getter(names.NewUserTag("mary@example.com"), names.NewModelTag("beef1beef2-0000-0000-000011112222"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (r *PermissionSuite) TestHasPermissionThroughGroup(c *gc.C) {
+	user := names.NewUserTag("bob@example.com")
+	access := fakeGroupUserAccess{"ops@oidc-group": permission.WriteAccess}
+	getter := common.GroupAccessGetter(access.call, user, []names.UserTag{names.NewUserTag("ops@oidc-group")})
+	target := names.NewModelTag("beef1beef2-0000-0000-000011112222")
+	ok, err := common.HasPermission(getter, user, permission.WriteAccess, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	ok, err = common.HasPermission(getter, user, permission.AdminAccess, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (r *PermissionSuite) TestGroupRolesGetter(c *gc.C) {
+	user := names.NewUserTag("bob@example.com")
+	operator := permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{permission.ActionsCapability},
+	}
+	rolesGetter := func(subject names.UserTag, _ names.Tag) ([]permission.Role, error) {
+		if subject.Id() == "ops@oidc-group" {
+			return []permission.Role{operator}, nil
+		}
+		return nil, nil
+	}
+	getter := common.GroupRolesGetter(rolesGetter, user, []names.UserTag{names.NewUserTag("ops@oidc-group")})
+	ok, err := common.HasCapability(
+		fakeGroupUserAccess{}.call, getter, user,
+		permission.ActionsCapability, names.NewModelTag("beef1beef2-0000-0000-000011112222"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// MachinePortRanges returns the port ranges opened in the given ports
+// document, with the units and endpoints they are opened for. APIs
+// require a stable order for results, so they are sorted by range.
+func MachinePortRanges(ports *state.Ports) []params.MachinePortRange {
+	portRanges := ports.PortRanges()
+	byRange := make(map[network.PortRange]state.PortRange, len(portRanges))
+	keys := make([]network.PortRange, len(portRanges))
+	for i, portRange := range portRanges {
+		keys[i] = network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		byRange[keys[i]] = portRange
+	}
+	network.SortPortRanges(keys)
+	result := make([]params.MachinePortRange, len(keys))
+	for i, key := range keys {
+		portRange := byRange[key]
+		result[i] = params.MachinePortRange{
+			UnitTag:   names.NewUnitTag(portRange.UnitName).String(),
+			PortRange: params.FromNetworkPortRange(key),
+			Endpoint:  portRange.Endpoint,
+		}
+	}
+	return result
+}
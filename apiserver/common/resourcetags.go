@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// MachineInstanceTags returns the tags to set on the cloud instance of
+// the given machine: the model's resource tags, overlaid with those of
+// the applications whose principal units are deployed to the machine,
+// and the tags Juju uses to identify the model, controller and units.
+// Where applications disagree on a tag, the application whose name
+// sorts last wins.
+func MachineInstanceTags(
+	m *state.Machine,
+	modelConfig *config.Config,
+	controllerUUID string,
+	getApplication func(string) (*state.Application, error),
+) (map[string]string, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames := make([]string, 0, len(units))
+	applicationNames := make(map[string]bool)
+	for _, unit := range units {
+		if !unit.IsPrincipal() {
+			continue
+		}
+		unitNames = append(unitNames, unit.Name())
+		applicationNames[unit.ApplicationName()] = true
+	}
+	sort.Strings(unitNames)
+
+	taggers := []tags.ResourceTagger{modelConfig}
+	for _, name := range sortedKeys(applicationNames) {
+		application, err := getApplication(name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		taggers = append(taggers, application)
+	}
+
+	var jobs []multiwatcher.MachineJob
+	for _, job := range m.Jobs() {
+		jobs = append(jobs, job.ToParams())
+	}
+	machineTags := instancecfg.InstanceTags(
+		modelConfig.UUID(), controllerUUID, mergedTagger(taggers), jobs,
+	)
+	if len(unitNames) > 0 {
+		machineTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
+	}
+	return machineTags, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergedTagger is a tags.ResourceTagger whose tags are those of each
+// of its taggers in turn, with later taggers taking precedence.
+type mergedTagger []tags.ResourceTagger
+
+// ResourceTags is part of the tags.ResourceTagger interface.
+func (m mergedTagger) ResourceTags() (map[string]string, bool) {
+	result := make(map[string]string)
+	for _, tagger := range m {
+		resourceTags, ok := tagger.ResourceTags()
+		if !ok {
+			continue
+		}
+		for k, v := range resourceTags {
+			result[k] = v
+		}
+	}
+	return result, len(result) > 0
+}
@@ -9,18 +9,21 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 )
 
 // FilesystemParams returns the parameters for creating or destroying the
-// given filesystem.
+// given filesystem. The resource tags of ownerTagger, which may be nil,
+// take precedence over the model's; see StorageOwnerTagger.
 func FilesystemParams(
 	f state.Filesystem,
 	storageInstance state.StorageInstance,
 	modelUUID, controllerUUID string,
 	environConfig *config.Config,
+	ownerTagger tags.ResourceTagger,
 	poolManager poolmanager.PoolManager,
 	registry storage.ProviderRegistry,
 ) (params.FilesystemParams, error) {
@@ -39,7 +42,7 @@ func FilesystemParams(
 		size = filesystemInfo.Size
 	}
 
-	filesystemTags, err := storageTags(storageInstance, modelUUID, controllerUUID, environConfig)
+	filesystemTags, err := StorageTags(storageInstance, modelUUID, controllerUUID, environConfig, ownerTagger)
 	if err != nil {
 		return params.FilesystemParams{}, errors.Annotate(err, "computing storage tags")
 	}
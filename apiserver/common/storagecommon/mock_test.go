@@ -142,6 +142,12 @@ func (pm *fakePoolManager) Get(name string) (*storage.Config, error) {
 	return nil, errors.NotFoundf("pool")
 }
 
+type fakeResourceTagger map[string]string
+
+func (t fakeResourceTagger) ResourceTags() (map[string]string, bool) {
+	return t, len(t) > 0
+}
+
 type nopSyncStarter struct{}
 
 func (nopSyncStarter) StartSync() {}
@@ -265,17 +265,24 @@ func MaybeAssignedStorageInstance(
 	return nil, errors.Trace(err)
 }
 
-// storageTags returns the tags that should be set on a volume or filesystem,
-// if the provider supports them.
-func storageTags(
+// StorageTags returns the tags that should be set on a volume or filesystem,
+// if the provider supports them. Tags from later taggers take precedence
+// over those from earlier ones, and nil taggers are ignored.
+func StorageTags(
 	storageInstance state.StorageInstance,
 	modelUUID, controllerUUID string,
-	tagger tags.ResourceTagger,
+	taggers ...tags.ResourceTagger,
 ) (map[string]string, error) {
+	resourceTaggers := make([]tags.ResourceTagger, 0, len(taggers))
+	for _, tagger := range taggers {
+		if tagger != nil {
+			resourceTaggers = append(resourceTaggers, tagger)
+		}
+	}
 	storageTags := tags.ResourceTags(
 		names.NewModelTag(modelUUID),
 		names.NewControllerTag(controllerUUID),
-		tagger,
+		resourceTaggers...,
 	)
 	if storageInstance != nil {
 		storageTags[tags.JujuStorageInstance] = storageInstance.Tag().Id()
@@ -285,3 +292,40 @@ func storageTags(
 	}
 	return storageTags, nil
 }
+
+// StorageOwnerTagger returns the application that owns the given storage
+// instance, directly or through one of its units, so that the resource
+// tags of the application can be set on the storage. It returns nil if
+// the storage instance is nil or has no owner.
+func StorageOwnerTagger(
+	storageInstance state.StorageInstance,
+	getApplication func(string) (*state.Application, error),
+) (tags.ResourceTagger, error) {
+	if storageInstance == nil {
+		return nil, nil
+	}
+	owner, ok := storageInstance.Owner()
+	if !ok {
+		return nil, nil
+	}
+	var applicationName string
+	switch owner := owner.(type) {
+	case names.ApplicationTag:
+		applicationName = owner.Id()
+	case names.UnitTag:
+		var err error
+		applicationName, err = names.UnitApplication(owner.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, nil
+	}
+	application, err := getApplication(applicationName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -26,12 +27,14 @@ func IsVolumeAlreadyProvisioned(err error) bool {
 }
 
 // VolumeParams returns the parameters for creating or destroying
-// the given volume.
+// the given volume. The resource tags of ownerTagger, which may be
+// nil, take precedence over the model's; see StorageOwnerTagger.
 func VolumeParams(
 	v state.Volume,
 	storageInstance state.StorageInstance,
 	modelUUID, controllerUUID string,
 	environConfig *config.Config,
+	ownerTagger tags.ResourceTagger,
 	poolManager poolmanager.PoolManager,
 	registry storage.ProviderRegistry,
 ) (params.VolumeParams, error) {
@@ -50,7 +53,7 @@ func VolumeParams(
 		size = volumeInfo.Size
 	}
 
-	volumeTags, err := storageTags(storageInstance, modelUUID, controllerUUID, environConfig)
+	volumeTags, err := StorageTags(storageInstance, modelUUID, controllerUUID, environConfig, ownerTagger)
 	if err != nil {
 		return params.VolumeParams{}, errors.Annotate(err, "computing storage tags")
 	}
@@ -44,6 +44,7 @@ func (*volumesSuite) testVolumeParams(c *gc.C, volumeParams *state.VolumeParams,
 		testing.CustomModelConfig(c, testing.Attrs{
 			"resource-tags": "a=b c=",
 		}),
+		nil, // owner tagger
 		&fakePoolManager{},
 		provider.CommonStorageProviders(),
 	)
@@ -72,7 +73,10 @@ func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 		&fakeStorageInstance{tag: storageTag, owner: unitTag},
 		testing.ModelTag.Id(),
 		testing.ControllerTag.Id(),
-		testing.CustomModelConfig(c, nil),
+		testing.CustomModelConfig(c, testing.Attrs{
+			"resource-tags": "team=ops cost-centre=42",
+		}),
+		fakeResourceTagger{"team": "db"},
 		&fakePoolManager{},
 		provider.CommonStorageProviders(),
 	)
@@ -86,6 +90,8 @@ func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 			tags.JujuModel:           testing.ModelTag.Id(),
 			tags.JujuStorageInstance: "mystore/0",
 			tags.JujuStorageOwner:    "mysql/123",
+			"team":                   "db",
+			"cost-centre":            "42",
 		},
 	})
 }
@@ -39,11 +39,13 @@ type Controller interface {
 	HostedModelConfigs() (params.HostedModelConfigsResults, error)
 	GetControllerAccess(params.Entities) (params.UserAccessResults, error)
 	ControllerConfig() (params.ControllerConfigResult, error)
+	ConfigSet(params.ControllerConfigSet) error
 	ListBlockedModels() (params.ModelBlockInfoList, error)
 	RemoveBlocks(args params.RemoveBlocksArgs) error
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
+	MigrationPrechecks(params.InitiateMigrationArgs) (params.MigrationPrecheckResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
 }
 
@@ -288,6 +290,15 @@ func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 	return errors.Trace(s.state.RemoveAllBlocksForController())
 }
 
+// ConfigSet changes the value of the controller configuration
+// attributes that may be changed after bootstrap.
+func (s *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
+	if err := s.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.state.UpdateControllerConfig(args.Config, args.Remove))
+}
+
 // WatchAllModels starts watching events for all models in the
 // controller. The returned AllWatcherId should be used with Next on the
 // AllModelWatcher endpoint to receive deltas.
@@ -387,36 +398,93 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationModelAndTarget(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Close()
 
-	// Ensure the model exists.
-	if _, err := c.state.GetModel(modelTag); err != nil {
-		return "", errors.Annotate(err, "unable to read model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState, &targetInfo); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.state.ForModel(modelTag)
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// MigrationPrechecks runs the source and target prechecks for one or
+// more model migrations without starting them, reporting every
+// problem which would prevent each migration.
+func (c *ControllerAPI) MigrationPrechecks(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		blockers, err := c.precheckOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Blockers = blockers
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) precheckOneMigration(spec params.MigrationSpec) ([]string, error) {
+	hostedState, targetInfo, err := c.migrationModelAndTarget(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer hostedState.Close()
+	blockers, err := runMigrationPrecheckBlockers(hostedState, &targetInfo)
+	return blockers, errors.Trace(err)
+}
+
+// migrationModelAndTarget returns the state for the model to be
+// migrated and the target details from the spec. The caller is
+// responsible for closing the returned state.
+func (c *ControllerAPI) migrationModelAndTarget(spec params.MigrationSpec) (*state.State, coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, empty, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, empty, errors.Annotate(err, "unable to read model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
 	targetInfo := coremigration.TargetInfo{
@@ -428,20 +496,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:     macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState, &targetInfo); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.state.ForModel(modelTag)
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, empty, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -494,6 +553,9 @@ var runMigrationPrechecks = func(st *state.State, targetInfo *coremigration.Targ
 	if err := migration.SourcePrecheck(backend); err != nil {
 		return errors.Annotate(err, "source prechecks failed")
 	}
+	if err := checkModelExport(st); err != nil {
+		return errors.Annotate(err, "source prechecks failed")
+	}
 
 	// Check target controller.
 	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
@@ -506,21 +568,104 @@ var runMigrationPrechecks = func(st *state.State, targetInfo *coremigration.Targ
 		return errors.Trace(err)
 	}
 	client := migrationtarget.NewClient(conn)
-	if targetInfo.CACert == "" {
-		targetInfo.CACert, err = client.CACert()
-		if err != nil {
-			if !params.IsCodeNotImplemented(err) {
-				return errors.Annotatef(err, "cannot retrieve CA certificate")
-			}
-			// If the call's not implemented, it indicates an earlier version
-			// of the controller, which we can't migrate to.
-			return errors.New("controller API version is too old")
-		}
+	if err := ensureTargetCACert(client, targetInfo); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkTargetImportsExtras(st, client); err != nil {
+		return errors.Annotate(err, "target prechecks failed")
 	}
 	err = client.Prechecks(modelInfo)
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationPrecheckBlockers runs the source and target prechecks
+// on the migration, returning every problem found rather than
+// stopping at the first.
+var runMigrationPrecheckBlockers = func(st *state.State, targetInfo *coremigration.TargetInfo) ([]string, error) {
+	var blockers []string
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	for _, err := range migration.SourcePrecheckAll(backend) {
+		blockers = append(blockers, "source: "+err.Error())
+	}
+	if err := checkModelExport(st); err != nil {
+		blockers = append(blockers, "source: "+err.Error())
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	if err := ensureTargetCACert(client, targetInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkTargetImportsExtras(st, client); err != nil {
+		blockers = append(blockers, "target: "+err.Error())
+	}
+	targetBlockers, err := client.PrecheckBlockers(modelInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "running target prechecks")
+	}
+	for _, blocker := range targetBlockers {
+		blockers = append(blockers, "target: "+blocker)
+	}
+	return blockers, nil
+}
+
+// checkModelExport returns an error if the model cannot be exported,
+// which would otherwise only be found once the migration has begun.
+func checkModelExport(st *state.State) error {
+	if _, err := st.Export(); err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+	return nil
+}
+
+// checkTargetImportsExtras returns an error if the model has parts
+// that the model description has no place for, such as the expose
+// settings of its applications' endpoints, and the target controller
+// is too old to import them.
+func checkTargetImportsExtras(st *state.State, client *migrationtarget.Client) error {
+	extras, err := st.ExportExtras()
+	if err != nil {
+		return errors.Annotate(err, "exporting model extras")
+	}
+	if extras != nil && !client.SupportsExtras() {
+		return errors.New("controller is too old to import settings the model description cannot hold, such as endpoint expose settings")
+	}
+	return nil
+}
+
+// ensureTargetCACert fills in the target controller's CA certificate
+// in targetInfo if it wasn't supplied.
+func ensureTargetCACert(client *migrationtarget.Client, targetInfo *coremigration.TargetInfo) error {
+	if targetInfo.CACert != "" {
+		return nil
+	}
+	var err error
+	targetInfo.CACert, err = client.CACert()
+	if err != nil {
+		if !params.IsCodeNotImplemented(err) {
+			return errors.Annotatef(err, "cannot retrieve CA certificate")
+		}
+		// If the call's not implemented, it indicates an earlier version
+		// of the controller, which we can't migrate to.
+		return errors.New("controller API version is too old")
+	}
+	return nil
+}
+
 func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -547,12 +692,24 @@ func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	}
 	controllerVersion, _ := controllerConfig.AgentVersion()
 
+	modelUsers, err := model.Users()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	users := make([]names.UserTag, len(modelUsers))
+	for i, user := range modelUsers {
+		users[i] = user.UserTag
+	}
+
 	return coremigration.ModelInfo{
 		UUID:                   model.UUID(),
 		Name:                   model.Name(),
 		Owner:                  model.Owner(),
 		AgentVersion:           agentVersion,
 		ControllerAgentVersion: controllerVersion,
+		Cloud:                  model.Cloud(),
+		CloudRegion:            model.CloudRegion(),
+		Users:                  users,
 	}, nil
 }
 
@@ -295,6 +295,45 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestConfigSet(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"api-allow": "10.0.0.0/8",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.controller.ConfigSet(params.ControllerConfigSet{Remove: []string{"api-allow"}})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+}
+
+func (s *controllerSuite) TestConfigSetRejectsImmutable(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"state-port": 1234,
+	}})
+	c.Assert(err, gc.ErrorMatches, `can't change "state-port" after bootstrap`)
+}
+
+func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
+	anAuthoriser := s.authorizer
+	anAuthoriser.Tag = names.NewUserTag("someoneelse")
+	endpoint, err := controller.NewControllerAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      anAuthoriser,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = endpoint.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"api-allow": "10.0.0.0/8",
+	}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -541,6 +580,61 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationPrechecks(c *gc.C) {
+	st1 := s.Factory.MakeModel(c, nil)
+	defer st1.Close()
+
+	controller.SetPrecheckBlockers(s, []string{
+		"source: machine 0 is dying",
+		"target: user bob not found in target controller",
+	}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st1.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: "not-a-tag",
+		}},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, st1.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Blockers, jc.DeepEquals, []string{
+		"source: machine 0 is dying",
+		"target: user bob not found in target controller",
+	})
+	c.Check(out.Results[1].Error, gc.ErrorMatches, `model tag: "not-a-tag" is not a valid tag`)
+
+	// No migration should have been started.
+	active, err := st1.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationPrechecksRequiresAdmin(c *gc.C) {
+	anAuthoriser := s.authorizer
+	anAuthoriser.Tag = names.NewUserTag("someoneelse")
+	endpoint, err := controller.NewControllerAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      anAuthoriser,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.MigrationPrechecks(params.InitiateMigrationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -17,3 +17,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetPrecheckBlockers(p patcher, blockers []string, err error) {
+	p.PatchValue(&runMigrationPrecheckBlockers, func(*state.State, *migration.TargetInfo) ([]string, error) {
+		return blockers, err
+	})
+}
@@ -114,6 +114,11 @@ type Authorizer interface {
 	// target by the given user.
 	UserHasPermission(user names.UserTag, operation permission.Access, target names.Tag) (bool, error)
 
+	// HasCapability reports whether the authenticated entity may
+	// exercise the given capability on the target, either through
+	// its access level or through a role it has been granted.
+	HasCapability(capability permission.Capability, target names.Tag) (bool, error)
+
 	// ConnectedModel returns the UUID of the model to which the API
 	// connection was made.
 	ConnectedModel() string
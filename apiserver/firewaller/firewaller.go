@@ -11,7 +11,6 @@ import (
 	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/watcher"
@@ -155,6 +154,80 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 	return "", nil, watcher.EnsureErr(watch)
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the subnets in the model. The CIDRs an application is exposed to
+// through spaces change along with their subnets.
+func (f *FirewallerAPI) WatchSubnets() (params.StringsWatchResult, error) {
+	watch := f.st.WatchSubnets(nil)
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// WatchMachinesProvisioning returns a NotifyWatcher that notifies
+// when machines in the model are provisioned. The firewall rules that
+// apply to the whole model may not exist until the first one is.
+func (f *FirewallerAPI) WatchMachinesProvisioning() (params.NotifyWatchResult, error) {
+	watch := f.st.WatchMachinesProvisioning()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: f.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// WatchModelFirewallRules returns a NotifyWatcher that notifies of
+// changes to the configuration determining the firewall rules that
+// apply to the whole model: the model's ssh-allow setting and the
+// controller's api-allow setting.
+func (f *FirewallerAPI) WatchModelFirewallRules() (params.NotifyWatchResult, error) {
+	watch := common.NewMultiNotifyWatcher(
+		f.st.WatchForModelConfigChanges(),
+		f.st.WatchControllerConfig(),
+	)
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: f.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// ModelFirewallRules returns the firewall rules that apply to the
+// whole model: SSH access from the model's ssh-allow CIDRs, and
+// access to the API port from the controller's api-allow CIDRs.
+func (f *FirewallerAPI) ModelFirewallRules() (params.IngressRulesResult, error) {
+	modelConfig, err := f.st.ModelConfig()
+	if err != nil {
+		return params.IngressRulesResult{}, errors.Trace(err)
+	}
+	controllerConfig, err := f.st.ControllerConfig()
+	if err != nil {
+		return params.IngressRulesResult{}, errors.Trace(err)
+	}
+	var result params.IngressRulesResult
+	if sshAllow := modelConfig.SSHAllow(); len(sshAllow) > 0 {
+		result.Rules = append(result.Rules, params.IngressRule{
+			PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+			SourceCIDRs: sshAllow,
+		})
+	}
+	apiPort := controllerConfig.APIPort()
+	result.Rules = append(result.Rules, params.IngressRule{
+		PortRange:   params.PortRange{FromPort: apiPort, ToPort: apiPort, Protocol: "tcp"},
+		SourceCIDRs: controllerConfig.APIAllow(),
+	})
+	return result, nil
+}
+
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
 // them.
@@ -191,21 +264,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 			continue
 		}
 		if ports != nil {
-			portRangeMap := ports.AllPortRanges()
-			var portRanges []network.PortRange
-			for portRange := range portRangeMap {
-				portRanges = append(portRanges, portRange)
-			}
-			network.SortPortRanges(portRanges)
-
-			for _, portRange := range portRanges {
-				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
-				result.Results[i].Ports = append(result.Results[i].Ports,
-					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
-					})
-			}
+			result.Results[i].Ports = common.MachinePortRanges(ports)
 		}
 	}
 	return result, nil
@@ -258,6 +317,42 @@ func (f *FirewallerAPI) GetMachineActiveSubnets(args params.Entities) (params.St
 	return result, nil
 }
 
+// GetMachineEgressRules returns the egress rules allowed by the
+// units on each given machine.
+func (f *FirewallerAPI) GetMachineEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		machineTag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, machineTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules, err := machine.EgressRules()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, rule := range rules {
+			result.Results[i].Rules = append(result.Results[i].Rules, params.EgressRule{
+				PortRange:        params.FromNetworkPortRange(rule.PortRange),
+				DestinationCIDRs: rule.DestinationCIDRs,
+			})
+		}
+	}
+	return result, nil
+}
+
 // GetExposed returns the exposed flag value for each given application.
 func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, error) {
 	result := params.BoolResults{
@@ -282,6 +377,72 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposeInfo returns the exposed flag value and the expose settings
+// of the endpoints of each given application. The spaces an endpoint
+// is exposed to are resolved to the CIDRs of their subnets.
+func (f *FirewallerAPI) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		exposed, err := f.exposedEndpoints(application)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Exposed = application.IsExposed()
+		result.Results[i].ExposedEndpoints = exposed
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPI) exposedEndpoints(application *state.Application) (map[string]params.ExposedEndpoint, error) {
+	exposedEndpoints := application.ExposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.ExposedEndpoint, len(exposedEndpoints))
+	for name, ep := range exposedEndpoints {
+		cidrs := append([]string(nil), ep.ExposeToCIDRs...)
+		for _, spaceName := range ep.ExposeToSpaces {
+			space, err := f.st.Space(spaceName)
+			if errors.IsNotFound(err) {
+				// A space that has been removed no longer
+				// contributes any CIDRs.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			subnets, err := space.Subnets()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, subnet := range subnets {
+				cidrs = append(cidrs, subnet.CIDR())
+			}
+		}
+		result[name] = params.ExposedEndpoint{
+			ExposeToSpaces: ep.ExposeToSpaces,
+			ExposeToCIDRs:  cidrs,
+		}
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -77,6 +77,43 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSpace("admin", "", []string{"10.20.30.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {
+			ExposeToSpaces: []string{"admin"},
+			ExposeToCIDRs:  []string{"192.168.1.0/24"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"url": {
+						ExposeToSpaces: []string{"admin"},
+						ExposeToCIDRs:  []string{"192.168.1.0/24", "10.20.30.0/24"},
+					},
+				},
+			},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
@@ -186,6 +223,29 @@ func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 
 }
 
+func (s *firewallerSuite) TestGetMachinePortsOnEndpoint(c *gc.C) {
+	err := s.units[0].OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPorts("tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.firewaller.GetMachinePorts(params.MachinePortsParams{
+		Params: []params.MachinePorts{{MachineTag: s.machines[0].Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit0Tag := s.units[0].Tag().String()
+	c.Assert(result.Results, jc.DeepEquals, []params.MachinePortsResult{{
+		Ports: []params.MachinePortRange{{
+			UnitTag:   unit0Tag,
+			PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			Endpoint:  "url",
+		}, {
+			UnitTag:   unit0Tag,
+			PortRange: params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+		}},
+	}})
+}
+
 func (s *firewallerSuite) TestGetMachineActiveSubnets(c *gc.C) {
 	s.openPorts(c)
 
@@ -217,3 +277,113 @@ func (s *firewallerSuite) TestGetMachineActiveSubnets(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetMachineEgressRules(c *gc.C) {
+	err := s.units[0].OpenEgress("tcp", 443, 443, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: s.units[0].Tag().String()},
+	}}
+	result, err := s.firewaller.GetMachineEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/8"},
+			}}},
+			{},
+			{Error: apiservertesting.ServerError(`"unit-wordpress-0" is not a valid machine tag`)},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestModelFirewallRules(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"ssh-allow": "10.0.0.0/8,192.168.0.0/16",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	controllerConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	apiPort := controllerConfig.APIPort()
+
+	result, err := s.firewaller.ModelFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IngressRulesResult{
+		Rules: []params.IngressRule{{
+			PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+			SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		}, {
+			PortRange:   params.PortRange{FromPort: apiPort, ToPort: apiPort, Protocol: "tcp"},
+			SourceCIDRs: []string{"0.0.0.0/0", "::/0"},
+		}},
+	})
+}
+
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.0.0/24")
+	wc.AssertNoChange()
+}
+
+func (s *firewallerSuite) TestWatchMachinesProvisioning(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.firewaller.WatchMachinesProvisioning()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProvisioned("i-new", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *firewallerSuite) TestWatchModelFirewallRules(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.firewaller.WatchModelFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"api-allow": "10.0.0.0/8",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,417 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the API used by the host
+// firewaller worker, which programs the firewall of a machine
+// from the ports opened on it when the model's firewall-mode
+// is "host".
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// HostFirewallerAPI provides access to the HostFirewaller API facade.
+type HostFirewallerAPI struct {
+	*common.ModelWatcher
+
+	st            *state.State
+	resources     facade.Resources
+	accessMachine common.AuthFunc
+}
+
+// NewHostFirewallerAPI creates a new server-side HostFirewallerAPI facade.
+func NewHostFirewallerAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*HostFirewallerAPI, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &HostFirewallerAPI{
+		ModelWatcher:  common.NewModelWatcher(st, resources, authorizer),
+		st:            st,
+		resources:     resources,
+		accessMachine: authorizer.AuthOwner,
+	}, nil
+}
+
+// WatchIngressRules returns a NotifyWatcher for each given machine
+// that triggers when the ingress rules of the machine may have
+// changed: when ports are opened or closed on it, when applications
+// are exposed or unexposed, when machines in the model are added or
+// removed or their addresses change, when units enter or leave the
+// scope of relations or change their relation settings, or when the
+// model's ssh-allow
+// setting or, for controller machines, the controller's api-allow
+// setting or set of controller machines changes.
+func (api *HostFirewallerAPI) WatchIngressRules(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.getMachine(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watchers := []state.NotifyWatcher{
+			machine.WatchOpenedPorts(),
+			api.st.WatchApplicationsExposure(),
+			api.st.WatchMachinesAddresses(),
+			api.st.WatchRelationScopes(),
+			api.st.WatchRelationSettings(),
+			api.st.WatchForModelConfigChanges(),
+		}
+		if machine.IsManager() {
+			watchers = append(watchers,
+				api.st.WatchControllerConfig(),
+				api.st.WatchControllerInfo(),
+			)
+		}
+		watch := common.NewMultiNotifyWatcher(watchers...)
+		if _, ok := <-watch.Changes(); ok {
+			result.Results[i].NotifyWatcherId = api.resources.Register(watch)
+		} else {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return result, nil
+}
+
+// IngressRules returns the ingress rules for each given machine: SSH
+// access from the model's ssh-allow CIDRs, any access from the other
+// machines in the model, access to the API port of controller
+// machines from the controller's api-allow CIDRs, access to the mongo
+// and API ports of controller machines from the other controller
+// machines, and access to the ports opened by units of exposed
+// applications from the sources their applications are exposed to
+// and, as for applications offered to other models, from the units
+// of the applications related to them from other models.
+func (api *HostFirewallerAPI) IngressRules(args params.Entities) (params.IngressRulesResults, error) {
+	result := params.IngressRulesResults{
+		Results: make([]params.IngressRulesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.getMachine(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules, err := api.machineIngressRules(machine)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Rules = make([]params.IngressRule, len(rules))
+		for j, rule := range rules {
+			result.Results[i].Rules[j] = params.IngressRule{
+				PortRange:   params.FromNetworkPortRange(rule.PortRange),
+				SourceCIDRs: rule.SourceCIDRs,
+			}
+		}
+	}
+	return result, nil
+}
+
+func (api *HostFirewallerAPI) getMachine(tagString string) (*state.Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	if !api.accessMachine(tag) {
+		return nil, common.ErrPerm
+	}
+	return api.st.Machine(tag.Id())
+}
+
+func (api *HostFirewallerAPI) machineIngressRules(machine *state.Machine) ([]network.IngressRule, error) {
+	modelConfig, err := api.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []network.IngressRule
+	if sshAllow := modelConfig.SSHAllow(); len(sshAllow) > 0 {
+		rule, err := network.NewIngressRule("tcp", 22, 22, sshAllow...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	if machine.IsManager() {
+		controllerConfig, err := api.st.ControllerConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		apiPort := controllerConfig.APIPort()
+		rule, err := network.NewIngressRule("tcp", apiPort, apiPort, controllerConfig.APIAllow()...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+
+		// Controllers replicate the database and
+		// forward API requests between each other.
+		peerCIDRs, err := api.controllerCIDRs()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !peerCIDRs.IsEmpty() {
+			for _, port := range []int{controllerConfig.StatePort(), apiPort} {
+				rule, err := network.NewIngressRule("tcp", port, port, peerCIDRs.SortedValues()...)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	// Related units connect to each other on ports that need not be
+	// opened, so machines in the model may reach each other freely.
+	modelCIDRs, err := api.machinesCIDRs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !modelCIDRs.IsEmpty() {
+		for _, portRange := range []network.PortRange{
+			{Protocol: "icmp", FromPort: -1, ToPort: -1},
+			{Protocol: "tcp", FromPort: 1, ToPort: 65535},
+			{Protocol: "udp", FromPort: 1, ToPort: 65535},
+		} {
+			rule, err := network.NewIngressRule(
+				portRange.Protocol,
+				portRange.FromPort,
+				portRange.ToPort,
+				modelCIDRs.SortedValues()...)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	allPorts, err := machine.AllPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The sources each application is reachable from, by name.
+	exposed := make(map[string]set.Strings)
+	for _, ports := range allPorts {
+		for portRange, unitName := range ports.AllPortRanges() {
+			appName, err := names.UnitApplication(unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			cidrs, ok := exposed[appName]
+			if !ok {
+				if cidrs, err = api.exposedCIDRs(appName); err != nil {
+					return nil, errors.Trace(err)
+				}
+				relationCIDRs, err := api.relationIngressCIDRs(appName)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				cidrs = cidrs.Union(relationCIDRs)
+				exposed[appName] = cidrs
+			}
+			if cidrs.IsEmpty() {
+				continue
+			}
+			rule, err := network.NewIngressRule(
+				portRange.Protocol,
+				portRange.FromPort,
+				portRange.ToPort,
+				cidrs.SortedValues()...)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			rules = append(rules, rule)
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// exposedCIDRs returns the CIDRs from which the ports opened by units
+// of the named application may be reached, which is none unless the
+// application is exposed.
+func (api *HostFirewallerAPI) exposedCIDRs(appName string) (set.Strings, error) {
+	cidrs := set.NewStrings()
+	application, err := api.st.Application(appName)
+	if errors.IsNotFound(err) {
+		return cidrs, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !application.IsExposed() {
+		return cidrs, nil
+	}
+	exposedEndpoints := application.ExposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return set.NewStrings("0.0.0.0/0", "::/0"), nil
+	}
+	for _, ep := range exposedEndpoints {
+		cidrs = cidrs.Union(set.NewStrings(ep.ExposeToCIDRs...))
+		for _, spaceName := range ep.ExposeToSpaces {
+			space, err := api.st.Space(spaceName)
+			if errors.IsNotFound(err) {
+				// A space that has been removed no longer
+				// contributes any CIDRs.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			subnets, err := space.Subnets()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, subnet := range subnets {
+				cidrs.Add(subnet.CIDR())
+			}
+		}
+	}
+	return cidrs, nil
+}
+
+// relationIngressCIDRs returns single address CIDRs for the addresses
+// of the units of applications in other models that are in scope in
+// relations in which the named application provides an endpoint, as
+// those units connect to the ports opened by its units.
+func (api *HostFirewallerAPI) relationIngressCIDRs(appName string) (set.Strings, error) {
+	cidrs := set.NewStrings()
+	application, err := api.st.Application(appName)
+	if errors.IsNotFound(err) {
+		return cidrs, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// As for the firewaller, only endpoints with the provider
+		// role are assumed to accept connections.
+		if ep.Role != charm.RoleProvider {
+			continue
+		}
+		related, err := rel.RelatedEndpoints(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, relatedEp := range related {
+			_, err := api.st.RemoteApplication(relatedEp.ApplicationName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			unitNames, err := rel.UnitsInScope(relatedEp.ApplicationName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, unitName := range unitNames {
+				address, err := remoteUnitAddress(rel, unitName)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if address != "" {
+					cidrs = cidrs.Union(addressCIDRs([]network.Address{network.NewAddress(address)}))
+				}
+			}
+		}
+	}
+	return cidrs, nil
+}
+
+// remoteUnitAddress returns the address the named unit of a remote
+// application connects from, as recorded in its relation settings,
+// or "" if it has none.
+func remoteUnitAddress(rel *state.Relation, unitName string) (string, error) {
+	ru, err := rel.RemoteUnit(unitName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	settings, err := ru.Settings()
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, key := range []string{"ingress-address", "private-address"} {
+		if address, ok := settings.Get(key); ok {
+			if address, ok := address.(string); ok && address != "" {
+				return address, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// machinesCIDRs returns single address CIDRs for the addresses
+// of all the machines in the model.
+func (api *HostFirewallerAPI) machinesCIDRs() (set.Strings, error) {
+	machines, err := api.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := set.NewStrings()
+	for _, machine := range machines {
+		cidrs = cidrs.Union(addressCIDRs(machine.Addresses()))
+	}
+	return cidrs, nil
+}
+
+// controllerCIDRs returns single address CIDRs for the addresses
+// of the controller machines.
+func (api *HostFirewallerAPI) controllerCIDRs() (set.Strings, error) {
+	info, err := api.st.ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := set.NewStrings()
+	for _, id := range info.MachineIds {
+		machine, err := api.st.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = cidrs.Union(addressCIDRs(machine.Addresses()))
+	}
+	return cidrs, nil
+}
+
+// addressCIDRs returns a CIDR matching only the address for each of
+// the given addresses that other machines could connect from.
+func addressCIDRs(addresses []network.Address) set.Strings {
+	cidrs := set.NewStrings()
+	for _, addr := range addresses {
+		if addr.Scope == network.ScopeMachineLocal || addr.Scope == network.ScopeLinkLocal {
+			continue
+		}
+		switch addr.Type {
+		case network.IPv4Address:
+			cidrs.Add(addr.Value + "/32")
+		case network.IPv6Address:
+			cidrs.Add(addr.Value + "/128")
+		}
+	}
+	return cidrs
+}
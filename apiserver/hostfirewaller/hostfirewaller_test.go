@@ -0,0 +1,265 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type hostFirewallerSuite struct {
+	testing.JujuConnSuite
+
+	controller  *state.Machine
+	machine     *state.Machine
+	application *state.Application
+	unit        *state.Unit
+	authorizer  apiservertesting.FakeAuthorizer
+	resources   *common.Resources
+	api         *hostfirewaller.HostFirewallerAPI
+}
+
+var _ = gc.Suite(&hostFirewallerSuite{})
+
+func (s *hostFirewallerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	var err error
+	s.controller, err = s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.application = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.unit, err = s.application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.machine.Tag(),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+
+	s.api, err = hostfirewaller.NewHostFirewallerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *hostFirewallerSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	anAuthorizer := s.authorizer
+	anAuthorizer.Tag = s.unit.Tag()
+	api, err := hostfirewaller.NewHostFirewallerAPI(s.State, s.resources, anAuthorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(api, gc.IsNil)
+}
+
+func (s *hostFirewallerSuite) TestIngressRules(c *gc.C) {
+	err := s.unit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machine.Tag().String()},
+		{Tag: "machine-42"},
+		{Tag: "unit-wordpress-0"},
+	}}
+	sshRule := params.IngressRule{
+		PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+		SourceCIDRs: []string{"0.0.0.0/0", "::/0"},
+	}
+	result, err := s.api.IngressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IngressRulesResults{
+		Results: []params.IngressRulesResult{
+			{Rules: []params.IngressRule{sshRule}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.api.IngressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0], jc.DeepEquals, params.IngressRulesResult{
+		Rules: []params.IngressRule{sshRule, {
+			PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			SourceCIDRs: []string{"10.0.0.0/8"},
+		}},
+	})
+}
+
+func (s *hostFirewallerSuite) TestIngressRulesCrossModelRelation(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlUnit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlUnit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlUnit.OpenPort("tcp", 3306)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An application in another model consumes mysql.
+	_, err = s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:            "remote-wordpress",
+		SourceModel:     coretesting.ModelTag,
+		IsConsumerProxy: true,
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("mysql", "remote-wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.RemoteUnit("remote-wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"private-address": "203.0.113.5"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.machine.Tag().String()}}}
+	result, err := s.api.IngressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Rules, jc.DeepEquals, []params.IngressRule{{
+		PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+		SourceCIDRs: []string{"0.0.0.0/0", "::/0"},
+	}, {
+		PortRange:   params.PortRange{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+		SourceCIDRs: []string{"203.0.113.5/32"},
+	}})
+
+	// The ingress address takes precedence.
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("ingress-address", "198.51.100.7")
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.api.IngressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Rules[1].SourceCIDRs, jc.DeepEquals, []string{"198.51.100.7/32"})
+
+	err = ru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.api.IngressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Rules, gc.HasLen, 1)
+}
+
+func (s *hostFirewallerSuite) TestIngressRulesControllerAndRelatedMachines(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlUnit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlUnit.AssignToMachine(mysqlMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for machine, addr := range map[*state.Machine]string{
+		s.controller: "10.0.0.1",
+		s.machine:    "10.0.0.2",
+		mysqlMachine: "10.0.0.3",
+	} {
+		err := machine.SetProviderAddresses(network.NewAddress(addr))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	controllerConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	apiPort := controllerConfig.APIPort()
+	statePort := controllerConfig.StatePort()
+
+	modelCIDRs := []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.3/32"}
+	modelRules := []params.IngressRule{{
+		PortRange:   params.PortRange{FromPort: -1, ToPort: -1, Protocol: "icmp"},
+		SourceCIDRs: modelCIDRs,
+	}, {
+		PortRange:   params.PortRange{FromPort: 1, ToPort: 65535, Protocol: "tcp"},
+		SourceCIDRs: modelCIDRs,
+	}, {
+		PortRange:   params.PortRange{FromPort: 1, ToPort: 65535, Protocol: "udp"},
+		SourceCIDRs: modelCIDRs,
+	}}
+	sshRule := params.IngressRule{
+		PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+		SourceCIDRs: []string{"0.0.0.0/0", "::/0"},
+	}
+
+	result, err := s.api.IngressRules(params.Entities{Entities: []params.Entity{
+		{Tag: s.machine.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Rules, jc.SameContents, append(modelRules, sshRule))
+
+	authorizer := s.authorizer
+	authorizer.Tag = s.controller.Tag()
+	api, err := hostfirewaller.NewHostFirewallerAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.IngressRules(params.Entities{Entities: []params.Entity{
+		{Tag: s.controller.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Rules, jc.SameContents, append(modelRules, sshRule, params.IngressRule{
+		PortRange:   params.PortRange{FromPort: apiPort, ToPort: apiPort, Protocol: "tcp"},
+		SourceCIDRs: controllerConfig.APIAllow(),
+	}, params.IngressRule{
+		PortRange:   params.PortRange{FromPort: apiPort, ToPort: apiPort, Protocol: "tcp"},
+		SourceCIDRs: []string{"10.0.0.1/32"},
+	}, params.IngressRule{
+		PortRange:   params.PortRange{FromPort: statePort, ToPort: statePort, Protocol: "tcp"},
+		SourceCIDRs: []string{"10.0.0.1/32"},
+	}))
+}
+
+func (s *hostFirewallerSuite) TestWatchIngressRules(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machine.Tag().String()},
+		{Tag: "machine-42"},
+	}}
+	result, err := s.api.WatchIngressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.unit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
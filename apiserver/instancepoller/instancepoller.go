@@ -12,6 +12,8 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 )
@@ -239,3 +241,53 @@ func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params
 	}
 	return result, nil
 }
+
+// ArePreemptible returns whether each given entity runs on a spot or
+// preemptible instance, which the cloud may terminate at any time.
+// Only machine tags are accepted.
+func (a *InstancePollerAPI) ArePreemptible(args params.Entities) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Entities {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			var cons constraints.Value
+			cons, err = machine.Constraints()
+			if err == nil {
+				result.Results[i].Result = cons.HasInstanceLifecycle()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ReplaceInstances forgets the terminated cloud instance of each given
+// entity, so that the provisioner starts a replacement instance for
+// it. Only machine tags are accepted.
+func (a *InstancePollerAPI) ReplaceInstances(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Entities {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			var instanceId instance.Id
+			instanceId, err = machine.InstanceId()
+			if err == nil {
+				err = machine.ReplaceInstance(instanceId)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/juju/juju/apiserver/instancepoller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -709,6 +711,72 @@ func (s *InstancePollerSuite) TestAreManuallyProvisionedFailure(c *gc.C) {
 	s.st.CheckFindEntityCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestArePreemptibleSuccess(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{id: "1", constraints: constraints.MustParse("instance-lifecycle=spot")})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", constraints: constraints.MustParse("mem=4G")})
+
+	result, err := s.api.ArePreemptible(s.mixedEntities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: true},
+			{Result: false},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ServerError(`"application-unknown" is not a valid machine tag`)},
+			{Error: apiservertesting.ServerError(`"invalid-tag" is not a valid tag`)},
+			{Error: apiservertesting.ServerError(`"unit-missing-1" is not a valid machine tag`)},
+			{Error: apiservertesting.ServerError(`"" is not a valid tag`)},
+			{Error: apiservertesting.ServerError(`"42" is not a valid tag`)},
+		}},
+	)
+
+	s.st.CheckFindEntityCall(c, 0, "1")
+	s.st.CheckCall(c, 1, "Constraints")
+	s.st.CheckFindEntityCall(c, 2, "2")
+	s.st.CheckCall(c, 3, "Constraints")
+	s.st.CheckFindEntityCall(c, 4, "42")
+}
+
+func (s *InstancePollerSuite) TestReplaceInstancesSuccess(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceId: "i-1"})
+
+	result, err := s.api.ReplaceInstances(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+		{Tag: "machine-42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+		}},
+	)
+
+	s.st.CheckFindEntityCall(c, 0, "1")
+	s.st.CheckCall(c, 1, "InstanceId")
+	s.st.CheckCall(c, 2, "ReplaceInstance", instance.Id("i-1"))
+	s.st.CheckFindEntityCall(c, 3, "42")
+}
+
+func (s *InstancePollerSuite) TestReplaceInstancesFailure(c *gc.C) {
+	s.st.SetErrors(
+		nil,                // m1 := FindEntity("1")
+		nil,                // m1.InstanceId()
+		errors.New("FAIL"), // m1.ReplaceInstance()
+	)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceId: "i-1"})
+
+	result, err := s.api.ReplaceInstances(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ServerError("FAIL")},
+		}},
+	)
+}
+
 func statusInfo(st string) status.StatusInfo {
 	return status.StatusInfo{Status: status.Status(st)}
 }
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/instancepoller"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -212,6 +213,7 @@ type machineInfo struct {
 	providerAddresses []network.Address
 	life              state.Life
 	isManual          bool
+	constraints       constraints.Value
 }
 
 type mockMachine struct {
@@ -308,6 +310,28 @@ func (m *mockMachine) IsManual() (bool, error) {
 	return m.isManual, m.NextErr()
 }
 
+// Constraints implements StateMachine.
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Constraints")
+	return m.constraints, m.NextErr()
+}
+
+// ReplaceInstance implements StateMachine.
+func (m *mockMachine) ReplaceInstance(id instance.Id) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "ReplaceInstance", id)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.instanceId = ""
+	return nil
+}
+
 // Status implements StateMachine.
 func (m *mockMachine) Status() (status.StatusInfo, error) {
 	m.mu.Lock()
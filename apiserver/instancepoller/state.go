@@ -4,6 +4,7 @@
 package instancepoller
 
 import (
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -26,6 +27,8 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+	Constraints() (constraints.Value, error)
+	ReplaceInstance(instance.Id) error
 }
 
 type StateInterface interface {
@@ -0,0 +1,187 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// AdoptableInstances looks up each of the given cloud instances with
+// the provider, and returns its addresses if it can be adopted into the
+// model as a machine.
+func (mm *MachineManagerAPI) AdoptableInstances(args params.AdoptableInstancesArgs) (params.AdoptableInstanceResults, error) {
+	return adoptableInstances(mm, environs.GetEnviron, args)
+}
+
+// AdoptInstances records existing cloud instances as machines in the
+// model. Each instance must be known to the provider, and must not be
+// in use by another machine. The addresses reported by the provider
+// are recorded for the new machines.
+func (mm *MachineManagerAPI) AdoptInstances(args params.AddMachines) (params.AddMachinesResults, error) {
+	return adoptInstances(mm, environs.GetEnviron, args)
+}
+
+func adoptableInstances(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.AdoptableInstancesArgs,
+) (params.AdoptableInstanceResults, error) {
+	results := params.AdoptableInstanceResults{
+		Results: make([]params.AdoptableInstanceResult, len(args.InstanceIds)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	env, err := modelEnviron(mm, getEnviron)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, id := range args.InstanceIds {
+		addrs, err := mm.adoptableInstanceAddresses(env, instance.Id(id))
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Addresses = params.FromNetworkAddresses(addrs...)
+	}
+	return results, nil
+}
+
+func adoptInstances(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.AddMachines,
+) (params.AddMachinesResults, error) {
+	results := params.AddMachinesResults{
+		Machines: make([]params.AddMachinesResult, len(args.MachineParams)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	env, err := modelEnviron(mm, getEnviron)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, p := range args.MachineParams {
+		id, err := mm.adoptInstance(env, p)
+		results.Machines[i].Error = common.ServerError(err)
+		if err == nil {
+			results.Machines[i].Machine = id
+		}
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) adoptInstance(env environs.Environ, p params.AddMachineParams) (string, error) {
+	if p.InstanceId == "" {
+		return "", errors.BadRequestf("instance ID not specified")
+	}
+	if p.Nonce == "" {
+		return "", errors.BadRequestf("nonce not specified")
+	}
+	if p.ContainerType != "" || p.ParentId != "" || p.Placement != nil {
+		return "", errors.BadRequestf("cannot adopt instance %q into a container or with placement", p.InstanceId)
+	}
+	addrs, err := mm.adoptableInstanceAddresses(env, p.InstanceId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// The addresses reported by the provider take precedence over
+	// the ones the client found, as the provider knows about all of
+	// the instance's networks.
+	if len(addrs) > 0 {
+		p.Addrs = params.FromNetworkAddresses(addrs...)
+	}
+	m, err := mm.addOneMachine(p)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// The machine is added before the instance is given the model's
+	// tags and security groups, as the firewall groups of some
+	// providers are named after the machine's ID.
+	if err := mm.tagAdoptedInstance(env, m.Id(), p); err != nil {
+		mm.removeAdoptedMachine(m.Id())
+		return "", errors.Trace(err)
+	}
+	return m.Id(), nil
+}
+
+// tagAdoptedInstance tags the instance being adopted for the given
+// machine parameters as the model's provisioner would have, and gives
+// it the model's security groups.
+func (mm *MachineManagerAPI) tagAdoptedInstance(env environs.Environ, machineId string, p params.AddMachineParams) error {
+	model, err := mm.st.GetModel(mm.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	instanceTags := instancecfg.InstanceTags(
+		model.ModelTag().Id(), model.ControllerUUID(), env.Config(), p.Jobs,
+	)
+	return env.(environs.InstanceAdopter).AdoptInstance(p.InstanceId, machineId, instanceTags)
+}
+
+// removeAdoptedMachine removes the machine added for an instance that
+// could not be adopted, leaving the instance running.
+func (mm *MachineManagerAPI) removeAdoptedMachine(machineId string) {
+	m, err := mm.st.Machine(machineId)
+	if err == nil {
+		err = m.SetKeepInstance(true)
+	}
+	if err == nil {
+		err = m.ForceDestroy()
+	}
+	if err != nil {
+		logger.Warningf("cannot remove machine %s after failing to adopt its instance: %v", machineId, err)
+	}
+}
+
+// adoptableInstanceAddresses returns the addresses of the cloud instance
+// with the given ID, if the provider can adopt it and no machine in the
+// model uses it.
+func (mm *MachineManagerAPI) adoptableInstanceAddresses(env environs.Environ, id instance.Id) ([]network.Address, error) {
+	adopter, ok := env.(environs.InstanceAdopter)
+	if !ok {
+		return nil, errors.NotSupportedf("adopting cloud instances on this provider")
+	}
+	machines, err := mm.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		machineInstanceId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machineInstanceId == id {
+			return nil, errors.NewAlreadyExists(nil, fmt.Sprintf(
+				"instance %q is already in use by machine %s", id, m.Id(),
+			))
+		}
+	}
+	// The instance was not started for the model, so it must be
+	// looked up without the provider's filtering on the model.
+	inst, err := adopter.AdoptableInstance(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addrs, err := inst.Addresses()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get addresses of instance %q", id)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+func newMockAdoptEnviron(c *gc.C) *mockAdoptEnviron {
+	cfg, err := config.New(config.UseDefaults, dummy.SampleConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	return &mockAdoptEnviron{
+		cfg: cfg,
+		instances: map[instance.Id]instance.Instance{
+			"i-1": &mockInstance{
+				id:        "i-1",
+				addresses: network.NewAddresses("10.0.0.1", "54.0.0.1"),
+			},
+			"i-2": &mockInstance{id: "i-2"},
+		},
+		adopted:  make(map[instance.Id]map[string]string),
+		machines: make(map[instance.Id]string),
+	}
+}
+
+func environGetter(env environs.Environ) func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+	return func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+}
+
+func (s *MachineManagerSuite) TestAdoptableInstances(c *gc.C) {
+	s.st.allMachines = []machinemanager.Machine{
+		&mockMachine{id: "0", instanceId: "i-2"},
+		&mockMachine{id: "1"},
+	}
+	results, err := machinemanager.AdoptableInstances(s.api, environGetter(newMockAdoptEnviron(c)), params.AdoptableInstancesArgs{
+		InstanceIds: []string{"i-1", "i-2", "i-3"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.AdoptableInstanceResult{{
+		Addresses: params.FromNetworkAddresses(network.NewAddresses("10.0.0.1", "54.0.0.1")...),
+	}, {
+		Error: &params.Error{
+			Message: `instance "i-2" is already in use by machine 0`,
+			Code:    params.CodeAlreadyExists,
+		},
+	}, {
+		Error: &params.Error{
+			Message: `instance "i-3" not found`,
+			Code:    params.CodeNotFound,
+		},
+	}})
+}
+
+func (s *MachineManagerSuite) TestAdoptInstances(c *gc.C) {
+	env := newMockAdoptEnviron(c)
+	results, err := machinemanager.AdoptInstances(s.api, environGetter(env), params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:     "xenial",
+			InstanceId: "i-1",
+			Nonce:      "i-1:nonce",
+			Addrs:      params.FromNetworkAddresses(network.NewAddress("54.0.0.1")),
+			Jobs:       []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}, {
+			Series:     "xenial",
+			InstanceId: "i-3",
+			Nonce:      "i-3:nonce",
+			Jobs:       []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}, {
+			Series: "xenial",
+			Nonce:  "nonce",
+			Jobs:   []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 3)
+	c.Assert(results.Machines[0].Error, gc.IsNil)
+	c.Assert(results.Machines[1].Error, gc.ErrorMatches, `instance "i-3" not found`)
+	c.Assert(results.Machines[2].Error, gc.ErrorMatches, "instance ID not specified")
+
+	c.Assert(s.st.machines, jc.DeepEquals, []state.MachineTemplate{{
+		Series:     "xenial",
+		InstanceId: "i-1",
+		Nonce:      "i-1:nonce",
+		Jobs:       []state.MachineJob{state.JobHostUnits},
+		Addresses:  network.NewAddresses("10.0.0.1", "54.0.0.1"),
+	}})
+	c.Assert(env.adopted, jc.DeepEquals, map[instance.Id]map[string]string{
+		"i-1": {
+			"juju-model-uuid":      "beef1beef1-0000-0000-000011112222",
+			"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		},
+	})
+	// The instance is adopted for the machine added for it.
+	c.Assert(env.machines, gc.HasLen, 1)
+	c.Assert(s.st.destroyCalls, gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestAdoptInstancesFailsTagging(c *gc.C) {
+	env := newMockAdoptEnviron(c)
+	env.adoptErr = errors.New("instance \"i-1\" is not in security group \"juju-model\"")
+	results, err := machinemanager.AdoptInstances(s.api, environGetter(env), params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:     "xenial",
+			InstanceId: "i-1",
+			Nonce:      "i-1:nonce",
+			Jobs:       []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines[0].Error, gc.ErrorMatches, `instance "i-1" is not in security group "juju-model"`)
+	c.Assert(s.st.machines, gc.HasLen, 1)
+	// The machine added for the instance is removed again, leaving
+	// the instance running.
+	c.Assert(s.st.destroyCalls, jc.DeepEquals, []string{
+		"keep-instance  true",
+		"force-destroy ",
+	})
+}
+
+func (s *MachineManagerSuite) TestAdoptInstancesNotSupported(c *gc.C) {
+	getEnviron := environGetter(&mockEnviron{})
+	results, err := machinemanager.AdoptableInstances(s.api, getEnviron, params.AdoptableInstancesArgs{
+		InstanceIds: []string{"i-1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "adopting cloud instances on this provider not supported")
+}
+
+func (s *MachineManagerSuite) TestAdoptInstancesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := machinemanager.AdoptInstances(s.api, environGetter(newMockAdoptEnviron(c)), params.AddMachines{
+		MachineParams: []params.AddMachineParams{{InstanceId: "i-1", Nonce: "nonce"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockAdoptEnviron struct {
+	environs.Environ
+
+	cfg       *config.Config
+	instances map[instance.Id]instance.Instance
+	adopted   map[instance.Id]map[string]string
+	machines  map[instance.Id]string
+	adoptErr  error
+}
+
+func (e *mockAdoptEnviron) Config() *config.Config {
+	return e.cfg
+}
+
+func (e *mockAdoptEnviron) AdoptableInstance(id instance.Id) (instance.Instance, error) {
+	inst, ok := e.instances[id]
+	if !ok {
+		return nil, errors.NotFoundf("instance %q", id)
+	}
+	return inst, nil
+}
+
+func (e *mockAdoptEnviron) AdoptInstance(id instance.Id, machineId string, tags map[string]string) error {
+	if e.adoptErr != nil {
+		return e.adoptErr
+	}
+	e.adopted[id] = tags
+	e.machines[id] = machineId
+	return nil
+}
+
+type mockInstance struct {
+	instance.Instance
+
+	id        instance.Id
+	addresses []network.Address
+}
+
+func (i *mockInstance) Id() instance.Id {
+	return i.id
+}
+
+func (i *mockInstance) Addresses() ([]network.Address, error) {
+	return i.addresses, nil
+}
@@ -28,3 +28,5 @@ func NewMachineManagerTestingAPI(st stateInterface, authorizer facade.Authorizer
 }
 
 var InstanceTypes = instanceTypes
+var AdoptableInstances = adoptableInstances
+var AdoptInstances = adoptInstances
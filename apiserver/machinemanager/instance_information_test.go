@@ -145,6 +145,10 @@ func (mockModel) ModelTag() names.ModelTag {
 	return names.NewModelTag("beef1beef1-0000-0000-000011112222")
 }
 
+func (mockModel) ControllerUUID() string {
+	return "deadbeef-1bad-500d-9000-4b1d0d06f00d"
+}
+
 func (*mockModel) Config() (*config.Config, error) {
 	return config.New(config.UseDefaults, dummy.SampleConfig())
 }
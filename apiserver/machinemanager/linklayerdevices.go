@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// LinkLayerDevices returns the link-layer devices recorded for
+// each of the given machines, along with their addresses.
+func (mm *MachineManagerAPI) LinkLayerDevices(args params.Entities) (params.LinkLayerDevicesResults, error) {
+	results := params.LinkLayerDevicesResults{
+		Results: make([]params.LinkLayerDevicesResult, len(args.Entities)),
+	}
+	if err := mm.checkCanRead(); err != nil {
+		return results, err
+	}
+	// Subnets are shared between machines, so look
+	// each of them up at most once.
+	spaces := make(map[string]string)
+	for i, entity := range args.Entities {
+		devices, err := mm.linkLayerDevices(entity.Tag, spaces)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Devices = devices
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) linkLayerDevices(tag string, spaces map[string]string) ([]params.LinkLayerDevice, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	devices, err := machine.AllLinkLayerDevices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.LinkLayerDevice, len(devices))
+	for i, device := range devices {
+		parentName, parentMachineId := device.ParentNameAndMachineID()
+		if parentMachineId == machineTag.Id() {
+			parentMachineId = ""
+		}
+		result[i] = params.LinkLayerDevice{
+			Name:            device.Name(),
+			Type:            string(device.Type()),
+			MACAddress:      device.MACAddress(),
+			MTU:             device.MTU(),
+			ProviderId:      string(device.ProviderID()),
+			IsUp:            device.IsUp(),
+			IsAutoStart:     device.IsAutoStart(),
+			ParentName:      parentName,
+			ParentMachineId: parentMachineId,
+		}
+		addresses, err := device.Addresses()
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting addresses of device %q", device.Name())
+		}
+		for _, addr := range addresses {
+			spaceName, err := mm.subnetSpaceName(addr.SubnetCIDR(), spaces)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result[i].Addresses = append(result[i].Addresses, params.LinkLayerDeviceAddress{
+				Value:          addr.Value(),
+				CIDR:           addr.SubnetCIDR(),
+				ConfigMethod:   string(addr.ConfigMethod()),
+				SpaceName:      spaceName,
+				ProviderId:     string(addr.ProviderID()),
+				GatewayAddress: addr.GatewayAddress(),
+				DNSServers:     addr.DNSServers(),
+			})
+		}
+	}
+	return result, nil
+}
+
+// subnetSpaceName returns the name of the space the subnet with the
+// given CIDR is in, caching the result in spaces. Subnets unknown to
+// the model, such as the loopback one, are in no space.
+func (mm *MachineManagerAPI) subnetSpaceName(cidr string, spaces map[string]string) (string, error) {
+	if spaceName, ok := spaces[cidr]; ok {
+		return spaceName, nil
+	}
+	var spaceName string
+	subnet, err := mm.st.Subnet(cidr)
+	if err == nil {
+		spaceName = subnet.SpaceName()
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Annotatef(err, "getting subnet %q", cidr)
+	}
+	spaces[cidr] = spaceName
+	return spaceName, nil
+}
+
+func (mm *MachineManagerAPI) checkCanRead() error {
+	canRead, err := mm.authorizer.HasPermission(permission.ReadAccess, mm.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -19,6 +20,8 @@ import (
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.machinemanager")
+
 // MachineManagerAPI provides access to the MachineManager API facade.
 type MachineManagerAPI struct {
 	st         stateInterface
@@ -174,15 +177,26 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 
 // DestroyMachine removes a set of machines from the model.
 func (mm *MachineManagerAPI) DestroyMachine(args params.Entities) (params.DestroyMachineResults, error) {
-	return mm.destroyMachine(args, false)
+	return mm.destroyMachine(args, false, false)
 }
 
 // ForceDestroyMachine forcibly removes a set of machines from the model.
 func (mm *MachineManagerAPI) ForceDestroyMachine(args params.Entities) (params.DestroyMachineResults, error) {
-	return mm.destroyMachine(args, true)
+	return mm.destroyMachine(args, true, false)
 }
 
-func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force bool) (params.DestroyMachineResults, error) {
+// DestroyMachineWithParams removes a set of machines from the model,
+// optionally forcibly, and optionally leaving their cloud instances
+// running.
+func (mm *MachineManagerAPI) DestroyMachineWithParams(args params.DestroyMachinesParams) (params.DestroyMachineResults, error) {
+	entities := params.Entities{Entities: make([]params.Entity, len(args.MachineTags))}
+	for i, tag := range args.MachineTags {
+		entities.Entities[i].Tag = tag
+	}
+	return mm.destroyMachine(entities, args.Force, args.KeepInstance)
+}
+
+func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keepInstance bool) (params.DestroyMachineResults, error) {
 	if err := mm.checkCanWrite(); err != nil {
 		return params.DestroyMachineResults{}, err
 	}
@@ -231,11 +245,23 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force bool) (p
 			info.DestroyedStorage = append(info.DestroyedStorage, destroyed...)
 			info.DetachedStorage = append(info.DetachedStorage, detached...)
 		}
+		if keepInstance {
+			if err := machine.SetKeepInstance(true); err != nil {
+				return nil, err
+			}
+		}
 		destroy := machine.Destroy
 		if force {
 			destroy = machine.ForceDestroy
 		}
 		if err := destroy(); err != nil {
+			if keepInstance {
+				// The machine is not being removed, so it must
+				// not keep its instance if it is removed later.
+				if err := machine.SetKeepInstance(false); err != nil {
+					logger.Warningf("cannot reset keep-instance of machine %s: %v", machineTag.Id(), err)
+				}
+			}
 			return nil, err
 		}
 		return &info, nil
@@ -4,8 +4,9 @@
 package machinemanager_test
 
 import (
-	"errors"
+	"fmt"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -141,10 +143,183 @@ func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestDestroyMachineWithParams(c *gc.C) {
+	results, err := s.api.DestroyMachineWithParams(params.DestroyMachinesParams{
+		MachineTags:  []string{"machine-0", "machine-1"},
+		Force:        true,
+		KeepInstance: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	for _, result := range results.Results {
+		c.Assert(result.Error, gc.IsNil)
+	}
+	c.Assert(s.st.destroyCalls, jc.DeepEquals, []string{
+		"keep-instance 0 true",
+		"force-destroy 0",
+		"keep-instance 1 true",
+		"force-destroy 1",
+	})
+}
+
+func (s *MachineManagerSuite) TestDestroyMachineWithParamsNoKeepInstance(c *gc.C) {
+	_, err := s.api.DestroyMachineWithParams(params.DestroyMachinesParams{
+		MachineTags: []string{"machine-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.destroyCalls, jc.DeepEquals, []string{"destroy 0"})
+}
+
+func (s *MachineManagerSuite) TestLinkLayerDevices(c *gc.C) {
+	results, err := s.api.LinkLayerDevices(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "application-foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.LinkLayerDevicesResults{
+		Results: []params.LinkLayerDevicesResult{{
+			Devices: []params.LinkLayerDevice{{
+				Name:        "eth0",
+				Type:        "ethernet",
+				MACAddress:  "aa:bb:cc:dd:ee:f0",
+				MTU:         1500,
+				ProviderId:  "nic-0",
+				IsUp:        true,
+				IsAutoStart: true,
+				ParentName:  "br-eth0",
+				Addresses: []params.LinkLayerDeviceAddress{{
+					Value:        "10.0.0.10",
+					CIDR:         "10.0.0.0/24",
+					ConfigMethod: "static",
+					SpaceName:    "db",
+					ProviderId:   "ip-0",
+					DNSServers:   []string{"10.0.0.1"},
+				}, {
+					Value:        "192.168.0.10",
+					CIDR:         "192.168.0.0/24",
+					ConfigMethod: "dynamic",
+				}},
+			}, {
+				Name:            "br-eth0",
+				Type:            "bridge",
+				ParentName:      "br-host",
+				ParentMachineId: "1",
+			}},
+		}, {
+			Error: &params.Error{Message: `"application-foo" is not a valid machine tag`},
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestLinkLayerDevicesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.api.LinkLayerDevices(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	results, err := s.api.UpgradeSeriesPrepare(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{
+			{Entity: params.Entity{Tag: "machine-0"}, Series: "xenial"},
+			{Entity: params.Entity{Tag: "machine-1"}, Series: "xenial"},
+			{Entity: params.Entity{Tag: "machine-2"}},
+			{Entity: params.Entity{Tag: "application-foo"}, Series: "xenial"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot lock machine "1" for series upgrade: machine is already locked`}},
+			{Error: &params.Error{Message: "series not specified", Code: params.CodeBadRequest}},
+			{Error: &params.Error{Message: `"application-foo" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.upgradeSeriesCalls, jc.DeepEquals, []string{
+		"prepare 0 xenial",
+		"prepare 1 xenial",
+	})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
+	results, err := s.api.UpgradeSeriesComplete(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{Entity: params.Entity{Tag: "machine-0"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	c.Assert(s.st.upgradeSeriesCalls, jc.DeepEquals, []string{"complete 0"})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesAbort(c *gc.C) {
+	results, err := s.api.UpgradeSeriesAbort(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{Entity: params.Entity{Tag: "machine-0"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	c.Assert(s.st.upgradeSeriesCalls, jc.DeepEquals, []string{"abort 0"})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.api.UpgradeSeriesPrepare(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{Entity: params.Entity{Tag: "machine-0"}, Series: "xenial"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestMaintainMachines(c *gc.C) {
+	results, err := s.api.MaintainMachines(params.MaintainMachineArgs{
+		Args: []params.MaintainMachineArg{
+			{Entity: params.Entity{Tag: "machine-0"}, Maintenance: true},
+			{Entity: params.Entity{Tag: "machine-1"}, Maintenance: true, Evacuate: true},
+			{Entity: params.Entity{Tag: "machine-2"}, Maintenance: false},
+			{Entity: params.Entity{Tag: "machine-3"}, Evacuate: true},
+			{Entity: params.Entity{Tag: "application-foo"}, Maintenance: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MaintainMachineResults{
+		Results: []params.MaintainMachineResult{
+			{},
+			{
+				EvacuatedUnits: map[string]string{"unit-foo-0": "unit-foo-2"},
+				KeptUnits:      []params.Entity{{Tag: "unit-foo-1"}},
+			},
+			{},
+			{Error: &params.Error{Message: "cannot evacuate a machine leaving maintenance", Code: params.CodeBadRequest}},
+			{Error: &params.Error{Message: `"application-foo" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.maintenanceCalls, jc.DeepEquals, []string{
+		"maintenance 0 true",
+		"maintenance 1 true",
+		"evacuate 1",
+		"maintenance 2 false",
+	})
+}
+
+func (s *MachineManagerSuite) TestMaintainMachinesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.api.MaintainMachines(params.MaintainMachineArgs{
+		Args: []params.MaintainMachineArg{{Entity: params.Entity{Tag: "machine-0"}, Maintenance: true}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockState struct {
 	calls    int
 	machines []state.MachineTemplate
 	err      error
+
+	upgradeSeriesCalls []string
+	maintenanceCalls   []string
+	destroyCalls       []string
+	allMachines        []machinemanager.Machine
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -199,7 +374,18 @@ func (st *mockState) GetModel(t names.ModelTag) (machinemanager.Model, error) {
 }
 
 func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
-	return &mockMachine{}, nil
+	return &mockMachine{id: id, st: st}, nil
+}
+
+func (st *mockState) AllMachines() ([]machinemanager.Machine, error) {
+	return st.allMachines, nil
+}
+
+func (st *mockState) Subnet(cidr string) (machinemanager.Subnet, error) {
+	if cidr == "10.0.0.0/24" {
+		return &mockSubnet{spaceName: "db"}, nil
+	}
+	return nil, errors.NotFoundf("subnet %q", cidr)
 }
 
 func (st *mockState) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
@@ -240,13 +426,35 @@ func (st *mockBlock) ModelUUID() string {
 	return "uuid"
 }
 
-type mockMachine struct{}
+type mockMachine struct {
+	id         string
+	instanceId instance.Id
+	st         *mockState
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instanceId, nil
+}
 
 func (m *mockMachine) Destroy() error {
+	m.st.destroyCalls = append(m.st.destroyCalls, "destroy "+m.id)
 	return nil
 }
 
 func (m *mockMachine) ForceDestroy() error {
+	m.st.destroyCalls = append(m.st.destroyCalls, "force-destroy "+m.id)
+	return nil
+}
+
+func (m *mockMachine) SetKeepInstance(keepInstance bool) error {
+	m.st.destroyCalls = append(m.st.destroyCalls, fmt.Sprintf("keep-instance %s %v", m.id, keepInstance))
 	return nil
 }
 
@@ -258,6 +466,159 @@ func (m *mockMachine) Units() ([]machinemanager.Unit, error) {
 	}, nil
 }
 
+func (m *mockMachine) CreateUpgradeSeriesLock(toSeries string) error {
+	m.st.upgradeSeriesCalls = append(m.st.upgradeSeriesCalls, "prepare "+m.id+" "+toSeries)
+	if m.id == "1" {
+		return errors.New(`cannot lock machine "1" for series upgrade: machine is already locked`)
+	}
+	return nil
+}
+
+func (m *mockMachine) CompleteUpgradeSeries() error {
+	m.st.upgradeSeriesCalls = append(m.st.upgradeSeriesCalls, "complete "+m.id)
+	return nil
+}
+
+func (m *mockMachine) AbortUpgradeSeries() error {
+	m.st.upgradeSeriesCalls = append(m.st.upgradeSeriesCalls, "abort "+m.id)
+	return nil
+}
+
+func (m *mockMachine) SetMaintenance(maintenance bool) error {
+	m.st.maintenanceCalls = append(m.st.maintenanceCalls, fmt.Sprintf("maintenance %s %v", m.id, maintenance))
+	return nil
+}
+
+func (m *mockMachine) EvacuateUnits() ([]state.EvacuatedUnit, []string, error) {
+	m.st.maintenanceCalls = append(m.st.maintenanceCalls, "evacuate "+m.id)
+	return []state.EvacuatedUnit{{Unit: "foo/0", Replacement: "foo/2"}}, []string{"foo/1"}, nil
+}
+
+func (m *mockMachine) AllLinkLayerDevices() ([]machinemanager.LinkLayerDevice, error) {
+	return []machinemanager.LinkLayerDevice{
+		&mockLinkLayerDevice{
+			name:            "eth0",
+			deviceType:      state.EthernetDevice,
+			macAddress:      "aa:bb:cc:dd:ee:f0",
+			mtu:             1500,
+			providerID:      "nic-0",
+			isUp:            true,
+			isAutoStart:     true,
+			parentName:      "br-eth0",
+			parentMachineID: "0",
+			addresses: []machinemanager.Address{
+				&mockAddress{
+					value:        "10.0.0.10",
+					subnetCIDR:   "10.0.0.0/24",
+					configMethod: state.StaticAddress,
+					providerID:   "ip-0",
+					dnsServers:   []string{"10.0.0.1"},
+				},
+				&mockAddress{
+					value:        "192.168.0.10",
+					subnetCIDR:   "192.168.0.0/24",
+					configMethod: state.DynamicAddress,
+				},
+			},
+		},
+		&mockLinkLayerDevice{
+			name:            "br-eth0",
+			deviceType:      state.BridgeDevice,
+			parentName:      "br-host",
+			parentMachineID: "1",
+		},
+	}, nil
+}
+
+type mockLinkLayerDevice struct {
+	name            string
+	deviceType      state.LinkLayerDeviceType
+	macAddress      string
+	mtu             uint
+	providerID      network.Id
+	isUp            bool
+	isAutoStart     bool
+	parentName      string
+	parentMachineID string
+	addresses       []machinemanager.Address
+}
+
+func (d *mockLinkLayerDevice) Name() string {
+	return d.name
+}
+
+func (d *mockLinkLayerDevice) Type() state.LinkLayerDeviceType {
+	return d.deviceType
+}
+
+func (d *mockLinkLayerDevice) MACAddress() string {
+	return d.macAddress
+}
+
+func (d *mockLinkLayerDevice) MTU() uint {
+	return d.mtu
+}
+
+func (d *mockLinkLayerDevice) ProviderID() network.Id {
+	return d.providerID
+}
+
+func (d *mockLinkLayerDevice) IsUp() bool {
+	return d.isUp
+}
+
+func (d *mockLinkLayerDevice) IsAutoStart() bool {
+	return d.isAutoStart
+}
+
+func (d *mockLinkLayerDevice) Addresses() ([]machinemanager.Address, error) {
+	return d.addresses, nil
+}
+
+func (d *mockLinkLayerDevice) ParentNameAndMachineID() (string, string) {
+	return d.parentName, d.parentMachineID
+}
+
+type mockAddress struct {
+	value        string
+	subnetCIDR   string
+	configMethod state.AddressConfigMethod
+	providerID   network.Id
+	dnsServers   []string
+}
+
+func (a *mockAddress) Value() string {
+	return a.value
+}
+
+func (a *mockAddress) SubnetCIDR() string {
+	return a.subnetCIDR
+}
+
+func (a *mockAddress) ConfigMethod() state.AddressConfigMethod {
+	return a.configMethod
+}
+
+func (a *mockAddress) ProviderID() network.Id {
+	return a.providerID
+}
+
+func (a *mockAddress) GatewayAddress() string {
+	return ""
+}
+
+func (a *mockAddress) DNSServers() []string {
+	return a.dnsServers
+}
+
+type mockSubnet struct {
+	spaceName string
+}
+
+func (s *mockSubnet) SpaceName() string {
+	return s.spaceName
+}
+
 type mockUnit struct {
 	tag names.UnitTag
 }
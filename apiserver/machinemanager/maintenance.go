@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// MaintainMachines puts each of the given machines into maintenance,
+// or takes it out again. No units or containers are placed on a machine
+// while it is in maintenance. If asked to, the stateless principal
+// units on a machine entering maintenance are replaced by new units on
+// other machines.
+func (mm *MachineManagerAPI) MaintainMachines(args params.MaintainMachineArgs) (params.MaintainMachineResults, error) {
+	results := params.MaintainMachineResults{
+		Results: make([]params.MaintainMachineResult, len(args.Args)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		result, err := mm.maintainMachine(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) maintainMachine(arg params.MaintainMachineArg) (params.MaintainMachineResult, error) {
+	var result params.MaintainMachineResult
+	machineTag, err := names.ParseMachineTag(arg.Entity.Tag)
+	if err != nil {
+		return result, err
+	}
+	if arg.Evacuate && !arg.Maintenance {
+		return result, errors.BadRequestf("cannot evacuate a machine leaving maintenance")
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return result, err
+	}
+	if err := machine.SetMaintenance(arg.Maintenance); err != nil {
+		return result, err
+	}
+	if !arg.Evacuate {
+		return result, nil
+	}
+	evacuated, kept, err := machine.EvacuateUnits()
+	if len(evacuated) > 0 {
+		result.EvacuatedUnits = make(map[string]string)
+		for _, e := range evacuated {
+			result.EvacuatedUnits[names.NewUnitTag(e.Unit).String()] = names.NewUnitTag(e.Replacement).String()
+		}
+	}
+	for _, unitName := range kept {
+		result.KeptUnits = append(result.KeptUnits, params.Entity{Tag: names.NewUnitTag(unitName).String()})
+	}
+	return result, err
+}
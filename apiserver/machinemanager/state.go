@@ -9,11 +9,13 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
 type stateInterface interface {
 	Machine(string) (Machine, error)
+	AllMachines() ([]Machine, error)
 	ModelConfig() (*config.Config, error)
 	Model() (*state.Model, error)
 	ModelTag() names.ModelTag
@@ -29,6 +31,7 @@ type stateInterface interface {
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 	UnitStorageAttachments(names.UnitTag) ([]state.StorageAttachment, error)
+	Subnet(cidr string) (Subnet, error)
 }
 
 type stateShim struct {
@@ -43,6 +46,18 @@ func (s stateShim) Machine(name string) (Machine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Machine, len(machines))
+	for i, m := range machines {
+		out[i] = machineShim{m}
+	}
+	return out, nil
+}
+
 func (s stateShim) ModelConfig() (*config.Config, error) {
 	return s.State.ModelConfig()
 }
@@ -70,6 +85,14 @@ func (s stateShim) AddMachineInsideMachine(template state.MachineTemplate, paren
 	return s.State.AddMachineInsideMachine(template, parentId, containerType)
 }
 
+func (s stateShim) Subnet(cidr string) (Subnet, error) {
+	subnet, err := s.State.Subnet(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return subnet, nil
+}
+
 func (s stateShim) GetModel(tag names.ModelTag) (Model, error) {
 	m, err := s.State.GetModel(tag)
 	if err != nil {
@@ -83,14 +106,24 @@ type Model interface {
 	CloudCredential() (names.CloudCredentialTag, bool)
 	CloudRegion() string
 	ModelTag() names.ModelTag
+	ControllerUUID() string
 
 	Config() (*config.Config, error)
 }
 
 type Machine interface {
+	Id() string
+	InstanceId() (instance.Id, error)
 	Destroy() error
 	ForceDestroy() error
+	SetKeepInstance(keepInstance bool) error
 	Units() ([]Unit, error)
+	AllLinkLayerDevices() ([]LinkLayerDevice, error)
+	CreateUpgradeSeriesLock(toSeries string) error
+	CompleteUpgradeSeries() error
+	AbortUpgradeSeries() error
+	SetMaintenance(maintenance bool) error
+	EvacuateUnits() ([]state.EvacuatedUnit, []string, error)
 }
 
 type machineShim struct {
@@ -109,6 +142,18 @@ func (m machineShim) Units() ([]Unit, error) {
 	return out, nil
 }
 
+func (m machineShim) AllLinkLayerDevices() ([]LinkLayerDevice, error) {
+	devices, err := m.Machine.AllLinkLayerDevices()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LinkLayerDevice, len(devices))
+	for i, d := range devices {
+		out[i] = linkLayerDeviceShim{d}
+	}
+	return out, nil
+}
+
 type Unit interface {
 	UnitTag() names.UnitTag
 }
@@ -116,3 +161,44 @@ type Unit interface {
 type unitShim struct {
 	*state.Unit
 }
+
+type Subnet interface {
+	SpaceName() string
+}
+
+type LinkLayerDevice interface {
+	Name() string
+	Type() state.LinkLayerDeviceType
+	MACAddress() string
+	MTU() uint
+	ProviderID() network.Id
+	IsUp() bool
+	IsAutoStart() bool
+	ParentNameAndMachineID() (string, string)
+	Addresses() ([]Address, error)
+}
+
+type linkLayerDeviceShim struct {
+	*state.LinkLayerDevice
+}
+
+func (d linkLayerDeviceShim) Addresses() ([]Address, error) {
+	addresses, err := d.LinkLayerDevice.Addresses()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Address, len(addresses))
+	for i, a := range addresses {
+		out[i] = a
+	}
+	return out, nil
+}
+
+type Address interface {
+	Value() string
+	SubnetCIDR() string
+	ConfigMethod() state.AddressConfigMethod
+	ProviderID() network.Id
+	GatewayAddress() string
+	DNSServers() []string
+}
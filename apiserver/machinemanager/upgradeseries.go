@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// UpgradeSeriesPrepare locks each of the given machines for an in-place
+// upgrade to the given series. The units deployed to a locked machine
+// run their pre-series-upgrade hooks, after which the machine agent
+// prepares itself for the new series.
+func (mm *MachineManagerAPI) UpgradeSeriesPrepare(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	return mm.upgradeSeries(args, func(machine Machine, series string) error {
+		if series == "" {
+			return errors.BadRequestf("series not specified")
+		}
+		return machine.CreateUpgradeSeriesLock(series)
+	})
+}
+
+// UpgradeSeriesComplete records that the operating system of each of
+// the given machines has been upgraded. The series of each machine and
+// of the units deployed to it are updated, and the units run their
+// post-series-upgrade hooks.
+func (mm *MachineManagerAPI) UpgradeSeriesComplete(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	return mm.upgradeSeries(args, func(machine Machine, _ string) error {
+		return machine.CompleteUpgradeSeries()
+	})
+}
+
+// UpgradeSeriesAbort cancels the series upgrade of each of the given
+// machines, unlocking it. An upgrade can only be aborted while the
+// units deployed to the machine are preparing for it.
+func (mm *MachineManagerAPI) UpgradeSeriesAbort(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	return mm.upgradeSeries(args, func(machine Machine, _ string) error {
+		return machine.AbortUpgradeSeries()
+	})
+}
+
+func (mm *MachineManagerAPI) upgradeSeries(
+	args params.UpdateSeriesArgs,
+	upgrade func(machine Machine, series string) error,
+) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		machineTag, err := names.ParseMachineTag(arg.Entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		machine, err := mm.st.Machine(machineTag.Id())
+		if err == nil {
+			err = upgrade(machine, arg.Series)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
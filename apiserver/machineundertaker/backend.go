@@ -33,6 +33,10 @@ type Machine interface {
 	// AllProviderInterfaceInfos returns the details needed to talk to
 	// the provider about this machine's attached devices.
 	AllProviderInterfaceInfos() ([]network.ProviderInterfaceInfo, error)
+
+	// KeepInstance returns whether the machine's cloud instance is
+	// left running when the machine is removed.
+	KeepInstance() bool
 }
 
 type backendShim struct {
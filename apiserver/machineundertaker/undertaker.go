@@ -119,6 +119,31 @@ func (m *API) getInterfaceInfoForOneMachine(machineTag string) ([]network.Provid
 	return interfaces, nil
 }
 
+// KeepInstance returns, for each machine requested, whether its cloud
+// instance is left running when the machine is removed. The provider
+// resources of such machines must not be cleaned up.
+func (m *API) KeepInstance(machines params.Entities) params.BoolResults {
+	results := make([]params.BoolResult, len(machines.Entities))
+	for i, entity := range machines.Entities {
+		keep, err := m.keepInstanceForOneMachine(entity.Tag)
+		results[i].Result = keep
+		results[i].Error = common.ServerError(err)
+	}
+	return params.BoolResults{Results: results}
+}
+
+func (m *API) keepInstanceForOneMachine(machineTag string) (bool, error) {
+	tag, err := names.ParseMachineTag(machineTag)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	machine, err := m.backend.Machine(tag.Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return machine.KeepInstance(), nil
+}
+
 // CompleteMachineRemovals removes the specified machines from the
 // model database. It should only be called once any provider-level
 // cleanup has been done for those machines.
@@ -164,6 +164,30 @@ func (*undertakerSuite) TestGetMachineProviderInterfaceInfoHandlesError(c *gc.C)
 	}})
 }
 
+func (*undertakerSuite) TestKeepInstance(c *gc.C) {
+	backend, _, api := makeAPI(c, "")
+	backend.machines = map[string]*mockMachine{
+		"0": &mockMachine{Stub: &testing.Stub{}},
+		"0/lxd/1": &mockMachine{
+			Stub:         &testing.Stub{},
+			keepInstance: true,
+		},
+	}
+	backend.SetErrors(nil, nil, errors.NotFoundf("machine 100"))
+
+	args := makeEntities("machine-0", "machine-0-lxd-1", "machine-100", "machine-inv")
+	result := api.KeepInstance(args)
+
+	c.Assert(result, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: false},
+			{Result: true},
+			{Error: common.ServerError(errors.NotFoundf("machine 100"))},
+			{Error: common.ServerError(errors.New(`"machine-inv" is not a valid machine tag`))},
+		},
+	})
+}
+
 func (*undertakerSuite) TestCompleteMachineRemovalsWithNonMachineTags(c *gc.C) {
 	_, _, api := makeAPI(c, "")
 	err := api.CompleteMachineRemovals(makeEntities("machine-2", "application-a1"))
@@ -290,6 +314,12 @@ func (b *mockBackend) Machine(id string) (machineundertaker.Machine, error) {
 type mockMachine struct {
 	*testing.Stub
 	interfaceInfos []network.ProviderInterfaceInfo
+	keepInstance   bool
+}
+
+func (m *mockMachine) KeepInstance() bool {
+	m.AddCall("KeepInstance")
+	return m.keepInstance
 }
 
 func (m *mockMachine) AllProviderInterfaceInfos() ([]network.ProviderInterfaceInfo, error) {
@@ -21,6 +21,10 @@ type Backend interface {
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	AllRoleGrants() ([]state.RoleGrant, error)
+	ModelUserGroups() ([]state.UserGroupMembership, error)
+	AllApplicationGrants() ([]state.ApplicationGrant, error)
+	ExportExtras() ([]byte, error)
 
 	migration.StateExporter
 }
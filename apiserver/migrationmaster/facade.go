@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -184,6 +185,30 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Charms = getUsedCharms(model)
 	serialized.Tools = getUsedTools(model)
 	serialized.Resources = getUsedResources(model)
+
+	grants, err := api.backend.AllRoleGrants()
+	if err != nil {
+		return serialized, errors.Annotate(err, "getting role grants")
+	}
+	serialized.RoleGrants = serializeRoleGrants(grants)
+
+	groups, err := api.backend.ModelUserGroups()
+	if err != nil {
+		return serialized, errors.Annotate(err, "getting user groups")
+	}
+	serialized.UserGroups = serializeUserGroups(groups)
+
+	appGrants, err := api.backend.AllApplicationGrants()
+	if err != nil {
+		return serialized, errors.Annotate(err, "getting application grants")
+	}
+	serialized.ApplicationGrants = serializeApplicationGrants(appGrants)
+
+	extras, err := api.backend.ExportExtras()
+	if err != nil {
+		return serialized, errors.Annotate(err, "getting model extras")
+	}
+	serialized.Extras = extras
 	return serialized, nil
 }
 
@@ -306,6 +331,49 @@ func addToolsVersionForMachine(machine description.Machine, usedVersions map[ver
 	}
 }
 
+func serializeRoleGrants(grants []state.RoleGrant) []params.SerializedRoleGrant {
+	var out []params.SerializedRoleGrant
+	for _, grant := range grants {
+		role := params.Role{Name: grant.Role.Name}
+		for _, capability := range grant.Role.Capabilities {
+			role.Capabilities = append(role.Capabilities, string(capability))
+		}
+		out = append(out, params.SerializedRoleGrant{
+			UserTag: grant.User.String(),
+			Role:    role,
+		})
+	}
+	return out
+}
+
+func serializeUserGroups(groups []state.UserGroupMembership) []params.SerializedUserGroup {
+	var out []params.SerializedUserGroup
+	for _, group := range groups {
+		members := make([]string, len(group.Members))
+		for i, member := range group.Members {
+			members[i] = member.String()
+		}
+		out = append(out, params.SerializedUserGroup{
+			Name:      group.Name,
+			CreatedBy: group.CreatedBy,
+			Members:   members,
+		})
+	}
+	return out
+}
+
+func serializeApplicationGrants(grants []state.ApplicationGrant) []params.SerializedApplicationGrant {
+	var out []params.SerializedApplicationGrant
+	for _, grant := range grants {
+		out = append(out, params.SerializedApplicationGrant{
+			ApplicationTag: names.NewApplicationTag(grant.Application).String(),
+			UserTag:        grant.User.String(),
+			Access:         string(grant.Access),
+		})
+	}
+	return out
+}
+
 func getUsedResources(model description.Model) []params.SerializedModelResource {
 	var out []params.SerializedModelResource
 	for _, app := range model.Applications() {
@@ -23,6 +23,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
@@ -298,7 +299,70 @@ func (s *Suite) TestExport(c *gc.C) {
 			},
 		},
 	}})
+	c.Check(serialized.RoleGrants, gc.HasLen, 0)
+	c.Check(serialized.UserGroups, gc.HasLen, 0)
+	c.Check(serialized.ApplicationGrants, gc.HasLen, 0)
+	c.Check(serialized.Extras, gc.IsNil)
+}
+
+func (s *Suite) TestExportRoleGrants(c *gc.C) {
+	s.backend.grants = []state.RoleGrant{{
+		User: names.NewUserTag("bob"),
+		Role: permission.Role{
+			Name:         "operator",
+			Capabilities: []permission.Capability{permission.ActionsCapability},
+		},
+	}}
+	api := s.mustMakeAPI(c)
+	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.RoleGrants, jc.DeepEquals, []params.SerializedRoleGrant{{
+		UserTag: "user-bob",
+		Role: params.Role{
+			Name:         "operator",
+			Capabilities: []string{"actions"},
+		},
+	}})
+}
+
+func (s *Suite) TestExportUserGroups(c *gc.C) {
+	s.backend.groups = []state.UserGroupMembership{{
+		Name:      "ops",
+		CreatedBy: "admin",
+		Members:   []names.UserTag{names.NewUserTag("bob"), names.NewUserTag("mary@external")},
+	}}
+	api := s.mustMakeAPI(c)
+	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.UserGroups, jc.DeepEquals, []params.SerializedUserGroup{{
+		Name:      "ops",
+		CreatedBy: "admin",
+		Members:   []string{"user-bob", "user-mary@external"},
+	}})
+}
+
+func (s *Suite) TestExportExtras(c *gc.C) {
+	s.backend.extras = []byte(`{"version":1}`)
+	api := s.mustMakeAPI(c)
+	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Extras, jc.DeepEquals, []byte(`{"version":1}`))
+}
 
+func (s *Suite) TestExportApplicationGrants(c *gc.C) {
+	s.backend.appGrants = []state.ApplicationGrant{{
+		Application: "mysql",
+		User:        names.NewUserTag("bob"),
+		Access:      permission.WriteAccess,
+	}}
+	api := s.mustMakeAPI(c)
+	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.ApplicationGrants, jc.DeepEquals, []params.SerializedApplicationGrant{{
+		ApplicationTag: "application-mysql",
+		UserTag:        "user-bob",
+		Access:         "write",
+	}})
 }
 
 func (s *Suite) TestReap(c *gc.C) {
@@ -406,6 +470,10 @@ type stubBackend struct {
 	removeErr error
 	migration *stubMigration
 	model     description.Model
+	grants    []state.RoleGrant
+	groups    []state.UserGroupMembership
+	appGrants []state.ApplicationGrant
+	extras    []byte
 }
 
 func (b *stubBackend) WatchForMigration() state.NotifyWatcher {
@@ -447,6 +515,26 @@ func (b *stubBackend) Export() (description.Model, error) {
 	return b.model, nil
 }
 
+func (b *stubBackend) AllRoleGrants() ([]state.RoleGrant, error) {
+	b.stub.AddCall("AllRoleGrants")
+	return b.grants, nil
+}
+
+func (b *stubBackend) ModelUserGroups() ([]state.UserGroupMembership, error) {
+	b.stub.AddCall("ModelUserGroups")
+	return b.groups, nil
+}
+
+func (b *stubBackend) AllApplicationGrants() ([]state.ApplicationGrant, error) {
+	b.stub.AddCall("AllApplicationGrants")
+	return b.appGrants, nil
+}
+
+func (b *stubBackend) ExportExtras() ([]byte, error) {
+	b.stub.AddCall("ExportExtras")
+	return b.extras, nil
+}
+
 type stubMigration struct {
 	state.ModelMigration
 
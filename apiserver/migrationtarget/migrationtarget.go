@@ -79,7 +79,7 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	modelInfo, err := modelInfoFromParams(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -87,16 +87,51 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
-		backend,
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-	)
+	return migration.TargetPrecheck(backend, modelInfo)
+}
+
+// PrecheckBlockers runs the same checks as Prechecks but reports
+// every problem which would prevent the model migration, rather than
+// only the first.
+func (api *API) PrecheckBlockers(model params.MigrationModelInfo) (params.StringsResult, error) {
+	var result params.StringsResult
+	modelInfo, err := modelInfoFromParams(model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	backend, err := migration.PrecheckShim(api.state)
+	if err != nil {
+		return result, errors.Annotate(err, "creating backend")
+	}
+	for _, err := range migration.TargetPrecheckAll(backend, modelInfo) {
+		result.Result = append(result.Result, err.Error())
+	}
+	return result, nil
+}
+
+func modelInfoFromParams(model params.MigrationModelInfo) (coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	var users []names.UserTag
+	for _, userTag := range model.UserTags {
+		user, err := names.ParseUserTag(userTag)
+		if err != nil {
+			return coremigration.ModelInfo{}, errors.Trace(err)
+		}
+		users = append(users, user)
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+		Cloud:                  model.CloudName,
+		CloudRegion:            model.CloudRegion,
+		Users:                  users,
+	}, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -113,6 +148,109 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// ImportRoleGrants grants roles to users on a model being imported,
+// defining any roles not yet known to this controller.
+func (api *API) ImportRoleGrants(args params.ImportRoleGrantsArgs) error {
+	model, err := api.getImportingModel(params.ModelArgs{ModelTag: args.ModelTag})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	grants := make([]state.RoleGrant, len(args.Grants))
+	for i, arg := range args.Grants {
+		user, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		role := permission.Role{Name: arg.Role.Name}
+		for _, capability := range arg.Role.Capabilities {
+			role.Capabilities = append(role.Capabilities, permission.Capability(capability))
+		}
+		grants[i] = state.RoleGrant{User: user, Role: role}
+	}
+
+	st, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	return errors.Trace(st.ImportRoleGrants(grants))
+}
+
+// ImportUserGroups ensures that the groups of users with access to a
+// model being imported exist in this controller, along with their
+// members.
+func (api *API) ImportUserGroups(args params.ImportUserGroupsArgs) error {
+	if _, err := api.getImportingModel(params.ModelArgs{ModelTag: args.ModelTag}); err != nil {
+		return errors.Trace(err)
+	}
+	groups := make([]state.UserGroupMembership, len(args.Groups))
+	for i, arg := range args.Groups {
+		members := make([]names.UserTag, len(arg.Members))
+		for j, member := range arg.Members {
+			user, err := names.ParseUserTag(member)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			members[j] = user
+		}
+		groups[i] = state.UserGroupMembership{
+			Name:      arg.Name,
+			CreatedBy: arg.CreatedBy,
+			Members:   members,
+		}
+	}
+	// Groups are controller global, so may be
+	// imported through the controller's state.
+	return errors.Trace(api.state.ImportUserGroups(groups))
+}
+
+// ImportApplicationGrants grants users access to the applications of
+// a model being imported.
+func (api *API) ImportApplicationGrants(args params.ImportApplicationGrantsArgs) error {
+	model, err := api.getImportingModel(params.ModelArgs{ModelTag: args.ModelTag})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	grants := make([]state.ApplicationGrant, len(args.Grants))
+	for i, arg := range args.Grants {
+		app, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		user, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		grants[i] = state.ApplicationGrant{
+			Application: app.Id(),
+			User:        user,
+			Access:      permission.Access(arg.Access),
+		}
+	}
+
+	st, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	return errors.Trace(st.ImportApplicationGrants(grants))
+}
+
+// ImportExtras applies the parts of a model being imported that the
+// model description has no place for.
+func (api *API) ImportExtras(args params.ImportModelExtrasArgs) error {
+	model, err := api.getImportingModel(params.ModelArgs{ModelTag: args.ModelTag})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	return errors.Trace(st.ImportExtras(args.Extras))
+}
+
 func (api *API) getModel(modelTag string) (*state.Model, error) {
 	tag, err := names.ParseModelTag(modelTag)
 	if err != nil {
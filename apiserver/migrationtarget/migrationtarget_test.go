@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -121,6 +122,42 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrecheckBlockers(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+	}
+	result, err := api.PrecheckBlockers(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.HasLen, 0)
+}
+
+func (s *Suite) TestPrecheckBlockersReportsAll(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+		CloudName:              "unknown",
+		UserTags: []string{
+			names.NewUserTag("bob").String(),
+			names.NewUserTag("mary@external").String(),
+		},
+	}
+	result, err := api.PrecheckBlockers(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, jc.DeepEquals, []string{
+		`cloud "unknown" not found in target controller`,
+		"user bob not found in target controller",
+	})
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -131,6 +168,110 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestImportRoleGrants(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	err := api.ImportRoleGrants(params.ImportRoleGrantsArgs{
+		ModelTag: tag.String(),
+		Grants: []params.SerializedRoleGrant{{
+			UserTag: names.NewUserTag("bob").String(),
+			Role:    params.Role{Name: "operator", Capabilities: []string{"actions"}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.ForModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	grants, err := st.AllRoleGrants()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.RoleGrant{{
+		User: names.NewUserTag("bob"),
+		Role: permission.Role{
+			Name:         "operator",
+			Capabilities: []permission.Capability{permission.ActionsCapability},
+		},
+	}})
+}
+
+func (s *Suite) TestImportRoleGrantsNotImportingEnv(c *gc.C) {
+	api := s.mustNewAPI(c)
+	err := api.ImportRoleGrants(params.ImportRoleGrantsArgs{
+		ModelTag: s.State.ModelTag().String(),
+	})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
+func (s *Suite) TestImportUserGroups(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	err := api.ImportUserGroups(params.ImportUserGroupsArgs{
+		ModelTag: tag.String(),
+		Groups: []params.SerializedUserGroup{{
+			Name:      "ops",
+			CreatedBy: "admin",
+			Members:   []string{names.NewUserTag("mary@external").String()},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	group, err := s.State.UserGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{names.NewUserTag("mary@external")})
+}
+
+func (s *Suite) TestImportUserGroupsNotImportingEnv(c *gc.C) {
+	api := s.mustNewAPI(c)
+	err := api.ImportUserGroups(params.ImportUserGroupsArgs{
+		ModelTag: s.State.ModelTag().String(),
+	})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
+func (s *Suite) TestImportApplicationGrantsMissingApplication(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	err := api.ImportApplicationGrants(params.ImportApplicationGrantsArgs{
+		ModelTag: tag.String(),
+		Grants: []params.SerializedApplicationGrant{{
+			ApplicationTag: names.NewApplicationTag("missing").String(),
+			UserTag:        names.NewUserTag("admin").String(),
+			Access:         "read",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "application grants refer to missing users, applications or existing grants")
+}
+
+func (s *Suite) TestImportApplicationGrantsNotImportingEnv(c *gc.C) {
+	api := s.mustNewAPI(c)
+	err := api.ImportApplicationGrants(params.ImportApplicationGrantsArgs{
+		ModelTag: s.State.ModelTag().String(),
+	})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
+func (s *Suite) TestImportExtras(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	err := api.ImportExtras(params.ImportModelExtrasArgs{
+		ModelTag: tag.String(),
+		Extras:   []byte(`{"version":1}`),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestImportExtrasNotImportingEnv(c *gc.C) {
+	api := s.mustNewAPI(c)
+	err := api.ImportExtras(params.ImportModelExtrasArgs{
+		ModelTag: s.State.ModelTag().String(),
+	})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
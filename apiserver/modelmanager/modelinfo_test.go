@@ -729,6 +729,26 @@ func (st *mockState) SetUserAccess(subject names.UserTag, target names.Tag, acce
 	return permission.UserAccess{}, st.NextErr()
 }
 
+func (st *mockState) GetApplicationAccess(app names.ApplicationTag, user names.UserTag) (permission.Access, error) {
+	st.MethodCall(st, "GetApplicationAccess", app, user)
+	return permission.ReadAccess, st.NextErr()
+}
+
+func (st *mockState) CreateApplicationAccess(app names.ApplicationTag, user names.UserTag, access permission.Access) error {
+	st.MethodCall(st, "CreateApplicationAccess", app, user, access)
+	return st.NextErr()
+}
+
+func (st *mockState) UpdateApplicationAccess(app names.ApplicationTag, user names.UserTag, access permission.Access) error {
+	st.MethodCall(st, "UpdateApplicationAccess", app, user, access)
+	return st.NextErr()
+}
+
+func (st *mockState) RemoveApplicationAccess(app names.ApplicationTag, user names.UserTag) error {
+	st.MethodCall(st, "RemoveApplicationAccess", app, user)
+	return st.NextErr()
+}
+
 func (st *mockState) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
 	st.MethodCall(st, "ModelConfigDefaultValues")
 	return st.cfgDefaults, nil
@@ -936,6 +956,11 @@ func (m *mockModel) Destroy() error {
 	return m.NextErr()
 }
 
+func (m *mockModel) DestroyKeepingInstances() error {
+	m.MethodCall(m, "DestroyKeepingInstances")
+	return m.NextErr()
+}
+
 func (m *mockModel) DestroyIncludingHosted() error {
 	m.MethodCall(m, "DestroyIncludingHosted")
 	return m.NextErr()
@@ -517,6 +517,40 @@ func (m *ModelManagerAPI) DestroyModels(args params.Entities) (params.ErrorResul
 	return results, nil
 }
 
+// DestroyModelsWithParams will try to destroy the specified models,
+// optionally leaving the cloud instances of their machines running.
+// If there is a block on destruction, this method will return an error.
+func (m *ModelManagerAPI) DestroyModelsWithParams(args params.DestroyModelsParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Models)),
+	}
+
+	destroyModel := func(arg params.DestroyModelParams) error {
+		tag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		model, err := m.state.GetModel(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := m.authCheck(model.Owner()); err != nil {
+			return errors.Trace(err)
+		}
+		if arg.KeepInstances {
+			return errors.Trace(common.DestroyModelKeepingInstances(m.state, tag))
+		}
+		return errors.Trace(common.DestroyModel(m.state, tag))
+	}
+
+	for i, arg := range args.Models {
+		if err := destroyModel(arg); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
@@ -818,6 +852,106 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 	}
 }
 
+// ModifyApplicationAccess changes the access granted to users on
+// individual applications within models.
+func (m *ModelManagerAPI) ModifyApplicationAccess(args params.ModifyApplicationAccessRequest) (result params.ErrorResults, _ error) {
+	result = params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+
+	canModifyController, err := m.authorizer.HasPermission(permission.SuperuserAccess, m.state.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	for i, arg := range args.Changes {
+		appAccess := permission.Access(arg.Access)
+		if err := permission.ValidateApplicationAccess(appAccess); err != nil {
+			err = errors.Annotate(err, "could not modify application access")
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+
+		modelTag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify application access"))
+			continue
+		}
+		canModifyModel, err := m.authorizer.HasPermission(permission.AdminAccess, modelTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if !canModifyController && !canModifyModel {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+
+		targetUserTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify application access"))
+			continue
+		}
+		appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify application access"))
+			continue
+		}
+
+		result.Results[i].Error = common.ServerError(
+			changeApplicationAccess(m.state, modelTag, appTag, targetUserTag, arg.Action, appAccess))
+	}
+	return result, nil
+}
+
+// changeApplicationAccess performs the requested access grant or revoke
+// action for the specified user on the specified application.
+func changeApplicationAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, appTag names.ApplicationTag, targetUserTag names.UserTag, action params.ModelAction, access permission.Access) error {
+	st, err := accessor.ForModel(modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not lookup model")
+	}
+	defer st.Close()
+
+	switch action {
+	case params.GrantModelAccess:
+		err := st.CreateApplicationAccess(appTag, targetUserTag, access)
+		if !errors.IsAlreadyExists(err) {
+			return errors.Annotate(err, "could not grant application access")
+		}
+		current, err := st.GetApplicationAccess(appTag, targetUserTag)
+		if err != nil {
+			return errors.Annotate(err, "could not look up application access for user")
+		}
+		// Only set access if greater access is being granted.
+		if current.EqualOrGreaterModelAccessThan(access) {
+			return errors.Errorf("user already has %q access or greater", access)
+		}
+		err = st.UpdateApplicationAccess(appTag, targetUserTag, access)
+		return errors.Annotate(err, "could not set application access for user")
+
+	case params.RevokeModelAccess:
+		switch access {
+		case permission.ReadAccess:
+			// Revoking read access removes all access.
+			err := st.RemoveApplicationAccess(appTag, targetUserTag)
+			return errors.Annotate(err, "could not revoke application access")
+		case permission.WriteAccess:
+			// Revoking write access sets read-only.
+			err := st.UpdateApplicationAccess(appTag, targetUserTag, permission.ReadAccess)
+			return errors.Annotate(err, "could not set application access to read-only")
+		case permission.AdminAccess:
+			// Revoking admin access sets read-write.
+			err := st.UpdateApplicationAccess(appTag, targetUserTag, permission.WriteAccess)
+			return errors.Annotate(err, "could not set application access to read-write")
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
 // ModelDefaults returns the default config values used when creating a new model.
 func (m *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
 	result := params.ModelDefaultsResult{}
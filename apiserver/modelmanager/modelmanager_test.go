@@ -961,6 +961,39 @@ func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
 }
 
+func (s *modelManagerStateSuite) TestDestroyModelKeepingInstances(c *gc.C) {
+	owner := names.NewUserTag("admin")
+	s.setAPIUser(c, owner)
+	m, err := s.modelmanager.CreateModel(createArgs(owner))
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.State.ForModel(names.NewModelTag(m.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(
+		common.NewModelManagerBackend(st), nil, s.authoriser,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.modelmanager.DestroyModelsWithParams(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag:      "model-" + m.UUID,
+			KeepInstances: true,
+		}, {
+			ModelTag: "machine-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-42" is not a valid model tag`)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
+	c.Assert(model.KeepInstances(), jc.IsTrue)
+}
+
 func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 	owner := names.NewUserTag("admin")
 	s.setAPIUser(c, owner)
@@ -1331,6 +1364,58 @@ func (s *modelManagerStateSuite) TestModifyModelAccessInvalidAction(c *gc.C) {
 	c.Assert(result.OneError(), gc.ErrorMatches, expectedErr)
 }
 
+func (s *modelManagerStateSuite) modifyApplicationAccess(c *gc.C, user names.UserTag, action params.ModelAction, access params.UserAccessPermission, app names.ApplicationTag) error {
+	args := params.ModifyApplicationAccessRequest{
+		Changes: []params.ModifyApplicationAccess{{
+			UserTag:        user.String(),
+			Action:         action,
+			Access:         access,
+			ModelTag:       s.State.ModelTag().String(),
+			ApplicationTag: app.String(),
+		}}}
+
+	result, err := s.modelmanager.ModifyApplicationAccess(args)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+func (s *modelManagerStateSuite) TestGrantRevokeApplicationAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	app := s.Factory.MakeApplication(c, nil)
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: permission.ReadAccess})
+
+	err := s.modifyApplicationAccess(c, user.UserTag, params.GrantModelAccess, params.ModelWriteAccess, app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.GetApplicationAccess(app.ApplicationTag(), user.UserTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.modifyApplicationAccess(c, user.UserTag, params.GrantModelAccess, params.ModelReadAccess, app.ApplicationTag())
+	c.Assert(err, gc.ErrorMatches, `user already has "read" access or greater`)
+
+	err = s.modifyApplicationAccess(c, user.UserTag, params.RevokeModelAccess, params.ModelWriteAccess, app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.GetApplicationAccess(app.ApplicationTag(), user.UserTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+
+	err = s.modifyApplicationAccess(c, user.UserTag, params.RevokeModelAccess, params.ModelReadAccess, app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.GetApplicationAccess(app.ApplicationTag(), user.UserTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelManagerStateSuite) TestGrantApplicationAccessRequiresModelAdmin(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: permission.WriteAccess})
+	s.setAPIUser(c, user.UserTag)
+
+	err := s.modifyApplicationAccess(c, user.UserTag, params.GrantModelAccess, params.ModelAdminAccess, app.ApplicationTag())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type fakeProvider struct {
 	environs.EnvironProvider
 }
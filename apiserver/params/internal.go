@@ -250,6 +250,13 @@ type ControllerConfigResult struct {
 	Config ControllerConfig `json:"config"`
 }
 
+// ControllerConfigSet holds new controller configuration values
+// to set, and the names of values to reset to their defaults.
+type ControllerConfigSet struct {
+	Config map[string]interface{} `json:"config"`
+	Remove []string               `json:"remove,omitempty"`
+}
+
 // RelationUnit holds a relation and a unit tag.
 type RelationUnit struct {
 	Relation string `json:"relation"`
@@ -616,6 +623,10 @@ type ProvisioningInfo struct {
 	ImageMetadata    []CloudImageMetadata      `json:"image-metadata,omitempty"`
 	EndpointBindings map[string]string         `json:"endpoint-bindings,omitempty"`
 	ControllerConfig map[string]interface{}    `json:"controller-config,omitempty"`
+
+	// AntiAffinityGroups holds the names of the applications with
+	// anti-affinity that have units on the machine.
+	AntiAffinityGroups []string `json:"anti-affinity-groups,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
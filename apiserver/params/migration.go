@@ -52,6 +52,21 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationPrecheckResults is used to return the problems found when
+// checking whether one or more models could be migrated.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
+}
+
+// MigrationPrecheckResult holds the problems which would prevent a
+// single model from being migrated. Error is set if the checks
+// themselves could not be run.
+type MigrationPrecheckResult struct {
+	ModelTag string   `json:"model-tag"`
+	Error    *Error   `json:"error,omitempty"`
+	Blockers []string `json:"blockers,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -67,10 +82,66 @@ type SetMigrationStatusMessageArgs struct {
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
-	Bytes     []byte                    `json:"bytes"`
-	Charms    []string                  `json:"charms"`
-	Tools     []SerializedModelTools    `json:"tools"`
-	Resources []SerializedModelResource `json:"resources"`
+	Bytes      []byte                    `json:"bytes"`
+	Charms     []string                  `json:"charms"`
+	Tools      []SerializedModelTools    `json:"tools"`
+	Resources  []SerializedModelResource `json:"resources"`
+	RoleGrants []SerializedRoleGrant     `json:"role-grants,omitempty"`
+	UserGroups []SerializedUserGroup     `json:"user-groups,omitempty"`
+
+	ApplicationGrants []SerializedApplicationGrant `json:"application-grants,omitempty"`
+	Extras            []byte                       `json:"extras,omitempty"`
+}
+
+// SerializedRoleGrant holds a role granted to a user on a model
+// being migrated.
+type SerializedRoleGrant struct {
+	UserTag string `json:"user-tag"`
+	Role    Role   `json:"role"`
+}
+
+// ImportRoleGrantsArgs holds the roles granted to users on a model
+// being imported by a migration.
+type ImportRoleGrantsArgs struct {
+	ModelTag string                `json:"model-tag"`
+	Grants   []SerializedRoleGrant `json:"grants"`
+}
+
+// SerializedUserGroup holds a group of users with access to a model
+// being migrated.
+type SerializedUserGroup struct {
+	Name      string   `json:"name"`
+	CreatedBy string   `json:"created-by"`
+	Members   []string `json:"members"`
+}
+
+// ImportUserGroupsArgs holds the groups of users with access to a
+// model being imported by a migration.
+type ImportUserGroupsArgs struct {
+	ModelTag string                `json:"model-tag"`
+	Groups   []SerializedUserGroup `json:"groups"`
+}
+
+// SerializedApplicationGrant holds the access granted to a user on
+// an application in a model being migrated.
+type SerializedApplicationGrant struct {
+	ApplicationTag string `json:"application-tag"`
+	UserTag        string `json:"user-tag"`
+	Access         string `json:"access"`
+}
+
+// ImportApplicationGrantsArgs holds the access granted to users on
+// the applications of a model being imported by a migration.
+type ImportApplicationGrantsArgs struct {
+	ModelTag string                       `json:"model-tag"`
+	Grants   []SerializedApplicationGrant `json:"grants"`
+}
+
+// ImportModelExtrasArgs holds the parts of a model being imported by
+// a migration that the model description has no place for.
+type ImportModelExtrasArgs struct {
+	ModelTag string `json:"model-tag"`
+	Extras   []byte `json:"extras"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
@@ -132,6 +203,9 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	CloudName              string         `json:"cloud-name,omitempty"`
+	CloudRegion            string         `json:"cloud-region,omitempty"`
+	UserTags               []string       `json:"user-tags,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
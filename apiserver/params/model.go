@@ -248,6 +248,22 @@ type ModifyModelAccess struct {
 	ModelTag string               `json:"model-tag"`
 }
 
+// ModifyApplicationAccessRequest holds the parameters for making grant and
+// revoke application calls.
+type ModifyApplicationAccessRequest struct {
+	Changes []ModifyApplicationAccess `json:"changes"`
+}
+
+// ModifyApplicationAccess contains parameters to grant and revoke access
+// to a single application in a model.
+type ModifyApplicationAccess struct {
+	UserTag        string               `json:"user-tag"`
+	Action         ModelAction          `json:"action"`
+	Access         UserAccessPermission `json:"access"`
+	ModelTag       string               `json:"model-tag"`
+	ApplicationTag string               `json:"application-tag"`
+}
+
 // ModelAction is an action that can be performed on a model.
 type ModelAction string
 
@@ -246,6 +246,58 @@ func (pr PortRange) NetworkPortRange() network.PortRange {
 	}
 }
 
+// IngressRule represents a range of ports that may be accessed from
+// the given source CIDRs.
+type IngressRule struct {
+	PortRange   PortRange `json:"port-range"`
+	SourceCIDRs []string  `json:"source-cidrs,omitempty"`
+}
+
+// IngressRulesResult holds the result of an API call
+// that returns ingress rules.
+type IngressRulesResult struct {
+	Rules []IngressRule `json:"rules,omitempty"`
+	Error *Error        `json:"error,omitempty"`
+}
+
+// IngressRulesResults holds the results of an API call
+// that returns ingress rules for several entities.
+type IngressRulesResults struct {
+	Results []IngressRulesResult `json:"results"`
+}
+
+// EgressRule is a rule for outgoing traffic to a range of ports,
+// optionally restricted to the given destinations.
+type EgressRule struct {
+	PortRange        PortRange `json:"port-range"`
+	DestinationCIDRs []string  `json:"destination-cidrs,omitempty"`
+}
+
+// EgressRulesResult holds the result of an API call
+// that returns egress rules.
+type EgressRulesResult struct {
+	Rules []EgressRule `json:"rules,omitempty"`
+	Error *Error       `json:"error,omitempty"`
+}
+
+// EgressRulesResults holds the results of an API call
+// that returns egress rules for several entities.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// EntityEgressRule holds an entity's tag and an egress rule.
+type EntityEgressRule struct {
+	Tag  string     `json:"tag"`
+	Rule EgressRule `json:"rule"`
+}
+
+// EntitiesEgressRules holds the parameters for making an OpenEgress
+// or CloseEgress call on some entities.
+type EntitiesEgressRules struct {
+	Entities []EntityEgressRule `json:"entities"`
+}
+
 // EntityPort holds an entity's tag, a protocol and a port.
 type EntityPort struct {
 	Tag      string `json:"tag"`
@@ -259,12 +311,14 @@ type EntitiesPorts struct {
 	Entities []EntityPort `json:"entities"`
 }
 
-// EntityPortRange holds an entity's tag, a protocol and a port range.
+// EntityPortRange holds an entity's tag, a protocol and a port range,
+// and the endpoint it is opened for if it is opened for just one.
 type EntityPortRange struct {
 	Tag      string `json:"tag"`
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -432,11 +486,13 @@ type MachineAddressesResults struct {
 }
 
 // MachinePortRange holds a single port range open on a machine for
-// the given unit and relation tags.
+// the given unit and relation tags, and the unit's endpoint if it is
+// opened for just one endpoint.
 type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	Endpoint    string    `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
@@ -508,6 +564,52 @@ type HostNetworkChangeResults struct {
 	Results []HostNetworkChange `json:"results"`
 }
 
+// LinkLayerDevice describes a link-layer network device of a machine,
+// as recorded in the model, together with its addresses.
+type LinkLayerDevice struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	MACAddress  string `json:"mac-address,omitempty"`
+	MTU         uint   `json:"mtu,omitempty"`
+	ProviderId  string `json:"provider-id,omitempty"`
+	IsUp        bool   `json:"is-up"`
+	IsAutoStart bool   `json:"is-auto-start"`
+
+	// ParentName is the name of the parent device, if any.
+	ParentName string `json:"parent-name,omitempty"`
+
+	// ParentMachineId is the ID of the machine the parent device is
+	// on, when it is not the device's own machine. This is the case
+	// for container devices bridged to a device on the host.
+	ParentMachineId string `json:"parent-machine-id,omitempty"`
+
+	Addresses []LinkLayerDeviceAddress `json:"addresses,omitempty"`
+}
+
+// LinkLayerDeviceAddress describes an IP address assigned to a
+// link-layer device.
+type LinkLayerDeviceAddress struct {
+	Value          string   `json:"value"`
+	CIDR           string   `json:"cidr"`
+	ConfigMethod   string   `json:"config-method"`
+	SpaceName      string   `json:"space-name,omitempty"`
+	ProviderId     string   `json:"provider-id,omitempty"`
+	GatewayAddress string   `json:"gateway-address,omitempty"`
+	DNSServers     []string `json:"dns-servers,omitempty"`
+}
+
+// LinkLayerDevicesResult holds the link-layer devices of a machine.
+type LinkLayerDevicesResult struct {
+	Devices []LinkLayerDevice `json:"devices,omitempty"`
+	Error   *Error            `json:"error,omitempty"`
+}
+
+// LinkLayerDevicesResults holds the link-layer devices of
+// several machines.
+type LinkLayerDevicesResults struct {
+	Results []LinkLayerDevicesResult `json:"results"`
+}
+
 // MachinePortsParams holds the arguments for making a
 // FirewallerAPIV1.GetMachinePorts() API call.
 type MachinePortsParams struct {
@@ -529,6 +631,21 @@ type MachinePortsResults struct {
 	Results []MachinePortsResult `json:"results"`
 }
 
+// ExposeInfoResult holds a single result of the
+// FirewallerAPI.GetExposeInfo() API call. The CIDRs of each exposed
+// endpoint include those of the subnets in its spaces.
+type ExposeInfoResult struct {
+	Error            *Error                     `json:"error,omitempty"`
+	Exposed          bool                       `json:"exposed"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposeInfoResults holds all the results of the
+// FirewallerAPI.GetExposeInfo() API call.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
 // APIHostPortsResult holds the result of an APIHostPorts
 // call. Each element in the top level slice holds
 // the addresses for one API server.
@@ -281,6 +281,58 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints holds the expose settings to merge into those
+	// of the application's endpoints, keyed by endpoint name. The
+	// empty endpoint name stands for all endpoints. If no settings
+	// are given, the application is exposed to everyone. This field
+	// is only understood by Application facade version 5 and greater.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ApplicationSetEndpointBindings holds the parameters for making an
+// application SetEndpointBindings call.
+type ApplicationSetEndpointBindings struct {
+	ApplicationName string `json:"application"`
+
+	// EndpointBindings maps endpoint names to the names of the spaces
+	// they are to be bound to. The empty endpoint name stands for the
+	// application's default binding.
+	EndpointBindings map[string]string `json:"endpoint-bindings"`
+}
+
+// ApplicationUpdateSeries holds the parameters for making an
+// application UpdateApplicationSeries call.
+type ApplicationUpdateSeries struct {
+	ApplicationName string `json:"application"`
+	Series          string `json:"series"`
+
+	// Force allows series that the application's charm does not
+	// declare support for, provided it supports their OS.
+	Force bool `json:"force,omitempty"`
+}
+
+// ApplicationUpdateResourceTags holds the parameters for making the
+// application UpdateResourceTags call. A tag with an empty value is
+// removed from the application.
+type ApplicationUpdateResourceTags struct {
+	ApplicationName string            `json:"application"`
+	ResourceTags    map[string]string `json:"resource-tags"`
+}
+
+// ApplicationSetAntiAffinity holds the parameters for making the
+// application SetAntiAffinity call.
+type ApplicationSetAntiAffinity struct {
+	ApplicationName string `json:"application"`
+	AntiAffinity    bool   `json:"anti-affinity"`
+}
+
+// ExposedEndpoint describes the sources from which the opened
+// ports of an exposed application may be reached through one of
+// its endpoints.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -311,6 +363,14 @@ type ApplicationGetResults struct {
 	Config      map[string]interface{} `json:"config"`
 	Constraints constraints.Value      `json:"constraints"`
 	Series      string                 `json:"series"`
+
+	// ResourceTags holds the tags set on the application's cloud
+	// resources, in addition to the model's resource-tags.
+	ResourceTags map[string]string `json:"resource-tags,omitempty"`
+
+	// AntiAffinity reports whether the application's units are
+	// kept on different hosts.
+	AntiAffinity bool `json:"anti-affinity,omitempty"`
 }
 
 // ApplicationCharmRelations holds parameters for making the application CharmRelations call.
@@ -415,6 +475,23 @@ type LoginRequest struct {
 	Nonce       string           `json:"nonce"`
 	Macaroons   []macaroon.Slice `json:"macaroons"`
 	UserData    string           `json:"user-data"`
+
+	// IDToken holds an OpenID Connect ID token identifying
+	// an external user. It is only used when AuthTag is empty.
+	IDToken string `json:"id-token,omitempty"`
+}
+
+// OIDCLoginInfo describes the OpenID Connect provider
+// that a client should obtain an ID token from.
+type OIDCLoginInfo struct {
+	// Issuer holds the issuer URL of the provider.
+	Issuer string `json:"issuer"`
+
+	// ClientID holds the client ID to request the token for.
+	ClientID string `json:"client-id"`
+
+	// Reason holds the reason a new token is required.
+	Reason string `json:"reason,omitempty"`
 }
 
 // LoginRequestCompat holds credentials for identifying an entity to the Login v1
@@ -547,6 +624,10 @@ type ContainerConfig struct {
 	Proxy                   proxy.Settings `json:"proxy"`
 	AptProxy                proxy.Settings `json:"apt-proxy"`
 	AptMirror               string         `json:"apt-mirror"`
+	// ContainerNetworkingMethod is how containers are attached to the
+	// network of their host machine, "bridge" or "macvlan". It is empty
+	// for older controllers, which always bridge.
+	ContainerNetworkingMethod string `json:"container-networking-method,omitempty"`
 	*UpdateBehavior
 }
 
@@ -663,6 +744,12 @@ type LoginResult struct {
 	// required.
 	DischargeRequiredReason string `json:"discharge-required-error,omitempty"`
 
+	// OIDCLoginRequired implies that the login request has failed
+	// because an OpenID Connect ID token is required, and none of
+	// the other fields are populated. It describes the provider the
+	// token should be obtained from before calling Login again.
+	OIDCLoginRequired *OIDCLoginInfo `json:"oidc-login-required,omitempty"`
+
 	// Servers is the list of API server addresses.
 	Servers [][]HostPort `json:"servers,omitempty"`
 
@@ -904,6 +991,35 @@ type MacaroonResult struct {
 	Error  *Error             `json:"error,omitempty"`
 }
 
+// DestroyMachinesParams holds the parameters for a
+// MachineManager.DestroyMachineWithParams API request.
+type DestroyMachinesParams struct {
+	MachineTags []string `json:"machine-tags"`
+
+	// Force is true if the machines should be removed along
+	// with their units and containers.
+	Force bool `json:"force,omitempty"`
+
+	// KeepInstance is true if the cloud instances of the machines
+	// should be left running when the machines are removed.
+	KeepInstance bool `json:"keep-instance,omitempty"`
+}
+
+// DestroyModelsParams holds the parameters for a
+// ModelManager.DestroyModelsWithParams API request.
+type DestroyModelsParams struct {
+	Models []DestroyModelParams `json:"models"`
+}
+
+// DestroyModelParams holds the parameters for destroying a model.
+type DestroyModelParams struct {
+	ModelTag string `json:"model-tag"`
+
+	// KeepInstances is true if the cloud instances of the model's
+	// machines should be left running when the model is destroyed.
+	KeepInstances bool `json:"keep-instances,omitempty"`
+}
+
 // DestroyMachineResults contains the results of a MachineManager.Destroy
 // API request.
 type DestroyMachineResults struct {
@@ -935,6 +1051,63 @@ type DestroyMachineInfo struct {
 	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
 }
 
+// MaintainMachineArgs holds the parameters for a
+// MachineManager.MaintainMachines API request.
+type MaintainMachineArgs struct {
+	Args []MaintainMachineArg `json:"args"`
+}
+
+// MaintainMachineArg holds the tag of a machine, whether it is to be
+// in maintenance, and whether its stateless units are to be moved to
+// other machines.
+type MaintainMachineArg struct {
+	Entity      Entity `json:"tag"`
+	Maintenance bool   `json:"maintenance"`
+	Evacuate    bool   `json:"evacuate,omitempty"`
+}
+
+// MaintainMachineResults contains the results of a
+// MachineManager.MaintainMachines API request.
+type MaintainMachineResults struct {
+	Results []MaintainMachineResult `json:"results"`
+}
+
+// MaintainMachineResult contains one of the results of a
+// MachineManager.MaintainMachines API request.
+type MaintainMachineResult struct {
+	// EvacuatedUnits maps the tags of units moved off the machine
+	// to the tags of the units that replace them.
+	EvacuatedUnits map[string]string `json:"evacuated-units,omitempty"`
+
+	// KeptUnits holds the tags of the units left on the machine
+	// because they have storage attached.
+	KeptUnits []Entity `json:"kept-units,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// AdoptableInstancesArgs holds the provider IDs of the cloud instances
+// looked up by a MachineManager.AdoptableInstances API request.
+type AdoptableInstancesArgs struct {
+	InstanceIds []string `json:"instance-ids"`
+}
+
+// AdoptableInstanceResults contains the results of a
+// MachineManager.AdoptableInstances API request.
+type AdoptableInstanceResults struct {
+	Results []AdoptableInstanceResult `json:"results"`
+}
+
+// AdoptableInstanceResult contains one of the results of a
+// MachineManager.AdoptableInstances API request.
+type AdoptableInstanceResult struct {
+	// Addresses holds the addresses of the instance, as reported
+	// by the provider.
+	Addresses []Address `json:"addresses,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
 // DestroyApplicationResults contains the results of a DestroyApplication
 // API request.
 type DestroyApplicationResults struct {
@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ResourceTags holds the tags that should be set on a cloud resource.
+type ResourceTags struct {
+	ProviderId string            `json:"provider-id"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// ModelResourceTags holds the tags that should be set on the cloud
+// resources of a model.
+type ModelResourceTags struct {
+	// ModelTags holds the tags for resources shared by the whole
+	// model, such as security groups.
+	ModelTags map[string]string `json:"model-tags"`
+
+	// Instances holds the tags for each provisioned machine instance.
+	Instances []ResourceTags `json:"instances"`
+
+	// Volumes holds the tags for each provisioned volume.
+	Volumes []ResourceTags `json:"volumes"`
+}
+
+// ModelResourceTagsResult holds the result of an API call that
+// returns a ModelResourceTags or an error.
+type ModelResourceTagsResult struct {
+	Error  *Error            `json:"error,omitempty"`
+	Result ModelResourceTags `json:"result"`
+}
+
+// ModelResourceTagsResults holds the results of an API call that
+// returns ModelResourceTags for a number of models.
+type ModelResourceTagsResults struct {
+	Results []ModelResourceTagsResult `json:"results"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// Role holds the definition of a role: a named set of model
+// capabilities.
+type Role struct {
+	Name         string   `json:"name"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// AddRoles holds the roles to define in the controller.
+type AddRoles struct {
+	Roles []Role `json:"roles"`
+}
+
+// ListRolesResults holds the roles defined in the controller.
+type ListRolesResults struct {
+	Roles []Role `json:"roles"`
+}
+
+// RoleGrant holds a role to grant to, or revoke from, a user on the
+// connected model.
+type RoleGrant struct {
+	UserTag string `json:"user-tag"`
+	Role    string `json:"role"`
+}
+
+// RoleGrants holds the role grants to change.
+type RoleGrants struct {
+	Grants []RoleGrant `json:"grants"`
+}
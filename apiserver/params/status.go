@@ -99,6 +99,10 @@ type MachineStatus struct {
 	Jobs      []multiwatcher.MachineJob `json:"jobs"`
 	HasVote   bool                      `json:"has-vote"`
 	WantsVote bool                      `json:"wants-vote"`
+
+	// Maintenance is true if the machine is in maintenance, and so is
+	// not available for the placement of new units or containers.
+	Maintenance bool `json:"maintenance,omitempty"`
 }
 
 // ApplicationStatus holds status info about an application.
@@ -115,6 +119,10 @@ type ApplicationStatus struct {
 	MeterStatuses   map[string]MeterStatus `json:"meter-statuses"`
 	Status          DetailedStatus         `json:"status"`
 	WorkloadVersion string                 `json:"workload-version"`
+
+	// ExposedEndpoints holds the expose settings of the application's
+	// endpoints, if it is exposed to specific spaces or CIDRs only.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
@@ -149,6 +157,10 @@ type UnitStatus struct {
 	Charm         string                `json:"charm"`
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
+
+	// Series holds the series of the unit's machine, if it differs
+	// from the series of the unit's application.
+	Series string `json:"series,omitempty"`
 }
 
 // RelationStatus holds status info about a relation.
@@ -10,6 +10,14 @@ type UndertakerModelInfo struct {
 	GlobalName string `json:"global-name"`
 	IsSystem   bool   `json:"is-system"`
 	Life       Life   `json:"life"`
+
+	// KeepInstances is true if the model was destroyed without
+	// stopping the cloud instances of its machines.
+	KeepInstances bool `json:"keep-instances,omitempty"`
+
+	// KeptInstances holds the ids of the cloud instances that were
+	// left running when their machines were removed from the model.
+	KeptInstances []string `json:"kept-instances,omitempty"`
 }
 
 // UndertakerModelInfoResult holds the result of an API call that returns an
@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// UpdateSeriesArg holds the tag of a machine and the series it is
+// to be upgraded to.
+type UpdateSeriesArg struct {
+	Entity Entity `json:"tag"`
+	Series string `json:"series,omitempty"`
+}
+
+// UpdateSeriesArgs holds the parameters for the UpgradeSeriesPrepare
+// and UpgradeSeriesComplete API calls.
+type UpdateSeriesArgs struct {
+	Args []UpdateSeriesArg `json:"args"`
+}
+
+// UpgradeSeriesStatusParam holds the tag of a machine or unit, and how
+// far through a series upgrade it has got.
+type UpgradeSeriesStatusParam struct {
+	Entity Entity `json:"entity"`
+	Status string `json:"status"`
+}
+
+// UpgradeSeriesStatusParams holds the parameters for setting the series
+// upgrade statuses of several machines or units.
+type UpgradeSeriesStatusParams struct {
+	Params []UpgradeSeriesStatusParam `json:"params"`
+}
+
+// UpgradeSeriesStatusResult holds how far through a series upgrade a
+// machine or unit has got, or an error.
+type UpgradeSeriesStatusResult struct {
+	Status string `json:"status,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// UpgradeSeriesStatusResults holds the series upgrade statuses of
+// several machines or units.
+type UpgradeSeriesStatusResults struct {
+	Results []UpgradeSeriesStatusResult `json:"results,omitempty"`
+}
+
+// UpgradeSeriesUnitStatusesResult holds how far through the series
+// upgrade of a machine each unit deployed to it has got, keyed by
+// unit name, or an error.
+type UpgradeSeriesUnitStatusesResult struct {
+	Statuses map[string]string `json:"statuses,omitempty"`
+	Error    *Error            `json:"error,omitempty"`
+}
+
+// UpgradeSeriesUnitStatusesResults holds the unit series upgrade
+// statuses of several machines.
+type UpgradeSeriesUnitStatusesResults struct {
+	Results []UpgradeSeriesUnitStatusesResult `json:"results,omitempty"`
+}
@@ -16,6 +16,19 @@ type UserInfo struct {
 	DateCreated    time.Time  `json:"date-created"`
 	LastConnection *time.Time `json:"last-connection,omitempty"`
 	Disabled       bool       `json:"disabled"`
+
+	// ApplicationAccess holds the access the user has been granted
+	// on individual applications.
+	ApplicationAccess []UserApplicationAccess `json:"application-access,omitempty"`
+}
+
+// UserApplicationAccess holds the access a user has been granted on an
+// application in a model.
+type UserApplicationAccess struct {
+	ModelTag       string `json:"model-tag"`
+	ModelName      string `json:"model-name"`
+	ApplicationTag string `json:"application-tag"`
+	Access         string `json:"access"`
 }
 
 // UserInfoResult holds the result of a UserInfo call.
@@ -67,3 +80,42 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// AddGroups holds the parameters for adding new groups of users.
+type AddGroups struct {
+	Groups []AddGroup `json:"groups"`
+}
+
+// AddGroup holds the parameters for adding one group of users.
+type AddGroup struct {
+	Name string `json:"name"`
+}
+
+// GroupMembersArgs holds the parameters for changing the members
+// of groups of users.
+type GroupMembersArgs struct {
+	Args []GroupMembers `json:"args"`
+}
+
+// GroupMembers holds the name of a group of users along with the
+// tags of users to add to or remove from it.
+type GroupMembers struct {
+	Group   string   `json:"group"`
+	Members []string `json:"members"`
+}
+
+// GroupInfo holds information on a group of users.
+type GroupInfo struct {
+	Name        string    `json:"name"`
+	CreatedBy   string    `json:"created-by"`
+	DateCreated time.Time `json:"date-created"`
+
+	// Members holds the names of the users
+	// that are members of the group.
+	Members []string `json:"members"`
+}
+
+// GroupInfoResults holds the result of a GroupInfo API call.
+type GroupInfoResults struct {
+	Results []GroupInfo `json:"results"`
+}
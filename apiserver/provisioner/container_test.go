@@ -84,6 +84,44 @@ func (s *containerProvisionerSuite) TestPrepareContainerInterfaceInfoPermission(
 // TODO(jam): Add a test for requesting PrepareContainerInterfaceInfo with a
 // machine that is not yet provisioned.
 
+func (s *containerProvisionerSuite) TestPrepareAddedContainerInterfaceInfoPermission(c *gc.C) {
+	// Login as a machine agent for machine 1, which has a container put on it
+	addContainerToMachine(c, s.State, s.machines[1])
+	addContainerToMachine(c, s.State, s.machines[2])
+
+	anAuthorizer := s.authorizer
+	anAuthorizer.Controller = false
+	anAuthorizer.Tag = s.machines[1].Tag()
+	aProvisioner, err := provisioner.NewProvisionerAPI(s.State, s.resources, anAuthorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aProvisioner, gc.NotNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{
+			Tag: "machine-1/lxd/0", // valid, but not provisioned
+		}, {
+			Tag: "machine-2/lxd/0", // wrong host machine
+		}, {
+			Tag: "machine-2", // host machine
+		}}}
+	results, err := aProvisioner.PrepareAddedContainerInterfaceInfo(args)
+	c.Assert(err, gc.ErrorMatches, "dummy provider network config not supported")
+	c.Skip("dummy provider needs networking https://pad.lv/1651974")
+	// Overall request is ok
+	c.Assert(err, jc.ErrorIsNil)
+
+	errors := make([]*params.Error, 0)
+	c.Check(results.Results, gc.HasLen, 3)
+	for _, configResult := range results.Results {
+		errors = append(errors, configResult.Error)
+	}
+	c.Check(errors, gc.DeepEquals, []*params.Error{
+		{Message: `container "1/lxd/0" not provisioned`},
+		apiservertesting.ErrUnauthorized, // not 2/lxd/0
+		apiservertesting.ErrUnauthorized, // nor 2
+	})
+}
+
 func (s *containerProvisionerSuite) TestHostChangesForContainersPermission(c *gc.C) {
 	// Login as a machine agent for machine 1, which has a container put on it
 	addContainerToMachine(c, s.State, s.machines[1])
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/network/containerizer"
@@ -276,6 +277,7 @@ func (p *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.Proxy = config.ProxySettings()
 	result.AptProxy = config.AptProxySettings()
 	result.AptMirror = config.AptMirror()
+	result.ContainerNetworkingMethod = config.ContainerNetworkingMethod()
 
 	return result, nil
 }
@@ -348,6 +350,46 @@ func (p *ProvisionerAPI) Series(args params.Entities) (params.StringResults, err
 	return result, nil
 }
 
+// KeepInstance returns, for each given machine entity, whether its
+// cloud instance should be left running when the machine is removed.
+func (p *ProvisionerAPI) KeepInstance(args params.Entities) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = machine.KeepInstance()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// KeptInstances returns the ids of the cloud instances that were left
+// running when their machines were removed from the model. The
+// provisioner must not stop them as unknown instances.
+func (p *ProvisionerAPI) KeptInstances() (params.StringsResult, error) {
+	var result params.StringsResult
+	model, err := p.st.Model()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, id := range model.KeptInstances() {
+		result.Result = append(result.Result, string(id))
+	}
+	return result, nil
+}
+
 // DistributionGroup returns, for each given machine entity,
 // a slice of instance.Ids that belong to the same distribution
 // group as that machine. This information may be used to
@@ -526,6 +568,22 @@ func (p *ProvisionerAPI) WatchMachineErrorRetry() (params.NotifyWatchResult, err
 	return result, nil
 }
 
+// WatchEndpointBindings returns a NotifyWatcher that notifies when the
+// endpoint bindings of any application in the model change, as the
+// containers hosting the application's units may need to join further
+// spaces.
+func (p *ProvisionerAPI) WatchEndpointBindings() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := p.st.WatchEndpointBindings()
+	// Consume any initial event and forward it to the result.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = p.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
 // ReleaseContainerAddresses finds addresses allocated to a container and marks
 // them as Dead, to be released and removed. It accepts container tags as
 // arguments.
@@ -593,6 +651,27 @@ func (p *ProvisionerAPI) GetContainerInterfaceInfo(args params.Entities) (
 	return p.prepareOrGetContainerInterfaceInfo(args, true)
 }
 
+// PrepareAddedContainerInterfaceInfo gives provisioned containers devices
+// on the host machine bridges for spaces they have since been required to
+// join, allocating their addresses, and returns information to configure
+// networking for the added devices only. It accepts container tags as
+// arguments.
+func (p *ProvisionerAPI) PrepareAddedContainerInterfaceInfo(args params.Entities) (
+	params.MachineNetworkConfigResults,
+	error,
+) {
+	ctx := &prepareOrGetContext{
+		result: params.MachineNetworkConfigResults{
+			Results: make([]params.MachineNetworkConfigResult, len(args.Entities)),
+		},
+		added: true,
+	}
+	if err := p.processEachContainer(args, ctx); err != nil {
+		return ctx.result, errors.Trace(err)
+	}
+	return ctx.result, nil
+}
+
 // perContainerHandler is the interface we need to trigger processing on
 // every container passed in as a list of things to process.
 type perContainerHandler interface {
@@ -652,9 +731,23 @@ func (p *ProvisionerAPI) processEachContainer(args params.Entities, handler perC
 	return nil
 }
 
+// newBridgePolicy returns the policy for attaching containers to the
+// network of their host machine, according to the model config.
+func newBridgePolicy(env environs.Environ, supportContainerAddresses bool) containerizer.BridgePolicy {
+	cfg := env.Config()
+	return containerizer.BridgePolicy{
+		NetBondReconfigureDelay: cfg.NetBondReconfigureDelay(),
+		UseLocalBridges:         !supportContainerAddresses,
+		UseMacvlan:              cfg.ContainerNetworkingMethod() == config.ContainerNetworkingMacvlan,
+	}
+}
+
 type prepareOrGetContext struct {
 	result   params.MachineNetworkConfigResults
 	maintain bool
+	// added is set when only the devices added to an already
+	// provisioned container are wanted.
+	added bool
 }
 
 func (ctx *prepareOrGetContext) SetError(idx int, err *params.Error) {
@@ -671,15 +764,26 @@ func (ctx *prepareOrGetContext) ProcessOneContainer(env environs.Environ, idx in
 			return errors.Errorf("container %q already provisioned as %q", container, containerId)
 		}
 	}
+	if ctx.added && errors.IsNotProvisioned(err) {
+		return errors.Errorf("container %q not provisioned", container)
+	}
 	// The only error we allow is NotProvisioned
 	if err != nil && !errors.IsNotProvisioned(err) {
 		return err
 	}
 
 	supportContainerAddresses := environs.SupportsContainerAddresses(env)
-	bridgePolicy := containerizer.BridgePolicy{
-		NetBondReconfigureDelay: env.Config().NetBondReconfigureDelay(),
-		UseLocalBridges:         !supportContainerAddresses,
+	bridgePolicy := newBridgePolicy(env, supportContainerAddresses)
+
+	existing := set.NewStrings()
+	if ctx.added {
+		devices, err := container.AllLinkLayerDevices()
+		if err != nil {
+			return err
+		}
+		for _, device := range devices {
+			existing.Add(device.Name())
+		}
 	}
 
 	// TODO(jam): 2017-01-31 PopulateContainerLinkLayerDevices should really
@@ -690,10 +794,20 @@ func (ctx *prepareOrGetContext) ProcessOneContainer(env environs.Environ, idx in
 		return err
 	}
 
-	containerDevices, err := container.AllLinkLayerDevices()
+	allDevices, err := container.AllLinkLayerDevices()
 	if err != nil {
 		return err
 	}
+	var containerDevices []*state.LinkLayerDevice
+	for _, device := range allDevices {
+		if !existing.Contains(device.Name()) {
+			containerDevices = append(containerDevices, device)
+		}
+	}
+	if ctx.added && len(containerDevices) == 0 {
+		// The container is already in all the spaces it needs.
+		return nil
+	}
 
 	preparedInfo := make([]network.InterfaceInfo, len(containerDevices))
 	for j, device := range containerDevices {
@@ -828,10 +942,7 @@ type hostChangesContext struct {
 }
 
 func (ctx *hostChangesContext) ProcessOneContainer(env environs.Environ, idx int, host, container *state.Machine) error {
-	bridgePolicy := containerizer.BridgePolicy{
-		NetBondReconfigureDelay: env.Config().NetBondReconfigureDelay(),
-		UseLocalBridges:         !environs.SupportsContainerAddresses(env),
-	}
+	bridgePolicy := newBridgePolicy(env, environs.SupportsContainerAddresses(env))
 	bridges, reconfigureDelay, err := bridgePolicy.FindMissingBridgesForContainer(host, container)
 	if err != nil {
 		return err
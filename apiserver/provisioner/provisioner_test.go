@@ -817,6 +817,47 @@ func (s *withoutControllerSuite) TestSeries(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestKeepInstance(c *gc.C) {
+	err := s.machines[1].SetKeepInstance(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: "machine-42"},
+		{Tag: "unit-foo-0"},
+	}}
+	result, err := s.provisioner.KeepInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: false},
+			{Result: true},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *withoutControllerSuite) TestKeptInstances(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("i-kept", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetKeepInstance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.MarkForRemoval()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.provisioner.KeptInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResult{
+		Result: []string{"i-kept"},
+	})
+}
+
 func (s *withoutControllerSuite) TestDistributionGroup(c *gc.C) {
 	addUnits := func(name string, machines ...*state.Machine) (units []*state.Unit) {
 		svc := s.AddTestingService(c, name, s.AddTestingCharm(c, name))
@@ -1195,6 +1236,18 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	c.Check(results.Proxy, gc.DeepEquals, expectedProxy)
 	c.Check(results.AptProxy, gc.DeepEquals, expectedAPTProxy)
 	c.Check(results.AptMirror, gc.DeepEquals, "http://example.mirror.com")
+	c.Check(results.ContainerNetworkingMethod, gc.Equals, "bridge")
+}
+
+func (s *withoutControllerSuite) TestContainerConfigMacvlan(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"container-networking-method": "macvlan",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.provisioner.ContainerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.ContainerNetworkingMethod, gc.Equals, "macvlan")
 }
 
 func (s *withoutControllerSuite) TestSetSupportedContainers(c *gc.C) {
@@ -1351,6 +1404,31 @@ func (s *withoutControllerSuite) TestWatchMachineErrorRetry(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{})
 }
 
+func (s *withoutControllerSuite) TestWatchEndpointBindings(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.provisioner.WatchEndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = app.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *withoutControllerSuite) TestFindTools(c *gc.C) {
 	args := params.FindToolsParams{
 		MajorVersion: -1,
@@ -6,7 +6,6 @@ package provisioner
 import (
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/series"
@@ -16,11 +15,10 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
-	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -57,6 +55,13 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		return nil, errors.Trace(err)
 	}
 
+	if err := p.checkHostMaintenance(m); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := p.checkHostSpaces(m); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	volumes, err := p.machineVolumeParams(m)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -67,7 +72,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		jobs = append(jobs, job.ToParams())
 	}
 
-	tags, err := p.machineTags(m, jobs)
+	tags, err := p.machineTags(m)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -89,18 +94,23 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
+	antiAffinityGroups, err := p.machineAntiAffinityGroups(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine machine anti-affinity groups")
+	}
 
 	return &params.ProvisioningInfo{
-		Constraints:      cons,
-		Series:           m.Series(),
-		Placement:        m.Placement(),
-		Jobs:             jobs,
-		Volumes:          volumes,
-		Tags:             tags,
-		SubnetsToZones:   subnetsToZones,
-		EndpointBindings: endpointBindings,
-		ImageMetadata:    imageMetadata,
-		ControllerConfig: controllerCfg,
+		Constraints:        cons,
+		Series:             m.Series(),
+		Placement:          m.Placement(),
+		Jobs:               jobs,
+		Volumes:            volumes,
+		Tags:               tags,
+		SubnetsToZones:     subnetsToZones,
+		EndpointBindings:   endpointBindings,
+		ImageMetadata:      imageMetadata,
+		ControllerConfig:   controllerCfg,
+		AntiAffinityGroups: antiAffinityGroups,
 	}, nil
 }
 
@@ -136,9 +146,13 @@ func (p *ProvisionerAPI) machineVolumeParams(m *state.Machine) ([]params.VolumeP
 		if err != nil {
 			return nil, errors.Annotatef(err, "getting volume %q storage instance", volumeTag.Id())
 		}
+		ownerTagger, err := storagecommon.StorageOwnerTagger(storageInstance, p.st.Application)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting volume %q storage owner", volumeTag.Id())
+		}
 		volumeParams, err := storagecommon.VolumeParams(
 			volume, storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(),
-			modelConfig, p.storagePoolManager, p.storageProviderRegistry,
+			modelConfig, ownerTagger, p.storagePoolManager, p.storageProviderRegistry,
 		)
 		if err != nil {
 			return nil, errors.Annotatef(err, "getting volume %q parameters", volumeTag.Id())
@@ -174,26 +188,52 @@ func (p *ProvisionerAPI) machineVolumeParams(m *state.Machine) ([]params.VolumeP
 	return allVolumeParams, nil
 }
 
-// machineTags returns machine-specific tags to set on the instance.
-func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.MachineJob) (map[string]string, error) {
-	// Names of all units deployed to the machine.
-	//
-	// TODO(axw) 2015-06-02 #1461358
-	// We need a worker that periodically updates
-	// instance tags with current deployment info.
-	units, err := m.Units()
+// machineAntiAffinityGroups returns the sorted names of the
+// applications with anti-affinity that have principal units on the
+// machine or in its containers, whose instances the provider should
+// keep on different physical hosts.
+func (p *ProvisionerAPI) machineAntiAffinityGroups(m *state.Machine) ([]string, error) {
+	machines := []*state.Machine{m}
+	containers, err := m.Containers()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	unitNames := make([]string, 0, len(units))
-	for _, unit := range units {
-		if !unit.IsPrincipal() {
+	for _, id := range containers {
+		container, err := p.st.Machine(id)
+		if errors.IsNotFound(err) {
 			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machines = append(machines, container)
+	}
+	groups := set.NewStrings()
+	for _, machine := range machines {
+		units, err := machine.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			if !unit.IsPrincipal() || groups.Contains(unit.ApplicationName()) {
+				continue
+			}
+			app, err := unit.Application()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if app.AntiAffinity() {
+				groups.Add(app.Name())
+			}
 		}
-		unitNames = append(unitNames, unit.Name())
 	}
-	sort.Strings(unitNames)
+	if groups.IsEmpty() {
+		return nil, nil
+	}
+	return groups.SortedValues(), nil
+}
 
+// machineTags returns machine-specific tags to set on the instance.
+func (p *ProvisionerAPI) machineTags(m *state.Machine) (map[string]string, error) {
 	cfg, err := p.st.ModelConfig()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -202,11 +242,7 @@ func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.Machi
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	machineTags := instancecfg.InstanceTags(cfg.UUID(), controllerCfg.ControllerUUID(), cfg, jobs)
-	if len(unitNames) > 0 {
-		machineTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
-	}
-	return machineTags, nil
+	return common.MachineInstanceTags(m, cfg, controllerCfg.ControllerUUID(), p.st.Application)
 }
 
 // machineSubnetsAndZones returns a map of subnet provider-specific id
@@ -271,6 +307,65 @@ func (p *ProvisionerAPI) machineSubnetsAndZones(m *state.Machine) (map[string][]
 	return subnetsToZones, nil
 }
 
+// checkHostMaintenance returns an error if the machine is a container
+// whose host machine is in maintenance. Containers added before the host
+// entered maintenance are not provisioned until it leaves it.
+func (p *ProvisionerAPI) checkHostMaintenance(m *state.Machine) error {
+	parentId, ok := m.ParentId()
+	if !ok {
+		return nil
+	}
+	host, err := p.st.Machine(parentId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if host.InMaintenance() {
+		return errors.Errorf("host machine %q of container %q is in maintenance", parentId, m.Id())
+	}
+	return nil
+}
+
+// checkHostSpaces returns an error if the machine is a container whose
+// host machine has no address in some of the spaces the container needs
+// access to. Such a container cannot be provisioned on its host, as it
+// could not be connected to those spaces. Hosts without any known
+// addresses are not checked.
+func (p *ProvisionerAPI) checkHostSpaces(m *state.Machine) error {
+	parentId, ok := m.ParentId()
+	if !ok {
+		return nil
+	}
+	spaces, err := m.DesiredSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if spaces.IsEmpty() {
+		return nil
+	}
+	host, err := p.st.Machine(parentId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	addresses, err := host.AllAddresses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+	hostSpaces, err := host.AllSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if missing := spaces.Difference(hostSpaces); !missing.IsEmpty() {
+		return errors.Errorf(
+			"host machine %q has no address in space(s) %s required by container %q",
+			parentId, network.QuoteSpaceSet(missing), m.Id(),
+		)
+	}
+	return nil
+}
+
 func (p *ProvisionerAPI) machineEndpointBindings(m *state.Machine) (map[string]string, error) {
 	units, err := m.Units()
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
@@ -229,6 +230,72 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithApplicationResourceTags(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"resource-tags": "team=ops cost-centre=42",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = wordpress.UpdateResourceTags(map[string]string{"team": "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.provisioner.ProvisioningInfo(params.Entities{Entities: []params.Entity{
+		{Tag: machine.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Tags, jc.DeepEquals, map[string]string{
+		tags.JujuController:    coretesting.ControllerTag.Id(),
+		tags.JujuModel:         coretesting.ModelTag.Id(),
+		tags.JujuUnitsDeployed: unit.Name(),
+		"team":                 "web",
+		"cost-centre":          "42",
+	})
+}
+
+func (s *withoutControllerSuite) TestProvisioningInfoWithAntiAffinityGroups(c *gc.C) {
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = wordpress.SetAntiAffinity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnitWithPlacement(unit, &instance.Placement{
+		Scope:     string(instance.LXD),
+		Directive: machine.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Applications without anti-affinity are not grouped.
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err = mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.provisioner.ProvisioningInfo(params.Entities{Entities: []params.Entity{
+		{Tag: machine.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.AntiAffinityGroups, jc.DeepEquals, []string{"wordpress"})
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstraints(c *gc.C) {
 	// Add an empty space.
 	_, err := s.State.AddSpace("empty", "", nil, true)
@@ -269,6 +336,89 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstra
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoContainerHostNotInSpaces(c *gc.C) {
+	s.addSpacesAndSubnets(c)
+
+	// The host only has an address in space1.
+	host := s.machines[0]
+	err := host.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+		IsUp: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = host.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		CIDRAddress:  "10.10.0.5/24",
+		ConfigMethod: state.StaticAddress,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpressCharm := s.AddTestingCharm(c, "wordpress")
+	wordpressService := s.AddTestingServiceWithBindings(c, "wordpress", wordpressCharm, map[string]string{
+		"url": "space1",
+		"db":  "space2",
+	})
+	wordpressUnit, err := wordpressService.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpressUnit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	anAuthorizer := s.authorizer
+	anAuthorizer.Controller = false
+	anAuthorizer.Tag = host.Tag()
+	aProvisioner, err := provisioner.NewProvisionerAPI(s.State, s.resources, anAuthorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: container.Tag().String()},
+	}}
+	result, err := aProvisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ProvisioningInfoResults{
+		Results: []params.ProvisioningInfoResult{{
+			Error: apiservertesting.ServerError(
+				`host machine "0" has no address in space(s) "space2" required by container "0/lxd/0"`,
+			),
+		}},
+	})
+}
+
+func (s *withoutControllerSuite) TestProvisioningInfoContainerHostInMaintenance(c *gc.C) {
+	host := s.machines[0]
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = host.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	anAuthorizer := s.authorizer
+	anAuthorizer.Controller = false
+	anAuthorizer.Tag = host.Tag()
+	aProvisioner, err := provisioner.NewProvisionerAPI(s.State, s.resources, anAuthorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: container.Tag().String()},
+	}}
+	result, err := aProvisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ProvisioningInfoResults{
+		Results: []params.ProvisioningInfoResult{{
+			Error: apiservertesting.ServerError(
+				`host machine "0" of container "0/lxd/0" is in maintenance`,
+			),
+		}},
+	})
+}
+
 func (s *withoutControllerSuite) TestStorageProviderFallbackToType(c *gc.C) {
 	template := state.MachineTemplate{
 		Series:    "quantal",
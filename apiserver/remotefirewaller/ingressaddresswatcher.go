@@ -181,20 +181,23 @@ func formatAsCIDR(addresses []string) []string {
 	return result
 }
 
+// unitAddress returns the address the unit is reached at in the
+// relation, which is the one in the space its endpoint is bound to,
+// or its public address.
 func (w *IngressAddressWatcher) unitAddress(unit Unit) (string, bool, error) {
-	addr, err := unit.PublicAddress()
+	addr, err := w.rel.UnitSettingsAddress(unit)
 	if errors.IsNotAssigned(err) {
 		logger.Debugf("unit %s is not assigned to a machine, can't get address", unit.Name())
 		return "", false, nil
 	}
 	if network.IsNoAddressError(err) {
-		logger.Debugf("unit %s has no public address", unit.Name())
+		logger.Debugf("unit %s has no address", unit.Name())
 		return "", false, nil
 	}
 	if err != nil {
 		return "", false, err
 	}
-	logger.Debugf("unit %q has public address %q", unit.Name(), addr.Value)
+	logger.Debugf("unit %q has address %q", unit.Name(), addr.Value)
 	return addr.Value, true, nil
 }
 
@@ -214,9 +217,6 @@ func (w *IngressAddressWatcher) processUnitChanges(c params.RelationUnitsChange)
 			return false, errors.Trace(err)
 		}
 
-		// We need to know whether to look at the public or cloud local address.
-		// For now, we'll use the public address and later if needed use a watcher
-		// parameter to look at the cloud local address.
 		addr, ok, err := w.unitAddress(u)
 		if err != nil {
 			return false, err
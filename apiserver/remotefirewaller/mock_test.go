@@ -195,6 +195,10 @@ func (r *mockRelation) UnitInScope(u remotefirewaller.Unit) (bool, error) {
 	return r.inScope.Contains(u.Name()), nil
 }
 
+func (r *mockRelation) UnitSettingsAddress(u remotefirewaller.Unit) (network.Address, error) {
+	return u.PublicAddress()
+}
+
 func newMockRelationUnitsWatcher() *mockRelationUnitsWatcher {
 	w := &mockRelationUnitsWatcher{changes: make(chan params.RelationUnitsChange, 1)}
 	go w.doneWhenDying()
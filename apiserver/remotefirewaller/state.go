@@ -50,6 +50,7 @@ type Relation interface {
 	Endpoints() []state.Endpoint
 	WatchUnits(applicationName string) (state.RelationUnitsWatcher, error)
 	UnitInScope(Unit) (bool, error)
+	UnitSettingsAddress(Unit) (network.Address, error)
 }
 
 type relationShim struct {
@@ -64,6 +65,14 @@ func (r relationShim) UnitInScope(u Unit) (bool, error) {
 	return ru.InScope()
 }
 
+func (r relationShim) UnitSettingsAddress(u Unit) (network.Address, error) {
+	ru, err := r.Relation.Unit(u.(*state.Unit))
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	return ru.SettingsAddress()
+}
+
 func (st stateShim) Application(name string) (Application, error) {
 	app, err := st.State.Application(name)
 	if err != nil {
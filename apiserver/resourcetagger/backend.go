@@ -0,0 +1,32 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the resource tagger facade needs from
+// state.State.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	ModelConfig() (*config.Config, error)
+	Model() (*state.Model, error)
+	AllMachines() ([]*state.Machine, error)
+	AllVolumes() ([]state.Volume, error)
+	Application(name string) (*state.Application, error)
+	StorageInstance(names.StorageTag) (state.StorageInstance, error)
+
+	// WatchForModelConfigChanges returns a NotifyWatcher that
+	// triggers whenever the model's config changes.
+	WatchForModelConfigChanges() state.NotifyWatcher
+
+	// WatchApplicationsResourceTags returns a NotifyWatcher that
+	// triggers whenever an application in the model changes.
+	WatchApplicationsResourceTags() state.NotifyWatcher
+}
@@ -0,0 +1,251 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcetagger provides the API facade used by the resource
+// tagger worker, which keeps the tags on a model's existing cloud
+// resources up to date.
+package resourcetagger
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API implements the API facade used by the resource tagger.
+type API struct {
+	backend        Backend
+	resources      facade.Resources
+	canManageModel func(modelUUID string) bool
+}
+
+// NewAPI returns a new resource tagger API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+		canManageModel: func(modelUUID string) bool {
+			return modelUUID == authorizer.ConnectedModel()
+		},
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, res, auth)
+}
+
+// WatchResourceTags returns a watcher for each requested model that
+// signals whenever the tags of its cloud resources may have changed:
+// when the model's config changes, or when an application's resource
+// tags change.
+func (api *API) WatchResourceTags(models params.Entities) params.NotifyWatchResults {
+	results := make([]params.NotifyWatchResult, len(models.Entities))
+	for i, entity := range models.Entities {
+		id, err := api.watchResourceTagsForTag(entity.Tag)
+		results[i].NotifyWatcherId = id
+		results[i].Error = common.ServerError(err)
+	}
+	return params.NotifyWatchResults{Results: results}
+}
+
+func (api *API) watchResourceTagsForTag(tag string) (string, error) {
+	if err := api.checkModelAuthorization(tag); err != nil {
+		return "", errors.Trace(err)
+	}
+	watch := common.NewMultiNotifyWatcher(
+		api.backend.WatchForModelConfigChanges(),
+		api.backend.WatchApplicationsResourceTags(),
+	)
+	if _, ok := <-watch.Changes(); ok {
+		return api.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// ModelResourceTags returns, for each requested model, the tags that
+// should be set on its provisioned instances and volumes, and on the
+// resources shared by the whole model. A user-specified tag that was
+// set on the model's resources but is no longer specified for a
+// resource is returned with an empty value, so that it is removed.
+func (api *API) ModelResourceTags(models params.Entities) params.ModelResourceTagsResults {
+	results := make([]params.ModelResourceTagsResult, len(models.Entities))
+	for i, entity := range models.Entities {
+		result, err := api.modelResourceTagsForTag(entity.Tag)
+		results[i].Result = result
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ModelResourceTagsResults{Results: results}
+}
+
+func (api *API) modelResourceTagsForTag(tag string) (params.ModelResourceTags, error) {
+	var result params.ModelResourceTags
+	if err := api.checkModelAuthorization(tag); err != nil {
+		return result, errors.Trace(err)
+	}
+	modelConfig, err := api.backend.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	modelTag := api.backend.ModelTag()
+	controllerTag := api.backend.ControllerTag()
+	result.ModelTags = tags.ResourceTags(modelTag, controllerTag, modelConfig)
+
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		// Containers and manually provisioned machines
+		// have no cloud instance of their own.
+		if m.IsContainer() {
+			continue
+		}
+		if manual, err := m.IsManual(); err != nil {
+			return result, errors.Trace(err)
+		} else if manual {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		machineTags, err := common.MachineInstanceTags(
+			m, modelConfig, controllerTag.Id(), api.backend.Application,
+		)
+		if err != nil {
+			return result, errors.Annotatef(err, "getting tags for machine %q", m.Id())
+		}
+		result.Instances = append(result.Instances, params.ResourceTags{
+			ProviderId: string(instId),
+			Tags:       machineTags,
+		})
+	}
+
+	volumes, err := api.backend.AllVolumes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		// Machine-scoped volumes are managed by the machine
+		// agent, and are not cloud resources.
+		if strings.Contains(v.VolumeTag().Id(), "/") {
+			continue
+		}
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		if info.VolumeId == "" {
+			continue
+		}
+		volumeTags, err := api.volumeTags(v, modelConfig)
+		if err != nil {
+			return result, errors.Annotatef(err, "getting tags for volume %q", v.VolumeTag().Id())
+		}
+		result.Volumes = append(result.Volumes, params.ResourceTags{
+			ProviderId: info.VolumeId,
+			Tags:       volumeTags,
+		})
+	}
+	if err := api.addRemovedResourceTags(&result); err != nil {
+		return params.ModelResourceTags{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// addRemovedResourceTags records the names of the user-specified tags
+// in the given resource tags, and gives each resource an empty value
+// for every recorded tag it should no longer have.
+func (api *API) addRemovedResourceTags(result *params.ModelResourceTags) error {
+	model, err := api.backend.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	allTags := []map[string]string{result.ModelTags}
+	for _, r := range result.Instances {
+		allTags = append(allTags, r.Tags)
+	}
+	for _, r := range result.Volumes {
+		allTags = append(allTags, r.Tags)
+	}
+	recorded := set.NewStrings(model.ResourceTagKeys()...)
+	specified := set.NewStrings()
+	for _, resourceTags := range allTags {
+		for k := range resourceTags {
+			if !strings.HasPrefix(k, tags.JujuTagPrefix) {
+				specified.Add(k)
+			}
+		}
+	}
+	if err := model.AddResourceTagKeys(specified.Difference(recorded).SortedValues()); err != nil {
+		return errors.Trace(err)
+	}
+	removeTags := func(resourceTags map[string]string) map[string]string {
+		for _, k := range recorded.Values() {
+			if _, ok := resourceTags[k]; !ok {
+				if resourceTags == nil {
+					resourceTags = make(map[string]string)
+				}
+				resourceTags[k] = ""
+			}
+		}
+		return resourceTags
+	}
+	result.ModelTags = removeTags(result.ModelTags)
+	for i := range result.Instances {
+		result.Instances[i].Tags = removeTags(result.Instances[i].Tags)
+	}
+	for i := range result.Volumes {
+		result.Volumes[i].Tags = removeTags(result.Volumes[i].Tags)
+	}
+	return nil
+}
+
+func (api *API) volumeTags(v state.Volume, modelConfig tags.ResourceTagger) (map[string]string, error) {
+	storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+		v.StorageInstance,
+		api.backend.StorageInstance,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ownerTagger, err := storagecommon.StorageOwnerTagger(storageInstance, api.backend.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return storagecommon.StorageTags(
+		storageInstance,
+		api.backend.ModelTag().Id(),
+		api.backend.ControllerTag().Id(),
+		modelConfig, ownerTagger,
+	)
+}
+
+func (api *API) checkModelAuthorization(tag string) error {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !api.canManageModel(modelTag.Id()) {
+		return errors.Trace(common.ErrPerm)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/resourcetagger"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type resourceTaggerSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *resourcetagger.API
+}
+
+var _ = gc.Suite(&resourceTaggerSuite{})
+
+func (s *resourceTaggerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+		ModelUUID:  s.State.ModelUUID(),
+	}
+	api, err := resourcetagger.NewFacade(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *resourceTaggerSuite) modelEntities(tags ...string) params.Entities {
+	entities := params.Entities{Entities: []params.Entity{{Tag: s.State.ModelTag().String()}}}
+	for _, tag := range tags {
+		entities.Entities = append(entities.Entities, params.Entity{Tag: tag})
+	}
+	return entities
+}
+
+func (s *resourceTaggerSuite) TestRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := resourcetagger.NewFacade(s.State, s.resources, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *resourceTaggerSuite) TestModelResourceTags(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"resource-tags": "team=ops cost-centre=42",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned(instance.Id("i-1"), "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = wordpress.UpdateResourceTags(map[string]string{"team": "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Unprovisioned machines and containers are skipped.
+	_, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetProvisioned(instance.Id("juju-lxd-1"), "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.api.ModelResourceTags(s.modelEntities(
+		"machine-0",
+		names.NewModelTag("12345678-1234-1234-1234-123456789abd").String(),
+	))
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, params.ModelResourceTags{
+		ModelTags: map[string]string{
+			tags.JujuController: coretesting.ControllerTag.Id(),
+			tags.JujuModel:      coretesting.ModelTag.Id(),
+			"team":              "ops",
+			"cost-centre":       "42",
+		},
+		Instances: []params.ResourceTags{{
+			ProviderId: "i-1",
+			Tags: map[string]string{
+				tags.JujuController:    coretesting.ControllerTag.Id(),
+				tags.JujuModel:         coretesting.ModelTag.Id(),
+				tags.JujuUnitsDeployed: unit.Name(),
+				"team":                 "web",
+				"cost-centre":          "42",
+			},
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *resourceTaggerSuite) TestModelResourceTagsRemovesUnspecifiedTags(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"resource-tags": "team=ops cost-centre=42",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned(instance.Id("i-1"), "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.api.ModelResourceTags(s.modelEntities())
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ResourceTagKeys(), jc.SameContents, []string{"team", "cost-centre"})
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"resource-tags": "team=db",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The cost-centre tag is still removed once the tag names
+	// have been recorded.
+	for i := 0; i < 2; i++ {
+		results = s.api.ModelResourceTags(s.modelEntities())
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Assert(results.Results[0].Error, gc.IsNil)
+		c.Assert(results.Results[0].Result, jc.DeepEquals, params.ModelResourceTags{
+			ModelTags: map[string]string{
+				tags.JujuController: coretesting.ControllerTag.Id(),
+				tags.JujuModel:      coretesting.ModelTag.Id(),
+				"team":              "db",
+				"cost-centre":       "",
+			},
+			Instances: []params.ResourceTags{{
+				ProviderId: "i-1",
+				Tags: map[string]string{
+					tags.JujuController: coretesting.ControllerTag.Id(),
+					tags.JujuModel:      coretesting.ModelTag.Id(),
+					"team":              "db",
+					"cost-centre":       "",
+				},
+			}},
+		})
+	}
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTags(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	results := s.api.WatchResourceTags(s.modelEntities())
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{NotifyWatcherId: "1"}},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.UpdateResourceTags(map[string]string{"team": "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"resource-tags": "team=ops",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTagsChecksModelTag(c *gc.C) {
+	results := s.api.WatchResourceTags(params.Entities{Entities: []params.Entity{
+		{Tag: names.NewModelTag("12345678-1234-1234-1234-123456789abd").String()},
+	}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
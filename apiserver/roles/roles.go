@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package roles provides the API facade used to define roles and to
+// grant them to users of a model.
+package roles
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade is used for API registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(NewStateBackend(st), auth)
+}
+
+// API implements the Roles facade.
+type API struct {
+	backend Backend
+	auth    facade.Authorizer
+}
+
+// NewAPI creates a new instance of the Roles facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		auth:    authorizer,
+	}, nil
+}
+
+func (api *API) checkPermission(perm permission.Access, target names.Tag) error {
+	allowed, err := api.auth.HasPermission(perm, target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) checkIsSuperuser() error {
+	return api.checkPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+}
+
+func (api *API) checkIsModelAdmin() error {
+	if err := api.checkIsSuperuser(); err == nil {
+		return nil
+	}
+	return api.checkPermission(permission.AdminAccess, api.backend.ModelTag())
+}
+
+// AddRoles defines new roles in the controller. Only controller
+// superusers may define roles.
+func (api *API) AddRoles(args params.AddRoles) (params.ErrorResults, error) {
+	if err := api.checkIsSuperuser(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Roles))
+	for i, arg := range args.Roles {
+		role := permission.Role{Name: arg.Name}
+		for _, c := range arg.Capabilities {
+			role.Capabilities = append(role.Capabilities, permission.Capability(c))
+		}
+		results[i].Error = common.ServerError(api.backend.AddRole(role))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// ListRoles returns the roles defined in the controller.
+func (api *API) ListRoles() (params.ListRolesResults, error) {
+	if err := api.checkPermission(permission.ReadAccess, api.backend.ModelTag()); err != nil {
+		if err := api.checkIsSuperuser(); err != nil {
+			return params.ListRolesResults{}, errors.Trace(err)
+		}
+	}
+	roles, err := api.backend.AllRoles()
+	if err != nil {
+		return params.ListRolesResults{}, errors.Trace(err)
+	}
+	result := make([]params.Role, len(roles))
+	for i, role := range roles {
+		result[i].Name = role.Name
+		for _, c := range role.Capabilities {
+			result[i].Capabilities = append(result[i].Capabilities, string(c))
+		}
+	}
+	return params.ListRolesResults{Roles: result}, nil
+}
+
+// GrantRoles grants roles to users on the model. Only model
+// administrators may grant roles.
+func (api *API) GrantRoles(args params.RoleGrants) (params.ErrorResults, error) {
+	return api.changeRoles(args, api.backend.GrantRole)
+}
+
+// RevokeRoles revokes roles from users on the model. Only model
+// administrators may revoke roles.
+func (api *API) RevokeRoles(args params.RoleGrants) (params.ErrorResults, error) {
+	return api.changeRoles(args, api.backend.RevokeRole)
+}
+
+func (api *API) changeRoles(args params.RoleGrants, change func(names.UserTag, string) error) (params.ErrorResults, error) {
+	if err := api.checkIsModelAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Grants))
+	for i, arg := range args.Grants {
+		userTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Error = common.ServerError(change(userTag, arg.Role))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package roles_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/roles"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type rolesSuite struct {
+	jujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *roles.API
+}
+
+var _ = gc.Suite(&rolesSuite{})
+
+func (s *rolesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{}
+	var err error
+	s.api, err = roles.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rolesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := roles.NewAPI(s.backend, &s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *rolesSuite) TestAddRoles(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.AddRoles(params.AddRoles{
+		Roles: []params.Role{
+			{Name: "operator", Capabilities: []string{"actions", "ssh"}},
+			{Name: "deployer", Capabilities: []string{"deploy"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "boom")
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{"AddRole", []interface{}{permission.Role{
+			Name:         "operator",
+			Capabilities: []permission.Capability{"actions", "ssh"},
+		}}},
+		{"AddRole", []interface{}{permission.Role{
+			Name:         "deployer",
+			Capabilities: []permission.Capability{"deploy"},
+		}}},
+	})
+}
+
+func (s *rolesSuite) TestAddRolesRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin" + coretesting.ModelTag.String())
+	_, err := s.api.AddRoles(params.AddRoles{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *rolesSuite) TestListRoles(c *gc.C) {
+	s.backend.roles = []permission.Role{{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"actions", "ssh"},
+	}}
+	result, err := s.api.ListRoles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListRolesResults{
+		Roles: []params.Role{{
+			Name:         "operator",
+			Capabilities: []string{"actions", "ssh"},
+		}},
+	})
+}
+
+func (s *rolesSuite) TestGrantRoles(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin" + coretesting.ModelTag.String())
+	results, err := s.api.GrantRoles(params.RoleGrants{
+		Grants: []params.RoleGrant{
+			{UserTag: "user-bob", Role: "operator"},
+			{UserTag: "machine-0", Role: "operator"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{"GrantRole", []interface{}{names.NewUserTag("bob"), "operator"}},
+	})
+}
+
+func (s *rolesSuite) TestRevokeRoles(c *gc.C) {
+	results, err := s.api.RevokeRoles(params.RoleGrants{
+		Grants: []params.RoleGrant{{UserTag: "user-bob", Role: "operator"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{"RevokeRole", []interface{}{names.NewUserTag("bob"), "operator"}},
+	})
+}
+
+func (s *rolesSuite) TestGrantRolesRequiresModelAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write" + coretesting.ModelTag.String())
+	_, err := s.api.GrantRoles(params.RoleGrants{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	jujutesting.Stub
+	roles []permission.Role
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AddRole(role permission.Role) error {
+	b.MethodCall(b, "AddRole", role)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AllRoles() ([]permission.Role, error) {
+	b.MethodCall(b, "AllRoles")
+	return b.roles, b.NextErr()
+}
+
+func (b *mockBackend) GrantRole(user names.UserTag, role string) error {
+	b.MethodCall(b, "GrantRole", user, role)
+	return b.NextErr()
+}
+
+func (b *mockBackend) RevokeRole(user names.UserTag, role string) error {
+	b.MethodCall(b, "RevokeRole", user, role)
+	return b.NextErr()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -385,7 +386,29 @@ func (r *apiHandler) GetAuthEntity() state.Entity {
 
 // HasPermission returns true if the logged in user can perform <operation> on <target>.
 func (r *apiHandler) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
-	return common.HasPermission(r.state.UserPermission, r.entity.Tag(), operation, target)
+	return common.HasPermission(r.userPermission(), r.entity.Tag(), operation, target)
+}
+
+// userPermission returns the function used to look up the logged in
+// user's access, which takes into account any groups they are in.
+func (r *apiHandler) userPermission() func(names.UserTag, names.Tag) (permission.Access, error) {
+	userTag, isUser := r.entity.Tag().(names.UserTag)
+	member, isMember := r.entity.(authentication.GroupMember)
+	if !isUser || !isMember {
+		return r.state.UserPermission
+	}
+	return common.GroupAccessGetter(r.state.UserPermission, userTag, member.Groups())
+}
+
+// userRoles returns the function used to look up the logged in
+// user's roles, which takes into account any groups they are in.
+func (r *apiHandler) userRoles() func(names.UserTag, names.Tag) ([]permission.Role, error) {
+	userTag, isUser := r.entity.Tag().(names.UserTag)
+	member, isMember := r.entity.(authentication.GroupMember)
+	if !isUser || !isMember {
+		return r.state.UserRoles
+	}
+	return common.GroupRolesGetter(r.state.UserRoles, userTag, member.Groups())
 }
 
 // UserHasPermission returns true if the passed in user can perform <operation> on <target>.
@@ -393,6 +416,11 @@ func (r *apiHandler) UserHasPermission(user names.UserTag, operation permission.
 	return common.HasPermission(r.state.UserPermission, user, operation, target)
 }
 
+// HasCapability returns true if the logged in user may exercise <capability> on <target>.
+func (r *apiHandler) HasCapability(capability permission.Capability, target names.Tag) (bool, error) {
+	return common.HasCapability(r.userPermission(), r.userRoles(), r.entity.Tag(), capability, target)
+}
+
 // DescribeFacades returns the list of available Facades and their Versions
 func DescribeFacades(registry *facade.Registry) []params.FacadeVersions {
 	facades := registry.List()
@@ -7,6 +7,7 @@ package sshclient
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -29,12 +30,50 @@ func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Fa
 	return &Facade{backend: backend, authorizer: authorizer}, nil
 }
 
-func (facade *Facade) checkIsModelAdmin() error {
-	isModelAdmin, err := facade.authorizer.HasPermission(permission.AdminAccess, facade.backend.ModelTag())
+// checkCanSSH returns an error if the authenticated user may not SSH
+// to every one of the given entities. Users without the ssh capability
+// on the model may still connect to units of applications they have
+// been granted admin access to.
+func (facade *Facade) checkCanSSH(entities []params.Entity) error {
+	modelTag := facade.backend.ModelTag()
+	canSSH, err := facade.authorizer.HasCapability(permission.SSHCapability, modelTag)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !isModelAdmin {
+	if canSSH {
+		return nil
+	}
+	if len(entities) == 0 {
+		return common.ErrPerm
+	}
+	for _, entity := range entities {
+		unitTag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			return common.ErrPerm
+		}
+		appName, err := names.UnitApplication(unitTag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		canSSH, err := common.HasApplicationCapability(
+			facade.authorizer, permission.SSHCapability, modelTag, names.NewApplicationTag(appName),
+		)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !canSSH {
+			return common.ErrPerm
+		}
+	}
+	return nil
+}
+
+func (facade *Facade) checkCanRead() error {
+	canRead, err := facade.authorizer.HasPermission(permission.ReadAccess, facade.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
 		return common.ErrPerm
 	}
 	return nil
@@ -43,7 +82,7 @@ func (facade *Facade) checkIsModelAdmin() error {
 // PublicAddress reports the preferred public network address for one
 // or more entities. Machines and units are suppored.
 func (facade *Facade) PublicAddress(args params.Entities) (params.SSHAddressResults, error) {
-	if err := facade.checkIsModelAdmin(); err != nil {
+	if err := facade.checkCanSSH(args.Entities); err != nil {
 		return params.SSHAddressResults{}, errors.Trace(err)
 	}
 
@@ -54,7 +93,7 @@ func (facade *Facade) PublicAddress(args params.Entities) (params.SSHAddressResu
 // PrivateAddress reports the preferred private network address for one or
 // more entities. Machines and units are supported.
 func (facade *Facade) PrivateAddress(args params.Entities) (params.SSHAddressResults, error) {
-	if err := facade.checkIsModelAdmin(); err != nil {
+	if err := facade.checkCanSSH(args.Entities); err != nil {
 		return params.SSHAddressResults{}, errors.Trace(err)
 	}
 
@@ -66,7 +105,7 @@ func (facade *Facade) PrivateAddress(args params.Entities) (params.SSHAddressRes
 // args. Machines and units are supported as entity types. Since the returned
 // addresses are gathered from multiple sources, results may include duplicates.
 func (facade *Facade) AllAddresses(args params.Entities) (params.SSHAddressesResults, error) {
-	if err := facade.checkIsModelAdmin(); err != nil {
+	if err := facade.checkCanSSH(args.Entities); err != nil {
 		return params.SSHAddressesResults{}, errors.Trace(err)
 	}
 
@@ -143,7 +182,7 @@ func (facade *Facade) getAddressPerEntity(args params.Entities, addressGetter fu
 // PublicKeys returns the public SSH hosts for one or more
 // entities. Machines and units are supported.
 func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResults, error) {
-	if err := facade.checkIsModelAdmin(); err != nil {
+	if err := facade.checkCanSSH(args.Entities); err != nil {
 		return params.SSHPublicKeysResults{}, errors.Trace(err)
 	}
 
@@ -168,8 +207,10 @@ func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResu
 
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the model associated with the API connection.
+// Only model read access is required, as users with application-scoped
+// access need to know this before connecting to their units.
 func (facade *Facade) Proxy() (params.SSHProxyResult, error) {
-	if err := facade.checkIsModelAdmin(); err != nil {
+	if err := facade.checkCanRead(); err != nil {
 		return params.SSHProxyResult{}, errors.Trace(err)
 	}
 	config, err := facade.backend.ModelConfig()
@@ -16,6 +16,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -61,6 +62,48 @@ func (s *facadeSuite) TestUnitAuthNotAllowed(c *gc.C) {
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
+func (s *facadeSuite) TestNonAdminNotAllowed(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.facade.PublicAddress(params.Entities{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestSSHCapabilityAllowed(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	s.authorizer.Capabilities = []permission.Capability{permission.SSHCapability}
+	args := params.Entities{
+		Entities: []params.Entity{{s.m0}},
+	}
+	results, err := s.facade.PublicAddress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHAddressResults{
+		Results: []params.SSHAddressResult{{Address: "1.1.1.1"}},
+	})
+}
+
+func (s *facadeSuite) TestApplicationAdminAllowedUnits(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin-application-foo")
+	args := params.Entities{
+		Entities: []params.Entity{{s.uFoo}},
+	}
+	results, err := s.facade.PublicAddress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHAddressResults{
+		Results: []params.SSHAddressResult{{Address: "3.3.3.3"}},
+	})
+}
+
+func (s *facadeSuite) TestApplicationAdminNotAllowedOtherEntities(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin-application-foo")
+	for _, tag := range []string{s.m0, s.uOther} {
+		args := params.Entities{
+			Entities: []params.Entity{{s.uFoo}, {tag}},
+		}
+		_, err := s.facade.PublicAddress(args)
+		c.Check(err, gc.Equals, common.ErrPerm)
+	}
+}
+
 func (s *facadeSuite) TestPublicAddress(c *gc.C) {
 	args := params.Entities{
 		Entities: []params.Entity{{s.m0}, {s.uFoo}, {s.uOther}},
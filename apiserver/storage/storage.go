@@ -72,6 +72,17 @@ func (api *API) checkCanWrite() error {
 	return nil
 }
 
+func (api *API) checkCanManageStorage() error {
+	canManage, err := api.authorizer.HasCapability(permission.StorageCapability, api.storage.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canManage {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // StorageDetails retrieves and returns detailed information about desired
 // storage identified by supplied tags. If specified storage cannot be
 // retrieved, individual error is returned instead of storage information.
@@ -685,7 +696,7 @@ func createFilesystemDetails(
 // instances from being processed.
 // A "CHANGE" block can block this operation.
 func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanManageStorage(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
 
@@ -729,7 +740,7 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 // Destroy sets the specified storage entities to Dying, unless they are
 // already Dying or Dead.
 func (a *API) Destroy(args params.Entities) (params.ErrorResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanManageStorage(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
 
@@ -761,7 +772,7 @@ func (a *API) Destroy(args params.Entities) (params.ErrorResults, error) {
 // already Dying or Dead. Any associated, persistent storage will remain
 // alive.
 func (a *API) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanManageStorage(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
 
@@ -826,7 +837,7 @@ func (api *API) detachStorage(storageTag names.StorageTag, unitTag names.UnitTag
 // Attach attaches existing storage instances to units.
 // A "CHANGE" block can block this operation.
 func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	if err := a.checkCanManageStorage(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
 
@@ -570,9 +570,13 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 		if err != nil {
 			return params.VolumeParams{}, err
 		}
+		ownerTagger, err := storagecommon.StorageOwnerTagger(storageInstance, s.st.Application)
+		if err != nil {
+			return params.VolumeParams{}, err
+		}
 		volumeParams, err := storagecommon.VolumeParams(
 			volume, storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(),
-			modelCfg, s.poolManager, s.registry,
+			modelCfg, ownerTagger, s.poolManager, s.registry,
 		)
 		if err != nil {
 			return params.VolumeParams{}, err
@@ -658,9 +662,13 @@ func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.F
 		if err != nil {
 			return params.FilesystemParams{}, err
 		}
+		ownerTagger, err := storagecommon.StorageOwnerTagger(storageInstance, s.st.Application)
+		if err != nil {
+			return params.FilesystemParams{}, err
+		}
 		filesystemParams, err := storagecommon.FilesystemParams(
 			filesystem, storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(),
-			modelConfig, s.poolManager, s.registry,
+			modelConfig, ownerTagger, s.poolManager, s.registry,
 		)
 		if err != nil {
 			return params.FilesystemParams{}, err
@@ -18,6 +18,10 @@ type FakeAuthorizer struct {
 	ModelUUID   string
 	AdminTag    names.UserTag
 	HasWriteTag names.UserTag
+
+	// Capabilities holds capabilities granted to the authenticated
+	// user through roles, in addition to those implied by its access.
+	Capabilities []permission.Capability
 }
 
 func (fa FakeAuthorizer) AuthOwner(tag names.Tag) bool {
@@ -70,6 +74,19 @@ func (fa FakeAuthorizer) HasPermission(operation permission.Access, target names
 	return false, nil
 }
 
+// HasCapability returns true if the logged in user has been given the
+// capability, or if it has the access level which implies it.
+func (fa FakeAuthorizer) HasCapability(capability permission.Capability, target names.Tag) (bool, error) {
+	if fa.Tag.Kind() == names.UserTagKind && target.Kind() == names.ModelTagKind {
+		for _, c := range fa.Capabilities {
+			if c == capability {
+				return true, nil
+			}
+		}
+	}
+	return fa.HasPermission(capability.ImpliedBy(), target)
+}
+
 // nameBasedHasPermission provides a way for tests to fake the expected outcomes of the
 // authentication.
 // setting permissionname as the name that user will always have the given permission.
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/undertaker"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 )
@@ -104,6 +105,9 @@ type mockModel struct {
 	name  string
 	uuid  string
 
+	keepInstances bool
+	keptInstances []instance.Id
+
 	status     status.Status
 	statusInfo string
 	statusData map[string]interface{}
@@ -131,6 +135,14 @@ func (m *mockModel) UUID() string {
 	return m.uuid
 }
 
+func (m *mockModel) KeepInstances() bool {
+	return m.keepInstances
+}
+
+func (m *mockModel) KeptInstances() []instance.Id {
+	return m.keptInstances
+}
+
 func (m *mockModel) Destroy() error {
 	m.life = state.Dying
 	return nil
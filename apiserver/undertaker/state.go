@@ -7,6 +7,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 )
 
@@ -67,6 +68,14 @@ type Model interface {
 	// UUID returns the universally unique identifier of the model.
 	UUID() string
 
+	// KeepInstances returns whether the cloud instances of the
+	// model's machines are left running when it is destroyed.
+	KeepInstances() bool
+
+	// KeptInstances returns the ids of the cloud instances that
+	// were left running when their machines were removed.
+	KeptInstances() []instance.Id
+
 	// Destroy sets the model's lifecycle to Dying, preventing
 	// addition of services or machines to state.
 	Destroy() error
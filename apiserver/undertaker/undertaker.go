@@ -69,6 +69,11 @@ func (u *UndertakerAPI) ModelInfo() (params.UndertakerModelInfoResult, error) {
 		Name:       env.Name(),
 		IsSystem:   u.st.IsController(),
 		Life:       params.Life(env.Life().String()),
+
+		KeepInstances: env.KeepInstances(),
+	}
+	for _, id := range env.KeptInstances() {
+		result.Result.KeptInstances = append(result.Result.KeptInstances, string(id))
 	}
 
 	return result, nil
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/apiserver/undertaker"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
@@ -87,9 +88,29 @@ func (s *undertakerSuite) TestEnvironInfo(c *gc.C) {
 		c.Assert(info.Name, gc.Equals, test.envName)
 		c.Assert(info.IsSystem, gc.Equals, test.isSystem)
 		c.Assert(info.Life, gc.Equals, params.Dying)
+		c.Assert(info.KeepInstances, jc.IsFalse)
 	}
 }
 
+func (s *undertakerSuite) TestModelInfoKeepInstances(c *gc.C) {
+	otherSt, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
+	otherSt.env.keepInstances = true
+
+	result, err := hostedAPI.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result.KeepInstances, jc.IsTrue)
+}
+
+func (s *undertakerSuite) TestModelInfoKeptInstances(c *gc.C) {
+	otherSt, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
+	otherSt.env.keptInstances = []instance.Id{"i-kept"}
+
+	result, err := hostedAPI.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result.KeepInstances, jc.IsFalse)
+	c.Assert(result.Result.KeptInstances, jc.DeepEquals, []string{"i-kept"})
+}
+
 func (s *undertakerSuite) TestProcessDyingEnviron(c *gc.C) {
 	otherSt, hostedAPI := s.setupStateAndAPI(c, false, "hostedenv")
 	env, err := otherSt.Model()
@@ -29,7 +29,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements API version 6, used by the uniter worker.
+// UniterAPI implements API version 7, used by the uniter worker.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -227,21 +227,7 @@ func (u *UniterAPI) getOneMachinePorts(canAccess common.AuthFunc, machineTag str
 	}
 	var resultPorts []params.MachinePortRange
 	for _, ports := range allPorts {
-		// AllPortRanges gives a map, but apis require a stable order
-		// for results, so sort the port ranges.
-		portRangesToUnits := ports.AllPortRanges()
-		portRanges := make([]network.PortRange, 0, len(portRangesToUnits))
-		for portRange := range portRangesToUnits {
-			portRanges = append(portRanges, portRange)
-		}
-		network.SortPortRanges(portRanges)
-		for _, portRange := range portRanges {
-			unitName := portRangesToUnits[portRange]
-			resultPorts = append(resultPorts, params.MachinePortRange{
-				UnitTag:   names.NewUnitTag(unitName).String(),
-				PortRange: params.FromNetworkPortRange(portRange),
-			})
-		}
+		resultPorts = append(resultPorts, common.MachinePortRanges(ports)...)
 	}
 	return params.MachinePortsResult{
 		Ports: resultPorts,
@@ -748,7 +734,7 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenPortsOnEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -785,6 +771,49 @@ func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResu
 	return result, nil
 }
 
+// OpenEgress allows outgoing traffic to the given egress rules
+// from the machines of all given units.
+func (u *UniterAPI) OpenEgress(args params.EntitiesEgressRules) (params.ErrorResults, error) {
+	return u.changeEgress(args, (*state.Unit).OpenEgress)
+}
+
+// CloseEgress removes the given egress rules, previously added with
+// OpenEgress, from the machines of all given units.
+func (u *UniterAPI) CloseEgress(args params.EntitiesEgressRules) (params.ErrorResults, error) {
+	return u.changeEgress(args, (*state.Unit).CloseEgress)
+}
+
+func (u *UniterAPI) changeEgress(
+	args params.EntitiesEgressRules,
+	change func(unit *state.Unit, protocol string, fromPort, toPort int, destinationCIDRs ...string) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				portRange := entity.Rule.PortRange
+				err = change(unit, portRange.Protocol, portRange.FromPort, portRange.ToPort, entity.Rule.DestinationCIDRs...)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // WatchConfigSettings returns a NotifyWatcher for observing changes
 // to each unit's service configuration settings. See also
 // state/watcher.go:Unit.WatchConfigSettings().
@@ -921,6 +921,42 @@ func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	c.Assert(openedPorts, gc.HasLen, 0)
 }
 
+func (s *uniterSuite) TestOpenCloseEgress(c *gc.C) {
+	rule := params.EgressRule{
+		PortRange:        params.PortRange{Protocol: "tcp", FromPort: 443, ToPort: 443},
+		DestinationCIDRs: []string{"10.0.0.0/8"},
+	}
+	args := params.EntitiesEgressRules{Entities: []params.EntityEgressRule{
+		{Tag: "unit-mysql-0", Rule: rule},
+		{Tag: "unit-wordpress-0", Rule: rule},
+		{Tag: "unit-foo-42", Rule: rule},
+	}}
+	expected := params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	}
+	result, err := s.uniter.OpenEgress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, expected)
+
+	egress, err := s.wordpressUnit.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+
+	result, err = s.uniter.CloseEgress(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, expected)
+
+	egress, err = s.wordpressUnit.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, gc.HasLen, 0)
+}
+
 func (s *uniterSuite) TestWatchConfigSettings(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -953,6 +989,59 @@ func (s *uniterSuite) TestWatchConfigSettings(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *uniterSuite) TestUpgradeSeriesUnitStatus(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.UpgradeSeriesUnitStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Status: "not started"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetUpgradeSeriesUnitStatus(c *gc.C) {
+	args := params.UpgradeSeriesStatusParams{Params: []params.UpgradeSeriesStatusParam{
+		{Entity: params.Entity{Tag: "unit-mysql-0"}, Status: "prepare completed"},
+		{Entity: params.Entity{Tag: "unit-wordpress-0"}, Status: "prepare completed"},
+	}}
+	result, err := s.uniter.SetUpgradeSeriesUnitStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `series upgrade lock for machine "0" not found`)
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *uniterSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+	}}
+	result, err := s.uniter.WatchUpgradeSeriesNotifications(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *uniterSuite) TestWatchActionNotifications(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries provides the API used by machine agents to
+// carry out in-place upgrades of their machines' series.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API provides access to the UpgradeSeries API facade.
+type API struct {
+	st        *state.State
+	resources facade.Resources
+	auth      facade.Authorizer
+}
+
+// NewAPI creates a new server-side UpgradeSeries API facade.
+func NewAPI(st *state.State, resources facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:        st,
+		resources: resources,
+		auth:      auth,
+	}, nil
+}
+
+// MachineStatus returns how far through its series upgrade each of the
+// given machines has got.
+func (api *API) MachineStatus(args params.Entities) (params.UpgradeSeriesStatusResults, error) {
+	result := params.UpgradeSeriesStatusResults{
+		Results: make([]params.UpgradeSeriesStatusResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.getMachine(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		status, err := machine.UpgradeSeriesStatus()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Status = string(status)
+	}
+	return result, nil
+}
+
+// SetMachineStatus records how far through its series upgrade each of
+// the given machines has got.
+func (api *API) SetMachineStatus(args params.UpgradeSeriesStatusParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Params)),
+	}
+	for i, arg := range args.Params {
+		machine, err := api.getMachine(arg.Entity.Tag)
+		if err == nil {
+			err = machine.SetUpgradeSeriesStatus(upgradeseries.Status(arg.Status))
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// TargetSeries returns the series each of the given machines is being
+// upgraded to.
+func (api *API) TargetSeries(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.getMachine(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		series, err := machine.UpgradeSeriesTarget()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = series
+	}
+	return result, nil
+}
+
+// UnitStatuses returns how far through the series upgrade of each of
+// the given machines the units deployed to it have got.
+func (api *API) UnitStatuses(args params.Entities) (params.UpgradeSeriesUnitStatusesResults, error) {
+	result := params.UpgradeSeriesUnitStatusesResults{
+		Results: make([]params.UpgradeSeriesUnitStatusesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.getMachine(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		statuses, err := machine.UpgradeSeriesUnitStatuses()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Statuses = make(map[string]string, len(statuses))
+		for unitName, status := range statuses {
+			result.Results[i].Statuses[unitName] = string(status)
+		}
+	}
+	return result, nil
+}
+
+// FinishUpgradeSeries unlocks each of the given machines once its
+// series upgrade is complete.
+func (api *API) FinishUpgradeSeries(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.getMachine(entity.Tag)
+		if err == nil {
+			err = machine.RemoveUpgradeSeriesLock()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for observing
+// changes to the series upgrade of each of the given machines.
+func (api *API) WatchUpgradeSeriesNotifications(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.getMachine(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := machine.WatchUpgradeSeriesNotifications()
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			result.Results[i].NotifyWatcherId = api.resources.Register(watch)
+		} else {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return result, nil
+}
+
+// getMachine returns the machine with the given tag, if the
+// authenticated agent is responsible for it.
+func (api *API) getMachine(tagString string) (*state.Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil || !api.auth.AuthOwner(tag) {
+		return nil, common.ErrPerm
+	}
+	machine, err := api.st.Machine(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine, nil
+}
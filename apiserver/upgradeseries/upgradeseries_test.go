@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/upgradeseries"
+	coreupgradeseries "github.com/juju/juju/core/upgradeseries"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type upgradeSeriesSuite struct {
+	jujutesting.JujuConnSuite
+
+	machine   *state.Machine
+	unit      *state.Unit
+	resources *common.Resources
+	api       *upgradeseries.API
+	args      params.Entities
+}
+
+var _ = gc.Suite(&upgradeSeriesSuite{})
+
+func (s *upgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series: "trusty",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "metered-multi-series",
+		URL:  "cs:metered-multi-series-1",
+	})
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:   "multi-series",
+		Series: "trusty",
+		Charm:  ch,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.machine, Application: app})
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	authorizer := apiservertesting.FakeAuthorizer{Tag: s.machine.Tag()}
+	s.api, err = upgradeseries.NewAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.args = params.Entities{Entities: []params.Entity{
+		{Tag: s.machine.Tag().String()},
+		{Tag: "machine-42"},
+		{Tag: s.unit.Tag().String()},
+	}}
+}
+
+func (s *upgradeSeriesSuite) TestNewAPIRefusesNonMachineAgent(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: s.unit.Tag()}
+	_, err := upgradeseries.NewAPI(s.State, s.resources, authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *upgradeSeriesSuite) TestMachineStatus(c *gc.C) {
+	result, err := s.api.MachineStatus(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{
+			{Status: "prepare started"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *upgradeSeriesSuite) TestSetMachineStatus(c *gc.C) {
+	result, err := s.api.SetMachineStatus(params.UpgradeSeriesStatusParams{
+		Params: []params.UpgradeSeriesStatusParam{
+			{Entity: params.Entity{Tag: s.machine.Tag().String()}, Status: "prepare completed"},
+			{Entity: params.Entity{Tag: "machine-42"}, Status: "prepare completed"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	status, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, coreupgradeseries.PrepareCompleted)
+}
+
+func (s *upgradeSeriesSuite) TestTargetSeries(c *gc.C) {
+	result, err := s.api.TargetSeries(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "xenial"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *upgradeSeriesSuite) TestUnitStatuses(c *gc.C) {
+	result, err := s.api.UnitStatuses(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpgradeSeriesUnitStatusesResults{
+		Results: []params.UpgradeSeriesUnitStatusesResult{
+			{Statuses: map[string]string{s.unit.Name(): "prepare started"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *upgradeSeriesSuite) TestFinishUpgradeSeries(c *gc.C) {
+	result, err := s.api.FinishUpgradeSeries(s.args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+}
+
+func (s *upgradeSeriesSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	result, err := s.api.WatchUpgradeSeriesNotifications(params.Entities{
+		Entities: []params.Entity{{Tag: s.machine.Tag().String()}, {Tag: "machine-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.machine.SetUpgradeSeriesStatus(coreupgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -258,6 +258,37 @@ func (api *UserManagerAPI) enableUserImpl(args params.Entities, action string, m
 	return result, nil
 }
 
+// applicationAccessForUser returns the application-scoped access granted
+// to the user across all models.
+func (api *UserManagerAPI) applicationAccessForUser(userTag names.UserTag) ([]params.UserApplicationAccess, error) {
+	grants, err := api.state.AllUserApplicationAccess(userTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.UserApplicationAccess
+	modelNames := make(map[string]string)
+	for _, grant := range grants {
+		modelName, ok := modelNames[grant.ModelUUID]
+		if !ok {
+			model, err := api.state.GetModel(names.NewModelTag(grant.ModelUUID))
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			modelName = model.Name()
+			modelNames[grant.ModelUUID] = modelName
+		}
+		result = append(result, params.UserApplicationAccess{
+			ModelTag:       names.NewModelTag(grant.ModelUUID).String(),
+			ModelName:      modelName,
+			ApplicationTag: grant.Application.String(),
+			Access:         string(grant.Access),
+		})
+	}
+	return result, nil
+}
+
 // UserInfo returns information on a user.
 func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.UserInfoResults, error) {
 	var results params.UserInfoResults
@@ -298,6 +329,13 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 			},
 		}
 		accessForUser(user.UserTag(), &result)
+		if result.Result != nil {
+			appAccess, err := api.applicationAccessForUser(user.UserTag())
+			if err != nil {
+				return params.UserInfoResult{Error: common.ServerError(err)}
+			}
+			result.Result.ApplicationAccess = appAccess
+		}
 		return result
 	}
 
@@ -393,3 +431,113 @@ func (api *UserManagerAPI) setPassword(arg params.EntityPassword) error {
 	}
 	return nil
 }
+
+// AddGroups adds groups of users to the controller. Only controller
+// superusers may manage groups.
+func (api *UserManagerAPI) AddGroups(args params.AddGroups) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkManageGroups(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(args.Groups))
+	for i, arg := range args.Groups {
+		if _, err := api.state.AddUserGroup(arg.Name, api.apiUser.Id()); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// AddGroupMembers adds users to groups. Only controller superusers
+// may manage groups.
+func (api *UserManagerAPI) AddGroupMembers(args params.GroupMembersArgs) (params.ErrorResults, error) {
+	return api.changeGroupMembers(args, (*state.UserGroup).AddMembers)
+}
+
+// RemoveGroupMembers removes users from groups. Only controller
+// superusers may manage groups.
+func (api *UserManagerAPI) RemoveGroupMembers(args params.GroupMembersArgs) (params.ErrorResults, error) {
+	return api.changeGroupMembers(args, (*state.UserGroup).RemoveMembers)
+}
+
+func (api *UserManagerAPI) changeGroupMembers(
+	args params.GroupMembersArgs,
+	change func(*state.UserGroup, ...names.UserTag) error,
+) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkManageGroups(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		if err := api.changeGroupMembersOne(arg, change); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) changeGroupMembersOne(
+	arg params.GroupMembers,
+	change func(*state.UserGroup, ...names.UserTag) error,
+) error {
+	members := make([]names.UserTag, len(arg.Members))
+	for i, member := range arg.Members {
+		tag, err := names.ParseUserTag(member)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		members[i] = tag
+	}
+	group, err := api.state.UserGroup(arg.Group)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(change(group, members...))
+}
+
+// GroupInfo returns information on all of the groups
+// of users in the controller.
+func (api *UserManagerAPI) GroupInfo() (params.GroupInfoResults, error) {
+	var result params.GroupInfoResults
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return result, common.ErrPerm
+	}
+	groups, err := api.state.AllUserGroups()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.GroupInfo, len(groups))
+	for i, group := range groups {
+		members := group.Members()
+		info := params.GroupInfo{
+			Name:        group.Name(),
+			CreatedBy:   group.CreatedBy(),
+			DateCreated: group.DateCreated(),
+			Members:     make([]string, len(members)),
+		}
+		for j, member := range members {
+			info.Members[j] = member.Id()
+		}
+		result.Results[i] = info
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) checkManageGroups() error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isSuperUser {
+		return common.ErrPerm
+	}
+	return nil
+}
@@ -334,6 +334,28 @@ func (s *userManagerSuite) TestEnableUserAsNormalUser(c *gc.C) {
 	c.Assert(barb.IsDisabled(), jc.IsTrue)
 }
 
+func (s *userManagerSuite) TestUserInfoApplicationAccess(c *gc.C) {
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: permission.ReadAccess})
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.CreateApplicationAccess(app.ApplicationTag(), user.UserTag, permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.usermanager.UserInfo(params.UserInfoRequest{
+		Entities: []params.Entity{{Tag: user.UserTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.ApplicationAccess, jc.DeepEquals, []params.UserApplicationAccess{{
+		ModelTag:       model.ModelTag().String(),
+		ModelName:      model.Name(),
+		ApplicationTag: app.ApplicationTag().String(),
+		Access:         "write",
+	}})
+}
+
 func (s *userManagerSuite) TestUserInfo(c *gc.C) {
 	userFoo := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", DisplayName: "Foo Bar"})
 	userBar := s.Factory.MakeUser(c, &factory.UserParams{Name: "barfoo", DisplayName: "Bar Foo", Disabled: true})
@@ -821,3 +843,81 @@ func (s *userManagerSuite) TestRemoveUserBulkSharedModels(c *gc.C) {
 	c.Assert(alice.IsDeleted(), jc.IsTrue)
 
 }
+
+func (s *userManagerSuite) TestAddGroups(c *gc.C) {
+	result, err := s.usermanager.AddGroups(params.AddGroups{
+		Groups: []params.AddGroup{{Name: "ops"}, {Name: "not/valid"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `invalid group name "not/valid"`}},
+		},
+	})
+	group, err := s.State.UserGroup("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.CreatedBy(), gc.Equals, s.adminName)
+}
+
+func (s *userManagerSuite) TestAddGroupsAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = usermanager.AddGroups(params.AddGroups{
+		Groups: []params.AddGroup{{Name: "ops"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.State.UserGroup("ops")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *userManagerSuite) TestBlockAddGroups(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockAddGroups")
+	_, err := s.usermanager.AddGroups(params.AddGroups{
+		Groups: []params.AddGroup{{Name: "ops"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockAddGroups")
+}
+
+func (s *userManagerSuite) TestAddRemoveGroupMembers(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	_, err := s.State.AddUserGroup("ops", s.adminName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.usermanager.AddGroupMembers(params.GroupMembersArgs{
+		Args: []params.GroupMembers{{
+			Group:   "ops",
+			Members: []string{"user-alex", "user-bob@external"},
+		}, {
+			Group:   "dev",
+			Members: []string{"user-alex"},
+		}, {
+			Group:   "ops",
+			Members: []string{"machine-0"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `group "dev" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+
+	result, err = s.usermanager.RemoveGroupMembers(params.GroupMembersArgs{
+		Args: []params.GroupMembers{{
+			Group:   "ops",
+			Members: []string{"user-alex"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	info, err := s.usermanager.GroupInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Results, gc.HasLen, 1)
+	c.Assert(info.Results[0].Name, gc.Equals, "ops")
+	c.Assert(info.Results[0].CreatedBy, gc.Equals, s.adminName)
+	c.Assert(info.Results[0].Members, jc.DeepEquals, []string{"bob@external"})
+}
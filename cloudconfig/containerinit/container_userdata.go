@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -63,6 +64,11 @@ var (
 	networkInterfacesFile       = systemNetworkInterfacesFile + "-juju"
 )
 
+// hotplugInterfacesDir is the directory holding the config of the
+// interfaces a running container is given, which the generated network
+// config includes.
+const hotplugInterfacesDir = "/etc/network/interfaces.d"
+
 // GenerateNetworkConfig renders a network config for one or more network
 // interfaces, using the given non-nil networkConfig containing a non-empty
 // Interfaces field.
@@ -137,6 +143,8 @@ func GenerateNetworkConfig(networkConfig *container.NetworkConfig) (string, erro
 		}
 	}
 
+	output.WriteString("\nsource " + HotplugInterfaceConfigFile("*") + "\n")
+
 	generatedConfig := output.String()
 	logger.Debugf("generated network config:\n%s", generatedConfig)
 
@@ -147,6 +155,42 @@ func GenerateNetworkConfig(networkConfig *container.NetworkConfig) (string, erro
 	return generatedConfig, nil
 }
 
+// HotplugInterfaceConfigFile returns the path of the file holding the
+// config of the named interface, given to a container once it is running.
+func HotplugInterfaceConfigFile(interfaceName string) string {
+	return path.Join(hotplugInterfacesDir, "juju-"+interfaceName+".cfg")
+}
+
+// GenerateHotplugInterfaceConfig renders the config of a network interface
+// given to a container once it is running, so that the interface is
+// configured as soon as it appears in the container. The default gateway
+// of the container is left as it is.
+func GenerateHotplugInterfaceConfig(info network.InterfaceInfo) string {
+	name := info.InterfaceName
+	var output bytes.Buffer
+	output.WriteString("allow-hotplug " + name + "\n")
+	cidr := info.CIDRAddress()
+	switch {
+	case cidr != "":
+		output.WriteString("iface " + name + " inet static\n")
+		output.WriteString("  address " + cidr + "\n")
+	case info.ConfigType == network.ConfigDHCP:
+		output.WriteString("iface " + name + " inet dhcp\n")
+	default:
+		output.WriteString("iface " + name + " inet manual\n")
+	}
+	if info.MTU != 0 && info.MTU != 1500 {
+		output.WriteString(fmt.Sprintf("  mtu %d\n", info.MTU))
+	}
+	for _, route := range info.Routes {
+		output.WriteString(fmt.Sprintf("  post-up ip route add %s via %s metric %d\n",
+			route.DestinationCIDR, route.GatewayIP, route.Metric))
+		output.WriteString(fmt.Sprintf("  pre-down ip route del %s via %s metric %d\n",
+			route.DestinationCIDR, route.GatewayIP, route.Metric))
+	}
+	return output.String()
+}
+
 // PreparedConfig holds all the necessary information to render a persistent
 // network config to a file.
 type PreparedConfig struct {
@@ -144,6 +144,8 @@ bootcmd:
   iface {ethaa_bb_cc_dd_ee_f3} inet dhcp
 
   iface {ethaa_bb_cc_dd_ee_f4} inet manual
+
+  source /etc/network/interfaces.d/juju-*.cfg
   ' > '%[1]s.templ'
 `
 	s.expectedSampleConfigTemplate = `
@@ -168,6 +170,8 @@ iface {ethaa_bb_cc_dd_ee_f2} inet dhcp
 iface {ethaa_bb_cc_dd_ee_f3} inet dhcp
 
 iface {ethaa_bb_cc_dd_ee_f4} inet manual
+
+source /etc/network/interfaces.d/juju-*.cfg
 `
 
 	networkInterfacesScriptYamled := strings.Replace(containerinit.NetworkInterfacesScript, "\n", "\n  ", -1)
@@ -200,6 +204,8 @@ bootcmd:
   iface lo inet loopback
 
   iface {eth} inet dhcp
+
+  source /etc/network/interfaces.d/juju-*.cfg
   ' > '%[1]s.templ'
 `
 
@@ -209,6 +215,8 @@ auto lo {eth}
 iface lo inet loopback
 
 iface {eth} inet dhcp
+
+source /etc/network/interfaces.d/juju-*.cfg
 `
 
 	s.expectedFallbackUserData = `
@@ -263,6 +271,27 @@ func (s *UserDataSuite) TestGenerateNetworkConfig(c *gc.C) {
 	c.Assert(data, gc.Equals, s.expectedSampleConfigTemplate)
 }
 
+func (s *UserDataSuite) TestGenerateHotplugInterfaceConfig(c *gc.C) {
+	c.Assert(containerinit.HotplugInterfaceConfigFile("eth1"), gc.Equals, "/etc/network/interfaces.d/juju-eth1.cfg")
+
+	info := s.fakeInterfaces[1]
+	info.InterfaceName = "eth1"
+	c.Assert(containerinit.GenerateHotplugInterfaceConfig(info), gc.Equals, `
+allow-hotplug eth1
+iface eth1 inet static
+  address 0.2.2.4/24
+  post-up ip route add 0.5.6.0/24 via 0.2.2.1 metric 50
+  pre-down ip route del 0.5.6.0/24 via 0.2.2.1 metric 50
+`[1:])
+
+	info = s.fakeInterfaces[3]
+	info.InterfaceName = "eth2"
+	c.Assert(containerinit.GenerateHotplugInterfaceConfig(info), gc.Equals, `
+allow-hotplug eth2
+iface eth2 inet dhcp
+`[1:])
+}
+
 func (s *UserDataSuite) TestNewCloudInitConfigWithNetworksSampleConfig(c *gc.C) {
 	netConfig := container.BridgeNetworkConfig("foo", 0, s.fakeInterfaces)
 	cloudConf, err := containerinit.NewCloudInitConfigWithNetworks("quantal", netConfig)
@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageAntiAffinitySummary = `
+Gets or sets whether an application's units are kept on different hosts.`[1:]
+
+var usageAntiAffinityDetails = `
+When an application has anti-affinity, Juju will not assign two of its
+units to the same host: a unit may not be placed on a machine, or in a
+container on a machine, that already hosts another of the application's
+units. Adding a unit that would break this fails instead, and units
+assigned to clean machines skip hosts already in use.
+
+Anti-affinity applies to units assigned after it is set; units that
+already share a host are not moved. With no value, the application's
+current setting is shown.
+
+When machines are provisioned for the units, clouds that support it
+also keep the machines on different physical hosts: OpenStack starts
+them in a server group with the anti-affinity policy, and AWS in a
+spread placement group.
+
+Examples:
+    juju anti-affinity mysql
+    juju anti-affinity mysql true
+    juju anti-affinity mysql false
+
+See also:
+    add-unit
+    deploy`[1:]
+
+// NewAntiAffinityCommand returns a command to get or set the
+// anti-affinity of an application.
+func NewAntiAffinityCommand() modelcmd.ModelCommand {
+	cmd := &antiAffinityCommand{}
+	cmd.newAPIFunc = func() (ApplicationAntiAffinityAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// antiAffinityCommand gets or sets the anti-affinity of an application.
+type antiAffinityCommand struct {
+	modelcmd.ModelCommandBase
+	out             cmd.Output
+	ApplicationName string
+	AntiAffinity    *bool
+	newAPIFunc      func() (ApplicationAntiAffinityAPI, error)
+}
+
+// ApplicationAntiAffinityAPI defines the API methods that the
+// anti-affinity command uses.
+type ApplicationAntiAffinityAPI interface {
+	Close() error
+	Get(application string) (*params.ApplicationGetResults, error)
+	SetAntiAffinity(application string, antiAffinity bool) error
+}
+
+func (c *antiAffinityCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "anti-affinity",
+		Args:    "<application name> [true|false]",
+		Purpose: usageAntiAffinitySummary,
+		Doc:     usageAntiAffinityDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *antiAffinityCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+func (c *antiAffinityCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1, 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	if len(args) == 1 {
+		return nil
+	}
+	antiAffinity, err := strconv.ParseBool(args[1])
+	if err != nil {
+		return errors.NotValidf("anti-affinity value %q", args[1])
+	}
+	c.AntiAffinity = &antiAffinity
+	return nil
+}
+
+// Run shows or sets the anti-affinity of the application.
+func (c *antiAffinityCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if c.AntiAffinity == nil {
+		results, err := client.Get(c.ApplicationName)
+		if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, results.AntiAffinity)
+	}
+	err = client.SetAntiAffinity(c.ApplicationName, *c.AntiAffinity)
+	if errors.IsNotSupported(err) {
+		return errors.New("application anti-affinity is not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
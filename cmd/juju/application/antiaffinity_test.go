@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	jtesting "github.com/juju/juju/testing"
+)
+
+type AntiAffinitySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockAntiAffinityAPI
+}
+
+func (s *AntiAffinitySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockAntiAffinityAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&AntiAffinitySuite{})
+
+func (s *AntiAffinitySuite) runAntiAffinity(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := NewAntiAffinityCommandForTest(s.mockAPI)
+	cmd.SetClientStore(NewMockStore())
+	return cmdtesting.RunCommand(c, cmd, args...)
+}
+
+func (s *AntiAffinitySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "maybe"},
+		err:  `anti-affinity value "maybe" not valid`,
+	}, {
+		args: []string{"mysql", "true", "false"},
+		err:  `unrecognized args: \["false"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runAntiAffinity(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *AntiAffinitySuite) TestShowAntiAffinity(c *gc.C) {
+	s.mockAPI.antiAffinity = true
+	ctx, err := s.runAntiAffinity(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "true\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"Get", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *AntiAffinitySuite) TestSetAntiAffinity(c *gc.C) {
+	_, err := s.runAntiAffinity(c, "mysql", "true")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetAntiAffinity", []interface{}{"mysql", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *AntiAffinitySuite) TestSetAntiAffinityNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("application anti-affinity on this controller"))
+	_, err := s.runAntiAffinity(c, "mysql", "true")
+	c.Assert(err, gc.ErrorMatches, "application anti-affinity is not supported by this controller")
+}
+
+func (s *AntiAffinitySuite) TestSetAntiAffinityBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetAntiAffinityBlocked"))
+	_, err := s.runAntiAffinity(c, "mysql", "false")
+	jtesting.AssertOperationWasBlocked(c, err, ".*TestSetAntiAffinityBlocked.*")
+}
+
+type mockAntiAffinityAPI struct {
+	*testing.Stub
+	antiAffinity bool
+}
+
+func (m *mockAntiAffinityAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockAntiAffinityAPI) Get(application string) (*params.ApplicationGetResults, error) {
+	m.MethodCall(m, "Get", application)
+	return &params.ApplicationGetResults{
+		Application:  application,
+		AntiAffinity: m.antiAffinity,
+	}, m.NextErr()
+}
+
+func (m *mockAntiAffinityAPI) SetAntiAffinity(application string, antiAffinity bool) error {
+	m.MethodCall(m, "SetAntiAffinity", application, antiAffinity)
+	return m.NextErr()
+}
@@ -0,0 +1,148 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces an application's endpoints are bound to.`[1:]
+
+var usageBindDetails = `
+Rebinds endpoints of a deployed application to different spaces, using
+the same form as the --bind option of deploy. A lone space name changes
+the application's default space, which applies to all endpoints that
+are not bound explicitly. Endpoints that are not mentioned keep their
+current bindings.
+
+Every machine hosting a unit of the application must already have an
+address in each of the new spaces. Containers only need their host
+machine to have one; they are bridged into the new spaces by their
+provisioner. Once the bindings are changed, the addresses of the units
+in the affected relations, cross-model ones included, are updated, and
+the units see config-changed and relation-changed hooks so that they
+can reconfigure themselves.
+
+Examples:
+    juju bind mysql db=internal
+    juju bind wordpress public website=dmz
+
+See also:
+    deploy
+    spaces`[1:]
+
+const bindErrorPrefix = "bindings must be in the form '[<default-space>] [<endpoint-name>=<space> ...]'. "
+
+// NewBindCommand returns a command to change the endpoint bindings of
+// an application.
+func NewBindCommand() modelcmd.ModelCommand {
+	cmd := &bindCommand{}
+	cmd.newAPIFunc = func() (ApplicationBindAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// bindCommand changes the spaces an application's endpoints are bound to.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Bindings        map[string]string
+	newAPIFunc      func() (ApplicationBindAPI, error)
+}
+
+// ApplicationBindAPI defines the API methods that the bind command uses.
+type ApplicationBindAPI interface {
+	Close() error
+	SetEndpointBindings(application string, bindings map[string]string) error
+}
+
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application name> [<default-space>] [<endpoint-name>=<space> ...]",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	bindings, err := parseBindings(args[1:])
+	if err != nil {
+		return err
+	}
+	c.Bindings = bindings
+	return nil
+}
+
+// parseBindings parses bindings given as separate arguments, each
+// either a lone space name for the default binding, or
+// endpoint-name=space-name.
+func parseBindings(args []string) (map[string]string, error) {
+	bindings := make(map[string]string)
+	for _, arg := range args {
+		v := strings.Split(arg, "=")
+		var endpoint, space string
+		switch len(v) {
+		case 1:
+			space = v[0]
+		case 2:
+			if v[0] == "" {
+				return nil, errors.New(bindErrorPrefix + "Found = without endpoint name. Use a lone space name to set the default.")
+			}
+			endpoint = v[0]
+			space = v[1]
+		default:
+			return nil, errors.New(bindErrorPrefix + "Found multiple = in binding.")
+		}
+		if !names.IsValidSpace(space) {
+			return nil, errors.New(bindErrorPrefix + "Space name invalid.")
+		}
+		if _, ok := bindings[endpoint]; ok {
+			if endpoint == "" {
+				return nil, errors.New(bindErrorPrefix + "Found more than one default space.")
+			}
+			return nil, errors.Errorf(bindErrorPrefix+"Found endpoint %q more than once.", endpoint)
+		}
+		bindings[endpoint] = space
+	}
+	return bindings, nil
+}
+
+// Run changes the endpoint bindings of the application.
+func (c *bindCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetEndpointBindings(c.ApplicationName, c.Bindings)
+	if errors.IsNotSupported(err) {
+		return errors.New("changing endpoint bindings is not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
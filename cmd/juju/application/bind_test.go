@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	jtesting "github.com/juju/juju/testing"
+)
+
+type BindSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockBindAPI
+}
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockBindAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+func (s *BindSuite) runBind(c *gc.C, args ...string) error {
+	cmd := NewBindCommandForTest(s.mockAPI)
+	cmd.SetClientStore(NewMockStore())
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	return err
+}
+
+func (s *BindSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no bindings specified",
+	}, {
+		args: []string{"mysql/0", "db=internal"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "=internal"},
+		err:  "bindings must be in the form .* Found = without endpoint name. .*",
+	}, {
+		args: []string{"mysql", "db=internal=dmz"},
+		err:  "bindings must be in the form .* Found multiple = in binding.",
+	}, {
+		args: []string{"mysql", "db=Bad!"},
+		err:  "bindings must be in the form .* Space name invalid.",
+	}, {
+		args: []string{"mysql", "db=internal", "db=dmz"},
+		err:  `bindings must be in the form .* Found endpoint "db" more than once.`,
+	}, {
+		args: []string{"mysql", "internal", "dmz"},
+		err:  "bindings must be in the form .* Found more than one default space.",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.runBind(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	err := s.runBind(c, "wordpress", "public", "db=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetEndpointBindings", []interface{}{"wordpress", map[string]string{
+			"":   "public",
+			"db": "internal",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *BindSuite) TestBindNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("changing endpoint bindings on this controller"))
+	err := s.runBind(c, "wordpress", "db=internal")
+	c.Assert(err, gc.ErrorMatches, "changing endpoint bindings is not supported by this controller")
+}
+
+func (s *BindSuite) TestBindFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`machine "0" hosting unit "wordpress/0" has no address in space "internal"`))
+	err := s.runBind(c, "wordpress", "db=internal")
+	c.Assert(err, gc.ErrorMatches, `machine "0" hosting unit "wordpress/0" has no address in space "internal"`)
+}
+
+func (s *BindSuite) TestBindBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestBindBlocked"))
+	err := s.runBind(c, "wordpress", "db=internal")
+	jtesting.AssertOperationWasBlocked(c, err, ".*TestBindBlocked.*")
+}
+
+type mockBindAPI struct {
+	*testing.Stub
+}
+
+func (m *mockBindAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockBindAPI) SetEndpointBindings(application string, bindings map[string]string) error {
+	m.MethodCall(m, "SetEndpointBindings", application, bindings)
+	return m.NextErr()
+}
@@ -62,6 +62,38 @@ func NewRemoveRelationCommandForTest(api ApplicationDestroyRelationAPI) modelcmd
 	return modelcmd.Wrap(cmd)
 }
 
+// NewBindCommandForTest returns a BindCommand with the api provided as specified.
+func NewBindCommandForTest(api ApplicationBindAPI) modelcmd.ModelCommand {
+	cmd := &bindCommand{newAPIFunc: func() (ApplicationBindAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetSeriesCommandForTest returns a SetSeriesCommand with the api provided as specified.
+func NewSetSeriesCommandForTest(api ApplicationSetSeriesAPI) modelcmd.ModelCommand {
+	cmd := &setSeriesCommand{newAPIFunc: func() (ApplicationSetSeriesAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResourceTagsCommandForTest returns a ResourceTagsCommand with the api provided as specified.
+func NewResourceTagsCommandForTest(api ApplicationResourceTagsAPI) modelcmd.ModelCommand {
+	cmd := &resourceTagsCommand{newAPIFunc: func() (ApplicationResourceTagsAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewAntiAffinityCommandForTest returns an AntiAffinityCommand with the api provided as specified.
+func NewAntiAffinityCommandForTest(api ApplicationAntiAffinityAPI) modelcmd.ModelCommand {
+	cmd := &antiAffinityCommand{newAPIFunc: func() (ApplicationAntiAffinityAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
 // NewConsumeCommandForTest returns a ConsumeCommand with the specified api.
 func NewConsumeCommandForTest(store jujuclient.ClientStore, api applicationConsumeAPI) cmd.Command {
 	c := &consumeCommand{api: api}
@@ -4,10 +4,14 @@
 package application
 
 import (
+	"net"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +23,21 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+Access may instead be limited to the subnets of some spaces with
+--to-spaces, or to some CIDRs with --to-cidrs. These limits apply to the
+endpoints named with --endpoints, or to all endpoints if none are named,
+and are added to those given before for other endpoints. Exposing an
+application without any of these options opens it to everyone again.
+
+Ports opened by units are not associated with endpoints, so they may be
+reached from the spaces and CIDRs of all of the exposed endpoints.
+` + "`juju status --format yaml`" + ` shows the exposed endpoints of an
+application, with "*" standing for all endpoints.
+
 Examples:
     juju expose wordpress
+    juju expose mysql --endpoints db --to-cidrs 10.0.0.0/8,192.168.1.0/24
+    juju expose mysql --to-spaces admin
 
 See also: 
     unexpose`[1:]
@@ -34,6 +51,9 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       []string
+	ToSpaces        []string
+	ToCIDRs         []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +65,51 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.Endpoints), "endpoints", "Limit exposure to the given endpoints")
+	f.Var(cmd.NewStringsValue(nil, &c.ToSpaces), "to-spaces", "Expose only to the subnets of the given spaces")
+	f.Var(cmd.NewStringsValue(nil, &c.ToCIDRs), "to-cidrs", "Expose only to the given CIDRs")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, cidr := range c.ToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
+// exposedEndpoints returns the expose settings to send to the
+// controller, or nil if the application is exposed to everyone.
+func (c *exposeCommand) exposedEndpoints() map[string]params.ExposedEndpoint {
+	if len(c.Endpoints) == 0 && len(c.ToSpaces) == 0 && len(c.ToCIDRs) == 0 {
+		return nil
+	}
+	ep := params.ExposedEndpoint{
+		ExposeToSpaces: c.ToSpaces,
+		ExposeToCIDRs:  c.ToCIDRs,
+	}
+	if len(c.Endpoints) == 0 {
+		return map[string]params.ExposedEndpoint{"": ep}
+	}
+	exposed := make(map[string]params.ExposedEndpoint)
+	for _, name := range c.Endpoints {
+		exposed[name] = ep
+	}
+	return exposed
+}
+
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeEndpoints(serviceName string, exposed map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +129,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if exposed := c.exposedEndpoints(); exposed != nil {
+		err = client.ExposeEndpoints(c.ApplicationName, exposed)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
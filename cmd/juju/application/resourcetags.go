@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageResourceTagsSummary = `
+Gets or sets the tags on an application's cloud resources.`[1:]
+
+var usageResourceTagsDetails = `
+Tags set on an application are added to the cloud instances that host
+its units, and to the volumes of its storage, on clouds that support
+tagging. They are merged over the model's "resource-tags" setting,
+taking precedence where both set the same tag.
+
+Tags are given as key=value pairs; a pair with an empty value removes
+the tag from the application. With no pairs, the application's current
+tags are shown.
+
+Changes are also applied to existing instances and volumes, and
+removed tags are deleted from them.
+
+Tags starting with "juju-" are reserved and may not be set.
+
+Examples:
+    juju resource-tags mysql
+    juju resource-tags mysql team=db cost-centre=42
+    juju resource-tags mysql cost-centre=
+
+See also:
+    model-config`[1:]
+
+// NewResourceTagsCommand returns a command to get or set the resource
+// tags of an application.
+func NewResourceTagsCommand() modelcmd.ModelCommand {
+	cmd := &resourceTagsCommand{}
+	cmd.newAPIFunc = func() (ApplicationResourceTagsAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// resourceTagsCommand gets or sets the resource tags of an application.
+type resourceTagsCommand struct {
+	modelcmd.ModelCommandBase
+	out             cmd.Output
+	ApplicationName string
+	ResourceTags    map[string]string
+	newAPIFunc      func() (ApplicationResourceTagsAPI, error)
+}
+
+// ApplicationResourceTagsAPI defines the API methods that the
+// resource-tags command uses.
+type ApplicationResourceTagsAPI interface {
+	Close() error
+	Get(application string) (*params.ApplicationGetResults, error)
+	UpdateResourceTags(application string, resourceTags map[string]string) error
+}
+
+func (c *resourceTagsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resource-tags",
+		Args:    "<application name> [<key>=[<value>] ...]",
+		Purpose: usageResourceTagsSummary,
+		Doc:     usageResourceTagsDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *resourceTagsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+func (c *resourceTagsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	if len(args) == 1 {
+		return nil
+	}
+	resourceTags, err := keyvalues.Parse(args[1:], true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.ResourceTags = resourceTags
+	return nil
+}
+
+// Run shows or updates the resource tags of the application.
+func (c *resourceTagsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if c.ResourceTags == nil {
+		results, err := client.Get(c.ApplicationName)
+		if err != nil {
+			return err
+		}
+		resourceTags := results.ResourceTags
+		if resourceTags == nil {
+			resourceTags = make(map[string]string)
+		}
+		return c.out.Write(ctx, resourceTags)
+	}
+	err = client.UpdateResourceTags(c.ApplicationName, c.ResourceTags)
+	if errors.IsNotSupported(err) {
+		return errors.New("application resource tags are not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	jtesting "github.com/juju/juju/testing"
+)
+
+type ResourceTagsSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockResourceTagsAPI
+}
+
+func (s *ResourceTagsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockResourceTagsAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&ResourceTagsSuite{})
+
+func (s *ResourceTagsSuite) runResourceTags(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := NewResourceTagsCommandForTest(s.mockAPI)
+	cmd.SetClientStore(NewMockStore())
+	return cmdtesting.RunCommand(c, cmd, args...)
+}
+
+func (s *ResourceTagsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "team"},
+		err:  `expected "key=value", got "team"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runResourceTags(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ResourceTagsSuite) TestShowResourceTags(c *gc.C) {
+	s.mockAPI.resourceTags = map[string]string{"team": "db", "cost-centre": "42"}
+	ctx, err := s.runResourceTags(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "cost-centre: \"42\"\nteam: db\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"Get", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ResourceTagsSuite) TestUpdateResourceTags(c *gc.C) {
+	_, err := s.runResourceTags(c, "mysql", "team=db", "cost-centre=")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UpdateResourceTags", []interface{}{"mysql", map[string]string{"team": "db", "cost-centre": ""}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ResourceTagsSuite) TestUpdateResourceTagsNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("application resource tags on this controller"))
+	_, err := s.runResourceTags(c, "mysql", "team=db")
+	c.Assert(err, gc.ErrorMatches, "application resource tags are not supported by this controller")
+}
+
+func (s *ResourceTagsSuite) TestUpdateResourceTagsBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestUpdateResourceTagsBlocked"))
+	_, err := s.runResourceTags(c, "mysql", "team=db")
+	jtesting.AssertOperationWasBlocked(c, err, ".*TestUpdateResourceTagsBlocked.*")
+}
+
+type mockResourceTagsAPI struct {
+	*testing.Stub
+	resourceTags map[string]string
+}
+
+func (m *mockResourceTagsAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockResourceTagsAPI) Get(application string) (*params.ApplicationGetResults, error) {
+	m.MethodCall(m, "Get", application)
+	return &params.ApplicationGetResults{
+		Application:  application,
+		ResourceTags: m.resourceTags,
+	}, m.NextErr()
+}
+
+func (m *mockResourceTagsAPI) UpdateResourceTags(application string, resourceTags map[string]string) error {
+	m.MethodCall(m, "UpdateResourceTags", application, resourceTags)
+	return m.NextErr()
+}
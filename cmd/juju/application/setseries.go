@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/series"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetSeriesSummary = `
+Changes the series of new machines for an application's units.`[1:]
+
+var usageSetSeriesDetails = `
+Sets the series that machines provisioned for new units of a deployed
+application will run. The application's charm must support the series,
+unless --force is given, in which case the charm need only support the
+series' operating system. The series of the application's subordinates
+is changed with it, so their charms must support the series too, and
+they must not be related to other principals of a different series.
+
+Existing units keep running on their machines' series; "juju status"
+shows the series of each unit whose machine runs a different series
+from its application's. Use upgrade-series to upgrade those machines
+in place.
+
+Examples:
+    juju set-series wordpress xenial
+    juju set-series --force mysql bionic
+
+See also:
+    status
+    upgrade-series`[1:]
+
+// NewSetSeriesCommand returns a command to change the series of an
+// application.
+func NewSetSeriesCommand() modelcmd.ModelCommand {
+	cmd := &setSeriesCommand{}
+	cmd.newAPIFunc = func() (ApplicationSetSeriesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// setSeriesCommand changes the series of an application.
+type setSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Series          string
+	Force           bool
+	newAPIFunc      func() (ApplicationSetSeriesAPI, error)
+}
+
+// ApplicationSetSeriesAPI defines the API methods that the set-series
+// command uses.
+type ApplicationSetSeriesAPI interface {
+	Close() error
+	UpdateApplicationSeries(application, series string, force bool) error
+}
+
+func (c *setSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-series",
+		Args:    "<application name> <series>",
+		Purpose: usageSetSeriesSummary,
+		Doc:     usageSetSeriesDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *setSeriesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Force, "force", false, "Allow a series the charm does not declare support for")
+}
+
+func (c *setSeriesCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no series specified")
+	case 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	if _, err := series.SeriesVersion(args[1]); err != nil {
+		return errors.Trace(err)
+	}
+	c.ApplicationName = args[0]
+	c.Series = args[1]
+	return nil
+}
+
+// Run changes the series of the application.
+func (c *setSeriesCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.UpdateApplicationSeries(c.ApplicationName, c.Series, c.Force)
+	if errors.IsNotSupported(err) {
+		return errors.New("changing the series of an application is not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
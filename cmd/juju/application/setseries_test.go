@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	jtesting "github.com/juju/juju/testing"
+)
+
+type SetSeriesSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockSetSeriesAPI
+}
+
+func (s *SetSeriesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockSetSeriesAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&SetSeriesSuite{})
+
+func (s *SetSeriesSuite) runSetSeries(c *gc.C, args ...string) error {
+	cmd := NewSetSeriesCommandForTest(s.mockAPI)
+	cmd.SetClientStore(NewMockStore())
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	return err
+}
+
+func (s *SetSeriesSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no series specified",
+	}, {
+		args: []string{"mysql/0", "xenial"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "nonsense"},
+		err:  `.*"nonsense".*`,
+	}, {
+		args: []string{"mysql", "xenial", "trusty"},
+		err:  `unrecognized args: \["trusty"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.runSetSeries(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *SetSeriesSuite) TestSetSeries(c *gc.C) {
+	err := s.runSetSeries(c, "mysql", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UpdateApplicationSeries", []interface{}{"mysql", "xenial", false}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetSeriesSuite) TestSetSeriesForce(c *gc.C) {
+	err := s.runSetSeries(c, "--force", "mysql", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UpdateApplicationSeries", []interface{}{"mysql", "xenial", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetSeriesSuite) TestSetSeriesNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("changing the series of an application on this controller"))
+	err := s.runSetSeries(c, "mysql", "xenial")
+	c.Assert(err, gc.ErrorMatches, "changing the series of an application is not supported by this controller")
+}
+
+func (s *SetSeriesSuite) TestSetSeriesFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`cannot update series of application "mysql" to "xenial": only these series are supported: trusty`))
+	err := s.runSetSeries(c, "mysql", "xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot update series of application "mysql" to "xenial": only these series are supported: trusty`)
+}
+
+func (s *SetSeriesSuite) TestSetSeriesBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetSeriesBlocked"))
+	err := s.runSetSeries(c, "mysql", "xenial")
+	jtesting.AssertOperationWasBlocked(c, err, ".*TestSetSeriesBlocked.*")
+}
+
+type mockSetSeriesAPI struct {
+	*testing.Stub
+}
+
+func (m *mockSetSeriesAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSetSeriesAPI) UpdateApplicationSeries(application, series string, force bool) error {
+	m.MethodCall(m, "UpdateApplicationSeries", application, series, force)
+	return m.NextErr()
+}
@@ -333,6 +333,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
+	r.Register(user.NewAddGroupCommand())
+	r.Register(user.NewAddToGroupCommand())
+	r.Register(user.NewRemoveFromGroupCommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -343,6 +346,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewShowNetworkCommand())
+	r.Register(machine.NewUpgradeSeriesCommand())
+	r.Register(machine.NewMaintainMachineCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -351,6 +357,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
+	r.Register(model.NewAddRoleCommand())
+	r.Register(model.NewGrantRoleCommand())
+	r.Register(model.NewRevokeRoleCommand())
 	r.Register(model.NewShowCommand())
 
 	r.Register(newMigrateCommand())
@@ -371,12 +380,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
+	r.Register(application.NewBindCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
+	r.Register(application.NewSetSeriesCommand())
+	r.Register(application.NewResourceTagsCommand())
+	r.Register(application.NewAntiAffinityCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
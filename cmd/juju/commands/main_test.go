@@ -390,20 +390,25 @@ var commandNames = []string{
 	"actions",
 	"add-cloud",
 	"add-credential",
+	"add-group",
 	"add-machine",
 	"add-model",
 	"add-relation",
+	"add-role",
 	"add-space",
 	"add-ssh-key",
 	"add-storage",
 	"add-subnet",
+	"add-to-group",
 	"add-unit",
 	"add-user",
 	"agree",
 	"agreements",
+	"anti-affinity",
 	"attach",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"bootstrap",
 	"budget",
 	"cached-images",
@@ -436,6 +441,7 @@ var commandNames = []string{
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
+	"grant-role",
 	"gui",
 	"help",
 	"help-tool",
@@ -465,6 +471,7 @@ var commandNames = []string{
 	"login",
 	"logout",
 	"machines",
+	"maintain-machine",
 	"metrics",
 	"migrate",
 	"model-config",
@@ -480,6 +487,7 @@ var commandNames = []string{
 	"remove-cached-images",
 	"remove-cloud",
 	"remove-credential",
+	"remove-from-group",
 	"remove-machine",
 	"remove-relation",
 	"remove-ssh-key",
@@ -487,10 +495,12 @@ var commandNames = []string{
 	"remove-unit",
 	"remove-user",
 	"resolved",
+	"resource-tags",
 	"resources",
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
+	"revoke-role",
 	"run",
 	"run-action",
 	"scp",
@@ -500,6 +510,7 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-series",
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
@@ -508,6 +519,7 @@ var commandNames = []string{
 	"show-controller",
 	"show-machine",
 	"show-model",
+	"show-network",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -530,6 +542,7 @@ var commandNames = []string{
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrade-series",
 	"upload-backup",
 	"users",
 	"version",
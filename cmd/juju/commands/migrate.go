@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
@@ -27,10 +30,12 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationPrechecks(spec controller.MigrationSpec) ([]string, error)
 }
 
 const migrateDoc = `
@@ -56,6 +61,11 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the checks normally made before a migration starts
+are run against both the source and target controllers, but the
+migration is not started. Every problem found which would prevent the
+migration is listed, rather than just the first.
+
 See also:
     login
     controllers
@@ -72,6 +82,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the migration would succeed without starting it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -140,6 +156,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runPrechecks(ctx, api, *spec)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -148,6 +167,21 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runPrechecks(ctx *cmd.Context, api migrateAPI, spec controller.MigrationSpec) error {
+	blockers, err := api.MigrationPrechecks(spec)
+	if err != nil {
+		return err
+	}
+	if len(blockers) == 0 {
+		ctx.Infof("Migration prechecks passed")
+		return nil
+	}
+	for _, blocker := range blockers {
+		fmt.Fprintln(ctx.Stdout, blocker)
+	}
+	return errors.Errorf("migration prechecks found %d problem(s)", len(blockers))
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
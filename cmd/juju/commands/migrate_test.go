@@ -172,6 +172,37 @@ func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration prechecks passed\n")
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started
+	c.Check(s.api.precheckSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunBlockers(c *gc.C) {
+	s.api.blockers = []string{
+		"source: machine 0 is dying",
+		`target: cloud "aws" not found in target controller`,
+	}
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `migration prechecks found 2 problem\(s\)`)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+source: machine 0 is dying
+target: cloud "aws" not found in target controller
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	cmd := s.makeCommand()
 	_, err := cmdtesting.RunCommand(c, cmd, "wat", "target")
@@ -231,7 +262,14 @@ func (s *MigrateSuite) run(c *gc.C, cmd *migrateCommand, args ...string) (*cmd.C
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen     *controller.MigrationSpec
+	precheckSeen *controller.MigrationSpec
+	blockers     []string
+}
+
+func (a *fakeMigrateAPI) MigrationPrechecks(spec controller.MigrationSpec) ([]string, error) {
+	a.precheckSeen = &spec
+	return a.blockers, nil
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
 	"github.com/juju/utils/set"
 
 	apicontroller "github.com/juju/juju/api/controller"
@@ -24,23 +25,38 @@ func NewGetConfigCommand() cmd.Command {
 }
 
 // getConfigCommand is able to output either the entire environment or
-// the requested value in a format of the user's choosing.
+// the requested value in a format of the user's choosing. It can also
+// change the values that may be changed after bootstrap.
 type getConfigCommand struct {
 	modelcmd.ControllerCommandBase
-	api controllerAPI
-	key string
-	out cmd.Output
+	api    controllerAPI
+	key    string
+	values map[string]interface{}
+	reset  []string
+	out    cmd.Output
 }
 
 const getControllerHelpDoc = `
 By default, all configuration (keys and values) for the controller are
 displayed if a key is not specified.
 
+Supplying one or more key=value pairs changes those values, and
+--reset restores the given keys to their defaults. Only the following
+keys may be changed after the controller has been bootstrapped:
+
+    api-allow    comma-separated CIDRs from which clients may connect
+                 to the API port (default 0.0.0.0/0,::/0)
+
+Changes to api-allow are applied to the controller's firewall as soon
+as they are made.
+
 Examples:
 
     juju controller-config
     juju controller-config api-port
     juju controller-config -c mycontroller
+    juju controller-config api-allow=10.0.0.0/8,192.168.1.0/24
+    juju controller-config --reset api-allow
 
 See also:
     controllers
@@ -49,8 +65,8 @@ See also:
 func (c *getConfigCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "controller-config",
-		Args:    "[<attribute key>]",
-		Purpose: "Displays configuration settings for a controller.",
+		Args:    "[<attribute key> | <attribute key>=<value> ...]",
+		Purpose: "Displays or sets configuration settings for a controller.",
 		Doc:     strings.TrimSpace(getControllerHelpDoc),
 	}
 }
@@ -62,16 +78,47 @@ func (c *getConfigCommand) SetFlags(f *gnuflag.FlagSet) {
 		"tabular": formatConfigTabular,
 		"yaml":    cmd.FormatYaml,
 	})
+	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
 }
 
 func (c *getConfigCommand) Init(args []string) (err error) {
+	var resetKeys []string
+	for _, value := range c.reset {
+		for _, key := range strings.Split(strings.Trim(value, ","), ",") {
+			if strings.Contains(key, "=") {
+				return errors.Errorf(`--reset accepts a comma delimited set of keys "a,b,c", received: %q`, key)
+			}
+			resetKeys = append(resetKeys, key)
+		}
+	}
+	c.reset = resetKeys
+	if len(args) > 0 && strings.Contains(args[0], "=") {
+		options, err := keyvalues.Parse(args, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.values = make(map[string]interface{})
+		for k, v := range options {
+			c.values[k] = v
+		}
+		for _, k := range c.reset {
+			if _, ok := c.values[k]; ok {
+				return errors.Errorf("key %q cannot be both set and reset in the same command", k)
+			}
+		}
+		return nil
+	}
 	c.key, err = cmd.ZeroOrOneArgs(args)
-	return
+	if err == nil && c.key != "" && len(c.reset) > 0 {
+		return errors.New("cannot get and reset values in the same command")
+	}
+	return err
 }
 
 type controllerAPI interface {
 	Close() error
 	ControllerConfig() (controller.Config, error)
+	ConfigSet(values map[string]interface{}, remove ...string) error
 }
 
 func (c *getConfigCommand) getAPI() (controllerAPI, error) {
@@ -96,6 +143,10 @@ func (c *getConfigCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if len(c.values) > 0 || len(c.reset) > 0 {
+		return errors.Trace(client.ConfigSet(c.values, c.reset...))
+	}
+
 	attrs, err := client.ControllerConfig()
 	if err != nil {
 		return err
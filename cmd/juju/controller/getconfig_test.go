@@ -89,8 +89,45 @@ func (s *GetConfigSuite) TestError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error")
 }
 
+func (s *GetConfigSuite) TestInitSetAndReset(c *gc.C) {
+	err := cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one", "--reset", "two"})
+	c.Check(err, gc.ErrorMatches, "cannot get and reset values in the same command")
+	err = cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one=1", "--reset", "one"})
+	c.Check(err, gc.ErrorMatches, `key "one" cannot be both set and reset in the same command`)
+	err = cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"--reset", "one=1"})
+	c.Check(err, gc.ErrorMatches, `--reset accepts a comma delimited set of keys "a,b,c", received: "one=1"`)
+}
+
+func (s *GetConfigSuite) TestSetValues(c *gc.C) {
+	api := &fakeControllerAPI{}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "api-allow=10.0.0.0/8,192.168.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.values, jc.DeepEquals, map[string]interface{}{
+		"api-allow": "10.0.0.0/8,192.168.0.0/16",
+	})
+	c.Assert(api.reset, gc.HasLen, 0)
+}
+
+func (s *GetConfigSuite) TestResetValues(c *gc.C) {
+	api := &fakeControllerAPI{}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--reset", "api-allow")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.values, gc.HasLen, 0)
+	c.Assert(api.reset, jc.DeepEquals, []string{"api-allow"})
+}
+
 type fakeControllerAPI struct {
-	err error
+	err    error
+	values map[string]interface{}
+	reset  []string
+}
+
+func (f *fakeControllerAPI) ConfigSet(values map[string]interface{}, reset ...string) error {
+	f.values = values
+	f.reset = reset
+	return f.err
 }
 
 func (f *fakeControllerAPI) Close() error {
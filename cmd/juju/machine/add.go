@@ -5,6 +5,7 @@ package machine
 
 import (
 	"fmt"
+	"net"
 	"path/filepath"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 	"github.com/juju/juju/environs/manual/winrmprovisioner"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
 )
@@ -57,10 +59,23 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Existing instances in the model's cloud can be adopted into the model with
+--adopt, without reinstalling them. The instance is looked up with the
+provider, and must be reachable via SSH at its public address; the Juju agent
+is then installed on it in the same way as for manual provisioning. Unlike a
+manually provisioned machine, the provider manages an adopted machine like any
+other: the instance is given the model's tags and security groups, removing it
+stops the instance, and firewalling applies to it. Adoption is only supported
+on some clouds. On AWS, security groups can only be added to instances in a
+VPC, so an EC2-Classic instance must already be in the model's security groups.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
-MAAS provider to acquire a particular node by specifying its hostname.
+MAAS provider to acquire a particular node by specifying its hostname. On
+AWS, "host=<dedicated host ID>" starts the machine on a dedicated host and
+"tenancy=dedicated" on dedicated hardware; on OpenStack, "server-group=<name
+or ID>" starts the machine in a server group.
 
 Examples:
    juju add-machine                      (starts a new machine)
@@ -71,6 +86,7 @@ Examples:
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
+   juju add-machine --adopt i-0a1b2c3d   (adopts existing instance i-0a1b2c3d)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
@@ -114,6 +130,8 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Adopt is the provider ID of an existing instance to adopt.
+	Adopt string
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -131,6 +149,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.Adopt, "adopt", "", "Adopt the existing cloud instance with this ID")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -152,6 +171,16 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Adopt != "" {
+		switch {
+		case c.Placement != nil:
+			return errors.New("cannot use --adopt when specifying a placement directive")
+		case c.NumMachines != 1:
+			return errors.New("cannot use --adopt with -n")
+		case c.Series != "" || c.ConstraintsStr != "" || len(c.Disks) > 0:
+			return errors.New("cannot use --adopt with --series, --constraints or --disks")
+		}
+	}
 	return nil
 }
 
@@ -170,6 +199,9 @@ type ModelConfigAPI interface {
 
 type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
+	AdoptableInstance(instanceId string) ([]network.Address, error)
+	AdoptInstances([]params.AddMachineParams) ([]params.AddMachinesResult, error)
+	DestroyMachinesWithParams(force, keepInstance bool, machines ...string) ([]params.DestroyMachineResult, error)
 	BestAPIVersion() int
 	Close() error
 }
@@ -259,6 +291,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.Adopt != "" {
+		return c.adoptInstance(client, config, ctx)
+	}
+
 	if c.Placement != nil {
 		err := c.tryManualProvision(client, config, ctx)
 		if err != errNonManualScope {
@@ -420,3 +456,96 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 
 	return err
 }
+
+// checkReachable returns an error if the host being adopted does not
+// accept SSH connections.
+var checkReachable = func(host string) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, "22"), 25*time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (c *addCommand) adoptInstance(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	machineManager, err := c.getMachineManagerAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer machineManager.Close()
+
+	addrs, err := machineManager.AdoptableInstance(c.Adopt)
+	if errors.IsNotSupported(err) {
+		return errors.New("adopting instances is not supported by this controller")
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	addr, ok := network.SelectPublicAddress(addrs)
+	if !ok {
+		return errors.Errorf("instance %q has no usable address", c.Adopt)
+	}
+	if err := checkReachable(addr.Value); err != nil {
+		return errors.Annotatef(err, "cannot reach instance %q at %s", c.Adopt, addr.Value)
+	}
+
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot read authorized-keys")
+	}
+	args := manual.ProvisionMachineArgs{
+		Host: addr.Value,
+		Client: adoptionClient{
+			client:         client,
+			machineManager: machineManager,
+		},
+		Stdin:          ctx.Stdin,
+		Stdout:         ctx.Stdout,
+		Stderr:         ctx.Stderr,
+		AuthorizedKeys: authKeys,
+		UpdateBehavior: &params.UpdateBehavior{
+			EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
+			EnableOSUpgrade:       config.EnableOSUpgrade(),
+		},
+		InstanceId: instance.Id(c.Adopt),
+	}
+	machineId, err := sshProvisioner(args)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	ctx.Infof("adopted instance %s as machine %v", c.Adopt, machineId)
+	return nil
+}
+
+// adoptionClient provides the API used to provision an adopted
+// instance. The machine is recorded through the MachineManager facade,
+// which checks the instance with the provider, and if provisioning
+// fails, the machine is removed while its instance is kept running.
+type adoptionClient struct {
+	client         AddMachineAPI
+	machineManager MachineManagerAPI
+}
+
+// AddMachines is part of the manual.ProvisioningClientAPI interface.
+func (a adoptionClient) AddMachines(machineParams []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	return a.machineManager.AdoptInstances(machineParams)
+}
+
+// ForceDestroyMachines is part of the manual.ProvisioningClientAPI interface.
+func (a adoptionClient) ForceDestroyMachines(machines ...string) error {
+	results, err := a.machineManager.DestroyMachinesWithParams(true, true, machines...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+	return nil
+}
+
+// ProvisioningScript is part of the manual.ProvisioningClientAPI interface.
+func (a adoptionClient) ProvisioningScript(args params.ProvisioningScriptParams) (string, error) {
+	return a.client.ProvisioningScript(args)
+}
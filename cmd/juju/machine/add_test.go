@@ -17,6 +17,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -156,6 +158,89 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) TestInitAdopt(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		args: []string{"--adopt", "i-1"},
+	}, {
+		args:        []string{"--adopt", "i-1", "lxd"},
+		errorString: "cannot use --adopt when specifying a placement directive",
+	}, {
+		args:        []string{"--adopt", "i-1", "-n", "2"},
+		errorString: "cannot use --adopt with -n",
+	}, {
+		args:        []string{"--adopt", "i-1", "--series", "xenial"},
+		errorString: "cannot use --adopt with --series, --constraints or --disks",
+	}} {
+		c.Logf("test %d", i)
+		wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(addCmd.Adopt, gc.Equals, "i-1")
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *AddMachineSuite) TestAdopt(c *gc.C) {
+	s.fakeMachineManager.adoptableAddrs = network.NewAddresses("10.0.0.1", "54.0.0.1")
+	var reached []string
+	s.PatchValue(machine.CheckReachable, func(host string) error {
+		reached = append(reached, host)
+		return nil
+	})
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Check(args.Host, gc.Equals, "54.0.0.1")
+		c.Check(args.InstanceId, gc.Equals, instance.Id("i-1"))
+		results, err := args.Client.AddMachines([]params.AddMachineParams{{InstanceId: args.InstanceId}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results, gc.HasLen, 1)
+		return results[0].Machine, nil
+	})
+	context, err := s.run(c, "--adopt", "i-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "adopted instance i-1 as machine 0\n")
+	c.Assert(reached, jc.DeepEquals, []string{"54.0.0.1"})
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.adoptArgs, jc.DeepEquals, []params.AddMachineParams{{InstanceId: "i-1"}})
+}
+
+func (s *AddMachineSuite) TestAdoptFailureKeepsInstance(c *gc.C) {
+	s.fakeMachineManager.adoptableAddrs = network.NewAddresses("54.0.0.1")
+	s.PatchValue(machine.CheckReachable, func(host string) error { return nil })
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		err := args.Client.ForceDestroyMachines("3")
+		c.Assert(err, jc.ErrorIsNil)
+		return "", errors.New("failed to initialize warp core")
+	})
+	_, err := s.run(c, "--adopt", "i-1")
+	c.Assert(err, gc.ErrorMatches, "failed to initialize warp core")
+	c.Assert(s.fakeMachineManager.destroyCalls, jc.DeepEquals, []string{"force keep-instance 3"})
+}
+
+func (s *AddMachineSuite) TestAdoptUnreachable(c *gc.C) {
+	s.fakeMachineManager.adoptableAddrs = network.NewAddresses("54.0.0.1")
+	s.PatchValue(machine.CheckReachable, func(host string) error {
+		return errors.New("connection refused")
+	})
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Fatalf("unexpected provisioning")
+		return "", nil
+	})
+	_, err := s.run(c, "--adopt", "i-1")
+	c.Assert(err, gc.ErrorMatches, `cannot reach instance "i-1" at 54.0.0.1: connection refused`)
+}
+
+func (s *AddMachineSuite) TestAdoptNotSupported(c *gc.C) {
+	s.fakeMachineManager.adoptableErr = errors.NotSupportedf("adopting instances")
+	_, err := s.run(c, "--adopt", "i-1")
+	c.Assert(err, gc.ErrorMatches, "adopting instances is not supported by this controller")
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
@@ -275,8 +360,40 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 type fakeMachineManagerAPI struct {
 	apiVersion int
 	fakeAddMachineAPI
+
+	adoptableAddrs []network.Address
+	adoptableErr   error
+	adoptArgs      []params.AddMachineParams
+	destroyCalls   []string
 }
 
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
 	return f.apiVersion
 }
+
+func (f *fakeMachineManagerAPI) AdoptableInstance(instanceId string) ([]network.Address, error) {
+	return f.adoptableAddrs, f.adoptableErr
+}
+
+func (f *fakeMachineManagerAPI) AdoptInstances(args []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	f.adoptArgs = append(f.adoptArgs, args...)
+	results := make([]params.AddMachinesResult, len(args))
+	for i := range args {
+		results[i].Machine = strconv.Itoa(i)
+	}
+	return results, nil
+}
+
+func (f *fakeMachineManagerAPI) DestroyMachinesWithParams(force, keepInstance bool, machines ...string) ([]params.DestroyMachineResult, error) {
+	for _, id := range machines {
+		call := id
+		if keepInstance {
+			call = "keep-instance " + call
+		}
+		if force {
+			call = "force " + call
+		}
+		f.destroyCalls = append(f.destroyCalls, call)
+	}
+	return make([]params.DestroyMachineResult, len(machines)), nil
+}
@@ -12,6 +12,7 @@ import (
 
 var (
 	SSHProvisioner = &sshProvisioner
+	CheckReachable = &checkReachable
 )
 
 type AddCommand struct {
@@ -55,3 +56,21 @@ func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand)
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewShowNetworkCommandForTest returns a showNetworkCommand with the
+// specified api.
+func NewShowNetworkCommandForTest(api ShowNetworkAPI) cmd.Command {
+	return modelcmd.Wrap(&showNetworkCommand{api: api})
+}
+
+// NewUpgradeSeriesCommandForTest returns an upgradeSeriesCommand with
+// the specified api.
+func NewUpgradeSeriesCommandForTest(api UpgradeSeriesAPI) cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{api: api})
+}
+
+// NewMaintainMachineCommandForTest returns a maintainMachineCommand with
+// the specified api.
+func NewMaintainMachineCommandForTest(api MaintainMachineAPI) cmd.Command {
+	return modelcmd.Wrap(&maintainMachineCommand{api: api})
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const maintainMachineDoc = `
+Put a machine into maintenance, or take it out of maintenance again.
+
+While a machine is in maintenance, no new units or containers are placed
+on it. Units and containers already on the machine keep running.
+
+With --evacuate, every principal unit on the machine, or in a container
+on it, that has no storage attached is replaced by a new unit of the same application on another
+machine, and the original unit is removed. Units with storage are kept
+on the machine and listed, so that they can be dealt with by hand.
+
+With --done, the machine is taken out of maintenance and becomes
+available for placement again.
+
+Examples:
+
+Put machine 3 into maintenance:
+
+    juju maintain-machine 3
+
+Put machine 3 into maintenance and move its stateless units elsewhere:
+
+    juju maintain-machine 3 --evacuate
+
+Take machine 3 out of maintenance:
+
+    juju maintain-machine 3 --done
+
+See also:
+    remove-machine
+    show-machine
+    status
+`
+
+// NewMaintainMachineCommand returns a command used to put a machine
+// into maintenance or take it out again.
+func NewMaintainMachineCommand() cmd.Command {
+	return modelcmd.Wrap(&maintainMachineCommand{})
+}
+
+// MaintainMachineAPI defines the API methods used by the maintain-machine
+// command.
+type MaintainMachineAPI interface {
+	MaintainMachine(machineId string, maintenance, evacuate bool) (params.MaintainMachineResult, error)
+	Close() error
+}
+
+// maintainMachineCommand puts a machine into maintenance.
+type maintainMachineCommand struct {
+	modelcmd.ModelCommandBase
+	api MaintainMachineAPI
+
+	machineId string
+	evacuate  bool
+	done      bool
+}
+
+// Info implements Command.Info.
+func (c *maintainMachineCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "maintain-machine",
+		Args:    "<machine>",
+		Purpose: "Put a machine into maintenance, or take it out again.",
+		Doc:     maintainMachineDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *maintainMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.evacuate, "evacuate", false, "Move stateless units off the machine")
+	f.BoolVar(&c.done, "done", false, "Take the machine out of maintenance")
+}
+
+// Init implements Command.Init.
+func (c *maintainMachineCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.Errorf("wrong number of arguments")
+	}
+	c.machineId = args[0]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.Errorf("invalid machine id %q", c.machineId)
+	}
+	if c.evacuate && c.done {
+		return errors.Errorf("--evacuate cannot be used with --done")
+	}
+	return nil
+}
+
+func (c *maintainMachineCommand) getAPI() (MaintainMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *maintainMachineCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.MaintainMachine(c.machineId, !c.done, c.evacuate)
+	if errors.IsNotSupported(err) {
+		return errors.New("machine maintenance is not supported by this controller")
+	}
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	if c.done {
+		ctx.Infof("machine %s is no longer in maintenance", c.machineId)
+		return nil
+	}
+	ctx.Infof("machine %s is in maintenance", c.machineId)
+
+	evacuated := make([]string, 0, len(result.EvacuatedUnits))
+	for unitTag, replacementTag := range result.EvacuatedUnits {
+		unit, err := unitId(unitTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		replacement, err := unitId(replacementTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		evacuated = append(evacuated, unit+" replaced by "+replacement)
+	}
+	sort.Strings(evacuated)
+	for _, line := range evacuated {
+		ctx.Infof("unit %s", line)
+	}
+	for _, entity := range result.KeptUnits {
+		unit, err := unitId(entity.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("unit %s has storage and was kept on the machine", unit)
+	}
+	return nil
+}
+
+func unitId(tag string) (string, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return "", err
+	}
+	return unitTag.Id(), nil
+}
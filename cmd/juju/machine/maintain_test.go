@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type MaintainMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeMaintainMachineAPI
+}
+
+var _ = gc.Suite(&MaintainMachineSuite{})
+
+func (s *MaintainMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeMaintainMachineAPI{}
+}
+
+func (s *MaintainMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, machine.NewMaintainMachineCommandForTest(s.fake), args...)
+}
+
+func (s *MaintainMachineSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "wrong number of arguments",
+	}, {
+		args: []string{"0", "1"},
+		err:  "wrong number of arguments",
+	}, {
+		args: []string{"lxd"},
+		err:  `invalid machine id "lxd"`,
+	}, {
+		args: []string{"0", "--evacuate", "--done"},
+		err:  "--evacuate cannot be used with --done",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(machine.NewMaintainMachineCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MaintainMachineSuite) TestMaintain(c *gc.C) {
+	ctx, err := s.run(c, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"1 true false"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "machine 1 is in maintenance\n")
+}
+
+func (s *MaintainMachineSuite) TestEvacuate(c *gc.C) {
+	s.fake.result = params.MaintainMachineResult{
+		EvacuatedUnits: map[string]string{
+			"unit-wordpress-1": "unit-wordpress-3",
+			"unit-mysql-0":     "unit-mysql-1",
+		},
+		KeptUnits: []params.Entity{{Tag: "unit-postgresql-0"}},
+	}
+	ctx, err := s.run(c, "1", "--evacuate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"1 true true"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+machine 1 is in maintenance
+unit mysql/0 replaced by mysql/1
+unit wordpress/1 replaced by wordpress/3
+unit postgresql/0 has storage and was kept on the machine
+`[1:])
+}
+
+func (s *MaintainMachineSuite) TestDone(c *gc.C) {
+	ctx, err := s.run(c, "1", "--done")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"1 false false"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "machine 1 is no longer in maintenance\n")
+}
+
+func (s *MaintainMachineSuite) TestNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("machine maintenance")
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.ErrorMatches, "machine maintenance is not supported by this controller")
+}
+
+func (s *MaintainMachineSuite) TestError(c *gc.C) {
+	s.fake.err = errors.New(`cannot set maintenance of machine 1: not found or dead`)
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.ErrorMatches, `cannot set maintenance of machine 1: not found or dead`)
+}
+
+func (s *MaintainMachineSuite) TestBlocked(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlocked")
+	_, err := s.run(c, "1")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+type fakeMaintainMachineAPI struct {
+	calls  []string
+	result params.MaintainMachineResult
+	err    error
+}
+
+func (f *fakeMaintainMachineAPI) Close() error {
+	return nil
+}
+
+func (f *fakeMaintainMachineAPI) MaintainMachine(machineId string, maintenance, evacuate bool) (params.MaintainMachineResult, error) {
+	f.calls = append(f.calls, fmt.Sprintf("%s %v %v", machineId, maintenance, evacuate))
+	return f.result, f.err
+}
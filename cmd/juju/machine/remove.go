@@ -24,9 +24,10 @@ func NewRemoveCommand() cmd.Command {
 // removeCommand causes an existing machine to be destroyed.
 type removeCommand struct {
 	modelcmd.ModelCommandBase
-	api        RemoveMachineAPI
-	MachineIds []string
-	Force      bool
+	api          RemoveMachineAPI
+	MachineIds   []string
+	Force        bool
+	KeepInstance bool
 }
 
 const destroyMachineDoc = `
@@ -36,6 +37,11 @@ Machines responsible for the model cannot be removed.
 Machines running units or containers can be removed using the '--force'
 option; this will also remove those units and containers without giving
 them an opportunity to shut down cleanly.
+Machines can be removed from the model without stopping their cloud
+instances using the '--keep-instance' option. The instances are left
+running and must then be cleaned up by hand. Instances kept this way
+may still be stopped by the provisioner if the model's
+"provisioner-harvest-mode" is set to "all" or "unknown".
 
 Examples:
 
@@ -47,6 +53,10 @@ Remove machine 6 and any running units or containers:
 
     juju remove-machine 6 --force
 
+Remove machine 7 from the model, leaving its cloud instance running:
+
+    juju remove-machine 7 --keep-instance
+
 See also:
     add-machine
 `
@@ -65,6 +75,7 @@ func (c *removeCommand) Info() *cmd.Info {
 func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
+	f.BoolVar(&c.KeepInstance, "keep-instance", false, "Do not stop the running cloud instance")
 }
 
 func (c *removeCommand) Init(args []string) error {
@@ -83,6 +94,7 @@ func (c *removeCommand) Init(args []string) error {
 type RemoveMachineAPI interface {
 	DestroyMachines(machines ...string) ([]params.DestroyMachineResult, error)
 	ForceDestroyMachines(machines ...string) ([]params.DestroyMachineResult, error)
+	DestroyMachinesWithParams(force, keepInstance bool, machines ...string) ([]params.DestroyMachineResult, error)
 	Close() error
 }
 
@@ -100,6 +112,10 @@ func (a removeMachineAdapter) ForceDestroyMachines(machines ...string) ([]params
 	return a.destroyMachines(a.Client.ForceDestroyMachines, machines)
 }
 
+func (a removeMachineAdapter) DestroyMachinesWithParams(force, keepInstance bool, machines ...string) ([]params.DestroyMachineResult, error) {
+	return nil, errors.NotSupportedf("destroying machines with parameters")
+}
+
 func (a removeMachineAdapter) destroyMachines(f func(...string) error, machines []string) ([]params.DestroyMachineResult, error) {
 	if err := f(machines...); err != nil {
 		return nil, err
@@ -137,8 +153,16 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	if c.Force {
 		destroy = client.ForceDestroyMachines
 	}
+	if c.KeepInstance {
+		destroy = func(machines ...string) ([]params.DestroyMachineResult, error) {
+			return client.DestroyMachinesWithParams(c.Force, c.KeepInstance, machines...)
+		}
+	}
 
 	results, err := destroy(c.MachineIds...)
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support --keep-instance")
+	}
 	if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
 		return err
 	}
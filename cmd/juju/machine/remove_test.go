@@ -6,6 +6,7 @@ package machine_test
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -114,6 +115,27 @@ func (s *RemoveMachineSuite) TestRemoveForce(c *gc.C) {
 	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1", "2/lxd/1"})
 }
 
+func (s *RemoveMachineSuite) TestRemoveKeepInstance(c *gc.C) {
+	_, err := s.run(c, "--keep-instance", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsFalse)
+	c.Assert(s.fake.keepInstance, jc.IsTrue)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1"})
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceKeepInstance(c *gc.C) {
+	_, err := s.run(c, "--force", "--keep-instance", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.forced, jc.IsTrue)
+	c.Assert(s.fake.keepInstance, jc.IsTrue)
+}
+
+func (s *RemoveMachineSuite) TestRemoveKeepInstanceNotSupported(c *gc.C) {
+	s.fake.removeError = errors.NotSupportedf("destroying machines with parameters")
+	_, err := s.run(c, "--keep-instance", "1")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support --keep-instance")
+}
+
 func (s *RemoveMachineSuite) TestBlockedError(c *gc.C) {
 	s.fake.removeError = common.OperationBlockedError("TestBlockedError")
 	_, err := s.run(c, "1")
@@ -129,10 +151,11 @@ func (s *RemoveMachineSuite) TestForceBlockedError(c *gc.C) {
 }
 
 type fakeRemoveMachineAPI struct {
-	forced      bool
-	machines    []string
-	removeError error
-	results     []params.DestroyMachineResult
+	forced       bool
+	keepInstance bool
+	machines     []string
+	removeError  error
+	results      []params.DestroyMachineResult
 }
 
 func (f *fakeRemoveMachineAPI) Close() error {
@@ -149,6 +172,12 @@ func (f *fakeRemoveMachineAPI) ForceDestroyMachines(machines ...string) ([]param
 	return f.destroyMachines(machines)
 }
 
+func (f *fakeRemoveMachineAPI) DestroyMachinesWithParams(force, keepInstance bool, machines ...string) ([]params.DestroyMachineResult, error) {
+	f.forced = force
+	f.keepInstance = keepInstance
+	return f.destroyMachines(machines)
+}
+
 func (f *fakeRemoveMachineAPI) destroyMachines(machines []string) ([]params.DestroyMachineResult, error) {
 	f.machines = machines
 	if f.removeError != nil || f.results != nil {
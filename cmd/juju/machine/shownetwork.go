@@ -0,0 +1,262 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showNetworkCommandDoc = `
+Show the network devices Juju knows about on the specified machines,
+including bridges and other virtual devices, along with their parent
+devices, MAC addresses, MTUs, provider IDs and addresses. The space
+each address is in is shown when the address's subnet is known to the
+model.
+
+The default format is tabular, other formats can be specified with the
+"--format" option. Available formats are yaml, tabular, and json.
+
+Examples:
+    # Display the network devices of machine 0
+    juju show-network 0
+
+    # Display the network devices of machine 1 and its container
+    juju show-network 1 1/lxd/0 --format yaml
+
+See also:
+    show-machine
+    spaces
+    subnets
+`
+
+// NewShowNetworkCommand returns a command that shows the network
+// devices of the specified machines.
+func NewShowNetworkCommand() cmd.Command {
+	return modelcmd.Wrap(&showNetworkCommand{})
+}
+
+// ShowNetworkAPI defines the API methods used by the show-network
+// command.
+type ShowNetworkAPI interface {
+	LinkLayerDevices(machines ...string) ([]params.LinkLayerDevicesResult, error)
+	Close() error
+}
+
+// showNetworkCommand shows the network devices of machines.
+type showNetworkCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	api        ShowNetworkAPI
+	machineIds []string
+}
+
+// Info implements Command.Info.
+func (c *showNetworkCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-network",
+		Args:    "<machineID> ...",
+		Purpose: "Show the network devices of machines.",
+		Doc:     showNetworkCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showNetworkCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatNetworkTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showNetworkCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid machine id %q", id)
+		}
+	}
+	c.machineIds = args
+	return nil
+}
+
+func (c *showNetworkCommand) getAPI() (ShowNetworkAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showNetworkCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.LinkLayerDevices(c.machineIds...)
+	if errors.IsNotSupported(err) {
+		return errors.New("showing network devices is not supported by this controller")
+	} else if err != nil {
+		return err
+	}
+
+	anyFailed := false
+	formatted := formattedNetworks{
+		Machines: make(map[string]formattedMachineNetwork),
+	}
+	for i, id := range c.machineIds {
+		result := results[i]
+		if result.Error != nil {
+			anyFailed = true
+			fmt.Fprintf(ctx.Stderr, "cannot show network of machine %s: %s\n", id, result.Error)
+			continue
+		}
+		formatted.Machines[id] = formatMachineNetwork(result.Devices)
+	}
+	if err := c.out.Write(ctx, formatted); err != nil {
+		return err
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+type formattedNetworks struct {
+	Machines map[string]formattedMachineNetwork `yaml:"machines" json:"machines"`
+}
+
+type formattedMachineNetwork struct {
+	Devices map[string]formattedDevice `yaml:"devices" json:"devices"`
+}
+
+type formattedDevice struct {
+	Type          string             `yaml:"type" json:"type"`
+	MACAddress    string             `yaml:"mac-address,omitempty" json:"mac-address,omitempty"`
+	MTU           uint               `yaml:"mtu,omitempty" json:"mtu,omitempty"`
+	ProviderId    string             `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Parent        string             `yaml:"parent,omitempty" json:"parent,omitempty"`
+	ParentMachine string             `yaml:"parent-machine,omitempty" json:"parent-machine,omitempty"`
+	Up            bool               `yaml:"up" json:"up"`
+	AutoStart     bool               `yaml:"auto-start" json:"auto-start"`
+	Addresses     []formattedAddress `yaml:"addresses,omitempty" json:"addresses,omitempty"`
+}
+
+type formattedAddress struct {
+	Value        string   `yaml:"address" json:"address"`
+	CIDR         string   `yaml:"cidr" json:"cidr"`
+	ConfigMethod string   `yaml:"config-method" json:"config-method"`
+	Space        string   `yaml:"space,omitempty" json:"space,omitempty"`
+	ProviderId   string   `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Gateway      string   `yaml:"gateway,omitempty" json:"gateway,omitempty"`
+	DNSServers   []string `yaml:"dns-servers,omitempty" json:"dns-servers,omitempty"`
+}
+
+func formatMachineNetwork(devices []params.LinkLayerDevice) formattedMachineNetwork {
+	out := formattedMachineNetwork{
+		Devices: make(map[string]formattedDevice),
+	}
+	for _, device := range devices {
+		formatted := formattedDevice{
+			Type:          device.Type,
+			MACAddress:    device.MACAddress,
+			MTU:           device.MTU,
+			ProviderId:    device.ProviderId,
+			Parent:        device.ParentName,
+			ParentMachine: device.ParentMachineId,
+			Up:            device.IsUp,
+			AutoStart:     device.IsAutoStart,
+		}
+		for _, addr := range device.Addresses {
+			formatted.Addresses = append(formatted.Addresses, formattedAddress{
+				Value:        addr.Value,
+				CIDR:         addr.CIDR,
+				ConfigMethod: addr.ConfigMethod,
+				Space:        addr.SpaceName,
+				ProviderId:   addr.ProviderId,
+				Gateway:      addr.GatewayAddress,
+				DNSServers:   addr.DNSServers,
+			})
+		}
+		out.Devices[device.Name] = formatted
+	}
+	return out
+}
+
+// formatNetworkTabular writes a row for each device, followed by
+// a row for each of its addresses after the first.
+func formatNetworkTabular(writer io.Writer, value interface{}) error {
+	networks, ok := value.(formattedNetworks)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", networks, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Machine\tDevice\tType\tParent\tMAC\tMTU\tState\tProvider ID\tAddress\tSubnet\tSpace")
+
+	machineIds := make([]string, 0, len(networks.Machines))
+	for id := range networks.Machines {
+		machineIds = append(machineIds, id)
+	}
+	sort.Strings(machineIds)
+	for _, id := range machineIds {
+		devices := networks.Machines[id].Devices
+		deviceNames := make([]string, 0, len(devices))
+		for name := range devices {
+			deviceNames = append(deviceNames, name)
+		}
+		sort.Strings(deviceNames)
+		for _, name := range deviceNames {
+			device := devices[name]
+			parent := device.Parent
+			if device.ParentMachine != "" {
+				parent = fmt.Sprintf("%s on %s", parent, device.ParentMachine)
+			}
+			mtu := ""
+			if device.MTU > 0 {
+				mtu = fmt.Sprint(device.MTU)
+			}
+			state := "down"
+			if device.Up {
+				state = "up"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t",
+				id, name, device.Type, parent, device.MACAddress, mtu, state, device.ProviderId,
+			)
+			if len(device.Addresses) == 0 {
+				fmt.Fprintln(tw, "\t\t")
+			}
+			for i, addr := range device.Addresses {
+				if i > 0 {
+					fmt.Fprint(tw, strings.Repeat("\t", 8))
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", addr.Value, addr.CIDR, addr.Space)
+			}
+		}
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type ShowNetworkSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeShowNetworkAPI
+}
+
+var _ = gc.Suite(&ShowNetworkSuite{})
+
+func (s *ShowNetworkSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeShowNetworkAPI{
+		results: map[string]params.LinkLayerDevicesResult{
+			"0": {
+				Devices: []params.LinkLayerDevice{{
+					Name:        "eth0",
+					Type:        "ethernet",
+					MACAddress:  "aa:bb:cc:dd:ee:f0",
+					MTU:         1500,
+					IsUp:        true,
+					IsAutoStart: true,
+					ParentName:  "br-eth0",
+				}, {
+					Name:        "br-eth0",
+					Type:        "bridge",
+					MACAddress:  "aa:bb:cc:dd:ee:f0",
+					MTU:         1500,
+					ProviderId:  "nic-1",
+					IsUp:        true,
+					IsAutoStart: true,
+					Addresses: []params.LinkLayerDeviceAddress{{
+						Value:          "10.0.0.10",
+						CIDR:           "10.0.0.0/24",
+						ConfigMethod:   "static",
+						SpaceName:      "db",
+						GatewayAddress: "10.0.0.1",
+						DNSServers:     []string{"10.0.0.2"},
+					}, {
+						Value:        "10.0.0.11",
+						CIDR:         "10.0.0.0/24",
+						ConfigMethod: "static",
+						SpaceName:    "db",
+					}},
+				}},
+			},
+			"0/lxd/0": {
+				Devices: []params.LinkLayerDevice{{
+					Name:            "eth0",
+					Type:            "ethernet",
+					MACAddress:      "aa:bb:cc:dd:ee:f1",
+					ParentName:      "br-eth0",
+					ParentMachineId: "0",
+					Addresses: []params.LinkLayerDeviceAddress{{
+						Value:        "10.0.0.20",
+						CIDR:         "10.0.0.0/24",
+						ConfigMethod: "dynamic",
+					}},
+				}},
+			},
+			"1": {
+				Error: &params.Error{Message: "machine 1 not found", Code: params.CodeNotFound},
+			},
+		},
+	}
+}
+
+func (s *ShowNetworkSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, machine.NewShowNetworkCommandForTest(s.fake), args...)
+}
+
+func (s *ShowNetworkSuite) TestInit(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no machines specified")
+	_, err = s.run(c, "lxd")
+	c.Assert(err, gc.ErrorMatches, `invalid machine id "lxd"`)
+}
+
+func (s *ShowNetworkSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "0", "0/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"0", "0/lxd/0"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Machine  Device   Type      Parent        MAC                MTU   State  Provider ID  Address    Subnet       Space\n"+
+		"0        br-eth0  bridge                  aa:bb:cc:dd:ee:f0  1500  up     nic-1        10.0.0.10  10.0.0.0/24  db\n"+
+		"                                                                                       10.0.0.11  10.0.0.0/24  db\n"+
+		"0        eth0     ethernet  br-eth0       aa:bb:cc:dd:ee:f0  1500  up                                          \n"+
+		"0/lxd/0  eth0     ethernet  br-eth0 on 0  aa:bb:cc:dd:ee:f1        down                10.0.0.20  10.0.0.0/24  \n",
+	)
+}
+
+func (s *ShowNetworkSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "0/lxd/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+machines:
+  0/lxd/0:
+    devices:
+      eth0:
+        type: ethernet
+        mac-address: aa:bb:cc:dd:ee:f1
+        parent: br-eth0
+        parent-machine: "0"
+        up: false
+        auto-start: false
+        addresses:
+        - address: 10.0.0.20
+          cidr: 10.0.0.0/24
+          config-method: dynamic
+`[1:])
+}
+
+func (s *ShowNetworkSuite) TestJSON(c *gc.C) {
+	ctx, err := s.run(c, "0", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"machines":{"0":{"devices":{`+
+		`"br-eth0":{"type":"bridge","mac-address":"aa:bb:cc:dd:ee:f0","mtu":1500,"provider-id":"nic-1","up":true,"auto-start":true,`+
+		`"addresses":[{"address":"10.0.0.10","cidr":"10.0.0.0/24","config-method":"static","space":"db","gateway":"10.0.0.1","dns-servers":["10.0.0.2"]},`+
+		`{"address":"10.0.0.11","cidr":"10.0.0.0/24","config-method":"static","space":"db"}]},`+
+		`"eth0":{"type":"ethernet","mac-address":"aa:bb:cc:dd:ee:f0","mtu":1500,"parent":"br-eth0","up":true,"auto-start":true}}}}}`+"\n")
+}
+
+func (s *ShowNetworkSuite) TestMachineError(c *gc.C) {
+	ctx, err := s.run(c, "1", "0/lxd/0", "--format", "yaml")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "cannot show network of machine 1: machine 1 not found\n")
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "0/lxd/0:")
+}
+
+func (s *ShowNetworkSuite) TestNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("showing link-layer devices")
+	_, err := s.run(c, "0")
+	c.Assert(err, gc.ErrorMatches, "showing network devices is not supported by this controller")
+}
+
+type fakeShowNetworkAPI struct {
+	machines []string
+	results  map[string]params.LinkLayerDevicesResult
+	err      error
+}
+
+func (f *fakeShowNetworkAPI) Close() error {
+	return nil
+}
+
+func (f *fakeShowNetworkAPI) LinkLayerDevices(machines ...string) ([]params.LinkLayerDevicesResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.machines = machines
+	results := make([]params.LinkLayerDevicesResult, len(machines))
+	for i, id := range machines {
+		results[i] = f.results[id]
+	}
+	return results, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/series"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	// PrepareCommand is the upgrade-series subcommand that prepares a
+	// machine for an upgrade of its operating system.
+	PrepareCommand = "prepare"
+
+	// CompleteCommand is the upgrade-series subcommand that completes
+	// the upgrade of a machine once its operating system is upgraded.
+	CompleteCommand = "complete"
+
+	// AbortCommand is the upgrade-series subcommand that cancels the
+	// upgrade of a machine whose units are still being prepared.
+	AbortCommand = "abort"
+)
+
+const upgradeSeriesDoc = `
+Upgrade the series of a machine in place, without redeploying it.
+
+Upgrading the series of a machine is done in three steps. First, the
+machine is prepared with the "prepare" subcommand. This locks the
+machine, so that no units or containers can be added to it and it cannot
+be removed, and runs the pre-series-upgrade hook of every unit on the
+machine. Once all the units are prepared, the machine agent stops the
+unit agents and rewrites their service files for the init system of the
+new series.
+
+Next, the operating system of the machine is upgraded by hand, for
+example with do-release-upgrade, and the machine is rebooted.
+
+Finally, the upgrade is completed with the "complete" subcommand. This
+updates the series of the machine and its units in the model, restarts
+the unit agents and runs the post-series-upgrade hook of every unit on
+the machine. The machine is unlocked once all the units have completed
+the upgrade.
+
+An upgrade can be cancelled with the "abort" subcommand while the units
+are still being prepared, which unlocks the machine. Once the machine has
+been prepared, its unit agents are stopped and the upgrade must be
+completed.
+
+Every charm deployed to the machine must support the new series.
+
+Examples:
+
+Prepare machine 3 for an upgrade to xenial:
+
+    juju upgrade-series 3 prepare xenial
+
+Complete the upgrade of machine 3, once its operating system is upgraded:
+
+    juju upgrade-series 3 complete
+
+Cancel the upgrade of machine 3 before its units are prepared:
+
+    juju upgrade-series 3 abort
+
+See also:
+    machines
+    status
+`
+
+// NewUpgradeSeriesCommand returns a command used to upgrade the series
+// of a machine in place.
+func NewUpgradeSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{})
+}
+
+// UpgradeSeriesAPI defines the API methods used by the upgrade-series
+// command.
+type UpgradeSeriesAPI interface {
+	UpgradeSeriesPrepare(machineId, series string) error
+	UpgradeSeriesComplete(machineId string) error
+	UpgradeSeriesAbort(machineId string) error
+	Close() error
+}
+
+// upgradeSeriesCommand upgrades the series of a machine in place.
+type upgradeSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	api UpgradeSeriesAPI
+
+	machineId     string
+	subCommand    string
+	upgradeSeries string
+}
+
+// Info implements Command.Info.
+func (c *upgradeSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upgrade-series",
+		Args:    "<machine> (prepare <series> | complete | abort)",
+		Purpose: "Upgrade the series of a machine in place.",
+		Doc:     upgradeSeriesDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *upgradeSeriesCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.Errorf("wrong number of arguments")
+	}
+	c.machineId, c.subCommand = args[0], args[1]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.Errorf("invalid machine id %q", c.machineId)
+	}
+	switch c.subCommand {
+	case PrepareCommand:
+		if len(args) != 3 {
+			return errors.Errorf("wrong number of arguments")
+		}
+		c.upgradeSeries = args[2]
+		if _, err := series.SeriesVersion(c.upgradeSeries); err != nil {
+			return errors.Trace(err)
+		}
+	case CompleteCommand, AbortCommand:
+		if len(args) != 2 {
+			return errors.Errorf("wrong number of arguments")
+		}
+	default:
+		return errors.Errorf("unknown subcommand %q, expected %q, %q or %q",
+			c.subCommand, PrepareCommand, CompleteCommand, AbortCommand)
+	}
+	return nil
+}
+
+func (c *upgradeSeriesCommand) getAPI() (UpgradeSeriesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *upgradeSeriesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	switch c.subCommand {
+	case PrepareCommand:
+		err = client.UpgradeSeriesPrepare(c.machineId, c.upgradeSeries)
+		if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+			return err
+		}
+		ctx.Infof("machine %s is being prepared for an upgrade to %s", c.machineId, c.upgradeSeries)
+		ctx.Infof("once all units are prepared, upgrade the operating system and run")
+		ctx.Infof("    juju upgrade-series %s %s", c.machineId, CompleteCommand)
+	case CompleteCommand:
+		err = client.UpgradeSeriesComplete(c.machineId)
+		if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+			return err
+		}
+		ctx.Infof("machine %s is completing its series upgrade", c.machineId)
+	case AbortCommand:
+		err = client.UpgradeSeriesAbort(c.machineId)
+		if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+			return err
+		}
+		ctx.Infof("series upgrade of machine %s aborted", c.machineId)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeUpgradeSeriesAPI
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeUpgradeSeriesAPI{}
+}
+
+func (s *UpgradeSeriesSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, machine.NewUpgradeSeriesCommandForTest(s.fake), args...)
+}
+
+func (s *UpgradeSeriesSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "wrong number of arguments",
+	}, {
+		args: []string{"0"},
+		err:  "wrong number of arguments",
+	}, {
+		args: []string{"lxd", "complete"},
+		err:  `invalid machine id "lxd"`,
+	}, {
+		args: []string{"0", "prepare"},
+		err:  "wrong number of arguments",
+	}, {
+		args: []string{"0", "prepare", "nonsense"},
+		err:  `.*"nonsense".*`,
+	}, {
+		args: []string{"0", "complete", "xenial"},
+		err:  "wrong number of arguments",
+	}, {
+		args: []string{"0", "rollback"},
+		err:  `unknown subcommand "rollback", expected "prepare", "complete" or "abort"`,
+	}, {
+		args: []string{"0", "abort", "xenial"},
+		err:  "wrong number of arguments",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(machine.NewUpgradeSeriesCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpgradeSeriesSuite) TestPrepare(c *gc.C) {
+	ctx, err := s.run(c, "1/lxd/0", "prepare", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"prepare 1/lxd/0 xenial"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+machine 1/lxd/0 is being prepared for an upgrade to xenial
+once all units are prepared, upgrade the operating system and run
+    juju upgrade-series 1/lxd/0 complete
+`[1:])
+}
+
+func (s *UpgradeSeriesSuite) TestComplete(c *gc.C) {
+	ctx, err := s.run(c, "1", "complete")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"complete 1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "machine 1 is completing its series upgrade\n")
+}
+
+func (s *UpgradeSeriesSuite) TestAbort(c *gc.C) {
+	ctx, err := s.run(c, "1", "abort")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"abort 1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "series upgrade of machine 1 aborted\n")
+}
+
+func (s *UpgradeSeriesSuite) TestError(c *gc.C) {
+	s.fake.err = errors.New(`cannot lock machine "1" for series upgrade: machine is already locked`)
+	_, err := s.run(c, "1", "prepare", "xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "1" for series upgrade: machine is already locked`)
+}
+
+func (s *UpgradeSeriesSuite) TestBlocked(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlocked")
+	_, err := s.run(c, "1", "complete")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+type fakeUpgradeSeriesAPI struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeUpgradeSeriesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesPrepare(machineId, series string) error {
+	f.calls = append(f.calls, "prepare "+machineId+" "+series)
+	return f.err
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesComplete(machineId string) error {
+	f.calls = append(f.calls, "complete "+machineId)
+	return f.err
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesAbort(machineId string) error {
+	f.calls = append(f.calls, "abort "+machineId)
+	return f.err
+}
@@ -55,10 +55,11 @@ type destroyCommand struct {
 	// sleepFunc is used when calling the timed function to get model status updates.
 	sleepFunc func(time.Duration)
 
-	envName   string
-	assumeYes bool
-	api       DestroyModelAPI
-	configApi ModelConfigAPI
+	envName      string
+	assumeYes    bool
+	keepInstance bool
+	api          DestroyModelAPI
+	configApi    ModelConfigAPI
 }
 
 var destroyDoc = `
@@ -68,10 +69,17 @@ there. Due to the irreversible nature of the command, it will prompt for
 confirmation (unless overridden with the '-y' option) before taking any
 action.
 
+With --keep-instance, the model's machines are removed from Juju but
+their cloud instances are left running, along with any other cloud
+resources created for the model. This is useful when the instances are
+needed for forensic investigation, or are being handed over to other
+tooling. The instances must then be cleaned up by hand.
+
 Examples:
 
     juju destroy-model test
     juju destroy-model -y mymodel
+    juju destroy-model --keep-instance mymodel
 
 See also:
     destroy-controller
@@ -87,6 +95,7 @@ Continue [y/N]? `[1:]
 type DestroyModelAPI interface {
 	Close() error
 	DestroyModel(names.ModelTag) error
+	DestroyModelKeepingInstances(names.ModelTag) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
@@ -112,6 +121,7 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
 	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.BoolVar(&c.keepInstance, "keep-instance", false, "Do not stop the running cloud instances")
 }
 
 // Init implements Command.Init.
@@ -212,7 +222,11 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 
 	// Attempt to destroy the model.
 	ctx.Infof("Destroying model")
-	err = api.DestroyModel(names.NewModelTag(modelDetails.ModelUUID))
+	destroy := api.DestroyModel
+	if c.keepInstance {
+		destroy = api.DestroyModelKeepingInstances
+	}
+	err = destroy(names.NewModelTag(modelDetails.ModelUUID))
 	if err != nil {
 		return c.handleError(errors.Annotate(err, "cannot destroy model"), modelName)
 	}
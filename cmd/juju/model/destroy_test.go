@@ -45,6 +45,7 @@ type fakeAPI struct {
 	env             map[string]interface{}
 	statusCallCount int
 	modelInfoErr    []*params.Error
+	keptInstances   bool
 }
 
 func (f *fakeAPI) Close() error { return nil }
@@ -53,6 +54,11 @@ func (f *fakeAPI) DestroyModel(names.ModelTag) error {
 	return f.err
 }
 
+func (f *fakeAPI) DestroyModelKeepingInstances(names.ModelTag) error {
+	f.keptInstances = true
+	return f.err
+}
+
 func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error) {
 	var err *params.Error = &params.Error{Code: params.CodeNotFound}
 	if f.statusCallCount < len(f.modelInfoErr) {
@@ -171,6 +177,13 @@ func (s *DestroySuite) TestDestroy(c *gc.C) {
 	s.stub.CheckNoCalls(c)
 }
 
+func (s *DestroySuite) TestDestroyKeepInstance(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--keep-instance")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.keptInstances, jc.IsTrue)
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+}
+
 func (s *DestroySuite) TestDestroyBlocks(c *gc.C) {
 	checkModelExistsInStore(c, "test1:admin/test2", s.store)
 	s.api.modelInfoErr = []*params.Error{{}, {Code: params.CodeNotFound}}
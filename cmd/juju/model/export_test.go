@@ -42,6 +42,27 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewAddRoleCommandForTest returns an addRoleCommand with the api provided as specified.
+func NewAddRoleCommandForTest(api RolesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &addRoleCommand{roleCommandBase: roleCommandBase{api: api}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewGrantRoleCommandForTest returns a grantRoleCommand with the api provided as specified.
+func NewGrantRoleCommandForTest(api RolesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &grantRoleCommand{roleGrantCommand{roleCommandBase: roleCommandBase{api: api}}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRevokeRoleCommandForTest returns a revokeRoleCommand with the api provided as specified.
+func NewRevokeRoleCommandForTest(api RolesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &revokeRoleCommand{roleGrantCommand{roleCommandBase: roleCommandBase{api: api}}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api, RefreshModels: refreshFunc}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/utils/featureflag"
 	"gopkg.in/juju/names.v2"
 
@@ -42,6 +43,11 @@ Valid access levels for controllers are:
     add-model
     superuser
 
+Valid access levels for applications within a model are:
+    read
+    write
+    admin
+
 Valid access levels for application offers are:
     read
     consume
@@ -63,6 +69,15 @@ Grant user 'sam' 'read' access to models 'model1' and 'model2':
 Grant user 'maria' 'add-model' access to the controller:
 
     juju grant maria add-model
+
+Grant user 'kim' 'write' access to applications 'mysql' and 'wordpress'
+in model 'mymodel':
+
+    juju grant kim write mymodel --application mysql,wordpress
+
+Grant every member of the group 'ops' 'admin' access to model 'mymodel':
+
+    juju grant --group ops admin mymodel
 %s
 See also: 
     revoke
@@ -107,6 +122,15 @@ Revoke 'write' access from user 'sam' for models 'model1' and 'model2':
 Revoke 'add-model' access from user 'maria' to the controller:
 
     juju revoke maria add-model
+
+Revoke 'write' access from user 'kim' for application 'mysql' in
+model 'mymodel':
+
+    juju revoke kim write mymodel --application mysql
+
+Revoke 'write' access from the group 'ops' for model 'mymodel':
+
+    juju revoke --group ops write mymodel
 %s
 See also: 
     grant`[1:]
@@ -124,10 +148,19 @@ Revoke 'consume' access from user 'sam' for models 'fred/prod.hosted-mysql' and
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
 
-	User       string
-	ModelNames []string
-	OfferURLs  []*crossmodel.ApplicationURL
-	Access     string
+	User         string
+	ModelNames   []string
+	OfferURLs    []*crossmodel.ApplicationURL
+	Applications []string
+	Access       string
+	Group        bool
+}
+
+// SetFlags implements cmd.Command.
+func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.Applications), "application", "Limit access to the given applications in the model")
+	f.BoolVar(&c.Group, "group", false, "Treat the user name as the name of a group of users")
 }
 
 // Init implements cmd.Command.
@@ -141,6 +174,12 @@ func (c *accessCommand) Init(args []string) error {
 	}
 
 	c.User = args[0]
+	if c.Group {
+		if !names.IsValidUserName(c.User) {
+			return errors.NotValidf("group name %q", c.User)
+		}
+		c.User = permission.GroupTag(c.User).Id()
+	}
 	c.Access = args[1]
 	// The remaining args are either model names or offer names.
 	for _, arg := range args[2:] {
@@ -167,6 +206,17 @@ func (c *accessCommand) Init(args []string) error {
 	if len(c.ModelNames) > 0 && len(c.OfferURLs) > 0 {
 		return errors.New("either specify model names or offer URLs but not both")
 	}
+	if len(c.Applications) > 0 {
+		if len(c.ModelNames) != 1 {
+			return errors.New("--application requires exactly one model name")
+		}
+		for _, app := range c.Applications {
+			if !names.IsValidApplication(app) {
+				return errors.NotValidf("application name %q", app)
+			}
+		}
+		return permission.ValidateApplicationAccess(permission.Access(c.Access))
+	}
 
 	// Special case for backwards compatibility.
 	if c.Access == "addmodel" {
@@ -252,6 +302,7 @@ func (c *grantCommand) getOfferAPI() (GrantOfferAPI, error) {
 type GrantModelAPI interface {
 	Close() error
 	GrantModel(user, access string, modelUUIDs ...string) error
+	GrantApplication(user, access, modelUUID string, applications ...string) error
 }
 
 // GrantControllerAPI defines the API functions used by the grant command.
@@ -301,6 +352,10 @@ func (c *grantCommand) runForModel() error {
 	if err != nil {
 		return err
 	}
+	if len(c.Applications) > 0 {
+		err := client.GrantApplication(c.User, c.Access, models[0], c.Applications...)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.GrantModel(c.User, c.Access, models...), block.BlockChange)
 }
 
@@ -378,6 +433,7 @@ func (c *revokeCommand) getOfferAPI() (RevokeOfferAPI, error) {
 type RevokeModelAPI interface {
 	Close() error
 	RevokeModel(user, access string, modelUUIDs ...string) error
+	RevokeApplication(user, access, modelUUID string, applications ...string) error
 }
 
 // RevokeControllerAPI defines the API functions used by the revoke command.
@@ -427,6 +483,10 @@ func (c *revokeCommand) runForModel() error {
 	if err != nil {
 		return err
 	}
+	if len(c.Applications) > 0 {
+		err := client.RevokeApplication(c.User, c.Access, models[0], c.Applications...)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.RevokeModel(c.User, c.Access, models...), block.BlockChange)
 }
 
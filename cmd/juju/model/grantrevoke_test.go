@@ -106,6 +106,42 @@ func (s *grantRevokeSuite) TestModelAccess(c *gc.C) {
 	c.Assert(s.fakeModelAPI.access, gc.Equals, "write")
 }
 
+func (s *grantRevokeSuite) TestApplicationAccess(c *gc.C) {
+	_, err := s.run(c, "sam", "write", "foo", "--application", "mysql,wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeModelAPI.user, gc.Equals, "sam")
+	c.Assert(s.fakeModelAPI.access, gc.Equals, "write")
+	c.Assert(s.fakeModelAPI.modelUUIDs, jc.DeepEquals, []string{fooModelUUID})
+	c.Assert(s.fakeModelAPI.applications, jc.DeepEquals, []string{"mysql", "wordpress"})
+}
+
+func (s *grantRevokeSuite) TestApplicationAccessRequiresOneModel(c *gc.C) {
+	_, err := s.run(c, "sam", "write", "foo", "bar", "--application", "mysql")
+	c.Assert(err, gc.ErrorMatches, "--application requires exactly one model name")
+	_, err = s.run(c, "sam", "write", "--application", "mysql")
+	c.Assert(err, gc.ErrorMatches, "--application requires exactly one model name")
+}
+
+func (s *grantRevokeSuite) TestApplicationAccessInvalid(c *gc.C) {
+	_, err := s.run(c, "sam", "consume", "foo", "--application", "mysql")
+	c.Assert(err, gc.ErrorMatches, `"consume" application access not valid`)
+	_, err = s.run(c, "sam", "write", "foo", "--application", "Bad_App")
+	c.Assert(err, gc.ErrorMatches, `application name "Bad_App" not valid`)
+}
+
+func (s *grantRevokeSuite) TestGroupAccess(c *gc.C) {
+	_, err := s.run(c, "--group", "ops", "write", "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeModelAPI.user, gc.Equals, "ops@group")
+	c.Assert(s.fakeModelAPI.access, gc.Equals, "write")
+	c.Assert(s.fakeModelAPI.modelUUIDs, jc.DeepEquals, []string{fooModelUUID})
+}
+
+func (s *grantRevokeSuite) TestGroupAccessInvalid(c *gc.C) {
+	_, err := s.run(c, "--group", "ops@external", "write", "foo")
+	c.Assert(err, gc.ErrorMatches, `group name "ops@external" not valid`)
+}
+
 func (s *grantRevokeSuite) TestModelBlockGrant(c *gc.C) {
 	s.fakeModelAPI.err = common.OperationBlockedError("TestBlockGrant")
 	_, err := s.run(c, "sam", "read", "foo")
@@ -237,10 +273,11 @@ func (s *grantSuite) TestControllerAccessForOffer(c *gc.C) {
 }
 
 type fakeModelGrantRevokeAPI struct {
-	err        error
-	user       string
-	access     string
-	modelUUIDs []string
+	err          error
+	user         string
+	access       string
+	modelUUIDs   []string
+	applications []string
 }
 
 func (f *fakeModelGrantRevokeAPI) Close() error { return nil }
@@ -253,6 +290,16 @@ func (f *fakeModelGrantRevokeAPI) RevokeModel(user, access string, modelUUIDs ..
 	return f.fake(user, access, modelUUIDs...)
 }
 
+func (f *fakeModelGrantRevokeAPI) GrantApplication(user, access, modelUUID string, applications ...string) error {
+	f.applications = applications
+	return f.fake(user, access, modelUUID)
+}
+
+func (f *fakeModelGrantRevokeAPI) RevokeApplication(user, access, modelUUID string, applications ...string) error {
+	f.applications = applications
+	return f.fake(user, access, modelUUID)
+}
+
 func (f *fakeModelGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) error {
 	f.user = user
 	f.access = access
@@ -0,0 +1,230 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/roles"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/permission"
+)
+
+var usageAddRoleSummary = `
+Defines a role composed of model capabilities.`[1:]
+
+var usageAddRoleDetails = `
+A role is a named set of capabilities which may be granted to users
+of a model with ` + "`juju grant-role`" + `, in addition to the access
+level they were given with ` + "`juju grant`" + `. Roles are defined
+once per controller, and only controller superusers may define them.
+
+Valid capabilities are:
+    %s
+
+Users with write access to a model have every capability except ssh,
+which requires admin access.
+
+Examples:
+Define a role allowing actions to be run and machines to be accessed
+with ssh, but nothing else to be changed:
+
+    juju add-role operator actions ssh
+
+See also:
+    grant-role
+    revoke-role`[1:]
+
+var usageGrantRoleSummary = `
+Grants a role to a Juju user on a model.`[1:]
+
+var usageGrantRoleDetails = `
+The user must already have access to the model. The capabilities of
+the role are added to those implied by the user's access level.
+
+Examples:
+Grant the 'operator' role to user 'joe' on model 'mymodel':
+
+    juju grant-role -m mymodel joe operator
+
+See also:
+    add-role
+    revoke-role
+    grant`[1:]
+
+var usageRevokeRoleSummary = `
+Revokes a role from a Juju user on a model.`[1:]
+
+var usageRevokeRoleDetails = `
+Revoking a role does not change the access level the user was given
+with ` + "`juju grant`" + `.
+
+Examples:
+Revoke the 'operator' role from user 'joe' on model 'mymodel':
+
+    juju revoke-role -m mymodel joe operator
+
+See also:
+    grant-role`[1:]
+
+// RolesAPI defines the API methods used by the role commands.
+type RolesAPI interface {
+	Close() error
+	AddRole(permission.Role) error
+	GrantRole(names.UserTag, string) error
+	RevokeRole(names.UserTag, string) error
+}
+
+type roleCommandBase struct {
+	modelcmd.ModelCommandBase
+	api RolesAPI
+}
+
+func (c *roleCommandBase) getAPI() (RolesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return roles.NewClient(root), nil
+}
+
+// NewAddRoleCommand returns a command to define a new role.
+func NewAddRoleCommand() cmd.Command {
+	return modelcmd.Wrap(&addRoleCommand{})
+}
+
+type addRoleCommand struct {
+	roleCommandBase
+	role permission.Role
+}
+
+// Info implements cmd.Command.
+func (c *addRoleCommand) Info() *cmd.Info {
+	capabilities := make([]string, len(permission.AllCapabilities))
+	for i, capability := range permission.AllCapabilities {
+		capabilities[i] = string(capability)
+	}
+	return &cmd.Info{
+		Name:    "add-role",
+		Args:    "<role name> <capability> [<capability> ...]",
+		Purpose: usageAddRoleSummary,
+		Doc:     fmt.Sprintf(usageAddRoleDetails, strings.Join(capabilities, "\n    ")),
+	}
+}
+
+// Init implements cmd.Command.
+func (c *addRoleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no role name specified")
+	}
+	if len(args) < 2 {
+		return errors.New("no capabilities specified")
+	}
+	c.role.Name = args[0]
+	for _, arg := range args[1:] {
+		c.role.Capabilities = append(c.role.Capabilities, permission.Capability(arg))
+	}
+	return c.role.Validate()
+}
+
+// Run implements cmd.Command.
+func (c *addRoleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return errors.Trace(client.AddRole(c.role))
+}
+
+type roleGrantCommand struct {
+	roleCommandBase
+	user names.UserTag
+	role string
+}
+
+// Init implements cmd.Command.
+func (c *roleGrantCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no user specified")
+	}
+	if len(args) < 2 {
+		return errors.New("no role specified")
+	}
+	if !names.IsValidUser(args[0]) {
+		return errors.NotValidf("user name %q", args[0])
+	}
+	if !permission.IsValidRoleName(args[1]) {
+		return errors.NotValidf("role name %q", args[1])
+	}
+	c.user = names.NewUserTag(args[0])
+	c.role = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// NewGrantRoleCommand returns a command to grant a role to a user.
+func NewGrantRoleCommand() cmd.Command {
+	return modelcmd.Wrap(&grantRoleCommand{})
+}
+
+type grantRoleCommand struct {
+	roleGrantCommand
+}
+
+// Info implements cmd.Command.
+func (c *grantRoleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "grant-role",
+		Args:    "<user name> <role name>",
+		Purpose: usageGrantRoleSummary,
+		Doc:     usageGrantRoleDetails,
+	}
+}
+
+// Run implements cmd.Command.
+func (c *grantRoleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return errors.Trace(client.GrantRole(c.user, c.role))
+}
+
+// NewRevokeRoleCommand returns a command to revoke a role from a user.
+func NewRevokeRoleCommand() cmd.Command {
+	return modelcmd.Wrap(&revokeRoleCommand{})
+}
+
+type revokeRoleCommand struct {
+	roleGrantCommand
+}
+
+// Info implements cmd.Command.
+func (c *revokeRoleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "revoke-role",
+		Args:    "<user name> <role name>",
+		Purpose: usageRevokeRoleSummary,
+		Doc:     usageRevokeRoleDetails,
+	}
+}
+
+// Run implements cmd.Command.
+func (c *revokeRoleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return errors.Trace(client.RevokeRole(c.user, c.role))
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing"
+)
+
+type RolesCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeRolesClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&RolesCommandSuite{})
+
+type fakeRolesClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeRolesClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeRolesClient) AddRole(role permission.Role) error {
+	f.MethodCall(f, "AddRole", role)
+	return f.NextErr()
+}
+
+func (f *fakeRolesClient) GrantRole(user names.UserTag, role string) error {
+	f.MethodCall(f, "GrantRole", user, role)
+	return f.NextErr()
+}
+
+func (f *fakeRolesClient) RevokeRole(user names.UserTag, role string) error {
+	f.MethodCall(f, "RevokeRole", user, role)
+	return f.NextErr()
+}
+
+func (s *RolesCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *RolesCommandSuite) run(c *gc.C, command cmd.Command, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, command, args...)
+	return err
+}
+
+func (s *RolesCommandSuite) TestAddRole(c *gc.C) {
+	err := s.run(c, model.NewAddRoleCommandForTest(&s.fake, s.store), "operator", "actions", "ssh")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AddRole", []interface{}{permission.Role{
+			Name:         "operator",
+			Capabilities: []permission.Capability{permission.ActionsCapability, permission.SSHCapability},
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *RolesCommandSuite) TestAddRoleInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no role name specified",
+	}, {
+		args: []string{"operator"},
+		err:  "no capabilities specified",
+	}, {
+		args: []string{"Operator", "ssh"},
+		err:  `role name "Operator" not valid`,
+	}, {
+		args: []string{"operator", "fly"},
+		err:  `role "operator": capability "fly" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.run(c, model.NewAddRoleCommandForTest(&s.fake, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *RolesCommandSuite) TestAddRoleError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	err := s.run(c, model.NewAddRoleCommandForTest(&s.fake, s.store), "operator", "ssh")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *RolesCommandSuite) TestGrantRole(c *gc.C) {
+	err := s.run(c, model.NewGrantRoleCommandForTest(&s.fake, s.store), "bob", "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GrantRole", []interface{}{names.NewUserTag("bob"), "operator"}},
+		{"Close", nil},
+	})
+}
+
+func (s *RolesCommandSuite) TestRevokeRole(c *gc.C) {
+	err := s.run(c, model.NewRevokeRoleCommandForTest(&s.fake, s.store), "bob", "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RevokeRole", []interface{}{names.NewUserTag("bob"), "operator"}},
+		{"Close", nil},
+	})
+}
+
+func (s *RolesCommandSuite) TestGrantRoleInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no user specified",
+	}, {
+		args: []string{"bob"},
+		err:  "no role specified",
+	}, {
+		args: []string{"bob!", "operator"},
+		err:  `user name "bob!" not valid`,
+	}, {
+		args: []string{"bob", "operator", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.run(c, model.NewGrantRoleCommandForTest(&s.fake, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
@@ -121,6 +121,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Containers:        make(map[string]machineStatus),
 		Constraints:       machine.Constraints,
 		Hardware:          machine.Hardware,
+		Maintenance:       machine.Maintenance,
 	}
 
 	for k, d := range machine.NetworkInterfaces {
@@ -187,6 +188,18 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		StatusInfo:    sf.getApplicationStatusInfo(application),
 		Version:       application.WorkloadVersion,
 	}
+	if len(application.ExposedEndpoints) > 0 {
+		out.ExposedEndpoints = make(map[string]exposedEndpoint)
+		for name, ep := range application.ExposedEndpoints {
+			if name == "" {
+				name = "*"
+			}
+			out.ExposedEndpoints[name] = exposedEndpoint{
+				ExposeToSpaces: ep.ExposeToSpaces,
+				ExposeToCIDRs:  ep.ExposeToCIDRs,
+			}
+		}
+	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
@@ -259,6 +272,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		WorkloadStatusInfo: sf.getWorkloadStatusInfo(info.unit),
 		JujuStatusInfo:     sf.getAgentStatusInfo(info.unit),
 		Machine:            info.unit.Machine,
+		Series:             info.unit.Series,
 		OpenedPorts:        info.unit.OpenedPorts,
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
@@ -159,10 +159,12 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		if len(version) > maxVersionWidth {
 			version = version[:truncatedWidth] + ellipsis
 		}
-		// Notes may well contain other things later.
-		notes := ""
+		var notes []string
 		if app.Exposed {
-			notes = "exposed"
+			notes = append(notes, "exposed")
+		}
+		if app.mixedSeries() {
+			notes = append(notes, "mixed series")
 		}
 		w.Print(appName, version)
 		w.PrintStatus(app.StatusInfo.Current)
@@ -176,7 +178,7 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 			app.CharmOrigin,
 			app.CharmRev,
 			app.OS,
-			notes)
+			strings.Join(notes, ", "))
 
 		for un, u := range app.Units {
 			units[un] = u
@@ -308,9 +310,13 @@ func printMachine(w output.Wrapper, m machineStatus) {
 	if hw.AvailabilityZone != nil {
 		az = *hw.AvailabilityZone
 	}
+	message := m.MachineStatus.Message
+	if m.Maintenance {
+		message = strings.TrimSuffix("in maintenance; "+message, "; ")
+	}
 	w.Print(m.Id)
 	w.PrintStatus(m.JujuStatus.Current)
-	w.Println(m.DNSName, m.InstanceId, m.Series, az, m.MachineStatus.Message)
+	w.Println(m.DNSName, m.InstanceId, m.Series, az, message)
 	for _, name := range utils.SortStringsNaturally(stringKeysFromMap(m.Containers)) {
 		printMachine(w, m.Containers[name])
 	}
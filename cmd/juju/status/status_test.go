@@ -4759,6 +4759,105 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatExposedEndpoints(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:   "cs:quantal/mysql-1",
+				Series:  "quantal",
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"":   {ExposeToSpaces: []string{"admin"}},
+					"db": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+				},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := formatted.Applications["mysql"]
+	c.Check(app.Exposed, jc.IsTrue)
+	c.Check(app.ExposedEndpoints, jc.DeepEquals, map[string]exposedEndpoint{
+		"*":  {ExposeToSpaces: []string{"admin"}},
+		"db": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+}
+
+func (s *StatusSuite) TestFormatMixedSeries(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:  "cs:mysql-1",
+				Series: "xenial",
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {Machine: "0", Series: "trusty"},
+					"mysql/1": {Machine: "1"},
+				},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := formatted.Applications["mysql"]
+	c.Check(app.Units["mysql/0"].Series, gc.Equals, "trusty")
+	c.Check(app.Units["mysql/1"].Series, gc.Equals, "")
+
+	out := &bytes.Buffer{}
+	err = FormatTabular(out, false, formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	sections, err := splitTableSections(out.Bytes())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sections["App"], gc.HasLen, 2)
+	c.Check(sections["App"][1], jc.Contains, "mixed series")
+}
+
+func (s *StatusSuite) TestFormatMachineMaintenance(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:             "0",
+				Maintenance:    true,
+				InstanceStatus: params.DetailedStatus{Info: "running"},
+			},
+			"1": {
+				Id:          "1",
+				Maintenance: true,
+			},
+			"2": {
+				Id: "2",
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(formatted.Machines["0"].Maintenance, jc.IsTrue)
+	c.Check(formatted.Machines["2"].Maintenance, jc.IsFalse)
+
+	out := &bytes.Buffer{}
+	err = FormatTabular(out, false, formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	sections, err := splitTableSections(out.Bytes())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sections["Machine"], gc.HasLen, 4)
+	c.Check(sections["Machine"][1], jc.HasSuffix, "in maintenance; running")
+	c.Check(sections["Machine"][2], jc.HasSuffix, "in maintenance")
+	c.Check(sections["Machine"][3], gc.Not(jc.Contains), "maintenance")
+}
+
 type tableSections map[string][]string
 
 func sectionTitle(lines []string) string {
@@ -124,3 +124,27 @@ func NewWhoAmICommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &whoAmICommand{store: store}
 	return c
 }
+
+// NewAddGroupCommandForTest returns an add-group command with the api
+// provided as specified.
+func NewAddGroupCommandForTest(api GroupsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addGroupCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddToGroupCommandForTest returns an add-to-group command with the
+// api provided as specified.
+func NewAddToGroupCommandForTest(api GroupsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addToGroupCommand{groupMembersCommand{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveFromGroupCommandForTest returns a remove-from-group command
+// with the api provided as specified.
+func NewRemoveFromGroupCommandForTest(api GroupsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeFromGroupCommand{groupMembersCommand{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
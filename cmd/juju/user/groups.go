@@ -0,0 +1,222 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageAddGroupSummary = `
+Adds a group of Juju users to a controller.`[1:]
+
+var usageAddGroupDetails = `
+A group of users may be granted access to models, controllers and
+offers with ` + "`juju grant --group`" + `, in the same way as a single
+user. Every member of the group holds the access granted to it, in
+addition to any access granted to them directly.
+
+Members may be local users of the controller or external users.
+Only controller superusers may manage groups.
+
+Examples:
+    juju add-group ops
+
+See also:
+    add-to-group
+    remove-from-group
+    grant`[1:]
+
+var usageAddToGroupSummary = `
+Adds Juju users to a group.`[1:]
+
+var usageAddToGroupDetails = `
+Users that are already members of the group are ignored.
+
+Examples:
+    juju add-to-group ops bob mary@external
+
+See also:
+    add-group
+    remove-from-group`[1:]
+
+var usageRemoveFromGroupSummary = `
+Removes Juju users from a group.`[1:]
+
+var usageRemoveFromGroupDetails = `
+Users that are not members of the group are ignored. Access granted
+to the users directly is not affected.
+
+Examples:
+    juju remove-from-group ops bob
+
+See also:
+    add-group
+    add-to-group`[1:]
+
+// GroupsAPI defines the API methods that the group commands use.
+type GroupsAPI interface {
+	AddGroup(name string) error
+	AddGroupMembers(group string, usernames ...string) error
+	RemoveGroupMembers(group string, usernames ...string) error
+	Close() error
+}
+
+type groupCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api GroupsAPI
+}
+
+func (c *groupCommandBase) getAPI() (GroupsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// NewAddGroupCommand returns a command to add a group of users.
+func NewAddGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addGroupCommand{})
+}
+
+// addGroupCommand adds groups of users.
+type addGroupCommand struct {
+	groupCommandBase
+	Group string
+}
+
+// Info implements Command.Info.
+func (c *addGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-group",
+		Args:    "<group name>",
+		Purpose: usageAddGroupSummary,
+		Doc:     usageAddGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *addGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	if !names.IsValidUserName(args[0]) {
+		return errors.NotValidf("group name %q", args[0])
+	}
+	c.Group = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *addGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddGroup(c.Group); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Group %q added", c.Group)
+	return nil
+}
+
+// groupMembersCommand is the common code for the commands
+// that change the members of a group.
+type groupMembersCommand struct {
+	groupCommandBase
+	Group string
+	Users []string
+}
+
+// Init implements Command.Init.
+func (c *groupMembersCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	if len(args) == 1 {
+		return errors.New("no users supplied")
+	}
+	c.Group = args[0]
+	for _, arg := range args[1:] {
+		if !names.IsValidUser(arg) {
+			return errors.NotValidf("user name %q", arg)
+		}
+	}
+	c.Users = args[1:]
+	return nil
+}
+
+// NewAddToGroupCommand returns a command to add users to a group.
+func NewAddToGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addToGroupCommand{})
+}
+
+// addToGroupCommand adds users to a group.
+type addToGroupCommand struct {
+	groupMembersCommand
+}
+
+// Info implements Command.Info.
+func (c *addToGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-to-group",
+		Args:    "<group name> <user name> [<user name> ...]",
+		Purpose: usageAddToGroupSummary,
+		Doc:     usageAddToGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *addToGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
+
+// NewRemoveFromGroupCommand returns a command to remove users from a group.
+func NewRemoveFromGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeFromGroupCommand{})
+}
+
+// removeFromGroupCommand removes users from a group.
+type removeFromGroupCommand struct {
+	groupMembersCommand
+}
+
+// Info implements Command.Info.
+func (c *removeFromGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-from-group",
+		Args:    "<group name> <user name> [<user name> ...]",
+		Purpose: usageRemoveFromGroupSummary,
+		Doc:     usageRemoveFromGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeFromGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemoveGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
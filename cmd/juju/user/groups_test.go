@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/user"
+)
+
+type GroupsCommandSuite struct {
+	BaseSuite
+	mock mockGroupsAPI
+}
+
+var _ = gc.Suite(&GroupsCommandSuite{})
+
+func (s *GroupsCommandSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = mockGroupsAPI{}
+}
+
+func (s *GroupsCommandSuite) TestAddGroup(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, user.NewAddGroupCommandForTest(&s.mock, s.store), "ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Group \"ops\" added\n")
+	s.mock.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AddGroup", []interface{}{"ops"}},
+		{"Close", nil},
+	})
+}
+
+func (s *GroupsCommandSuite) TestAddGroupInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no group name supplied",
+	}, {
+		args: []string{"not/valid"},
+		err:  `group name "not/valid" not valid`,
+	}, {
+		args: []string{"ops", "dev"},
+		err:  `unrecognized args: \["dev"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, user.NewAddGroupCommandForTest(&s.mock, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mock.CheckNoCalls(c)
+}
+
+func (s *GroupsCommandSuite) TestAddGroupError(c *gc.C) {
+	s.mock.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, user.NewAddGroupCommandForTest(&s.mock, s.store), "ops")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *GroupsCommandSuite) TestAddToGroup(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, user.NewAddToGroupCommandForTest(&s.mock, s.store), "ops", "bob", "mary@external")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mock.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AddGroupMembers", []interface{}{"ops", []string{"bob", "mary@external"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *GroupsCommandSuite) TestRemoveFromGroup(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, user.NewRemoveFromGroupCommandForTest(&s.mock, s.store), "ops", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mock.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RemoveGroupMembers", []interface{}{"ops", []string{"bob"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *GroupsCommandSuite) TestGroupMembersInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no group name supplied",
+	}, {
+		args: []string{"ops"},
+		err:  "no users supplied",
+	}, {
+		args: []string{"ops", "bob", "not!good"},
+		err:  `user name "not!good" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, user.NewAddToGroupCommandForTest(&s.mock, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+		_, err = cmdtesting.RunCommand(c, user.NewRemoveFromGroupCommandForTest(&s.mock, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mock.CheckNoCalls(c)
+}
+
+type mockGroupsAPI struct {
+	gitjujutesting.Stub
+}
+
+func (m *mockGroupsAPI) AddGroup(name string) error {
+	m.MethodCall(m, "AddGroup", name)
+	return m.NextErr()
+}
+
+func (m *mockGroupsAPI) AddGroupMembers(group string, usernames ...string) error {
+	m.MethodCall(m, "AddGroupMembers", group, usernames)
+	return m.NextErr()
+}
+
+func (m *mockGroupsAPI) RemoveGroupMembers(group string, usernames ...string) error {
+	m.MethodCall(m, "RemoveGroupMembers", group, usernames)
+	return m.NextErr()
+}
+
+func (m *mockGroupsAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -77,6 +77,16 @@ type UserInfo struct {
 	DateCreated    string `yaml:"date-created,omitempty" json:"date-created,omitempty"`
 	LastConnection string `yaml:"last-connection,omitempty" json:"last-connection,omitempty"`
 	Disabled       bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	ApplicationAccess []ApplicationAccess `yaml:"application-access,omitempty" json:"application-access,omitempty"`
+}
+
+// ApplicationAccess holds the access a user has been granted on an
+// application in a model.
+type ApplicationAccess struct {
+	Model       string `yaml:"model" json:"model"`
+	Application string `yaml:"application" json:"application"`
+	Access      string `yaml:"access" json:"access"`
 }
 
 // Info implements Command.Info.
@@ -147,6 +157,17 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 			Access:      info.Access,
 			Disabled:    info.Disabled,
 		}
+		for _, appAccess := range info.ApplicationAccess {
+			appTag, err := names.ParseApplicationTag(appAccess.ApplicationTag)
+			if err != nil {
+				continue
+			}
+			outInfo.ApplicationAccess = append(outInfo.ApplicationAccess, ApplicationAccess{
+				Model:       appAccess.ModelName,
+				Application: appTag.Id(),
+				Access:      appAccess.Access,
+			})
+		}
 		// TODO(wallyworld) record login information about external users.
 		if names.NewUserTag(info.Username).IsLocal() {
 			outInfo.LastConnection = common.LastConnection(info.LastConnection, now, c.exactTime)
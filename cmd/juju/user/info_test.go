@@ -58,6 +58,15 @@ func (*fakeUserInfoAPI) UserInfo(usernames []string, all usermanager.IncludeDisa
 		info.Username = "foobar"
 		info.DisplayName = "Foo Bar"
 		info.Access = "login"
+	case "teamuser":
+		info.Username = "teamuser"
+		info.Access = "login"
+		info.ApplicationAccess = []params.UserApplicationAccess{{
+			ModelTag:       "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			ModelName:      "prod",
+			ApplicationTag: "application-mysql",
+			Access:         "write",
+		}}
 	case "fred@external":
 		info.Username = "fred@external"
 		info.DisplayName = "Fred External"
@@ -99,6 +108,20 @@ last-connection: 2014-01-01
 `)
 }
 
+func (s *UserInfoCommandSuite) TestUserInfoApplicationAccess(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, s.NewShowUserCommand(), "teamuser")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `user-name: teamuser
+access: login
+date-created: 1981-02-27
+last-connection: 2014-01-01
+application-access:
+- model: prod
+  application: mysql
+  access: write
+`)
+}
+
 func (s *UserInfoCommandSuite) TestUserInfoExternalUser(c *gc.C) {
 	context, err := cmdtesting.RunCommand(c, s.NewShowUserCommand(), "fred@external")
 	c.Assert(err, jc.ErrorIsNil)
@@ -48,6 +48,13 @@ After login, a token ("macaroon") will become active. It has an expiration
 time of 24 hours. Upon expiration, no further Juju commands can be issued
 and the user will be prompted to log in again.
 
+If the controller is configured to trust an OpenID Connect provider
+(see the oidc-issuer-url controller configuration), external users
+are asked to visit the provider's verification page and enter the
+code shown to complete the login. The resulting ID token is stored
+locally and presented on subsequent connections until it expires,
+at which point the user is asked to log in with the provider again.
+
 Aliases
 -------
 
@@ -104,6 +111,10 @@ type loginCommand struct {
 	// onRunError is executed if non-nil if there is an error at the end
 	// of the Run method.
 	onRunError func()
+
+	// idToken holds any OpenID Connect ID token
+	// obtained while logging in.
+	idToken string
 }
 
 // Info implements Command.Info.
@@ -265,6 +276,7 @@ func (c *loginCommand) existingControllerLogin(ctx *cmd.Context, store jujuclien
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		args.DialOpts.OIDCLogin = c.recordIDToken(args.DialOpts.OIDCLogin)
 		return newAPIConnection(args)
 	}
 	return c.login(ctx, currentAccountDetails, dial)
@@ -308,6 +320,7 @@ func (c *loginCommand) publicControllerLogin(
 	}
 	dialOpts := api.DefaultDialOpts()
 	dialOpts.BakeryClient = bclient
+	dialOpts.OIDCLogin = c.recordIDToken(c.CommandBase.OIDCLogin)
 
 	dial := func(d *jujuclient.AccountDetails) (api.Connection, error) {
 		var tag names.Tag
@@ -317,6 +330,7 @@ func (c *loginCommand) publicControllerLogin(
 		return apiOpen(&c.CommandBase, &api.Info{
 			Tag:      tag,
 			Password: d.Password,
+			IDToken:  d.IDToken,
 			Addrs:    []string{host},
 		}, dialOpts)
 	}
@@ -373,16 +387,25 @@ Run "juju logout" first before attempting to log in as a different user.`,
 		}
 	}
 	if c.username == "" {
-		// No username specified, so try external-user login first.
-		conn, err := dial(&jujuclient.AccountDetails{})
+		// No username specified, so try external-user login first,
+		// presenting any ID token we obtained previously.
+		var idToken string
+		if accountDetails != nil {
+			idToken = accountDetails.IDToken
+		}
+		conn, err := dial(&jujuclient.AccountDetails{IDToken: idToken})
 		if err == nil {
 			user, ok := conn.AuthTag().(names.UserTag)
 			if !ok {
 				conn.Close()
 				return nil, nil, errors.Errorf("logged in as %v, not a user", conn.AuthTag())
 			}
+			if c.idToken != "" {
+				idToken = c.idToken
+			}
 			return conn, &jujuclient.AccountDetails{
-				User: user.Id(),
+				User:    user.Id(),
+				IDToken: idToken,
 			}, nil
 		}
 		if !params.IsCodeNoCreds(err) {
@@ -412,6 +435,23 @@ Run "juju logout" first before attempting to log in as a different user.`,
 	return conn, accountDetails, errors.Trace(err)
 }
 
+// recordIDToken returns a function that obtains an ID token
+// using the given function, recording it in c.idToken so that
+// it can be saved with the account details.
+func (c *loginCommand) recordIDToken(login func(issuer, clientID string) (string, error)) func(issuer, clientID string) (string, error) {
+	if login == nil {
+		return nil
+	}
+	return func(issuer, clientID string) (string, error) {
+		token, err := login(issuer, clientID)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		c.idToken = token
+		return token, nil
+	}
+}
+
 const noModelsMessage = `
 There are no models available. You can add models with
 "juju add-model", or you can ask an administrator or owner
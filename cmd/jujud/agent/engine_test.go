@@ -48,6 +48,7 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"application-scaler",
+		"resource-tagger",
 		"space-importer",
 		"state-cleaner",
 		"status-history-pruner",
@@ -111,6 +112,7 @@ var (
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
 		"disk-manager",
+		// "host-firewaller", uninstalls unless firewall-mode is "host"
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
@@ -118,6 +120,7 @@ var (
 		"machiner",
 		"proxy-config-updater",
 		"reboot-executor",
+		"series-upgrader",
 		"ssh-authkeys-updater",
 		"storage-provisioner",
 		"unconverted-api-workers",
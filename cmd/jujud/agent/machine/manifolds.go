@@ -37,6 +37,7 @@ import (
 	"github.com/juju/juju/worker/diskmanager"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
+	"github.com/juju/juju/worker/hostfirewaller"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/logforwarder"
@@ -50,6 +51,7 @@ import (
 	"github.com/juju/juju/worker/proxyupdater"
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/resumer"
+	"github.com/juju/juju/worker/seriesupgrader"
 	workerstate "github.com/juju/juju/worker/state"
 	"github.com/juju/juju/worker/stateconfigwatcher"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -476,6 +478,22 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		seriesUpgraderName: ifNotMigrating(seriesupgrader.Manifold(seriesupgrader.ManifoldConfig{
+			AgentName:         agentName,
+			APICallerName:     apiCallerName,
+			NewFacade:         seriesupgrader.NewFacade,
+			NewServiceManager: seriesupgrader.NewServiceManager,
+			NewWorker:         seriesupgrader.NewWorker,
+		})),
+
+		hostFirewallerName: ifNotMigrating(hostfirewaller.Manifold(hostfirewaller.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     hostfirewaller.NewFacade,
+			NewBackend:    hostfirewaller.NewBackend,
+			NewWorker:     hostfirewaller.NewWorker,
+		})),
 		logForwarderName: ifFullyUpgraded(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			StateName:     stateName,
 			APICallerName: apiCallerName,
@@ -539,5 +557,7 @@ const (
 	toolsVersionCheckerName  = "tools-version-checker"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	hostFirewallerName       = "host-firewaller"
+	seriesUpgraderName       = "series-upgrader"
 	logForwarderName         = "log-forwarder"
 )
@@ -48,6 +48,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-config-watcher",
 		"central-hub",
 		"disk-manager",
+		"host-firewaller",
 		"host-key-reporter",
 		"log-forwarder",
 		"log-sender",
@@ -60,6 +61,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"migration-inactive-flag",
 		"proxy-config-updater",
 		"reboot-executor",
+		"series-upgrader",
 		"serving-info-setter",
 		"ssh-authkeys-updater",
 		"ssh-identity-writer",
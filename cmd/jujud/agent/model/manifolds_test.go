@@ -50,6 +50,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"migration-master",
 		"not-alive-flag",
 		"not-dead-flag",
+		"resource-tagger",
 		"space-importer",
 		"spaces-imported-gate",
 		"state-cleaner",
@@ -160,6 +161,7 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"resource-tagger",
 		"space-importer",
 		"spaces-imported-gate",
 		"state-cleaner",
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
@@ -27,6 +28,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/oidc"
 )
 
 var errNoNameSpecified = errors.New("no name specified")
@@ -202,13 +204,35 @@ func (c *CommandBase) NewAPIConnectionParams(
 		}
 	}
 
-	return newAPIConnectionParams(
+	connParams, err := newAPIConnectionParams(
 		store, controllerName, modelName,
 		accountDetails,
 		bakeryClient,
 		c.apiOpen,
 		getPassword,
 	)
+	if err != nil {
+		return juju.NewAPIConnectionParams{}, errors.Trace(err)
+	}
+	connParams.DialOpts.OIDCLogin = c.OIDCLogin
+	return connParams, nil
+}
+
+// OIDCLogin obtains an OpenID Connect ID token from the given issuer
+// using the device authorization flow, asking the user to visit the
+// provider's verification page to approve the login.
+func (c *CommandBase) OIDCLogin(issuer, clientID string) (string, error) {
+	if c.cmdContext == nil {
+		return "", errors.New("no context to prompt for OpenID Connect login")
+	}
+	return oidc.DeviceLogin(http.DefaultClient, clock.WallClock, issuer, clientID, func(auth *oidc.DeviceAuthorization) error {
+		if auth.VerificationURIComplete != "" {
+			fmt.Fprintf(c.cmdContext.Stderr, "Please visit %s to log in.\n", auth.VerificationURIComplete)
+		} else {
+			fmt.Fprintf(c.cmdContext.Stderr, "Please visit %s and enter code %s to log in.\n", auth.VerificationURI, auth.UserCode)
+		}
+		return nil
+	})
 }
 
 // HTTPClient returns an http.Client that contains the loaded
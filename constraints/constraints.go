@@ -32,6 +32,18 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+
+	InstanceLifecycle = "instance-lifecycle"
+)
+
+// The following constants list the supported values of the
+// instance-lifecycle constraint.
+const (
+	// LifecycleSpot requests an EC2 spot instance.
+	LifecycleSpot = "spot"
+
+	// LifecyclePreemptible requests a GCE preemptible instance.
+	LifecyclePreemptible = "preemptible"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +97,12 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// InstanceLifecycle, if not nil or empty, indicates that a machine
+	// must run on a cheaper instance that the cloud may terminate at any
+	// time, such as an EC2 spot or GCE preemptible instance. Such
+	// instances are replaced by the controller when they are terminated.
+	InstanceLifecycle *string `json:"instance-lifecycle,omitempty" yaml:"instance-lifecycle,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +198,12 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasInstanceLifecycle returns true if the constraints.Value specifies
+// an instance lifecycle.
+func (v *Value) HasInstanceLifecycle() bool {
+	return v.InstanceLifecycle != nil && *v.InstanceLifecycle != ""
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -195,6 +219,9 @@ func (v Value) String() string {
 	if v.CpuPower != nil {
 		strs = append(strs, "cpu-power="+uintStr(*v.CpuPower))
 	}
+	if v.InstanceLifecycle != nil {
+		strs = append(strs, "instance-lifecycle="+string(*v.InstanceLifecycle))
+	}
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+string(*v.InstanceType))
 	}
@@ -264,6 +291,9 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.InstanceLifecycle != nil {
+		values = append(values, fmt.Sprintf("InstanceLifecycle: %q", *v.InstanceLifecycle))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -420,6 +450,8 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case InstanceLifecycle:
+		err = v.setInstanceLifecycle(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +515,8 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case InstanceLifecycle:
+			err = v.setInstanceLifecycle(vstr)
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +641,19 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setInstanceLifecycle(str string) error {
+	if v.InstanceLifecycle != nil {
+		return errors.Errorf("already set")
+	}
+	switch str {
+	case "", LifecycleSpot, LifecyclePreemptible:
+	default:
+		return errors.Errorf("%q not recognized", str)
+	}
+	v.InstanceLifecycle = &str
+	return nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
@@ -313,6 +313,26 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "instance-lifecycle" in detail.
+	{
+		summary: "set instance-lifecycle empty",
+		args:    []string{"instance-lifecycle="},
+	}, {
+		summary: "set instance-lifecycle spot",
+		args:    []string{"instance-lifecycle=spot"},
+	}, {
+		summary: "set instance-lifecycle preemptible",
+		args:    []string{"instance-lifecycle=preemptible"},
+	}, {
+		summary: "set nonsense instance-lifecycle",
+		args:    []string{"instance-lifecycle=cheap"},
+		err:     `bad "instance-lifecycle" constraint: "cheap" not recognized`,
+	}, {
+		summary: "double set instance-lifecycle",
+		args:    []string{"instance-lifecycle=spot", "instance-lifecycle="},
+		err:     `bad "instance-lifecycle" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -510,6 +530,8 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"InstanceLifecycle1", constraints.Value{InstanceLifecycle: strp("")}},
+	{"InstanceLifecycle2", constraints.Value{InstanceLifecycle: strp("spot")}},
 	{"All", constraints.Value{
 		Arch:         strp("i386"),
 		Container:    ctypep("lxd"),
@@ -597,6 +619,13 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasInstanceLifecycle(c *gc.C) {
+	cons := constraints.MustParse("instance-lifecycle=")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsFalse)
+	cons = constraints.MustParse("instance-lifecycle=preemptible")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
@@ -49,6 +49,15 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// InterfaceAdder is implemented by managers whose containers can be given
+// further network interfaces while they are running.
+type InterfaceAdder interface {
+	// AddInterfaces gives the running container with the given instance
+	// id the interfaces of the given network config, which it has none of
+	// yet, and configures them in the container.
+	AddInterfaces(id instance.Id, network *NetworkConfig) error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
@@ -197,6 +197,43 @@ func (manager *containerManager) DestroyContainer(id instance.Id) error {
 	return errors.Trace(manager.client.RemoveInstances(manager.namespace.Prefix(), string(id)))
 }
 
+var _ container.InterfaceAdder = (*containerManager)(nil)
+
+// AddInterfaces is part of the container.InterfaceAdder interface. The
+// config of each interface is written to the container before the
+// interface is attached, so that the interface is brought up as soon as
+// the container sees it.
+func (manager *containerManager) AddInterfaces(id instance.Id, networkConfig *container.NetworkConfig) error {
+	if manager.client == nil {
+		var err error
+		manager.client, err = ConnectLocal()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	nics, err := networkDevices(networkConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := string(id)
+	for _, info := range networkConfig.Interfaces {
+		nic, ok := nics[info.InterfaceName]
+		if !ok {
+			continue
+		}
+		config := containerinit.GenerateHotplugInterfaceConfig(info)
+		path := containerinit.HotplugInterfaceConfigFile(info.InterfaceName)
+		if err := manager.client.WriteFile(name, path, []byte(config), 0644); err != nil {
+			return errors.Annotatef(err, "writing config of interface %q", info.InterfaceName)
+		}
+		if err := manager.client.AttachNIC(name, info.InterfaceName, nic); err != nil {
+			return errors.Annotatef(err, "attaching interface %q", info.InterfaceName)
+		}
+		logger.Infof("instance %q given network device %v", name, nic)
+	}
+	return nil
+}
+
 func (manager *containerManager) ListContainers() (result []instance.Instance, err error) {
 	result = []instance.Instance{}
 	if manager.client == nil {
@@ -265,6 +302,12 @@ func nicDevice(deviceName, parentDevice, hwAddr string, mtu int) (lxdclient.Devi
 
 func networkDevices(networkConfig *container.NetworkConfig) (lxdclient.Devices, error) {
 	nics := make(lxdclient.Devices)
+	// Devices are bridged unless macvlan was requested, in which case they
+	// are attached directly to their parent host device.
+	nicType := "bridged"
+	if networkConfig.NetworkType == container.MacvlanNetwork {
+		nicType = "macvlan"
+	}
 
 	if len(networkConfig.Interfaces) > 0 {
 		for _, v := range networkConfig.Interfaces {
@@ -279,6 +322,7 @@ func networkDevices(networkConfig *container.NetworkConfig) (lxdclient.Devices,
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
+			device["nictype"] = nicType
 			nics[v.InterfaceName] = device
 		}
 	} else if networkConfig.Device != "" {
@@ -286,6 +330,7 @@ func networkDevices(networkConfig *container.NetworkConfig) (lxdclient.Devices,
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		device["nictype"] = nicType
 		nics["eth0"] = device
 	}
 
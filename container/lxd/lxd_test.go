@@ -201,3 +201,41 @@ func (t *LxdSuite) TestNetworkDevicesWithParentDevice(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, expected)
 }
+
+func (t *LxdSuite) TestNetworkDevicesMacvlan(c *gc.C) {
+	interfaces := []network.InterfaceInfo{{
+		ParentInterfaceName: "eth0",
+		InterfaceName:       "eth0",
+		InterfaceType:       "ethernet",
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+	}, {
+		ParentInterfaceName: "bond0.100",
+		InterfaceName:       "eth1",
+		InterfaceType:       "ethernet",
+		MACAddress:          "aa:bb:cc:dd:ee:f1",
+		MTU:                 9000,
+	}}
+
+	expected := lxdclient.Devices{
+		"eth0": lxdclient.Device{
+			"hwaddr":  "aa:bb:cc:dd:ee:f0",
+			"name":    "eth0",
+			"nictype": "macvlan",
+			"parent":  "eth0",
+			"type":    "nic",
+		},
+		"eth1": lxdclient.Device{
+			"hwaddr":  "aa:bb:cc:dd:ee:f1",
+			"mtu":     "9000",
+			"name":    "eth1",
+			"nictype": "macvlan",
+			"parent":  "bond0.100",
+			"type":    "nic",
+		},
+	}
+
+	result, err := lxd.NetworkDevices(container.MacvlanNetworkConfig(0, interfaces))
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
@@ -12,6 +12,9 @@ const (
 	BridgeNetwork = "bridge"
 	// PhyscialNetwork will have the container use a specified network device.
 	PhysicalNetwork = "physical"
+	// MacvlanNetwork will have the container use macvlan devices on the
+	// host's network devices.
+	MacvlanNetwork = "macvlan"
 	// DefaultLxdBridge is the default name for the lxd bridge.
 	DefaultLxdBridge = "lxdbr0"
 	// DefaultLxcBridge is the package created container bridge.
@@ -49,3 +52,10 @@ func BridgeNetworkConfig(device string, mtu int, interfaces []network.InterfaceI
 	}
 	return &NetworkConfig{BridgeNetwork, device, mtu, interfaces}
 }
+
+// MacvlanNetworkConfig returns a valid NetworkConfig to attach the container's
+// network interfaces directly to their parent host devices using macvlan,
+// without any bridge. Each interface must have a ParentInterfaceName.
+func MacvlanNetworkConfig(mtu int, interfaces []network.InterfaceInfo) *NetworkConfig {
+	return &NetworkConfig{MacvlanNetwork, "", mtu, interfaces}
+}
@@ -5,16 +5,20 @@ package controller
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/utils/set"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/oidc"
 )
 
 const (
@@ -47,6 +51,28 @@ const (
 	// IdentityPublicKey sets the public key of the identity manager.
 	IdentityPublicKey = "identity-public-key"
 
+	// OIDCIssuerURL sets the issuer URL of the OpenID Connect
+	// provider that external users may log in with.
+	OIDCIssuerURL = "oidc-issuer-url"
+
+	// OIDCClientID sets the client ID that ID tokens presented
+	// at login must have been issued to.
+	OIDCClientID = "oidc-client-id"
+
+	// OIDCKeySet holds the JSON Web Key Set of the OpenID Connect
+	// provider. If it is not set, the key set is fetched from the
+	// provider when first needed, and again when it is a day old or
+	// a token is signed with a key it does not hold.
+	OIDCKeySet = "oidc-jwks"
+
+	// OIDCUsernameClaim sets the ID token claim that holds the
+	// Juju user name of an external user.
+	OIDCUsernameClaim = "oidc-username-claim"
+
+	// OIDCGroupsClaim sets the ID token claim that holds the
+	// groups an external user is a member of.
+	OIDCGroupsClaim = "oidc-groups-claim"
+
 	// NUMAControlPolicyKey stores the value for this setting
 	SetNUMAControlPolicyKey = "set-numa-control-policy"
 
@@ -79,6 +105,10 @@ const (
 	// before it is pruned, eg "4M"
 	MaxLogsSize = "max-logs-size"
 
+	// APIAllowKey is the key for the comma-separated list of CIDRs
+	// from which clients may connect to the controller's API port.
+	APIAllowKey = "api-allow"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -89,6 +119,14 @@ const (
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
 
+	// DefaultOIDCUsernameClaim is the default value for the
+	// OIDCUsernameClaim config value.
+	DefaultOIDCUsernameClaim = "email"
+
+	// DefaultOIDCGroupsClaim is the default value for the
+	// OIDCGroupsClaim config value.
+	DefaultOIDCGroupsClaim = "groups"
+
 	// DefaultStatePort is the default port the controller is listening on.
 	DefaultStatePort int = 37017
 
@@ -104,12 +142,16 @@ const (
 	// DefaultMaxLogCollectionMB is the maximum size the log collection can
 	// grow to before being pruned.
 	DefaultMaxLogCollectionMB = 4 * 1024 // 4 GB
+
+	// DefaultAPIAllow is the default value for APIAllowKey.
+	DefaultAPIAllow = "0.0.0.0/0,::/0"
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
+	APIAllowKey,
 	APIPort,
 	AutocertDNSNameKey,
 	AutocertURLKey,
@@ -117,6 +159,11 @@ var ControllerOnlyConfigAttributes = []string{
 	ControllerUUIDKey,
 	IdentityPublicKey,
 	IdentityURL,
+	OIDCIssuerURL,
+	OIDCClientID,
+	OIDCKeySet,
+	OIDCUsernameClaim,
+	OIDCGroupsClaim,
 	SetNUMAControlPolicyKey,
 	StatePort,
 	MongoMemoryProfile,
@@ -124,6 +171,17 @@ var ControllerOnlyConfigAttributes = []string{
 	MaxLogsAge,
 }
 
+// AllowedUpdateConfigAttributes contains the attributes that
+// may be changed after the controller has been bootstrapped.
+var AllowedUpdateConfigAttributes = set.NewStrings(
+	APIAllowKey,
+	OIDCIssuerURL,
+	OIDCClientID,
+	OIDCKeySet,
+	OIDCUsernameClaim,
+	OIDCGroupsClaim,
+)
+
 // ControllerOnlyAttribute returns true if the specified attribute name
 // is only relevant for a controller.
 func ControllerOnlyAttribute(attr string) bool {
@@ -231,6 +289,49 @@ func (c Config) IdentityURL() string {
 	return c.asString(IdentityURL)
 }
 
+// OIDCIssuerURL returns the issuer URL of the OpenID Connect provider,
+// or the empty string if OpenID Connect login is not configured.
+func (c Config) OIDCIssuerURL() string {
+	return c.asString(OIDCIssuerURL)
+}
+
+// OIDCClientID returns the client ID that ID tokens must be issued to.
+func (c Config) OIDCClientID() string {
+	return c.asString(OIDCClientID)
+}
+
+// OIDCKeySet returns the configured key set of the OpenID Connect
+// provider, or nil if it should be fetched from the provider.
+func (c Config) OIDCKeySet() oidc.KeySet {
+	data := c.asString(OIDCKeySet)
+	if data == "" {
+		return nil
+	}
+	keys, err := oidc.ParseKeySet([]byte(data))
+	if err != nil {
+		// We check that the key set can be parsed in the
+		// Validate function, so we really do not expect this to fail.
+		panic(err)
+	}
+	return keys
+}
+
+// OIDCUsernameClaim returns the ID token claim holding the user name.
+func (c Config) OIDCUsernameClaim() string {
+	if claim := c.asString(OIDCUsernameClaim); claim != "" {
+		return claim
+	}
+	return DefaultOIDCUsernameClaim
+}
+
+// OIDCGroupsClaim returns the ID token claim holding the user's groups.
+func (c Config) OIDCGroupsClaim() string {
+	if claim := c.asString(OIDCGroupsClaim); claim != "" {
+		return claim
+	}
+	return DefaultOIDCGroupsClaim
+}
+
 // AutocertURL returns the URL used to obtain official TLS certificates
 // when a client connects to the API. See AutocertURLKey
 // for more details.
@@ -299,6 +400,22 @@ func (c Config) MaxLogSizeMB() int {
 	return int(val)
 }
 
+// APIAllow returns the CIDRs from which clients may connect
+// to the controller's API port.
+func (c Config) APIAllow() []string {
+	value, ok := c[APIAllowKey].(string)
+	if !ok {
+		value = DefaultAPIAllow
+	}
+	var cidrs []string
+	for _, cidr := range strings.Split(value, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -322,6 +439,27 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[OIDCKeySet].(string); ok {
+		if _, err := oidc.ParseKeySet([]byte(v)); err != nil {
+			return errors.Annotate(err, "invalid OpenID Connect key set")
+		}
+	}
+
+	if v, ok := c[OIDCIssuerURL].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid OpenID Connect issuer URL")
+		}
+		// As for the identity URL, an HTTP issuer is only allowed
+		// when we need not fetch the key set from it.
+		if _, ok := c[OIDCKeySet]; !ok && u.Scheme != "https" {
+			return errors.Errorf("%s needs to be https when %s not provided", OIDCIssuerURL, OIDCKeySet)
+		}
+		if c.OIDCClientID() == "" {
+			return errors.Errorf("%s must be set when %s is provided", OIDCClientID, OIDCIssuerURL)
+		}
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -352,6 +490,19 @@ func Validate(c Config) error {
 		}
 	}
 
+	if _, ok := c[APIAllowKey].(string); ok {
+		// Allowing access from nowhere would lock every
+		// client, including the CLI, out of the controller.
+		if len(c.APIAllow()) == 0 {
+			return errors.Errorf("%s must not be empty", APIAllowKey)
+		}
+		for _, cidr := range c.APIAllow() {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.Annotatef(err, "invalid %s in configuration", APIAllowKey)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -367,6 +518,11 @@ var configChecker = schema.FieldMap(schema.Fields{
 	StatePort:               schema.ForceInt(),
 	IdentityURL:             schema.String(),
 	IdentityPublicKey:       schema.String(),
+	OIDCIssuerURL:           schema.String(),
+	OIDCClientID:            schema.String(),
+	OIDCKeySet:              schema.String(),
+	OIDCUsernameClaim:       schema.String(),
+	OIDCGroupsClaim:         schema.String(),
 	SetNUMAControlPolicyKey: schema.Bool(),
 	AutocertURLKey:          schema.String(),
 	AutocertDNSNameKey:      schema.String(),
@@ -374,12 +530,18 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MongoMemoryProfile:      schema.String(),
 	MaxLogsAge:              schema.String(),
 	MaxLogsSize:             schema.String(),
+	APIAllowKey:             schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
+	OIDCIssuerURL:           schema.Omit,
+	OIDCClientID:            schema.Omit,
+	OIDCKeySet:              schema.Omit,
+	OIDCUsernameClaim:       schema.Omit,
+	OIDCGroupsClaim:         schema.Omit,
 	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
 	AutocertURLKey:          schema.Omit,
 	AutocertDNSNameKey:      schema.Omit,
@@ -387,4 +549,5 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MongoMemoryProfile:      schema.Omit,
 	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
 	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	APIAllowKey:             schema.Omit,
 })
@@ -121,8 +121,67 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "HTTPS OpenID Connect issuer OK",
+	config: controller.Config{
+		controller.OIDCIssuerURL: "https://login.example.com",
+		controller.OIDCClientID:  "juju",
+		controller.CACertKey:     testing.CACert,
+	},
+}, {
+	about: "OpenID Connect issuer requires client ID",
+	config: controller.Config{
+		controller.OIDCIssuerURL: "https://login.example.com",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `oidc-client-id must be set when oidc-issuer-url is provided`,
+}, {
+	about: "HTTP OpenID Connect issuer requires key set",
+	config: controller.Config{
+		controller.OIDCIssuerURL: "http://login.example.com",
+		controller.OIDCClientID:  "juju",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `oidc-issuer-url needs to be https when oidc-jwks not provided`,
+}, {
+	about: "HTTP OpenID Connect issuer OK if key set is provided",
+	config: controller.Config{
+		controller.OIDCIssuerURL: "http://login.example.com",
+		controller.OIDCClientID:  "juju",
+		controller.OIDCKeySet:    testKeySet,
+		controller.CACertKey:     testing.CACert,
+	},
+}, {
+	about: "invalid OpenID Connect key set",
+	config: controller.Config{
+		controller.OIDCKeySet: `{"keys": []}`,
+		controller.CACertKey:  testing.CACert,
+	},
+	expectError: `invalid OpenID Connect key set: key set contains no RSA signing keys`,
+}, {
+	about: "invalid api-allow CIDR",
+	config: controller.Config{
+		controller.APIAllowKey: "10.0.0.0/8,192.168.1.1",
+		controller.CACertKey:   testing.CACert,
+	},
+	expectError: `invalid api-allow in configuration: invalid CIDR address: 192.168.1.1`,
+}, {
+	about: "empty api-allow",
+	config: controller.Config{
+		controller.APIAllowKey: " , ",
+		controller.CACertKey:   testing.CACert,
+	},
+	expectError: `api-allow must not be empty`,
 }}
 
+// testKeySet holds a JSON Web Key Set containing a single RSA key.
+const testKeySet = `{"keys": [{
+	"kty": "RSA",
+	"kid": "test",
+	"n": "wMyW4BDL07k6PULLtMCc8MzZqZjG3oT1xX2bKdyUcvtGk8tXrm9u7FkHhTDeF6rEVTkaC-ldvVt04a8PxJwDHQ",
+	"e": "AQAB"
+}]}`
+
 func (s *ConfigSuite) TestValidate(c *gc.C) {
 	for i, test := range validateTests {
 		c.Logf("test %d: %v", i, test.about)
@@ -142,6 +201,47 @@ func (s *ConfigSuite) TestLogConfigDefaults(c *gc.C) {
 	c.Assert(cfg.MaxLogSizeMB(), gc.Equals, 4096)
 }
 
+func (s *ConfigSuite) TestOIDCConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"oidc-issuer-url": "http://login.example.com",
+			"oidc-client-id":  "juju",
+			"oidc-jwks":       testKeySet,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OIDCIssuerURL(), gc.Equals, "http://login.example.com")
+	c.Assert(cfg.OIDCClientID(), gc.Equals, "juju")
+	c.Assert(cfg.OIDCKeySet(), gc.HasLen, 1)
+	c.Assert(cfg.OIDCUsernameClaim(), gc.Equals, "email")
+	c.Assert(cfg.OIDCGroupsClaim(), gc.Equals, "groups")
+}
+
+func (s *ConfigSuite) TestOIDCConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OIDCIssuerURL(), gc.Equals, "")
+	c.Assert(cfg.OIDCKeySet(), gc.IsNil)
+}
+
+func (s *ConfigSuite) TestAPIAllow(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"api-allow": "10.0.0.0/8, 192.168.0.0/16",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+}
+
 func (s *ConfigSuite) TestLogConfigValues(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource"
 )
 
@@ -51,6 +52,50 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// RoleGrants lists the roles granted to users on the model.
+	// Roles are not part of the model description, so are
+	// transferred separately.
+	RoleGrants []RoleGrant
+
+	// UserGroups lists the groups of users that have been granted
+	// access to the model, along with their members. Groups are
+	// not part of the model description, so are transferred
+	// separately.
+	UserGroups []UserGroup
+
+	// ApplicationGrants lists the access granted to users on the
+	// model's applications, which is not part of the model
+	// description either.
+	ApplicationGrants []ApplicationGrant
+
+	// Extras holds the parts of the model that the model
+	// description has no place for, such as the expose settings
+	// of its applications' endpoints, as serialized by the source
+	// controller. It is nil if the model has none.
+	Extras []byte
+}
+
+// RoleGrant describes a role granted to a user on a migrating model.
+type RoleGrant struct {
+	User names.UserTag
+	Role permission.Role
+}
+
+// UserGroup describes a group of users with access to a migrating
+// model.
+type UserGroup struct {
+	Name      string
+	CreatedBy string
+	Members   []names.UserTag
+}
+
+// ApplicationGrant describes the access granted to a user on an
+// application in a migrating model.
+type ApplicationGrant struct {
+	Application string
+	User        names.UserTag
+	Access      permission.Access
 }
 
 // SerializedModelResource defines the resource revisions for a
@@ -68,6 +113,13 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// Cloud and CloudRegion identify where the model is hosted. Users
+	// holds the users with access to the model. These are optional
+	// and only checked by the target controller when provided.
+	Cloud       string
+	CloudRegion string
+	Users       []names.UserTag
 }
 
 func (i *ModelInfo) Validate() error {
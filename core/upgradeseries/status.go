@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries holds the statuses a machine, and the units
+// deployed to it, move through while the machine's series is upgraded
+// in place.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+)
+
+// Status indicates how far through a series upgrade a machine, or a
+// unit deployed to it, has got.
+type Status string
+
+const (
+	// NotStarted indicates that no series upgrade is in progress.
+	NotStarted Status = "not started"
+
+	// PrepareStarted indicates that the machine has been locked for a
+	// series upgrade, and that the pre-series-upgrade hooks are due.
+	PrepareStarted Status = "prepare started"
+
+	// PrepareCompleted indicates that the pre-series-upgrade hooks have
+	// run and, for the machine, that its agents have been stopped and
+	// set up for the new series. The operating system can now be
+	// upgraded.
+	PrepareCompleted Status = "prepare completed"
+
+	// CompleteStarted indicates that the operating system has been
+	// upgraded, and that the post-series-upgrade hooks are due.
+	CompleteStarted Status = "complete started"
+
+	// Completed indicates that the post-series-upgrade hooks have run.
+	Completed Status = "completed"
+)
+
+// Validate returns an error if the status is not known.
+func (s Status) Validate() error {
+	switch s {
+	case NotStarted, PrepareStarted, PrepareCompleted, CompleteStarted, Completed:
+		return nil
+	}
+	return errors.NotValidf("upgrade series status %q", s)
+}
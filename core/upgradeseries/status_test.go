@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/upgradeseries"
+)
+
+type StatusSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&StatusSuite{})
+
+func (*StatusSuite) TestValidateValid(c *gc.C) {
+	for i, test := range []upgradeseries.Status{
+		upgradeseries.NotStarted,
+		upgradeseries.PrepareStarted,
+		upgradeseries.PrepareCompleted,
+		upgradeseries.CompleteStarted,
+		upgradeseries.Completed,
+	} {
+		c.Logf("test %d: %s", i, test)
+		err := test.Validate()
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (*StatusSuite) TestValidateInvalid(c *gc.C) {
+	for i, test := range []upgradeseries.Status{
+		"", "started", "Completed",
+	} {
+		c.Logf("test %d: %s", i, test)
+		err := test.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `upgrade series status ".*" not valid`)
+	}
+}
@@ -48,6 +48,12 @@ type StartInstanceParams struct {
 	// high availability.
 	DistributionGroup func() ([]instance.Id, error)
 
+	// AntiAffinityGroups holds the names of the applications with
+	// anti-affinity that the machine being provisioned hosts units
+	// of. The InstanceBroker may use this information to keep the
+	// instances of each group on different physical hosts.
+	AntiAffinityGroups []string
+
 	// Volumes is a set of parameters for volumes that should be created.
 	//
 	// StartInstance need not check the value of the Attachment field,
@@ -73,6 +79,12 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// APIAllow holds the CIDRs from which clients may connect to the
+	// controller's API port, for providers that open that port when
+	// setting up the firewall for the instance. If it is empty, the
+	// port is opened to all addresses.
+	APIAllow []string
+
 	// CleanupCallback is a callback to be used to clean up any residual
 	// status-reporting output from StatusCallback.
 	CleanupCallback func(info string) error
@@ -5,6 +5,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -42,6 +43,24 @@ const (
 	// useful for clouds without support for either global or per
 	// instance security groups.
 	FwNone = "none"
+
+	// FwHost requests that each machine agent programs the firewall
+	// of its own host, with nftables or iptables, from the ingress
+	// rules of the machine. No firewaller worker will be started.
+	// It's useful for clouds without security groups, such as MAAS
+	// and manual machines.
+	FwHost = "host"
+)
+
+const (
+	// ContainerNetworkingBridge requests that containers are attached to
+	// bridges created on their host machine's network devices.
+	ContainerNetworkingBridge = "bridge"
+
+	// ContainerNetworkingMacvlan requests that LXD containers are attached
+	// directly to their host machine's network devices with macvlan
+	// devices, so the host's network configuration is never rewritten.
+	ContainerNetworkingMacvlan = "macvlan"
 )
 
 // TODO(katco-): Please grow this over time.
@@ -70,6 +89,15 @@ const (
 	// ProvisionerHarvestModeKey stores the key for this setting.
 	ProvisionerHarvestModeKey = "provisioner-harvest-mode"
 
+	// ProvisionerRetryCountKey is the key for the number of times the
+	// provisioner retries starting an instance before giving up.
+	ProvisionerRetryCountKey = "provisioner-retry-count"
+
+	// ProvisionerRetryDelayKey is the key for how long the provisioner
+	// waits before first retrying to start an instance, eg "10s". The
+	// delay doubles with each further attempt.
+	ProvisionerRetryDelayKey = "provisioner-retry-delay"
+
 	// AgentStreamKey stores the key for this setting.
 	AgentStreamKey = "agent-stream"
 
@@ -104,6 +132,10 @@ const (
 	// the network for containers.
 	NetBondReconfigureDelayKey = "net-bond-reconfigure-delay"
 
+	// ContainerNetworkingMethodKey is the key for how containers are
+	// attached to the network of their host machine.
+	ContainerNetworkingMethodKey = "container-networking-method"
+
 	// The default block storage source.
 	StorageDefaultBlockSourceKey = "storage-default-block-source"
 
@@ -149,6 +181,14 @@ const (
 	// collection can grow to before it is pruned, eg "5M"
 	MaxStatusHistorySize = "max-status-history-size"
 
+	// SSHAllowKey is the key for the comma-separated list of CIDRs
+	// from which SSH access to the model's machines is allowed.
+	SSHAllowKey = "ssh-allow"
+
+	// PreferIPv6Key is the key for whether IPv6 addresses are
+	// preferred over IPv4 ones when both are available.
+	PreferIPv6Key = "prefer-ipv6"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -303,6 +343,17 @@ const (
 
 	// DefaultStatusHistorySize is the default value for MaxStatusHistorySize.
 	DefaultStatusHistorySize = "5G"
+
+	// DefaultSSHAllow is the default value for SSHAllowKey.
+	DefaultSSHAllow = "0.0.0.0/0,::/0"
+
+	// DefaultProvisionerRetryCount is the default value for
+	// ProvisionerRetryCountKey.
+	DefaultProvisionerRetryCount = 10
+
+	// DefaultProvisionerRetryDelay is the default value for
+	// ProvisionerRetryDelayKey.
+	DefaultProvisionerRetryDelay = "10s"
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -312,6 +363,8 @@ var defaultConfigValues = map[string]interface{}{
 	IgnoreMachineAddresses:       false,
 	"ssl-hostname-verification":  true,
 	"proxy-ssh":                  false,
+	SSHAllowKey:                  DefaultSSHAllow,
+	PreferIPv6Key:                false,
 
 	// Why is net-bond-reconfigure-delay set to 17 seconds?
 	//
@@ -332,8 +385,12 @@ var defaultConfigValues = map[string]interface{}{
 	// $ juju model-config net-bond-reconfigure-delay=30
 	NetBondReconfigureDelayKey: 17,
 
+	ContainerNetworkingMethodKey: ContainerNetworkingBridge,
+
 	"default-series":           series.LatestLts(),
 	ProvisionerHarvestModeKey:  HarvestDestroyed.String(),
+	ProvisionerRetryCountKey:   DefaultProvisionerRetryCount,
+	ProvisionerRetryDelayKey:   DefaultProvisionerRetryDelay,
 	ResourceTagsKey:            "",
 	"logging-config":           "",
 	AutomaticallyRetryHooks:    true,
@@ -515,6 +572,24 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[ProvisionerRetryCountKey].(int); ok && v < 0 {
+		return errors.Errorf("invalid %s in model configuration: %d is negative", ProvisionerRetryCountKey, v)
+	}
+
+	if v, ok := cfg.defined[ProvisionerRetryDelayKey].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", ProvisionerRetryDelayKey)
+		}
+	}
+
+	if v, ok := cfg.defined[SSHAllowKey].(string); ok {
+		for _, cidr := range splitCIDRs(v) {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.Annotatef(err, "invalid %s in model configuration", SSHAllowKey)
+			}
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -620,6 +695,38 @@ func (c *Config) ProxySSH() bool {
 	return value
 }
 
+// SSHAllow returns the CIDRs from which SSH access to the model's
+// machines is allowed. An empty result means that SSH access is not
+// allowed from anywhere.
+func (c *Config) SSHAllow() []string {
+	value, ok := c.defined[SSHAllowKey].(string)
+	if !ok {
+		value = DefaultSSHAllow
+	}
+	return splitCIDRs(value)
+}
+
+// PreferIPv6 reports whether IPv6 addresses are preferred over IPv4
+// ones when selecting the addresses of the model's machines, for
+// dual-stack models. IPv6 addresses are always used when they are the
+// only ones available.
+func (c *Config) PreferIPv6() bool {
+	value, _ := c.defined[PreferIPv6Key].(bool)
+	return value
+}
+
+// splitCIDRs returns the elements of the given
+// comma-separated list of CIDRs.
+func splitCIDRs(value string) []string {
+	var cidrs []string
+	for _, cidr := range strings.Split(value, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
 // NetBondReconfigureDelay returns the duration in seconds that should be
 // passed to the bridge script when bridging bonded interfaces.
 func (c *Config) NetBondReconfigureDelay() int {
@@ -627,6 +734,16 @@ func (c *Config) NetBondReconfigureDelay() int {
 	return value
 }
 
+// ContainerNetworkingMethod returns how containers are attached to the
+// network of their host machine (ContainerNetworkingBridge or
+// ContainerNetworkingMacvlan).
+func (c *Config) ContainerNetworkingMethod() string {
+	if value, ok := c.defined[ContainerNetworkingMethodKey].(string); ok && value != "" {
+		return value
+	}
+	return ContainerNetworkingBridge
+}
+
 // ProxySettings returns all four proxy settings; http, https, ftp, and no
 // proxy.
 func (c *Config) ProxySettings() proxy.Settings {
@@ -749,8 +866,8 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 }
 
 // FirewallMode returns whether the firewall should
-// manage ports per machine, globally, or not at all.
-// (FwInstance, FwGlobal, or FwNone).
+// manage ports per machine, globally, on each host, or not at all.
+// (FwInstance, FwGlobal, FwHost, or FwNone).
 func (c *Config) FirewallMode() string {
 	return c.mustString("firewall-mode")
 }
@@ -860,6 +977,27 @@ func (c *Config) ProvisionerHarvestMode() HarvestMode {
 	}
 }
 
+// ProvisionerRetryCount returns the number of times the provisioner
+// retries starting an instance before giving up.
+func (c *Config) ProvisionerRetryCount() int {
+	if v, ok := c.defined[ProvisionerRetryCountKey].(int); ok {
+		return v
+	}
+	return DefaultProvisionerRetryCount
+}
+
+// ProvisionerRetryDelay returns how long the provisioner waits before
+// first retrying to start an instance.
+func (c *Config) ProvisionerRetryDelay() time.Duration {
+	v, ok := c.defined[ProvisionerRetryDelayKey].(string)
+	if !ok {
+		v = DefaultProvisionerRetryDelay
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(v)
+	return val
+}
+
 // ImageStream returns the simplestreams stream
 // used to identify which image ids to search
 // when starting an instance.
@@ -1030,6 +1168,8 @@ var alwaysOptional = schema.Defaults{
 	"firewall-mode":              schema.Omit,
 	"logging-config":             schema.Omit,
 	ProvisionerHarvestModeKey:    schema.Omit,
+	ProvisionerRetryCountKey:     schema.Omit,
+	ProvisionerRetryDelayKey:     schema.Omit,
 	HTTPProxyKey:                 schema.Omit,
 	HTTPSProxyKey:                schema.Omit,
 	FTPProxyKey:                  schema.Omit,
@@ -1051,12 +1191,15 @@ var alwaysOptional = schema.Defaults{
 	"development":                schema.Omit,
 	"ssl-hostname-verification":  schema.Omit,
 	"proxy-ssh":                  schema.Omit,
+	SSHAllowKey:                  schema.Omit,
+	PreferIPv6Key:                schema.Omit,
 	"disable-network-management": schema.Omit,
 	IgnoreMachineAddresses:       schema.Omit,
 	AutomaticallyRetryHooks:      schema.Omit,
 	"test-mode":                  schema.Omit,
 	TransmitVendorMetricsKey:     schema.Omit,
 	NetBondReconfigureDelayKey:   schema.Omit,
+	ContainerNetworkingMethodKey: schema.Omit,
 	MaxStatusHistoryAge:          schema.Omit,
 	MaxStatusHistorySize:         schema.Omit,
 }
@@ -1292,11 +1435,15 @@ var configSchema = environschema.Fields{
 for a network port is enabled to one instance if any instance requires
 that port).
 
+'host' requests that each machine programs its own host
+firewall (nftables or iptables) from the ports opened on it. It's
+useful for clouds without security groups, such as MAAS and manual.
+
 'none' requests that no firewalling should be performed
 inside the model. It's useful for clouds without support for either
 global or per instance security groups.`,
 		Type:      environschema.Tstring,
-		Values:    []interface{}{FwInstance, FwGlobal, FwNone},
+		Values:    []interface{}{FwInstance, FwGlobal, FwHost, FwNone},
 		Immutable: true,
 		Group:     environschema.EnvironGroup,
 	},
@@ -1349,6 +1496,16 @@ global or per instance security groups.`,
 		Values:      []interface{}{"all", "none", "unknown", "destroyed"},
 		Group:       environschema.EnvironGroup,
 	},
+	ProvisionerRetryCountKey: {
+		Description: "The number of times the provisioner retries starting an instance before giving up",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ProvisionerRetryDelayKey: {
+		Description: "How long the provisioner waits before first retrying to start an instance; the delay doubles with each further attempt (default 10s)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"proxy-ssh": {
 		// default: true
 		Description: `Whether SSH commands should be proxied through the API server`,
@@ -1385,11 +1542,21 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	PreferIPv6Key: {
+		Description: "Whether IPv6 addresses are preferred over IPv4 ones when both are available",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	SSHAllowKey: {
+		Description: "List of CIDRs from which SSH access to the model's machines is allowed (comma-separated)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	StorageDefaultBlockSourceKey: {
 		Description: "The default block storage source for the model",
 		Type:        environschema.Tstring,
@@ -1430,6 +1597,19 @@ data of the store. (default false)`,
 		Type:        environschema.Tint,
 		Group:       environschema.EnvironGroup,
 	},
+	ContainerNetworkingMethodKey: {
+		Description: `How containers are attached to the network of their host machine.
+
+'bridge' bridges the host machine's network devices and attaches
+containers to those bridges.
+
+'macvlan' attaches LXD containers directly to the host machine's
+network devices with macvlan devices, without bridging them. Note
+that the host machine cannot reach its own containers over macvlan.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{ContainerNetworkingBridge, ContainerNetworkingMacvlan},
+		Group:  environschema.EnvironGroup,
+	},
 	MaxStatusHistoryAge: {
 		Description: "The maximum age for status history entries before they are pruned, in human-readable time format",
 		Type:        environschema.Tstring,
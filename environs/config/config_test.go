@@ -260,7 +260,7 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": "",
 		}),
-		err: `firewall-mode: expected one of \[instance global none\], got ""`,
+		err: `firewall-mode: expected one of \[instance global host none\], got ""`,
 	}, {
 		about:       "Instance firewall mode",
 		useDefaults: config.UseDefaults,
@@ -279,13 +279,19 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": config.FwNone,
 		}),
+	}, {
+		about:       "Host firewall mode",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"firewall-mode": config.FwHost,
+		}),
 	}, {
 		about:       "Illegal firewall mode",
 		useDefaults: config.UseDefaults,
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": "illegal",
 		}),
-		err: `firewall-mode: expected one of \[instance global none\], got "illegal"`,
+		err: `firewall-mode: expected one of \[instance global host none\], got "illegal"`,
 	}, {
 		about:       "ssl-hostname-verification off",
 		useDefaults: config.UseDefaults,
@@ -1121,6 +1127,81 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestProvisionerRetryDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ProvisionerRetryCount(), gc.Equals, 10)
+	c.Assert(cfg.ProvisionerRetryDelay(), gc.Equals, 10*time.Second)
+}
+
+func (s *ConfigSuite) TestProvisionerRetry(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"provisioner-retry-count": 3,
+		"provisioner-retry-delay": "1m",
+	})
+	c.Assert(cfg.ProvisionerRetryCount(), gc.Equals, 3)
+	c.Assert(cfg.ProvisionerRetryDelay(), gc.Equals, time.Minute)
+}
+
+func (s *ConfigSuite) TestProvisionerRetryInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"provisioner-retry-count": -1,
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid provisioner-retry-count in model configuration: -1 is negative`)
+
+	_, err = config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"provisioner-retry-delay": "soon",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid provisioner-retry-delay in model configuration: time: invalid duration soon`)
+}
+
+func (s *ConfigSuite) TestSSHAllowDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.SSHAllow(), jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+}
+
+func (s *ConfigSuite) TestSSHAllow(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"ssh-allow": "192.168.0.0/24, 10.0.0.0/8",
+	})
+	c.Assert(cfg.SSHAllow(), jc.DeepEquals, []string{"192.168.0.0/24", "10.0.0.0/8"})
+
+	cfg, err := cfg.Apply(map[string]interface{}{"ssh-allow": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SSHAllow(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestSSHAllowInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"ssh-allow": "192.168.0.0/24,10.0.0.1",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid ssh-allow in model configuration: invalid CIDR address: 10.0.0.1`)
+}
+
+func (s *ConfigSuite) TestPreferIPv6(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PreferIPv6(), jc.IsFalse)
+
+	cfg, err := cfg.Apply(map[string]interface{}{"prefer-ipv6": true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.PreferIPv6(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestContainerNetworkingMethod(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ContainerNetworkingMethod(), gc.Equals, config.ContainerNetworkingBridge)
+
+	cfg, err := cfg.Apply(map[string]interface{}{"container-networking-method": "macvlan"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ContainerNetworkingMethod(), gc.Equals, config.ContainerNetworkingMacvlan)
+}
+
+func (s *ConfigSuite) TestContainerNetworkingMethodInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"container-networking-method": "fan",
+	}))
+	c.Assert(err, gc.ErrorMatches, `container-networking-method: expected one of \[bridge macvlan\], got "fan"`)
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
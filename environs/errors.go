@@ -12,3 +12,43 @@ var (
 	ErrNoInstances      = errors.NotFoundf("instances")
 	ErrPartialInstances = errors.New("only some instances were found")
 )
+
+// CapacityError is returned by InstanceBroker.StartInstance when an
+// instance could not be started because the cloud lacked the capacity
+// for it. Starting the instance in another availability zone, or with
+// another instance type, may succeed.
+type CapacityError struct {
+	// Zones holds the availability zones that lacked capacity,
+	// if they are known. A provider that tried several zones
+	// before giving up reports all of them.
+	Zones []string
+
+	// InstanceType is the instance type that lacked capacity,
+	// if it is known.
+	InstanceType string
+
+	err error
+}
+
+// NewCapacityError returns a CapacityError wrapping the given error,
+// for the given availability zones and instance type. Either of zones
+// and instanceType may be empty if they are not known.
+func NewCapacityError(err error, zones []string, instanceType string) error {
+	return &CapacityError{
+		Zones:        zones,
+		InstanceType: instanceType,
+		err:          err,
+	}
+}
+
+// Error is part of the error interface.
+func (e *CapacityError) Error() string {
+	return e.err.Error()
+}
+
+// IsCapacityError reports whether the cause of the given
+// error is a CapacityError.
+func IsCapacityError(err error) bool {
+	_, ok := errors.Cause(err).(*CapacityError)
+	return ok
+}
@@ -384,15 +384,100 @@ type Firewaller interface {
 	IngressRules() ([]network.IngressRule, error)
 }
 
+// ModelFirewaller exposes methods for managing the ingress rules that
+// apply to every machine in a model regardless of what is deployed to
+// it, such as those allowing SSH access and access to the controller's
+// API port. It is implemented by environs that open such ports when
+// setting up their firewalls, and is independent of the firewall mode.
+type ModelFirewaller interface {
+	// OpenModelPorts opens the given port ranges for every
+	// machine in the model.
+	OpenModelPorts(rules []network.IngressRule) error
+
+	// CloseModelPorts closes the given port ranges for every
+	// machine in the model.
+	CloseModelPorts(rules []network.IngressRule) error
+
+	// ModelIngressRules returns the ingress rules applied to every
+	// machine in the model by OpenModelPorts, or when the firewall
+	// was set up. As for Firewaller.IngressRules, there will be only
+	// one rule for a given port range.
+	ModelIngressRules() ([]network.IngressRule, error)
+}
+
+// ModelIngressRulesFilterer is implemented by a ModelFirewaller that
+// cannot apply every source CIDR, such as one whose firewall supports
+// only IPv4. The firewaller compares only the filtered rules with those
+// returned by ModelIngressRules, so that it does not try again and
+// again to open rules the provider cannot apply.
+type ModelIngressRulesFilterer interface {
+	// FilterModelIngressRules returns the given rules restricted
+	// to the source CIDRs that the provider can apply. Rules left
+	// without any source CIDRs are dropped.
+	FilterModelIngressRules(rules []network.IngressRule) []network.IngressRule
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
 	//
 	// The specified tags will replace any existing ones with the
-	// same names, but other existing tags will be left alone.
+	// same names, but other existing tags will be left alone. A
+	// tag specified with an empty value is removed.
 	TagInstance(id instance.Id, tags map[string]string) error
 }
 
+// InstanceKeepingDestroyer is an interface that can be used for tearing
+// down an environment while leaving some of its instances running. It
+// is implemented by providers that cannot remove the juju tags from
+// instances kept when their machines are removed.
+type InstanceKeepingDestroyer interface {
+	// DestroyKeepingInstances is like Destroy, but leaves the
+	// instances with the given ids running.
+	DestroyKeepingInstances(keep []instance.Id) error
+}
+
+// InstanceAdopter is an interface that can be used for adopting
+// existing cloud instances, not started by Juju, into the model.
+type InstanceAdopter interface {
+	// AdoptableInstance returns the instance with the given ID,
+	// whether or not it was started for the model. An error
+	// satisfying errors.IsNotFound is returned if there is no such
+	// instance, and an error describing why if it cannot be adopted.
+	AdoptableInstance(id instance.Id) (instance.Instance, error)
+
+	// AdoptInstance tags the instance with the given ID with the
+	// specified tags, and gives it the security groups, or their
+	// equivalent, that the model's instances are started with,
+	// including those of the machine with the given ID.
+	AdoptInstance(id instance.Id, machineId string, tags map[string]string) error
+}
+
+// VolumeTagger is an interface that can be used for tagging volumes
+// created by the environment's storage providers.
+type VolumeTagger interface {
+	// TagVolume tags the volume with the given provider ID with the
+	// specified tags.
+	//
+	// The specified tags will replace any existing ones with the
+	// same names, but other existing tags will be left alone. A
+	// tag specified with an empty value is removed.
+	TagVolume(volumeId string, tags map[string]string) error
+}
+
+// ModelResourceTagger is an interface that can be used for tagging
+// the resources created for a model as a whole, rather than for one
+// of its instances or volumes, such as its security groups.
+type ModelResourceTagger interface {
+	// TagModelResources tags each of the model's shared resources
+	// with the specified tags.
+	//
+	// The specified tags will replace any existing ones with the
+	// same names, but other existing tags will be left alone. A
+	// tag specified with an empty value is removed.
+	TagModelResources(tags map[string]string) error
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/utils/winrm"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 )
 
 var (
@@ -54,6 +55,13 @@ type ProvisionMachineArgs struct {
 	// WinRM contains keys and client interface api with the remote windows machine
 	WinRM WinRMArgs
 
+	// InstanceId, if set, is the provider ID of an existing cloud
+	// instance that is being adopted into the model. The machine is
+	// recorded with this instance ID instead of a manual one, so that
+	// the provider manages it like any other machine. Only the SSH
+	// provisioner supports adopting instances.
+	InstanceId instance.Id
+
 	*params.UpdateBehavior
 }
 
@@ -209,8 +209,10 @@ cat /proc/cpuinfo`
 // we are about to provision. It will SSH into that machine as the ubuntu user.
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
-// the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+// the DNS resolved name, rather than the name that was supplied.
+// If instanceId is set, the machine is an existing cloud instance
+// with that provider ID.
+func gatherMachineParams(hostname string, instanceId instance.Id) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -236,12 +238,15 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, errors.Annotatef(err, "error detecting linux hardware characteristics")
 	}
 
-	// There will never be a corresponding "instance" that any provider
-	// knows about. This is fine, and works well with the provisioner
-	// task. The provisioner task will happily remove any and all dead
+	// Unless an existing cloud instance is being adopted, there will
+	// never be a corresponding "instance" that any provider knows
+	// about. This is fine, and works well with the provisioner task.
+	// The provisioner task will happily remove any and all dead
 	// machines from state, but will ignore the associated instance ID
 	// if it isn't one that the environment provider knows about.
-	instanceId := instance.Id(manual.ManualInstancePrefix + hostname)
+	if instanceId == "" {
+		instanceId = instance.Id(manual.ManualInstancePrefix + hostname)
+	}
 	nonce := fmt.Sprintf("%s:%s", instanceId, uuid.String())
 	machineParams := &params.AddMachineParams{
 		Series:                  series,
@@ -57,6 +57,28 @@ type Instance interface {
 	IngressRules(machineId string) ([]network.IngressRule, error)
 }
 
+// EgressFirewaller is implemented by instances whose outgoing
+// traffic can be restricted. While an instance has no egress rules,
+// its outgoing traffic is not restricted; once it has any, it may
+// only send traffic allowed by them, and to the Juju controller.
+type EgressFirewaller interface {
+	// OpenEgress allows outgoing traffic matching the given rules
+	// from the instance, which should have been started with the
+	// given machine id.
+	OpenEgress(machineId string, rules []network.EgressRule) error
+
+	// CloseEgress stops allowing outgoing traffic matching the given
+	// rules from the instance, which should have been started with
+	// the given machine id.
+	CloseEgress(machineId string, rules []network.EgressRule) error
+
+	// EgressRules returns the set of egress rules for the instance,
+	// which should have been applied to the given machine id. The
+	// rules are returned as sorted by network.SortEgressRules(),
+	// with one rule for each port range.
+	EgressRules(machineId string) ([]network.EgressRule, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
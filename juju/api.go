@@ -50,7 +50,8 @@ type NewAPIConnectionParams struct {
 	// AccountDetails contains the account details to use for logging
 	// in to the Juju API. If this is nil, then no login will take
 	// place. If AccountDetails.Password and AccountDetails.Macaroon
-	// are zero, the login will be as an external user.
+	// are zero, the login will be as an external user, presenting
+	// AccountDetails.IDToken if it is set.
 	AccountDetails *jujuclient.AccountDetails
 
 	// ModelUUID is an optional model UUID. If specified, the API connection
@@ -71,6 +72,18 @@ func NewAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
 		return nil, errors.New("no API addresses")
 	}
 	logger.Infof("connecting to API addresses: %v", apiInfo.Addrs)
+	// Record any ID token obtained during login, so that
+	// it can be saved with the account details.
+	idToken := apiInfo.IDToken
+	if oidcLogin := args.DialOpts.OIDCLogin; oidcLogin != nil {
+		args.DialOpts.OIDCLogin = func(issuer, clientID string) (string, error) {
+			token, err := oidcLogin(issuer, clientID)
+			if err == nil {
+				idToken = token
+			}
+			return token, err
+		}
+	}
 	st, err := args.OpenAPI(apiInfo, args.DialOpts)
 	if err != nil {
 		redirErr, ok := errors.Cause(err).(*api.RedirectError)
@@ -139,10 +152,12 @@ func NewAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
 			}
 		}
 		if ok && !user.IsLocal() && apiInfo.Tag == nil {
-			// We used macaroon auth to login; save the username
-			// that we've logged in as.
+			// We used macaroon or OpenID Connect auth to login;
+			// save the username that we've logged in as, along
+			// with the ID token if there is one.
 			accountDetails = &jujuclient.AccountDetails{
 				User:            user.Id(),
+				IDToken:         idToken,
 				LastKnownAccess: st.ControllerAccess(),
 			}
 		} else if apiInfo.Tag == nil {
@@ -193,6 +208,10 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 		// If no password is recorded, we'll attempt to
 		// authenticate using macaroons.
 		apiInfo.Password = account.Password
+	} else if account.IDToken != "" {
+		// External users authenticated with OpenID
+		// Connect present their most recent ID token.
+		apiInfo.IDToken = account.IDToken
 	}
 	return apiInfo, controller, nil
 }
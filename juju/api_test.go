@@ -187,6 +187,46 @@ func (s *NewAPIClientSuite) TestUpdatesLastKnownAccess(c *gc.C) {
 	)
 }
 
+func (s *NewAPIClientSuite) TestSavesObtainedIDToken(c *gc.C) {
+	store := newClientStore(c, "noconfig")
+	err := store.UpdateAccount("noconfig", jujuclient.AccountDetails{
+		User:    "bob@example.com",
+		IDToken: "old-token",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(apiInfo.Tag, gc.IsNil)
+		c.Check(apiInfo.IDToken, gc.Equals, "old-token")
+		// Simulate the controller rejecting the old token.
+		token, err := opts.OIDCLogin("https://issuer.example.com", "juju")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(token, gc.Equals, "new-token")
+		conn := mockedAPIState(noFlags)
+		conn.authTag = names.NewUserTag("bob@example.com")
+		return conn, nil
+	}
+	accountDetails, err := store.AccountDetails("noconfig")
+	c.Assert(err, jc.ErrorIsNil)
+	dialOpts := api.DefaultDialOpts()
+	dialOpts.OIDCLogin = func(issuer, clientID string) (string, error) {
+		return "new-token", nil
+	}
+	_, err = juju.NewAPIConnection(juju.NewAPIConnectionParams{
+		Store:          store,
+		ControllerName: "noconfig",
+		AccountDetails: accountDetails,
+		DialOpts:       dialOpts,
+		OpenAPI:        apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.Accounts["noconfig"], jc.DeepEquals, jujuclient.AccountDetails{
+		User:            "bob@example.com",
+		IDToken:         "new-token",
+		LastKnownAccess: "superuser",
+	})
+}
+
 func (s *NewAPIClientSuite) TestUpdatesPublicDNSName(c *gc.C) {
 	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
 		conn := mockedAPIState(noFlags)
@@ -25,6 +25,10 @@ type mockAPIState struct {
 	modelTag      string
 	controllerTag string
 	publicDNSName string
+
+	// If non-nil, authTag is returned by AuthTag
+	// instead of the local admin user.
+	authTag names.Tag
 }
 
 type mockedStateFlags int
@@ -110,6 +114,9 @@ func (s *mockAPIState) ControllerTag() names.ControllerTag {
 }
 
 func (s *mockAPIState) AuthTag() names.Tag {
+	if s.authTag != nil {
+		return s.authTag
+	}
 	return names.NewUserTag("admin")
 }
 
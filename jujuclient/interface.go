@@ -83,6 +83,10 @@ type AccountDetails struct {
 	// Password is the password for the account.
 	Password string `yaml:"password,omitempty"`
 
+	// IDToken is the most recent OpenID Connect ID token
+	// obtained for an external user.
+	IDToken string `yaml:"id-token,omitempty"`
+
 	// LastKnownAccess is the last known access level for the account.
 	LastKnownAccess string `yaml:"last-known-access,omitempty"`
 }
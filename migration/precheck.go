@@ -34,6 +34,9 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackendCloser, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	Charm(*charm.URL) (PrecheckCharm, error)
+	Cloud(string) (cloud.Cloud, error)
+	HasUser(names.UserTag) (bool, error)
 }
 
 // PrecheckBackendCloser adds the Close method to the standard
@@ -64,6 +67,8 @@ type PrecheckMachine interface {
 	AgentPresence() (bool, error)
 	InstanceStatus() (status.StatusInfo, error)
 	ShouldRebootOrShutdown() (state.RebootAction, error)
+	IsLockedForSeriesUpgrade() (bool, error)
+	InMaintenance() bool
 }
 
 // PrecheckApplication describes the state interface for an
@@ -76,6 +81,12 @@ type PrecheckApplication interface {
 	MinUnits() int
 }
 
+// PrecheckCharm describes the state interface for a charm needed by
+// migration prechecks.
+type PrecheckCharm interface {
+	IsUploaded() bool
+}
+
 // PrecheckUnit describes state interface for a unit needed by
 // migration prechecks.
 type PrecheckUnit interface {
@@ -92,34 +103,49 @@ type PrecheckUnit interface {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the model to be migrated.
 func SourcePrecheck(backend PrecheckBackend) error {
-	if err := checkModel(backend); err != nil {
-		return errors.Trace(err)
-	}
+	b := &blockers{}
+	sourcePrecheck(backend, b)
+	return b.first()
+}
 
-	if err := checkMachines(backend); err != nil {
-		return errors.Trace(err)
-	}
+// SourcePrecheckAll runs the same checks as SourcePrecheck but,
+// rather than stopping at the first problem, returns every problem
+// found that would prevent the model from being migrated.
+func SourcePrecheckAll(backend PrecheckBackend) []error {
+	b := &blockers{all: true}
+	sourcePrecheck(backend, b)
+	return b.errs
+}
 
-	if err := checkApplications(backend); err != nil {
-		return errors.Trace(err)
+func sourcePrecheck(backend PrecheckBackend, b *blockers) {
+	if b.add(checkModel(backend)) {
+		return
+	}
+	if checkMachines(backend, b) {
+		return
+	}
+	if checkApplications(backend, b) {
+		return
 	}
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+		if b.add(errors.Annotate(err, "checking cleanups")) {
+			return
+		}
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if b.add(errors.New("cleanup needed")) {
+			return
+		}
 	}
 
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
+		b.add(errors.Trace(err))
+		return
 	}
 	defer controllerBackend.Close()
-	if err := checkController(controllerBackend); err != nil {
-		return errors.Annotate(err, "controller")
-	}
-	return nil
+	checkController(controllerBackend, b.annotated("controller"))
 }
 
 func checkModel(backend PrecheckBackend) error {
@@ -149,8 +175,26 @@ func checkModel(backend PrecheckBackend) error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
+	b := &blockers{}
+	targetPrecheck(backend, modelInfo, b)
+	return b.first()
+}
+
+// TargetPrecheckAll runs the same checks as TargetPrecheck but,
+// rather than stopping at the first problem, returns every problem
+// found that would prevent the model from being migrated.
+func TargetPrecheckAll(backend PrecheckBackend, modelInfo coremigration.ModelInfo) []error {
+	b := &blockers{all: true}
+	targetPrecheck(backend, modelInfo, b)
+	return b.errs
+}
+
+func targetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo, b *blockers) {
 	if err := modelInfo.Validate(); err != nil {
-		return errors.Trace(err)
+		// Nothing else can be sensibly checked without valid
+		// model details.
+		b.add(errors.Trace(err))
+		return
 	}
 
 	// This check is necessary because there is a window between the
@@ -161,48 +205,112 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	//
 	// See also https://lpad.tv/1611391
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+		if b.add(errors.Annotate(err, "checking for active migration")) {
+			return
+		}
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if b.add(errors.New("model is being migrated out of target controller")) {
+			return
+		}
 	}
 
 	controllerVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		b.add(errors.Annotate(err, "retrieving model version"))
+		return
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		if b.add(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion)) {
+			return
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		if b.add(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion)) {
+			return
+		}
 	}
 
-	if err := checkController(backend); err != nil {
-		return errors.Trace(err)
+	if checkController(backend, b) {
+		return
 	}
 
 	// Check for conflicts with existing models
 	models, err := backend.AllModels()
 	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+		b.add(errors.Annotate(err, "retrieving models"))
+		return
 	}
 	for _, model := range models {
 		// If the model is importing then it's probably left behind
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			if b.add(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)) {
+				return
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if b.add(errors.Errorf("model named %q already exists", model.Name())) {
+				return
+			}
 		}
 	}
 
-	return nil
+	if b.add(checkTargetCloud(backend, modelInfo)) {
+		return
+	}
+	checkTargetUsers(backend, modelInfo, b)
+}
+
+// checkTargetCloud ensures that the cloud and region hosting the
+// model are known to the target controller. Source controllers which
+// don't report the cloud are not checked.
+func checkTargetCloud(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
+	if modelInfo.Cloud == "" {
+		return nil
+	}
+	modelCloud, err := backend.Cloud(modelInfo.Cloud)
+	if errors.IsNotFound(err) {
+		return errors.Errorf("cloud %q not found in target controller", modelInfo.Cloud)
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving cloud %q", modelInfo.Cloud)
+	}
+	if modelInfo.CloudRegion == "" {
+		return nil
+	}
+	for _, region := range modelCloud.Regions {
+		if region.Name == modelInfo.CloudRegion {
+			return nil
+		}
+	}
+	return errors.Errorf("cloud %q in target controller has no region %q",
+		modelInfo.Cloud, modelInfo.CloudRegion)
+}
+
+// checkTargetUsers ensures that every local user with access to the
+// model also exists in the target controller, so that their access
+// can be carried across. External users need no local account.
+func checkTargetUsers(backend PrecheckBackend, modelInfo coremigration.ModelInfo, b *blockers) bool {
+	for _, user := range modelInfo.Users {
+		if !user.IsLocal() {
+			continue
+		}
+		exists, err := backend.HasUser(user)
+		if err != nil {
+			if b.add(errors.Annotatef(err, "retrieving user %s", user.Id())) {
+				return true
+			}
+		} else if !exists {
+			if b.add(errors.Errorf("user %s not found in target controller", user.Id())) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
@@ -222,124 +330,185 @@ func versionToMajMin(ver version.Number) version.Number {
 	return ver
 }
 
-func checkController(backend PrecheckBackend) error {
+// checkController adds any problems found with the controller to b,
+// reporting whether the prechecks should stop.
+func checkController(backend PrecheckBackend, b *blockers) bool {
 	model, err := backend.Model()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model")
+		return b.add(errors.Annotate(err, "retrieving model"))
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if b.add(errors.Errorf("model is %s", model.Life())) {
+			return true
+		}
 	}
 
 	if upgrading, err := backend.IsUpgrading(); err != nil {
-		return errors.Annotate(err, "checking for upgrades")
+		if b.add(errors.Annotate(err, "checking for upgrades")) {
+			return true
+		}
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if b.add(errors.New("upgrade in progress")) {
+			return true
+		}
 	}
 
-	err = checkMachines(backend)
-	return errors.Trace(err)
+	return checkMachines(backend, b)
 }
 
-func checkMachines(backend PrecheckBackend) error {
+// checkMachines adds any problems found with the backend's machines
+// to b, reporting whether the prechecks should stop.
+func checkMachines(backend PrecheckBackend, b *blockers) bool {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return b.add(errors.Annotate(err, "retrieving model version"))
 	}
 
 	machines, err := backend.AllMachines()
 	if err != nil {
-		return errors.Annotate(err, "retrieving machines")
+		return b.add(errors.Annotate(err, "retrieving machines"))
 	}
 	for _, machine := range machines {
-		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+		if b.add(checkMachine(machine, modelVersion)) {
+			return true
 		}
+	}
+	return false
+}
 
-		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
-		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
-		}
+func checkMachine(machine PrecheckMachine, modelVersion version.Number) error {
+	if machine.Life() != state.Alive {
+		return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+	}
 
-		if statusInfo, err := common.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
-		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
-		}
+	if statusInfo, err := machine.InstanceStatus(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+	} else if statusInfo.Status != status.Running {
+		return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+	}
 
-		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
-		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
-		}
+	if statusInfo, err := common.MachineStatus(machine); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+	} else if statusInfo.Status != status.Started {
+		return newStatusError("machine %s agent not functioning at this time",
+			machine.Id(), statusInfo.Status)
+	}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+	if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+	} else if rebootAction != state.ShouldDoNothing {
+		return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
 	}
-	return nil
+
+	if locked, err := machine.IsLockedForSeriesUpgrade(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s series upgrade status", machine.Id())
+	} else if locked {
+		return errors.Errorf("machine %s is upgrading its series", machine.Id())
+	}
+
+	if machine.InMaintenance() {
+		return errors.Errorf("machine %s is in maintenance", machine.Id())
+	}
+
+	return errors.Trace(checkAgentTools(modelVersion, machine, "machine "+machine.Id()))
 }
 
-func checkApplications(backend PrecheckBackend) error {
+// checkApplications adds any problems found with the backend's
+// applications and their units to b, reporting whether the prechecks
+// should stop.
+func checkApplications(backend PrecheckBackend, b *blockers) bool {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return b.add(errors.Annotate(err, "retrieving model version"))
 	}
 	apps, err := backend.AllApplications()
 	if err != nil {
-		return errors.Annotate(err, "retrieving applications")
+		return b.add(errors.Annotate(err, "retrieving applications"))
 	}
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if b.add(errors.Errorf("application %s is %s", app.Name(), app.Life())) {
+				return true
+			}
 		}
-		err := checkUnits(app, modelVersion)
-		if err != nil {
-			return errors.Trace(err)
+		if b.add(checkCharm(backend, app)) {
+			return true
+		}
+		if checkUnits(app, modelVersion, b) {
+			return true
 		}
 
 		resources, err := backend.ListPendingResources(app.Name())
 		if err != nil {
-			return errors.Annotate(err, "checking resources")
-		}
-		if len(resources) > 0 {
+			if b.add(errors.Annotate(err, "checking resources")) {
+				return true
+			}
+		} else if len(resources) > 0 {
 			resName := resources[0].Name
-			return errors.Errorf("resource %q is pending for application %s", resName, app.Name())
+			if b.add(errors.Errorf("resource %q is pending for application %s", resName, app.Name())) {
+				return true
+			}
 		}
 	}
+	return false
+}
+
+// checkCharm ensures that the application's charm is available to be
+// exported along with the model.
+func checkCharm(backend PrecheckBackend, app PrecheckApplication) error {
+	curl, _ := app.CharmURL()
+	if curl == nil {
+		return nil
+	}
+	ch, err := backend.Charm(curl)
+	if errors.IsNotFound(err) {
+		return errors.Errorf("charm %s for application %s not found", curl, app.Name())
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving charm %s", curl)
+	}
+	if !ch.IsUploaded() {
+		return errors.Errorf("charm %s for application %s is not uploaded", curl, app.Name())
+	}
 	return nil
 }
 
-func checkUnits(app PrecheckApplication, modelVersion version.Number) error {
+func checkUnits(app PrecheckApplication, modelVersion version.Number, b *blockers) bool {
 	units, err := app.AllUnits()
 	if err != nil {
-		return errors.Annotatef(err, "retrieving units for %s", app.Name())
+		return b.add(errors.Annotatef(err, "retrieving units for %s", app.Name()))
 	}
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if b.add(errors.Errorf("application %s is below its minimum units threshold", app.Name())) {
+			return true
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
-		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+		if b.add(checkUnit(unit, appCharmURL, modelVersion)) {
+			return true
 		}
+	}
+	return false
+}
 
-		if err := checkUnitAgentStatus(unit); err != nil {
-			return errors.Trace(err)
-		}
+func checkUnit(unit PrecheckUnit, appCharmURL *charm.URL, modelVersion version.Number) error {
+	if unit.Life() != state.Alive {
+		return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+	}
 
-		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
-			return errors.Trace(err)
-		}
+	if err := checkUnitAgentStatus(unit); err != nil {
+		return errors.Trace(err)
+	}
 
-		unitCharmURL, _ := unit.CharmURL()
-		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
-		}
+	if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+		return errors.Trace(err)
+	}
+
+	unitCharmURL, _ := unit.CharmURL()
+	if appCharmURL.String() != unitCharmURL.String() {
+		return errors.Errorf("unit %s is upgrading", unit.Name())
 	}
 	return nil
 }
@@ -383,3 +552,40 @@ func newStatusError(format, id string, s status.Status) error {
 	}
 	return errors.New(msg)
 }
+
+// blockers accumulates the problems found by the prechecks. Unless
+// all is set, checking stops at the first problem found.
+type blockers struct {
+	all    bool
+	prefix string
+	errs   []error
+	parent *blockers
+}
+
+// add records err, if non-nil, and reports whether the prechecks
+// should stop.
+func (b *blockers) add(err error) bool {
+	if err == nil {
+		return false
+	}
+	if b.parent != nil {
+		return b.parent.add(errors.Annotate(err, b.prefix))
+	}
+	b.errs = append(b.errs, err)
+	return !b.all
+}
+
+// annotated returns a blockers which records problems in b, prefixed
+// with the given message.
+func (b *blockers) annotated(prefix string) *blockers {
+	return &blockers{prefix: prefix, parent: b}
+}
+
+// first returns the first problem recorded, or nil if there were
+// none.
+func (b *blockers) first() error {
+	if len(b.errs) == 0 {
+		return nil
+	}
+	return b.errs[0]
+}
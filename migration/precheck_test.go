@@ -87,6 +87,57 @@ func (*SourcePrecheckSuite) TestPendingResourcesError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `checking resources: blam`)
 }
 
+func (*SourcePrecheckSuite) TestCharmNotFound(c *gc.C) {
+	backend := newHappyBackend()
+	backend.charmErr = errors.NotFoundf("charm")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm cs:foo-1 for application foo not found")
+}
+
+func (*SourcePrecheckSuite) TestCharmError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.charmErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving charm cs:foo-1: boom")
+}
+
+func (*SourcePrecheckSuite) TestCharmNotUploaded(c *gc.C) {
+	backend := newHappyBackend()
+	backend.charmNotUploaded = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm cs:foo-1 for application foo is not uploaded")
+}
+
+func (*SourcePrecheckSuite) TestAllSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	errs := migration.SourcePrecheckAll(backend)
+	c.Assert(errs, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestAllReportsEveryBlocker(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.model.life = state.Dying
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:  "foo",
+			units: []migration.PrecheckUnit{&fakeUnit{name: "foo/0", life: state.Dead}},
+		},
+	}
+	backend.charmNotUploaded = true
+	backend.cleanupNeeded = true
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+	errs := migration.SourcePrecheckAll(backend)
+	c.Assert(errorStrings(errs), jc.DeepEquals, []string{
+		"model is dying",
+		"machine 0 is dying",
+		"charm cs:foo-1 for application foo is not uploaded",
+		"unit foo/0 is dead",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
 func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.migrationMode = state.MigrationModeImporting
@@ -134,6 +185,23 @@ func (s *SourcePrecheckSuite) TestMachineVersionsDontMatch(c *gc.C) {
 	s.checkMachineVersionsDontMatch(c, sourcePrecheck)
 }
 
+func (s *SourcePrecheckSuite) TestMachineLockedForSeriesUpgrade(c *gc.C) {
+	backend := newBackendWithMachineLockedForSeriesUpgrade()
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 0 is upgrading its series")
+}
+
+func (s *SourcePrecheckSuite) TestMachineInMaintenance(c *gc.C) {
+	backend := &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0"},
+			&fakeMachine{id: "1", inMaintenance: true},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 1 is in maintenance")
+}
+
 func (s *SourcePrecheckSuite) TestDyingMachine(c *gc.C) {
 	backend := newBackendWithDyingMachine()
 	err := migration.SourcePrecheck(backend)
@@ -523,6 +591,91 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestCloudNotFound(c *gc.C) {
+	s.modelInfo.Cloud = "foo"
+	err := s.runPrecheck(newHappyBackend())
+	c.Assert(err, gc.ErrorMatches, `cloud "foo" not found in target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudRegionNotFound(c *gc.C) {
+	s.modelInfo.Cloud = "foo"
+	s.modelInfo.CloudRegion = "nowhere"
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{
+		"foo": {Regions: []cloud.Region{{Name: "somewhere"}}},
+	}
+	err := s.runPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `cloud "foo" in target controller has no region "nowhere"`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudAndRegionFound(c *gc.C) {
+	s.modelInfo.Cloud = "foo"
+	s.modelInfo.CloudRegion = "somewhere"
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{
+		"foo": {Regions: []cloud.Region{{Name: "somewhere"}}},
+	}
+	err := s.runPrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestLocalUserNotFound(c *gc.C) {
+	s.modelInfo.Users = []names.UserTag{
+		names.NewUserTag("bob"),
+		names.NewUserTag("mary@external"),
+	}
+	err := s.runPrecheck(newHappyBackend())
+	c.Assert(err, gc.ErrorMatches, `user bob not found in target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestLocalUserFound(c *gc.C) {
+	s.modelInfo.Users = []names.UserTag{names.NewUserTag("bob")}
+	backend := newHappyBackend()
+	backend.users = []names.UserTag{names.NewUserTag("bob")}
+	err := s.runPrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestUserError(c *gc.C) {
+	s.modelInfo.Users = []names.UserTag{names.NewUserTag("bob")}
+	backend := newHappyBackend()
+	backend.usersErr = errors.New("boom")
+	err := s.runPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `retrieving user bob: boom`)
+}
+
+func (s *TargetPrecheckSuite) TestAllReportsEveryBlocker(c *gc.C) {
+	s.modelInfo.Cloud = "foo"
+	s.modelInfo.Users = []names.UserTag{
+		names.NewUserTag("bob"),
+		names.NewUserTag("mary"),
+	}
+	backend := newBackendWithRebootingMachine()
+	backend.migrationActive = true
+	errs := migration.TargetPrecheckAll(backend, s.modelInfo)
+	c.Assert(errorStrings(errs), jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"machine 0 is scheduled to reboot",
+		`cloud "foo" not found in target controller`,
+		"user bob not found in target controller",
+		"user mary not found in target controller",
+	})
+}
+
+func (s *TargetPrecheckSuite) TestAllInvalidModelInfo(c *gc.C) {
+	s.modelInfo.UUID = ""
+	errs := migration.TargetPrecheckAll(newHappyBackend(), s.modelInfo)
+	c.Assert(errorStrings(errs), jc.DeepEquals, []string{"empty UUID not valid"})
+}
+
+func errorStrings(errs []error) []string {
+	out := make([]string, len(errs))
+	for i, err := range errs {
+		out[i] = err.Error()
+	}
+	return out
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -595,6 +748,15 @@ func newBackendWithDyingMachine() *fakeBackend {
 	}
 }
 
+func newBackendWithMachineLockedForSeriesUpgrade() *fakeBackend {
+	return &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", lockedForSeriesUpgrade: true},
+			&fakeMachine{id: "1"},
+		},
+	}
+}
+
 func newBackendWithDownMachine() *fakeBackend {
 	return &fakeBackend{
 		machines: []migration.PrecheckMachine{
@@ -655,6 +817,13 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	charmNotUploaded bool
+	charmErr         error
+
+	clouds   map[string]cloud.Cloud
+	users    []names.UserTag
+	usersErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -703,6 +872,32 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) Charm(curl *charm.URL) (migration.PrecheckCharm, error) {
+	if b.charmErr != nil {
+		return nil, b.charmErr
+	}
+	return &fakeCharm{uploaded: !b.charmNotUploaded}, nil
+}
+
+func (b *fakeBackend) Cloud(name string) (cloud.Cloud, error) {
+	if c, ok := b.clouds[name]; ok {
+		return c, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
+func (b *fakeBackend) HasUser(tag names.UserTag) (bool, error) {
+	if b.usersErr != nil {
+		return false, b.usersErr
+	}
+	for _, user := range b.users {
+		if user == tag {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackendCloser, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -754,6 +949,9 @@ type fakeMachine struct {
 	instanceStatus status.Status
 	lost           bool
 	rebootAction   state.RebootAction
+
+	lockedForSeriesUpgrade bool
+	inMaintenance          bool
 }
 
 func (m *fakeMachine) Id() string {
@@ -805,6 +1003,14 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 	return m.rebootAction, nil
 }
 
+func (m *fakeMachine) IsLockedForSeriesUpgrade() (bool, error) {
+	return m.lockedForSeriesUpgrade, nil
+}
+
+func (m *fakeMachine) InMaintenance() bool {
+	return m.inMaintenance
+}
+
 type fakeApp struct {
 	name     string
 	life     state.Life
@@ -837,6 +1043,14 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+type fakeCharm struct {
+	uploaded bool
+}
+
+func (ch *fakeCharm) IsUploaded() bool {
+	return ch.uploaded
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -33,6 +33,25 @@ const (
 	LoopbackIPv6CIDR = "::1/128"
 )
 
+// AddressSelector selects and sorts addresses for a model, ranking
+// the addresses of the model's preferred IP version ahead of those of
+// the other version with the same scope. The zero value prefers IPv4
+// addresses, as do the package level selection and sorting functions.
+type AddressSelector struct {
+	// PreferIPv6 is true when IPv6 addresses are preferred,
+	// from the model's "prefer-ipv6" config setting.
+	PreferIPv6 bool
+}
+
+// preferredType returns the type of IP address that is
+// preferred when both IPv4 and IPv6 addresses are available.
+func (s AddressSelector) preferredType() AddressType {
+	if s.PreferIPv6 {
+		return IPv6Address
+	}
+	return IPv4Address
+}
+
 func mustParseCIDR(s string) *net.IPNet {
 	_, net, err := net.ParseCIDR(s)
 	if err != nil {
@@ -143,13 +162,15 @@ func NewAddress(value string) Address {
 }
 
 // NewScopedAddress creates a new Address, deriving its type from the
-// value.
+// value. An IPv6 address enclosed in brackets, as in a host:port
+// string, is accepted and stored without them.
 //
 // If the specified scope is ScopeUnknown, then NewScopedAddress will
 // attempt derive the scope based on reserved IP address ranges.
 // Because passing ScopeUnknown is fairly common, NewAddress() above
 // does exactly that.
 func NewScopedAddress(value string, scope Scope) Address {
+	value = trimIPv6Brackets(value)
 	addr := Address{
 		Value: value,
 		Type:  DeriveAddressType(value),
@@ -189,6 +210,19 @@ func NewAddressesOnSpace(spaceName string, inAddresses ...string) (outAddresses
 	return outAddresses
 }
 
+// trimIPv6Brackets returns value without the enclosing brackets if it
+// is a bracketed IPv6 address, and unchanged otherwise.
+func trimIPv6Brackets(value string) string {
+	if len(value) < 2 || value[0] != '[' || value[len(value)-1] != ']' {
+		return value
+	}
+	inner := value[1 : len(value)-1]
+	if ip := net.ParseIP(inner); ip == nil || ip.To4() != nil {
+		return value
+	}
+	return inner
+}
+
 // DeriveAddressType attempts to detect the type of address given.
 func DeriveAddressType(value string) AddressType {
 	ip := net.ParseIP(value)
@@ -365,9 +399,15 @@ func SelectMongoHostPortsByScope(hostPorts []HostPort, machineLocal bool) []stri
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address is then ok is true.
 func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return AddressSelector{}.SelectPublicAddress(addresses)
+}
+
+// SelectPublicAddress is like the package level SelectPublicAddress,
+// but follows the selector's IP version preference.
+func (s AddressSelector) SelectPublicAddress(addresses []Address) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, s.publicMatch)
 	if index < 0 {
 		return Address{}, false
 	}
@@ -380,7 +420,7 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 func SelectPublicHostPort(hps []HostPort) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, publicMatch)
+	}, AddressSelector{}.publicMatch)
 	if index < 0 {
 		return ""
 	}
@@ -392,9 +432,15 @@ func SelectPublicHostPort(hps []HostPort) string {
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address was found then ok is true.
 func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	return AddressSelector{}.SelectInternalAddress(addresses, machineLocal)
+}
+
+// SelectInternalAddress is like the package level SelectInternalAddress,
+// but follows the selector's IP version preference.
+func (s AddressSelector) SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, s.internalAddressMatcher(machineLocal))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -408,7 +454,7 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, AddressSelector{}.internalAddressMatcher(machineLocal))
 	if index < 0 {
 		return ""
 	}
@@ -422,7 +468,7 @@ func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := bestAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, AddressSelector{}.internalAddressMatcher(machineLocal))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -438,7 +484,7 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, AddressSelector{}.internalAddressMatcher(machineLocal))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -447,62 +493,62 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
-func publicMatch(addr Address) scopeMatch {
+func (s AddressSelector) publicMatch(addr Address) scopeMatch {
 	switch addr.Scope {
 	case ScopePublic:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+		if addr.Type == s.preferredType() {
+			return exactScopePreferred
 		}
 		return exactScope
 	case ScopeCloudLocal, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
+		if addr.Type == s.preferredType() {
+			return fallbackScopePreferred
 		}
 		return fallbackScope
 	}
 	return invalidScope
 }
 
-func internalAddressMatcher(machineLocal bool) scopeMatchFunc {
+func (s AddressSelector) internalAddressMatcher(machineLocal bool) scopeMatchFunc {
 	if machineLocal {
-		return cloudOrMachineLocalMatch
+		return s.cloudOrMachineLocalMatch
 	}
-	return cloudLocalMatch
+	return s.cloudLocalMatch
 }
 
-func cloudLocalMatch(addr Address) scopeMatch {
+func (s AddressSelector) cloudLocalMatch(addr Address) scopeMatch {
 	switch addr.Scope {
 	case ScopeCloudLocal:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+		if addr.Type == s.preferredType() {
+			return exactScopePreferred
 		}
 		return exactScope
 	case ScopePublic, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
+		if addr.Type == s.preferredType() {
+			return fallbackScopePreferred
 		}
 		return fallbackScope
 	}
 	return invalidScope
 }
 
-func cloudOrMachineLocalMatch(addr Address) scopeMatch {
+func (s AddressSelector) cloudOrMachineLocalMatch(addr Address) scopeMatch {
 	if addr.Scope == ScopeMachineLocal {
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+		if addr.Type == s.preferredType() {
+			return exactScopePreferred
 		}
 		return exactScope
 	}
-	return cloudLocalMatch(addr)
+	return s.cloudLocalMatch(addr)
 }
 
 type scopeMatch int
 
 const (
 	invalidScope scopeMatch = iota
-	exactScopeIPv4
+	exactScopePreferred
 	exactScope
-	fallbackScopeIPv4
+	fallbackScopePreferred
 	fallbackScope
 )
 
@@ -529,7 +575,7 @@ func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc s
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
 		if ok && len(indexes) > 0 {
@@ -544,7 +590,7 @@ func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matc
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	var prioritized []int
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
@@ -561,7 +607,7 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 	for i := 0; i < numAddr; i++ {
 		matchType := matchFunc(getAddrFunc(i))
 		switch matchType {
-		case exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope:
+		case exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope:
 			matches[matchType] = append(matches[matchType], i)
 		}
 	}
@@ -575,7 +621,8 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - machine-local next;
 // - link-local next;
 // - non-hostnames with unknown scope last.
-func (a Address) sortOrder() int {
+// Within each scope, addresses of the preferred IP version come first.
+func (a Address) sortOrder(preferredType AddressType) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -593,23 +640,29 @@ func (a Address) sortOrder() int {
 		if a.Value == "localhost" {
 			order++
 		}
-	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
-	case IPv4Address:
+	case IPv4Address, IPv6Address:
+		// Prefer addresses of the preferred IP version.
+		if a.Type != preferredType {
+			order++
+		}
 	}
 	return order
 }
 
-type addressesPreferringIPv4Slice []Address
+type addressesByPreferenceSlice struct {
+	addrs         []Address
+	preferredType AddressType
+}
 
-func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
-func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesByPreferenceSlice) Len() int { return len(a.addrs) }
+func (a addressesByPreferenceSlice) Swap(i, j int) {
+	a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i]
+}
+func (a addressesByPreferenceSlice) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := addr1.sortOrder(a.preferredType)
+	order2 := addr2.sortOrder(a.preferredType)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -619,7 +672,13 @@ func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 // SortAddresses sorts the given Address slice according to the sortOrder of
 // each address. See Address.sortOrder() for more info.
 func SortAddresses(addrs []Address) {
-	sort.Sort(addressesPreferringIPv4Slice(addrs))
+	AddressSelector{}.SortAddresses(addrs)
+}
+
+// SortAddresses is like the package level SortAddresses,
+// but follows the selector's IP version preference.
+func (s AddressSelector) SortAddresses(addrs []Address) {
+	sort.Sort(addressesByPreferenceSlice{addrs, s.preferredType()})
 }
 
 // DecimalToIPv4 converts a decimal to the dotted quad IP address format.
@@ -244,6 +244,18 @@ func (s *AddressSuite) TestNewScopedAddressHostname(c *gc.C) {
 	c.Check(addr.Scope, gc.Equals, network.ScopeUnknown)
 }
 
+func (s *AddressSuite) TestNewAddressBracketedIPv6(c *gc.C) {
+	addr := network.NewAddress("[2001:db8::1]")
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+	c.Check(addr.Type, gc.Equals, network.IPv6Address)
+	c.Check(addr.Scope, gc.Equals, network.ScopePublic)
+
+	// Brackets are only removed from IPv6 addresses.
+	addr = network.NewAddress("[10.0.0.1]")
+	c.Check(addr.Value, gc.Equals, "[10.0.0.1]")
+	c.Check(addr.Type, gc.Equals, network.HostName)
+}
+
 type selectTest struct {
 	about         string
 	addresses     []network.Address
@@ -511,6 +523,97 @@ var selectInternalMachineTests = []selectTest{{
 	2,
 }}
 
+var selectIPv6Tests = []struct {
+	selectTest
+	preferIPv6       bool
+	public, internal int
+}{{
+	selectTest: selectTest{
+		about: "IPv6-only public and cloud local addresses",
+		addresses: []network.Address{
+			network.NewScopedAddress("fe80::1", network.ScopeLinkLocal),
+			network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+			network.NewScopedAddress("fd00::1", network.ScopeCloudLocal),
+		},
+	},
+	public:   1,
+	internal: 2,
+}, {
+	selectTest: selectTest{
+		about: "IPv6-only public addresses",
+		addresses: []network.Address{
+			network.NewScopedAddress("::1", network.ScopeMachineLocal),
+			network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+			network.NewScopedAddress("2001:db8::2", network.ScopePublic),
+		},
+	},
+	public:   1,
+	internal: 1,
+}, {
+	selectTest: selectTest{
+		about: "dual-stack addresses prefer IPv4 by default",
+		addresses: []network.Address{
+			network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+			network.NewScopedAddress("fd00::1", network.ScopeCloudLocal),
+			network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+			network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		},
+	},
+	public:   2,
+	internal: 3,
+}, {
+	selectTest: selectTest{
+		about: "dual-stack addresses prefer IPv6 when asked to",
+		addresses: []network.Address{
+			network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+			network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+			network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+			network.NewScopedAddress("fd00::1", network.ScopeCloudLocal),
+		},
+	},
+	preferIPv6: true,
+	public:     2,
+	internal:   3,
+}, {
+	selectTest: selectTest{
+		about: "IPv4 is used when IPv6 is preferred but unavailable",
+		addresses: []network.Address{
+			network.NewScopedAddress("fe80::1", network.ScopeLinkLocal),
+			network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+			network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		},
+	},
+	preferIPv6: true,
+	public:     1,
+	internal:   2,
+}, {
+	selectTest: selectTest{
+		about: "scope matters more than the preferred IP version",
+		addresses: []network.Address{
+			network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+			network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		},
+	},
+	preferIPv6: true,
+	public:     1,
+	internal:   0,
+}}
+
+func (s *AddressSuite) TestSelectAddressesIPv6(c *gc.C) {
+	for i, t := range selectIPv6Tests {
+		c.Logf("test %d: %s", i, t.about)
+		selector := network.AddressSelector{PreferIPv6: t.preferIPv6}
+
+		addr, ok := selector.SelectPublicAddress(t.addresses)
+		c.Check(ok, jc.IsTrue)
+		c.Check(addr, gc.Equals, t.addresses[t.public])
+
+		addr, ok = selector.SelectInternalAddress(t.addresses, false)
+		c.Check(ok, jc.IsTrue)
+		c.Check(addr, gc.Equals, t.addresses[t.internal])
+	}
+}
+
 func (s *AddressSuite) TestSelectInternalMachineAddress(c *gc.C) {
 	for i, t := range selectInternalMachineTests {
 		c.Logf("test %d: %s", i, t.about)
@@ -734,6 +837,28 @@ func (*AddressSuite) TestSortAddresses(c *gc.C) {
 	))
 }
 
+func (s *AddressSuite) TestSortAddressesPreferIPv6(c *gc.C) {
+	addrs := network.NewAddresses(
+		"127.0.0.1",
+		"::1",
+		"fc00::1",
+		"2001:db8::1",
+		"example.com",
+		"172.16.0.1",
+		"8.8.8.8",
+	)
+	network.AddressSelector{PreferIPv6: true}.SortAddresses(addrs)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(
+		"2001:db8::1",
+		"8.8.8.8",
+		"example.com",
+		"fc00::1",
+		"172.16.0.1",
+		"::1",
+		"127.0.0.1",
+	))
+}
+
 func (*AddressSuite) TestIPv4ToDecimal(c *gc.C) {
 	zeroIP, err := network.IPv4ToDecimal(net.ParseIP("0.0.0.0"))
 	c.Assert(err, jc.ErrorIsNil)
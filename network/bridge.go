@@ -5,11 +5,16 @@ package network
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/network/debinterfaces"
+	"github.com/juju/juju/network/netplan"
 	"github.com/juju/utils/clock"
+	jujuos "github.com/juju/utils/os"
+	"github.com/juju/utils/series"
 )
 
 // Bridger creates network bridges to support addressable containers.
@@ -87,3 +92,96 @@ func newEtcNetworkInterfacesBridger(clock clock.Clock, timeout time.Duration, fi
 func DefaultEtcNetworkInterfacesBridger(timeout time.Duration, filename string) (Bridger, error) {
 	return newEtcNetworkInterfacesBridger(clock.WallClock, timeout, filename, false), nil
 }
+
+type netplanBridger struct {
+	Clock     clock.Clock
+	DryRun    bool
+	Directory string
+	Timeout   time.Duration
+}
+
+var _ Bridger = (*netplanBridger)(nil)
+
+// Bridge is part of the Bridger interface. The reconfigureDelay is not
+// needed, as netplan reconfigures all the devices at once.
+func (b *netplanBridger) Bridge(devices []DeviceToBridge, reconfigureDelay int) error {
+	devicesMap := make(map[string]string)
+	for _, k := range devices {
+		devicesMap[k.DeviceName] = k.BridgeName
+	}
+	params := netplan.ActivationParams{
+		Clock:     b.Clock,
+		Devices:   devicesMap,
+		Directory: b.Directory,
+		DryRun:    b.DryRun,
+		Timeout:   b.Timeout,
+	}
+
+	result, err := netplan.BridgeAndActivate(params)
+	if err != nil {
+		return errors.Errorf("bridge activation error: %s", err)
+	}
+	if result != nil {
+		logger.Infof("netplan result=%v", result.Code)
+		logger.Tracef("netplan stdout\n%s\n", result.Stdout)
+		logger.Tracef("netplan stderr\n%s\n", result.Stderr)
+	} else {
+		logger.Infof("netplan configuration unchanged")
+	}
+	return nil
+}
+
+func newNetplanBridger(clock clock.Clock, timeout time.Duration, directory string, dryRun bool) Bridger {
+	return &netplanBridger{
+		Clock:     clock,
+		DryRun:    dryRun,
+		Directory: directory,
+		Timeout:   timeout,
+	}
+}
+
+// DefaultNetplanBridger returns a Bridger instance that can parse the
+// netplan configuration in directory to transform existing devices into
+// bridged devices.
+func DefaultNetplanBridger(timeout time.Duration, directory string) (Bridger, error) {
+	return newNetplanBridger(clock.WallClock, timeout, directory, false), nil
+}
+
+// netplanFirstVersion is the first Ubuntu release configuring its
+// network with netplan rather than ifupdown.
+var netplanFirstVersion = [2]int{17, 10}
+
+// UsesNetplan reports whether machines of the given series configure
+// their network with netplan, rather than with interfaces(5).
+func UsesNetplan(seriesName string) bool {
+	seriesOS, err := series.GetOSFromSeries(seriesName)
+	if err != nil || seriesOS != jujuos.Ubuntu {
+		return false
+	}
+	version, err := series.SeriesVersion(seriesName)
+	if err != nil {
+		return false
+	}
+	return isNetplanVersion(version)
+}
+
+// isNetplanVersion reports whether the given Ubuntu version, such as
+// "16.04", configures its network with netplan.
+func isNetplanVersion(version string) bool {
+	parts := strings.SplitN(version, ".", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	if major != netplanFirstVersion[0] {
+		return major > netplanFirstVersion[0]
+	}
+	return minor >= netplanFirstVersion[1]
+}
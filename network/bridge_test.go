@@ -4,11 +4,14 @@
 package network_test
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"time"
 
 	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
@@ -106,3 +109,57 @@ func (*BridgeSuite) TestENIBridgerWithDryRun(c *gc.C) {
 	err := bridger.Bridge(devices, 0)
 	c.Assert(err, gc.IsNil)
 }
+
+func (*BridgeSuite) TestNetplanBridgerWithDryRun(c *gc.C) {
+	dir := c.MkDir()
+	content := `
+network:
+  version: 2
+  ethernets:
+    ens123:
+      dhcp4: true
+`[1:]
+	err := ioutil.WriteFile(filepath.Join(dir, "50-cloud-init.yaml"), []byte(content), 0644)
+	c.Assert(err, gc.IsNil)
+	devices := []network.DeviceToBridge{{
+		DeviceName: "ens123",
+		BridgeName: "br-ens123",
+	}}
+	bridger := network.NewNetplanBridger(clock.WallClock, 0, dir, true)
+	err = bridger.Bridge(devices, 0)
+	c.Assert(err, gc.IsNil)
+	_, err = os.Stat(filepath.Join(dir, "99-juju.yaml"))
+	c.Assert(err, gc.IsNil)
+}
+
+func (*BridgeSuite) TestNetplanBridgerWithMissingDevice(c *gc.C) {
+	devices := []network.DeviceToBridge{{
+		DeviceName: "ens123",
+		BridgeName: "br-ens123",
+	}}
+	bridger := network.NewNetplanBridger(clock.WallClock, 0, c.MkDir(), true)
+	err := bridger.Bridge(devices, 0)
+	c.Assert(err, gc.ErrorMatches, `bridge activation error: device "ens123" in netplan configuration not found`)
+}
+
+func (*BridgeSuite) TestUsesNetplan(c *gc.C) {
+	for _, seriesName := range []string{"trusty", "xenial", "centos7", "win2012", "unknown"} {
+		c.Check(network.UsesNetplan(seriesName), jc.IsFalse, gc.Commentf("series %q", seriesName))
+	}
+}
+
+func (*BridgeSuite) TestIsNetplanVersion(c *gc.C) {
+	for version, expected := range map[string]bool{
+		"14.04": false,
+		"16.04": false,
+		"17.04": false,
+		"17.10": true,
+		"18.04": true,
+		"20.04": true,
+		"":      false,
+		"18":    false,
+		"x.y":   false,
+	} {
+		c.Check(network.IsNetplanVersion(version), gc.Equals, expected, gc.Commentf("version %q", version))
+	}
+}
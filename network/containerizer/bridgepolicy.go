@@ -34,6 +34,17 @@ type BridgePolicy struct {
 	// UseLocalBridges decides if we should use local-only bridges ("lxdbr0", "virbr0"),
 	// to handle unnamed space requests.
 	UseLocalBridges bool
+	// UseMacvlan decides if LXD containers should be attached directly to
+	// the host machine's devices with macvlan, rather than to bridges. When
+	// set, host devices are never bridged for LXD containers. Other
+	// container types are always bridged.
+	UseMacvlan bool
+}
+
+// useMacvlan reports whether the given container should be attached to the
+// host machine's devices with macvlan.
+func (p *BridgePolicy) useMacvlan(containerMachine Container) bool {
+	return p.UseMacvlan && containerMachine.ContainerType() == instance.LXD
 }
 
 // Machine describes either a host machine, or a container machine. Either way
@@ -53,6 +64,7 @@ type Container interface {
 	Machine
 	ContainerType() instance.ContainerType
 	DesiredSpaces() (set.Strings, error)
+	AllLinkLayerDevices() ([]*state.LinkLayerDevice, error)
 	SetLinkLayerDevices(...state.LinkLayerDeviceArgs) error
 }
 
@@ -157,6 +169,79 @@ func possibleBridgeTarget(dev *state.LinkLayerDevice) (bool, error) {
 	return false, nil
 }
 
+// possibleMacvlanParent reports whether a container device can be attached
+// to the given host device with macvlan. Besides any device that could be
+// bridged, existing bridges other than the local-only ones can be used.
+func possibleMacvlanParent(dev *state.LinkLayerDevice) (bool, error) {
+	if dev.Type() == state.BridgeDevice {
+		return !skippedDeviceNames.Contains(dev.Name()), nil
+	}
+	return possibleBridgeTarget(dev)
+}
+
+// findMacvlanParentsForContainer returns the host machine devices that the
+// container's devices should be attached to with macvlan, naturally sorted
+// by name. A single device is used for each space the container wants to be
+// in, except for the unknown space, where all usable devices are returned.
+// This will return an Error if the container wants a space that the host
+// machine cannot provide.
+func (p *BridgePolicy) findMacvlanParentsForContainer(m Machine, containerMachine Container) ([]*state.LinkLayerDevice, error) {
+	containerSpaces, devicesPerSpace, err := p.findSpacesAndDevicesForContainer(m, containerMachine)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("findMacvlanParentsForContainer(%q) spaces %s devices %v",
+		containerMachine.Id(), network.QuoteSpaceSet(containerSpaces),
+		formatDeviceMap(devicesPerSpace))
+	spacesFound := set.NewStrings()
+	parentsByName := make(map[string]*state.LinkLayerDevice)
+	for _, spaceName := range containerSpaces.Values() {
+		deviceNames := make([]string, 0)
+		deviceByName := make(map[string]*state.LinkLayerDevice)
+		for _, hostDevice := range devicesPerSpace[spaceName] {
+			possible, err := possibleMacvlanParent(hostDevice)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !possible {
+				continue
+			}
+			deviceNames = append(deviceNames, hostDevice.Name())
+			deviceByName[hostDevice.Name()] = hostDevice
+		}
+		if len(deviceNames) == 0 {
+			continue
+		}
+		spacesFound.Add(spaceName)
+		deviceNames = network.NaturallySortDeviceNames(deviceNames...)
+		if spaceName != "" {
+			// As when bridging, we stably pick a single device for a
+			// known space, but use all of them for the unknown space.
+			deviceNames = deviceNames[:1]
+		}
+		for _, name := range deviceNames {
+			parentsByName[name] = deviceByName[name]
+		}
+	}
+	notFound := containerSpaces.Difference(spacesFound)
+	if !notFound.IsEmpty() {
+		logger.Warningf("container %q wants spaces %s, but host machine %q has no macvlan parent for %s",
+			containerMachine.Id(), network.QuoteSpaceSet(containerSpaces),
+			m.Id(), network.QuoteSpaceSet(notFound))
+		return nil, errors.Errorf("host machine %q has no available device in space(s) %s",
+			m.Id(), network.QuoteSpaceSet(notFound))
+	}
+	parentNames := make([]string, 0, len(parentsByName))
+	for name := range parentsByName {
+		parentNames = append(parentNames, name)
+	}
+	parents := make([]*state.LinkLayerDevice, 0, len(parentNames))
+	for _, name := range network.NaturallySortDeviceNames(parentNames...) {
+		parents = append(parents, parentsByName[name])
+	}
+	return parents, nil
+}
+
 func formatDeviceMap(spacesToDevices map[string][]*state.LinkLayerDevice) string {
 	spaceNames := make([]string, len(spacesToDevices))
 	i := 0
@@ -213,7 +298,15 @@ func BridgeNameForDevice(device string) string {
 // bridged.
 // This will return an Error if the container wants a space that the host
 // machine cannot provide.
+// When using macvlan, no bridges are ever missing, but the host machine
+// must still have a device in each of the container's spaces.
 func (b *BridgePolicy) FindMissingBridgesForContainer(m Machine, containerMachine Container) ([]network.DeviceToBridge, int, error) {
+	if b.useMacvlan(containerMachine) {
+		if _, err := b.findMacvlanParentsForContainer(m, containerMachine); err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		return nil, 0, nil
+	}
 	reconfigureDelay := 0
 	containerSpaces, devicesPerSpace, err := b.findSpacesAndDevicesForContainer(m, containerMachine)
 	if err != nil {
@@ -310,7 +403,15 @@ func (b *BridgePolicy) FindMissingBridgesForContainer(m Machine, containerMachin
 // BridgeDevice of the host machine. It also records when one of the
 // desired spaces is available on the host machine, but not currently
 // bridged.
+// When using macvlan, each device is linked directly to a host device in
+// the corresponding space instead.
+// Devices the container already has are kept, so that a container which
+// is already running can be given devices for spaces it has been newly
+// asked to join.
 func (p *BridgePolicy) PopulateContainerLinkLayerDevices(m Machine, containerMachine Container) error {
+	if p.useMacvlan(containerMachine) {
+		return p.populateContainerMacvlanDevices(m, containerMachine)
+	}
 	// TODO(jam): 20017-01-31 This doesn't quite feel right that we would be
 	// defining devices that 'will' exist in the container, but don't exist
 	// yet. If anything, this feels more like "Provider" level devices, because
@@ -365,15 +466,67 @@ func (p *BridgePolicy) PopulateContainerLinkLayerDevices(m Machine, containerMac
 	sortedBridgeDeviceNames := network.NaturallySortDeviceNames(bridgeDeviceNames...)
 	logger.Debugf("for container %q using host machine %q bridge devices: %s",
 		containerMachine.Id(), m.Id(), network.QuoteSpaces(sortedBridgeDeviceNames))
-	containerDevicesArgs := make([]state.LinkLayerDeviceArgs, len(bridgeDeviceNames))
-
+	hostBridges := make([]*state.LinkLayerDevice, len(sortedBridgeDeviceNames))
 	for i, hostBridgeName := range sortedBridgeDeviceNames {
-		hostBridge := devicesByName[hostBridgeName]
-		newLLD, err := state.DefineEthernetDeviceOnBridge(fmt.Sprintf("eth%d", i), hostBridge)
+		hostBridges[i] = devicesByName[hostBridgeName]
+	}
+	return errors.Trace(setContainerDevices(containerMachine, hostBridges, state.DefineEthernetDeviceOnBridge))
+}
+
+// populateContainerMacvlanDevices sets the link-layer devices of the given
+// containerMachine, setting each device linked directly to a host machine
+// device, as used by macvlan.
+func (p *BridgePolicy) populateContainerMacvlanDevices(m Machine, containerMachine Container) error {
+	parents, err := p.findMacvlanParentsForContainer(m, containerMachine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(setContainerDevices(containerMachine, parents, state.DefineEthernetDeviceOnParent))
+}
+
+// setContainerDevices sets a device of the container linked to each of the
+// given host devices the container has no device linked to yet, defining
+// it with the given function. New devices are named after the lowest
+// "ethN" names the container does not use.
+func setContainerDevices(
+	containerMachine Container,
+	hostDevices []*state.LinkLayerDevice,
+	define func(string, *state.LinkLayerDevice) (state.LinkLayerDeviceArgs, error),
+) error {
+	existing, err := containerMachine.AllLinkLayerDevices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	usedNames := set.NewStrings()
+	linked := set.NewStrings()
+	for _, device := range existing {
+		usedNames.Add(device.Name())
+		parentName, parentMachineID := device.ParentNameAndMachineID()
+		if parentName != "" {
+			linked.Add(parentMachineID + ":" + parentName)
+		}
+	}
+
+	var containerDevicesArgs []state.LinkLayerDeviceArgs
+	index := 0
+	for _, hostDevice := range hostDevices {
+		if linked.Contains(hostDevice.MachineID() + ":" + hostDevice.Name()) {
+			continue
+		}
+		for usedNames.Contains(fmt.Sprintf("eth%d", index)) {
+			index++
+		}
+		name := fmt.Sprintf("eth%d", index)
+		usedNames.Add(name)
+		newLLD, err := define(name, hostDevice)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		containerDevicesArgs[i] = newLLD
+		containerDevicesArgs = append(containerDevicesArgs, newLLD)
+	}
+	if len(containerDevicesArgs) == 0 {
+		logger.Debugf("container %q network config is up to date", containerMachine.Id())
+		return nil
 	}
 	logger.Debugf("prepared container %q network config: %+v", containerMachine.Id(), containerDevicesArgs)
 
@@ -369,6 +369,39 @@ func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesUnitBindin
 	c.Check(containerDevice.ParentName(), gc.Equals, `m#0#d#br-ens33`)
 }
 
+func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesKeepsExistingDevices(c *gc.C) {
+	s.setupMachineInTwoSpaces(c)
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"dmz"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	containerDevices, err := s.containerMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containerDevices, gc.HasLen, 1)
+	macAddress := containerDevices[0].MACAddress()
+
+	// Asking for another space adds a device for it, leaving the
+	// existing one as it is.
+	err = s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"dmz", "default"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	containerDevices, err = s.containerMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containerDevices, gc.HasLen, 2)
+	c.Check(containerDevices[0].Name(), gc.Equals, "eth0")
+	c.Check(containerDevices[0].MACAddress(), gc.Equals, macAddress)
+	c.Check(containerDevices[0].ParentName(), gc.Equals, `m#0#d#br-ens0p10`)
+	c.Check(containerDevices[1].Name(), gc.Equals, "eth1")
+	c.Check(containerDevices[1].ParentName(), gc.Equals, `m#0#d#br-ens33`)
+}
+
 func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesHostOneSpace(c *gc.C) {
 	s.setupTwoSpaces(c)
 	// Is put into the 'default' space
@@ -1088,6 +1121,105 @@ func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerVLANOnBond(c
 	c.Check(reconfigureDelay, gc.Equals, 13)
 }
 
+func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerMacvlan(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"default"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.bridgePolicy.UseMacvlan = true
+	missing, reconfigureDelay, err := s.bridgePolicy.FindMissingBridgesForContainer(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(missing, gc.HasLen, 0)
+	c.Check(reconfigureDelay, gc.Equals, 0)
+}
+
+func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerMacvlanNoHostDevices(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"dmz"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.bridgePolicy.UseMacvlan = true
+	_, _, err = s.bridgePolicy.FindMissingBridgesForContainer(s.machine, s.containerMachine)
+	c.Assert(err, gc.ErrorMatches, `host machine "0" has no available device in space\(s\) "dmz"`)
+}
+
+func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesMacvlan(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	s.createNICWithIP(c, s.machine, "eth1", "10.10.0.20/24")
+	s.createAllDefaultDevices(c, s.machine)
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"default", "dmz"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.bridgePolicy.UseMacvlan = true
+
+	err = s.bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	containerDevices, err := s.containerMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containerDevices, gc.HasLen, 2)
+	parentNames := make(map[string]string)
+	for _, device := range containerDevices {
+		c.Check(device.Type(), gc.Equals, state.EthernetDevice)
+		c.Check(device.MACAddress(), gc.Matches, "00:16:3e(:[0-9a-f]{2}){3}")
+		parentNames[device.Name()] = device.ParentName()
+	}
+	// The host devices are used directly, without bridging them.
+	c.Check(parentNames, jc.DeepEquals, map[string]string{
+		"eth0": "m#0#d#eth0",
+		"eth1": "m#0#d#eth1",
+	})
+	s.assertAllLinkLayerDevicesOnMachineMatchCount(c, s.machine, 6)
+}
+
+func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesMacvlanUsesExistingBridge(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICAndBridgeWithIP(c, s.machine, "eth0", "br-eth0", "10.0.0.20/24")
+	s.addContainerMachine(c)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"default"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.bridgePolicy.UseMacvlan = true
+
+	err = s.bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	containerDevices, err := s.containerMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containerDevices, gc.HasLen, 1)
+	c.Check(containerDevices[0].ParentName(), gc.Equals, "m#0#d#br-eth0")
+}
+
+func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerMacvlanKVM(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "eth0", "10.0.0.20/24")
+	containerTemplate := state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("spaces=default"),
+	}
+	kvmContainer, err := s.State.AddMachineInsideMachine(containerTemplate, s.machine.Id(), instance.KVM)
+	c.Assert(err, jc.ErrorIsNil)
+	s.bridgePolicy.UseMacvlan = true
+	// Macvlan is only used for LXD containers, so KVM ones are still bridged.
+	missing, _, err := s.bridgePolicy.FindMissingBridgesForContainer(s.machine, kvmContainer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(missing, gc.DeepEquals, []network.DeviceToBridge{{
+		DeviceName: "eth0",
+		BridgeName: "br-eth0",
+	}})
+}
+
 var bridgeNames = map[string]string{
 	"eno0":            "br-eno0",
 	"twelvechars0":    "br-twelvechars0",
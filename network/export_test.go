@@ -8,4 +8,6 @@ var (
 	NetListen                      = &netListen
 	RunCommand                     = runCommand
 	NewEtcNetworkInterfacesBridger = newEtcNetworkInterfacesBridger
+	NewNetplanBridger              = newNetplanBridger
+	IsNetplanVersion               = isNetplanVersion
 )
@@ -72,3 +72,51 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("udp", 5000, 5010, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "5000-5010/udp to 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 53, 53)
+	rule2 := network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")
+	rule3 := network.MustNewEgressRule("tcp", 443, 443)
+	rule4 := network.MustNewEgressRule("tcp", 80, 80)
+
+	rules := []network.EgressRule{rule1, rule2, rule3, rule4}
+	network.SortEgressRules(rules)
+	c.Assert(rules, gc.DeepEquals, []network.EgressRule{rule4, rule3, rule2, rule1})
+}
+
+func (*FirewallSuite) TestNewEgressRuleBadCIDR(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 443, 443, "10.0/8")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 10.0/8")
+}
+
+func (*FirewallSuite) TestIPv4IngressRules(c *gc.C) {
+	rules := network.IPv4IngressRules([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8", "2001:db8::/32"),
+		network.MustNewIngressRule("tcp", 80, 80, "::/0"),
+		{PortRange: network.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"}},
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+		{PortRange: network.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"}},
+	})
+}
+
+func (*FirewallSuite) TestIPv4CIDRs(c *gc.C) {
+	cidrs := network.IPv4CIDRs([]string{"0.0.0.0/0", "::/0", "192.168.1.0/24", "bad"})
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0", "192.168.1.0/24"})
+}
+
+func (*FirewallSuite) TestIsIPv6CIDR(c *gc.C) {
+	c.Assert(network.IsIPv6CIDR("2001:db8::/32"), jc.IsTrue)
+	c.Assert(network.IsIPv6CIDR("10.0.0.0/8"), jc.IsFalse)
+	c.Assert(network.IsIPv6CIDR("bad"), jc.IsFalse)
+}
@@ -38,8 +38,8 @@ func (hp HostPort) GoString() string {
 // Less reports whether hp1 is ordered before hp2
 // according to the criteria used by SortHostPorts.
 func (hp1 HostPort) Less(hp2 HostPort) bool {
-	order1 := hp1.sortOrder()
-	order2 := hp2.sortOrder()
+	order1 := hp1.sortOrder(IPv4Address)
+	order2 := hp2.sortOrder(IPv4Address)
 	if order1 == order2 {
 		if hp1.Address.Value == hp2.Address.Value {
 			return hp1.Port < hp2.Port
@@ -207,6 +207,22 @@ func (*HostPortSuite) TestNewHostPorts(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (*HostPortSuite) TestNewHostPortsBracketedIPv6(c *gc.C) {
+	hps := network.NewHostPorts(17070, "[2001:db8::1]", "[fd00::1]")
+	c.Assert(hps, jc.DeepEquals, network.NewHostPorts(17070, "2001:db8::1", "fd00::1"))
+	c.Assert(network.HostPortsToStrings(hps), jc.DeepEquals, []string{
+		"[2001:db8::1]:17070",
+		"[fd00::1]:17070",
+	})
+
+	parsed, err := network.ParseHostPorts(network.HostPortsToStrings(hps)...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, hps)
+
+	// Bracketed addresses are not mistaken for hostnames.
+	c.Assert(network.ResolveOrDropHostnames(hps), jc.DeepEquals, hps)
+}
+
 func (*HostPortSuite) TestParseHostPortsErrors(c *gc.C) {
 	for i, test := range []struct {
 		input string
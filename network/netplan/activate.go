@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package netplan
+
+import (
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+)
+
+var logger = loggo.GetLogger("juju.network.netplan")
+
+// activationCmd generates and applies the netplan configuration. When
+// DRYRUN is set, the commands are echoed instead of being run.
+var activationCmd = `
+: ${DRYRUN:=}
+${DRYRUN} netplan generate
+${DRYRUN} netplan apply
+`[1:]
+
+// ActivationParams contains options to use when bridging interfaces
+type ActivationParams struct {
+	Clock clock.Clock
+	// map deviceName -> bridgeName
+	Devices   map[string]string
+	Directory string
+	DryRun    bool
+	Timeout   time.Duration
+}
+
+// ActivationResult captures the result of actively bridging the
+// interfaces using netplan.
+type ActivationResult struct {
+	Stdout []byte
+	Stderr []byte
+	Code   int
+}
+
+// BridgeAndActivate will read the netplan configuration in the params
+// directory, change the definitions of the requested devices to be
+// bridged, write the result to a single file in place of the original
+// ones, then reconfigure the network using netplan for the new bridges.
+// The original files are restored if netplan fails.
+func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
+	if len(params.Devices) == 0 {
+		return nil, errors.Errorf("no devices specified")
+	}
+
+	np, err := ReadDirectory(params.Directory)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	origContent, err := Marshal(np)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := np.Bridge(params.Devices); err != nil {
+		return nil, errors.Trace(err)
+	}
+	bridgedContent, err := Marshal(np)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if string(origContent) == string(bridgedContent) {
+		return nil, nil // nothing to do; old == new.
+	}
+
+	if err := np.MoveYamlsToBak(); err != nil {
+		rollback(np)
+		return nil, errors.Trace(err)
+	}
+	if _, err := np.Write(""); err != nil {
+		rollback(np)
+		return nil, errors.Trace(err)
+	}
+
+	environ := os.Environ()
+	if params.DryRun {
+		environ = append(environ, "DRYRUN=echo")
+	}
+	result, err := runCommand(activationCmd, environ, params.Clock, params.Timeout)
+
+	if err != nil {
+		rollback(np)
+		if result == nil {
+			return nil, errors.Errorf("bridge activation error: %s", err)
+		}
+		return &ActivationResult{
+			Stderr: result.Stderr,
+			Stdout: result.Stdout,
+			Code:   result.Code,
+		}, errors.Errorf("bridge activation error: %s", err)
+	}
+
+	activationResult := ActivationResult{
+		Stderr: result.Stderr,
+		Stdout: result.Stdout,
+		Code:   result.Code,
+	}
+
+	logger.Infof("bridge activation result=%v", result.Code)
+
+	if result.Code != 0 {
+		logger.Errorf("bridge activation stdout\n%s\n", result.Stdout)
+		logger.Errorf("bridge activation stderr\n%s\n", result.Stderr)
+		rollback(np)
+		return &activationResult, errors.Errorf("bridge activation failed: %s", string(result.Stderr))
+	}
+
+	logger.Tracef("bridge activation stdout\n%s\n", result.Stdout)
+	logger.Tracef("bridge activation stderr\n%s\n", result.Stderr)
+
+	return &activationResult, nil
+}
+
+// rollback restores the original netplan configuration files, logging
+// any failure to do so.
+func rollback(np *Netplan) {
+	if err := np.Rollback(); err != nil {
+		logger.Errorf("cannot restore netplan configuration: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package netplan_test
+
+// These tests verify the commands that would be executed, but using a
+// dryrun option to the script that is executed.
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/netplan"
+)
+
+type ActivationSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ActivationSuite{})
+
+func (s *ActivationSuite) SetUpSuite(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("skipping ActivationSuite tests on windows")
+	}
+	s.IsolationSuite.SetUpSuite(c)
+}
+
+func (s *ActivationSuite) TestActivateNoDevices(c *gc.C) {
+	params := netplan.ActivationParams{
+		Clock:     clock.WallClock,
+		Directory: "testdata/TestBridgeEthernet/input",
+		DryRun:    true,
+		Timeout:   5 * time.Minute,
+	}
+	result, err := netplan.BridgeAndActivate(params)
+	c.Assert(err, gc.ErrorMatches, "no devices specified")
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *ActivationSuite) TestActivateNonExistentDevice(c *gc.C) {
+	dir := copyInput(c, "TestBridgeEthernet")
+	params := netplan.ActivationParams{
+		Clock:     clock.WallClock,
+		Devices:   map[string]string{"non-existent": "br-non-existent"},
+		Directory: dir,
+		DryRun:    true,
+		Timeout:   5 * time.Minute,
+	}
+	result, err := netplan.BridgeAndActivate(params)
+	c.Assert(err, gc.ErrorMatches, `device "non-existent" in netplan configuration not found`)
+	c.Assert(result, gc.IsNil)
+	c.Assert(dirFileNames(c, dir), jc.DeepEquals, []string{"50-cloud-init.yaml"})
+}
+
+func (s *ActivationSuite) TestActivateEth0(c *gc.C) {
+	dir := copyInput(c, "TestBridgeEthernet")
+	params := netplan.ActivationParams{
+		Clock:     clock.WallClock,
+		Devices:   map[string]string{"eth0": "br-eth0"},
+		Directory: dir,
+		DryRun:    true,
+		Timeout:   5 * time.Minute,
+	}
+	result, err := netplan.BridgeAndActivate(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.NotNil)
+	c.Assert(result.Code, gc.Equals, 0)
+
+	expected := `
+netplan generate
+netplan apply
+`
+	c.Assert(string(result.Stdout), gc.Equals, expected[1:])
+
+	names := dirFileNames(c, dir)
+	c.Assert(names, gc.HasLen, 2)
+	c.Check(names[0], gc.Matches, `50-cloud-init\.yaml\.bak\.[0-9]+`)
+	c.Check(names[1], gc.Equals, "99-juju.yaml")
+	written, err := ioutil.ReadFile(filepath.Join(dir, "99-juju.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	expectedContent, err := ioutil.ReadFile("testdata/TestBridgeEthernet/expected.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(written), gc.Equals, string(expectedContent))
+
+	// Activating again finds the device already bridged.
+	result, err = netplan.BridgeAndActivate(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *ActivationSuite) TestActivateFailureRollsBack(c *gc.C) {
+	s.PatchValue(netplan.ActivationCmd, "echo artificial failure >&2; exit 1")
+	dir := copyInput(c, "TestBridgeEthernet")
+	params := netplan.ActivationParams{
+		Clock:     clock.WallClock,
+		Devices:   map[string]string{"eth0": "br-eth0"},
+		Directory: dir,
+		DryRun:    true,
+		Timeout:   5 * time.Minute,
+	}
+	result, err := netplan.BridgeAndActivate(params)
+	c.Assert(err, gc.ErrorMatches, "bridge activation failed: artificial failure\n")
+	c.Assert(result, gc.NotNil)
+	c.Assert(result.Code, gc.Equals, 1)
+	c.Assert(dirFileNames(c, dir), jc.DeepEquals, []string{"50-cloud-init.yaml"})
+}
+
+func (s *ActivationSuite) TestActivateTimeoutRollsBack(c *gc.C) {
+	s.PatchValue(netplan.ActivationCmd, "sleep 30")
+	dir := copyInput(c, "TestBridgeEthernet")
+	params := netplan.ActivationParams{
+		Clock:     clock.WallClock,
+		Devices:   map[string]string{"eth0": "br-eth0"},
+		Directory: dir,
+		DryRun:    true,
+		Timeout:   500 * time.Millisecond,
+	}
+	_, err := netplan.BridgeAndActivate(params)
+	c.Assert(err, gc.ErrorMatches, "bridge activation error: command cancelled")
+	c.Assert(dirFileNames(c, dir), jc.DeepEquals, []string{"50-cloud-init.yaml"})
+}
@@ -0,0 +1,363 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package netplan
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// jujuNetplanFile is the name of the file written in the netplan
+// directory, holding the merged and bridged configuration.
+const jujuNetplanFile = "99-juju.yaml"
+
+// Nameservers holds the DNS configuration of a device.
+type Nameservers struct {
+	Search    []string `yaml:"search,omitempty,flow"`
+	Addresses []string `yaml:"addresses,omitempty,flow"`
+}
+
+// Route is a static route of a device.
+type Route struct {
+	To     string `yaml:"to,omitempty"`
+	Via    string `yaml:"via,omitempty"`
+	Metric *int   `yaml:"metric,omitempty"`
+	// Other holds the route settings not otherwise understood.
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// Interface holds the IP configuration of a device. When a device is
+// bridged, its Interface moves to the bridge.
+type Interface struct {
+	Addresses      []string               `yaml:"addresses,omitempty"`
+	DHCP4          *bool                  `yaml:"dhcp4,omitempty"`
+	DHCP6          *bool                  `yaml:"dhcp6,omitempty"`
+	DHCP4Overrides map[string]interface{} `yaml:"dhcp4-overrides,omitempty"`
+	DHCP6Overrides map[string]interface{} `yaml:"dhcp6-overrides,omitempty"`
+	AcceptRA       *bool                  `yaml:"accept-ra,omitempty"`
+	Gateway4       string                 `yaml:"gateway4,omitempty"`
+	Gateway6       string                 `yaml:"gateway6,omitempty"`
+	Nameservers    *Nameservers           `yaml:"nameservers,omitempty"`
+	Routes         []Route                `yaml:"routes,omitempty"`
+	MTU            int                    `yaml:"mtu,omitempty"`
+}
+
+// Ethernet is a physical device.
+type Ethernet struct {
+	Match     map[string]string `yaml:"match,omitempty"`
+	SetName   string            `yaml:"set-name,omitempty"`
+	Interface `yaml:",inline"`
+	// Other holds the device settings not otherwise understood.
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// Bond is a bond of other devices.
+type Bond struct {
+	Interfaces []string `yaml:"interfaces,omitempty,flow"`
+	Interface  `yaml:",inline"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty"`
+	// Other holds the device settings not otherwise understood.
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// Bridge is a bridge of other devices.
+type Bridge struct {
+	Interfaces []string `yaml:"interfaces,omitempty,flow"`
+	Interface  `yaml:",inline"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty"`
+	// Other holds the device settings not otherwise understood.
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// VLAN is a tagged device on top of another device.
+type VLAN struct {
+	ID        int    `yaml:"id"`
+	Link      string `yaml:"link"`
+	Interface `yaml:",inline"`
+	// Other holds the device settings not otherwise understood.
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// Network is the top-level netplan configuration.
+type Network struct {
+	Version   int                 `yaml:"version"`
+	Renderer  string              `yaml:"renderer,omitempty"`
+	Ethernets map[string]Ethernet `yaml:"ethernets,omitempty"`
+	Bonds     map[string]Bond     `yaml:"bonds,omitempty"`
+	Bridges   map[string]Bridge   `yaml:"bridges,omitempty"`
+	VLANs     map[string]VLAN     `yaml:"vlans,omitempty"`
+	// Other holds the settings not otherwise understood, such as
+	// the wifis definitions.
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// Netplan is the configuration read from a netplan directory.
+type Netplan struct {
+	Network Network `yaml:"network"`
+
+	sourceDirectory string
+	sourceFiles     []string
+	backedFiles     map[string]string
+	writtenFile     string
+}
+
+// Unmarshal parses the netplan YAML in data.
+func Unmarshal(data []byte) (*Netplan, error) {
+	var np Netplan
+	if err := yaml.Unmarshal(data, &np); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &np, nil
+}
+
+// Marshal returns the netplan YAML of the configuration.
+func Marshal(np *Netplan) ([]byte, error) {
+	out, err := yaml.Marshal(np)
+	return out, errors.Trace(err)
+}
+
+// ReadDirectory reads all the .yaml files in the given directory, and
+// merges them in lexical order of their names the way netplan does: a
+// mapping defined in several files is merged, any other value defined
+// in a later file replaces the earlier one.
+func ReadDirectory(dirPath string) (*Netplan, error) {
+	fileInfos, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var sourceFiles []string
+	for _, fileInfo := range fileInfos {
+		if !fileInfo.IsDir() && strings.HasSuffix(fileInfo.Name(), ".yaml") {
+			sourceFiles = append(sourceFiles, fileInfo.Name())
+		}
+	}
+	sort.Strings(sourceFiles)
+
+	merged := make(map[interface{}]interface{})
+	for _, fileName := range sourceFiles {
+		content, err := ioutil.ReadFile(filepath.Join(dirPath, fileName))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var doc map[interface{}]interface{}
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, errors.Annotatef(err, "cannot parse %q", fileName)
+		}
+		mergeMaps(merged, doc)
+	}
+	content, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	np, err := Unmarshal(content)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot parse netplan configuration in %q", dirPath)
+	}
+	np.sourceDirectory = dirPath
+	np.sourceFiles = sourceFiles
+	return np, nil
+}
+
+// mergeMaps merges src into dst, recursively for the values which are
+// mappings in both.
+func mergeMaps(dst, src map[interface{}]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[interface{}]interface{})
+		dstMap, dstIsMap := dst[key].(map[interface{}]interface{})
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcValue
+	}
+}
+
+// Write writes the configuration to the given path, or to a file in the
+// directory it was read from when the path is empty, and returns the
+// path written.
+func (np *Netplan) Write(inPath string) (string, error) {
+	if np.writtenFile != "" {
+		return "", errors.Errorf("netplan configuration already written to %q", np.writtenFile)
+	}
+	filePath := inPath
+	if filePath == "" {
+		filePath = filepath.Join(np.sourceDirectory, jujuNetplanFile)
+	}
+	content, err := Marshal(np)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(filePath, content, 0644); err != nil {
+		return "", errors.Trace(err)
+	}
+	np.writtenFile = filePath
+	return filePath, nil
+}
+
+// MoveYamlsToBak renames the files the configuration was read from, so
+// that netplan no longer reads them.
+func (np *Netplan) MoveYamlsToBak() error {
+	if np.backedFiles != nil {
+		return errors.Errorf("netplan configuration files already backed up")
+	}
+	suffix := fmt.Sprintf(".bak.%d", time.Now().Unix())
+	backedFiles := make(map[string]string)
+	for _, fileName := range np.sourceFiles {
+		oldPath := filepath.Join(np.sourceDirectory, fileName)
+		newPath := oldPath + suffix
+		if err := os.Rename(oldPath, newPath); err != nil {
+			np.backedFiles = backedFiles
+			return errors.Trace(err)
+		}
+		backedFiles[oldPath] = newPath
+	}
+	np.backedFiles = backedFiles
+	return nil
+}
+
+// Rollback removes the file written by Write, and restores the files
+// renamed by MoveYamlsToBak.
+func (np *Netplan) Rollback() error {
+	if np.writtenFile != "" {
+		if err := os.Remove(np.writtenFile); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		np.writtenFile = ""
+	}
+	for oldPath, newPath := range np.backedFiles {
+		if err := os.Rename(newPath, oldPath); err != nil {
+			return errors.Trace(err)
+		}
+		delete(np.backedFiles, oldPath)
+	}
+	np.backedFiles = nil
+	return nil
+}
+
+// Bridge turns the given devices, mapped to the names of their bridges,
+// into bridged devices. The IP configuration of each device moves to its
+// bridge, while the settings of the device itself, such as the VLAN id
+// and link, or the bond interfaces and parameters, stay with the device.
+// Devices already bridged with the requested name are left untouched.
+func (np *Netplan) Bridge(devices map[string]string) error {
+	deviceNames := make([]string, 0, len(devices))
+	for deviceName := range devices {
+		deviceNames = append(deviceNames, deviceName)
+	}
+	sort.Strings(deviceNames)
+	for _, deviceName := range deviceNames {
+		if err := np.bridgeDevice(deviceName, devices[deviceName]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (np *Netplan) bridgeDevice(deviceName, bridgeName string) error {
+	kind, id, err := np.findDevice(deviceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if bridge, ok := np.Network.Bridges[bridgeName]; ok {
+		if len(bridge.Interfaces) == 1 && bridge.Interfaces[0] == id {
+			return nil
+		}
+		return errors.AlreadyExistsf("bridge %q", bridgeName)
+	}
+	if _, _, err := np.findDevice(bridgeName); err == nil {
+		return errors.AlreadyExistsf("device %q", bridgeName)
+	}
+	if bridgeName, ok := np.findParent(id, np.bridgeInterfaces()); ok {
+		return errors.Errorf("device %q is already bridged by %q", deviceName, bridgeName)
+	}
+	if bondName, ok := np.findParent(id, np.bondInterfaces()); ok {
+		return errors.Errorf("cannot bridge device %q: member of bond %q", deviceName, bondName)
+	}
+
+	var iface Interface
+	switch kind {
+	case "ethernet":
+		ethernet := np.Network.Ethernets[id]
+		iface, ethernet.Interface = ethernet.Interface, Interface{MTU: ethernet.MTU}
+		np.Network.Ethernets[id] = ethernet
+	case "bond":
+		bond := np.Network.Bonds[id]
+		iface, bond.Interface = bond.Interface, Interface{MTU: bond.MTU}
+		np.Network.Bonds[id] = bond
+	case "vlan":
+		vlan := np.Network.VLANs[id]
+		iface, vlan.Interface = vlan.Interface, Interface{MTU: vlan.MTU}
+		np.Network.VLANs[id] = vlan
+	default:
+		return errors.Errorf("cannot bridge %s device %q", kind, deviceName)
+	}
+	if np.Network.Bridges == nil {
+		np.Network.Bridges = make(map[string]Bridge)
+	}
+	np.Network.Bridges[bridgeName] = Bridge{
+		Interfaces: []string{id},
+		Interface:  iface,
+	}
+	return nil
+}
+
+// findDevice returns the kind and the id of the device which will be
+// named deviceName once netplan applies the configuration.
+func (np *Netplan) findDevice(deviceName string) (kind, id string, err error) {
+	for id, ethernet := range np.Network.Ethernets {
+		if ethernet.SetName == deviceName {
+			return "ethernet", id, nil
+		}
+		if ethernet.SetName == "" && (id == deviceName || ethernet.Match["name"] == deviceName) {
+			return "ethernet", id, nil
+		}
+	}
+	if _, ok := np.Network.Bonds[deviceName]; ok {
+		return "bond", deviceName, nil
+	}
+	if _, ok := np.Network.VLANs[deviceName]; ok {
+		return "vlan", deviceName, nil
+	}
+	if _, ok := np.Network.Bridges[deviceName]; ok {
+		return "bridge", deviceName, nil
+	}
+	return "", "", errors.NotFoundf("device %q in netplan configuration", deviceName)
+}
+
+func (np *Netplan) bridgeInterfaces() map[string][]string {
+	result := make(map[string][]string)
+	for name, bridge := range np.Network.Bridges {
+		result[name] = bridge.Interfaces
+	}
+	return result
+}
+
+func (np *Netplan) bondInterfaces() map[string][]string {
+	result := make(map[string][]string)
+	for name, bond := range np.Network.Bonds {
+		result[name] = bond.Interfaces
+	}
+	return result
+}
+
+// findParent returns the name of the device whose interfaces include
+// the given device id.
+func (np *Netplan) findParent(id string, interfaces map[string][]string) (string, bool) {
+	for name, members := range interfaces {
+		for _, member := range members {
+			if member == id {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
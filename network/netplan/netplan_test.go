@@ -0,0 +1,259 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package netplan_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/netplan"
+)
+
+type NetplanSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NetplanSuite{})
+
+// goldenTests maps each directory in testdata to the devices to bridge.
+// Each directory holds the netplan files to read in "input", and the
+// bridged configuration expected in "expected.yaml".
+var goldenTests = map[string]map[string]string{
+	"TestBridgeEthernet":          {"eth0": "br-eth0"},
+	"TestBridgeEthernetBySetName": {"ens3": "br-ens3"},
+	"TestBridgeVLAN":              {"eth0.100": "br-eth0.100"},
+	"TestBridgeBond":              {"bond0": "br-bond0", "bond0.10": "br-bond0.10"},
+	"TestMergeFiles":              {"eth0": "br-eth0"},
+}
+
+func (s *NetplanSuite) TestBridgeGoldenFiles(c *gc.C) {
+	names := make([]string, 0, len(goldenTests))
+	for name := range goldenTests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.Logf("test %q", name)
+		np, err := netplan.ReadDirectory(filepath.Join("testdata", name, "input"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = np.Bridge(goldenTests[name])
+		c.Assert(err, jc.ErrorIsNil)
+		out, err := netplan.Marshal(np)
+		c.Assert(err, jc.ErrorIsNil)
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", name, "expected.yaml"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(out), gc.Equals, string(expected))
+
+		// Bridging again is a no-op.
+		np, err = netplan.Unmarshal(out)
+		c.Assert(err, jc.ErrorIsNil)
+		err = np.Bridge(goldenTests[name])
+		c.Assert(err, jc.ErrorIsNil)
+		again, err := netplan.Marshal(np)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(again), gc.Equals, string(expected))
+	}
+}
+
+func (s *NetplanSuite) TestUnmarshalMarshalRoundTrip(c *gc.C) {
+	input := `
+network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eth0:
+      dhcp4: true
+      wakeonlan: true
+  wifis:
+    wlan0:
+      access-points:
+        home:
+          password: secret
+`[1:]
+	np, err := netplan.Unmarshal([]byte(input))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(np.Network.Ethernets["eth0"].Other, jc.DeepEquals, map[string]interface{}{"wakeonlan": true})
+	c.Check(np.Network.Other, gc.HasLen, 1)
+
+	out, err := netplan.Marshal(np)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), gc.Equals, input)
+}
+
+func (s *NetplanSuite) assertBridgeError(c *gc.C, input string, devices map[string]string, expected string) error {
+	np, err := netplan.Unmarshal([]byte(input))
+	c.Assert(err, jc.ErrorIsNil)
+	err = np.Bridge(devices)
+	c.Check(err, gc.ErrorMatches, expected)
+	return err
+}
+
+func (s *NetplanSuite) TestBridgeDeviceNotFound(c *gc.C) {
+	input := `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      dhcp4: true
+`
+	err := s.assertBridgeError(c, input, map[string]string{"eth1": "br-eth1"},
+		`device "eth1" in netplan configuration not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *NetplanSuite) TestBridgeBondMember(c *gc.C) {
+	input := `
+network:
+  version: 2
+  ethernets:
+    eth0: {}
+    eth1: {}
+  bonds:
+    bond0:
+      interfaces: [eth0, eth1]
+      dhcp4: true
+`
+	s.assertBridgeError(c, input, map[string]string{"eth1": "br-eth1"},
+		`cannot bridge device "eth1": member of bond "bond0"`)
+}
+
+func (s *NetplanSuite) TestBridgeAlreadyBridgedWithAnotherName(c *gc.C) {
+	input := `
+network:
+  version: 2
+  ethernets:
+    eth0: {}
+  bridges:
+    br0:
+      interfaces: [eth0]
+      dhcp4: true
+`
+	s.assertBridgeError(c, input, map[string]string{"eth0": "br-eth0"},
+		`device "eth0" is already bridged by "br0"`)
+}
+
+func (s *NetplanSuite) TestBridgeNameInUse(c *gc.C) {
+	input := `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      dhcp4: true
+    br-eth0:
+      dhcp4: true
+`
+	err := s.assertBridgeError(c, input, map[string]string{"eth0": "br-eth0"},
+		`device "br-eth0" already exists`)
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *NetplanSuite) TestBridgeBridge(c *gc.C) {
+	input := `
+network:
+  version: 2
+  bridges:
+    br0:
+      dhcp4: true
+`
+	s.assertBridgeError(c, input, map[string]string{"br0": "br-br0"},
+		`cannot bridge bridge device "br0"`)
+}
+
+func (s *NetplanSuite) TestReadDirectoryInvalidYAML(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "50-bad.yaml"), []byte("network: ["), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = netplan.ReadDirectory(dir)
+	c.Assert(err, gc.ErrorMatches, `cannot parse "50-bad.yaml": .*`)
+}
+
+func (s *NetplanSuite) TestReadDirectoryNotFound(c *gc.C) {
+	_, err := netplan.ReadDirectory("testdata/non-existent-directory")
+	c.Assert(err, gc.ErrorMatches, `open testdata/non-existent-directory: no such file or directory`)
+}
+
+// copyInput copies the input files of the given golden test to a new
+// directory, and returns its path.
+func copyInput(c *gc.C, name string) string {
+	dir := c.MkDir()
+	inputDir := filepath.Join("testdata", name, "input")
+	fileInfos, err := ioutil.ReadDir(inputDir)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, fileInfo := range fileInfos {
+		content, err := ioutil.ReadFile(filepath.Join(inputDir, fileInfo.Name()))
+		c.Assert(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(filepath.Join(dir, fileInfo.Name()), content, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return dir
+}
+
+func dirFileNames(c *gc.C, dir string) []string {
+	fileInfos, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, fileInfo := range fileInfos {
+		names = append(names, fileInfo.Name())
+	}
+	return names
+}
+
+func (s *NetplanSuite) TestWriteAndMoveYamlsToBak(c *gc.C) {
+	dir := copyInput(c, "TestMergeFiles")
+	np, err := netplan.ReadDirectory(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = np.Bridge(goldenTests["TestMergeFiles"])
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = np.MoveYamlsToBak()
+	c.Assert(err, jc.ErrorIsNil)
+	path, err := np.Write("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(path, gc.Equals, filepath.Join(dir, "99-juju.yaml"))
+
+	names := dirFileNames(c, dir)
+	c.Assert(names, gc.HasLen, 4)
+	c.Check(names[0], gc.Matches, `01-netcfg\.yaml\.bak\.[0-9]+`)
+	c.Check(names[1], gc.Matches, `50-juju-overrides\.yaml\.bak\.[0-9]+`)
+	c.Check(names[2:], jc.DeepEquals, []string{"99-juju.yaml", "README"})
+
+	written, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := ioutil.ReadFile("testdata/TestMergeFiles/expected.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(written), gc.Equals, string(expected))
+
+	// Only the written file is read back.
+	np, err = netplan.ReadDirectory(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(np.Network.Bridges, gc.HasLen, 1)
+}
+
+func (s *NetplanSuite) TestRollbackRestoresFiles(c *gc.C) {
+	dir := copyInput(c, "TestMergeFiles")
+	np, err := netplan.ReadDirectory(dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = np.MoveYamlsToBak()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = np.Write("")
+	c.Assert(err, jc.ErrorIsNil)
+	err = np.Rollback()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(dirFileNames(c, dir), jc.DeepEquals, []string{
+		"01-netcfg.yaml",
+		"50-juju-overrides.yaml",
+		"README",
+	})
+	_, err = os.Stat(filepath.Join(dir, "99-juju.yaml"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// deviceCodeGrantType is the grant type used to poll the token
+// endpoint during a device authorization (RFC 8628).
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultPollInterval is used when the provider does not
+// specify how often the token endpoint should be polled.
+const defaultPollInterval = 5 * time.Second
+
+// DefaultScopes holds the scopes requested when a DeviceFlow
+// does not specify any.
+var DefaultScopes = []string{"openid", "profile", "email"}
+
+// DeviceAuthorization holds a provider's response to a device
+// authorization request.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// tokenResponse holds the parts of a successful token
+// response that we are interested in.
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// DeviceFlow obtains ID tokens from an OpenID Connect provider using
+// the OAuth 2.0 device authorization grant, which lets a user log in
+// through a browser on any device.
+type DeviceFlow struct {
+	// Client is used to make requests to the provider.
+	Client *http.Client
+
+	// Metadata holds the provider's discovered endpoints.
+	Metadata *Metadata
+
+	// ClientID holds the client ID to request tokens for.
+	ClientID string
+
+	// Scopes holds the scopes to request. If it is empty,
+	// DefaultScopes are requested.
+	Scopes []string
+
+	// Clock is used to pace polling of the token endpoint.
+	Clock clock.Clock
+}
+
+// Start requests a device code and user code from the provider.
+// The user must visit the returned verification URI and enter the
+// user code before Wait will succeed.
+func (f *DeviceFlow) Start() (*DeviceAuthorization, error) {
+	if f.Metadata.DeviceAuthorizationEndpoint == "" {
+		return nil, errors.NotSupportedf("device authorization by %q", f.Metadata.Issuer)
+	}
+	scopes := f.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	var auth DeviceAuthorization
+	if err := postForm(f.Client, f.Metadata.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {f.ClientID},
+		"scope":     {strings.Join(scopes, " ")},
+	}, &auth); err != nil {
+		return nil, errors.Annotate(err, "cannot start device authorization")
+	}
+	if auth.DeviceCode == "" || auth.UserCode == "" || auth.VerificationURI == "" {
+		return nil, errors.New("incomplete device authorization response")
+	}
+	return &auth, nil
+}
+
+// Wait polls the provider's token endpoint until the user has
+// completed the given authorization, and returns the ID token
+// that was issued.
+func (f *DeviceFlow) Wait(auth *DeviceAuthorization) (string, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	var deadline time.Time
+	if auth.ExpiresIn > 0 {
+		deadline = f.Clock.Now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+	}
+	for {
+		<-f.Clock.After(interval)
+		if !deadline.IsZero() && f.Clock.Now().After(deadline) {
+			return "", errors.New("device authorization expired")
+		}
+		var resp tokenResponse
+		err := postForm(f.Client, f.Metadata.TokenEndpoint, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {auth.DeviceCode},
+			"client_id":   {f.ClientID},
+		}, &resp)
+		if err == nil {
+			if resp.IDToken == "" {
+				return "", errors.New("token response contains no ID token")
+			}
+			return resp.IDToken, nil
+		}
+		tokenErr, ok := err.(*TokenError)
+		if !ok {
+			return "", errors.Annotate(err, "cannot obtain token")
+		}
+		switch tokenErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += defaultPollInterval
+		default:
+			return "", errors.Annotate(err, "device authorization failed")
+		}
+	}
+}
+
+// DeviceLogin performs a complete device authorization with the
+// OpenID Connect provider at the given issuer URL, calling prompt
+// with the details the user needs to complete it, and returns the
+// issued ID token.
+func DeviceLogin(
+	client *http.Client,
+	clock clock.Clock,
+	issuer, clientID string,
+	prompt func(*DeviceAuthorization) error,
+) (string, error) {
+	metadata, err := Discover(client, issuer)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	flow := &DeviceFlow{
+		Client:   client,
+		Metadata: metadata,
+		ClientID: clientID,
+		Clock:    clock,
+	}
+	auth, err := flow.Start()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := prompt(auth); err != nil {
+		return "", errors.Trace(err)
+	}
+	return flow.Wait(auth)
+}
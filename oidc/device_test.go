@@ -0,0 +1,185 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/oidc"
+	"github.com/juju/juju/oidc/oidctest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type deviceSuite struct {
+	testing.IsolationSuite
+	issuer *oidctest.Issuer
+	clock  *testing.Clock
+}
+
+var _ = gc.Suite(&deviceSuite{})
+
+func (s *deviceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.issuer = oidctest.NewIssuer("juju")
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+	s.clock = testing.NewClock(time.Now())
+}
+
+func (s *deviceSuite) flow(c *gc.C) *oidc.DeviceFlow {
+	metadata, err := oidc.Discover(http.DefaultClient, s.issuer.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	return &oidc.DeviceFlow{
+		Client:   http.DefaultClient,
+		Metadata: metadata,
+		ClientID: "juju",
+		Clock:    s.clock,
+	}
+}
+
+// wait calls flow.Wait in the background, advancing the test
+// clock until it returns.
+func (s *deviceSuite) wait(c *gc.C, flow *oidc.DeviceFlow, auth *oidc.DeviceAuthorization) (string, error) {
+	type result struct {
+		token string
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		token, err := flow.Wait(auth)
+		done <- result{token, err}
+	}()
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case r := <-done:
+			return r.token, r.err
+		case <-time.After(coretesting.ShortWait):
+			s.clock.Advance(time.Second)
+		case <-timeout:
+			c.Fatalf("timed out waiting for device authorization")
+		}
+	}
+}
+
+func (s *deviceSuite) TestDeviceFlow(c *gc.C) {
+	flow := s.flow(c)
+	auth, err := flow.Start()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth.UserCode, gc.Not(gc.Equals), "")
+	c.Assert(auth.VerificationURI, gc.Equals, s.issuer.URL()+"/activate")
+
+	err = s.issuer.Approve(auth.UserCode, map[string]interface{}{"email": "bob@example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	token, err := s.wait(c, flow, auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	keys, err := oidc.ParseKeySet([]byte(s.issuer.JWKS()))
+	c.Assert(err, jc.ErrorIsNil)
+	verifier := &oidc.Verifier{
+		Issuer:   s.issuer.URL(),
+		ClientID: "juju",
+		Keys:     keys,
+		Clock:    clock.WallClock,
+	}
+	claims, err := verifier.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.String("email"), gc.Equals, "bob@example.com")
+}
+
+func (s *deviceSuite) TestDeviceFlowPending(c *gc.C) {
+	flow := s.flow(c)
+	auth, err := flow.Start()
+	c.Assert(err, jc.ErrorIsNil)
+
+	done := make(chan string, 1)
+	go func() {
+		token, err := flow.Wait(auth)
+		c.Check(err, jc.ErrorIsNil)
+		done <- token
+	}()
+	// Let the client poll once and be told the authorization
+	// is pending; it then waits to poll again.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+		c.Fatalf("device flow completed before approval")
+	default:
+	}
+
+	err = s.issuer.Approve(auth.UserCode, map[string]interface{}{"email": "bob@example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case token := <-done:
+		c.Assert(token, gc.Not(gc.Equals), "")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for device authorization")
+	}
+}
+
+func (s *deviceSuite) TestDeviceFlowExpiredCode(c *gc.C) {
+	flow := s.flow(c)
+	_, err := s.wait(c, flow, &oidc.DeviceAuthorization{
+		DeviceCode: "unknown",
+		Interval:   1,
+	})
+	c.Assert(err, gc.ErrorMatches, `device authorization failed: expired_token`)
+	_, ok := errors.Cause(err).(*oidc.TokenError)
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *deviceSuite) TestDeviceFlowWrongClient(c *gc.C) {
+	flow := s.flow(c)
+	flow.ClientID = "other"
+	_, err := flow.Start()
+	c.Assert(err, gc.ErrorMatches, `cannot start device authorization: invalid_client`)
+}
+
+func (s *deviceSuite) TestDeviceFlowNotSupported(c *gc.C) {
+	flow := s.flow(c)
+	flow.Metadata.DeviceAuthorizationEndpoint = ""
+	_, err := flow.Start()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *deviceSuite) TestDeviceLogin(c *gc.C) {
+	var prompted *oidc.DeviceAuthorization
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(coretesting.ShortWait):
+				s.clock.Advance(time.Second)
+			}
+		}
+	}()
+	token, err := oidc.DeviceLogin(http.DefaultClient, s.clock, s.issuer.URL(), "juju", func(auth *oidc.DeviceAuthorization) error {
+		prompted = auth
+		return s.issuer.Approve(auth.UserCode, map[string]interface{}{"email": "bob@example.com"})
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Not(gc.Equals), "")
+	c.Assert(prompted, gc.NotNil)
+}
+
+func (s *deviceSuite) TestDeviceLoginPromptError(c *gc.C) {
+	_, err := oidc.DeviceLogin(http.DefaultClient, s.clock, s.issuer.URL(), "juju", func(*oidc.DeviceAuthorization) error {
+		return errors.New("no terminal")
+	})
+	c.Assert(err, gc.ErrorMatches, `no terminal`)
+}
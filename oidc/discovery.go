@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package oidc implements the parts of OpenID Connect that Juju uses
+// to authenticate external users: provider discovery, verification of
+// ID tokens and the OAuth 2.0 device authorization grant used by
+// "juju login".
+package oidc
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// discoveryPath is the path, relative to the issuer URL, of an
+// OpenID Connect provider's discovery document.
+const discoveryPath = "/.well-known/openid-configuration"
+
+// Metadata holds the subset of an OpenID Connect provider's discovery
+// document that Juju makes use of.
+type Metadata struct {
+	Issuer                      string `json:"issuer"`
+	JWKSURI                     string `json:"jwks_uri"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+}
+
+// Discover fetches the discovery document of the OpenID Connect
+// provider with the given issuer URL.
+func Discover(client *http.Client, issuer string) (*Metadata, error) {
+	var m Metadata
+	if err := getJSON(client, strings.TrimSuffix(issuer, "/")+discoveryPath, &m); err != nil {
+		return nil, errors.Annotatef(err, "cannot discover OpenID Connect provider %q", issuer)
+	}
+	if m.Issuer != issuer {
+		return nil, errors.Errorf("discovery document issuer %q does not match %q", m.Issuer, issuer)
+	}
+	return &m, nil
+}
+
+// getJSON fetches the given URL and unmarshals the JSON body
+// of the response into v.
+func getJSON(client *http.Client, url string, v interface{}) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return errors.Trace(json.NewDecoder(resp.Body).Decode(v))
+}
+
+// postForm posts the given form values to the given URL and
+// unmarshals the JSON body of a successful response into v.
+// An OAuth 2.0 error response is returned as a *TokenError.
+func postForm(client *http.Client, url string, form url.Values, v interface{}) error {
+	resp, err := client.PostForm(url, form)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		var tokenErr TokenError
+		if err := json.Unmarshal(body, &tokenErr); err == nil && tokenErr.Code != "" {
+			return &tokenErr
+		}
+		return errors.Errorf("POST %s: %s", url, resp.Status)
+	}
+	return errors.Trace(json.NewDecoder(resp.Body).Decode(v))
+}
+
+// TokenError holds an error response from an OAuth 2.0 endpoint.
+type TokenError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// Error implements error.
+func (e *TokenError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
@@ -0,0 +1,182 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// KeySource provides the public keys an OpenID Connect provider
+// signs ID tokens with.
+type KeySource interface {
+	// Key returns the key with the given key ID. An empty key ID
+	// identifies the provider's only key, if it has just one.
+	Key(kid string) (*rsa.PublicKey, error)
+}
+
+// KeySet holds the public keys an OpenID Connect provider signs
+// ID tokens with, indexed by key ID.
+type KeySet map[string]*rsa.PublicKey
+
+// Key implements KeySource.
+func (keys KeySet) Key(kid string) (*rsa.PublicKey, error) {
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(keys) == 1 {
+		// Providers with a single key need not identify it.
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, errors.Errorf("unknown signing key %q", kid)
+}
+
+const (
+	// keySetTTL is how long a fetched key set is used before
+	// it is fetched again.
+	keySetTTL = 24 * time.Hour
+
+	// keySetMinRefresh is the minimum time between fetches of
+	// a key set prompted by tokens signed with unknown keys,
+	// so that such tokens cannot make us flood the provider
+	// with requests.
+	keySetMinRefresh = time.Minute
+)
+
+// RemoteKeySet is a KeySource that fetches the JSON Web Key Set at a
+// URL. The key set is fetched again when it is older than a day, or
+// when a token is signed with a key that it does not hold, so that
+// providers can rotate their keys. Failed fetches are not remembered.
+type RemoteKeySet struct {
+	client *http.Client
+	url    string
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	keys      KeySet
+	fetchedAt time.Time
+}
+
+// NewRemoteKeySet returns a RemoteKeySet that fetches the key set at
+// the given URL with the given client. The key set is not fetched
+// until a key is needed.
+func NewRemoteKeySet(client *http.Client, url string, clock clock.Clock) *RemoteKeySet {
+	return &RemoteKeySet{
+		client: client,
+		url:    url,
+		clock:  clock,
+	}
+}
+
+// Key implements KeySource.
+func (r *RemoteKeySet) Key(kid string) (*rsa.PublicKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock.Now()
+	age := now.Sub(r.fetchedAt)
+	if r.keys != nil && age < keySetTTL {
+		key, err := r.keys.Key(kid)
+		if err == nil || age < keySetMinRefresh {
+			return key, err
+		}
+	}
+	keys, err := FetchKeySet(r.client, r.url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r.keys = keys
+	r.fetchedAt = now
+	return keys.Key(kid)
+}
+
+// jsonWebKeySet holds a JSON Web Key Set as defined in RFC 7517.
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// jsonWebKey holds the fields of a JSON Web Key that are needed
+// for RSA signature verification.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// ParseKeySet parses a JSON Web Key Set document. Keys that are not
+// RSA signing keys are ignored.
+func ParseKeySet(data []byte) (KeySet, error) {
+	var jwks jsonWebKeySet
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, errors.Annotate(err, "cannot parse key set")
+	}
+	return newKeySet(jwks)
+}
+
+// FetchKeySet fetches the JSON Web Key Set at the given URL.
+func FetchKeySet(client *http.Client, url string) (KeySet, error) {
+	var jwks jsonWebKeySet
+	if err := getJSON(client, url, &jwks); err != nil {
+		return nil, errors.Annotate(err, "cannot fetch key set")
+	}
+	return newKeySet(jwks)
+}
+
+func newKeySet(jwks jsonWebKeySet) (KeySet, error) {
+	keys := make(KeySet)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := rsaPublicKey(k)
+		if err != nil {
+			return nil, errors.Annotatef(err, "key %q", k.Kid)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("key set contains no RSA signing keys")
+	}
+	return keys, nil
+}
+
+func rsaPublicKey(k jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := decodeBase64URL(k.N)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid modulus")
+	}
+	e, err := decodeBase64URL(k.E)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid exponent")
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if exponent.BitLen() > 31 || exponent.Int64() < 3 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
+
+// decodeBase64URL decodes unpadded (or, leniently, padded)
+// base64url-encoded data.
+func decodeBase64URL(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("empty value")
+	}
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,252 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package oidctest provides a local stand-in for an OpenID Connect
+// provider, for use in tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/juju/errors"
+)
+
+// KeyID is the key ID of the key the issuer signs tokens with.
+const KeyID = "test-key"
+
+// Issuer is an OpenID Connect provider serving discovery, key set,
+// device authorization and token endpoints from a local HTTP server.
+// Device authorizations started against it remain pending until
+// approved with Approve.
+type Issuer struct {
+	// ClientID holds the client ID tokens are issued to.
+	ClientID string
+
+	server *httptest.Server
+
+	mu         sync.Mutex
+	key        *rsa.PrivateKey
+	keyID      string
+	keyFetches int
+	serial     int
+	pending    map[string]*deviceGrant
+}
+
+// deviceGrant records a device authorization started
+// against the issuer.
+type deviceGrant struct {
+	deviceCode string
+	claims     map[string]interface{}
+}
+
+// NewIssuer starts a new Issuer that issues tokens to the given
+// client ID. The caller is responsible for calling Close.
+func NewIssuer(clientID string) *Issuer {
+	i := &Issuer{
+		ClientID: clientID,
+		key:      generateKey(),
+		keyID:    KeyID,
+		pending:  make(map[string]*deviceGrant),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.serveDiscovery)
+	mux.HandleFunc("/keys", i.serveKeys)
+	mux.HandleFunc("/device", i.serveDeviceAuthorization)
+	mux.HandleFunc("/token", i.serveToken)
+	i.server = httptest.NewServer(mux)
+	return i
+}
+
+// URL returns the issuer URL.
+func (i *Issuer) URL() string {
+	return i.server.URL
+}
+
+// Close shuts down the issuer's HTTP server.
+func (i *Issuer) Close() {
+	i.server.Close()
+}
+
+func generateKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// RotateKey replaces the key the issuer signs tokens with by a new
+// key with a different key ID, which it returns.
+func (i *Issuer) RotateKey() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.serial++
+	i.key = generateKey()
+	i.keyID = fmt.Sprintf("%s-%d", KeyID, i.serial)
+	return i.keyID
+}
+
+// KeyFetches returns the number of times the issuer's key set
+// has been fetched from its HTTP server.
+func (i *Issuer) KeyFetches() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.keyFetches
+}
+
+// JWKS returns the issuer's key set as a JSON Web Key Set document.
+func (i *Issuer) JWKS() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.jwks()
+}
+
+// jwks implements JWKS. It must be called with i.mu held.
+func (i *Issuer) jwks() string {
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": i.keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// Token returns an ID token signed by the issuer holding the given
+// claims. The "iss", "aud", "iat" and "exp" claims are filled in
+// with valid values unless they are present in claims.
+func (i *Issuer) Token(claims map[string]interface{}) string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.token(claims)
+}
+
+// token implements Token. It must be called with i.mu held.
+func (i *Issuer) token(claims map[string]interface{}) string {
+	now := time.Now()
+	mapClaims := jwt.MapClaims{
+		"iss": i.URL(),
+		"aud": i.ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		mapClaims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mapClaims)
+	token.Header["kid"] = i.keyID
+	signed, err := token.SignedString(i.key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// Approve completes the pending device authorization with the given
+// user code, so that the client polling for it receives an ID token
+// holding the given claims.
+func (i *Issuer) Approve(userCode string, claims map[string]interface{}) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	grant, ok := i.pending[userCode]
+	if !ok {
+		return errors.NotFoundf("device authorization %q", userCode)
+	}
+	grant.claims = claims
+	return nil
+}
+
+func (i *Issuer) serveDiscovery(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                        i.URL(),
+		"jwks_uri":                      i.URL() + "/keys",
+		"token_endpoint":                i.URL() + "/token",
+		"device_authorization_endpoint": i.URL() + "/device",
+	})
+}
+
+func (i *Issuer) serveKeys(w http.ResponseWriter, req *http.Request) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keyFetches++
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, i.jwks())
+}
+
+func (i *Issuer) serveDeviceAuthorization(w http.ResponseWriter, req *http.Request) {
+	if req.PostFormValue("client_id") != i.ClientID {
+		writeError(w, "invalid_client")
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.serial++
+	userCode := fmt.Sprintf("CODE-%d", i.serial)
+	grant := &deviceGrant{deviceCode: fmt.Sprintf("device-%d", i.serial)}
+	i.pending[userCode] = grant
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"device_code":      grant.deviceCode,
+		"user_code":        userCode,
+		"verification_uri": i.URL() + "/activate",
+		"expires_in":       600,
+		"interval":         1,
+	})
+}
+
+func (i *Issuer) serveToken(w http.ResponseWriter, req *http.Request) {
+	if req.PostFormValue("grant_type") != "urn:ietf:params:oauth:grant-type:device_code" {
+		writeError(w, "unsupported_grant_type")
+		return
+	}
+	if req.PostFormValue("client_id") != i.ClientID {
+		writeError(w, "invalid_client")
+		return
+	}
+	deviceCode := req.PostFormValue("device_code")
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for userCode, grant := range i.pending {
+		if grant.deviceCode != deviceCode {
+			continue
+		}
+		if grant.claims == nil {
+			writeError(w, "authorization_pending")
+			return
+		}
+		delete(i.pending, userCode)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": "access-" + deviceCode,
+			"token_type":   "Bearer",
+			"id_token":     i.token(grant.claims),
+		})
+		return
+	}
+	writeError(w, "expired_token")
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
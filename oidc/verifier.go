@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc
+
+import (
+	"github.com/dgrijalva/jwt-go"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// Verifier verifies ID tokens issued by an OpenID Connect provider
+// to a particular client.
+type Verifier struct {
+	// Issuer holds the issuer URL that ID tokens must carry.
+	Issuer string
+
+	// ClientID holds the client ID that ID tokens must be issued to.
+	ClientID string
+
+	// Keys holds the provider's signing keys.
+	Keys KeySource
+
+	// Clock is used to check the validity period of ID tokens.
+	Clock clock.Clock
+}
+
+// Claims holds the claims of a verified ID token.
+type Claims map[string]interface{}
+
+// String returns the named claim as a string, or the empty string if
+// the claim is absent or not a string.
+func (c Claims) String(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// Strings returns the named claim as a slice of strings. A claim
+// holding a single string is returned as a slice of one element;
+// non-string elements are ignored.
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var values []string
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Verify checks the signature, issuer, audience and validity period
+// of the given ID token and returns its claims.
+func (v *Verifier) Verify(rawToken string) (Claims, error) {
+	parser := jwt.Parser{
+		ValidMethods: []string{jwt.SigningMethodRS256.Alg()},
+		// The time-based claims are checked below against our clock.
+		SkipClaimsValidation: true,
+	}
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(rawToken, claims, v.signingKey); err != nil {
+		return nil, errors.Annotate(err, "invalid ID token")
+	}
+	if !claims.VerifyIssuer(v.Issuer, true) {
+		return nil, errors.Errorf("ID token issued by %q, expected %q", claims["iss"], v.Issuer)
+	}
+	if !containsString(Claims(claims).Strings("aud"), v.ClientID) {
+		return nil, errors.Errorf("ID token not issued to client %q", v.ClientID)
+	}
+	now := v.Clock.Now().Unix()
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("ID token has expired")
+	}
+	if !claims.VerifyNotBefore(now, false) {
+		return nil, errors.New("ID token is not valid yet")
+	}
+	return Claims(claims), nil
+}
+
+// signingKey implements jwt.Keyfunc by returning the key
+// identified by the token's "kid" header.
+func (v *Verifier) signingKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	return v.Keys.Key(kid)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
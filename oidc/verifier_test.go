@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/oidc"
+	"github.com/juju/juju/oidc/oidctest"
+)
+
+type verifierSuite struct {
+	testing.IsolationSuite
+	issuer   *oidctest.Issuer
+	verifier *oidc.Verifier
+}
+
+var _ = gc.Suite(&verifierSuite{})
+
+func (s *verifierSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.issuer = oidctest.NewIssuer("juju")
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+	keys, err := oidc.ParseKeySet([]byte(s.issuer.JWKS()))
+	c.Assert(err, jc.ErrorIsNil)
+	s.verifier = &oidc.Verifier{
+		Issuer:   s.issuer.URL(),
+		ClientID: "juju",
+		Keys:     keys,
+		Clock:    clock.WallClock,
+	}
+}
+
+func (s *verifierSuite) TestVerify(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{
+		"email":  "bob@example.com",
+		"groups": []string{"ops", "dev"},
+	})
+	claims, err := s.verifier.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.String("email"), gc.Equals, "bob@example.com")
+	c.Assert(claims.Strings("groups"), jc.DeepEquals, []string{"ops", "dev"})
+	c.Assert(claims.Strings("aud"), jc.DeepEquals, []string{"juju"})
+}
+
+func (s *verifierSuite) TestVerifyAudienceList(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{
+		"aud": []string{"other", "juju"},
+	})
+	_, err := s.verifier.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *verifierSuite) TestVerifyWrongAudience(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{"aud": "other"})
+	_, err := s.verifier.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `ID token not issued to client "juju"`)
+}
+
+func (s *verifierSuite) TestVerifyWrongIssuer(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{"iss": "https://elsewhere.example.com"})
+	_, err := s.verifier.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `ID token issued by "https://elsewhere.example.com", expected ".*"`)
+}
+
+func (s *verifierSuite) TestVerifyExpired(c *gc.C) {
+	token := s.issuer.Token(map[string]interface{}{
+		"exp": time.Now().Add(-time.Minute).Unix(),
+	})
+	_, err := s.verifier.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `ID token has expired`)
+}
+
+func (s *verifierSuite) TestVerifyUsesClock(c *gc.C) {
+	token := s.issuer.Token(nil)
+	s.verifier.Clock = testing.NewClock(time.Now().Add(2 * time.Hour))
+	_, err := s.verifier.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `ID token has expired`)
+}
+
+func (s *verifierSuite) TestVerifyOtherIssuerKey(c *gc.C) {
+	other := oidctest.NewIssuer("juju")
+	defer other.Close()
+	token := other.Token(map[string]interface{}{"iss": s.issuer.URL()})
+	_, err := s.verifier.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `invalid ID token: .*`)
+}
+
+func (s *verifierSuite) TestVerifyGarbage(c *gc.C) {
+	_, err := s.verifier.Verify("not-a-token")
+	c.Assert(err, gc.ErrorMatches, `invalid ID token: .*`)
+}
+
+func (s *verifierSuite) TestFetchKeySet(c *gc.C) {
+	metadata, err := oidc.Discover(http.DefaultClient, s.issuer.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata.Issuer, gc.Equals, s.issuer.URL())
+	keys, err := oidc.FetchKeySet(http.DefaultClient, metadata.JWKSURI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, gc.HasLen, 1)
+	c.Assert(keys[oidctest.KeyID], gc.NotNil)
+}
+
+func (s *verifierSuite) TestParseKeySetNoRSAKeys(c *gc.C) {
+	_, err := oidc.ParseKeySet([]byte(`{"keys": [{"kty": "EC", "kid": "x"}]}`))
+	c.Assert(err, gc.ErrorMatches, `key set contains no RSA signing keys`)
+}
+
+func (s *verifierSuite) TestParseKeySetInvalid(c *gc.C) {
+	_, err := oidc.ParseKeySet([]byte(`{"keys": [{"kty": "RSA", "kid": "x", "n": "", "e": "AQAB"}]}`))
+	c.Assert(err, gc.ErrorMatches, `key "x": invalid modulus: empty value`)
+}
+
+func (s *verifierSuite) TestRemoteKeySetRefetchesOnUnknownKey(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	keys := oidc.NewRemoteKeySet(http.DefaultClient, s.issuer.URL()+"/keys", clock)
+	s.verifier.Keys = keys
+	_, err := s.verifier.Verify(s.issuer.Token(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.issuer.KeyFetches(), gc.Equals, 1)
+
+	// A token signed with a rotated key is not accepted
+	// until the key set has been held for a while...
+	newKeyID := s.issuer.RotateKey()
+	token := s.issuer.Token(nil)
+	_, err = s.verifier.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `invalid ID token: unknown signing key "`+newKeyID+`"`)
+	c.Assert(s.issuer.KeyFetches(), gc.Equals, 1)
+
+	// ... after which the key set is fetched again.
+	clock.Advance(time.Minute)
+	_, err = s.verifier.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.issuer.KeyFetches(), gc.Equals, 2)
+}
+
+func (s *verifierSuite) TestRemoteKeySetExpires(c *gc.C) {
+	clock := testing.NewClock(time.Now())
+	keys := oidc.NewRemoteKeySet(http.DefaultClient, s.issuer.URL()+"/keys", clock)
+	_, err := keys.Key(oidctest.KeyID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = keys.Key(oidctest.KeyID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.issuer.KeyFetches(), gc.Equals, 1)
+
+	clock.Advance(24 * time.Hour)
+	_, err = keys.Key(oidctest.KeyID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.issuer.KeyFetches(), gc.Equals, 2)
+}
+
+func (s *verifierSuite) TestRemoteKeySetFailureNotCached(c *gc.C) {
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, s.issuer.JWKS())
+	}))
+	defer server.Close()
+	keys := oidc.NewRemoteKeySet(http.DefaultClient, server.URL, testing.NewClock(time.Now()))
+	_, err := keys.Key(oidctest.KeyID)
+	c.Assert(err, gc.ErrorMatches, `cannot fetch key set: GET .*: 503 Service Unavailable`)
+
+	fail = false
+	_, err = keys.Key(oidctest.KeyID)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -72,6 +72,16 @@ func ValidateOfferAccess(access Access) error {
 	return errors.NotValidf("%q offer access", access)
 }
 
+// ValidateApplicationAccess returns error if the passed access is not a
+// valid application access level.
+func ValidateApplicationAccess(access Access) error {
+	switch access {
+	case ReadAccess, WriteAccess, AdminAccess:
+		return nil
+	}
+	return errors.NotValidf("%q application access", access)
+}
+
 //ValidateControllerAccess returns error if the passed access is not a valid
 // controller access level.
 func ValidateControllerAccess(access Access) error {
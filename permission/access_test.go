@@ -199,3 +199,22 @@ func (*accessSuite) TestGreaterControllerAccessThan(c *gc.C) {
 	c.Check(superuser.GreaterControllerAccessThan(addmodel), jc.IsTrue)
 	c.Check(superuser.GreaterControllerAccessThan(superuser), jc.IsFalse)
 }
+
+func (*accessSuite) TestValidateApplicationAccess(c *gc.C) {
+	for _, access := range []permission.Access{
+		permission.ReadAccess,
+		permission.WriteAccess,
+		permission.AdminAccess,
+	} {
+		c.Check(permission.ValidateApplicationAccess(access), jc.ErrorIsNil)
+	}
+	for _, access := range []permission.Access{
+		permission.NoAccess,
+		permission.ConsumeAccess,
+		permission.LoginAccess,
+		permission.SuperuserAccess,
+	} {
+		err := permission.ValidateApplicationAccess(access)
+		c.Check(err, gc.ErrorMatches, `".*" application access not valid`)
+	}
+}
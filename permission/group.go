@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission
+
+import (
+	"gopkg.in/juju/names.v2"
+)
+
+const (
+	// GroupDomain is the user domain given to groups of users
+	// managed by the controller. Access granted to the user
+	// "ops@group" is held by all members of the "ops" group.
+	GroupDomain = "group"
+
+	// OIDCGroupDomain is the user domain given to groups asserted
+	// by an OpenID Connect provider when an external user logs in.
+	OIDCGroupDomain = "oidc-group"
+)
+
+// GroupTag returns the tag that stands for the controller
+// managed group with the given name when granting access.
+func GroupTag(name string) names.UserTag {
+	return names.NewLocalUserTag(name).WithDomain(GroupDomain)
+}
+
+// IsGroup reports whether the given tag stands for a group of
+// users rather than an individual user. No user may log in as
+// a group.
+func IsGroup(tag names.UserTag) bool {
+	switch tag.Domain() {
+	case GroupDomain, OIDCGroupDomain:
+		return true
+	}
+	return false
+}
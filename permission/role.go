@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+)
+
+// Capability represents a single kind of operation within a model
+// which may be granted to a user through a Role, independently of
+// the user's model access level.
+type Capability string
+
+const (
+	// DeployCapability allows a user to deploy applications, add
+	// units and upgrade charms.
+	DeployCapability Capability = "deploy"
+
+	// RemoveCapability allows a user to remove applications and
+	// units.
+	RemoveCapability Capability = "remove"
+
+	// ConfigCapability allows a user to change application config
+	// and constraints.
+	ConfigCapability Capability = "config"
+
+	// ActionsCapability allows a user to run and cancel actions.
+	ActionsCapability Capability = "actions"
+
+	// SSHCapability allows a user to ssh to machines and units.
+	SSHCapability Capability = "ssh"
+
+	// ExposeCapability allows a user to expose and unexpose
+	// applications.
+	ExposeCapability Capability = "expose"
+
+	// RelateCapability allows a user to add and remove relations.
+	RelateCapability Capability = "relate"
+
+	// StorageCapability allows a user to add, attach, detach and
+	// remove storage.
+	StorageCapability Capability = "storage"
+)
+
+// AllCapabilities holds every known capability.
+var AllCapabilities = []Capability{
+	DeployCapability,
+	RemoveCapability,
+	ConfigCapability,
+	ActionsCapability,
+	SSHCapability,
+	ExposeCapability,
+	RelateCapability,
+	StorageCapability,
+}
+
+// Validate returns an error if the capability is not known.
+func (c Capability) Validate() error {
+	for _, known := range AllCapabilities {
+		if c == known {
+			return nil
+		}
+	}
+	return errors.NotValidf("capability %q", string(c))
+}
+
+// ImpliedBy returns the model access level which grants the
+// capability without the need for a role.
+func (c Capability) ImpliedBy() Access {
+	if c == SSHCapability {
+		return AdminAccess
+	}
+	return WriteAccess
+}
+
+var validRoleName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// IsValidRoleName returns whether name is a valid role name.
+func IsValidRoleName(name string) bool {
+	return validRoleName.MatchString(name)
+}
+
+// Role is a named set of capabilities which may be granted to users
+// on a model.
+type Role struct {
+	// Name uniquely identifies the role within a controller.
+	Name string
+
+	// Capabilities holds the capabilities conferred by the role.
+	Capabilities []Capability
+}
+
+// Validate returns an error if the role has an invalid name, or
+// holds no or unknown capabilities.
+func (r Role) Validate() error {
+	if !IsValidRoleName(r.Name) {
+		return errors.NotValidf("role name %q", r.Name)
+	}
+	if len(r.Capabilities) == 0 {
+		return errors.NotValidf("role %q with no capabilities", r.Name)
+	}
+	for _, c := range r.Capabilities {
+		if err := c.Validate(); err != nil {
+			return errors.Annotatef(err, "role %q", r.Name)
+		}
+	}
+	return nil
+}
+
+// HasCapability returns whether the role confers the given
+// capability.
+func (r Role) HasCapability(capability Capability) bool {
+	for _, c := range r.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
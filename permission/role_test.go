@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/permission"
+)
+
+type roleSuite struct{}
+
+var _ = gc.Suite(&roleSuite{})
+
+func (*roleSuite) TestCapabilityValidate(c *gc.C) {
+	for _, capability := range permission.AllCapabilities {
+		c.Check(capability.Validate(), jc.ErrorIsNil)
+	}
+	err := permission.Capability("fly").Validate()
+	c.Check(err, gc.ErrorMatches, `capability "fly" not valid`)
+}
+
+func (*roleSuite) TestCapabilityImpliedBy(c *gc.C) {
+	c.Check(permission.SSHCapability.ImpliedBy(), gc.Equals, permission.AdminAccess)
+	c.Check(permission.DeployCapability.ImpliedBy(), gc.Equals, permission.WriteAccess)
+	c.Check(permission.ActionsCapability.ImpliedBy(), gc.Equals, permission.WriteAccess)
+}
+
+func (*roleSuite) TestIsValidRoleName(c *gc.C) {
+	for name, valid := range map[string]bool{
+		"operator":      true,
+		"action-runner": true,
+		"ops2":          true,
+		"":              false,
+		"2ops":          false,
+		"Operator":      false,
+		"ops-":          false,
+		"ops--runner":   false,
+		"ops runner":    false,
+	} {
+		c.Check(permission.IsValidRoleName(name), gc.Equals, valid, gc.Commentf("%q", name))
+	}
+}
+
+func (*roleSuite) TestRoleValidate(c *gc.C) {
+	role := permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{permission.ActionsCapability, permission.SSHCapability},
+	}
+	c.Check(role.Validate(), jc.ErrorIsNil)
+
+	role.Name = "Bad Name"
+	c.Check(role.Validate(), gc.ErrorMatches, `role name "Bad Name" not valid`)
+
+	role.Name = "operator"
+	role.Capabilities = nil
+	c.Check(role.Validate(), gc.ErrorMatches, `role "operator" with no capabilities not valid`)
+
+	role.Capabilities = []permission.Capability{"fly"}
+	c.Check(role.Validate(), gc.ErrorMatches, `role "operator": capability "fly" not valid`)
+}
+
+func (*roleSuite) TestRoleHasCapability(c *gc.C) {
+	role := permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{permission.ActionsCapability},
+	}
+	c.Check(role.HasCapability(permission.ActionsCapability), jc.IsTrue)
+	c.Check(role.HasCapability(permission.DeployCapability), jc.IsFalse)
+}
@@ -111,6 +111,9 @@ type azureEnviron struct {
 
 var _ environs.Environ = (*azureEnviron)(nil)
 var _ state.Prechecker = (*azureEnviron)(nil)
+var _ environs.ModelFirewaller = (*azureEnviron)(nil)
+var _ environs.InstanceTagger = (*azureEnviron)(nil)
+var _ environs.ModelResourceTagger = (*azureEnviron)(nil)
 
 // newEnviron creates a new azureEnviron.
 func newEnviron(
@@ -282,7 +285,7 @@ func (env *azureEnviron) createCommonResourceDeployment(
 ) error {
 	const apiPort = -1
 	commonResources := networkTemplateResources(
-		env.location, tags, apiPort, rules,
+		env.location, tags, apiPort, env.Config().SSHAllow(), nil, rules,
 	)
 	commonResources = append(commonResources, storageAccountTemplateResource(
 		env.location, tags,
@@ -579,7 +582,14 @@ func (env *azureEnviron) createVirtualMachine(
 	if createCommonResources {
 		// We're starting the bootstrap machine, so we will create the
 		// common resources in the same deployment.
-		commonResources := networkTemplateResources(env.location, envTags, apiPort, nil)
+		var apiAllow []string
+		if instanceConfig.Controller != nil {
+			apiAllow = instanceConfig.Controller.Config.APIAllow()
+		}
+		commonResources := networkTemplateResources(
+			env.location, envTags, apiPort,
+			env.Config().SSHAllow(), apiAllow, nil,
+		)
 		commonResources = append(commonResources, storageAccountTemplateResource(
 			env.location, envTags,
 			env.storageAccountName, storageAccountType,
@@ -1239,7 +1249,8 @@ func (env *azureEnviron) instances(
 func (env *azureEnviron) AdoptResources(controllerUUID string, fromVersion version.Number) error {
 	groupClient := resources.GroupsClient{env.resources}
 
-	err := env.updateGroupControllerTag(&groupClient, env.resourceGroup, controllerUUID)
+	controllerTag := map[string]string{tags.JujuController: controllerUUID}
+	err := env.updateGroupTags(&groupClient, env.resourceGroup, controllerTag)
 	if err != nil {
 		// If we can't update the group there's no point updating the
 		// contained resources - the group will be killed if the
@@ -1247,12 +1258,71 @@ func (env *azureEnviron) AdoptResources(controllerUUID string, fromVersion versi
 		return errors.Trace(err)
 	}
 
+	failed, err := env.updateResourcesTags(func(resources.GenericResource) bool {
+		return true
+	}, controllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to update controller for some resources: %v", failed)
+	}
+	return nil
+}
+
+// TagInstance is part of the environs.InstanceTagger interface. The
+// tags are set on the virtual machine and on the resources created
+// with it, such as its network interface and public IP address.
+func (env *azureEnviron) TagInstance(id instance.Id, tags map[string]string) error {
+	failed, err := env.updateResourcesTags(func(resource resources.GenericResource) bool {
+		return toTags(resource.Tags)[jujuMachineNameTag] == string(id)
+	}, tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to update tags of some resources of instance %q: %v", id, failed)
+	}
+	return nil
+}
+
+// TagModelResources is part of the environs.ModelResourceTagger
+// interface. The tags are set on the model's resource group, and on
+// each resource in it that was not created for a particular virtual
+// machine, such as its network security groups, virtual network,
+// storage account and availability sets.
+func (env *azureEnviron) TagModelResources(tags map[string]string) error {
+	groupClient := resources.GroupsClient{env.resources}
+	if err := env.updateGroupTags(&groupClient, env.resourceGroup, tags); err != nil {
+		return errors.Trace(err)
+	}
+	failed, err := env.updateResourcesTags(func(resource resources.GenericResource) bool {
+		_, ok := toTags(resource.Tags)[jujuMachineNameTag]
+		return !ok
+	}, tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to update tags of some model resources: %v", failed)
+	}
+	return nil
+}
+
+// updateResourcesTags sets the given tags on each resource in the
+// model's resource group for which match returns true. It returns the
+// names of the resources that could not be updated.
+func (env *azureEnviron) updateResourcesTags(
+	match func(resources.GenericResource) bool,
+	resourceTags map[string]string,
+) ([]string, error) {
+	groupClient := resources.GroupsClient{env.resources}
 	resourceClient := resources.Client{env.resources}
 	var failed []string
 
 	apiVersions, err := collectAPIVersions(env.callAPI, env.resources)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	var res resources.ResourceListResult
@@ -1262,16 +1332,19 @@ func (env *azureEnviron) AdoptResources(controllerUUID string, fromVersion versi
 		return res.Response, err
 	})
 	if err != nil {
-		return errors.Annotate(err, "listing resources")
+		return nil, errors.Annotate(err, "listing resources")
 	}
 	for res.Value != nil {
 		for _, resource := range *res.Value {
+			if !match(resource) {
+				continue
+			}
 			// We need to set the API version to a value that's
 			// correct for the specific resource type. If we leave it
 			// as the version for the Microsoft.Resources provider we
 			// get NoRegisteredProviderFound errors.
 			resourceClient.APIVersion = apiVersions[to.String(resource.Type)]
-			err := env.updateResourceControllerTag(&resourceClient, resource, controllerUUID)
+			err := env.updateResourceTags(&resourceClient, resource, resourceTags)
 			if err != nil {
 				name := to.String(resource.Name)
 				logger.Errorf("error updating resource tags for %q: %v", name, err)
@@ -1284,17 +1357,15 @@ func (env *azureEnviron) AdoptResources(controllerUUID string, fromVersion versi
 			return res.Response, err
 		})
 		if err != nil {
-			return errors.Annotate(err, "getting next page of resources")
+			return nil, errors.Annotate(err, "getting next page of resources")
 		}
 	}
-
-	if len(failed) > 0 {
-		return errors.Errorf("failed to update controller for some resources: %v", failed)
-	}
-	return nil
+	return failed, nil
 }
 
-func (env *azureEnviron) updateGroupControllerTag(client *resources.GroupsClient, groupName, controllerUUID string) error {
+// updateGroupTags sets the given tags on the resource group, leaving
+// its other tags alone.
+func (env *azureEnviron) updateGroupTags(client *resources.GroupsClient, groupName string, newTags map[string]string) error {
 	var group resources.ResourceGroup
 	err := env.callAPI(func() (autorest.Response, error) {
 		var err error
@@ -1305,11 +1376,13 @@ func (env *azureEnviron) updateGroupControllerTag(client *resources.GroupsClient
 		return errors.Trace(err)
 	}
 
-	logger.Debugf("updating resource group %s juju controller uuid to %s",
-		to.String(group.Name), controllerUUID)
 	groupTags := toTags(group.Tags)
-	groupTags[tags.JujuController] = controllerUUID
-	group.Tags = to.StringMapPtr(groupTags)
+	if tagsUpToDate(groupTags, newTags) {
+		// No update needed.
+		return nil
+	}
+	logger.Debugf("updating tags of resource group %s: %v", to.String(group.Name), newTags)
+	group.Tags = to.StringMapPtr(applyTags(groupTags, newTags))
 
 	// The Azure API forbids specifying ProvisioningState on the update.
 	if group.Properties != nil {
@@ -1320,12 +1393,13 @@ func (env *azureEnviron) updateGroupControllerTag(client *resources.GroupsClient
 		res, err := client.CreateOrUpdate(groupName, group)
 		return res.Response, err
 	})
-	return errors.Annotatef(err, "updating controller for resource group %q", groupName)
+	return errors.Annotatef(err, "updating tags of resource group %q", groupName)
 }
 
-func (env *azureEnviron) updateResourceControllerTag(client *resources.Client, stubResource resources.GenericResource, controllerUUID string) error {
-	stubTags := toTags(stubResource.Tags)
-	if stubTags[tags.JujuController] == controllerUUID {
+// updateResourceTags sets the given tags on the resource, leaving its
+// other tags alone.
+func (env *azureEnviron) updateResourceTags(client *resources.Client, stubResource resources.GenericResource, newTags map[string]string) error {
+	if tagsUpToDate(toTags(stubResource.Tags), newTags) {
 		// No update needed.
 		return nil
 	}
@@ -1353,11 +1427,9 @@ func (env *azureEnviron) updateResourceControllerTag(client *resources.Client, s
 		return errors.Annotatef(err, "getting full resource %q", to.String(stubResource.Name))
 	}
 
-	logger.Debugf("updating %s (%s) juju controller uuid to %s",
-		to.String(resource.Name), to.String(resource.Type), controllerUUID)
-	resourceTags := toTags(resource.Tags)
-	resourceTags[tags.JujuController] = controllerUUID
-	resource.Tags = to.StringMapPtr(resourceTags)
+	logger.Debugf("updating tags of %s (%s): %v",
+		to.String(resource.Name), to.String(resource.Type), newTags)
+	resource.Tags = to.StringMapPtr(applyTags(toTags(resource.Tags), newTags))
 
 	err = env.callAPI(func() (autorest.Response, error) {
 		res, err := client.CreateOrUpdate(
@@ -1370,7 +1442,35 @@ func (env *azureEnviron) updateResourceControllerTag(client *resources.Client, s
 		)
 		return res.Response, err
 	})
-	return errors.Annotatef(err, "updating controller for %q", to.String(resource.Name))
+	return errors.Annotatef(err, "updating tags of %q", to.String(resource.Name))
+}
+
+// tagsUpToDate reports whether the existing tags already have each of
+// the new tags, and none of the tags given empty values.
+func tagsUpToDate(existing, newTags map[string]string) bool {
+	for k, v := range newTags {
+		value, ok := existing[k]
+		if (v == "" && ok) || (v != "" && value != v) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyTags sets the new tags in the existing ones, removing the tags
+// given empty values, and returns the result.
+func applyTags(existing, newTags map[string]string) map[string]string {
+	if existing == nil {
+		existing = make(map[string]string)
+	}
+	for k, v := range newTags {
+		if v == "" {
+			delete(existing, k)
+		} else {
+			existing[k] = v
+		}
+	}
+	return existing
 }
 
 // splitResourceType breaks the resource type into provider namespace,
@@ -1588,6 +1688,154 @@ func (env *azureEnviron) IngressRules() ([]jujunetwork.IngressRule, error) {
 	return nil, errNoFwGlobal
 }
 
+// OpenModelPorts is specified in the environs.ModelFirewaller interface.
+// The rules are added to the internal range of the network security
+// group shared by all machines in the model.
+func (env *azureEnviron) OpenModelPorts(rules []jujunetwork.IngressRule) error {
+	nsgClient := network.SecurityGroupsClient{env.network}
+	securityRuleClient := network.SecurityRulesClient{env.network}
+	var nsg network.SecurityGroup
+	if err := env.callAPI(func() (autorest.Response, error) {
+		var err error
+		nsg, err = nsgClient.Get(env.resourceGroup, internalSecurityGroupName, "")
+		return nsg.Response, err
+	}); err != nil {
+		return errors.Annotate(err, "querying network security group")
+	}
+	var securityRules []network.SecurityRule
+	if nsg.Properties.SecurityRules != nil {
+		securityRules = *nsg.Properties.SecurityRules
+	} else {
+		nsg.Properties.SecurityRules = &securityRules
+	}
+
+	for _, rule := range explodeIngressRules(rules) {
+		prefixes := sourceAddressPrefixes(rule.SourceCIDRs)
+		if rule.SourceCIDRs[0] == "*" {
+			prefixes = []string{"*"}
+		}
+		if len(prefixes) == 0 {
+			logger.Debugf("skipping non-IPv4 rule %s", rule)
+			continue
+		}
+		if _, ok := findModelSecurityRule(securityRules, rule.PortRange, prefixes[0]); ok {
+			continue
+		}
+		var protocol network.SecurityRuleProtocol
+		switch rule.Protocol {
+		case "tcp":
+			protocol = network.TCP
+		case "udp":
+			protocol = network.UDP
+		default:
+			return errors.Errorf("invalid protocol %q", rule.Protocol)
+		}
+		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMin, securityRuleInternalMax)
+		if err != nil {
+			return errors.Annotatef(err, "getting security rule priority for %s", rule)
+		}
+		ruleName := securityRuleName(modelSecurityRulePrefix, rule)
+		securityRule := network.SecurityRule{
+			Name: to.StringPtr(ruleName),
+			Properties: &network.SecurityRulePropertiesFormat{
+				Description:              to.StringPtr(rule.String()),
+				Protocol:                 protocol,
+				SourcePortRange:          to.StringPtr("*"),
+				DestinationPortRange:     to.StringPtr(securityRulePortRange(rule.PortRange)),
+				SourceAddressPrefix:      to.StringPtr(prefixes[0]),
+				DestinationAddressPrefix: to.StringPtr("*"),
+				Access:                   network.Allow,
+				Priority:                 to.Int32Ptr(priority),
+				Direction:                network.Inbound,
+			},
+		}
+		logger.Debugf("creating security rule %q", ruleName)
+		if err := env.callAPI(func() (autorest.Response, error) {
+			return securityRuleClient.CreateOrUpdate(
+				env.resourceGroup, internalSecurityGroupName, ruleName, securityRule,
+				nil, // abort channel
+			)
+		}); err != nil {
+			return errors.Annotatef(err, "creating security rule for %s", rule)
+		}
+		securityRules = append(securityRules, securityRule)
+	}
+	return nil
+}
+
+// CloseModelPorts is specified in the environs.ModelFirewaller interface.
+func (env *azureEnviron) CloseModelPorts(rules []jujunetwork.IngressRule) error {
+	nsgClient := network.SecurityGroupsClient{env.network}
+	securityRuleClient := network.SecurityRulesClient{env.network}
+	securityRules, err := networkSecurityRules(nsgClient, env.callAPI, env.resourceGroup)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rule := range explodeIngressRules(rules) {
+		prefixes := sourceAddressPrefixes(rule.SourceCIDRs)
+		if rule.SourceCIDRs[0] == "*" {
+			prefixes = []string{"*"}
+		}
+		if len(prefixes) == 0 {
+			continue
+		}
+		ruleName, ok := findModelSecurityRule(securityRules, rule.PortRange, prefixes[0])
+		if !ok {
+			continue
+		}
+		logger.Debugf("deleting security rule %q", ruleName)
+		var result autorest.Response
+		if err := env.callAPI(func() (autorest.Response, error) {
+			var err error
+			result, err = securityRuleClient.Delete(
+				env.resourceGroup, internalSecurityGroupName, ruleName,
+				nil, // abort channel
+			)
+			return result, err
+		}); err != nil {
+			if result.Response == nil || result.StatusCode != http.StatusNotFound {
+				return errors.Annotatef(err, "deleting security rule %q", ruleName)
+			}
+		}
+	}
+	return nil
+}
+
+// ModelIngressRules is specified in the environs.ModelFirewaller interface.
+// It returns the rules in the internal range of the network security
+// group shared by all machines in the model.
+func (env *azureEnviron) ModelIngressRules() ([]jujunetwork.IngressRule, error) {
+	nsgClient := network.SecurityGroupsClient{env.network}
+	securityRules, err := networkSecurityRules(nsgClient, env.callAPI, env.resourceGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	portSourceCIDRs := make(map[jujunetwork.PortRange][]string)
+	for _, rule := range securityRules {
+		portRange, ok := modelSecurityRulePortRange(rule)
+		if !ok {
+			continue
+		}
+		source := to.String(rule.Properties.SourceAddressPrefix)
+		if source == "*" {
+			source = "0.0.0.0/0"
+		}
+		portSourceCIDRs[portRange] = append(portSourceCIDRs[portRange], source)
+	}
+	var result []jujunetwork.IngressRule
+	for portRange, sourceCIDRs := range portSourceCIDRs {
+		rule, err := jujunetwork.NewIngressRule(
+			portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCIDRs...,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, rule)
+	}
+	jujunetwork.SortIngressRules(result)
+	return result, nil
+}
+
 // Provider is specified in the Environ interface.
 func (env *azureEnviron) Provider() environs.EnvironProvider {
 	return env.provider
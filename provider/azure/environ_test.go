@@ -42,6 +42,7 @@ import (
 	envtools "github.com/juju/juju/environs/tools"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/provider/azure"
 	"github.com/juju/juju/provider/azure/internal/armtemplates"
 	"github.com/juju/juju/provider/azure/internal/azureauth"
@@ -1575,6 +1576,110 @@ func (s *environSuite) TestAdoptResources(c *gc.C) {
 	c.Check(gTags[tags.JujuController], gc.Equals, "new-controller")
 }
 
+func (s *environSuite) TestTagInstance(c *gc.C) {
+	providersResult := makeProvidersResult()
+	resourcesResult := makeResourcesResult()
+	res1 := (*resourcesResult.Value)[0]
+	(*res1.Tags)["juju-machine-name"] = to.StringPtr("machine-0")
+	res2 := (*resourcesResult.Value)[1]
+	(*res2.Tags)["juju-machine-name"] = to.StringPtr("machine-1")
+
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{
+		s.makeSender(".*/providers", providersResult),
+		s.makeSender(".*/resourceGroups/juju-testenv-.*/resources", resourcesResult),
+
+		// Only the resources of machine-0 are updated.
+		s.makeSender(".*/resourcegroups/.*/providers/Beck.Replica/liars/scissor/boxing-day-blues", res1),
+		s.makeSender(".*/resourcegroups/.*/providers/Beck.Replica/liars/scissor/boxing-day-blues", res1),
+	}
+
+	err := env.(environs.InstanceTagger).TagInstance("machine-0", map[string]string{
+		"team":           "db",
+		"something else": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 4)
+
+	c.Check(s.requests[3].Method, gc.Equals, "PUT")
+	rTags := requestTags(c, s.requests[3])
+	c.Check(rTags["team"], gc.Equals, "db")
+	c.Check(rTags["juju-machine-name"], gc.Equals, "machine-0")
+	// Tags given empty values are removed.
+	_, ok := rTags["something else"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *environSuite) TestTagInstanceUpToDate(c *gc.C) {
+	providersResult := makeProvidersResult()
+	resourcesResult := makeResourcesResult()
+	res1 := (*resourcesResult.Value)[0]
+	(*res1.Tags)["juju-machine-name"] = to.StringPtr("machine-0")
+
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{
+		s.makeSender(".*/providers", providersResult),
+		s.makeSender(".*/resourceGroups/juju-testenv-.*/resources", resourcesResult),
+	}
+
+	// Tags that are already set, or already absent, need no update.
+	err := env.(environs.InstanceTagger).TagInstance("machine-0", map[string]string{
+		"something else": "good",
+		"team":           "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+}
+
+func (s *environSuite) TestTagModelResources(c *gc.C) {
+	providersResult := makeProvidersResult()
+	resourcesResult := makeResourcesResult()
+	res1 := (*resourcesResult.Value)[0]
+	(*res1.Tags)["juju-machine-name"] = to.StringPtr("machine-0")
+	res2 := (*resourcesResult.Value)[1]
+
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{
+		s.makeSender(".*/resourcegroups/juju-testenv-.*", makeResourceGroupResult()),
+		s.makeSender(".*/resourcegroups/juju-testenv-.*", nil),
+
+		s.makeSender(".*/providers", providersResult),
+		s.makeSender(".*/resourceGroups/juju-testenv-.*/resources", resourcesResult),
+
+		// Only the resource not created for a machine is updated.
+		s.makeSender(".*/resourcegroups/.*/providers/Tuneyards.Bizness//micachu/drop-dead", res2),
+		s.makeSender(".*/resourcegroups/.*/providers/Tuneyards.Bizness//micachu/drop-dead", res2),
+	}
+
+	err := env.(environs.ModelResourceTagger).TagModelResources(map[string]string{
+		"team":           "db",
+		"something else": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 6)
+
+	for _, i := range []int{1, 5} {
+		c.Check(s.requests[i].Method, gc.Equals, "PUT")
+		rTags := requestTags(c, s.requests[i])
+		c.Check(rTags["team"], gc.Equals, "db")
+		c.Check(rTags[tags.JujuController], gc.Equals, "old-controller")
+		_, ok := rTags["something else"]
+		c.Check(ok, jc.IsFalse)
+	}
+}
+
+// requestTags returns the tags of the resource, or resource group,
+// in the body of the given request.
+func requestTags(c *gc.C, req *http.Request) map[string]string {
+	data := make([]byte, req.ContentLength)
+	_, err := req.Body.Read(data)
+	c.Assert(err, jc.ErrorIsNil)
+	var resource resources.GenericResource
+	err = json.Unmarshal(data, &resource)
+	c.Assert(err, jc.ErrorIsNil)
+	return to.StringMap(*resource.Tags)
+}
+
 func makeProvidersResult() resources.ProviderListResult {
 	providers := []resources.Provider{{
 		Namespace: to.StringPtr("Beck.Replica"),
@@ -1774,3 +1879,43 @@ func (s *environSuite) TestAdoptResourcesErrorUpdating(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `failed to update controller for some resources: \[boxing-day-blues\]`)
 	c.Check(s.requests, gc.HasLen, 8)
 }
+
+func (s *environSuite) TestModelIngressRules(c *gc.C) {
+	env := s.openEnviron(c)
+	ssh := makeSecurityRule("SSHInbound", "*", "22")
+	ssh.Properties.SourceAddressPrefix = to.StringPtr("*")
+	ssh.Properties.Priority = to.Int32Ptr(100)
+	sshFromLAN := makeSecurityRule("SSHInbound-1", "*", "22")
+	sshFromLAN.Properties.SourceAddressPrefix = to.StringPtr("10.0.0.0/8")
+	sshFromLAN.Properties.Priority = to.Int32Ptr(101)
+	s.sender = azuretesting.Senders{networkSecurityGroupSender([]network.SecurityRule{
+		ssh, sshFromLAN,
+		// Rules outside the internal range belong to machines.
+		makeSecurityRule("machine-0-80", "192.168.0.4", "80"),
+	})}
+
+	rules, err := env.(environs.ModelFirewaller).ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0", "10.0.0.0/8"),
+	})
+}
+
+func (s *environSuite) TestCloseModelPorts(c *gc.C) {
+	env := s.openEnviron(c)
+	ssh := makeSecurityRule("SSHInbound", "*", "22")
+	ssh.Properties.SourceAddressPrefix = to.StringPtr("*")
+	ssh.Properties.Priority = to.Int32Ptr(100)
+	s.sender = azuretesting.Senders{
+		networkSecurityGroupSender([]network.SecurityRule{ssh}),
+		mocks.NewSender(),
+	}
+
+	err := env.(environs.ModelFirewaller).CloseModelPorts([]jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0", "::/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "DELETE")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, securityRulePath("SSHInbound"))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/juju/errors"
 
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/provider/azure/internal/armtemplates"
 	"github.com/juju/juju/provider/azure/internal/iputils"
 )
@@ -66,15 +67,21 @@ const (
 const (
 	// securityRuleInternalSSHInbound is the priority of the
 	// security rule that allows inbound SSH access to all
-	// machines.
+	// machines. If SSH access is allowed from more than one
+	// source, the rules for the other sources follow on.
 	securityRuleInternalSSHInbound = securityRuleInternalMin + iota
 
 	// securityRuleInternalAPIInbound is the priority of the
 	// security rule that allows inbound Juju API access to
-	// controller machines
+	// controller machines, when SSH access is allowed from a
+	// single source.
 	securityRuleInternalAPIInbound
 )
 
+// modelSecurityRulePrefix is the prefix of the names of the security
+// rules added to the internal range by the model's firewaller.
+const modelSecurityRulePrefix = "model-"
+
 var (
 	sshSecurityRule = network.SecurityRule{
 		Name: to.StringPtr("SSHInbound"),
@@ -108,7 +115,8 @@ var (
 )
 
 // networkTemplateResources returns resource definitions for creating network
-// resources shared by all machines in a model.
+// resources shared by all machines in a model. SSH access is allowed
+// from the sshAllow CIDRs, and Juju API access from the apiAllow CIDRs.
 //
 // If apiPort is -1, then there should be no controller subnet created, and
 // no network security rule allowing Juju API traffic.
@@ -116,18 +124,24 @@ func networkTemplateResources(
 	location string,
 	envTags map[string]string,
 	apiPort int,
+	sshAllow, apiAllow []string,
 	extraRules []network.SecurityRule,
 ) []armtemplates.Resource {
 	// Create a network security group for the environment. There is only
 	// one NSG per environment (there's a limit of 100 per subscription),
 	// in which we manage rules for each exposed machine.
-	securityRules := []network.SecurityRule{sshSecurityRule}
+	securityRules := sourceSecurityRules(sshSecurityRule, sshAllow)
 	if apiPort != -1 {
 		apiSecurityRule := apiSecurityRule
 		properties := *apiSecurityRule.Properties
 		properties.DestinationPortRange = to.StringPtr(fmt.Sprint(apiPort))
 		apiSecurityRule.Properties = &properties
-		securityRules = append(securityRules, apiSecurityRule)
+		securityRules = append(securityRules, sourceSecurityRules(apiSecurityRule, apiAllow)...)
+	}
+	for i := range securityRules {
+		properties := *securityRules[i].Properties
+		properties.Priority = to.Int32Ptr(securityRuleInternalMin + int32(i))
+		securityRules[i].Properties = &properties
 	}
 	securityRules = append(securityRules, extraRules...)
 
@@ -182,6 +196,97 @@ func networkTemplateResources(
 	return resources
 }
 
+// sourceSecurityRules returns copies of the given security rule
+// allowing access from each of the given CIDRs. Azure network
+// security groups only support IPv4 prefixes, so any others are
+// skipped. The first rule keeps the name of the given rule.
+func sourceSecurityRules(rule network.SecurityRule, sourceCIDRs []string) []network.SecurityRule {
+	var rules []network.SecurityRule
+	for _, prefix := range sourceAddressPrefixes(sourceCIDRs) {
+		rule := rule
+		if len(rules) > 0 {
+			rule.Name = to.StringPtr(fmt.Sprintf("%s-%d", to.String(rule.Name), len(rules)))
+		}
+		properties := *rule.Properties
+		properties.SourceAddressPrefix = to.StringPtr(prefix)
+		rule.Properties = &properties
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// sourceAddressPrefixes returns the security rule source address
+// prefixes for the IPv4 CIDRs in the given list. Access from
+// anywhere is represented by "*".
+func sourceAddressPrefixes(cidrs []string) []string {
+	var prefixes []string
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil || ip.To4() == nil {
+			continue
+		}
+		if cidr == "0.0.0.0/0" {
+			cidr = "*"
+		}
+		prefixes = append(prefixes, cidr)
+	}
+	return prefixes
+}
+
+// modelSecurityRulePortRange returns the port range of the given
+// security rule, if it is an inbound TCP or UDP rule in the internal
+// range of priorities.
+func modelSecurityRulePortRange(rule network.SecurityRule) (jujunetwork.PortRange, bool) {
+	properties := rule.Properties
+	if properties == nil || properties.Direction != network.Inbound || properties.Access != network.Allow {
+		return jujunetwork.PortRange{}, false
+	}
+	priority := to.Int32(properties.Priority)
+	if priority < securityRuleInternalMin || priority > securityRuleInternalMax {
+		return jujunetwork.PortRange{}, false
+	}
+	var protocol string
+	switch properties.Protocol {
+	case network.TCP:
+		protocol = "tcp"
+	case network.UDP:
+		protocol = "udp"
+	default:
+		return jujunetwork.PortRange{}, false
+	}
+	portRange, err := jujunetwork.ParsePortRange(to.String(properties.DestinationPortRange))
+	if err != nil {
+		return jujunetwork.PortRange{}, false
+	}
+	portRange.Protocol = protocol
+	return portRange, true
+}
+
+// findModelSecurityRule returns the name of the security rule in the
+// internal range of priorities that allows access to the given port
+// range from the given source address prefix.
+func findModelSecurityRule(rules []network.SecurityRule, portRange jujunetwork.PortRange, source string) (string, bool) {
+	for _, rule := range rules {
+		ruleRange, ok := modelSecurityRulePortRange(rule)
+		if !ok || ruleRange != portRange {
+			continue
+		}
+		if to.String(rule.Properties.SourceAddressPrefix) == source {
+			return to.String(rule.Name), true
+		}
+	}
+	return "", false
+}
+
+// securityRulePortRange returns the destination port range of a
+// security rule allowing access to the given port range.
+func securityRulePortRange(portRange jujunetwork.PortRange) string {
+	if portRange.FromPort != portRange.ToPort {
+		return fmt.Sprintf("%d-%d", portRange.FromPort, portRange.ToPort)
+	}
+	return fmt.Sprint(portRange.FromPort)
+}
+
 // nextSecurityRulePriority returns the next available priority in the given
 // security group within a specified range.
 func nextSecurityRulePriority(group network.SecurityGroup, min, max int32) (int32, error) {
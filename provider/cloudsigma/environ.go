@@ -117,6 +117,12 @@ func (env *environ) Destroy() error {
 	return common.Destroy(env)
 }
 
+// DestroyKeepingInstances is specified in the InstanceKeepingDestroyer
+// interface.
+func (env *environ) DestroyKeepingInstances(keep []instance.Id) error {
+	return common.DestroyKeepingInstances(env, keep)
+}
+
 // DestroyController implements the Environ interface.
 func (env *environ) DestroyController(controllerUUID string) error {
 	// TODO(wallyworld): destroy hosted model resources
@@ -178,6 +178,7 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		InstanceConfig:  instanceConfig,
 		Placement:       args.Placement,
 		ImageMetadata:   imageMetadata,
+		APIAllow:        args.ControllerConfig.APIAllow(),
 		StatusCallback:  instanceStatus,
 		CleanupCallback: statusCleanup,
 	})
@@ -17,8 +17,15 @@ import (
 // environs.Environ; we strongly recommend that this implementation be
 // used when writing a new provider.
 func Destroy(env environs.Environ) error {
+	return DestroyKeepingInstances(env, nil)
+}
+
+// DestroyKeepingInstances is like Destroy, but leaves the instances with
+// the given ids running. It is a common implementation of the method
+// defined on environs.InstanceKeepingDestroyer.
+func DestroyKeepingInstances(env environs.Environ, keep []instance.Id) error {
 	logger.Infof("destroying model %q", env.Config().Name())
-	if err := destroyInstances(env); err != nil {
+	if err := destroyInstances(env, keep); err != nil {
 		return errors.Annotate(err, "destroying instances")
 	}
 	if err := destroyStorage(env); err != nil {
@@ -27,14 +34,22 @@ func Destroy(env environs.Environ) error {
 	return nil
 }
 
-func destroyInstances(env environs.Environ) error {
+func destroyInstances(env environs.Environ, keep []instance.Id) error {
 	logger.Infof("destroying instances")
 	instances, err := env.AllInstances()
 	switch err {
 	case nil:
-		ids := make([]instance.Id, len(instances))
-		for i, inst := range instances {
-			ids[i] = inst.Id()
+		kept := make(map[instance.Id]bool, len(keep))
+		for _, id := range keep {
+			kept[id] = true
+		}
+		ids := make([]instance.Id, 0, len(instances))
+		for _, inst := range instances {
+			if kept[inst.Id()] {
+				logger.Infof("keeping instance %q", inst.Id())
+				continue
+			}
+			ids = append(ids, inst.Id())
 		}
 		if err := env.StopInstances(ids...); err != nil {
 			return err
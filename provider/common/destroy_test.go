@@ -110,6 +110,27 @@ func (s *DestroySuite) TestSuccess(c *gc.C) {
 	r.Close()
 }
 
+func (s *DestroySuite) TestDestroyKeepingInstances(c *gc.C) {
+	var stopped []instance.Id
+	env := &mockEnviron{
+		allInstances: func() ([]instance.Instance, error) {
+			return []instance.Instance{
+				&mockInstance{id: "one"},
+				&mockInstance{id: "kept"},
+				&mockInstance{id: "another"},
+			}, nil
+		},
+		stopInstances: func(ids []instance.Id) error {
+			stopped = ids
+			return nil
+		},
+		config: configGetter(c),
+	}
+	err := common.DestroyKeepingInstances(env, []instance.Id{"kept"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stopped, jc.DeepEquals, []instance.Id{"one", "another"})
+}
+
 func (s *DestroySuite) TestSuccessWhenNoInstances(c *gc.C) {
 	s.PatchValue(&jujuversion.Current, testing.FakeVersionNumber)
 	stor := newStorage(s, c)
@@ -40,6 +40,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
@@ -1470,6 +1471,7 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	rules        network.IngressRuleSlice
+	egress       map[network.PortRange]set.Strings
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1647,6 +1649,88 @@ func (inst *dummyInstance) IngressRules(machineId string) (rules []network.Ingre
 	return
 }
 
+var _ instance.EgressFirewaller = (*dummyInstance)(nil)
+
+// OpenEgress is specified in the instance.EgressFirewaller interface.
+func (inst *dummyInstance) OpenEgress(machineId string, rules []network.EgressRule) error {
+	defer delay()
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("OpenEgress with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("OpenEgress"); err != nil {
+		return err
+	}
+	if inst.egress == nil {
+		inst.egress = make(map[network.PortRange]set.Strings)
+	}
+	for _, r := range rules {
+		cidrs, ok := inst.egress[r.PortRange]
+		if !ok {
+			cidrs = set.NewStrings()
+			inst.egress[r.PortRange] = cidrs
+		}
+		if len(r.DestinationCIDRs) == 0 {
+			cidrs.Add("0.0.0.0/0")
+		}
+		for _, cidr := range r.DestinationCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+	return nil
+}
+
+// CloseEgress is specified in the instance.EgressFirewaller interface.
+func (inst *dummyInstance) CloseEgress(machineId string, rules []network.EgressRule) error {
+	defer delay()
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("CloseEgress with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("CloseEgress"); err != nil {
+		return err
+	}
+	for _, r := range rules {
+		cidrs, ok := inst.egress[r.PortRange]
+		if !ok {
+			continue
+		}
+		if len(r.DestinationCIDRs) == 0 {
+			cidrs.Remove("0.0.0.0/0")
+		}
+		for _, cidr := range r.DestinationCIDRs {
+			cidrs.Remove(cidr)
+		}
+		if cidrs.IsEmpty() {
+			delete(inst.egress, r.PortRange)
+		}
+	}
+	return nil
+}
+
+// EgressRules is specified in the instance.EgressFirewaller interface.
+func (inst *dummyInstance) EgressRules(machineId string) (rules []network.EgressRule, err error) {
+	defer delay()
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("EgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("EgressRules"); err != nil {
+		return nil, err
+	}
+	for portRange, cidrs := range inst.egress {
+		rules = append(rules, network.EgressRule{
+			PortRange:        portRange,
+			DestinationCIDRs: cidrs.SortedValues(),
+		})
+	}
+	network.SortEgressRules(rules)
+	return
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
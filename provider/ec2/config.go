@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -112,9 +113,28 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 		}
 	}
 
+	if err := validateSSHAllow(ecfg.SSHAllow()); err != nil {
+		return nil, err
+	}
+
 	// ssl-hostname-verification cannot be disabled
 	if !ecfg.SSLHostnameVerification() {
 		return nil, fmt.Errorf("disabling ssh-hostname-verification is not supported")
 	}
 	return ecfg, nil
 }
+
+// validateSSHAllow returns an error if the ssh-allow setting has IPv6
+// CIDRs, which EC2 security groups in this provider cannot apply. The
+// ::/0 CIDR, included by default to allow access from anywhere, is
+// accepted and ignored.
+func validateSSHAllow(cidrs []string) error {
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil || ip.To4() != nil || cidr == "::/0" {
+			continue
+		}
+		return fmt.Errorf("%s: IPv6 CIDR %q not supported", config.SSHAllowKey, cidr)
+	}
+	return nil
+}
@@ -163,6 +163,15 @@ var configTests = []configTest{
 		err:        `.*cannot use vpc-id-force without specifying vpc-id as well`,
 		vpcID:      "",
 		forceVPCID: true,
+	}, {
+		config: attrs{
+			"ssh-allow": "10.0.0.0/8,::/0",
+		},
+	}, {
+		config: attrs{
+			"ssh-allow": "10.0.0.0/8,2001:db8::/32",
+		},
+		err: `.*ssh-allow: IPv6 CIDR "2001:db8::/32" not supported`,
 	}, {
 		config: attrs{
 			"vpc-id": "vpc-a1b2c3d4",
@@ -0,0 +1,292 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// ec2APIVersion is the version of the EC2 API used for the requests
+// that the gopkg.in/amz.v3/ec2 client has no methods for.
+const ec2APIVersion = "2016-11-15"
+
+// ec2Query sends a request for the given action to the client's
+// endpoint, signed with the client's credentials, and decodes the
+// response into resp if it is not nil. Failed requests return an
+// *ec2.Error, as the client's own methods do.
+var ec2Query = func(client *ec2.EC2, action string, params map[string]string, resp interface{}) error {
+	req, err := http.NewRequest("GET", client.Region.EC2Endpoint, nil)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	query := req.URL.Query()
+	for name, value := range params {
+		query.Add(name, value)
+	}
+	query.Add("Action", action)
+	query.Add("Version", ec2APIVersion)
+	query.Add("Timestamp", now.Format(time.RFC3339))
+	req.URL.RawQuery = query.Encode()
+	req.Header.Set("x-amz-date", now.Format(aws.ISO8601BasicFormat))
+	if err := client.Sign(req, client.Auth); err != nil {
+		return err
+	}
+
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		var errResp struct {
+			RequestId string      `xml:"RequestID"`
+			Errors    []ec2.Error `xml:"Errors>Error"`
+		}
+		xml.NewDecoder(r.Body).Decode(&errResp)
+		var ec2Err ec2.Error
+		if len(errResp.Errors) > 0 {
+			ec2Err = errResp.Errors[0]
+		}
+		ec2Err.RequestId = errResp.RequestId
+		ec2Err.StatusCode = r.StatusCode
+		if ec2Err.Message == "" {
+			ec2Err.Message = r.Status
+		}
+		return &ec2Err
+	}
+	if resp == nil {
+		return nil
+	}
+	return xml.NewDecoder(r.Body).Decode(resp)
+}
+
+// addIPPermParams adds the parameters describing perms to params,
+// as ec2.AuthorizeSecurityGroup does. For egress permissions, the
+// SourceIPs and SourceGroups hold the destinations.
+func addIPPermParams(params map[string]string, perms []ec2.IPPerm) {
+	for i, perm := range perms {
+		prefix := "IpPermissions." + strconv.Itoa(i+1)
+		params[prefix+".IpProtocol"] = perm.Protocol
+		params[prefix+".FromPort"] = strconv.Itoa(perm.FromPort)
+		params[prefix+".ToPort"] = strconv.Itoa(perm.ToPort)
+		for j, ip := range perm.SourceIPs {
+			params[prefix+".IpRanges."+strconv.Itoa(j+1)+".CidrIp"] = ip
+		}
+		for j, g := range perm.SourceGroups {
+			subprefix := prefix + ".Groups." + strconv.Itoa(j+1)
+			if g.OwnerId != "" {
+				params[subprefix+".UserId"] = g.OwnerId
+			}
+			params[subprefix+".GroupId"] = g.Id
+		}
+	}
+}
+
+// authorizeSecurityGroupEgress adds egress permissions to a VPC
+// security group.
+func authorizeSecurityGroupEgress(client *ec2.EC2, group ec2.SecurityGroup, perms []ec2.IPPerm) error {
+	params := map[string]string{"GroupId": group.Id}
+	addIPPermParams(params, perms)
+	return ec2Query(client, "AuthorizeSecurityGroupEgress", params, nil)
+}
+
+// revokeSecurityGroupEgress removes egress permissions from a VPC
+// security group.
+func revokeSecurityGroupEgress(client *ec2.EC2, group ec2.SecurityGroup, perms []ec2.IPPerm) error {
+	params := map[string]string{"GroupId": group.Id}
+	addIPPermParams(params, perms)
+	return ec2Query(client, "RevokeSecurityGroupEgress", params, nil)
+}
+
+// securityGroupEgress returns the egress permissions of a VPC
+// security group.
+func securityGroupEgress(client *ec2.EC2, group ec2.SecurityGroup) ([]ec2.IPPerm, error) {
+	var resp struct {
+		Groups []struct {
+			IPPermsEgress []ec2.IPPerm `xml:"ipPermissionsEgress>item"`
+		} `xml:"securityGroupInfo>item"`
+	}
+	params := map[string]string{"GroupId.1": group.Id}
+	if err := ec2Query(client, "DescribeSecurityGroups", params, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Groups) != 1 {
+		return nil, errors.NotFoundf("security group %q", group.Id)
+	}
+	return resp.Groups[0].IPPermsEgress, nil
+}
+
+// modifyInstanceGroups replaces the security groups of a VPC instance
+// with the groups with the given IDs.
+func modifyInstanceGroups(client *ec2.EC2, instId string, groupIds []string) error {
+	params := map[string]string{"InstanceId": instId}
+	for i, id := range groupIds {
+		params["GroupId."+strconv.Itoa(i+1)] = id
+	}
+	return ec2Query(client, "ModifyInstanceAttribute", params, nil)
+}
+
+// deleteTags deletes the tags with the given keys, whatever their
+// values, from each of the resources.
+func deleteTags(client *ec2.EC2, keys []string, resourceIds ...string) error {
+	params := make(map[string]string)
+	for i, id := range resourceIds {
+		params["ResourceId."+strconv.Itoa(i+1)] = id
+	}
+	for i, key := range keys {
+		params["Tag."+strconv.Itoa(i+1)+".Key"] = key
+	}
+	return ec2Query(client, "DeleteTags", params, nil)
+}
+
+// dedicatedHostZone returns the name of the availability zone of the
+// dedicated host with the given ID.
+func dedicatedHostZone(client *ec2.EC2, hostId string) (string, error) {
+	var resp struct {
+		Hosts []struct {
+			AvailZone string `xml:"availabilityZone"`
+		} `xml:"hostSet>item"`
+	}
+	params := map[string]string{"HostId.1": hostId}
+	if err := ec2Query(client, "DescribeHosts", params, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Hosts) != 1 {
+		return "", errors.NotFoundf("dedicated host %q", hostId)
+	}
+	return resp.Hosts[0].AvailZone, nil
+}
+
+// createPlacementGroup creates a placement group with the given name
+// and strategy.
+func createPlacementGroup(client *ec2.EC2, name, strategy string) error {
+	params := map[string]string{
+		"GroupName": name,
+		"Strategy":  strategy,
+	}
+	return ec2Query(client, "CreatePlacementGroup", params, nil)
+}
+
+// placementGroupNames returns the names of the placement groups whose
+// names match the given pattern, which may contain wildcards.
+func placementGroupNames(client *ec2.EC2, pattern string) ([]string, error) {
+	var resp struct {
+		Groups []struct {
+			Name string `xml:"groupName"`
+		} `xml:"placementGroupSet>item"`
+	}
+	params := map[string]string{
+		"Filter.1.Name":    "group-name",
+		"Filter.1.Value.1": pattern,
+	}
+	if err := ec2Query(client, "DescribePlacementGroups", params, &resp); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(resp.Groups))
+	for i, g := range resp.Groups {
+		names[i] = g.Name
+	}
+	return names, nil
+}
+
+// deletePlacementGroup deletes the placement group with the given
+// name.
+func deletePlacementGroup(client *ec2.EC2, name string) error {
+	return ec2Query(client, "DeletePlacementGroup", map[string]string{"GroupName": name}, nil)
+}
+
+// runInstancesQuery starts instances as ec2.EC2.RunInstances does,
+// with additional request parameters that ri has no fields for, such
+// as those requesting spot instances. ri's network interfaces are not
+// supported, as Juju does not use them.
+func runInstancesQuery(client *ec2.EC2, ri *ec2.RunInstances, extraParams map[string]string) (*ec2.RunInstancesResp, error) {
+	if len(ri.NetworkInterfaces) > 0 {
+		return nil, errors.NotSupportedf("network interfaces")
+	}
+	params := map[string]string{
+		"ImageId":      ri.ImageId,
+		"InstanceType": ri.InstanceType,
+		"MinCount":     strconv.Itoa(ri.MinCount),
+		"MaxCount":     strconv.Itoa(ri.MaxCount),
+	}
+	if ri.MinCount == 0 {
+		params["MinCount"] = "1"
+	}
+	if ri.MaxCount == 0 {
+		params["MaxCount"] = params["MinCount"]
+	}
+	groupIds, groupNames := 1, 1
+	for _, g := range ri.SecurityGroups {
+		if g.Id != "" {
+			params["SecurityGroupId."+strconv.Itoa(groupIds)] = g.Id
+			groupIds++
+		} else {
+			params["SecurityGroup."+strconv.Itoa(groupNames)] = g.Name
+			groupNames++
+		}
+	}
+	for i, b := range ri.BlockDeviceMappings {
+		prefix := "BlockDeviceMapping." + strconv.Itoa(i+1)
+		optionalParam(params, prefix+".DeviceName", b.DeviceName)
+		optionalParam(params, prefix+".VirtualName", b.VirtualName)
+		optionalParam(params, prefix+".Ebs.SnapshotId", b.SnapshotId)
+		optionalParam(params, prefix+".Ebs.VolumeType", b.VolumeType)
+		if b.VolumeSize > 0 {
+			params[prefix+".Ebs.VolumeSize"] = strconv.FormatInt(b.VolumeSize, 10)
+		}
+		if b.IOPS > 0 {
+			params[prefix+".Ebs.Iops"] = strconv.FormatInt(b.IOPS, 10)
+		}
+		if b.DeleteOnTermination {
+			params[prefix+".Ebs.DeleteOnTermination"] = "true"
+		}
+	}
+	if ri.UserData != nil {
+		params["UserData"] = base64.StdEncoding.EncodeToString(ri.UserData)
+	}
+	optionalParam(params, "KeyName", ri.KeyName)
+	optionalParam(params, "KernelId", ri.KernelId)
+	optionalParam(params, "RamdiskId", ri.RamdiskId)
+	optionalParam(params, "Placement.AvailabilityZone", ri.AvailZone)
+	optionalParam(params, "Placement.GroupName", ri.PlacementGroupName)
+	optionalParam(params, "SubnetId", ri.SubnetId)
+	optionalParam(params, "InstanceInitiatedShutdownBehavior", ri.ShutdownBehavior)
+	optionalParam(params, "PrivateIpAddress", ri.PrivateIPAddress)
+	optionalParam(params, "IamInstanceProfile.Name", ri.IAMInstanceProfile)
+	if ri.Monitoring {
+		params["Monitoring.Enabled"] = "true"
+	}
+	if ri.DisableAPITermination {
+		params["DisableApiTermination"] = "true"
+	}
+	if ri.EBSOptimized {
+		params["EbsOptimized"] = "true"
+	}
+	for name, value := range extraParams {
+		params[name] = value
+	}
+
+	var resp ec2.RunInstancesResp
+	if err := ec2Query(client, "RunInstances", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// optionalParam sets the named parameter in params, unless value is
+// empty.
+func optionalParam(params map[string]string, name, value string) {
+	if value != "" {
+		params[name] = value
+	}
+}
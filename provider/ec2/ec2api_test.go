@@ -0,0 +1,233 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+)
+
+type ec2APISuite struct {
+	server   *httptest.Server
+	client   *amzec2.EC2
+	requests []url.Values
+	response string
+	status   int
+}
+
+var _ = gc.Suite(&ec2APISuite{})
+
+func (s *ec2APISuite) SetUpTest(c *gc.C) {
+	s.requests = nil
+	s.response = `<Response><return>true</return></Response>`
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Header.Get("Authorization"), gc.Not(gc.Equals), "")
+		s.requests = append(s.requests, req.URL.Query())
+		w.WriteHeader(s.status)
+		fmt.Fprint(w, s.response)
+	}))
+	region := aws.Region{Name: "test", EC2Endpoint: s.server.URL}
+	auth := aws.Auth{AccessKey: "access", SecretKey: "secret"}
+	s.client = amzec2.New(auth, region, aws.SignV4Factory(region.Name, "ec2"))
+}
+
+func (s *ec2APISuite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+func (s *ec2APISuite) TestAuthorizeSecurityGroupEgress(c *gc.C) {
+	err := authorizeSecurityGroupEgress(s.client, amzec2.SecurityGroup{Id: "sg-1"}, []amzec2.IPPerm{{
+		Protocol:     "tcp",
+		FromPort:     80,
+		ToPort:       443,
+		SourceIPs:    []string{"10.0.0.0/8"},
+		SourceGroups: []amzec2.UserSecurityGroup{{Id: "sg-2"}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	query := s.requests[0]
+	c.Check(query.Get("Action"), gc.Equals, "AuthorizeSecurityGroupEgress")
+	c.Check(query.Get("Version"), gc.Equals, ec2APIVersion)
+	c.Check(query.Get("GroupId"), gc.Equals, "sg-1")
+	c.Check(query.Get("IpPermissions.1.IpProtocol"), gc.Equals, "tcp")
+	c.Check(query.Get("IpPermissions.1.FromPort"), gc.Equals, "80")
+	c.Check(query.Get("IpPermissions.1.ToPort"), gc.Equals, "443")
+	c.Check(query.Get("IpPermissions.1.IpRanges.1.CidrIp"), gc.Equals, "10.0.0.0/8")
+	c.Check(query.Get("IpPermissions.1.Groups.1.GroupId"), gc.Equals, "sg-2")
+}
+
+func (s *ec2APISuite) TestModifyInstanceGroups(c *gc.C) {
+	err := modifyInstanceGroups(s.client, "i-1", []string{"sg-1", "sg-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	query := s.requests[0]
+	c.Check(query.Get("Action"), gc.Equals, "ModifyInstanceAttribute")
+	c.Check(query.Get("InstanceId"), gc.Equals, "i-1")
+	c.Check(query.Get("GroupId.1"), gc.Equals, "sg-1")
+	c.Check(query.Get("GroupId.2"), gc.Equals, "sg-2")
+}
+
+func (s *ec2APISuite) TestDeleteTags(c *gc.C) {
+	err := deleteTags(s.client, []string{"team", "cost-centre"}, "i-1", "vol-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	query := s.requests[0]
+	c.Check(query.Get("Action"), gc.Equals, "DeleteTags")
+	c.Check(query.Get("ResourceId.1"), gc.Equals, "i-1")
+	c.Check(query.Get("ResourceId.2"), gc.Equals, "vol-1")
+	c.Check(query.Get("Tag.1.Key"), gc.Equals, "team")
+	c.Check(query.Get("Tag.2.Key"), gc.Equals, "cost-centre")
+	c.Check(query["Tag.1.Value"], gc.IsNil)
+}
+
+func (s *ec2APISuite) TestDedicatedHostZone(c *gc.C) {
+	s.response = `
+<DescribeHostsResponse>
+  <hostSet>
+    <item>
+      <hostId>h-0123456789abcdef0</hostId>
+      <availabilityZone>us-east-1a</availabilityZone>
+    </item>
+  </hostSet>
+</DescribeHostsResponse>`
+	zone, err := dedicatedHostZone(s.client, "h-0123456789abcdef0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "us-east-1a")
+	c.Assert(s.requests, gc.HasLen, 1)
+	query := s.requests[0]
+	c.Check(query.Get("Action"), gc.Equals, "DescribeHosts")
+	c.Check(query.Get("HostId.1"), gc.Equals, "h-0123456789abcdef0")
+}
+
+func (s *ec2APISuite) TestDedicatedHostZoneNotFound(c *gc.C) {
+	s.response = `<DescribeHostsResponse><hostSet/></DescribeHostsResponse>`
+	_, err := dedicatedHostZone(s.client, "h-0123456789abcdef0")
+	c.Assert(err, gc.ErrorMatches, `dedicated host "h-0123456789abcdef0" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ec2APISuite) TestPlacementGroups(c *gc.C) {
+	err := createPlacementGroup(s.client, "juju-model-mysql", "spread")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.response = `
+<DescribePlacementGroupsResponse>
+  <placementGroupSet>
+    <item>
+      <groupName>juju-model-mysql</groupName>
+      <strategy>spread</strategy>
+    </item>
+  </placementGroupSet>
+</DescribePlacementGroupsResponse>`
+	names, err := placementGroupNames(s.client, "juju-model-*")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"juju-model-mysql"})
+
+	err = deletePlacementGroup(s.client, "juju-model-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Check(s.requests[0].Get("Action"), gc.Equals, "CreatePlacementGroup")
+	c.Check(s.requests[0].Get("GroupName"), gc.Equals, "juju-model-mysql")
+	c.Check(s.requests[0].Get("Strategy"), gc.Equals, "spread")
+	c.Check(s.requests[1].Get("Action"), gc.Equals, "DescribePlacementGroups")
+	c.Check(s.requests[1].Get("Filter.1.Name"), gc.Equals, "group-name")
+	c.Check(s.requests[1].Get("Filter.1.Value.1"), gc.Equals, "juju-model-*")
+	c.Check(s.requests[2].Get("Action"), gc.Equals, "DeletePlacementGroup")
+	c.Check(s.requests[2].Get("GroupName"), gc.Equals, "juju-model-mysql")
+}
+
+func (s *ec2APISuite) TestRunInstancesQuery(c *gc.C) {
+	s.response = `
+<RunInstancesResponse>
+  <reservationId>r-1</reservationId>
+  <instancesSet>
+    <item>
+      <instanceId>i-1</instanceId>
+      <instanceType>m3.medium</instanceType>
+    </item>
+  </instancesSet>
+</RunInstancesResponse>`
+	resp, err := runInstancesQuery(s.client, &amzec2.RunInstances{
+		ImageId:        "ami-1",
+		InstanceType:   "m3.medium",
+		MinCount:       1,
+		UserData:       []byte("data"),
+		AvailZone:      "us-east-1a",
+		SecurityGroups: []amzec2.SecurityGroup{{Id: "sg-1"}, {Name: "juju"}},
+		BlockDeviceMappings: []amzec2.BlockDeviceMapping{{
+			DeviceName: "/dev/sda1",
+			VolumeSize: 8,
+		}},
+	}, map[string]string{"InstanceMarketOptions.MarketType": "spot"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.ReservationId, gc.Equals, "r-1")
+	c.Assert(resp.Instances, gc.HasLen, 1)
+	c.Assert(resp.Instances[0].InstanceId, gc.Equals, "i-1")
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	query := s.requests[0]
+	c.Check(query.Get("Action"), gc.Equals, "RunInstances")
+	c.Check(query.Get("ImageId"), gc.Equals, "ami-1")
+	c.Check(query.Get("InstanceType"), gc.Equals, "m3.medium")
+	c.Check(query.Get("MinCount"), gc.Equals, "1")
+	c.Check(query.Get("MaxCount"), gc.Equals, "1")
+	c.Check(query.Get("UserData"), gc.Equals, "ZGF0YQ==")
+	c.Check(query.Get("Placement.AvailabilityZone"), gc.Equals, "us-east-1a")
+	c.Check(query.Get("SecurityGroupId.1"), gc.Equals, "sg-1")
+	c.Check(query.Get("SecurityGroup.1"), gc.Equals, "juju")
+	c.Check(query.Get("BlockDeviceMapping.1.DeviceName"), gc.Equals, "/dev/sda1")
+	c.Check(query.Get("BlockDeviceMapping.1.Ebs.VolumeSize"), gc.Equals, "8")
+	c.Check(query.Get("InstanceMarketOptions.MarketType"), gc.Equals, "spot")
+}
+
+func (s *ec2APISuite) TestSecurityGroupEgress(c *gc.C) {
+	s.response = `
+<DescribeSecurityGroupsResponse>
+  <securityGroupInfo>
+    <item>
+      <groupId>sg-1</groupId>
+      <ipPermissionsEgress>
+        <item>
+          <ipProtocol>tcp</ipProtocol>
+          <fromPort>80</fromPort>
+          <toPort>80</toPort>
+          <ipRanges><item><cidrIp>0.0.0.0/0</cidrIp></item></ipRanges>
+        </item>
+      </ipPermissionsEgress>
+    </item>
+  </securityGroupInfo>
+</DescribeSecurityGroupsResponse>`
+	perms, err := securityGroupEgress(s.client, amzec2.SecurityGroup{Id: "sg-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perms, jc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}})
+	c.Assert(s.requests[0].Get("GroupId.1"), gc.Equals, "sg-1")
+}
+
+func (s *ec2APISuite) TestQueryError(c *gc.C) {
+	s.status = http.StatusBadRequest
+	s.response = `
+<Response>
+  <Errors><Error><Code>InvalidPermission.Duplicate</Code><Message>duplicate</Message></Error></Errors>
+  <RequestID>req-1</RequestID>
+</Response>`
+	err := revokeSecurityGroupEgress(s.client, amzec2.SecurityGroup{Id: "sg-1"}, nil)
+	c.Assert(err, gc.ErrorMatches, `duplicate \(InvalidPermission.Duplicate\)`)
+	c.Assert(ec2ErrCode(err), gc.Equals, "InvalidPermission.Duplicate")
+	c.Assert(err.(*amzec2.Error).RequestId, gc.Equals, "req-1")
+}
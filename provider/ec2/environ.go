@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -173,6 +175,7 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	validator.RegisterVocabulary(constraints.InstanceLifecycle, []string{constraints.LifecycleSpot})
 	return validator, nil
 }
 
@@ -240,9 +243,14 @@ func (e *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, er
 	return zones, err
 }
 
+// dedicatedHostIdPattern matches the IDs of EC2 dedicated hosts.
+var dedicatedHostIdPattern = regexp.MustCompile(`^h-[0-9a-f]+$`)
+
 type ec2Placement struct {
 	availabilityZone *ec2.AvailabilityZoneInfo
 	subnet           *ec2.Subnet
+	hostId           string
+	tenancy          string
 }
 
 func (e *environ) parsePlacement(placement string) (*ec2Placement, error) {
@@ -252,20 +260,11 @@ func (e *environ) parsePlacement(placement string) (*ec2Placement, error) {
 	}
 	switch key, value := placement[:pos], placement[pos+1:]; key {
 	case "zone":
-		availabilityZone := value
-		zones, err := e.AvailabilityZones()
+		availabilityZone, err := e.availabilityZoneInfo(value)
 		if err != nil {
 			return nil, err
 		}
-		for _, z := range zones {
-			if z.Name() == availabilityZone {
-				ec2AZ := z.(*ec2AvailabilityZone)
-				return &ec2Placement{
-					availabilityZone: &ec2AZ.AvailabilityZoneInfo,
-				}, nil
-			}
-		}
-		return nil, fmt.Errorf("invalid availability zone %q", availabilityZone)
+		return &ec2Placement{availabilityZone: availabilityZone}, nil
 	case "subnet":
 		logger.Debugf("searching for subnet matching placement directive %q", value)
 		matcher := CreateSubnetMatcher(value)
@@ -297,10 +296,49 @@ func (e *environ) parsePlacement(placement string) (*ec2Placement, error) {
 			}
 		}
 		logger.Debugf("searched for subnet %q, did not find it in all subnets %v", value, allSubnets)
+	case "host":
+		if !dedicatedHostIdPattern.MatchString(value) {
+			return nil, errors.NotValidf("dedicated host ID %q", value)
+		}
+		// Instances on a dedicated host must be started
+		// in the host's availability zone.
+		zoneName, err := dedicatedHostZone(e.ec2, value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get dedicated host %q", value)
+		}
+		availabilityZone, err := e.availabilityZoneInfo(zoneName)
+		if err != nil {
+			return nil, err
+		}
+		return &ec2Placement{
+			availabilityZone: availabilityZone,
+			hostId:           value,
+		}, nil
+	case "tenancy":
+		if value != "dedicated" {
+			return nil, errors.NotValidf("tenancy %q", value)
+		}
+		return &ec2Placement{tenancy: value}, nil
 	}
 	return nil, fmt.Errorf("unknown placement directive: %v", placement)
 }
 
+// availabilityZoneInfo returns the availability zone with the given
+// name.
+func (e *environ) availabilityZoneInfo(name string) (*ec2.AvailabilityZoneInfo, error) {
+	zones, err := e.AvailabilityZones()
+	if err != nil {
+		return nil, err
+	}
+	for _, z := range zones {
+		if z.Name() == name {
+			ec2AZ := z.(*ec2AvailabilityZone)
+			return &ec2AZ.AvailabilityZoneInfo, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid availability zone %q", name)
+}
+
 // PrecheckInstance is defined on the state.Prechecker interface.
 func (e *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
 	if placement != "" {
@@ -402,16 +440,20 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}()
 
 	var availabilityZones []string
-	var placementSubnetID string
+	var placementSubnetID, placementHostID, placementTenancy string
 	if args.Placement != "" {
 		placement, err := e.parsePlacement(args.Placement)
 		if err != nil {
 			return nil, err
 		}
-		if placement.availabilityZone.State != availableState {
-			return nil, errors.Errorf("availability zone %q is %q", placement.availabilityZone.Name, placement.availabilityZone.State)
+		if placement.availabilityZone != nil {
+			if placement.availabilityZone.State != availableState {
+				return nil, errors.Errorf("availability zone %q is %q", placement.availabilityZone.Name, placement.availabilityZone.State)
+			}
+			availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 		}
-		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
+		placementHostID = placement.hostId
+		placementTenancy = placement.tenancy
 		if placement.subnet != nil {
 			if placement.subnet.State != availableState {
 				return nil, errors.Errorf("subnet %q is %q", placement.subnet.CIDRBlock, placement.subnet.State)
@@ -497,7 +539,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		apiPort = args.InstanceConfig.APIInfo.Ports()[0]
 	}
 	callback(status.Allocating, "Setting up groups", nil)
-	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort, args.APIAllow)
 
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot set up groups")
@@ -534,8 +576,44 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		ImageId:             spec.Image.Id,
 	}
 
+	// runParams holds the request parameters that ec2.RunInstances
+	// has no fields for.
+	runParams := make(map[string]string)
+	if args.Constraints.HasInstanceLifecycle() && *args.Constraints.InstanceLifecycle == constraints.LifecycleSpot {
+		runParams["InstanceMarketOptions.MarketType"] = "spot"
+	}
+	switch {
+	case placementHostID != "":
+		runParams["Placement.Tenancy"] = "host"
+		runParams["Placement.HostId"] = placementHostID
+	case placementTenancy != "":
+		runParams["Placement.Tenancy"] = placementTenancy
+	}
+
+	// Keep the instances of an anti-affinity group on different
+	// hardware with a spread placement group. Instances on
+	// dedicated hardware cannot be in spread placement groups.
+	if groups := args.AntiAffinityGroups; len(groups) > 0 {
+		if _, ok := runParams["Placement.Tenancy"]; ok {
+			logger.Warningf("not keeping instance in anti-affinity groups %v apart: dedicated instances cannot be in spread placement groups", groups)
+		} else {
+			if len(groups) > 1 {
+				logger.Infof("ignoring all but the first anti-affinity group: %v", groups)
+			}
+			callback(status.Allocating, "Setting up placement group", nil)
+			groupName, err := e.ensureAntiAffinityPlacementGroup(groups[0])
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot set up placement group")
+			}
+			commonRunArgs.PlacementGroupName = groupName
+		}
+	}
+
 	haveVPCID := isVPCIDSet(e.ecfg().vpcID())
 
+	// exhaustedZones records the zones that lacked capacity for the
+	// instance, so that the provisioner does not try them again.
+	var exhaustedZones []string
 	for _, zone := range availabilityZones {
 		runArgs := commonRunArgs
 		runArgs.AvailZone = zone
@@ -588,16 +666,25 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		}
 
 		callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", zone), nil)
-		instResp, err = runInstances(e.ec2, runArgs, callback)
+		instResp, err = runInstances(e.ec2, runArgs, runParams, callback)
 		if err == nil || !isZoneOrSubnetConstrainedError(err) {
 			break
 		}
+		if ec2ErrCode(err) == "InsufficientInstanceCapacity" {
+			exhaustedZones = append(exhaustedZones, zone)
+		}
 
 		logger.Infof("%q is constrained, trying another availability zone", zone)
 	}
 
 	if err != nil {
-		return nil, errors.Annotate(err, "cannot run instances")
+		err = errors.Annotate(err, "cannot run instances")
+		if ec2ErrCode(err) == "InsufficientInstanceCapacity" {
+			// Let the provisioner try again elsewhere,
+			// or with another instance type.
+			return nil, environs.NewCapacityError(err, exhaustedZones, spec.InstanceType.Name)
+		}
+		return nil, err
 	}
 	if len(instResp.Instances) != 1 {
 		return nil, errors.Errorf("expected 1 started instance, got %d", len(instResp.Instances))
@@ -655,19 +742,32 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 }
 
 // tagResources calls ec2.CreateTags, tagging each of the specified resources
-// with the given tags. tagResources will retry for a short period of time
-// if it receives a *.NotFound error response from EC2.
+// with the given tags, and deletes the tags given empty values from them.
+// tagResources will retry for a short period of time if it receives a
+// *.NotFound error response from EC2.
 func tagResources(e *ec2.EC2, tags map[string]string, resourceIds ...string) error {
 	if len(tags) == 0 {
 		return nil
 	}
 	ec2Tags := make([]ec2.Tag, 0, len(tags))
+	var removeKeys []string
 	for k, v := range tags {
+		if v == "" {
+			removeKeys = append(removeKeys, k)
+			continue
+		}
 		ec2Tags = append(ec2Tags, ec2.Tag{k, v})
 	}
+	sort.Strings(removeKeys)
 	var err error
 	for a := shortAttempt.Start(); a.Next(); {
-		_, err = e.CreateTags(resourceIds, ec2Tags)
+		err = nil
+		if len(ec2Tags) > 0 {
+			_, err = e.CreateTags(resourceIds, ec2Tags)
+		}
+		if err == nil && len(removeKeys) > 0 {
+			err = deleteTags(e, removeKeys, resourceIds...)
+		}
 		if err == nil || !strings.HasSuffix(ec2ErrCode(err), ".NotFound") {
 			return err
 		}
@@ -721,12 +821,17 @@ var runInstances = _runInstances
 
 // runInstances calls ec2.RunInstances for a fixed number of attempts until
 // RunInstances returns an error code that does not indicate an error that
-// may be caused by eventual consistency.
-func _runInstances(e *ec2.EC2, ri *ec2.RunInstances, c environs.StatusCallbackFunc) (resp *ec2.RunInstancesResp, err error) {
+// may be caused by eventual consistency. If there are extraParams, which
+// ec2.RunInstances cannot send, runInstancesQuery is called instead.
+func _runInstances(e *ec2.EC2, ri *ec2.RunInstances, extraParams map[string]string, c environs.StatusCallbackFunc) (resp *ec2.RunInstancesResp, err error) {
 	try := 1
 	for a := shortAttempt.Start(); a.Next(); {
 		c(status.Allocating, fmt.Sprintf("Start instance attempt %d", try), nil)
-		resp, err = e.RunInstances(ri)
+		if len(extraParams) > 0 {
+			resp, err = runInstancesQuery(e, ri, extraParams)
+		} else {
+			resp, err = e.RunInstances(ri)
+		}
 		if err == nil || !isNotFoundError(err) {
 			break
 		}
@@ -1039,6 +1144,175 @@ func (e *environ) AdoptResources(controllerUUID string, fromVersion version.Numb
 	return errors.Annotate(tagResources(e.ec2, tags, resourceIds...), "updating tags")
 }
 
+// TagInstance is part of the environs.InstanceTagger interface.
+// The tags are also set on the instance's root EBS volume, if it
+// has one.
+func (e *environ) TagInstance(id instance.Id, tags map[string]string) error {
+	resp, err := e.ec2.Instances([]string{string(id)}, nil)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get instance %q", id)
+	}
+	resourceIds := []string{string(id)}
+	for _, r := range resp.Reservations {
+		for _, inst := range r.Instances {
+			for _, m := range inst.BlockDeviceMappings {
+				if m.DeviceName == inst.RootDeviceName && m.VolumeId != "" {
+					resourceIds = append(resourceIds, m.VolumeId)
+				}
+			}
+		}
+	}
+	return errors.Annotate(tagResources(e.ec2, tags, resourceIds...), "tagging instance")
+}
+
+// AdoptableInstance is part of the environs.InstanceAdopter interface.
+// The instance is looked up without filtering on the model's tags, as
+// it was not started for the model.
+func (e *environ) AdoptableInstance(id instance.Id) (instance.Instance, error) {
+	inst, err := e.adoptableInstance(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ec2Instance{e: e, Instance: inst}, nil
+}
+
+func (e *environ) adoptableInstance(id instance.Id) (*ec2.Instance, error) {
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", aliveInstanceStates...)
+	resp, err := e.ec2.Instances([]string{string(id)}, filter)
+	if ec2ErrCode(err) == "InvalidInstanceID.NotFound" {
+		return nil, errors.NotFoundf("instance %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get instance %q", id)
+	}
+	for _, r := range resp.Reservations {
+		for i := range r.Instances {
+			if r.Instances[i].InstanceId == string(id) {
+				return &r.Instances[i], nil
+			}
+		}
+	}
+	return nil, errors.NotFoundf("instance %q", id)
+}
+
+// AdoptInstance is part of the environs.InstanceAdopter interface.
+// The instance is added to the security groups the model's instances
+// are started with, creating any that do not exist yet, and tagged.
+func (e *environ) AdoptInstance(id instance.Id, machineId string, instanceTags map[string]string) error {
+	inst, err := e.adoptableInstance(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	groups, err := e.adoptionGroups(instanceTags[tags.JujuController], machineId)
+	if err != nil {
+		return errors.Annotate(err, "cannot set up groups")
+	}
+	if err := e.addInstanceGroups(inst, groups); err != nil {
+		return errors.Annotatef(err, "adopting instance %q", id)
+	}
+	return errors.Annotatef(e.TagInstance(id, instanceTags), "adopting instance %q", id)
+}
+
+// adoptionGroups returns the security groups that an adopted instance
+// for the given machine must be in. Groups that exist already are left
+// as they are; missing ones are created as setUpGroups would, except
+// that the juju group does not allow access to the API port, which
+// only controllers serve.
+func (e *environ) adoptionGroups(controllerUUID, machineId string) ([]ec2.SecurityGroup, error) {
+	var perms []ec2.IPPerm
+	if sshAllow := network.IPv4CIDRs(e.Config().SSHAllow()); len(sshAllow) > 0 {
+		perms = append(perms, ec2.IPPerm{
+			Protocol:  "tcp",
+			FromPort:  22,
+			ToPort:    22,
+			SourceIPs: sshAllow,
+		})
+	}
+	jujuGroup, err := e.existingOrNewGroup(controllerUUID, e.jujuGroupName(), append(perms, internalGroupPerms...))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups := []ec2.SecurityGroup{jujuGroup}
+	var machineGroupName string
+	switch e.Config().FirewallMode() {
+	case config.FwInstance:
+		machineGroupName = e.machineGroupName(machineId)
+	case config.FwGlobal:
+		machineGroupName = e.globalGroupName()
+	}
+	if machineGroupName != "" {
+		machineGroup, err := e.existingOrNewGroup(controllerUUID, machineGroupName, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		groups = append(groups, machineGroup)
+	}
+	return groups, nil
+}
+
+// existingOrNewGroup returns the security group with the given name,
+// creating it with the given permissions if it does not exist. Unlike
+// ensureGroup, it leaves the permissions of an existing group alone.
+func (e *environ) existingOrNewGroup(controllerUUID, name string, perms []ec2.IPPerm) (ec2.SecurityGroup, error) {
+	resp, err := e.securityGroupsByNameOrID(name)
+	if err != nil && ec2ErrCode(err) != "InvalidGroup.NotFound" {
+		return zeroGroup, errors.Annotatef(err, "cannot get security group %q", name)
+	}
+	if err == nil && len(resp.Groups) > 0 {
+		return resp.Groups[0].SecurityGroup, nil
+	}
+	return e.ensureGroup(controllerUUID, name, perms)
+}
+
+// addInstanceGroups adds the given security groups to those the
+// instance is in. Only the security groups of instances in a VPC can
+// be changed once they are running.
+func (e *environ) addInstanceGroups(inst *ec2.Instance, groups []ec2.SecurityGroup) error {
+	groupIds := set.NewStrings()
+	var allIds []string
+	for _, g := range inst.SecurityGroups {
+		groupIds.Add(g.Id)
+		allIds = append(allIds, g.Id)
+	}
+	var missing []string
+	for _, g := range groups {
+		if groupIds.Contains(g.Id) {
+			continue
+		}
+		groupIds.Add(g.Id)
+		allIds = append(allIds, g.Id)
+		missing = append(missing, g.Name)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if inst.VPCId == "" {
+		return errors.Errorf(
+			"instance is not in security groups %q, and security groups can only be added to instances in a VPC",
+			missing,
+		)
+	}
+	return errors.Annotate(modifyInstanceGroups(e.ec2, inst.InstanceId, allIds), "cannot add security groups")
+}
+
+// TagVolume is part of the environs.VolumeTagger interface.
+func (e *environ) TagVolume(volumeId string, tags map[string]string) error {
+	return errors.Annotate(tagResources(e.ec2, tags, volumeId), "tagging volume")
+}
+
+// TagModelResources is part of the environs.ModelResourceTagger
+// interface. The model's shared resources are its security groups.
+func (e *environ) TagModelResources(tags map[string]string) error {
+	groupIds, err := e.modelSecurityGroupIDs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(groupIds) == 0 {
+		return nil
+	}
+	return errors.Annotate(tagResources(e.ec2, tags, groupIds...), "tagging security groups")
+}
+
 // AllInstances is part of the environs.InstanceBroker interface.
 func (e *environ) AllInstances() ([]instance.Instance, error) {
 	return e.AllInstancesByState("pending", "running")
@@ -1145,6 +1419,7 @@ func (e *environ) Destroy() error {
 	if err := e.cleanEnvironmentSecurityGroups(); err != nil {
 		return errors.Annotate(err, "cannot delete environment security groups")
 	}
+	e.cleanAntiAffinityPlacementGroups(clock.WallClock)
 	return nil
 }
 
@@ -1302,6 +1577,166 @@ func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule,
 	return rules, nil
 }
 
+// allowAllEgressPerm is the permission that EC2 gives every new
+// security group in a VPC, allowing all outgoing traffic.
+var allowAllEgressPerm = ec2.IPPerm{
+	Protocol:  "-1",
+	SourceIPs: []string{defaultRouteCIDRBlock},
+}
+
+// egressRulesToIPPerms maps egress rules to ec2 permissions. For
+// egress permissions, the SourceIPs hold the destination CIDRs.
+func egressRulesToIPPerms(rules []network.EgressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
+		ipPerms[i] = ec2.IPPerm{
+			Protocol: r.Protocol,
+			FromPort: r.FromPort,
+			ToPort:   r.ToPort,
+		}
+		if len(r.DestinationCIDRs) == 0 {
+			ipPerms[i].SourceIPs = []string{defaultRouteCIDRBlock}
+		} else {
+			ipPerms[i].SourceIPs = make([]string, len(r.DestinationCIDRs))
+			copy(ipPerms[i].SourceIPs, r.DestinationCIDRs)
+		}
+	}
+	return ipPerms
+}
+
+// supportsEgress reports whether the security groups of the model
+// can restrict outgoing traffic, which EC2-Classic does not allow.
+func (e *environ) supportsEgress() (bool, error) {
+	if isVPCIDSet(e.ecfg().vpcID()) {
+		return true, nil
+	}
+	return e.hasDefaultVPC()
+}
+
+func (e *environ) openEgressInGroup(name string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	if ok, err := e.supportsEgress(); err != nil {
+		return errors.Trace(err)
+	} else if !ok {
+		return errors.NotSupportedf("egress rules in EC2-Classic")
+	}
+	g, err := e.groupByName(name)
+	if err != nil {
+		return err
+	}
+	ipPerms := egressRulesToIPPerms(rules)
+	for i := range ipPerms {
+		err := authorizeSecurityGroupEgress(e.ec2, g, ipPerms[i:i+1])
+		if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
+			return fmt.Errorf("cannot open egress %v: %v", ipPerms[i], err)
+		}
+	}
+	// Outgoing traffic is only restricted once the permission
+	// that allows all of it has gone. Revoking a permission that
+	// is not granted is not an error.
+	if err := revokeSecurityGroupEgress(e.ec2, g, []ec2.IPPerm{allowAllEgressPerm}); err != nil {
+		return fmt.Errorf("cannot restrict egress: %v", err)
+	}
+	return nil
+}
+
+func (e *environ) closeEgressInGroup(name string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	if ok, err := e.supportsEgress(); err != nil {
+		return errors.Trace(err)
+	} else if !ok {
+		return errors.NotSupportedf("egress rules in EC2-Classic")
+	}
+	g, err := e.groupByName(name)
+	if err != nil {
+		return err
+	}
+	if err := revokeSecurityGroupEgress(e.ec2, g, egressRulesToIPPerms(rules)); err != nil {
+		return fmt.Errorf("cannot close egress: %v", err)
+	}
+	remaining, err := e.egressRulesInGroup(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(remaining) == 0 {
+		// The last egress rule has gone, so allow all outgoing
+		// traffic again as EC2 does for a new group.
+		err := authorizeSecurityGroupEgress(e.ec2, g, []ec2.IPPerm{allowAllEgressPerm})
+		if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
+			return fmt.Errorf("cannot allow all egress: %v", err)
+		}
+	}
+	return nil
+}
+
+// egressRulesInGroup returns the egress rules of the named group.
+// The permission that allows all outgoing traffic when no egress
+// rules apply is not included.
+func (e *environ) egressRulesInGroup(name string) (rules []network.EgressRule, err error) {
+	group, err := e.groupByName(name)
+	if err != nil {
+		return nil, err
+	}
+	perms, err := securityGroupEgress(e.ec2, group)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range perms {
+		if p.Protocol == allowAllEgressPerm.Protocol {
+			continue
+		}
+		ips := p.SourceIPs
+		if len(ips) == 0 {
+			ips = []string{defaultRouteCIDRBlock}
+		}
+		rule, err := network.NewEgressRule(p.Protocol, p.FromPort, p.ToPort, ips...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// restrictModelEgress replaces the permission that allows all
+// outgoing traffic from the group shared by all machines in the
+// model with permissions that allow traffic between the machines
+// in the model and to the controller API. Outgoing traffic from
+// each machine is then governed by its own security group, which
+// allows all outgoing traffic until egress rules are applied to it.
+func (e *environ) restrictModelEgress(g ec2.SecurityGroup, apiPort int) error {
+	if ok, err := e.supportsEgress(); err != nil || !ok {
+		return errors.Trace(err)
+	}
+	perms := []ec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  apiPort,
+		ToPort:    apiPort,
+		SourceIPs: []string{defaultRouteCIDRBlock},
+	}}
+	for _, p := range []ec2.IPPerm{
+		{Protocol: "tcp", FromPort: 0, ToPort: 65535},
+		{Protocol: "udp", FromPort: 0, ToPort: 65535},
+		{Protocol: "icmp", FromPort: -1, ToPort: -1},
+	} {
+		p.SourceGroups = []ec2.UserSecurityGroup{{Id: g.Id}}
+		perms = append(perms, p)
+	}
+	for i := range perms {
+		err := authorizeSecurityGroupEgress(e.ec2, g, perms[i:i+1])
+		if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
+			return errors.Trace(err)
+		}
+	}
+	err := revokeSecurityGroupEgress(e.ec2, g, []ec2.IPPerm{allowAllEgressPerm})
+	return errors.Trace(err)
+}
+
 func (e *environ) OpenPorts(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
@@ -1331,6 +1766,73 @@ func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	return e.ingressRulesInGroup(e.globalGroupName())
 }
 
+var _ environs.ModelFirewaller = (*environ)(nil)
+var _ environs.ModelIngressRulesFilterer = (*environ)(nil)
+var _ environs.InstanceTagger = (*environ)(nil)
+var _ environs.InstanceAdopter = (*environ)(nil)
+var _ environs.VolumeTagger = (*environ)(nil)
+var _ environs.ModelResourceTagger = (*environ)(nil)
+
+// OpenModelPorts is specified in the environs.ModelFirewaller
+// interface. The ports are opened in the group that every instance
+// in the model belongs to. EC2 security groups in this provider only
+// support IPv4, so IPv6 source CIDRs are ignored; see
+// FilterModelIngressRules.
+func (e *environ) OpenModelPorts(rules []network.IngressRule) error {
+	if err := e.openPortsInGroup(e.jujuGroupName(), network.IPv4IngressRules(rules)); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in model group: %v", rules)
+	return nil
+}
+
+// CloseModelPorts is specified in the environs.ModelFirewaller interface.
+func (e *environ) CloseModelPorts(rules []network.IngressRule) error {
+	if err := e.closePortsInGroup(e.jujuGroupName(), network.IPv4IngressRules(rules)); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in model group: %v", rules)
+	return nil
+}
+
+// ModelIngressRules is specified in the environs.ModelFirewaller
+// interface. Permissions that allow traffic between the instances
+// in the group are not included.
+func (e *environ) ModelIngressRules() ([]network.IngressRule, error) {
+	group, err := e.groupInfoByName(e.jujuGroupName())
+	if err != nil {
+		return nil, err
+	}
+	cidrs := make(map[network.PortRange][]string)
+	for _, p := range group.IPPerms {
+		if len(p.SourceIPs) == 0 {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: p.Protocol,
+			FromPort: p.FromPort,
+			ToPort:   p.ToPort,
+		}
+		cidrs[portRange] = append(cidrs[portRange], p.SourceIPs...)
+	}
+	var rules []network.IngressRule
+	for portRange, sourceCIDRs := range cidrs {
+		rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// FilterModelIngressRules is specified in the
+// environs.ModelIngressRulesFilterer interface.
+func (e *environ) FilterModelIngressRules(rules []network.IngressRule) []network.IngressRule {
+	return network.IPv4IngressRules(rules)
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
@@ -1408,6 +1910,61 @@ func (e *environ) cleanEnvironmentSecurityGroups() error {
 	return nil
 }
 
+// antiAffinityPlacementGroupName returns the name of the placement
+// group that keeps the instances of the given anti-affinity group
+// apart.
+func (e *environ) antiAffinityPlacementGroupName(group string) string {
+	return fmt.Sprintf("%s-%s", e.jujuGroupName(), group)
+}
+
+// ensureAntiAffinityPlacementGroup creates the spread placement group
+// for the given anti-affinity group, if it does not already exist, and
+// returns its name.
+func (e *environ) ensureAntiAffinityPlacementGroup(group string) (string, error) {
+	name := e.antiAffinityPlacementGroupName(group)
+	err := createPlacementGroup(e.ec2, name, "spread")
+	if err != nil && ec2ErrCode(err) != "InvalidPlacementGroup.Duplicate" {
+		return "", errors.Trace(err)
+	}
+	return name, nil
+}
+
+// cleanAntiAffinityPlacementGroups deletes the placement groups
+// created for the model's anti-affinity groups. Empty placement groups
+// cost nothing and hold nothing up, so failures are only logged.
+func (e *environ) cleanAntiAffinityPlacementGroups(clock clock.Clock) {
+	names, err := placementGroupNames(e.ec2, e.antiAffinityPlacementGroupName("*"))
+	if err != nil {
+		logger.Warningf("cannot list anti-affinity placement groups: %v", err)
+		return
+	}
+	for _, name := range names {
+		name := name
+		// The group cannot be deleted until the
+		// instances in it have terminated.
+		err := retry.Call(retry.CallArgs{
+			Attempts:    30,
+			Delay:       time.Second,
+			MaxDelay:    time.Minute,
+			BackoffFunc: retry.DoubleDelay,
+			Clock:       clock,
+			Func: func() error {
+				err := deletePlacementGroup(e.ec2, name)
+				if err == nil || ec2ErrCode(err) == "InvalidPlacementGroup.Unknown" {
+					return nil
+				}
+				return errors.Trace(err)
+			},
+			NotifyFunc: func(err error, attempt int) {
+				logger.Debugf("deleting placement group %q, attempt %d", name, attempt)
+			},
+		})
+		if err != nil {
+			logger.Warningf("cannot delete placement group %q: consider deleting it manually: %v", name, err)
+		}
+	}
+}
+
 func (e *environ) terminateInstances(ids []instance.Id) error {
 	if len(ids) == 0 {
 		return nil
@@ -1567,33 +2124,35 @@ func (e *environ) jujuGroupName() string {
 // other instances that might be running on the same EC2 account.  In
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]ec2.SecurityGroup, error) {
-
-	// Ensure there's a global group for Juju-related traffic.
-	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
-		[]ec2.IPPerm{{
+//
+// SSH access to the machines is allowed from the CIDRs in the model's
+// ssh-allow setting, and access to the API port from apiAllow, or
+// from anywhere if apiAllow is empty.
+func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int, apiAllow []string) ([]ec2.SecurityGroup, error) {
+	if len(apiAllow) == 0 {
+		apiAllow = []string{defaultRouteCIDRBlock}
+	}
+	var perms []ec2.IPPerm
+	if sshAllow := network.IPv4CIDRs(e.Config().SSHAllow()); len(sshAllow) > 0 {
+		perms = append(perms, ec2.IPPerm{
 			Protocol:  "tcp",
 			FromPort:  22,
 			ToPort:    22,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
+			SourceIPs: sshAllow,
+		})
+	}
+	if apiAllow := network.IPv4CIDRs(apiAllow); len(apiAllow) > 0 {
+		perms = append(perms, ec2.IPPerm{
 			Protocol:  "tcp",
 			FromPort:  apiPort,
 			ToPort:    apiPort,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
-			Protocol: "tcp",
-			FromPort: 0,
-			ToPort:   65535,
-		}, {
-			Protocol: "udp",
-			FromPort: 0,
-			ToPort:   65535,
-		}, {
-			Protocol: "icmp",
-			FromPort: -1,
-			ToPort:   -1,
-		}},
+			SourceIPs: apiAllow,
+		})
+	}
+
+	// Ensure there's a global group for Juju-related traffic.
+	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
+		append(perms, internalGroupPerms...),
 	)
 	if err != nil {
 		return nil, err
@@ -1602,6 +2161,12 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 	var machineGroup ec2.SecurityGroup
 	switch e.Config().FirewallMode() {
 	case config.FwInstance:
+		// Egress rules are applied to the machine security
+		// group, so the model group must not allow all
+		// outgoing traffic.
+		if err := e.restrictModelEgress(jujuGroup, apiPort); err != nil {
+			logger.Warningf("cannot restrict outgoing traffic from security group %q: %v", jujuGroup.Name, err)
+		}
 		machineGroup, err = e.ensureGroup(controllerUUID, e.machineGroupName(machineId), nil)
 	case config.FwGlobal:
 		machineGroup, err = e.ensureGroup(controllerUUID, e.globalGroupName(), nil)
@@ -1612,6 +2177,22 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 	return []ec2.SecurityGroup{jujuGroup, machineGroup}, nil
 }
 
+// internalGroupPerms are the permissions of the juju group that allow
+// all traffic between the model's instances.
+var internalGroupPerms = []ec2.IPPerm{{
+	Protocol: "tcp",
+	FromPort: 0,
+	ToPort:   65535,
+}, {
+	Protocol: "udp",
+	FromPort: 0,
+	ToPort:   65535,
+}, {
+	Protocol: "icmp",
+	FromPort: -1,
+	ToPort:   -1,
+}}
+
 // zeroGroup holds the zero security group.
 var zeroGroup ec2.SecurityGroup
 
@@ -6,6 +6,7 @@ package ec2
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/amz.v3/ec2"
 
 	"github.com/juju/juju/environs/config"
@@ -112,3 +113,47 @@ func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule,
 	}
 	return ranges, nil
 }
+
+var _ instance.EgressFirewaller = (*ec2Instance)(nil)
+
+// OpenEgress implements instance.EgressFirewaller.
+func (inst *ec2Instance) OpenEgress(machineId string, rules []network.EgressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening egress on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.openEgressInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened egress in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseEgress implements instance.EgressFirewaller.
+func (inst *ec2Instance) CloseEgress(machineId string, rules []network.EgressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing egress on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.closeEgressInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed egress in security group %s: %v", name, rules)
+	return nil
+}
+
+// EgressRules implements instance.EgressFirewaller.
+func (inst *ec2Instance) EgressRules(machineId string) ([]network.EgressRule, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			inst.e.Config().FirewallMode())
+	}
+	if ok, err := inst.e.supportsEgress(); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.NotSupportedf("egress rules in EC2-Classic")
+	}
+	return inst.e.egressRulesInGroup(inst.e.machineGroupName(machineId))
+}
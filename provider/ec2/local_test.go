@@ -4,6 +4,7 @@
 package ec2_test
 
 import (
+	"encoding/xml"
 	"fmt"
 	"regexp"
 	"sort"
@@ -579,6 +580,61 @@ func (t *localServerSuite) TestInstanceSecurityGroupsWitheInstanceStatusFilter(c
 	c.Assert(groupsFilteredForTerminatedInstances, gc.HasLen, 0)
 }
 
+func (t *localServerSuite) TestModelPorts(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	fwEnv := env.(environs.ModelFirewaller)
+	apiPort := coretesting.FakeControllerConfig().APIPort()
+
+	rules, err := fwEnv.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0"),
+	})
+
+	err = fwEnv.CloseModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0", "::/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwEnv.OpenModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8", "2001:db8::/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = fwEnv.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0"),
+	})
+}
+
+func (t *localServerSuite) TestStartInstanceUsesSSHAllow(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"ssh-allow": "192.168.0.0/16",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	defer env.StopInstances(inst.Id())
+
+	rules, err := env.(environs.ModelFirewaller).ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules[0], jc.DeepEquals, network.MustNewIngressRule("tcp", 22, 22, "192.168.0.0/16"))
+}
+
+func (t *localServerSuite) TestInstanceIsEgressFirewaller(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	defer env.StopInstances(inst.Id())
+
+	_, ok := inst.(instance.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+}
+
 func (t *localServerSuite) TestDestroyControllerModelDeleteSecurityGroupInsistentlyError(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 	msg := "destroy security group error"
@@ -960,6 +1016,25 @@ func (t *localServerSuite) TestStartInstanceAvailZoneAllInsufficientInstanceCapa
 	t.testStartInstanceAvailZoneAllConstrained(c, azInsufficientInstanceCapacityErr)
 }
 
+func (t *localServerSuite) TestStartInstanceAvailZoneAllInsufficientInstanceCapacityIsCapacityError(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1"}, {ZoneName: "az2"},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, extraParams map[string]string, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		return nil, azInsufficientInstanceCapacityErr
+	})
+	_, _, _, err := testing.StartInstance(env, t.ControllerUUID, "1")
+	c.Assert(err, jc.Satisfies, environs.IsCapacityError)
+	capacityErr := errors.Cause(err).(*environs.CapacityError)
+	c.Assert(capacityErr.Zones, jc.DeepEquals, []string{"az1", "az2"})
+	c.Assert(capacityErr.InstanceType, gc.Not(gc.Equals), "")
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZoneAllNoDefaultSubnet(c *gc.C) {
 	t.testStartInstanceAvailZoneAllConstrained(c, azNoDefaultSubnetErr)
 }
@@ -976,7 +1051,7 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 
 	var azArgs []string
 
-	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, extraParams map[string]string, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
 		azArgs = append(azArgs, ri.AvailZone)
 		return nil, runInstancesError
 	})
@@ -989,6 +1064,130 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
 }
 
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	// The test server cannot start spot instances, so the
+	// instance is started without the extra parameters.
+	var extraParams map[string]string
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, params map[string]string, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		extraParams = params
+		return realRunInstances(e, ri, nil, c)
+	})
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("instance-lifecycle=spot"),
+		StatusCallback: fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extraParams, jc.DeepEquals, map[string]string{
+		"InstanceMarketOptions.MarketType": "spot",
+	})
+}
+
+func (t *localServerSuite) TestStartInstanceDedicatedHost(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	t.patchDescribeHosts("test-available")
+
+	// The test server cannot place instances on dedicated
+	// hosts, so the instance is started without the extra
+	// parameters.
+	var extraParams map[string]string
+	var zone string
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, params map[string]string, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		extraParams = params
+		zone = ri.AvailZone
+		return realRunInstances(e, ri, nil, c)
+	})
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Placement:      "host=h-0123456789abcdef0",
+		StatusCallback: fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "test-available")
+	c.Assert(extraParams, jc.DeepEquals, map[string]string{
+		"Placement.Tenancy": "host",
+		"Placement.HostId":  "h-0123456789abcdef0",
+	})
+}
+
+func (t *localServerSuite) TestStartInstanceDedicatedTenancy(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	var extraParams map[string]string
+	var placementGroup string
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, params map[string]string, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		extraParams = params
+		placementGroup = ri.PlacementGroupName
+		return realRunInstances(e, ri, nil, c)
+	})
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Placement:      "tenancy=dedicated",
+		// Dedicated instances cannot be kept apart
+		// with placement groups.
+		AntiAffinityGroups: []string{"mysql"},
+		StatusCallback:     fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(placementGroup, gc.Equals, "")
+	c.Assert(extraParams, jc.DeepEquals, map[string]string{
+		"Placement.Tenancy": "dedicated",
+	})
+}
+
+func (t *localServerSuite) TestStartInstanceAntiAffinityGroups(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	// The test server does not support placement groups.
+	var created []map[string]string
+	ec2Query := *ec2.EC2Query
+	t.PatchValue(ec2.EC2Query, func(client *amzec2.EC2, action string, params map[string]string, resp interface{}) error {
+		if action != "CreatePlacementGroup" {
+			return ec2Query(client, action, params, resp)
+		}
+		created = append(created, params)
+		if len(created) > 1 {
+			return &amzec2.Error{Code: "InvalidPlacementGroup.Duplicate"}
+		}
+		return nil
+	})
+	var placementGroups []string
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, params map[string]string, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		placementGroups = append(placementGroups, ri.PlacementGroupName)
+		return realRunInstances(e, ri, params, c)
+	})
+
+	// The placement group is created for the first
+	// instance, and used again for the second.
+	for _, machineId := range []string{"1", "2"} {
+		params := environs.StartInstanceParams{
+			ControllerUUID:     t.ControllerUUID,
+			AntiAffinityGroups: []string{"mysql", "wordpress"},
+			StatusCallback:     fakeCallback,
+		}
+		_, err := testing.StartInstanceWithParams(env, machineId, params)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	groupName := "juju-" + env.Config().UUID() + "-mysql"
+	c.Assert(created, jc.DeepEquals, []map[string]string{{
+		"GroupName": groupName,
+		"Strategy":  "spread",
+	}, {
+		"GroupName": groupName,
+		"Strategy":  "spread",
+	}})
+	c.Assert(placementGroups, jc.DeepEquals, []string{groupName, groupName})
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets.
@@ -1143,12 +1342,12 @@ func (t *localServerSuite) testStartInstanceAvailZoneOneConstrained(c *gc.C, run
 	var azArgs []string
 	realRunInstances := *ec2.RunInstances
 
-	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, extraParams map[string]string, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
 		azArgs = append(azArgs, ri.AvailZone)
 		if len(azArgs) == 1 {
 			return nil, runInstancesError
 		}
-		return realRunInstances(e, ri, fakeCallback)
+		return realRunInstances(e, ri, extraParams, fakeCallback)
 	})
 	inst, hwc := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
 	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
@@ -1197,6 +1396,10 @@ func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	cons := constraints.MustParse("instance-type=foo")
 	_, err = validator.Validate(cons)
 	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-type=foo\nvalid values are:.*")
+
+	cons = constraints.MustParse("instance-lifecycle=preemptible")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-lifecycle=preemptible\nvalid values are: \\[spot\\]")
 }
 
 func (t *localServerSuite) TestConstraintsValidatorVocabNoDefaultOrSpecifiedVPC(c *gc.C) {
@@ -1295,6 +1498,57 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceDedicatedHost(c *gc.C) {
+	env := t.Prepare(c)
+	t.patchDescribeHosts("test-available")
+	placement := "host=h-0123456789abcdef0"
+	err := env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	placement = "host=i-0123456789abcdef0"
+	err = env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, gc.ErrorMatches, `dedicated host ID "i-0123456789abcdef0" not valid`)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceDedicatedHostUnknownZone(c *gc.C) {
+	env := t.Prepare(c)
+	t.patchDescribeHosts("test-unknown")
+	placement := "host=h-0123456789abcdef0"
+	err := env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceTenancy(c *gc.C) {
+	env := t.Prepare(c)
+	placement := "tenancy=dedicated"
+	err := env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	placement = "tenancy=shared"
+	err = env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, gc.ErrorMatches, `tenancy "shared" not valid`)
+}
+
+// patchDescribeHosts answers DescribeHosts requests, which the test
+// server does not support, with a dedicated host in the given zone.
+func (t *localServerSuite) patchDescribeHosts(zone string) {
+	ec2Query := *ec2.EC2Query
+	t.PatchValue(ec2.EC2Query, func(client *amzec2.EC2, action string, params map[string]string, resp interface{}) error {
+		if action != "DescribeHosts" {
+			return ec2Query(client, action, params, resp)
+		}
+		return xml.Unmarshal([]byte(fmt.Sprintf(`
+<DescribeHostsResponse>
+  <hostSet>
+    <item>
+      <hostId>%s</hostId>
+      <availabilityZone>%s</availabilityZone>
+    </item>
+  </hostSet>
+</DescribeHostsResponse>`, params["HostId.1"], zone)), resp)
+	})
+}
+
 func (t *localServerSuite) TestValidateImageMetadata(c *gc.C) {
 	region := t.srv.region()
 	aws.Regions[region.Name] = t.srv.region()
@@ -1669,6 +1923,172 @@ func (s *localServerSuite) TestAdoptResources(c *gc.C) {
 	checkGroupTags(origController, controllerGroups...)
 }
 
+func (s *localServerSuite) TestAdoptInstance(c *gc.C) {
+	env, adopter := s.prepareAdoptionModel(c, "global")
+
+	// Start an instance in the model's security groups, and tag it
+	// for another model so that it is no longer one of the model's
+	// instances.
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "0")
+	err := env.(environs.InstanceTagger).TagInstance(inst.Id(), map[string]string{
+		tags.JujuModel: "other-model",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = env.Instances([]instance.Id{inst.Id()})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+
+	modifyCalls := s.patchModifyInstanceGroups()
+	adoptable, err := adopter.AdoptableInstance(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(adoptable.Id(), gc.Equals, inst.Id())
+	err = adopter.AdoptInstance(inst.Id(), "1", s.adoptionTags())
+	c.Assert(err, jc.ErrorIsNil)
+	insts, err := env.Instances([]instance.Id{inst.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts[0].Id(), gc.Equals, inst.Id())
+	// The instance is in the model's groups already.
+	c.Assert(*modifyCalls, gc.HasLen, 0)
+
+	// An instance in a VPC is added to the model's groups.
+	ids := s.srv.ec2srv.NewInstancesVPC(s.srv.defaultVPC.Id, "", 1, "m1.small", "ami-a7f539ce", ec2test.Running, nil)
+	err = adopter.AdoptInstance(instance.Id(ids[0]), "2", s.adoptionTags())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*modifyCalls, jc.DeepEquals, []map[string]string{{
+		"InstanceId": ids[0],
+		"GroupId.1":  s.groupId(c, env, ec2.JujuGroupName(env)),
+		"GroupId.2":  s.groupId(c, env, ec2.JujuGroupName(env)+"-global"),
+	}})
+
+	// Security groups can only be added to instances in a VPC.
+	ids = s.srv.ec2srv.NewInstances(1, "m1.small", "ami-a7f539ce", ec2test.Running, nil)
+	_, err = adopter.AdoptableInstance(instance.Id(ids[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	err = adopter.AdoptInstance(instance.Id(ids[0]), "3", s.adoptionTags())
+	c.Assert(err, gc.ErrorMatches, `adopting instance ".*": instance is not in security groups \["juju-.*" "juju-.*-global"\], and security groups can only be added to instances in a VPC`)
+
+	_, err = adopter.AdoptableInstance("i-unknown")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *localServerSuite) TestAdoptInstanceFirewallModeInstance(c *gc.C) {
+	env, adopter := s.prepareAdoptionModel(c, "instance")
+	modifyCalls := s.patchModifyInstanceGroups()
+
+	// The machine's own group is created for the instance.
+	ids := s.srv.ec2srv.NewInstancesVPC(s.srv.defaultVPC.Id, "", 1, "m1.small", "ami-a7f539ce", ec2test.Running, nil)
+	err := adopter.AdoptInstance(instance.Id(ids[0]), "5", s.adoptionTags())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*modifyCalls, jc.DeepEquals, []map[string]string{{
+		"InstanceId": ids[0],
+		"GroupId.1":  s.groupId(c, env, ec2.JujuGroupName(env)),
+		"GroupId.2":  s.groupId(c, env, ec2.MachineGroupName(env, "5")),
+	}})
+}
+
+const adoptionModelUUID = "7e386e08-cba7-44a4-a76e-7c1633584210"
+
+// prepareAdoptionModel bootstraps a controller, and returns a hosted
+// model with the given firewall mode to adopt instances into.
+func (s *localServerSuite) prepareAdoptionModel(c *gc.C, firewallMode string) (environs.Environ, environs.InstanceAdopter) {
+	controllerEnv := s.prepareAndBootstrap(c)
+	s.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	cfg, err := controllerEnv.Config().Apply(map[string]interface{}{
+		"uuid":          adoptionModelUUID,
+		"firewall-mode": firewallMode,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  s.CloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return env, env.(environs.InstanceAdopter)
+}
+
+func (s *localServerSuite) adoptionTags() map[string]string {
+	return map[string]string{
+		tags.JujuModel:      adoptionModelUUID,
+		tags.JujuController: s.ControllerUUID,
+	}
+}
+
+// patchModifyInstanceGroups records the ModifyInstanceAttribute
+// requests made, which the test server does not support for security
+// groups.
+func (s *localServerSuite) patchModifyInstanceGroups() *[]map[string]string {
+	var calls []map[string]string
+	ec2Query := *ec2.EC2Query
+	s.BaseSuite.PatchValue(ec2.EC2Query, func(client *amzec2.EC2, action string, params map[string]string, resp interface{}) error {
+		if action != "ModifyInstanceAttribute" {
+			return ec2Query(client, action, params, resp)
+		}
+		calls = append(calls, params)
+		return nil
+	})
+	return &calls
+}
+
+func (s *localServerSuite) groupId(c *gc.C, env environs.Environ, name string) string {
+	resp, err := ec2.EnvironEC2(env).SecurityGroups(amzec2.SecurityGroupNames(name), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Groups, gc.HasLen, 1)
+	return resp.Groups[0].Id
+}
+
+func (s *localServerSuite) TestTagResources(c *gc.C) {
+	env := s.prepareAndBootstrap(c)
+	insts, err := env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 1)
+	rootVolumes, err := ec2.AllModelVolumes(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rootVolumes, gc.HasLen, 1)
+	groups, err := ec2.AllModelGroups(env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	teamTag := map[string]string{"team": "db"}
+	err = env.(environs.InstanceTagger).TagInstance(insts[0].Id(), teamTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.(environs.ModelResourceTagger).TagModelResources(teamTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ec2conn := ec2.EnvironEC2(env)
+	instResp, err := ec2conn.Instances(nil, makeFilter("tag:team", "db"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instResp.Reservations, gc.HasLen, 1)
+	c.Assert(instResp.Reservations[0].Instances, gc.HasLen, 1)
+	c.Check(instResp.Reservations[0].Instances[0].InstanceId, gc.Equals, string(insts[0].Id()))
+
+	volResp, err := ec2conn.Volumes(nil, makeFilter("tag:team", "db"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volResp.Volumes, gc.HasLen, 1)
+	c.Check(volResp.Volumes[0].Id, gc.Equals, rootVolumes[0])
+
+	groupResp, err := ec2conn.SecurityGroups(nil, makeFilter("tag:team", "db"))
+	c.Assert(err, jc.ErrorIsNil)
+	groupIds := set.NewStrings()
+	for _, group := range groupResp.Groups {
+		groupIds.Add(group.Id)
+	}
+	c.Check(groupIds, gc.DeepEquals, set.NewStrings(groups...))
+
+	// Tags given empty values are deleted. The test server
+	// cannot delete tags, so the requests are recorded.
+	var deleteParams []map[string]string
+	s.BaseSuite.PatchValue(ec2.EC2Query, func(client *amzec2.EC2, action string, params map[string]string, resp interface{}) error {
+		c.Check(action, gc.Equals, "DeleteTags")
+		deleteParams = append(deleteParams, params)
+		return nil
+	})
+	err = env.(environs.InstanceTagger).TagInstance(insts[0].Id(), map[string]string{"team": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deleteParams, jc.DeepEquals, []map[string]string{{
+		"ResourceId.1": string(insts[0].Id()),
+		"ResourceId.2": rootVolumes[0],
+		"Tag.1.Key":    "team",
+	}})
+}
+
 // localNonUSEastSuite is similar to localServerSuite but the S3 mock server
 // behaves as if it is not in the us-east region.
 type localNonUSEastSuite struct {
@@ -4,6 +4,8 @@
 package gce
 
 import (
+	"net"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -73,6 +75,16 @@ func newConfig(cfg, old *config.Config) (*environConfig, error) {
 		}
 	}
 
+	// GCE firewalls only support IPv4 source ranges. The ::/0 CIDR,
+	// included by default to allow access from anywhere, is accepted
+	// and ignored.
+	for _, cidr := range cfg.SSHAllow() {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err == nil && ip.To4() == nil && cidr != "::/0" {
+			return nil, errors.Errorf("%s: IPv6 CIDR %q not supported", config.SSHAllowKey, cidr)
+		}
+	}
+
 	ecfg := &environConfig{
 		config: cfg,
 		attrs:  attrs,
@@ -109,6 +109,14 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
 	expect: testing.Attrs{"unknown-field": 12345},
+}, {
+	info:   "ssh-allow may include ::/0",
+	insert: testing.Attrs{"ssh-allow": "10.0.0.0/8,::/0"},
+	expect: testing.Attrs{"ssh-allow": "10.0.0.0/8,::/0"},
+}, {
+	info:   "ssh-allow must not have other IPv6 CIDRs",
+	insert: testing.Attrs{"ssh-allow": "10.0.0.0/8,2001:db8::/32"},
+	err:    `ssh-allow: IPv6 CIDR "2001:db8::/32" not supported`,
 }}
 
 func (s *ConfigSuite) TestNewModelConfig(c *gc.C) {
@@ -34,6 +34,8 @@ type gceConnection interface {
 	IngressRules(fwname string) ([]network.IngressRule, error)
 	OpenPorts(fwname string, rules ...network.IngressRule) error
 	ClosePorts(fwname string, rules ...network.IngressRule) error
+	OpenTargetPorts(fwname, target string, rules ...network.IngressRule) error
+	CloseTargetPorts(fwname, target string, rules ...network.IngressRule) error
 
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 	// Subnetworks returns the subnetworks that machines can be
@@ -200,13 +202,18 @@ func (env *environ) Create(environs.CreateParams) error {
 func (env *environ) Bootstrap(ctx environs.BootstrapContext, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
 	// Ensure the API server port is open (globally for all instances
 	// on the network, not just for the specific node of the state
-	// server). See LP bug #1436191 for details.
-	rule := network.NewOpenIngressRule(
+	// server). See LP bug #1436191 for details. Access is restricted
+	// to the controller's api-allow CIDRs.
+	rule, err := network.NewIngressRule(
 		"tcp",
 		params.ControllerConfig.APIPort(),
 		params.ControllerConfig.APIPort(),
+		network.IPv4CIDRs(params.ControllerConfig.APIAllow())...,
 	)
-	if err := env.gce.OpenPorts(env.globalFirewallName(), rule); err != nil {
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := env.OpenModelPorts([]network.IngressRule{rule}); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return bootstrap(ctx, env, params)
@@ -226,6 +233,16 @@ func (env *environ) Destroy() error {
 		}
 	}
 
+	modelRules, err := env.ModelIngressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(modelRules) > 0 {
+		if err := env.CloseModelPorts(modelRules); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	return destroyEnv(env)
 }
 
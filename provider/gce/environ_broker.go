@@ -167,6 +167,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata:          metadata,
 		Tags:              tags,
+		Preemptible:       isPreemptible(args.Constraints),
 		// Network is omitted (left empty).
 	}
 
@@ -179,6 +180,12 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 	return inst, errors.Trace(err)
 }
 
+// isPreemptible reports whether the constraints
+// request a preemptible instance.
+func isPreemptible(cons constraints.Value) bool {
+	return cons.HasInstanceLifecycle() && *cons.InstanceLifecycle == constraints.LifecyclePreemptible
+}
+
 // getMetadata builds the raw "user-defined" metadata for the new
 // instance (relative to the provided args) and returns it.
 func getMetadata(args environs.StartInstanceParams, os jujuos.OSType) (map[string]string, error) {
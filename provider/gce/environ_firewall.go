@@ -6,15 +6,28 @@ package gce
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 )
 
+var _ environs.ModelFirewaller = (*environ)(nil)
+var _ environs.ModelIngressRulesFilterer = (*environ)(nil)
+
 // globalFirewallName returns the name to use for the global firewall.
 func (env *environ) globalFirewallName() string {
 	return common.EnvFullName(env.uuid)
 }
 
+// modelFirewallName returns the name prefix to use for the firewall
+// rules that apply to the whole model, such as SSH access. The rules
+// target the global firewall tag carried by every instance, but must
+// not share its name prefix so they are kept apart from the ports
+// opened in the FwGlobal firewall mode.
+func (env *environ) modelFirewallName() string {
+	return "juju-model-" + env.uuid
+}
+
 // OpenPorts opens the given port ranges for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
@@ -38,3 +51,37 @@ func (env *environ) IngressRules() ([]network.IngressRule, error) {
 	rules, err := env.gce.IngressRules(env.globalFirewallName())
 	return rules, errors.Trace(err)
 }
+
+// OpenModelPorts is specified in the environs.ModelFirewaller interface.
+// GCE firewalls only support IPv4 source ranges, so any others are
+// ignored.
+func (env *environ) OpenModelPorts(rules []network.IngressRule) error {
+	rules = network.IPv4IngressRules(rules)
+	if len(rules) == 0 {
+		return nil
+	}
+	err := env.gce.OpenTargetPorts(env.modelFirewallName(), env.globalFirewallName(), rules...)
+	return errors.Trace(err)
+}
+
+// CloseModelPorts is specified in the environs.ModelFirewaller interface.
+func (env *environ) CloseModelPorts(rules []network.IngressRule) error {
+	rules = network.IPv4IngressRules(rules)
+	if len(rules) == 0 {
+		return nil
+	}
+	err := env.gce.CloseTargetPorts(env.modelFirewallName(), env.globalFirewallName(), rules...)
+	return errors.Trace(err)
+}
+
+// ModelIngressRules is specified in the environs.ModelFirewaller interface.
+func (env *environ) ModelIngressRules() ([]network.IngressRule, error) {
+	rules, err := env.gce.IngressRules(env.modelFirewallName())
+	return rules, errors.Trace(err)
+}
+
+// FilterModelIngressRules is specified in the
+// environs.ModelIngressRulesFilterer interface.
+func (env *environ) FilterModelIngressRules(rules []network.IngressRule) []network.IngressRule {
+	return network.IPv4IngressRules(rules)
+}
@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce"
 )
 
@@ -75,3 +76,39 @@ func (s *environFirewallSuite) TestPortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
 }
+
+func (s *environFirewallSuite) TestOpenModelPorts(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8", "2001:db8::/32"),
+		network.MustNewIngressRule("tcp", 17070, 17070, "::/0"),
+	}
+	err := s.Env.OpenModelPorts(rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenTargetPorts")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, "juju-model-"+s.Config.UUID())
+	c.Check(s.FakeConn.Calls[0].Target, gc.Equals, gce.GlobalFirewallName(s.Env))
+	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+	})
+}
+
+func (s *environFirewallSuite) TestCloseModelPortsIPv6Only(c *gc.C) {
+	err := s.Env.CloseModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "::/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 0)
+}
+
+func (s *environFirewallSuite) TestModelIngressRules(c *gc.C) {
+	s.FakeConn.Rules = s.Rules
+	rules, err := s.Env.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, s.Rules)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, "juju-model-"+s.Config.UUID())
+}
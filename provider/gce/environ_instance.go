@@ -4,6 +4,7 @@
 package gce
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -137,6 +138,24 @@ func (env *environ) AdoptResources(controllerUUID string, fromVersion version.Nu
 	return errors.Trace(env.gce.UpdateMetadata(tags.JujuController, controllerUUID, stringIds...))
 }
 
+var _ environs.InstanceTagger = (*environ)(nil)
+
+// TagInstance implements environs.InstanceTagger. GCE instances
+// carry their tags in their metadata.
+func (env *environ) TagInstance(id instance.Id, tags map[string]string) error {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := env.gce.UpdateMetadata(key, tags[key], string(id)); err != nil {
+			return errors.Annotatef(err, "setting metadata %q of instance %q", key, id)
+		}
+	}
+	return nil
+}
+
 // TODO(ericsnow) Turn into an interface.
 type instPlacement struct {
 	Zone *google.AvailabilityZone
@@ -217,3 +217,19 @@ func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 	c.Check(call.Key, gc.Equals, tags.JujuController)
 	c.Check(call.Value, gc.Equals, "other-uuid")
 }
+
+func (s *environInstSuite) TestTagInstance(c *gc.C) {
+	err := s.Env.TagInstance("john", map[string]string{
+		"team":        "db",
+		"cost-centre": "42",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	for i, expected := range [][]string{{"cost-centre", "42"}, {"team", "db"}} {
+		call := s.FakeConn.Calls[i]
+		c.Check(call.FuncName, gc.Equals, "UpdateMetadata")
+		c.Check(call.IDs, gc.DeepEquals, []string{"john"})
+		c.Check(call.Key, gc.Equals, expected[0])
+		c.Check(call.Value, gc.Equals, expected[1])
+	}
+}
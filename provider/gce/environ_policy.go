@@ -67,6 +67,8 @@ func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
 
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
 
+	validator.RegisterVocabulary(constraints.InstanceLifecycle, []string{constraints.LifecyclePreemptible})
+
 	return validator, nil
 }
 
@@ -175,6 +175,19 @@ func (s *environPolSuite) TestConstraintsValidatorVocabContainer(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: container=lxd\nvalid values are:.*")
 }
 
+func (s *environPolSuite) TestConstraintsValidatorVocabInstanceLifecycle(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("instance-lifecycle=preemptible")
+	_, err = validator.Validate(cons)
+	c.Check(err, jc.ErrorIsNil)
+
+	cons = constraints.MustParse("instance-lifecycle=spot")
+	_, err = validator.Validate(cons)
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: instance-lifecycle=spot\nvalid values are: \\[preemptible\\]")
+}
+
 func (s *environPolSuite) TestConstraintsValidatorConflicts(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator()
 	c.Assert(err, jc.ErrorIsNil)
@@ -98,11 +98,12 @@ func (s *environSuite) TestBootstrapOpensAPIPort(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	apiPort := params.ControllerConfig.APIPort()
 
-	called, calls := s.FakeConn.WasCalled("OpenPorts")
+	called, calls := s.FakeConn.WasCalled("OpenTargetPorts")
 	c.Check(called, gc.Equals, true)
 	c.Check(calls, gc.HasLen, 1)
-	c.Check(calls[0].FirewallName, gc.Equals, gce.GlobalFirewallName(s.Env))
-	expectRules := []network.IngressRule{network.MustNewIngressRule("tcp", apiPort, apiPort)}
+	c.Check(calls[0].FirewallName, gc.Equals, gce.ModelFirewallName(s.Env))
+	c.Check(calls[0].Target, gc.Equals, gce.GlobalFirewallName(s.Env))
+	expectRules := []network.IngressRule{network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0")}
 	c.Check(calls[0].Rules, jc.DeepEquals, expectRules)
 }
 
@@ -134,10 +135,12 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 	err := s.Env.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	fwname := common.EnvFullName(s.Env.Config().UUID())
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "Ports")
+	c.Check(s.FakeConn.Calls[1].FirewallName, gc.Equals, "juju-model-"+s.Env.Config().UUID())
 	s.FakeCommon.CheckCalls(c, []gce.FakeCall{{
 		FuncName: "Destroy",
 		Args: gce.FakeCallArgs{
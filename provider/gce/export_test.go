@@ -46,6 +46,10 @@ func GlobalFirewallName(env *environ) string {
 	return env.globalFirewallName()
 }
 
+func ModelFirewallName(env *environ) string {
+	return env.modelFirewallName()
+}
+
 func ParsePlacement(env *environ, placement string) (*instPlacement, error) {
 	return env.parsePlacement(placement)
 }
@@ -148,8 +148,9 @@ func (gce *Connection) RemoveInstances(prefix string, ids ...string) error {
 }
 
 // UpdateMetadata sets the metadata key to the specified value for
-// all of the instance ids given. The call blocks until all
-// of the instances are updated or the request fails.
+// all of the instance ids given. An empty value removes the key
+// instead. The call blocks until all of the instances are updated or
+// the request fails.
 func (gce *Connection) UpdateMetadata(key, value string, ids ...string) error {
 	if len(ids) == 0 {
 		return nil
@@ -182,7 +183,19 @@ func (gce *Connection) UpdateMetadata(key, value string, ids ...string) error {
 func (gce *Connection) updateInstanceMetadata(instance *compute.Instance, key, value string) error {
 	metadata := instance.Metadata
 	existingItem := findMetadataItem(metadata.Items, key)
-	if existingItem != nil && existingItem.Value != nil && *existingItem.Value == value {
+	if value == "" {
+		if existingItem == nil {
+			// The key's already gone.
+			return nil
+		}
+		items := make([]*compute.MetadataItems, 0, len(metadata.Items)-1)
+		for _, item := range metadata.Items {
+			if item != existingItem {
+				items = append(items, item)
+			}
+		}
+		metadata.Items = items
+	} else if existingItem != nil && existingItem.Value != nil && *existingItem.Value == value {
 		// The value's already right.
 		return nil
 	} else if existingItem == nil {
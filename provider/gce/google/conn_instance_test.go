@@ -372,6 +372,34 @@ func (s *connSuite) TestUpdateMetadataExistingAttribute(c *gc.C) {
 	checkMetadataItems(c, md.Items[0], "eggs", "beans")
 }
 
+func (s *connSuite) TestUpdateMetadataRemovesAttribute(c *gc.C) {
+	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.UpdateMetadata("eggs", "", s.RawInstanceFull.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+
+	call := s.FakeConn.Calls[1]
+	c.Check(call.FuncName, gc.Equals, "SetMetadata")
+	c.Check(call.InstanceId, gc.Equals, "spam")
+	c.Check(call.Metadata.Fingerprint, gc.Equals, "heymumwatchthis")
+	c.Check(call.Metadata.Items, gc.HasLen, 0)
+}
+
+func (s *connSuite) TestUpdateMetadataRemovesMissingAttribute(c *gc.C) {
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.UpdateMetadata("business", "", s.RawInstanceFull.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing needs updating.
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+}
+
 func (s *connSuite) TestUpdateMetadataMultipleInstances(c *gc.C) {
 	// Ensure we extract the name from the URL we get on the raw instance.
 	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
@@ -60,12 +60,23 @@ type FirewallNamer func(fw *firewall, prefix string, existingNames set.Strings)
 // firewall name - this is mostly useful for getting predictable
 // results in tests.
 func (gce Connection) OpenPortsWithNamer(target string, namer FirewallNamer, rules ...network.IngressRule) error {
+	return errors.Trace(gce.openPorts(target, target, namer, rules))
+}
+
+// OpenTargetPorts adds or creates firewall rules in the same way as
+// OpenPorts, but the firewalls are named using fwname and apply to
+// the instances tagged with target.
+func (gce Connection) OpenTargetPorts(fwname, target string, rules ...network.IngressRule) error {
+	return errors.Trace(gce.openPorts(fwname, target, RandomSuffixNamer, rules))
+}
+
+func (gce Connection) openPorts(fwname, target string, namer FirewallNamer, rules []network.IngressRule) error {
 	if len(rules) == 0 {
 		return nil
 	}
 
 	// First gather the current ingress rules.
-	currentRuleSet, err := gce.firewallRules(target)
+	currentRuleSet, err := gce.firewallRules(fwname)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -96,7 +107,7 @@ func (gce Connection) OpenPortsWithNamer(target string, namer FirewallNamer, rul
 
 		if !ok {
 			// Create a new firewall.
-			name, err := namer(inputFirewall, target, allNames)
+			name, err := namer(inputFirewall, fwname, allNames)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -157,8 +168,19 @@ func RandomSuffixNamer(fw *firewall, prefix string, existingNames set.Strings) (
 // match the provided port ranges. The call blocks until the ports are
 // closed or the request fails.
 func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) error {
+	return errors.Trace(gce.closePorts(target, target, rules))
+}
+
+// CloseTargetPorts closes port ranges in the same way as ClosePorts,
+// on the firewalls named using fwname that apply to the instances
+// tagged with target.
+func (gce Connection) CloseTargetPorts(fwname, target string, rules ...network.IngressRule) error {
+	return errors.Trace(gce.closePorts(fwname, target, rules))
+}
+
+func (gce Connection) closePorts(fwname, target string, rules []network.IngressRule) error {
 	// First gather the current ingress rules.
-	currentRuleSet, err := gce.firewallRules(target)
+	currentRuleSet, err := gce.firewallRules(fwname)
 	if err != nil {
 		return errors.Trace(err)
 	}
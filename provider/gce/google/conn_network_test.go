@@ -170,6 +170,28 @@ func (s *connSuite) TestConnectionOpenPortsAdd(c *gc.C) {
 	})
 }
 
+func (s *connSuite) TestConnectionOpenTargetPorts(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+
+	rule := network.MustNewIngressRule("tcp", 22, 22)
+	err := s.Conn.OpenTargetPorts("spam-model", "spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam-model")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         "spam-model",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	})
+}
+
 func (s *connSuite) TestConnectionOpenPortsUpdateSameCIDR(c *gc.C) {
 	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam-ad7554",
@@ -320,6 +342,36 @@ func (s *connSuite) TestConnectionClosePortsRemove(c *gc.C) {
 	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam")
 }
 
+func (s *connSuite) TestConnectionCloseTargetPorts(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam-model",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0", "10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	}}
+
+	rule := network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0")
+	err := s.Conn.CloseTargetPorts("spam-model", "spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "UpdateFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam-model")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         "spam-model",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	})
+}
+
 func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam",
@@ -12,6 +12,7 @@ var (
 	NewRawConnection = &newRawConnection
 
 	NewInstanceRaw      = newInstance
+	InstanceSpecRaw     = InstanceSpec.raw
 	PackMetadata        = packMetadata
 	UnpackMetadata      = unpackMetadata
 	FormatMachineType   = formatMachineType
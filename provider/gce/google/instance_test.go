@@ -99,3 +99,20 @@ func (s *instanceSuite) TestFormatMachineType(c *gc.C) {
 
 	c.Check(resolved, gc.Equals, "zones/a-zone/machineTypes/spam")
 }
+
+func (s *instanceSuite) TestInstanceSpecRaw(c *gc.C) {
+	raw := google.InstanceSpecRaw(s.InstanceSpec)
+
+	c.Check(raw.Name, gc.Equals, "spam")
+	c.Check(raw.Scheduling, gc.IsNil)
+}
+
+func (s *instanceSuite) TestInstanceSpecRawPreemptible(c *gc.C) {
+	s.InstanceSpec.Preemptible = true
+	raw := google.InstanceSpecRaw(s.InstanceSpec)
+
+	c.Assert(raw.Scheduling, gc.NotNil)
+	c.Check(raw.Scheduling.Preemptible, jc.IsTrue)
+	c.Check(*raw.Scheduling.AutomaticRestart, jc.IsFalse)
+	c.Check(raw.Scheduling.OnHostMaintenance, gc.Equals, "TERMINATE")
+}
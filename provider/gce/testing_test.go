@@ -471,6 +471,7 @@ type fakeConnCall struct {
 	Statuses     []string
 	InstanceSpec google.InstanceSpec
 	FirewallName string
+	Target       string
 	Rules        []network.IngressRule
 	Region       string
 	Disks        []google.DiskSpec
@@ -586,6 +587,26 @@ func (fc *fakeConn) ClosePorts(fwname string, rules ...network.IngressRule) erro
 	return fc.err()
 }
 
+func (fc *fakeConn) OpenTargetPorts(fwname, target string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "OpenTargetPorts",
+		FirewallName: fwname,
+		Target:       target,
+		Rules:        rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CloseTargetPorts(fwname, target string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CloseTargetPorts",
+		FirewallName: fwname,
+		Target:       target,
+		Rules:        rules,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -148,6 +148,12 @@ func (env *joyentEnviron) Destroy() error {
 	return errors.Trace(common.Destroy(env))
 }
 
+// DestroyKeepingInstances is specified in the InstanceKeepingDestroyer
+// interface.
+func (env *joyentEnviron) DestroyKeepingInstances(keep []instance.Id) error {
+	return errors.Trace(common.DestroyKeepingInstances(env, keep))
+}
+
 // DestroyController implements the Environ interface.
 func (env *joyentEnviron) DestroyController(controllerUUID string) error {
 	// TODO(wallyworld): destroy hosted model resources
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -321,7 +322,8 @@ func (p environProviderCredentials) isLocalEndpoint(endpoint string) (bool, erro
 	}
 	host, _, err := net.SplitHostPort(endpointURL.Host)
 	if err != nil {
-		host = endpointURL.Host
+		// No port, but an IPv6 address may still be in brackets.
+		host = strings.TrimSuffix(strings.TrimPrefix(endpointURL.Host, "["), "]")
 	}
 	endpointAddrs, err := p.lookupHost(host)
 	if err != nil {
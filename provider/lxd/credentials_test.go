@@ -152,6 +152,21 @@ func (s *credentialsSuite) TestFinalizeCredentialLocal(c *gc.C) {
 	)
 }
 
+func (s *credentialsSuite) TestFinalizeCredentialLocalIPv6(c *gc.C) {
+	s.PatchValue(&s.InterfaceAddrs, []net.Addr{&net.IPNet{IP: net.ParseIP("2001:db8::1")}})
+	s.PatchValue(&s.EndpointAddrs, []string{"2001:db8::1"})
+	cert, _ := s.TestingCert(c)
+	_, err := s.Provider.FinalizeCredential(cmdtesting.Context(c), environs.FinalizeCredentialParams{
+		CloudEndpoint: "[2001:db8::1]",
+		Credential: cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+			"client-cert": string(cert.CertPEM),
+			"client-key":  string(cert.KeyPEM),
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCall(c, 0, "LookupHost", "2001:db8::1")
+}
+
 func (s *credentialsSuite) TestFinalizeCredentialLocalAddCert(c *gc.C) {
 	s.Stub.SetErrors(errors.NotFoundf("certificate"))
 	cert, _ := s.TestingCert(c)
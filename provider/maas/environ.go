@@ -108,6 +108,7 @@ type maasEnviron struct {
 }
 
 var _ environs.Environ = (*maasEnviron)(nil)
+var _ environs.InstanceKeepingDestroyer = (*maasEnviron)(nil)
 
 // MaasCapabilities represents a function that gets the capabilities of a MAAS
 // installation.
@@ -2014,7 +2015,14 @@ func (env *maasEnviron) Storage() storage.Storage {
 }
 
 func (environ *maasEnviron) Destroy() error {
-	if err := common.Destroy(environ); err != nil {
+	return environ.DestroyKeepingInstances(nil)
+}
+
+// DestroyKeepingInstances is specified in the InstanceKeepingDestroyer
+// interface. MAAS cannot tag nodes, so the nodes kept when their
+// machines were removed must be left acquired explicitly.
+func (environ *maasEnviron) DestroyKeepingInstances(keep []instance.Id) error {
+	if err := common.DestroyKeepingInstances(environ, keep); err != nil {
 		return errors.Trace(err)
 	}
 	return environ.Storage().RemoveAll()
@@ -4,15 +4,38 @@
 package manual
 
 import (
+	"strings"
+
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 )
 
+const (
+	// machinePoolAuthType is the auth type of credentials that
+	// register a pool of hosts from which machines are allocated.
+	// The pool is held in the credential, rather than in model
+	// config, so that only the credential's owner can change it.
+	machinePoolAuthType cloud.AuthType = "machine-pool"
+
+	credAttrHosts     = "hosts"
+	credAttrHostsFile = "hosts-file"
+)
+
 type environProviderCredentials struct{}
 
 // CredentialSchemas is part of the environs.ProviderCredentials interface.
 func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
-	return map[cloud.AuthType]cloud.CredentialSchema{cloud.EmptyAuthType: {}}
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.EmptyAuthType: {},
+		machinePoolAuthType: {{
+			credAttrHosts, cloud.CredentialAttr{
+				Description: "hosts in the machine pool, separated by commas or newlines, each of the form <host> [<label>[=<value>] ...]",
+				FileAttr:    credAttrHostsFile,
+			},
+		}},
+	}
 }
 
 // DetectCredentials is part of the environs.ProviderCredentials interface.
@@ -22,5 +45,29 @@ func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, e
 
 // FinalizeCredential is part of the environs.ProviderCredentials interface.
 func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	if _, err := machinePool(&args.Credential); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &args.Credential, nil
 }
+
+// machinePool returns the hosts in the machine pool registered by the
+// credential, if it has the machine-pool auth type.
+func machinePool(cred *cloud.Credential) ([]poolHost, error) {
+	if cred == nil || cred.AuthType() != machinePoolAuthType {
+		return nil, nil
+	}
+	var entries []string
+	for _, line := range strings.Split(cred.Attributes()[credAttrHosts], "\n") {
+		for _, entry := range strings.Split(line, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	hosts, err := parseMachinePool(entries)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid machine pool credential")
+	}
+	return hosts, nil
+}
@@ -29,7 +29,23 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
-	envtesting.AssertProviderAuthTypes(c, s.provider, "empty")
+	envtesting.AssertProviderAuthTypes(c, s.provider, "empty", "machine-pool")
+}
+
+func (s *credentialsSuite) TestMachinePoolCredentialsValid(c *gc.C) {
+	envtesting.AssertProviderCredentialsValid(c, s.provider, "machine-pool", map[string]string{
+		"hosts": "10.0.0.1 rack=r1, 10.0.0.2 rack=r2",
+	})
+}
+
+func (s *credentialsSuite) TestFinalizeMachinePoolCredential(c *gc.C) {
+	cred := cloud.NewCredential("machine-pool", map[string]string{
+		"hosts": "10.0.0.1 cores=many",
+	})
+	_, err := s.provider.FinalizeCredential(nil, environs.FinalizeCredentialParams{
+		Credential: cred,
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid machine pool credential: parsing labels of machine pool host "10.0.0.1": .*`)
 }
 
 func (s *credentialsSuite) TestDetectCredentials(c *gc.C) {
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/featureflag"
+	"github.com/juju/utils/set"
 	"github.com/juju/utils/ssh"
 	"github.com/juju/version"
 
@@ -33,6 +34,8 @@ import (
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/tools"
 )
 
 const (
@@ -54,6 +57,15 @@ type manualEnviron struct {
 	// target machine. We cache these, as they should not change.
 	hw     *instance.HardwareCharacteristics
 	series string
+
+	// pool holds the hosts from which machines are allocated,
+	// as registered in the cloud credential.
+	pool []poolHost
+
+	// allocating holds the machine pool hosts that are being
+	// probed or provisioned, so that they are not allocated twice.
+	poolMu     sync.Mutex
+	allocating set.Strings
 }
 
 var errNoStartInstance = errors.New("manual provider cannot start instances")
@@ -64,14 +76,129 @@ func (*manualEnviron) MaintainInstance(args environs.StartInstanceParams) error
 	return nil
 }
 
-func (*manualEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
-	return nil, errNoStartInstance
+// StartInstance is specified in the InstanceBroker interface. Instances
+// can only be started if the cloud credential has a machine pool, by
+// provisioning a free host from the pool that matches the constraints.
+func (e *manualEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	if len(e.pool) == 0 {
+		return nil, errNoStartInstance
+	}
+	if args.Placement != "" {
+		return nil, errors.Errorf("unknown placement directive: %v", args.Placement)
+	}
+	host, hostArch, err := e.allocatePoolHost(args.Constraints, args.InstanceConfig.Series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer e.unmarkAllocating(host)
+
+	selectedTools, err := args.Tools.Match(tools.Filter{Arch: hostArch})
+	if err != nil {
+		return nil, errors.Annotatef(err, "selecting tools for machine pool host %q", host.host)
+	}
+	if err := args.InstanceConfig.SetTools(selectedTools); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, e.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if args.StatusCallback != nil {
+		args.StatusCallback(status.Allocating, fmt.Sprintf("Provisioning machine pool host %q", host.host), nil)
+	}
+	if err := provisionPoolHost(host.host, args.InstanceConfig); err != nil {
+		// Remove anything that was installed, so the
+		// host remains free.
+		if err := releasePoolHost(host.host); err != nil {
+			logger.Errorf("error releasing failed machine pool host: %v", err)
+		}
+		return nil, errors.Trace(err)
+	}
+
+	hc := host.hardware
+	hc.Arch = &hostArch
+	return &environs.StartInstanceResult{
+		Instance: newPoolInstance(host),
+		Hardware: &hc,
+	}, nil
+}
+
+// allocatePoolHost returns the first host in the pool that matches the
+// constraints and series and is not in use, along with its detected
+// architecture. The host is marked as allocating, and must be unmarked
+// by the caller.
+//
+// Whether a host is in use is recorded on the host itself, by the agent
+// installed there, so each candidate is probed. Candidates are marked
+// before they are probed, so that the lock need not be held while
+// waiting for SSH.
+func (e *manualEnviron) allocatePoolHost(cons constraints.Value, series string) (poolHost, string, error) {
+	for _, host := range e.pool {
+		if !host.matches(cons, "") || !e.markAllocating(host) {
+			continue
+		}
+		hostStatus, err := probePoolHost(host.host)
+		switch {
+		case err != nil:
+			logger.Warningf("%v", err)
+		case hostStatus.provisioned:
+			logger.Debugf("machine pool host %q is in use", host.host)
+		case hostStatus.series != series:
+			logger.Debugf("machine pool host %q runs %q, not %q", host.host, hostStatus.series, series)
+		case !host.matches(cons, hostStatus.arch):
+			logger.Debugf("machine pool host %q has architecture %q", host.host, hostStatus.arch)
+		default:
+			return host, hostStatus.arch, nil
+		}
+		e.unmarkAllocating(host)
+	}
+	return poolHost{}, "", errors.Errorf(
+		"no free host in the machine pool matches series %q and constraints %q",
+		series, cons,
+	)
+}
+
+// markAllocating marks the host as allocating, and reports whether
+// it was not already marked.
+func (e *manualEnviron) markAllocating(host poolHost) bool {
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	if e.allocating.Contains(host.host) {
+		return false
+	}
+	e.allocating.Add(host.host)
+	return true
+}
+
+func (e *manualEnviron) unmarkAllocating(host poolHost) {
+	e.poolMu.Lock()
+	defer e.poolMu.Unlock()
+	e.allocating.Remove(host.host)
 }
 
-func (*manualEnviron) StopInstances(...instance.Id) error {
-	return errNoStopInstance
+// StopInstances is specified in the InstanceBroker interface. Only
+// hosts allocated from the machine pool can be stopped, which releases
+// them back to the pool.
+func (e *manualEnviron) StopInstances(ids ...instance.Id) error {
+	hosts := make([]poolHost, len(ids))
+	for i, id := range ids {
+		host, ok := poolHostForId(e.pool, id)
+		if !ok {
+			return errNoStopInstance
+		}
+		hosts[i] = host
+	}
+	for _, host := range hosts {
+		if err := releasePoolHost(host.host); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
+// AllInstances is specified in the InstanceBroker interface. It only
+// returns the bootstrap instance: hosts allocated from the machine pool
+// are recorded as their machines' instance IDs in state, and are
+// resolved by Instances.
 func (e *manualEnviron) AllInstances() ([]instance.Instance, error) {
 	return e.Instances([]instance.Id{BootstrapInstanceId})
 }
@@ -193,8 +320,9 @@ func (e *manualEnviron) SetConfig(cfg *config.Config) error {
 // Implements environs.Environ.
 //
 // This method will only ever return an Instance for the Id
-// BootstrapInstanceId. If any others are specified, then
-// ErrPartialInstances or ErrNoInstances will result.
+// BootstrapInstanceId, or for the Id of a host in the machine
+// pool. If any others are specified, then ErrPartialInstances
+// or ErrNoInstances will result.
 func (e *manualEnviron) Instances(ids []instance.Id) (instances []instance.Instance, err error) {
 	instances = make([]instance.Instance, len(ids))
 	var found bool
@@ -202,6 +330,9 @@ func (e *manualEnviron) Instances(ids []instance.Id) (instances []instance.Insta
 		if id == BootstrapInstanceId {
 			instances[i] = manualBootstrapInstance{e.host}
 			found = true
+		} else if host, ok := poolHostForId(e.pool, id); ok {
+			instances[i] = newPoolInstance(host)
+			found = true
 		} else {
 			err = environs.ErrPartialInstances
 		}
@@ -213,7 +344,11 @@ func (e *manualEnviron) Instances(ids []instance.Id) (instances []instance.Insta
 }
 
 var runSSHCommand = func(host string, command []string, stdin string) (stdout, stderr string, err error) {
-	cmd := ssh.Command(host, command, nil)
+	return runSSHCommandWithOptions(host, command, stdin, nil)
+}
+
+func runSSHCommandWithOptions(host string, command []string, stdin string, options *ssh.Options) (stdout, stderr string, err error) {
+	cmd := ssh.Command(host, command, options)
 	cmd.Stdin = strings.NewReader(stdin)
 	var stdoutBuf, stderrBuf bytes.Buffer
 	cmd.Stdout = &stdoutBuf
@@ -314,8 +449,22 @@ exit 0
 	return err
 }
 
-func (*manualEnviron) PrecheckInstance(series string, _ constraints.Value, placement string) error {
-	return errors.New(`use "juju add-machine ssh:[user@]<host>" to provision machines`)
+// PrecheckInstance is defined on the state.Prechecker interface.
+// Machines can only be added if the cloud credential has a machine
+// pool with a host that may match the constraints.
+func (e *manualEnviron) PrecheckInstance(series string, cons constraints.Value, placement string) error {
+	if len(e.pool) == 0 {
+		return errors.New(`use "juju add-machine ssh:[user@]<host>" to provision machines`)
+	}
+	if placement != "" {
+		return errors.Errorf("unknown placement directive: %v", placement)
+	}
+	for _, host := range e.pool {
+		if host.matches(cons, "") {
+			return nil
+		}
+	}
+	return errors.Errorf("no host in the machine pool matches constraints %q", cons)
 }
 
 var unsupportedConstraints = []string{
@@ -325,9 +474,24 @@ var unsupportedConstraints = []string{
 	constraints.VirtType,
 }
 
+// poolUnsupportedConstraints are the constraints that are unsupported
+// when the cloud credential has a machine pool. Tags select hosts by
+// their labels.
+var poolUnsupportedConstraints = []string{
+	constraints.CpuPower,
+	constraints.InstanceType,
+	constraints.VirtType,
+}
+
 // ConstraintsValidator is defined on the Environs interface.
 func (e *manualEnviron) ConstraintsValidator() (constraints.Validator, error) {
 	validator := constraints.NewValidator()
+	if len(e.pool) > 0 {
+		// Pool hosts may have any architecture, which is
+		// checked when they are allocated.
+		validator.RegisterUnsupported(poolUnsupportedConstraints)
+		return validator, nil
+	}
 	validator.RegisterUnsupported(unsupportedConstraints)
 	if isRunningController() {
 		validator.UpdateVocabulary(constraints.Arch, []string{arch.HostArch()})
@@ -49,3 +49,17 @@ func (manualBootstrapInstance) ClosePorts(machineId string, rules []network.Ingr
 func (manualBootstrapInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
 	return nil, nil
 }
+
+// manualPoolInstance is a host allocated from the machine pool.
+// Apart from its ID, it behaves as the bootstrap instance does.
+type manualPoolInstance struct {
+	manualBootstrapInstance
+}
+
+func newPoolInstance(host poolHost) manualPoolInstance {
+	return manualPoolInstance{manualBootstrapInstance{host.host}}
+}
+
+func (inst manualPoolInstance) Id() instance.Id {
+	return instance.Id(manual.ManualInstancePrefix + inst.host)
+}
@@ -0,0 +1,294 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/sshinit"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/service"
+)
+
+// poolHost is a host in the machine pool. Machines are
+// allocated from the pool by provisioning a host that has no Juju
+// agent, and released to it by removing the agent again.
+type poolHost struct {
+	// host is the hostname or address of the host, which
+	// is reached over SSH as the "ubuntu" user.
+	host string
+
+	// hardware holds the hardware characteristics given by
+	// the host's labels. Labels that do not describe hardware
+	// are recorded as tags.
+	hardware instance.HardwareCharacteristics
+}
+
+// hardwareLabels holds the labels that describe the hardware of
+// a pool host, rather than being recorded as tags.
+var hardwareLabels = map[string]bool{
+	constraints.Arch:     true,
+	constraints.Cores:    true,
+	"cpu-cores":          true,
+	constraints.Mem:      true,
+	constraints.RootDisk: true,
+}
+
+// parseMachinePool parses the entries of a machine pool credential's
+// "hosts" attribute, each of which has the form
+//
+//	<host> [<label>[=<value>] ...]
+//
+// Labels named arch, cores, mem and root-disk describe the host's
+// hardware, and are written as they would be in constraints. All
+// other labels, such as "rack=r1" or "ssd", are tags that may be
+// required with the tags constraint.
+func parseMachinePool(entries []string) ([]poolHost, error) {
+	hosts := make([]poolHost, 0, len(entries))
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		host, err := parsePoolHost(entry)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if seen[host.host] {
+			return nil, errors.Errorf("machine pool host %q listed more than once", host.host)
+		}
+		seen[host.host] = true
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+func parsePoolHost(entry string) (poolHost, error) {
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return poolHost{}, errors.New("machine pool host not specified")
+	}
+	result := poolHost{host: fields[0]}
+	if strings.ContainsAny(result.host, "@=") {
+		return poolHost{}, errors.Errorf(
+			`machine pool host %q not valid: expected a hostname or address, reached as the "ubuntu" user`,
+			result.host,
+		)
+	}
+	var hardware, tags []string
+	for _, label := range fields[1:] {
+		key := label
+		if i := strings.IndexRune(label, '='); i >= 0 {
+			key = label[:i]
+		}
+		if hardwareLabels[key] {
+			hardware = append(hardware, label)
+		} else {
+			tags = append(tags, label)
+		}
+	}
+	cons, err := constraints.Parse(hardware...)
+	if err != nil {
+		return poolHost{}, errors.Annotatef(err, "parsing labels of machine pool host %q", result.host)
+	}
+	result.hardware = instance.HardwareCharacteristics{
+		Arch:     cons.Arch,
+		CpuCores: cons.CpuCores,
+		Mem:      cons.Mem,
+		RootDisk: cons.RootDisk,
+	}
+	if len(tags) > 0 {
+		result.hardware.Tags = &tags
+	}
+	return result, nil
+}
+
+// matches reports whether the host satisfies the given constraints,
+// judged by its labels. If hostArch is non-empty, it is the detected
+// architecture of the host; otherwise a host without an arch label
+// is assumed to have the required architecture. Hosts without a
+// label for any other constrained value do not match.
+func (h poolHost) matches(cons constraints.Value, hostArch string) bool {
+	hw := h.hardware
+	if hostArch == "" && hw.Arch != nil {
+		hostArch = *hw.Arch
+	}
+	if cons.Arch != nil && hostArch != "" && hostArch != *cons.Arch {
+		return false
+	}
+	if !atLeast(hw.CpuCores, cons.CpuCores) || !atLeast(hw.Mem, cons.Mem) || !atLeast(hw.RootDisk, cons.RootDisk) {
+		return false
+	}
+	if cons.Tags != nil {
+		var tags []string
+		if hw.Tags != nil {
+			tags = *hw.Tags
+		}
+		for _, tag := range *cons.Tags {
+			if strings.HasPrefix(tag, "^") {
+				if hasTag(tags, tag[1:]) {
+					return false
+				}
+			} else if !hasTag(tags, tag) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func atLeast(have, want *uint64) bool {
+	if want == nil || *want == 0 {
+		return true
+	}
+	return have != nil && *have >= *want
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// poolHostForId returns the host in the pool that the instance with
+// the given ID represents, if any. Allocated hosts have the same
+// instance ID they would have if added with "juju add-machine ssh:".
+func poolHostForId(pool []poolHost, id instance.Id) (poolHost, bool) {
+	for _, h := range pool {
+		if id == instance.Id(manual.ManualInstancePrefix+h.host) {
+			return h, true
+		}
+	}
+	return poolHost{}, false
+}
+
+// poolHostStatus describes a pool host, as reported by probeScript.
+type poolHostStatus struct {
+	arch        string
+	series      string
+	provisioned bool
+	modelTag    string
+}
+
+// probeScript reports the architecture and series of a pool host,
+// the model of any Juju agent installed on it, and then its services.
+var probeScript = fmt.Sprintf(`
+uname -m
+lsb_release -cs 2>/dev/null || echo
+echo "$(sed -n 's/^model: *//p' %s/agents/machine-*/agent.conf 2>/dev/null | head -n 1)"
+%s`[1:],
+	utils.ShQuote(agent.DefaultPaths.DataDir),
+	service.ListServicesScript(),
+)
+
+func probePoolHost(host string) (poolHostStatus, error) {
+	stdout, _, err := runPoolSSHCommand("ubuntu@"+host, []string{"/bin/bash"}, probeScript)
+	if err != nil {
+		return poolHostStatus{}, errors.Annotatef(err, "probing machine pool host %q", host)
+	}
+	lines := strings.SplitN(stdout, "\n", 4)
+	if len(lines) < 4 {
+		return poolHostStatus{}, errors.Errorf("probing machine pool host %q: unexpected output %q", host, stdout)
+	}
+	modelTag := strings.TrimSpace(lines[2])
+	return poolHostStatus{
+		arch:   arch.NormaliseArch(strings.TrimSpace(lines[0])),
+		series: strings.TrimSpace(lines[1]),
+		// As with "juju add-machine ssh:", a host with any Juju
+		// service is considered provisioned.
+		provisioned: modelTag != "" || strings.Contains(lines[3], "juju"),
+		modelTag:    modelTag,
+	}, nil
+}
+
+// belongsTo reports whether the host has an agent of the given model.
+func (s poolHostStatus) belongsTo(modelUUID string) bool {
+	return s.modelTag == names.NewModelTag(modelUUID).String()
+}
+
+// releaseScript removes the Juju agents from a pool host, so that it
+// may be allocated again. The machine agent normally uninstalls itself
+// when its machine is removed; this cleans up after agents that could
+// not, and after failed provisioning.
+const releaseScript = `
+set -x
+touch %[1]s
+# SIGABRT lets the agent know it should uninstall itself.
+pkill -SIGABRT jujud
+for i in {1..30}; do
+    pgrep jujud > /dev/null || break
+    sleep 1
+done
+pkill -SIGKILL jujud
+rm -f /etc/init/jujud*
+rm -f /etc/systemd/system{,/multi-user.target.wants}/jujud*
+rm -fr %[2]s %[3]s
+exit 0
+`
+
+func releasePoolHost(host string) error {
+	script := fmt.Sprintf(
+		releaseScript,
+		// WARNING: this is linked with the use of uninstallFile in
+		// the agent package, as is the script in DestroyController.
+		utils.ShQuote(path.Join(
+			agent.DefaultPaths.DataDir,
+			agent.UninstallFile,
+		)),
+		utils.ShQuote(agent.DefaultPaths.DataDir),
+		utils.ShQuote(agent.DefaultPaths.LogDir),
+	)
+	stdout, stderr, err := runPoolSSHCommand(
+		"ubuntu@"+host,
+		[]string{"sudo", "/bin/bash"}, script,
+	)
+	logger.Debugf("release script stdout: \n%s", stdout)
+	logger.Debugf("release script stderr: \n%s", stderr)
+	return errors.Annotatef(err, "releasing machine pool host %q", host)
+}
+
+// poolSSHOptions returns the options for connecting to pool hosts.
+// Machines are allocated by the controller, which connects with the
+// controller's system identity.
+func poolSSHOptions() *ssh.Options {
+	var options ssh.Options
+	options.SetIdentities(path.Join(agent.DefaultPaths.DataDir, agent.SystemIdentity))
+	return &options
+}
+
+var runPoolSSHCommand = func(host string, command []string, stdin string) (stdout, stderr string, err error) {
+	return runSSHCommandWithOptions(host, command, stdin, poolSSHOptions())
+}
+
+var provisionPoolHost = func(host string, icfg *instancecfg.InstanceConfig) error {
+	script, err := sshprovisioner.ProvisioningScript(icfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var progress bytes.Buffer
+	if err := sshinit.RunConfigureScript(script, sshinit.ConfigureParams{
+		Host:           "ubuntu@" + host,
+		SSHOptions:     poolSSHOptions(),
+		ProgressWriter: &progress,
+		Series:         icfg.Series,
+	}); err != nil {
+		logger.Errorf("provisioning machine pool host %q failed:\n%s", host, progress.String())
+		return errors.Annotatef(err, "provisioning machine pool host %q", host)
+	}
+	return nil
+}
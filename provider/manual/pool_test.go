@@ -0,0 +1,319 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type machinePoolSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	env *manualEnviron
+
+	// probes holds the output of the probe script on each host.
+	probes       map[string]string
+	released     []string
+	provisioned  []string
+	provisionErr error
+}
+
+var _ = gc.Suite(&machinePoolSuite{})
+
+var testMachinePool = []string{
+	"10.0.0.1 cores=4 mem=8G rack=r1",
+	"10.0.0.2 cores=8 mem=16G rack=r2 ssd",
+	"10.0.0.3 arch=arm64 cores=2 mem=4G",
+}
+
+func machinePoolCredential(hosts string) *cloud.Credential {
+	cred := cloud.NewCredential(machinePoolAuthType, map[string]string{
+		credAttrHosts: hosts,
+	})
+	return &cred
+}
+
+func (s *machinePoolSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	spec := CloudSpec()
+	spec.Credential = machinePoolCredential(strings.Join(testMachinePool, "\n"))
+	env, err := ManualProvider{}.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: MinimalConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env.(*manualEnviron)
+
+	s.probes = map[string]string{
+		"10.0.0.1": freeProbe("x86_64"),
+		"10.0.0.2": freeProbe("x86_64"),
+		"10.0.0.3": freeProbe("aarch64"),
+	}
+	s.released = nil
+	s.provisioned = nil
+	s.provisionErr = nil
+	s.PatchValue(&runPoolSSHCommand, func(host string, command []string, stdin string) (string, string, error) {
+		switch len(command) {
+		case 1:
+			c.Assert(stdin, gc.Equals, probeScript)
+			stdout, ok := s.probes[host[len("ubuntu@"):]]
+			if !ok {
+				return "", "", errors.New("no route to host")
+			}
+			return stdout, "", nil
+		default:
+			c.Assert(command, jc.DeepEquals, []string{"sudo", "/bin/bash"})
+			s.released = append(s.released, host)
+			return "", "", nil
+		}
+	})
+	s.PatchValue(&provisionPoolHost, func(host string, icfg *instancecfg.InstanceConfig) error {
+		s.provisioned = append(s.provisioned, host)
+		return s.provisionErr
+	})
+}
+
+func freeProbe(uname string) string {
+	return uname + "\nxenial\n\nsnapd\n"
+}
+
+func modelProbe(modelUUID string) string {
+	return fmt.Sprintf("x86_64\nxenial\n%s\njujud-machine-1\n", names.NewModelTag(modelUUID))
+}
+
+func (s *machinePoolSuite) startInstanceParams(c *gc.C, cons string) environs.StartInstanceParams {
+	machineTag := names.NewMachineTag("1")
+	apiInfo := &api.Info{
+		Addrs:    []string{"localhost:17777"},
+		CACert:   coretesting.CACert,
+		Password: "password",
+		Tag:      machineTag,
+		ModelTag: coretesting.ModelTag,
+	}
+	icfg, err := instancecfg.NewInstanceConfig(
+		coretesting.ControllerTag, machineTag.Id(), "nonce",
+		imagemetadata.ReleasedStream, "xenial", apiInfo,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	var toolsList tools.List
+	for _, a := range []string{arch.AMD64, arch.ARM64} {
+		toolsList = append(toolsList, &tools.Tools{
+			Version: version.Binary{
+				Number: version.MustParse("2.2.0"),
+				Series: "xenial",
+				Arch:   a,
+			},
+			URL: "http://example.com/tools/juju-2.2.0-xenial-" + a + ".tgz",
+		})
+	}
+	return environs.StartInstanceParams{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		Constraints:    constraints.MustParse(cons),
+		Tools:          toolsList,
+		InstanceConfig: icfg,
+	}
+}
+
+func (s *machinePoolSuite) TestParseMachinePool(c *gc.C) {
+	hosts, err := parseMachinePool(testMachinePool)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hosts, gc.HasLen, 3)
+	c.Assert(hosts[1].host, gc.Equals, "10.0.0.2")
+	c.Assert(hosts[1].hardware.String(), gc.Equals, "cores=8 mem=16384M tags=rack=r2,ssd")
+	c.Assert(hosts[2].hardware.String(), gc.Equals, "arch=arm64 cores=2 mem=4096M")
+}
+
+func (s *machinePoolSuite) TestParseMachinePoolErrors(c *gc.C) {
+	for i, test := range []struct {
+		entries []string
+		err     string
+	}{{
+		entries: []string{"  "},
+		err:     "machine pool host not specified",
+	}, {
+		entries: []string{"ubuntu@10.0.0.1"},
+		err:     `machine pool host "ubuntu@10.0.0.1" not valid: expected a hostname or address, reached as the "ubuntu" user`,
+	}, {
+		entries: []string{"10.0.0.1 mem=lots"},
+		err:     `parsing labels of machine pool host "10.0.0.1": bad "mem" constraint: .*`,
+	}, {
+		entries: []string{"10.0.0.1", "10.0.0.1 ssd"},
+		err:     `machine pool host "10.0.0.1" listed more than once`,
+	}} {
+		c.Logf("test %d: %v", i, test.entries)
+		_, err := parseMachinePool(test.entries)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *machinePoolSuite) TestMachinePoolCredential(c *gc.C) {
+	hosts, err := machinePool(machinePoolCredential("10.0.0.1 rack=r1, 10.0.0.2 rack=r2\n10.0.0.3"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hosts, gc.HasLen, 3)
+	c.Assert(hosts[2].host, gc.Equals, "10.0.0.3")
+
+	empty := cloud.NewEmptyCredential()
+	hosts, err = machinePool(&empty)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hosts, gc.HasLen, 0)
+
+	_, err = ManualProvider{}.Open(environs.OpenParams{
+		Cloud: environs.CloudSpec{
+			Type:       "manual",
+			Name:       "manual",
+			Endpoint:   "hostname",
+			Credential: machinePoolCredential("10.0.0.1 cores=many"),
+		},
+		Config: MinimalConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid machine pool credential: parsing labels of machine pool host "10.0.0.1": .*`)
+}
+
+func (s *machinePoolSuite) TestPrecheckInstance(c *gc.C) {
+	err := s.env.PrecheckInstance("xenial", constraints.MustParse("mem=12G tags=ssd"), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.PrecheckInstance("xenial", constraints.MustParse("tags=rack=r3"), "")
+	c.Assert(err, gc.ErrorMatches, `no host in the machine pool matches constraints "tags=rack=r3"`)
+	err = s.env.PrecheckInstance("xenial", constraints.Value{}, "zone=a")
+	c.Assert(err, gc.ErrorMatches, `unknown placement directive: zone=a`)
+}
+
+func (s *machinePoolSuite) TestPrecheckInstanceNoPool(c *gc.C) {
+	env, err := ManualProvider{}.Open(environs.OpenParams{
+		Cloud:  CloudSpec(),
+		Config: MinimalConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.PrecheckInstance("xenial", constraints.Value{}, "")
+	c.Assert(err, gc.ErrorMatches, `use "juju add-machine ssh:\[user@\]<host>" to provision machines`)
+	_, err = env.StartInstance(s.startInstanceParams(c, ""))
+	c.Assert(err, gc.Equals, errNoStartInstance)
+}
+
+func (s *machinePoolSuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	cons := constraints.MustParse("arch=arm64 instance-type=foo tags=ssd cpu-power=10 virt-type=kvm")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "instance-type", "virt-type"})
+}
+
+func (s *machinePoolSuite) TestStartInstance(c *gc.C) {
+	// The first host is in use, and the second runs the wrong series.
+	s.probes["10.0.0.1"] = modelProbe(coretesting.ModelTag.Id())
+	s.probes["10.0.0.2"] = "x86_64\ntrusty\n\n"
+	s.probes["10.0.0.3"] = freeProbe("x86_64")
+
+	params := s.startInstanceParams(c, "")
+	result, err := s.env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("manual:10.0.0.3"))
+	// Detected hardware takes precedence over labels.
+	c.Assert(result.Hardware.String(), gc.Equals, "arch=amd64 cores=2 mem=4096M")
+	c.Assert(s.provisioned, jc.DeepEquals, []string{"10.0.0.3"})
+	c.Assert(params.InstanceConfig.AgentVersion().Arch, gc.Equals, arch.AMD64)
+	c.Assert(s.released, gc.HasLen, 0)
+}
+
+func (s *machinePoolSuite) TestStartInstanceConstraints(c *gc.C) {
+	result, err := s.env.StartInstance(s.startInstanceParams(c, "cores=6 tags=rack=r2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("manual:10.0.0.2"))
+	c.Assert(result.Hardware.String(), gc.Equals, "arch=amd64 cores=8 mem=16384M tags=rack=r2,ssd")
+
+	result, err = s.env.StartInstance(s.startInstanceParams(c, "arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("manual:10.0.0.3"))
+	c.Assert(s.provisioned, jc.DeepEquals, []string{"10.0.0.2", "10.0.0.3"})
+}
+
+func (s *machinePoolSuite) TestStartInstanceNoFreeHost(c *gc.C) {
+	delete(s.probes, "10.0.0.2")
+	_, err := s.env.StartInstance(s.startInstanceParams(c, "tags=ssd"))
+	c.Assert(err, gc.ErrorMatches, `no free host in the machine pool matches series "xenial" and constraints "tags=ssd"`)
+	c.Assert(s.provisioned, gc.HasLen, 0)
+}
+
+func (s *machinePoolSuite) TestStartInstanceSkipsAllocatingHost(c *gc.C) {
+	c.Assert(s.env.markAllocating(s.env.pool[0]), jc.IsTrue)
+	result, err := s.env.StartInstance(s.startInstanceParams(c, "arch=amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("manual:10.0.0.2"))
+	c.Assert(s.provisioned, jc.DeepEquals, []string{"10.0.0.2"})
+	c.Assert(s.env.allocating.Values(), jc.DeepEquals, []string{"10.0.0.1"})
+}
+
+func (s *machinePoolSuite) TestStartInstanceProbesWithoutLock(c *gc.C) {
+	probe := runPoolSSHCommand
+	s.PatchValue(&runPoolSSHCommand, func(host string, command []string, stdin string) (string, string, error) {
+		// Another allocation can mark a host while
+		// this one is being probed.
+		if host == "ubuntu@10.0.0.1" {
+			c.Assert(s.env.markAllocating(s.env.pool[1]), jc.IsTrue)
+		}
+		return probe(host, command, stdin)
+	})
+	s.probes["10.0.0.1"] = modelProbe(coretesting.ModelTag.Id())
+	s.probes["10.0.0.3"] = freeProbe("x86_64")
+	result, err := s.env.StartInstance(s.startInstanceParams(c, ""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("manual:10.0.0.3"))
+}
+
+func (s *machinePoolSuite) TestStartInstanceProvisioningFails(c *gc.C) {
+	s.provisionErr = errors.New("boom")
+	_, err := s.env.StartInstance(s.startInstanceParams(c, ""))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(s.provisioned, jc.DeepEquals, []string{"10.0.0.1"})
+	c.Assert(s.released, jc.DeepEquals, []string{"ubuntu@10.0.0.1"})
+	c.Assert(s.env.allocating.Size(), gc.Equals, 0)
+}
+
+func (s *machinePoolSuite) TestStopInstances(c *gc.C) {
+	err := s.env.StopInstances("manual:10.0.0.1", "manual:10.0.0.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.released, jc.DeepEquals, []string{"ubuntu@10.0.0.1", "ubuntu@10.0.0.3"})
+
+	s.released = nil
+	err = s.env.StopInstances("manual:10.0.0.1", "manual:10.0.0.9")
+	c.Assert(err, gc.Equals, errNoStopInstance)
+	c.Assert(s.released, gc.HasLen, 0)
+}
+
+func (s *machinePoolSuite) TestAllInstances(c *gc.C) {
+	s.PatchValue(&runPoolSSHCommand, func(host string, command []string, stdin string) (string, string, error) {
+		c.Fatalf("unexpected SSH command on %q", host)
+		return "", "", nil
+	})
+	instances, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+	c.Assert(instances[0].Id(), gc.Equals, BootstrapInstanceId)
+}
+
+func (s *machinePoolSuite) TestInstances(c *gc.C) {
+	instances, err := s.env.Instances([]instance.Id{"manual:10.0.0.2", "manual:10.0.0.9"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(instances, gc.HasLen, 2)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("manual:10.0.0.2"))
+	c.Assert(instances[1], gc.IsNil)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
@@ -117,7 +118,11 @@ func (p ManualProvider) Open(args environs.OpenParams) (environs.Environ, error)
 	if i := strings.IndexRune(host, '@'); i >= 0 {
 		user, host = host[:i], host[i+1:]
 	}
-	return p.open(host, user, envConfig)
+	pool, err := machinePool(args.Cloud.Credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return p.open(host, user, envConfig, pool)
 }
 
 func validateCloudSpec(spec environs.CloudSpec) error {
@@ -130,8 +135,14 @@ func validateCloudSpec(spec environs.CloudSpec) error {
 	return nil
 }
 
-func (p ManualProvider) open(host, user string, cfg *environConfig) (environs.Environ, error) {
-	env := &manualEnviron{host: host, user: user, cfg: cfg}
+func (p ManualProvider) open(host, user string, cfg *environConfig, pool []poolHost) (environs.Environ, error) {
+	env := &manualEnviron{
+		host:       host,
+		user:       user,
+		cfg:        cfg,
+		pool:       pool,
+		allocating: set.NewStrings(),
+	}
 	// Need to call SetConfig to initialise storage.
 	if err := env.SetConfig(cfg.Config); err != nil {
 		return nil, err
@@ -5,7 +5,9 @@ package openstack
 
 import (
 	"math"
+	"net/http"
 	"net/url"
+	"path"
 	"sync"
 	"time"
 
@@ -84,6 +86,8 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 	return &openstackStorageAdapter{
 		cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)},
 		novaClient{env.novaUnlocked},
+		env.volumeURL,
+		client.Token,
 	}, nil
 }
 
@@ -482,6 +486,7 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	DeleteVolumeMetadata(volumeId string, keys []string) error
 }
 
 type endpointResolver interface {
@@ -512,6 +517,11 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+
+	// volumeURL and token are used to send the requests
+	// that the cinder client does not support.
+	volumeURL *url.URL
+	token     func() string
 }
 
 type cinderClient struct {
@@ -553,3 +563,27 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error) {
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
+
+// DeleteVolumeMetadata is part of the OpenstackStorage interface. The
+// cinder client cannot delete volume metadata, so the requests are
+// sent directly to the volume endpoint, as the client sends its own.
+func (ga *openstackStorageAdapter) DeleteVolumeMetadata(volumeId string, keys []string) error {
+	for _, key := range keys {
+		itemURL := *ga.volumeURL
+		itemURL.Path = path.Join(itemURL.Path, "volumes", volumeId, "metadata", key)
+		req, err := http.NewRequest("DELETE", itemURL.String(), nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		req.Header.Set("X-Auth-Token", ga.token())
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return errors.Annotatef(err, "deleting metadata %q of volume %q", key, volumeId)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("deleting metadata %q of volume %q: %s", key, volumeId, resp.Status)
+		}
+	}
+	return nil
+}
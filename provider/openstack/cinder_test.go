@@ -474,6 +474,7 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	deleteVolumeMetadata  func(string, []string) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -543,6 +544,14 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) DeleteVolumeMetadata(volumeId string, keys []string) error {
+	ma.MethodCall(ma, "DeleteVolumeMetadata", volumeId, keys)
+	if ma.deleteVolumeMetadata != nil {
+		return ma.deleteVolumeMetadata(volumeId, keys)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/template"
 
+	"gopkg.in/goose.v2/client"
 	"gopkg.in/goose.v2/errors"
 	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/neutron"
@@ -74,8 +75,51 @@ var (
 	NovaListAvailabilityZones   = &novaListAvailabilityZones
 	AvailabilityZoneAllocations = &availabilityZoneAllocations
 	NewOpenstackStorage         = &newOpenstackStorage
+	DeleteServerMetadataItem    = &deleteServerMetadataItem
+	RunServer                   = &runServer
 )
 
+// FakeServerGroups holds the server groups seen by the server group
+// requests, which the test service does not support, once patched by
+// PatchServerGroups.
+type FakeServerGroups struct {
+	// Names holds the names of the server groups, keyed by ID.
+	Names map[string]string
+
+	// Created and Deleted hold the IDs of the server groups
+	// created and deleted.
+	Created []string
+	Deleted []string
+}
+
+// PatchServerGroups patches the server group requests to use the given
+// fake server groups.
+func PatchServerGroups(patcher interface {
+	PatchValue(dest, value interface{})
+}, groups *FakeServerGroups) {
+	patcher.PatchValue(&listServerGroups, func(client.Client) ([]serverGroup, error) {
+		var result []serverGroup
+		for id, name := range groups.Names {
+			result = append(result, serverGroup{Id: id, Name: name})
+		}
+		return result, nil
+	})
+	patcher.PatchValue(&createServerGroup, func(_ client.Client, name, policy string) (*serverGroup, error) {
+		if policy != antiAffinityPolicy {
+			return nil, fmt.Errorf("unexpected policy %q", policy)
+		}
+		id := fmt.Sprintf("group-%d", len(groups.Names))
+		groups.Names[id] = name
+		groups.Created = append(groups.Created, id)
+		return &serverGroup{Id: id, Name: name, Policies: []string{policy}}, nil
+	})
+	patcher.PatchValue(&deleteServerGroup, func(_ client.Client, id string) error {
+		delete(groups.Names, id)
+		groups.Deleted = append(groups.Deleted, id)
+		return nil
+	})
+}
+
 func NewCinderVolumeSource(s OpenstackStorage) storage.VolumeSource {
 	return NewCinderVolumeSourceForModel(s, testing.ModelTag.Id())
 }
@@ -458,12 +502,12 @@ func SetUseFloatingIP(e environs.Environ, val bool) {
 	env.ecfg().attrs["use-floating-ip"] = val
 }
 
-func SetUpGlobalGroup(e environs.Environ, name string, apiPort int) (neutron.SecurityGroupV2, error) {
+func SetUpGlobalGroup(e environs.Environ, name string, apiPort int, apiAllow []string) (neutron.SecurityGroupV2, error) {
 	switching := e.(*Environ).firewaller.(*switchingFirewaller)
 	if err := switching.initFirewaller(); err != nil {
 		return neutron.SecurityGroupV2{}, err
 	}
-	return switching.fw.(*neutronFirewaller).setUpGlobalGroup(name, apiPort)
+	return switching.fw.(*neutronFirewaller).setUpGlobalGroup(name, apiPort, apiAllow)
 }
 
 func EnsureGroup(e environs.Environ, name string, rules []neutron.RuleInfoV2) (neutron.SecurityGroupV2, error) {
@@ -474,6 +518,11 @@ func EnsureGroup(e environs.Environ, name string, rules []neutron.RuleInfoV2) (n
 	return switching.fw.(*neutronFirewaller).ensureGroup(name, rules)
 }
 
+func JujuGroupRegexp(e environs.Environ) string {
+	switching := e.(*Environ).firewaller.(*switchingFirewaller)
+	return switching.fw.(*neutronFirewaller).jujuGroupRegexp()
+}
+
 func MachineGroupRegexp(e environs.Environ, machineId string) string {
 	switching := e.(*Environ).firewaller.(*switchingFirewaller)
 	return switching.fw.(*neutronFirewaller).machineGroupRegexp(machineId)
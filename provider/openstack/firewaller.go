@@ -5,6 +5,7 @@ package openstack
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 	"sync"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	gooseerrors "gopkg.in/goose.v2/errors"
 	"gopkg.in/goose.v2/neutron"
 
 	"github.com/juju/juju/environs"
@@ -70,8 +72,30 @@ type Firewaller interface {
 	GetSecurityGroups(ids ...instance.Id) ([]string, error)
 
 	// SetUpGroups sets up initial security groups, if any, and returns
-	// their names.
-	SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error)
+	// their names. SSH access is allowed from the model's ssh-allow
+	// CIDRs, and access to the API port from the apiAllow CIDRs. In
+	// instance firewall mode, outgoing traffic to the API port is only
+	// allowed to the controller addresses in apiAddrs.
+	SetUpGroups(controllerUUID, machineId string, apiPort int, apiAllow, apiAddrs []string) ([]string, error)
+
+	// SetUpAdoptedGroups sets up the security groups for an existing
+	// instance being adopted into the model as the given machine, if
+	// any, and returns their names. Groups that exist already are
+	// left as they are; a missing model group does not allow access
+	// to the API port, which only controllers serve.
+	SetUpAdoptedGroups(controllerUUID, machineId string) ([]string, error)
+
+	// OpenModelPorts opens the given port ranges in the security
+	// group shared by all machines in the model.
+	OpenModelPorts(rules []network.IngressRule) error
+
+	// CloseModelPorts closes the given port ranges in the security
+	// group shared by all machines in the model.
+	CloseModelPorts(rules []network.IngressRule) error
+
+	// ModelIngressRules returns the ingress rules applied to the
+	// security group shared by all machines in the model.
+	ModelIngressRules() ([]network.IngressRule, error)
 
 	// OpenInstancePorts opens the given port ranges for the specified  instance.
 	OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error
@@ -81,6 +105,19 @@ type Firewaller interface {
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
 	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+
+	// OpenInstanceEgress allows outgoing traffic matching the given
+	// rules from the specified instance. Once any egress rule is
+	// applied, outgoing traffic not matching a rule is denied.
+	OpenInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// CloseInstanceEgress removes the given egress rules from the
+	// specified instance.
+	CloseInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// InstanceEgressRules returns the egress rules applied to the
+	// specified instance.
+	InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error)
 }
 
 type firewallerFactory struct {
@@ -177,11 +214,39 @@ func (f *switchingFirewaller) GetSecurityGroups(ids ...instance.Id) ([]string, e
 	return f.fw.GetSecurityGroups(ids...)
 }
 
-func (f *switchingFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
+func (f *switchingFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int, apiAllow, apiAddrs []string) ([]string, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.SetUpGroups(controllerUUID, machineId, apiPort, apiAllow, apiAddrs)
+}
+
+func (f *switchingFirewaller) SetUpAdoptedGroups(controllerUUID, machineId string) ([]string, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.SetUpAdoptedGroups(controllerUUID, machineId)
+}
+
+func (f *switchingFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenModelPorts(rules)
+}
+
+func (f *switchingFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseModelPorts(rules)
+}
+
+func (f *switchingFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
 	if err := f.initFirewaller(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return f.fw.SetUpGroups(controllerUUID, machineId, apiPort)
+	return f.fw.ModelIngressRules()
 }
 
 func (f *switchingFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
@@ -205,6 +270,27 @@ func (f *switchingFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return f.fw.InstanceIngressRules(inst, machineId)
 }
 
+func (f *switchingFirewaller) OpenInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenInstanceEgress(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) CloseInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseInstanceEgress(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.InstanceEgressRules(inst, machineId)
+}
+
 type firewallerBase struct {
 	environ *Environ
 }
@@ -421,6 +507,11 @@ func (c *firewallerBase) globalGroupRegexp() string {
 	return fmt.Sprintf("%s-global", c.jujuGroupRegexp())
 }
 
+func (c *firewallerBase) modelGroupRegexp() string {
+	// we are only looking to match the group shared by the whole model
+	return fmt.Sprintf("%s$", c.jujuGroupRegexp())
+}
+
 func (c *firewallerBase) machineGroupRegexp(machineId string) string {
 	// we are only looking to match 1 machine
 	return fmt.Sprintf("%s-%s$", c.jujuGroupRegexp(), machineId)
@@ -441,14 +532,20 @@ type neutronFirewaller struct {
 // Note: ideally we'd have a better way to determine group membership so that 2
 // people that happen to share an openstack account and name their environment
 // "openstack" don't end up destroying each other's machines.
-func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
-	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort)
+func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int, apiAllow, apiAddrs []string) ([]string, error) {
+	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort, apiAllow)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	var machineGroup neutron.SecurityGroupV2
 	switch c.environ.Config().FirewallMode() {
 	case config.FwInstance:
+		// Egress rules are applied to the machine security
+		// group, so the model group must not allow all
+		// outgoing traffic.
+		if err := c.restrictModelEgress(jujuGroup, apiPort, apiAddrs); err != nil {
+			logger.Warningf("cannot restrict outgoing traffic from security group %q: %v", jujuGroup.Name, err)
+		}
 		machineGroup, err = c.ensureGroup(c.machineGroupName(controllerUUID, machineId), nil)
 	case config.FwGlobal:
 		machineGroup, err = c.ensureGroup(c.globalGroupName(controllerUUID), nil)
@@ -463,39 +560,70 @@ func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, apiPor
 	return groups, nil
 }
 
-func (c *neutronFirewaller) setUpGlobalGroup(groupName string, apiPort int) (neutron.SecurityGroupV2, error) {
+// SetUpAdoptedGroups implements Firewaller interface.
+func (c *neutronFirewaller) SetUpAdoptedGroups(controllerUUID, machineId string) ([]string, error) {
+	jujuGroupName := c.jujuGroupName(controllerUUID)
+	groupNames := []string{jujuGroupName}
+	switch c.environ.Config().FirewallMode() {
+	case config.FwInstance:
+		groupNames = append(groupNames, c.machineGroupName(controllerUUID, machineId))
+	case config.FwGlobal:
+		groupNames = append(groupNames, c.globalGroupName(controllerUUID))
+	}
+	// ensureGroup replaces the rules of existing groups, so only
+	// the missing groups are set up.
+	for _, name := range groupNames {
+		exists, err := c.groupExists(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exists {
+			continue
+		}
+		if name == jujuGroupName {
+			_, err = c.setUpGlobalGroup(name, 0, nil)
+		} else {
+			_, err = c.ensureGroup(name, nil)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if c.environ.ecfg().useDefaultSecurityGroup() {
+		groupNames = append(groupNames, "default")
+	}
+	return groupNames, nil
+}
+
+// groupExists reports whether there is a security group with the
+// given name.
+func (c *neutronFirewaller) groupExists(name string) (bool, error) {
+	_, err := c.environ.neutron().SecurityGroupByNameV2(name)
+	if err == nil {
+		return true, nil
+	}
+	// TODO(hml): We should use a typed error here.  SecurityGroupByNameV2
+	// doesn't currently return one for this case.
+	if strings.Contains(err.Error(), "failed to find security group") {
+		return false, nil
+	}
+	return false, err
+}
+
+// setUpGlobalGroup ensures the model's group allows SSH access and all
+// traffic between the model's machines, and access to the API port
+// from the apiAllow CIDRs unless apiPort is zero.
+func (c *neutronFirewaller) setUpGlobalGroup(groupName string, apiPort int, apiAllow []string) (neutron.SecurityGroupV2, error) {
+	if len(apiAllow) == 0 {
+		apiAllow = []string{"::/0", "0.0.0.0/0"}
+	}
+	var rules []neutron.RuleInfoV2
+	rules = append(rules, sourceRuleInfo(22, c.environ.Config().SSHAllow())...)
+	if apiPort != 0 {
+		rules = append(rules, sourceRuleInfo(apiPort, apiAllow)...)
+	}
 	return c.ensureGroup(groupName,
-		[]neutron.RuleInfoV2{
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   22,
-				PortRangeMin:   22,
-				RemoteIPPrefix: "::/0",
-				EthernetType:   "IPv6",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   22,
-				PortRangeMin:   22,
-				RemoteIPPrefix: "0.0.0.0/0",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   apiPort,
-				PortRangeMin:   apiPort,
-				RemoteIPPrefix: "::/0",
-				EthernetType:   "IPv6",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   apiPort,
-				PortRangeMin:   apiPort,
-				RemoteIPPrefix: "0.0.0.0/0",
-			},
+		append(rules, []neutron.RuleInfoV2{
 			{
 				Direction:    "ingress",
 				IPProtocol:   "tcp",
@@ -531,7 +659,26 @@ func (c *neutronFirewaller) setUpGlobalGroup(groupName string, apiPort int) (neu
 				Direction:  "ingress",
 				IPProtocol: "icmp",
 			},
-		})
+		}...))
+}
+
+// sourceRuleInfo returns the rules that allow access to the given
+// TCP port from each of the given CIDRs.
+func sourceRuleInfo(port int, sourceCIDRs []string) []neutron.RuleInfoV2 {
+	rules := make([]neutron.RuleInfoV2, len(sourceCIDRs))
+	for i, cidr := range sourceCIDRs {
+		rules[i] = neutron.RuleInfoV2{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMax:   port,
+			PortRangeMin:   port,
+			RemoteIPPrefix: cidr,
+		}
+		if network.IsIPv6CIDR(cidr) {
+			rules[i].EthernetType = "IPv6"
+		}
+	}
+	return rules
 }
 
 // zeroGroup holds the zero security group.
@@ -755,6 +902,66 @@ func (c *neutronFirewaller) IngressRules() ([]network.IngressRule, error) {
 	return c.ingressRules(c.ingressRulesInGroup)
 }
 
+// OpenModelPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	return errors.Trace(c.openPortsInGroup(c.modelGroupRegexp(), rules))
+}
+
+// CloseModelPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	// closePortsInGroup removes a single security group rule for each
+	// ingress rule, so close each source CIDR separately.
+	var single []network.IngressRule
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			single = append(single, network.IngressRule{
+				PortRange:   rule.PortRange,
+				SourceCIDRs: []string{cidr},
+			})
+		}
+	}
+	return errors.Trace(c.closePortsInGroup(c.modelGroupRegexp(), single))
+}
+
+// ModelIngressRules implements Firewaller interface. Rules that allow
+// access between the members of the group are not included.
+func (c *neutronFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	group, err := c.matchingGroup(c.modelGroupRegexp())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	portSourceCIDRs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		if p.Direction == "egress" || p.RemoteIPPrefix == "" || p.IPProtocol == nil {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+		}
+		if p.PortRangeMin != nil {
+			portRange.FromPort = *p.PortRangeMin
+		}
+		if p.PortRangeMax != nil {
+			portRange.ToPort = *p.PortRangeMax
+		}
+		portSourceCIDRs[portRange] = append(portSourceCIDRs[portRange], p.RemoteIPPrefix)
+	}
+	var rules []network.IngressRule
+	for portRange, sourceCIDRs := range portSourceCIDRs {
+		rule, err := network.NewIngressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			sourceCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 // OpenInstancePorts implements Firewaller interface.
 func (c *neutronFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
@@ -803,6 +1010,275 @@ func (c *neutronFirewaller) InstanceIngressRules(inst instance.Instance, machine
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenInstanceEgress implements Firewaller interface.
+func (c *neutronFirewaller) OpenInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for opening egress on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See OpenInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	group, err := c.matchingGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, rule := range egressRulesToRuleInfo(group.Id, rules) {
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil && !gooseerrors.IsDuplicateValue(err) {
+			return errors.Annotatef(err, "cannot open egress %v", rules)
+		}
+	}
+	// Outgoing traffic is only restricted once the rules that
+	// Neutron creates to allow all of it have gone, which must
+	// not happen unless the rules replacing them all exist.
+	for _, p := range group.Rules {
+		if !isDefaultEgressRule(p) {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	logger.Infof("opened egress in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// CloseInstanceEgress implements Firewaller interface.
+func (c *neutronFirewaller) CloseInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for closing egress on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See OpenInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	deleted := make(map[string]bool)
+	for _, rule := range rules {
+		destinationCIDRs := rule.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range destinationCIDRs {
+			for _, p := range group.Rules {
+				if deleted[p.Id] || !secGroupMatchesEgressRule(p, rule.PortRange, cidr) {
+					continue
+				}
+				if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+					return errors.Trace(err)
+				}
+				deleted[p.Id] = true
+				break
+			}
+		}
+	}
+	remaining := 0
+	for _, p := range group.Rules {
+		if p.Direction == "egress" && !isDefaultEgressRule(p) && !deleted[p.Id] {
+			remaining++
+		}
+	}
+	if remaining == 0 {
+		// The last egress rule has gone, so allow all outgoing
+		// traffic again as Neutron does for a new group.
+		for _, ethernetType := range []string{"IPv4", "IPv6"} {
+			_, err := neutronClient.CreateSecurityGroupRuleV2(neutron.RuleInfoV2{
+				Direction:     "egress",
+				EthernetType:  ethernetType,
+				ParentGroupId: group.Id,
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	logger.Infof("closed egress in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// InstanceEgressRules implements Firewaller interface. The rules that
+// allow all outgoing traffic when no egress rules apply are not included.
+func (c *neutronFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See OpenInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return []network.EgressRule{}, nil
+	}
+	group, err := c.matchingGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	portDestinationCIDRs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		if p.Direction != "egress" || isDefaultEgressRule(p) || p.IPProtocol == nil {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+		}
+		if p.PortRangeMin != nil {
+			portRange.FromPort = *p.PortRangeMin
+		}
+		if p.PortRangeMax != nil {
+			portRange.ToPort = *p.PortRangeMax
+		}
+		remotePrefix := p.RemoteIPPrefix
+		if remotePrefix == "" {
+			remotePrefix = "0.0.0.0/0"
+		}
+		portDestinationCIDRs[portRange] = append(portDestinationCIDRs[portRange], remotePrefix)
+	}
+	var rules []network.EgressRule
+	for portRange, destinationCIDRs := range portDestinationCIDRs {
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			destinationCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// restrictModelEgress replaces the rules that allow all outgoing
+// traffic from the security group shared by all machines in the
+// model with rules that allow traffic between the machines in the
+// model and to the API port of the controller addresses given. The
+// controller machines are all in the controller model, so need no
+// addresses. Outgoing traffic from each machine is then governed by
+// its own security group, which allows all outgoing traffic until
+// egress rules are applied to it.
+func (c *neutronFirewaller) restrictModelEgress(group neutron.SecurityGroupV2, apiPort int, apiAddrs []string) error {
+	want := []neutron.RuleInfoV2{
+		{Direction: "egress", IPProtocol: "tcp", PortRangeMin: 1, PortRangeMax: 65535, EthernetType: "IPv6"},
+		{Direction: "egress", IPProtocol: "tcp", PortRangeMin: 1, PortRangeMax: 65535, EthernetType: "IPv4"},
+		{Direction: "egress", IPProtocol: "udp", PortRangeMin: 1, PortRangeMax: 65535, EthernetType: "IPv6"},
+		{Direction: "egress", IPProtocol: "udp", PortRangeMin: 1, PortRangeMax: 65535, EthernetType: "IPv4"},
+		{Direction: "egress", IPProtocol: "icmp", EthernetType: "IPv6"},
+		{Direction: "egress", IPProtocol: "icmp", EthernetType: "IPv4"},
+	}
+	want = append(want, controllerEgressRuleInfo(apiPort, apiAddrs)...)
+	neutronClient := c.environ.neutron()
+	have := newRuleInfoSetFromRules(group.Rules)
+	for _, rule := range want {
+		if _, ok := have[rule]; ok {
+			continue
+		}
+		rule.ParentGroupId = group.Id
+		if rule.RemoteIPPrefix == "" {
+			rule.RemoteGroupId = group.Id
+		}
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil && !gooseerrors.IsDuplicateValue(err) {
+			return errors.Trace(err)
+		}
+	}
+	// Controllers that have gone away are no longer allowed.
+	wantSet := newRuleInfoSetFromRuleInfo(want)
+	for rule, id := range have {
+		isAPIRule := rule.Direction == "egress" &&
+			rule.IPProtocol == "tcp" &&
+			rule.PortRangeMin == apiPort &&
+			rule.PortRangeMax == apiPort &&
+			rule.RemoteIPPrefix != ""
+		if _, ok := wantSet[rule]; ok || !isAPIRule {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, p := range group.Rules {
+		if !isDefaultEgressRule(p) {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// controllerEgressRuleInfo returns the rules allowing outgoing traffic
+// to the API port of the given controller addresses, which are of the
+// form host:port. Addresses whose host is not an IP address are
+// ignored, as security group rules cannot refer to them.
+func controllerEgressRuleInfo(apiPort int, apiAddrs []string) []neutron.RuleInfoV2 {
+	var rules []neutron.RuleInfoV2
+	for _, addr := range apiAddrs {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			logger.Debugf("not allowing egress to controller address %q: not an IP address", addr)
+			continue
+		}
+		rule := neutron.RuleInfoV2{
+			Direction:    "egress",
+			IPProtocol:   "tcp",
+			PortRangeMin: apiPort,
+			PortRangeMax: apiPort,
+		}
+		if ip.To4() != nil {
+			rule.RemoteIPPrefix = ip.String() + "/32"
+			rule.EthernetType = "IPv4"
+		} else {
+			rule.RemoteIPPrefix = ip.String() + "/128"
+			rule.EthernetType = "IPv6"
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// secGroupMatchesEgressRule checks if supplied neutron security group
+// rule allows outgoing traffic on the port range to the destination.
+func secGroupMatchesEgressRule(secGroupRule neutron.SecurityGroupRuleV2, portRange network.PortRange, cidr string) bool {
+	if secGroupRule.Direction != "egress" || secGroupRule.IPProtocol == nil {
+		return false
+	}
+	var from, to int
+	if secGroupRule.PortRangeMin != nil {
+		from = *secGroupRule.PortRangeMin
+	}
+	if secGroupRule.PortRangeMax != nil {
+		to = *secGroupRule.PortRangeMax
+	}
+	remotePrefix := secGroupRule.RemoteIPPrefix
+	if remotePrefix == "" {
+		remotePrefix = "0.0.0.0/0"
+	}
+	return *secGroupRule.IPProtocol == portRange.Protocol &&
+		from == portRange.FromPort &&
+		to == portRange.ToPort &&
+		remotePrefix == cidr
+}
+
+// isDefaultEgressRule reports whether the given rule is one of
+// those that Neutron creates with any new security group to allow
+// all outgoing traffic.
+func isDefaultEgressRule(rule neutron.SecurityGroupRuleV2) bool {
+	return rule.Direction == "egress" && rule.IPProtocol == nil && rule.RemoteIPPrefix == ""
+}
+
 // Matching a security group by name only works if each name is unqiue.  Neutron
 // security groups are not required to have unique names.  Juju constructs unique
 // names, but there are frequently multiple matches to 'default'
@@ -861,10 +1337,12 @@ func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule n
 	}
 	// The ports match, so if the security group RemoteIPPrefix matches *any* of the
 	// rule's source ranges, then that's a match.
-	if len(rule.SourceCIDRs) == 0 {
-		return secGroupRule.RemoteIPPrefix == "" || secGroupRule.RemoteIPPrefix == "0.0.0.0/0"
+	// The IPv6 rule opened alongside an IPv4 one for anywhere is
+	// matched by the same source ranges.
+	if secGroupRule.RemoteIPPrefix == "" {
+		return len(rule.SourceCIDRs) == 0
 	}
-	for _, r := range rule.SourceCIDRs {
+	for _, r := range withIPv6Anywhere(rule.SourceCIDRs) {
 		if r == secGroupRule.RemoteIPPrefix {
 			return true
 		}
@@ -882,16 +1360,17 @@ func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, rules []network
 	}
 	neutronClient := c.environ.neutron()
 	// TODO: Hey look ma, it's quadratic
+	deleted := make(map[string]bool)
 	for _, rule := range rules {
 		for _, p := range group.Rules {
-			if !secGroupMatchesIngressRule(p, rule) {
+			if deleted[p.Id] || !secGroupMatchesIngressRule(p, rule) {
 				continue
 			}
 			err := neutronClient.DeleteSecurityGroupRuleV2(p.Id)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			break
+			deleted[p.Id] = true
 		}
 	}
 	return nil
@@ -930,13 +1409,14 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 		}
 		*sourceCIDRs = append(*sourceCIDRs, remotePrefix)
 	}
-	// Combine all the port ranges and remote prefixes.
+	// Combine all the port ranges and remote prefixes, reporting
+	// the IPv4 and IPv6 rules opened for anywhere as one.
 	for portRange, sourceCIDRs := range portSourceCIDRs {
 		rule, err := network.NewIngressRule(
 			portRange.Protocol,
 			portRange.FromPort,
 			portRange.ToPort,
-			*sourceCIDRs...)
+			withoutIPv6Anywhere(*sourceCIDRs)...)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -946,6 +1426,27 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	return rules, nil
 }
 
+// withoutIPv6Anywhere returns the given CIDRs without "::/0" if they
+// also include "0.0.0.0/0", undoing withIPv6Anywhere.
+func withoutIPv6Anywhere(cidrs []string) []string {
+	var anywhere4 bool
+	for _, cidr := range cidrs {
+		if cidr == "0.0.0.0/0" {
+			anywhere4 = true
+		}
+	}
+	if !anywhere4 {
+		return cidrs
+	}
+	result := make([]string, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if cidr != "::/0" {
+			result = append(result, cidr)
+		}
+	}
+	return result
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
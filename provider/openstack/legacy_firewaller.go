@@ -29,8 +29,8 @@ type legacyNovaFirewaller struct {
 // other instances that might be running on the same OpenStack account.
 // In addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (c *legacyNovaFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
-	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort)
+func (c *legacyNovaFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int, apiAllow, apiAddrs []string) ([]string, error) {
+	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort, apiAllow)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -51,21 +51,26 @@ func (c *legacyNovaFirewaller) SetUpGroups(controllerUUID, machineId string, api
 	return groupNames, nil
 }
 
-func (c *legacyNovaFirewaller) setUpGlobalGroup(groupName string, apiPort int) (nova.SecurityGroup, error) {
+// SetUpAdoptedGroups implements Firewaller interface. The groups are
+// set up as for a new machine, as ensureGroup leaves existing groups
+// as they are.
+func (c *legacyNovaFirewaller) SetUpAdoptedGroups(controllerUUID, machineId string) ([]string, error) {
+	return c.SetUpGroups(controllerUUID, machineId, 0, nil, nil)
+}
+
+// setUpGlobalGroup ensures the model's group. The API port rule is
+// left out if apiPort is zero.
+func (c *legacyNovaFirewaller) setUpGlobalGroup(groupName string, apiPort int, apiAllow []string) (nova.SecurityGroup, error) {
+	if len(apiAllow) == 0 {
+		apiAllow = []string{"0.0.0.0/0"}
+	}
+	var rules []nova.RuleInfo
+	rules = append(rules, legacySourceRuleInfo(22, c.environ.Config().SSHAllow())...)
+	if apiPort != 0 {
+		rules = append(rules, legacySourceRuleInfo(apiPort, apiAllow)...)
+	}
 	return c.ensureGroup(groupName,
-		[]nova.RuleInfo{
-			{
-				IPProtocol: "tcp",
-				ToPort:     22,
-				FromPort:   22,
-				Cidr:       "0.0.0.0/0",
-			},
-			{
-				IPProtocol: "tcp",
-				ToPort:     apiPort,
-				FromPort:   apiPort,
-				Cidr:       "0.0.0.0/0",
-			},
+		append(rules, []nova.RuleInfo{
 			{
 				IPProtocol: "tcp",
 				FromPort:   1,
@@ -81,7 +86,26 @@ func (c *legacyNovaFirewaller) setUpGlobalGroup(groupName string, apiPort int) (
 				FromPort:   -1,
 				ToPort:     -1,
 			},
+		}...))
+}
+
+// legacySourceRuleInfo returns the rules that allow access to the
+// given TCP port from each of the given CIDRs. Nova security groups
+// only support IPv4 CIDRs, so any others are skipped.
+func legacySourceRuleInfo(port int, sourceCIDRs []string) []nova.RuleInfo {
+	var rules []nova.RuleInfo
+	for _, cidr := range sourceCIDRs {
+		if network.IsIPv6CIDR(cidr) {
+			continue
+		}
+		rules = append(rules, nova.RuleInfo{
+			IPProtocol: "tcp",
+			ToPort:     port,
+			FromPort:   port,
+			Cidr:       cidr,
 		})
+	}
+	return rules
 }
 
 // legacyZeroGroup holds the zero security group.
@@ -216,6 +240,22 @@ func (c *legacyNovaFirewaller) IngressRules() ([]network.IngressRule, error) {
 	return c.ingressRules(c.ingressRulesInGroup)
 }
 
+// OpenModelPorts implements Firewaller interface. The rules of
+// nova security groups are only set when the group is created.
+func (c *legacyNovaFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("changing model firewall rules with nova security groups")
+}
+
+// CloseModelPorts implements Firewaller interface.
+func (c *legacyNovaFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("changing model firewall rules with nova security groups")
+}
+
+// ModelIngressRules implements Firewaller interface.
+func (c *legacyNovaFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("changing model firewall rules with nova security groups")
+}
+
 // OpenInstancePorts implements Firewaller interface.
 func (c *legacyNovaFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	return c.openInstancePorts(c.openPortsInGroup, machineId, rules)
@@ -231,6 +271,21 @@ func (c *legacyNovaFirewaller) InstanceIngressRules(inst instance.Instance, mach
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenInstanceEgress implements Firewaller interface.
+func (c *legacyNovaFirewaller) OpenInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules with nova security groups")
+}
+
+// CloseInstanceEgress implements Firewaller interface.
+func (c *legacyNovaFirewaller) CloseInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules with nova security groups")
+}
+
+// InstanceEgressRules implements Firewaller interface.
+func (c *legacyNovaFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules with nova security groups")
+}
+
 func (c *legacyNovaFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
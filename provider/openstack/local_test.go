@@ -190,6 +190,15 @@ func makeMockAdapter() *mockAdapter {
 			}
 			return nil, errors.New("not found")
 		},
+		deleteVolumeMetadata: func(volumeId string, keys []string) error {
+			if volume, ok := volumes[volumeId]; ok {
+				for _, k := range keys {
+					delete(volume.Metadata, k)
+				}
+				return nil
+			}
+			return errors.New("not found")
+		},
 	}
 }
 
@@ -676,6 +685,130 @@ func (s *localServerSuite) TestStopInstance(c *gc.C) {
 	})
 }
 
+func (s *localServerSuite) TestModelPorts(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"ssh-allow": "10.0.0.0/8"})
+	_, err := env.(environs.ModelFirewaller).ModelIngressRules()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fw := env.(environs.ModelFirewaller)
+	sshSources := func() []string {
+		rules, err := fw.ModelIngressRules()
+		c.Assert(err, jc.ErrorIsNil)
+		for _, rule := range rules {
+			if rule.PortRange == (network.PortRange{Protocol: "tcp", FromPort: 22, ToPort: 22}) {
+				return rule.SourceCIDRs
+			}
+		}
+		return nil
+	}
+	c.Assert(sshSources(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = fw.OpenModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "192.168.0.0/16", "2001:db8::/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sshSources(), jc.SameContents, []string{"10.0.0.0/8", "192.168.0.0/16", "2001:db8::/32"})
+
+	err = fw.CloseModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8", "2001:db8::/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sshSources(), jc.DeepEquals, []string{"192.168.0.0/16"})
+}
+
+func (s *localServerSuite) TestInstanceEgress(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fwInst, ok := inst.(instance.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	rules, err := fwInst.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	opened := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53),
+	}
+	err = fwInst.OpenEgress("100", opened)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fwInst.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+	machineGroup, err := openstack.MatchingGroup(env, openstack.MachineGroupRegexp(env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, rule := range ruleToRuleInfo(machineGroup.Rules) {
+		c.Check(rule.Direction == "egress" && rule.IPProtocol == "", jc.IsFalse)
+	}
+
+	err = fwInst.CloseEgress("100", opened)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fwInst.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+	machineGroup, err = openstack.MatchingGroup(env, openstack.MachineGroupRegexp(env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ruleToRuleInfo(machineGroup.Rules), jc.SameContents, []neutron.RuleInfoV2{
+		{Direction: "egress", EthernetType: "IPv4"},
+		{Direction: "egress", EthernetType: "IPv6"},
+	})
+}
+
+func (s *localServerSuite) TestInstanceEgressError(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fwInst := inst.(instance.EgressFirewaller)
+
+	cleanup := s.srv.Neutron.RegisterControlPoint(
+		"addSecurityGroupRule",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("failed on purpose")
+		},
+	)
+	defer cleanup()
+	err := fwInst.OpenEgress("100", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot open egress .*failed on purpose.*")
+
+	// The rules allowing all outgoing traffic are left alone.
+	machineGroup, err := openstack.MatchingGroup(env, openstack.MachineGroupRegexp(env, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ruleToRuleInfo(machineGroup.Rules), jc.SameContents, []neutron.RuleInfoV2{
+		{Direction: "egress", EthernetType: "IPv4"},
+		{Direction: "egress", EthernetType: "IPv6"},
+	})
+}
+
+func (s *localServerSuite) TestModelEgressToControllerAddresses(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+
+	modelGroup, err := openstack.MatchingGroup(env, openstack.JujuGroupRegexp(env))
+	c.Assert(err, jc.ErrorIsNil)
+	var apiRules []neutron.RuleInfoV2
+	for _, rule := range ruleToRuleInfo(modelGroup.Rules) {
+		c.Check(rule.Direction == "egress" && rule.IPProtocol == "", jc.IsFalse)
+		if rule.Direction == "egress" && rule.RemoteIPPrefix != "" {
+			apiRules = append(apiRules, rule)
+		}
+	}
+	// Outgoing traffic to the API port is only allowed to the
+	// controller address in the instance's API info.
+	c.Assert(apiRules, jc.DeepEquals, []neutron.RuleInfoV2{{
+		Direction:      "egress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   17777,
+		PortRangeMax:   17777,
+		RemoteIPPrefix: "0.1.2.3/32",
+		EthernetType:   "IPv4",
+	}})
+}
+
 // Due to bug #1300755 it can happen that the security group intended for
 // an instance is also used as the common security group of another
 // environment. If this is the case, the attempt to delete the instance's
@@ -1297,6 +1430,43 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZonesUnsupported(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceHost(c *gc.C) {
+	placement := "host=compute-1"
+	err := t.env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	placement = "host="
+	err = t.env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, gc.ErrorMatches, `compute host not specified`)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceServerGroup(c *gc.C) {
+	openstack.PatchServerGroups(t, &openstack.FakeServerGroups{
+		Names: map[string]string{
+			"group-0": "licensed",
+			"group-1": "shared",
+			"group-2": "shared",
+		},
+	})
+	for _, placement := range []string{"server-group=licensed", "server-group=group-1"} {
+		err := t.env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	placement := "server-group=unknown"
+	err := t.env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, gc.ErrorMatches, `server group "unknown" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	placement = "server-group=shared"
+	err = t.env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, gc.ErrorMatches, `server group name "shared" is ambiguous, use its ID`)
+
+	placement = "server-group="
+	err = t.env.PrecheckInstance(series.LatestLts(), constraints.Value{}, placement)
+	c.Assert(err, gc.ErrorMatches, `server group not specified`)
+}
+
 func (s *localServerSuite) TestValidateImageMetadata(c *gc.C) {
 	env := s.Open(c, s.env.Config())
 	params, err := env.(simplestreams.MetadataValidator).MetadataLookupParams("some-region")
@@ -1789,6 +1959,72 @@ func (t *localServerSuite) testStartInstanceAvailZone(c *gc.C, zone string) (ins
 	return result.Instance, nil
 }
 
+func (t *localServerSuite) TestStartInstanceServerGroup(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+	groups := &openstack.FakeServerGroups{
+		Names: map[string]string{"group-0": "licensed"},
+	}
+	openstack.PatchServerGroups(t, groups)
+	hints := t.patchRunServer()
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Placement:      "server-group=licensed",
+		// A server is in one server group at most, so
+		// the anti-affinity groups are not used.
+		AntiAffinityGroups: []string{"mysql"},
+	}
+	_, err = testing.StartInstanceWithParams(t.env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*hints, jc.DeepEquals, []map[string]interface{}{{"group": "group-0"}})
+	c.Assert(groups.Created, gc.HasLen, 0)
+}
+
+func (t *localServerSuite) TestStartInstanceAntiAffinityGroups(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+	groups := &openstack.FakeServerGroups{
+		Names: map[string]string{"group-0": "unrelated"},
+	}
+	openstack.PatchServerGroups(t, groups)
+	hints := t.patchRunServer()
+
+	// The server group is created for the first
+	// instance, and used again for the second.
+	for _, machineId := range []string{"1", "2"} {
+		params := environs.StartInstanceParams{
+			ControllerUUID:     t.ControllerUUID,
+			AntiAffinityGroups: []string{"mysql", "wordpress"},
+		}
+		_, err = testing.StartInstanceWithParams(t.env, machineId, params)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(groups.Created, jc.DeepEquals, []string{"group-1"})
+	c.Assert(strings.HasSuffix(groups.Names["group-1"], "-anti-affinity-mysql"), jc.IsTrue)
+	c.Assert(*hints, jc.DeepEquals, []map[string]interface{}{
+		{"group": "group-1"},
+		{"group": "group-1"},
+	})
+
+	// Destroying the model deletes its server groups only.
+	err = t.env.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups.Deleted, jc.DeepEquals, []string{"group-1"})
+}
+
+// patchRunServer records the scheduler hints servers are started
+// with, and starts them without, as the test service ignores them.
+func (t *localServerSuite) patchRunServer() *[]map[string]interface{} {
+	var hints []map[string]interface{}
+	runServer := *openstack.RunServer
+	t.PatchValue(openstack.RunServer, func(cl client.Client, opts nova.RunServerOpts, schedulerHints map[string]interface{}) (*nova.Entity, error) {
+		hints = append(hints, schedulerHints)
+		return runServer(cl, opts, nil)
+	})
+	return &hints
+}
+
 func (t *localServerSuite) TestGetAvailabilityZones(c *gc.C) {
 	var resultZones []nova.AvailabilityZone
 	var resultErr error
@@ -2146,6 +2382,124 @@ func (s *localServerSuite) TestAdoptResourcesNoStorage(c *gc.C) {
 	s.checkGroupController(c, env, newController)
 }
 
+func (s *localServerSuite) TestTagVolume(c *gc.C) {
+	err := bootstrapEnv(c, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	addVolume(c, s.env, coretesting.ControllerTag.Id(), "99/9")
+
+	env := s.env.(*openstack.Environ)
+	storage, err := (*openstack.NewOpenstackStorage)(env)
+	c.Assert(err, jc.ErrorIsNil)
+	source := openstack.NewCinderVolumeSourceForModel(storage, env.Config().UUID())
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, gc.HasLen, 1)
+
+	err = env.TagVolume(volumeIds[0], map[string]string{"team": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := storage.GetVolume(volumeIds[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(volume.Metadata["team"], gc.Equals, "db")
+	c.Check(volume.Metadata[tags.JujuController], gc.Equals, coretesting.ControllerTag.Id())
+}
+
+func (s *localServerSuite) TestTagVolumeRemovesTags(c *gc.C) {
+	err := bootstrapEnv(c, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	addVolume(c, s.env, coretesting.ControllerTag.Id(), "99/9")
+
+	env := s.env.(*openstack.Environ)
+	storage, err := (*openstack.NewOpenstackStorage)(env)
+	c.Assert(err, jc.ErrorIsNil)
+	source := openstack.NewCinderVolumeSourceForModel(storage, env.Config().UUID())
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, gc.HasLen, 1)
+	err = env.TagVolume(volumeIds[0], map[string]string{"team": "db", "owner": "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = env.TagVolume(volumeIds[0], map[string]string{"team": "", "owner": "alice", "absent": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := storage.GetVolume(volumeIds[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(volume.Metadata["owner"], gc.Equals, "alice")
+	_, ok := volume.Metadata["team"]
+	c.Check(ok, jc.IsFalse)
+	c.Check(volume.Metadata[tags.JujuController], gc.Equals, coretesting.ControllerTag.Id())
+}
+
+func (t *localServerSuite) TestTagInstanceRemovesTags(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+	instances, err := t.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+	id := instances[0].Id()
+	err = t.env.(environs.InstanceTagger).TagInstance(id, map[string]string{"team": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var deleted []string
+	t.PatchValue(openstack.DeleteServerMetadataItem, func(_ client.Client, serverId, key string) error {
+		c.Check(serverId, gc.Equals, string(id))
+		deleted = append(deleted, key)
+		return nil
+	})
+	err = t.env.(environs.InstanceTagger).TagInstance(id, map[string]string{
+		"team":   "",
+		"absent": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Only metadata the server has is deleted.
+	c.Check(deleted, jc.DeepEquals, []string{"team"})
+}
+
+func (s *localServerSuite) TestAdoptInstance(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	adopter := env.(environs.InstanceAdopter)
+
+	// Start a server outside the model's security groups.
+	novaClient := openstack.GetNovaClient(env)
+	entity, err := novaClient.RunServer(nova.RunServerOpts{
+		Name:     "not-juju",
+		FlavorId: "1", // test service has 1,2,3 for flavor ids
+		ImageId:  "1", // UseTestImageData sets up images 1 and 2
+		Networks: []nova.ServerNetworks{{NetworkId: "1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	id := instance.Id(entity.Id)
+
+	adoptable, err := adopter.AdoptableInstance(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(adoptable.Id(), gc.Equals, id)
+	err = adopter.AdoptInstance(id, "5", map[string]string{
+		tags.JujuModel:      coretesting.ModelTag.Id(),
+		tags.JujuController: s.ControllerUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	detail, err := novaClient.GetServer(entity.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(detail.Metadata, jc.DeepEquals, map[string]string{
+		tags.JujuModel:      coretesting.ModelTag.Id(),
+		tags.JujuController: s.ControllerUUID,
+	})
+	c.Assert(detail.Groups, gc.NotNil)
+	var groupNames []string
+	for _, g := range *detail.Groups {
+		groupNames = append(groupNames, g.Name)
+	}
+	c.Assert(groupNames, jc.SameContents, []string{
+		fmt.Sprintf("juju-%s-%s", s.ControllerUUID, env.Config().UUID()),
+		openstack.MachineGroupName(env, s.ControllerUUID, "5"),
+	})
+	insts, err := env.Instances([]instance.Id{id})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts[0].Id(), gc.Equals, id)
+
+	_, err = adopter.AdoptableInstance("unknown")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func addVolume(c *gc.C, env environs.Environ, controllerUUID, name string) {
 	storageAdapter, err := (*openstack.NewOpenstackStorage)(env.(*openstack.Environ))
 	c.Assert(err, jc.ErrorIsNil)
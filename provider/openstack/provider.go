@@ -7,7 +7,9 @@ package openstack
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,10 +20,12 @@ import (
 	"github.com/juju/retry"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/goose.v2/cinder"
 	"gopkg.in/goose.v2/client"
 	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
 	"gopkg.in/goose.v2/identity"
 	gooselogging "gopkg.in/goose.v2/logging"
 	"gopkg.in/goose.v2/neutron"
@@ -295,6 +299,10 @@ var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ state.Prechecker = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.VolumeTagger = (*Environ)(nil)
+var _ environs.InstanceAdopter = (*Environ)(nil)
+
+var _ environs.ModelFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -458,6 +466,23 @@ func (inst *openstackInstance) IngressRules(machineId string) ([]network.Ingress
 	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
 }
 
+var _ instance.EgressFirewaller = (*openstackInstance)(nil)
+
+// OpenEgress implements instance.EgressFirewaller.
+func (inst *openstackInstance) OpenEgress(machineId string, rules []network.EgressRule) error {
+	return inst.e.firewaller.OpenInstanceEgress(inst, machineId, rules)
+}
+
+// CloseEgress implements instance.EgressFirewaller.
+func (inst *openstackInstance) CloseEgress(machineId string, rules []network.EgressRule) error {
+	return inst.e.firewaller.CloseInstanceEgress(inst, machineId, rules)
+}
+
+// EgressRules implements instance.EgressFirewaller.
+func (inst *openstackInstance) EgressRules(machineId string) ([]network.EgressRule, error) {
+	return inst.e.firewaller.InstanceEgressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -565,6 +590,14 @@ func (e *Environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, er
 
 type openstackPlacement struct {
 	availabilityZone nova.AvailabilityZone
+
+	// host, if set, is the compute host on which the instance
+	// must be started. Forcing a host requires admin credentials.
+	host string
+
+	// serverGroup, if set, is the ID of the server group the
+	// instance is started in.
+	serverGroup string
 }
 
 func (e *Environ) parsePlacement(placement string) (*openstackPlacement, error) {
@@ -587,6 +620,20 @@ func (e *Environ) parsePlacement(placement string) (*openstackPlacement, error)
 			}
 		}
 		return nil, errors.Errorf("invalid availability zone %q", availabilityZone)
+	case "host":
+		if value == "" {
+			return nil, errors.New("compute host not specified")
+		}
+		return &openstackPlacement{host: value}, nil
+	case "server-group":
+		if value == "" {
+			return nil, errors.New("server group not specified")
+		}
+		id, err := e.findServerGroup(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &openstackPlacement{serverGroup: id}, nil
 	}
 	return nil, errors.Errorf("unknown placement directive: %v", placement)
 }
@@ -931,15 +978,25 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		return nil, errors.New("missing controller UUID")
 	}
 	var availabilityZones []string
+	var placementServerGroup string
 	if args.Placement != "" {
 		placement, err := e.parsePlacement(args.Placement)
 		if err != nil {
 			return nil, err
 		}
-		if !placement.availabilityZone.State.Available {
-			return nil, errors.Errorf("availability zone %q is unavailable", placement.availabilityZone.Name)
+		switch {
+		case placement.host != "":
+			// Nova forces the host given as "zone:host"; an
+			// empty zone leaves Nova to use its default zone.
+			availabilityZones = append(availabilityZones, ":"+placement.host)
+		case placement.serverGroup != "":
+			placementServerGroup = placement.serverGroup
+		default:
+			if !placement.availabilityZone.State.Available {
+				return nil, errors.Errorf("availability zone %q is unavailable", placement.availabilityZone.Name)
+			}
+			availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 		}
-		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
 	// If no availability zone is specified, then automatically spread across
@@ -1042,13 +1099,18 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	var novaGroupNames = []nova.SecurityGroupName{}
 	if createSecurityGroups {
 		var apiPort int
+		var apiAddrs []string
 		if args.InstanceConfig.Controller != nil {
 			apiPort = args.InstanceConfig.Controller.Config.APIPort()
 		} else {
 			// All ports are the same so pick the first.
 			apiPort = args.InstanceConfig.APIInfo.Ports()[0]
+			apiAddrs = args.InstanceConfig.APIInfo.Addrs
 		}
-		groupNames, err := e.firewaller.SetUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+		groupNames, err := e.firewaller.SetUpGroups(
+			args.ControllerUUID, args.InstanceConfig.MachineId,
+			apiPort, args.APIAllow, apiAddrs,
+		)
 		if err != nil {
 			return nil, errors.Annotate(err, "cannot set up groups")
 		}
@@ -1058,6 +1120,25 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		}
 	}
 
+	// Nova takes a single server group for each server, so the
+	// server group placed in wins over any anti-affinity groups.
+	var schedulerHints map[string]interface{}
+	if placementServerGroup != "" {
+		if len(args.AntiAffinityGroups) > 0 {
+			logger.Warningf("not keeping instance in anti-affinity groups %v apart: placed in server group %q", args.AntiAffinityGroups, placementServerGroup)
+		}
+		schedulerHints = map[string]interface{}{"group": placementServerGroup}
+	} else if groups := args.AntiAffinityGroups; len(groups) > 0 {
+		if len(groups) > 1 {
+			logger.Infof("ignoring all but the first anti-affinity group: %v", groups)
+		}
+		groupId, err := e.ensureAntiAffinityServerGroup(groups[0])
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot set up server group")
+		}
+		schedulerHints = map[string]interface{}{"group": groupId}
+	}
+
 	machineName := resourceName(
 		e.namespace,
 		e.name,
@@ -1104,7 +1185,11 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		instanceOpts nova.RunServerOpts,
 	) (server *nova.Entity, err error) {
 		for a := attempts.Start(); a.Next(); {
-			server, err = client.RunServer(instanceOpts)
+			if len(schedulerHints) > 0 {
+				server, err = runServer(e.client(), instanceOpts, schedulerHints)
+			} else {
+				server, err = client.RunServer(instanceOpts)
+			}
 			if err != nil {
 				break
 			}
@@ -1473,6 +1558,7 @@ func (e *Environ) Destroy() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	e.deleteAntiAffinityServerGroups()
 	// Delete all security groups remaining in the model.
 	return e.firewaller.DeleteAllModelGroups()
 }
@@ -1568,12 +1654,63 @@ func rulesToRuleInfo(groupId string, rules []network.IngressRule) []neutron.Rule
 			PortRangeMax:  r.ToPort,
 			IPProtocol:    r.Protocol,
 		}
-		sourceCIDRs := r.SourceCIDRs
-		if len(sourceCIDRs) == 0 {
-			sourceCIDRs = []string{"0.0.0.0/0"}
-		}
-		for _, sr := range sourceCIDRs {
+		for _, sr := range withIPv6Anywhere(r.SourceCIDRs) {
 			ruleInfo.RemoteIPPrefix = sr
+			ruleInfo.EthernetType = ""
+			if network.IsIPv6CIDR(sr) {
+				ruleInfo.EthernetType = "IPv6"
+			}
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
+// withIPv6Anywhere returns the given CIDRs, with "::/0" added if they
+// allow traffic from anywhere. The firewaller asks for "0.0.0.0/0", or
+// for no CIDRs at all, to mean anywhere; in neutron that only covers
+// IPv4, so instances in IPv6-only or dual-stack networks would not be
+// reachable over IPv6 without a matching IPv6 rule.
+func withIPv6Anywhere(cidrs []string) []string {
+	if len(cidrs) == 0 {
+		return []string{"0.0.0.0/0", "::/0"}
+	}
+	var anywhere4, anywhere6 bool
+	for _, cidr := range cidrs {
+		switch cidr {
+		case "0.0.0.0/0":
+			anywhere4 = true
+		case "::/0":
+			anywhere6 = true
+		}
+	}
+	if anywhere4 && !anywhere6 {
+		return append(cidrs[:len(cidrs):len(cidrs)], "::/0")
+	}
+	return cidrs
+}
+
+// egressRulesToRuleInfo maps egress rules to neutron rules
+func egressRulesToRuleInfo(groupId string, rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:     "egress",
+			ParentGroupId: groupId,
+			PortRangeMin:  r.FromPort,
+			PortRangeMax:  r.ToPort,
+			IPProtocol:    r.Protocol,
+		}
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, dr := range destinationCIDRs {
+			ruleInfo.RemoteIPPrefix = dr
+			ruleInfo.EthernetType = ""
+			if network.IsIPv6CIDR(dr) {
+				ruleInfo.EthernetType = "IPv6"
+			}
 			result = append(result, ruleInfo)
 		}
 	}
@@ -1592,6 +1729,21 @@ func (e *Environ) IngressRules() ([]network.IngressRule, error) {
 	return e.firewaller.IngressRules()
 }
 
+// OpenModelPorts is specified in the environs.ModelFirewaller interface.
+func (e *Environ) OpenModelPorts(rules []network.IngressRule) error {
+	return e.firewaller.OpenModelPorts(rules)
+}
+
+// CloseModelPorts is specified in the environs.ModelFirewaller interface.
+func (e *Environ) CloseModelPorts(rules []network.IngressRule) error {
+	return e.firewaller.CloseModelPorts(rules)
+}
+
+// ModelIngressRules is specified in the environs.ModelFirewaller interface.
+func (e *Environ) ModelIngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.ModelIngressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
@@ -1645,12 +1797,131 @@ func (e *Environ) cloudSpec(region string) (simplestreams.CloudSpec, error) {
 
 // TagInstance implements environs.InstanceTagger.
 func (e *Environ) TagInstance(id instance.Id, tags map[string]string) error {
-	if err := e.nova().SetServerMetadata(string(id), tags); err != nil {
-		return errors.Annotate(err, "setting server metadata")
+	setTags, removeKeys := splitTags(tags)
+	if len(setTags) > 0 {
+		if err := e.nova().SetServerMetadata(string(id), setTags); err != nil {
+			return errors.Annotate(err, "setting server metadata")
+		}
+	}
+	if len(removeKeys) == 0 {
+		return nil
+	}
+	server, err := e.nova().GetServer(string(id))
+	if err != nil {
+		return errors.Annotatef(err, "getting server %q", id)
+	}
+	for _, key := range removeKeys {
+		if _, ok := server.Metadata[key]; !ok {
+			continue
+		}
+		if err := deleteServerMetadataItem(e.client(), string(id), key); err != nil {
+			return errors.Annotatef(err, "deleting server metadata %q", key)
+		}
 	}
 	return nil
 }
 
+// AdoptableInstance implements environs.InstanceAdopter. The server
+// is looked up without filtering on the model's metadata, as it was
+// not started for the model.
+func (e *Environ) AdoptableInstance(id instance.Id) (instance.Instance, error) {
+	server, err := e.nova().GetServer(string(id))
+	if gooseerrors.IsNotFound(err) || (err == nil && !e.isAliveServer(*server)) {
+		return nil, errors.NotFoundf("instance %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get instance %q", id)
+	}
+	return &openstackInstance{e: e, serverDetail: server}, nil
+}
+
+// AdoptInstance implements environs.InstanceAdopter. The server is
+// added to the security groups the model's instances are started
+// with, creating any that do not exist yet, and its metadata is set.
+func (e *Environ) AdoptInstance(id instance.Id, machineId string, instanceTags map[string]string) error {
+	inst, err := e.AdoptableInstance(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	groupNames, err := e.firewaller.SetUpAdoptedGroups(instanceTags[tags.JujuController], machineId)
+	if err != nil {
+		return errors.Annotate(err, "cannot set up groups")
+	}
+	serverGroups := set.NewStrings()
+	if groups := inst.(*openstackInstance).getServerDetail().Groups; groups != nil {
+		for _, g := range *groups {
+			serverGroups.Add(g.Name)
+		}
+	}
+	for _, name := range groupNames {
+		if serverGroups.Contains(name) {
+			continue
+		}
+		if err := e.nova().AddServerSecurityGroup(string(id), name); err != nil {
+			return errors.Annotatef(err, "adding instance %q to security group %q", id, name)
+		}
+	}
+	return errors.Annotatef(e.TagInstance(id, instanceTags), "adopting instance %q", id)
+}
+
+// deleteServerMetadataItem deletes the server metadata item with the
+// given key. The nova client has no call to do so.
+var deleteServerMetadataItem = func(cl client.Client, serverId, key string) error {
+	itemURL := fmt.Sprintf("servers/%s/metadata/%s", serverId, key)
+	requestData := goosehttp.RequestData{ExpectedStatus: []int{http.StatusNoContent}}
+	return cl.SendRequest(client.DELETE, "compute", "v2", itemURL, &requestData)
+}
+
+// TagVolume implements environs.VolumeTagger.
+func (e *Environ) TagVolume(volumeId string, tags map[string]string) error {
+	cinder, err := e.cinderProvider()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	setTags, removeKeys := splitTags(tags)
+	if len(setTags) > 0 {
+		if _, err := cinder.storageAdapter.SetVolumeMetadata(volumeId, setTags); err != nil {
+			return errors.Annotate(err, "setting volume metadata")
+		}
+	}
+	if len(removeKeys) == 0 {
+		return nil
+	}
+	volume, err := cinder.storageAdapter.GetVolume(volumeId)
+	if err != nil {
+		return errors.Annotatef(err, "getting volume %q", volumeId)
+	}
+	var present []string
+	for _, key := range removeKeys {
+		if _, ok := volume.Metadata[key]; ok {
+			present = append(present, key)
+		}
+	}
+	if len(present) == 0 {
+		return nil
+	}
+	if err := cinder.storageAdapter.DeleteVolumeMetadata(volumeId, present); err != nil {
+		return errors.Annotate(err, "deleting volume metadata")
+	}
+	return nil
+}
+
+// splitTags returns the tags with values, which are to be set, and
+// the sorted names of the tags with empty values, which are to be
+// removed.
+func splitTags(tags map[string]string) (map[string]string, []string) {
+	setTags := make(map[string]string)
+	var removeKeys []string
+	for k, v := range tags {
+		if v == "" {
+			removeKeys = append(removeKeys, k)
+		} else {
+			setTags[k] = v
+		}
+	}
+	sort.Strings(removeKeys)
+	return setTags, removeKeys
+}
+
 func (e *Environ) SetClock(clock clock.Clock) {
 	e.clock = clock
 }
@@ -195,70 +195,66 @@ func (t *localTests) TestGetServerAddresses(c *gc.C) {
 
 func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 	groupId := "groupid"
+	anywhere := func(protocol string, from, to int) []neutron.RuleInfoV2 {
+		return []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     protocol,
+			PortRangeMin:   from,
+			PortRangeMax:   to,
+			RemoteIPPrefix: "0.0.0.0/0",
+			ParentGroupId:  groupId,
+		}, {
+			Direction:      "ingress",
+			IPProtocol:     protocol,
+			PortRangeMin:   from,
+			PortRangeMax:   to,
+			RemoteIPPrefix: "::/0",
+			EthernetType:   "IPv6",
+			ParentGroupId:  groupId,
+		}}
+	}
 	testCases := []struct {
 		about    string
 		rules    []network.IngressRule
 		expected []neutron.RuleInfoV2
 	}{{
-		about: "single port",
-		rules: []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)},
-		expected: []neutron.RuleInfoV2{{
-			Direction:      "ingress",
-			IPProtocol:     "tcp",
-			PortRangeMin:   80,
-			PortRangeMax:   80,
-			RemoteIPPrefix: "0.0.0.0/0",
-			ParentGroupId:  groupId,
-		}},
+		about:    "single port",
+		rules:    []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)},
+		expected: anywhere("tcp", 80, 80),
 	}, {
-		about: "multiple ports",
-		rules: []network.IngressRule{network.MustNewIngressRule("tcp", 80, 82)},
-		expected: []neutron.RuleInfoV2{{
-			Direction:      "ingress",
-			IPProtocol:     "tcp",
-			PortRangeMin:   80,
-			PortRangeMax:   82,
-			RemoteIPPrefix: "0.0.0.0/0",
-			ParentGroupId:  groupId,
-		}},
+		about:    "multiple ports",
+		rules:    []network.IngressRule{network.MustNewIngressRule("tcp", 80, 82)},
+		expected: anywhere("tcp", 80, 82),
 	}, {
 		about: "multiple port ranges",
 		rules: []network.IngressRule{
 			network.MustNewIngressRule("tcp", 80, 82),
 			network.MustNewIngressRule("tcp", 100, 120),
 		},
-		expected: []neutron.RuleInfoV2{{
-			Direction:      "ingress",
-			IPProtocol:     "tcp",
-			PortRangeMin:   80,
-			PortRangeMax:   82,
-			RemoteIPPrefix: "0.0.0.0/0",
-			ParentGroupId:  groupId,
-		}, {
-			Direction:      "ingress",
-			IPProtocol:     "tcp",
-			PortRangeMin:   100,
-			PortRangeMax:   120,
-			RemoteIPPrefix: "0.0.0.0/0",
-			ParentGroupId:  groupId,
-		}},
+		expected: append(anywhere("tcp", 80, 82), anywhere("tcp", 100, 120)...),
 	}, {
 		about: "source range",
 		rules: []network.IngressRule{network.MustNewIngressRule(
 			"tcp", 80, 100, "192.168.1.0/24", "0.0.0.0/0")},
-		expected: []neutron.RuleInfoV2{{
+		expected: append([]neutron.RuleInfoV2{{
 			Direction:      "ingress",
 			IPProtocol:     "tcp",
 			PortRangeMin:   80,
 			PortRangeMax:   100,
 			RemoteIPPrefix: "192.168.1.0/24",
 			ParentGroupId:  groupId,
-		}, {
+		}}, anywhere("tcp", 80, 100)...),
+	}, {
+		about: "IPv6 source range",
+		rules: []network.IngressRule{network.MustNewIngressRule(
+			"tcp", 443, 443, "2001:db8::/32")},
+		expected: []neutron.RuleInfoV2{{
 			Direction:      "ingress",
 			IPProtocol:     "tcp",
-			PortRangeMin:   80,
-			PortRangeMax:   100,
-			RemoteIPPrefix: "0.0.0.0/0",
+			PortRangeMin:   443,
+			PortRangeMax:   443,
+			RemoteIPPrefix: "2001:db8::/32",
+			EthernetType:   "IPv6",
 			ParentGroupId:  groupId,
 		}},
 	}}
@@ -348,6 +344,26 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 			RemoteIPPrefix: "192.168.100.0/24",
 		},
 		expected: false,
+	}, {
+		about: "IPv6 RemoteIPPrefix opened for anywhere",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 85),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_80,
+			PortRangeMax:   &port_85,
+			RemoteIPPrefix: "::/0",
+		},
+		expected: true,
+	}, {
+		about: "IPv6 RemoteIPPrefix not opened for specific sources",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 85, "192.168.1.0/24"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_80,
+			PortRangeMax:   &port_85,
+			RemoteIPPrefix: "::/0",
+		},
+		expected: false,
 	}}
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+	"gopkg.in/goose.v2/nova"
+)
+
+// antiAffinityPolicy is the policy of the Nova server groups that keep
+// the instances of an anti-affinity group on different compute hosts.
+const antiAffinityPolicy = "anti-affinity"
+
+// serverGroup describes a Nova server group. The nova client has no
+// calls for server groups, so the requests are made directly.
+type serverGroup struct {
+	Id       string   `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	Policies []string `json:"policies"`
+}
+
+// listServerGroups returns the server groups of the project.
+var listServerGroups = func(cl client.Client) ([]serverGroup, error) {
+	var resp struct {
+		ServerGroups []serverGroup `json:"server_groups"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := cl.SendRequest(client.GET, "compute", "v2", "os-server-groups", &requestData); err != nil {
+		return nil, err
+	}
+	return resp.ServerGroups, nil
+}
+
+// createServerGroup creates a server group with the given name and
+// policy.
+var createServerGroup = func(cl client.Client, name, policy string) (*serverGroup, error) {
+	var req struct {
+		ServerGroup serverGroup `json:"server_group"`
+	}
+	req.ServerGroup = serverGroup{Name: name, Policies: []string{policy}}
+	var resp struct {
+		ServerGroup serverGroup `json:"server_group"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := cl.SendRequest(client.POST, "compute", "v2", "os-server-groups", &requestData); err != nil {
+		return nil, err
+	}
+	return &resp.ServerGroup, nil
+}
+
+// deleteServerGroup deletes the server group with the given ID.
+var deleteServerGroup = func(cl client.Client, id string) error {
+	groupURL := fmt.Sprintf("os-server-groups/%s", id)
+	requestData := goosehttp.RequestData{ExpectedStatus: []int{http.StatusNoContent}}
+	return cl.SendRequest(client.DELETE, "compute", "v2", groupURL, &requestData)
+}
+
+// runServer starts a server as nova.Client.RunServer does, passing the
+// given scheduler hints, which nova.RunServerOpts has no field for.
+var runServer = func(cl client.Client, opts nova.RunServerOpts, schedulerHints map[string]interface{}) (*nova.Entity, error) {
+	var req struct {
+		Server         nova.RunServerOpts     `json:"server"`
+		SchedulerHints map[string]interface{} `json:"os:scheduler_hints,omitempty"`
+	}
+	req.Server = opts
+	req.SchedulerHints = schedulerHints
+	var resp struct {
+		Server nova.Entity `json:"server"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	if err := cl.SendRequest(client.POST, "compute", "v2", "servers", &requestData); err != nil {
+		return nil, err
+	}
+	return &resp.Server, nil
+}
+
+// findServerGroup returns the ID of the server group with the given ID
+// or name.
+func (e *Environ) findServerGroup(idOrName string) (string, error) {
+	groups, err := listServerGroups(e.client())
+	if err != nil {
+		return "", errors.Annotate(err, "cannot list server groups")
+	}
+	var ids []string
+	for _, g := range groups {
+		if g.Id == idOrName {
+			return g.Id, nil
+		}
+		if g.Name == idOrName {
+			ids = append(ids, g.Id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", errors.NotFoundf("server group %q", idOrName)
+	case 1:
+		return ids[0], nil
+	}
+	return "", errors.Errorf("server group name %q is ambiguous, use its ID", idOrName)
+}
+
+// antiAffinityServerGroupName returns the name of the server group that
+// keeps the instances of the given anti-affinity group apart.
+func (e *Environ) antiAffinityServerGroupName(group string) string {
+	return resourceName(e.namespace, e.name, "anti-affinity-"+group)
+}
+
+// ensureAntiAffinityServerGroup returns the ID of the server group that
+// keeps the instances of the given anti-affinity group on different
+// compute hosts, creating it if it does not exist.
+func (e *Environ) ensureAntiAffinityServerGroup(group string) (string, error) {
+	name := e.antiAffinityServerGroupName(group)
+	groups, err := listServerGroups(e.client())
+	if err != nil {
+		return "", errors.Annotate(err, "cannot list server groups")
+	}
+	for _, g := range groups {
+		if g.Name == name {
+			return g.Id, nil
+		}
+	}
+	g, err := createServerGroup(e.client(), name, antiAffinityPolicy)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot create server group %q", name)
+	}
+	return g.Id, nil
+}
+
+// deleteAntiAffinityServerGroups deletes the server groups created for
+// the model's anti-affinity groups. Empty server groups hold nothing
+// up, so failures are only logged.
+func (e *Environ) deleteAntiAffinityServerGroups() {
+	groups, err := listServerGroups(e.client())
+	if err != nil {
+		logger.Warningf("cannot list anti-affinity server groups: %v", err)
+		return
+	}
+	prefix := e.antiAffinityServerGroupName("")
+	for _, g := range groups {
+		if !strings.HasPrefix(g.Name, prefix) {
+			continue
+		}
+		if err := deleteServerGroup(e.client(), g.Id); err != nil {
+			logger.Warningf("cannot delete server group %q: consider deleting it manually: %v", g.Name, err)
+		}
+	}
+}
@@ -748,6 +748,12 @@ func (o *OracleEnviron) Destroy() error {
 	return common.Destroy(o)
 }
 
+// DestroyKeepingInstances is part of the environs.InstanceKeepingDestroyer
+// interface.
+func (o *OracleEnviron) DestroyKeepingInstances(keep []instance.Id) error {
+	return common.DestroyKeepingInstances(o, keep)
+}
+
 // DestroyController is part of the environs.Environ interface.
 func (o *OracleEnviron) DestroyController(controllerUUID string) error {
 	err := o.Destroy()
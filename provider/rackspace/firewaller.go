@@ -69,10 +69,30 @@ func (c *rackspaceFirewaller) GetSecurityGroups(ids ...instance.Id) ([]string, e
 }
 
 // SetUpGroups implements OpenstackFirewaller interface.
-func (c *rackspaceFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
+func (c *rackspaceFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int, apiAllow, apiAddrs []string) ([]string, error) {
 	return nil, nil
 }
 
+// SetUpAdoptedGroups implements OpenstackFirewaller interface.
+func (c *rackspaceFirewaller) SetUpAdoptedGroups(controllerUUID, machineId string) ([]string, error) {
+	return nil, nil
+}
+
+// OpenModelPorts implements OpenstackFirewaller interface.
+func (c *rackspaceFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("model firewall rules")
+}
+
+// CloseModelPorts implements OpenstackFirewaller interface.
+func (c *rackspaceFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("model firewall rules")
+}
+
+// ModelIngressRules implements OpenstackFirewaller interface.
+func (c *rackspaceFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("model firewall rules")
+}
+
 // OpenInstancePorts implements Firewaller interface.
 func (c *rackspaceFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	return c.changeIngressRules(inst, true, rules)
@@ -92,6 +112,21 @@ func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return configurator.FindIngressRules()
 }
 
+// OpenInstanceEgress implements Firewaller interface.
+func (c *rackspaceFirewaller) OpenInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules")
+}
+
+// CloseInstanceEgress implements Firewaller interface.
+func (c *rackspaceFirewaller) CloseInstanceEgress(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules")
+}
+
+// InstanceEgressRules implements Firewaller interface.
+func (c *rackspaceFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules")
+}
+
 func (c *rackspaceFirewaller) changeIngressRules(inst instance.Instance, insert bool, rules []network.IngressRule) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
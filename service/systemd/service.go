@@ -512,6 +512,40 @@ func (s *Service) writeConf() (string, error) {
 	return filename, nil
 }
 
+// systemdDir is the directory holding the unit files installed by
+// the system administrator.
+const systemdDir = "/etc/systemd/system"
+
+// WriteService writes the service's conf file and links it into the
+// systemd configuration so that the service is started at boot. Unlike
+// Install it does not talk to systemd, so it can prepare the service on
+// a host that is about to be upgraded to a series that uses systemd.
+func (s *Service) WriteService() error {
+	if s.NoConf() {
+		return s.errorf(nil, "missing conf")
+	}
+	filename, err := s.writeConf()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	wantsDir := path.Join(systemdDir, "multi-user.target.wants")
+	if err := mkdirAll(wantsDir); err != nil {
+		return s.errorf(err, "failed to create %q", wantsDir)
+	}
+	for _, link := range []string{
+		path.Join(systemdDir, s.ConfName),
+		path.Join(wantsDir, s.ConfName),
+	} {
+		if err := removeAll(link); err != nil {
+			return s.errorf(err, "failed to remove %q", link)
+		}
+		if err := symlink(filename, link); err != nil {
+			return s.errorf(err, "failed to link %q", link)
+		}
+	}
+	return nil
+}
+
 var mkdirAll = func(dirname string) error {
 	return os.MkdirAll(dirname, 0755)
 }
@@ -520,6 +554,10 @@ var createFile = func(filename string, data []byte, perm os.FileMode) error {
 	return ioutil.WriteFile(filename, data, perm)
 }
 
+var symlink = func(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
 // InstallCommands implements Service.
 func (s *Service) InstallCommands() ([]string, error) {
 	if s.NoConf() {
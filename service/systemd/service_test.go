@@ -767,6 +767,47 @@ func (s *initSystemSuite) TestInstallEmptyConf(c *gc.C) {
 	s.stub.CheckCalls(c, nil)
 }
 
+func (s *initSystemSuite) TestWriteService(c *gc.C) {
+	err := s.service.WriteService()
+	c.Assert(err, jc.ErrorIsNil)
+
+	dirname := fmt.Sprintf("%s/init/%s", s.dataDir, s.name)
+	filename := fmt.Sprintf("%s/%s.service", dirname, s.name)
+	createFileOutput := s.stub.Calls()[1].Args[1]
+	c.Check(string(createFileOutput.([]byte)), gc.Equals, s.newConfStr(s.name))
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "MkdirAll",
+		Args:     []interface{}{dirname},
+	}, {
+		FuncName: "CreateFile",
+		Args:     []interface{}{filename, createFileOutput, os.FileMode(0644)},
+	}, {
+		FuncName: "MkdirAll",
+		Args:     []interface{}{"/etc/systemd/system/multi-user.target.wants"},
+	}, {
+		FuncName: "RemoveAll",
+		Args:     []interface{}{"/etc/systemd/system/" + s.name + ".service"},
+	}, {
+		FuncName: "Symlink",
+		Args:     []interface{}{filename, "/etc/systemd/system/" + s.name + ".service"},
+	}, {
+		FuncName: "RemoveAll",
+		Args:     []interface{}{"/etc/systemd/system/multi-user.target.wants/" + s.name + ".service"},
+	}, {
+		FuncName: "Symlink",
+		Args:     []interface{}{filename, "/etc/systemd/system/multi-user.target.wants/" + s.name + ".service"},
+	}})
+}
+
+func (s *initSystemSuite) TestWriteServiceEmptyConf(c *gc.C) {
+	s.service.Service.Conf = common.Conf{}
+
+	err := s.service.WriteService()
+
+	c.Check(err, gc.ErrorMatches, `.*missing conf.*`)
+	s.stub.CheckCalls(c, nil)
+}
+
 func (s *initSystemSuite) TestInstallCommands(c *gc.C) {
 	name := "jujud-machine-0"
 	commands, err := s.service.InstallCommands()
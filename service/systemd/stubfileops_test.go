@@ -30,3 +30,9 @@ func (sfo *StubFileOps) CreateFile(filename string, data []byte, perm os.FileMod
 
 	return sfo.NextErr()
 }
+
+func (sfo *StubFileOps) Symlink(oldname, newname string) error {
+	sfo.AddCall("Symlink", oldname, newname)
+
+	return sfo.NextErr()
+}
@@ -271,7 +271,10 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	mdoc, err := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -344,18 +347,35 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if !parent.supportsContainerType(containerType) {
 		return nil, nil, errors.Errorf("machine %s cannot host %s containers", parentId, containerType)
 	}
+	if err := checkNotLockedForSeriesUpgrade(parent); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := checkNotInMaintenance(parent); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	newId, err := st.newContainerId(parentId, containerType)
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 	prereqOps = append(prereqOps,
+		// The host machine must not be upgrading its series.
+		assertNoUpgradeSeriesLockOp(st, parentId),
+		// The host machine must not be in maintenance.
+		txn.Op{
+			C:      machinesC,
+			Id:     st.docID(parentId),
+			Assert: notInMaintenanceDoc,
+		},
 		// Update containers record for host machine.
 		st.addChildToContainerRefOp(parentId, mdoc.Id),
 		// Create a containers reference document for the container itself.
@@ -399,7 +419,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		}
 	}
 
-	parentDoc := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	parentDoc, err := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	newId, err := st.newContainerId(parentDoc.Id, containerType)
 	if err != nil {
 		return nil, nil, err
@@ -408,7 +431,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
 	if err != nil {
@@ -428,13 +454,17 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	return mdoc, append(prereqOps, parentOp, machineOp), nil
 }
 
-func (st *State) machineDocForTemplate(template MachineTemplate, id string) *machineDoc {
+func (st *State) machineDocForTemplate(template MachineTemplate, id string) (*machineDoc, error) {
+	selector, err := st.addressSelector()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// We ignore the error from Select*Address as an error indicates
 	// no address is available, in which case the empty address is returned
 	// and setting the preferred address to an empty one is the correct
 	// thing to do when none is available.
-	privateAddr, _ := network.SelectInternalAddress(template.Addresses, false)
-	publicAddr, _ := network.SelectPublicAddress(template.Addresses)
+	privateAddr, _ := selector.SelectInternalAddress(template.Addresses, false)
+	publicAddr, _ := selector.SelectPublicAddress(template.Addresses)
 	logger.Infof(
 		"new machine %q has preferred addresses: private %q, public %q",
 		id, privateAddr, publicAddr,
@@ -454,7 +484,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
-	}
+	}, nil
 }
 
 // insertNewMachineOps returns operations to insert the given machine document
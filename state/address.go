@@ -142,6 +142,38 @@ func (st *State) APIHostPorts() ([][]network.HostPort, error) {
 	return networkHostsPorts(doc.APIHostPorts), nil
 }
 
+// addressSelector returns the selector used to pick and sort the
+// addresses of the model's machines, which follows the model's
+// "prefer-ipv6" setting.
+func (st *State) addressSelector() (network.AddressSelector, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return network.AddressSelector{}, errors.Trace(err)
+	}
+	return network.AddressSelector{PreferIPv6: cfg.PreferIPv6()}, nil
+}
+
+// updatePreferredAddresses selects the preferred addresses of all
+// the model's machines again, after the model's IP version preference
+// has changed.
+func (st *State) updatePreferredAddresses() error {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.Life() == Dead {
+			continue
+		}
+		// Setting the same provider addresses again
+		// reselects the preferred ones.
+		if err := m.SetProviderAddresses(m.ProviderAddresses()...); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // address represents the location of a machine, including metadata
 // about what kind of location the address describes.
 //
@@ -146,6 +146,15 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds the groups of users defined in the
+		// controller, along with their members.
+		userGroupsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"members"},
+			}},
+		},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
@@ -197,6 +206,10 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds the definitions of roles, each a named
+		// set of capabilities which may be granted to users on models.
+		rolesC: {global: true},
+
 		// This collection holds information cached by autocert certificate
 		// acquisition.
 		autocertCacheC: {
@@ -219,6 +232,13 @@ func allCollections() collectionSchema {
 		// of the intersection axis of permissionsC
 		modelUsersC: {},
 
+		// This collection holds the roles granted to users on a model.
+		roleGrantsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "user"},
+			}},
+		},
+
 		// This collection contains governors that prevent certain kinds of
 		// changes from being accepted.
 		blocksC: {},
@@ -293,6 +313,10 @@ func allCollections() collectionSchema {
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
 
+		// This collection holds the locks of machines being upgraded
+		// to a new series in place.
+		upgradeSeriesLocksC: {},
+
 		// -----
 
 		// These collections hold information associated with storage.
@@ -474,6 +498,7 @@ const (
 	leasesC                  = "leases"
 	machinesC                = "machines"
 	machineRemovalsC         = "machineremovals"
+	upgradeSeriesLocksC      = "machineUpgradeSeriesLocks"
 	meterStatusC             = "meterStatus"
 	metricsC                 = "metrics"
 	metricsManagerC          = "metricsmanager"
@@ -494,6 +519,8 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	roleGrantsC              = "rolegrants"
+	rolesC                   = "roles"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
@@ -516,6 +543,7 @@ const (
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
+	userGroupsC              = "usergroups"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
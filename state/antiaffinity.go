@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// AntiAffinity reports whether the application's units must be kept on
+// different hosts. Units are kept on different top-level machines, as
+// a unit in a container shares its host with the units on the
+// container's top-level machine and its other containers. Providers
+// that can keep the instances of those machines on different physical
+// hosts are asked to when the machines are provisioned.
+func (a *Application) AntiAffinity() bool {
+	return a.doc.AntiAffinity
+}
+
+// SetAntiAffinity sets whether the application's units must be kept on
+// different hosts. The policy constrains units assigned after it is
+// set; units that already share a host are left where they are.
+func (a *Application) SetAntiAffinity(antiAffinity bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if a.doc.AntiAffinity == antiAffinity {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$set", bson.D{{"anti-affinity", true}}}}
+		if !antiAffinity {
+			update = bson.D{{"$unset", bson.D{{"anti-affinity", nil}}}}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set anti-affinity of application %q", a)
+	}
+	a.doc.AntiAffinity = antiAffinity
+	return nil
+}
+
+// antiAffinityOps returns txn.Ops asserting that no other unit of the
+// unit's application is assigned to the host of the given machine, if
+// the application has anti-affinity. An error satisfying
+// IsAntiAffinityError is returned if one already is.
+func (u *Unit) antiAffinityOps(machineId string) ([]txn.Op, error) {
+	if u.doc.Principal != "" {
+		// Subordinates go wherever their principals are.
+		return nil, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !app.doc.AntiAffinity {
+		return nil, nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Juju can only tell which units share a top-level machine;
+	// keeping the machines' instances on different physical hosts
+	// is left to the provider.
+	host := TopParentId(machineId)
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: bson.D{{"anti-affinity", true}},
+	}}
+	for _, other := range units {
+		if other.doc.Name == u.doc.Name {
+			continue
+		}
+		otherMachineId := other.doc.MachineId
+		if otherMachineId != "" && TopParentId(otherMachineId) == host {
+			return nil, &antiAffinityError{
+				application: app.doc.Name,
+				unit:        other.doc.Name,
+				host:        host,
+			}
+		}
+		// Other units must not be assigned to the
+		// same host concurrently.
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     other.doc.DocID,
+			Assert: bson.D{{"machineid", otherMachineId}},
+		})
+	}
+	return ops, nil
+}
+
+type antiAffinityError struct {
+	application string
+	unit        string
+	host        string
+}
+
+func (e *antiAffinityError) Error() string {
+	return fmt.Sprintf(
+		"application %q has anti-affinity and unit %q is already on host machine %s",
+		e.application, e.unit, e.host,
+	)
+}
+
+// IsAntiAffinityError returns whether the error is because assigning a
+// unit would put it on the same host as another unit of an application
+// with anti-affinity.
+func IsAntiAffinityError(err error) bool {
+	_, ok := errors.Cause(err).(*antiAffinityError)
+	return ok
+}
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -23,6 +25,8 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/status"
 )
@@ -36,22 +40,25 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string     `bson:"_id"`
-	Name                 string     `bson:"name"`
-	ModelUUID            string     `bson:"model-uuid"`
-	Series               string     `bson:"series"`
-	Subordinate          bool       `bson:"subordinate"`
-	CharmURL             *charm.URL `bson:"charmurl"`
-	Channel              string     `bson:"cs-channel"`
-	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
-	ForceCharm           bool       `bson:"forcecharm"`
-	Life                 Life       `bson:"life"`
-	UnitCount            int        `bson:"unitcount"`
-	RelationCount        int        `bson:"relationcount"`
-	Exposed              bool       `bson:"exposed"`
-	MinUnits             int        `bson:"minunits"`
-	TxnRevno             int64      `bson:"txn-revno"`
-	MetricCredentials    []byte     `bson:"metric-credentials"`
+	DocID                string                     `bson:"_id"`
+	Name                 string                     `bson:"name"`
+	ModelUUID            string                     `bson:"model-uuid"`
+	Series               string                     `bson:"series"`
+	Subordinate          bool                       `bson:"subordinate"`
+	CharmURL             *charm.URL                 `bson:"charmurl"`
+	Channel              string                     `bson:"cs-channel"`
+	CharmModifiedVersion int                        `bson:"charmmodifiedversion"`
+	ForceCharm           bool                       `bson:"forcecharm"`
+	Life                 Life                       `bson:"life"`
+	UnitCount            int                        `bson:"unitcount"`
+	RelationCount        int                        `bson:"relationcount"`
+	Exposed              bool                       `bson:"exposed"`
+	ExposedEndpoints     map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                        `bson:"minunits"`
+	ResourceTags         map[string]string          `bson:"resource-tags,omitempty"`
+	AntiAffinity         bool                       `bson:"anti-affinity,omitempty"`
+	TxnRevno             int64                      `bson:"txn-revno"`
+	MetricCredentials    []byte                     `bson:"metric-credentials"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -121,6 +128,179 @@ func (a *Application) Series() string {
 	return a.doc.Series
 }
 
+// UpdateApplicationSeries changes the series used when new machines
+// are provisioned for the application's units. Existing units keep
+// running on their machines' series. Unless force is true, the
+// application's charm must declare support for the new series. The
+// series of the application's subordinates is changed with it, as
+// principals and their subordinates must have the same series.
+func (a *Application) UpdateApplicationSeries(toSeries string, force bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update series of application %q to %q", a, toSeries)
+	if toSeries == "" {
+		return errors.NotValidf("empty series")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if a.doc.Series == toSeries {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if a.doc.Subordinate {
+			return nil, errors.New("application is a subordinate; change the series of its principals instead")
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkCharmSupportsSeries(ch, toSeries, force); err != nil {
+			return nil, errors.Trace(err)
+		}
+		subordinateOps, err := a.updateSubordinatesSeriesOps(toSeries, force)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", a.doc.CharmURL},
+				{"series", a.doc.Series},
+			},
+			Update: bson.D{{"$set", bson.D{{"series", toSeries}}}},
+		}}, subordinateOps...), nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return err
+	}
+	a.doc.Series = toSeries
+	return nil
+}
+
+// updateSubordinatesSeriesOps returns the operations to change the
+// series of the application's subordinates to the given one. It
+// returns an error if a subordinate's charm does not support the
+// series, or a subordinate is also related to a principal that keeps
+// another series.
+func (a *Application) updateSubordinatesSeriesOps(toSeries string, force bool) ([]txn.Op, error) {
+	subordinates, err := a.containerRelatedApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, sub := range subordinates {
+		if !sub.doc.Subordinate || sub.doc.Series == toSeries {
+			continue
+		}
+		principals, err := sub.containerRelatedApplications()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, principal := range principals {
+			if principal.doc.Name != a.doc.Name && principal.doc.Series != toSeries {
+				return nil, errors.Errorf(
+					"subordinate %q is also related to %q, which has series %q",
+					sub.doc.Name, principal.doc.Name, principal.doc.Series,
+				)
+			}
+		}
+		ch, _, err := sub.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkCharmSupportsSeries(ch, toSeries, force); err != nil {
+			return nil, errors.Annotatef(err, "subordinate %q", sub.doc.Name)
+		}
+		ops = append(ops, txn.Op{
+			C:  applicationsC,
+			Id: sub.doc.DocID,
+			Assert: bson.D{
+				{"charmurl", sub.doc.CharmURL},
+				{"series", sub.doc.Series},
+			},
+			Update: bson.D{{"$set", bson.D{{"series", toSeries}}}},
+		})
+	}
+	return ops, nil
+}
+
+// containerRelatedApplications returns the local applications related
+// to the application by container scoped relations.
+func (a *Application) containerRelatedApplications() ([]*Application, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := set.NewStrings()
+	var applications []*Application
+	for _, rel := range relations {
+		for _, ep := range rel.Endpoints() {
+			if ep.Scope != charm.ScopeContainer || ep.ApplicationName == a.doc.Name {
+				continue
+			}
+			if seen.Contains(ep.ApplicationName) {
+				continue
+			}
+			seen.Add(ep.ApplicationName)
+			application, err := a.st.Application(ep.ApplicationName)
+			if errors.IsNotFound(err) {
+				// Remote applications are never subordinates.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			applications = append(applications, application)
+		}
+	}
+	return applications, nil
+}
+
+// checkCharmSupportsSeries returns an error if the charm cannot be
+// deployed to machines running the given series. Charms written for a
+// single series are bound to it; with force, multi-series charms may
+// be used with any series of an operating system they support.
+func checkCharmSupportsSeries(ch *Charm, toSeries string, force bool) error {
+	if curlSeries := ch.URL().Series; curlSeries != "" {
+		if curlSeries != toSeries {
+			return errors.Errorf("charm %q only supports series %q", ch.URL(), curlSeries)
+		}
+		return nil
+	}
+	supportedSeries := ch.Meta().Series
+	for _, s := range supportedSeries {
+		if s == toSeries {
+			return nil
+		}
+	}
+	if !force {
+		supported := "no series"
+		if len(supportedSeries) > 0 {
+			supported = strings.Join(supportedSeries, ", ")
+		}
+		return errors.Errorf("only these series are supported: %v", supported)
+	}
+	toOS, err := series.GetOSFromSeries(toSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range supportedSeries {
+		charmOS, err := series.GetOSFromSeries(s)
+		if err == nil && charmOS == toOS {
+			return nil
+		}
+	}
+	if len(supportedSeries) > 0 {
+		return errors.Errorf("OS %q not supported by charm", toOS)
+	}
+	return nil
+}
+
 // Life returns whether the application is Alive, Dying or Dead.
 func (a *Application) Life() Life {
 	return a.doc.Life
@@ -296,9 +476,29 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
 	)
+	accessOps, err := a.st.removeApplicationAccessOps(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, accessOps...)
 	return ops, nil
 }
 
+// ExposedEndpoint describes the sources from which the opened ports
+// of an exposed application may be reached through one of its endpoints.
+type ExposedEndpoint struct {
+	// ExposeToSpaces holds the names of spaces whose
+	// subnets may reach the endpoint.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs holds the CIDRs that may reach the endpoint.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllEndpoints is the endpoint name used in expose settings
+// that stands for all of an application's endpoints.
+const AllEndpoints = ""
+
 // IsExposed returns whether this application is exposed. The explicitly open
 // ports (with open-port) for exposed applications may be accessed from machines
 // outside of the local deployment network. See SetExposed and ClearExposed.
@@ -306,13 +506,30 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedEndpoints returns the expose settings of the application's
+// endpoints, keyed by endpoint name. An exposed application with no
+// expose settings is exposed to everyone on all of its endpoints.
+// See MergeExposeSettings.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for name, ep := range a.doc.ExposedEndpoints {
+		result[name] = ep
+	}
+	return result
+}
+
+// SetExposed marks the application as exposed to everyone,
+// discarding any expose settings of its endpoints.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag from the application,
+// along with any expose settings of its endpoints.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
@@ -323,12 +540,161 @@ func (a *Application) setExposed(exposed bool) (err error) {
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-endpoints", nil}}},
+		},
 	}}
 	if err := a.st.runTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedEndpoints = nil
+	return nil
+}
+
+// MergeExposeSettings marks the application as exposed, and merges
+// the given settings into the expose settings of its endpoints. The
+// settings given for an endpoint replace any it had before, and an
+// endpoint given neither spaces nor CIDRs is exposed to everyone.
+// The AllEndpoints name applies settings to every endpoint.
+func (a *Application) MergeExposeSettings(exposed map[string]ExposedEndpoint) error {
+	if err := a.validateExposeSettings(exposed); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", a)
+	}
+	var merged map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		merged = make(map[string]ExposedEndpoint)
+		for name, ep := range a.doc.ExposedEndpoints {
+			merged[name] = ep
+		}
+		for name, ep := range exposed {
+			if len(ep.ExposeToSpaces) == 0 && len(ep.ExposeToCIDRs) == 0 {
+				ep.ExposeToCIDRs = []string{"0.0.0.0/0"}
+			}
+			merged[name] = ep
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", merged},
+			}}},
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", a)
+	}
+	a.doc.Exposed = true
+	a.doc.ExposedEndpoints = merged
+	return nil
+}
+
+// validateExposeSettings checks that the endpoints, spaces
+// and CIDRs named in the given expose settings are valid.
+func (a *Application) validateExposeSettings(exposed map[string]ExposedEndpoint) error {
+	endpoints, err := a.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := set.NewStrings(AllEndpoints)
+	for _, ep := range endpoints {
+		known.Add(ep.Name)
+	}
+	for name, ep := range exposed {
+		if !known.Contains(name) {
+			return errors.NotFoundf("endpoint %q", name)
+		}
+		for _, spaceName := range ep.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, cidr := range ep.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceTags returns the tags that are set on the cloud resources of
+// the application's units, over the model's resource tags, and a flag
+// indicating whether the application has any. Application implements
+// tags.ResourceTagger.
+func (a *Application) ResourceTags() (map[string]string, bool) {
+	if len(a.doc.ResourceTags) == 0 {
+		return nil, false
+	}
+	result := make(map[string]string, len(a.doc.ResourceTags))
+	for k, v := range a.doc.ResourceTags {
+		result[k] = v
+	}
+	return result, true
+}
+
+// UpdateResourceTags merges the given tags into the application's
+// resource tags. A tag given an empty value is removed. Tags with the
+// prefix reserved for Juju may not be set.
+func (a *Application) UpdateResourceTags(resourceTags map[string]string) error {
+	for k := range resourceTags {
+		if k == "" {
+			return errors.NotValidf("empty tag name")
+		}
+		if strings.HasPrefix(k, tags.JujuTagPrefix) {
+			return errors.NotValidf("tag %q with reserved prefix %q", k, tags.JujuTagPrefix)
+		}
+	}
+	var merged map[string]string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		merged = make(map[string]string)
+		for k, v := range a.doc.ResourceTags {
+			merged[k] = v
+		}
+		for k, v := range resourceTags {
+			if v == "" {
+				delete(merged, k)
+			} else {
+				merged[k] = v
+			}
+		}
+		update := bson.D{{"$set", bson.D{{"resource-tags", merged}}}}
+		if len(merged) == 0 {
+			update = bson.D{{"$unset", bson.D{{"resource-tags", nil}}}}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update resource tags of application %q", a)
+	}
+	if len(merged) == 0 {
+		merged = nil
+	}
+	a.doc.ResourceTags = merged
 	return nil
 }
 
@@ -1531,6 +1897,176 @@ func (a *Application) EndpointBindings() (map[string]string, error) {
 	return bindings, nil
 }
 
+// SetEndpointBindings merges the given map of endpoint names to space names
+// into the application's endpoint bindings. Every machine hosting a unit of
+// the application must have an address in each space an endpoint is newly
+// bound to, or be a container whose host machine has one. Units see the
+// change as a config-changed hook, and the address each unit has published
+// in the relations of the rebound endpoints, cross-model ones included, is
+// updated to match the new bindings.
+func (a *Application) SetEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for application %q", a)
+	var rebound set.Strings
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		existing, err := a.EndpointBindings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(a.st, a.globalKey(), bindings, ch.Meta())
+		if err == jujutxn.ErrNoOperations {
+			return nil, err
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		merged, _, err := mergeBindings(bindings, existing, ch.Meta())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rebound = set.NewStrings()
+		spaces := set.NewStrings()
+		for endpoint, space := range merged {
+			if endpoint == defaultEndpointName || existing[endpoint] == space {
+				continue
+			}
+			rebound.Add(endpoint)
+			if space != environs.DefaultSpaceName {
+				spaces.Add(space)
+			}
+		}
+		if err := a.checkUnitMachinesInSpaces(spaces); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"charmurl", a.doc.CharmURL}},
+		}, bindingsOp}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return err
+	}
+	return errors.Trace(a.updateRelationAddresses(rebound))
+}
+
+// checkUnitMachinesInSpaces returns an error if a machine hosting one of
+// the application's units has no address in one of the given spaces.
+// A container is bridged into the new spaces by its provisioner, so it
+// only needs its host machine to have an address in them.
+func (a *Application) checkUnitMachinesInSpaces(spaces set.Strings) error {
+	if spaces.IsEmpty() {
+		return nil
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		machine, err := a.st.Machine(machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var host *Machine
+		if parentId, ok := machine.ParentId(); ok {
+			if host, err = a.st.Machine(parentId); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, space := range spaces.SortedValues() {
+			_, err := machine.addressInSpace(space)
+			if errors.IsNotFound(err) && host != nil {
+				_, err = host.addressInSpace(space)
+				if errors.IsNotFound(err) {
+					return errors.Errorf(
+						"neither container %q hosting unit %q nor its host machine %q has an address in space %q",
+						machineId, unit.Name(), host.Id(), space,
+					)
+				}
+			}
+			if errors.IsNotFound(err) {
+				return errors.Errorf(
+					"machine %q hosting unit %q has no address in space %q",
+					machineId, unit.Name(), space,
+				)
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// updateRelationAddresses updates the private-address setting of each
+// unit of the application in the relations of the given endpoints, so
+// that related units see the unit's address in the endpoint's space.
+func (a *Application) updateRelationAddresses(endpoints set.Strings) error {
+	if endpoints.IsEmpty() {
+		return nil
+	}
+	relations, err := a.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(a.doc.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !endpoints.Contains(ep.Name) {
+			continue
+		}
+		for _, unit := range units {
+			ru, err := rel.Unit(unit)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if inScope, err := ru.InScope(); err != nil {
+				return errors.Trace(err)
+			} else if !inScope {
+				continue
+			}
+			address, err := ru.SettingsAddress()
+			if err != nil {
+				logger.Warningf("cannot update address of unit %q in relation %q: %v", unit, rel, err)
+				continue
+			}
+			settings, err := ru.Settings()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if current, _ := settings.Get("private-address"); current == address.Value {
+				continue
+			}
+			settings.Set("private-address", address.Value)
+			if _, err := settings.Write(); err != nil {
+				return errors.Annotatef(err, "updating address of unit %q in relation %q", unit, rel)
+			}
+		}
+	}
+	return nil
+}
+
 // defaultEndpointBindings returns a map with each endpoint from the current
 // charm metadata bound to an empty space. If no charm URL is set yet, it
 // returns an empty map.
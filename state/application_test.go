@@ -15,10 +15,12 @@ import (
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -176,6 +178,119 @@ func (s *ApplicationSuite) TestClientServiceSetCharmWrongOS(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "application" to charm "cs:multi-series-windows-1": OS "Ubuntu" not supported by charm`)
 }
 
+func (s *ApplicationSuite) TestUpdateApplicationSeries(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := app.UpdateApplicationSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "trusty")
+
+	app, err = s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "trusty")
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationSeriesUnsupported(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := app.UpdateApplicationSeries("xenial", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series of application "application" to "xenial": only these series are supported: precise, trusty`)
+	c.Assert(app.Series(), gc.Equals, "precise")
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationSeriesForce(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := app.UpdateApplicationSeries("xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "xenial")
+
+	err = app.UpdateApplicationSeries("win2012r2", true)
+	c.Assert(err, gc.ErrorMatches, `cannot update series of application "application" to "win2012r2": OS "Windows" not supported by charm`)
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationSeriesSingleSeriesCharm(c *gc.C) {
+	err := s.mysql.UpdateApplicationSeries("trusty", true)
+	c.Assert(err, gc.ErrorMatches, `cannot update series of application "mysql" to "trusty": charm "local:quantal/quantal-mysql-[0-9]+" only supports series "quantal"`)
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationSeriesNewUnitMachines(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	err := app.UpdateApplicationSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignNew)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Series(), gc.Equals, "trusty")
+}
+
+func (s *ApplicationSuite) addMultiSeriesSubordinate(c *gc.C, principals ...string) *state.Application {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series-subordinate")
+	sub := state.AddTestingServiceForSeries(c, s.State, "precise", "subordinate", ch)
+	for _, principal := range principals {
+		eps, err := s.State.InferEndpoints(principal, "subordinate")
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = s.State.AddRelation(eps...)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return sub
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationSeriesUpdatesSubordinates(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	sub := s.addMultiSeriesSubordinate(c, "application")
+
+	err := app.UpdateApplicationSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sub.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sub.Series(), gc.Equals, "trusty")
+
+	// The subordinate can still be related to other
+	// principals of the new series.
+	other := state.AddTestingServiceForSeries(c, s.State, "trusty", "other", ch)
+	eps, err := s.State.InferEndpoints(other.Name(), "subordinate")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationSeriesSubordinateOfOtherPrincipal(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	state.AddTestingServiceForSeries(c, s.State, "precise", "other", ch)
+	sub := s.addMultiSeriesSubordinate(c, "application", "other")
+
+	err := app.UpdateApplicationSeries("trusty", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series of application "application" to "trusty": `+
+		`subordinate "subordinate" is also related to "other", which has series "precise"`)
+	c.Assert(app.Series(), gc.Equals, "precise")
+	err = sub.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sub.Series(), gc.Equals, "precise")
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationSeriesOfSubordinate(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	sub := s.addMultiSeriesSubordinate(c, "application")
+
+	err := sub.UpdateApplicationSeries("trusty", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series of application "subordinate" to "trusty": `+
+		`application is a subordinate; change the series of its principals instead`)
+}
+
 func (s *ApplicationSuite) TestSetCharmPreconditions(c *gc.C) {
 	logging := s.AddTestingCharm(c, "logging")
 	cfg := state.SetCharmConfig{Charm: logging}
@@ -1393,6 +1508,143 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestMergeExposeSettings(c *gc.C) {
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToSpaces: []string{"admin"}},
+		"juju-info":        {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToSpaces: []string{"admin"}},
+		"juju-info":        {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		"server":           {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	}
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+
+	// Exposing the application to everyone discards the settings.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		exposed map[string]state.ExposedEndpoint
+		err     string
+	}{{
+		exposed: map[string]state.ExposedEndpoint{"nope": {}},
+		err:     `cannot expose application "mysql": endpoint "nope" not found`,
+	}, {
+		exposed: map[string]state.ExposedEndpoint{"server": {ExposeToSpaces: []string{"missing"}}},
+		err:     `cannot expose application "mysql": space "missing" not found`,
+	}, {
+		exposed: map[string]state.ExposedEndpoint{"server": {ExposeToCIDRs: []string{"10.0.0.0"}}},
+		err:     `cannot expose application "mysql": CIDR "10.0.0.0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.mysql.MergeExposeSettings(test.exposed)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{"server": {}})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestUpdateResourceTags(c *gc.C) {
+	_, ok := s.mysql.ResourceTags()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.mysql.UpdateResourceTags(map[string]string{"team": "db", "cost-centre": "42"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.UpdateResourceTags(map[string]string{"team": "dba", "cost-centre": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	resourceTags, ok := s.mysql.ResourceTags()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(resourceTags, jc.DeepEquals, map[string]string{"team": "dba"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	resourceTags, ok = s.mysql.ResourceTags()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(resourceTags, jc.DeepEquals, map[string]string{"team": "dba"})
+
+	// Removing the last tag leaves the application with none.
+	err = s.mysql.UpdateResourceTags(map[string]string{"team": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.mysql.ResourceTags()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestUpdateResourceTagsInvalid(c *gc.C) {
+	err := s.mysql.UpdateResourceTags(map[string]string{"juju-model-uuid": "nope"})
+	c.Assert(err, gc.ErrorMatches, `tag "juju-model-uuid" with reserved prefix "juju-" not valid`)
+	err = s.mysql.UpdateResourceTags(map[string]string{"": "nope"})
+	c.Assert(err, gc.ErrorMatches, `empty tag name not valid`)
+	_, ok := s.mysql.ResourceTags()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestUpdateResourceTagsNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.UpdateResourceTags(map[string]string{"team": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot update resource tags of application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestSetAntiAffinity(c *gc.C) {
+	c.Assert(s.mysql.AntiAffinity(), jc.IsFalse)
+	err := s.mysql.SetAntiAffinity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.AntiAffinity(), jc.IsTrue)
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.AntiAffinity(), jc.IsTrue)
+
+	err = app.SetAntiAffinity(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.AntiAffinity(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestSetAntiAffinityNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetAntiAffinity(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set anti-affinity of application "mysql": not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -2558,6 +2810,178 @@ func (s *ApplicationSuite) TestEndpointBindingsWithExplictOverrides(c *gc.C) {
 	s.assertApplicationRemovedWithItsBindings(c, service)
 }
 
+func (s *ApplicationSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{
+		"server":       "db",
+		"server-admin": "",
+	})
+
+	// Setting the same bindings again is a no-op.
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsUnknownSpace(c *gc.C) {
+	err := s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": unknown space "db" not valid`)
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsUnknownEndpoint(c *gc.C) {
+	err := s.mysql.SetEndpointBindings(map[string]string{"foo": ""})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": unknown endpoint "foo" not valid`)
+}
+
+func (s *ApplicationSuite) addSpaceWithSubnet(c *gc.C, spaceName, cidr string) {
+	_, err := s.State.AddSpace(spaceName, "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR:      cidr,
+		SpaceName: spaceName,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) addUnitWithAddresses(c *gc.C, cidrAddresses ...string) *state.Unit {
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setMachineAddresses(c, machine, cidrAddresses...)
+	return unit
+}
+
+func (s *ApplicationSuite) setMachineAddresses(c *gc.C, machine *state.Machine, cidrAddresses ...string) {
+	for i, cidrAddress := range cidrAddresses {
+		deviceName := fmt.Sprintf("eth%d", i)
+		err := machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+			Name: deviceName,
+			Type: state.EthernetDevice,
+			IsUp: true,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+			DeviceName:   deviceName,
+			CIDRAddress:  cidrAddress,
+			ConfigMethod: state.StaticAddress,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsMachineNotInSpace(c *gc.C) {
+	s.addSpaceWithSubnet(c, "db", "10.10.0.0/24")
+	s.addUnitWithAddresses(c, "10.0.0.5/24")
+
+	err := s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": `+
+		`machine "0" hosting unit "mysql/0" has no address in space "db"`)
+	bindings, err := s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "")
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsContainerNotInSpace(c *gc.C) {
+	s.addSpaceWithSubnet(c, "db", "10.10.0.0/24")
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	container, err := s.State.AddMachineInsideNewMachine(template, template, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": `+
+		`neither container "0/lxd/0" hosting unit "mysql/0" nor its host machine "0" has an address in space "db"`)
+	bindings, err := s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "")
+
+	// The container is bridged into the space once its host machine
+	// has an address in it.
+	host, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.setMachineAddresses(c, host, "10.10.0.5/24")
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err = s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsCrossModelRelation(c *gc.C) {
+	s.addSpaceWithSubnet(c, "db", "10.10.0.0/24")
+	unit := s.addUnitWithAddresses(c, "10.0.0.5/24", "10.10.0.5/24")
+	remote, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "remote-wordpress",
+		SourceModel: names.NewModelTag("source-model"),
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Limit:     1,
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	remoteEP, err := remote.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlEP, err := s.mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(remoteEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"private-address": "10.0.0.5"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The address given to the remote model follows the binding.
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{
+		"private-address": "10.10.0.5",
+	})
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsUpdatesRelationAddresses(c *gc.C) {
+	s.addSpaceWithSubnet(c, "db", "10.10.0.0/24")
+	unit := s.addUnitWithAddresses(c, "10.0.0.5/24", "10.10.0.5/24")
+
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"private-address": "10.0.0.5"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{
+		"private-address": "10.10.0.5",
+	})
+}
+
 func (s *ApplicationSuite) TestSetCharmExtraBindingsUseDefaults(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
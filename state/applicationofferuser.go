@@ -42,6 +42,9 @@ func (st *State) CreateOfferAccess(offer names.ApplicationOfferTag, user names.U
 			return errors.Trace(err)
 		}
 	}
+	if err := st.checkGroupExists(user); err != nil {
+		return errors.Trace(err)
+	}
 
 	offerUUID, err := applicationOfferUUID(st, offer.Name)
 	if err != nil {
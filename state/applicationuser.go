@@ -0,0 +1,256 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// applicationAccessKey returns the key used as the object of
+// permissions granted on the named application in a model.
+func applicationAccessKey(modelUUID, appName string) string {
+	return fmt.Sprintf("%s#%s", modelKey(modelUUID), applicationGlobalKey(appName))
+}
+
+// ApplicationAccess describes the access a user has been granted on
+// an application.
+type ApplicationAccess struct {
+	ModelUUID   string
+	Application names.ApplicationTag
+	Access      permission.Access
+}
+
+// GetApplicationAccess gets the access permission for the specified
+// user on an application in the model.
+func (st *State) GetApplicationAccess(app names.ApplicationTag, user names.UserTag) (permission.Access, error) {
+	perm, err := st.userPermission(applicationAccessKey(st.ModelUUID(), app.Id()), userGlobalKey(userAccessID(user)))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// CreateApplicationAccess grants a user access to an application in
+// the model. The user must already have access to the model.
+func (st *State) CreateApplicationAccess(app names.ApplicationTag, user names.UserTag, access permission.Access) error {
+	if err := permission.ValidateApplicationAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.modelUser(st.ModelUUID(), user); err != nil {
+				return nil, errors.Trace(err)
+			}
+			application, err := st.Application(app.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if application.Life() != Alive {
+				return nil, errors.Errorf("application %q is not alive", app.Id())
+			}
+			return nil, errors.AlreadyExistsf("permission for user %q for application %q", user.Id(), app.Id())
+		}
+		return []txn.Op{{
+			C:      modelUsersC,
+			Id:     userAccessID(user),
+			Assert: txn.DocExists,
+		}, {
+			C:      applicationsC,
+			Id:     app.Id(),
+			Assert: isAliveDoc,
+		},
+			createPermissionOp(applicationAccessKey(st.ModelUUID(), app.Id()), userGlobalKey(userAccessID(user)), access),
+		}, nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
+
+// UpdateApplicationAccess changes the user's access permissions on an
+// application in the model.
+func (st *State) UpdateApplicationAccess(app names.ApplicationTag, user names.UserTag, access permission.Access) error {
+	if err := permission.ValidateApplicationAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	op := updatePermissionOp(applicationAccessKey(st.ModelUUID(), app.Id()), userGlobalKey(userAccessID(user)), access)
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("existing permissions")
+	}
+	return errors.Trace(err)
+}
+
+// RemoveApplicationAccess removes the access permission for a user on
+// an application in the model.
+func (st *State) RemoveApplicationAccess(app names.ApplicationTag, user names.UserTag) error {
+	op := removePermissionOp(applicationAccessKey(st.ModelUUID(), app.Id()), userGlobalKey(userAccessID(user)))
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NewNotFound(nil, fmt.Sprintf("application user %q does not exist", user.Id()))
+	}
+	return errors.Trace(err)
+}
+
+// UserApplicationAccess returns the access the user has been granted
+// on applications in the model.
+func (st *State) UserApplicationAccess(user names.UserTag) ([]ApplicationAccess, error) {
+	return st.userApplicationAccess(user, regexp.QuoteMeta(modelKey(st.ModelUUID())))
+}
+
+// AllUserApplicationAccess returns the access the user has been
+// granted on applications in every model in the controller.
+func (st *State) AllUserApplicationAccess(user names.UserTag) ([]ApplicationAccess, error) {
+	return st.userApplicationAccess(user, regexp.QuoteMeta(modelGlobalKey+"#")+"[^#]+")
+}
+
+func (st *State) userApplicationAccess(user names.UserTag, modelPattern string) ([]ApplicationAccess, error) {
+	permissions, closer := st.db().GetCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	query := bson.D{
+		{"subject-global-key", userGlobalKey(userAccessID(user))},
+		{"object-global-key", bson.RegEx{Pattern: "^" + modelPattern + "#a#"}},
+	}
+	if err := permissions.Find(query).Sort("object-global-key").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get application access for user %q", user.Id())
+	}
+	result := make([]ApplicationAccess, len(docs))
+	for i, doc := range docs {
+		// The object key has the form e#<model-uuid>#a#<application>.
+		parts := strings.SplitN(doc.ObjectGlobalKey, "#", 4)
+		if len(parts) != 4 {
+			return nil, errors.Errorf("unexpected application permission key %q", doc.ObjectGlobalKey)
+		}
+		result[i] = ApplicationAccess{
+			ModelUUID:   parts[1],
+			Application: names.NewApplicationTag(parts[3]),
+			Access:      stringToAccess(doc.Access),
+		}
+	}
+	return result, nil
+}
+
+// ApplicationGrant describes the access granted to a user on an
+// application in the model.
+type ApplicationGrant struct {
+	Application string
+	User        names.UserTag
+	Access      permission.Access
+}
+
+// AllApplicationGrants returns the access granted to users on the
+// applications in the model, so that it may be migrated with the
+// model.
+func (st *State) AllApplicationGrants() ([]ApplicationGrant, error) {
+	permissions, closer := st.db().GetCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	prefix := applicationAccessKey(st.ModelUUID(), "")
+	query := bson.D{{"object-global-key", bson.RegEx{Pattern: "^" + regexp.QuoteMeta(prefix)}}}
+	if err := permissions.Find(query).Sort("object-global-key", "subject-global-key").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get application access")
+	}
+	userPrefix := userGlobalKey("")
+	result := make([]ApplicationGrant, len(docs))
+	for i, doc := range docs {
+		result[i] = ApplicationGrant{
+			Application: strings.TrimPrefix(doc.ObjectGlobalKey, prefix),
+			User:        names.NewUserTag(strings.TrimPrefix(doc.SubjectGlobalKey, userPrefix)),
+			Access:      stringToAccess(doc.Access),
+		}
+	}
+	return result, nil
+}
+
+// ImportApplicationGrants grants users access to the applications of
+// a model being imported by a migration. The users must already have
+// access to the model.
+func (st *State) ImportApplicationGrants(grants []ApplicationGrant) error {
+	var ops []txn.Op
+	users := set.NewStrings()
+	apps := set.NewStrings()
+	for _, grant := range grants {
+		if err := permission.ValidateApplicationAccess(grant.Access); err != nil {
+			return errors.Trace(err)
+		}
+		if userID := userAccessID(grant.User); !users.Contains(userID) {
+			users.Add(userID)
+			ops = append(ops, txn.Op{
+				C:      modelUsersC,
+				Id:     userID,
+				Assert: txn.DocExists,
+			})
+		}
+		if !apps.Contains(grant.Application) {
+			apps.Add(grant.Application)
+			ops = append(ops, txn.Op{
+				C:      applicationsC,
+				Id:     grant.Application,
+				Assert: txn.DocExists,
+			})
+		}
+		ops = append(ops, createPermissionOp(
+			applicationAccessKey(st.ModelUUID(), grant.Application),
+			userGlobalKey(userAccessID(grant.User)),
+			grant.Access,
+		))
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.New("application grants refer to missing users, applications or existing grants")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// removeApplicationAccessOps returns the operations required to remove
+// all permissions granted on the named application.
+func (st *State) removeApplicationAccessOps(appName string) ([]txn.Op, error) {
+	permissions, closer := st.db().GetCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	query := bson.D{{"object-global-key", applicationAccessKey(st.ModelUUID(), appName)}}
+	if err := permissions.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get access for application %q", appName)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      permissionsC,
+			Id:     doc.ID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// removeApplicationAccessForUserOps returns the operations required to
+// remove all of the user's permissions on applications in the model.
+func (st *State) removeApplicationAccessForUserOps(user names.UserTag) ([]txn.Op, error) {
+	access, err := st.UserApplicationAccess(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(access))
+	for i, a := range access {
+		ops[i] = txn.Op{
+			C:      permissionsC,
+			Id:     permissionID(applicationAccessKey(a.ModelUUID, a.Application.Id()), userGlobalKey(userAccessID(user))),
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ApplicationUserSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ApplicationUserSuite{})
+
+func (s *ApplicationUserSuite) makeAccess(c *gc.C, access permission.Access) (*state.Application, names.UserTag) {
+	app := s.Factory.MakeApplication(c, nil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername"})
+
+	// Initially no access.
+	_, err := s.State.GetApplicationAccess(app.ApplicationTag(), user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.CreateApplicationAccess(app.ApplicationTag(), user.UserTag(), access)
+	c.Assert(err, jc.ErrorIsNil)
+	return app, user.UserTag()
+}
+
+func (s *ApplicationUserSuite) TestCreateApplicationAccess(c *gc.C) {
+	app, user := s.makeAccess(c, permission.WriteAccess)
+
+	access, err := s.State.GetApplicationAccess(app.ApplicationTag(), user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	access, err = s.State.UserPermission(user, app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *ApplicationUserSuite) TestCreateApplicationAccessInvalid(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername"})
+	err := s.State.CreateApplicationAccess(app.ApplicationTag(), user.UserTag(), permission.ConsumeAccess)
+	c.Assert(err, gc.ErrorMatches, `"consume" application access not valid`)
+}
+
+func (s *ApplicationUserSuite) TestCreateApplicationAccessAlreadyExists(c *gc.C) {
+	app, user := s.makeAccess(c, permission.ReadAccess)
+	err := s.State.CreateApplicationAccess(app.ApplicationTag(), user, permission.WriteAccess)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ApplicationUserSuite) TestCreateApplicationAccessRequiresModelUser(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	err := s.State.CreateApplicationAccess(app.ApplicationTag(), user.UserTag(), permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `model user "validusername" not found`)
+}
+
+func (s *ApplicationUserSuite) TestCreateApplicationAccessMissingApplication(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername"})
+	err := s.State.CreateApplicationAccess(names.NewApplicationTag("foo"), user.UserTag(), permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `application "foo" not found`)
+}
+
+func (s *ApplicationUserSuite) TestUpdateApplicationAccess(c *gc.C) {
+	app, user := s.makeAccess(c, permission.ReadAccess)
+	err := s.State.UpdateApplicationAccess(app.ApplicationTag(), user, permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.GetApplicationAccess(app.ApplicationTag(), user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *ApplicationUserSuite) TestRemoveApplicationAccess(c *gc.C) {
+	app, user := s.makeAccess(c, permission.WriteAccess)
+	err := s.State.RemoveApplicationAccess(app.ApplicationTag(), user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.GetApplicationAccess(app.ApplicationTag(), user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveApplicationAccess(app.ApplicationTag(), user)
+	c.Assert(err, gc.ErrorMatches, `application user "validusername" does not exist`)
+}
+
+func (s *ApplicationUserSuite) TestUserApplicationAccess(c *gc.C) {
+	app, user := s.makeAccess(c, permission.WriteAccess)
+
+	access, err := s.State.UserApplicationAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, jc.DeepEquals, []state.ApplicationAccess{{
+		ModelUUID:   s.State.ModelUUID(),
+		Application: app.ApplicationTag(),
+		Access:      permission.WriteAccess,
+	}})
+
+	all, err := s.State.AllUserApplicationAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, access)
+}
+
+func (s *ApplicationUserSuite) TestRemoveApplicationRemovesAccess(c *gc.C) {
+	app, user := s.makeAccess(c, permission.WriteAccess)
+	err := app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserApplicationAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.HasLen, 0)
+}
+
+func (s *ApplicationUserSuite) TestRemoveModelUserRemovesAccess(c *gc.C) {
+	_, user := s.makeAccess(c, permission.WriteAccess)
+	err := s.State.RemoveUserAccess(user, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserApplicationAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.HasLen, 0)
+}
+
+func (s *ApplicationUserSuite) TestAllApplicationGrants(c *gc.C) {
+	app, user := s.makeAccess(c, permission.WriteAccess)
+	grants, err := s.State.AllApplicationGrants()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.ApplicationGrant{{
+		Application: app.Name(),
+		User:        user,
+		Access:      permission.WriteAccess,
+	}})
+}
+
+func (s *ApplicationUserSuite) TestImportApplicationGrants(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername"})
+	err := s.State.ImportApplicationGrants([]state.ApplicationGrant{{
+		Application: app.Name(),
+		User:        user.UserTag(),
+		Access:      permission.ReadAccess,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.GetApplicationAccess(app.ApplicationTag(), user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+}
+
+func (s *ApplicationUserSuite) TestImportApplicationGrantsMissingApplication(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername"})
+	err := s.State.ImportApplicationGrants([]state.ApplicationGrant{{
+		Application: "missing",
+		User:        user.UserTag(),
+		Access:      permission.ReadAccess,
+	}})
+	c.Assert(err, gc.ErrorMatches, "application grants refer to missing users, applications or existing grants")
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/txn"
 	"github.com/juju/utils/arch"
@@ -291,6 +292,241 @@ func (s *AssignSuite) TestAssignMachineDifferentSeries(c *gc.C) {
 		`cannot assign unit "wordpress/0" to machine 0: series does not match`)
 }
 
+// setUpSpaces adds the "db" and "public" spaces, each with a subnet,
+// and binds the wordpress "db" endpoint to the "db" space.
+func (s *AssignSuite) setUpSpaces(c *gc.C) {
+	for space, cidr := range map[string]string{
+		"db":     "10.10.0.0/24",
+		"public": "10.0.0.0/24",
+	} {
+		_, err := s.State.AddSpace(space, "", nil, false)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = s.State.AddSubnet(state.SubnetInfo{
+			CIDR:      cidr,
+			SpaceName: space,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.wordpress.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// addMachineWithAddresses adds a clean machine with one device for
+// each of the given CIDR addresses.
+func (s *AssignSuite) addMachineWithAddresses(c *gc.C, cidrAddresses ...string) *state.Machine {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	for i, cidrAddress := range cidrAddresses {
+		deviceName := fmt.Sprintf("eth%d", i)
+		err := machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+			Name: deviceName,
+			Type: state.EthernetDevice,
+			IsUp: true,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+			DeviceName:   deviceName,
+			CIDRAddress:  cidrAddress,
+			ConfigMethod: state.StaticAddress,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return machine
+}
+
+func (s *AssignSuite) TestAssignMachineNotInBindingSpace(c *gc.C) {
+	s.setUpSpaces(c)
+	machine := s.addMachineWithAddresses(c, "10.0.0.5/24")
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot assign unit "wordpress/0" to machine 0: machine "0" has no address in space\(s\) "db"`)
+	_, err = unit.AssignedMachineId()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+}
+
+func (s *AssignSuite) TestAssignMachineNotInConstraintSpace(c *gc.C) {
+	s.setUpSpaces(c)
+	err := s.wordpress.SetConstraints(constraints.MustParse("spaces=public"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.addMachineWithAddresses(c, "10.10.0.5/24")
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot assign unit "wordpress/0" to machine 0: machine "0" has no address in space\(s\) "public"`)
+}
+
+func (s *AssignSuite) TestAssignMachineInSpaces(c *gc.C) {
+	s.setUpSpaces(c)
+	machine := s.addMachineWithAddresses(c, "10.0.0.5/24", "10.10.0.5/24")
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AssignSuite) TestAssignMachineWithoutAddressesIgnoresSpaces(c *gc.C) {
+	s.setUpSpaces(c)
+	machine := s.addMachineWithAddresses(c)
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AssignSuite) TestAssignUnitCleanSkipsMachinesNotInSpaces(c *gc.C) {
+	s.setUpSpaces(c)
+	s.addMachineWithAddresses(c, "10.0.0.5/24")
+	machine := s.addMachineWithAddresses(c, "10.10.0.6/24")
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AssignUnit(unit, state.AssignClean)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, machine.Id())
+}
+
+func (s *AssignSuite) TestAssignUnitCleanNoMachineInSpaces(c *gc.C) {
+	s.setUpSpaces(c)
+	s.addMachineWithAddresses(c, "10.0.0.5/24")
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// With no eligible clean machine, a new one is added.
+	err = s.State.AssignUnit(unit, state.AssignClean)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, "1")
+}
+
+func (s *AssignSuite) TestAssignUnitWithPlacementContainerHostNotInSpaces(c *gc.C) {
+	s.setUpSpaces(c)
+	host := s.addMachineWithAddresses(c, "10.0.0.5/24")
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AssignUnitWithPlacement(unit, &instance.Placement{
+		Scope:     string(instance.LXD),
+		Directive: host.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot place unit "wordpress/0" in a container: machine "0" has no address in space\(s\) "db"`)
+	containers, err := host.Containers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containers, gc.HasLen, 0)
+}
+
+func (s *AssignSuite) TestAssignUnitWithPlacementContainerHostInSpaces(c *gc.C) {
+	s.setUpSpaces(c)
+	host := s.addMachineWithAddresses(c, "10.10.0.5/24")
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AssignUnitWithPlacement(unit, &instance.Placement{
+		Scope:     string(instance.LXD),
+		Directive: host.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, "0/lxd/0")
+}
+
+func (s *AssignSuite) TestAssignAntiAffinity(c *gc.C) {
+	err := s.wordpress.SetAntiAffinity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit0, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit0.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit1, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit1.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/1" to machine 0: application "wordpress" has anti-affinity and unit "wordpress/0" is already on host machine 0`)
+	c.Assert(err, jc.Satisfies, state.IsAntiAffinityError)
+
+	// Units of other applications may still share the host.
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without anti-affinity, units may share a host again.
+	err = s.wordpress.SetAntiAffinity(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit1.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AssignSuite) TestAssignAntiAffinityContainer(c *gc.C) {
+	err := s.wordpress.SetAntiAffinity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit0, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnitWithPlacement(unit0, &instance.Placement{
+		Scope:     string(instance.LXD),
+		Directive: host.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A unit directly on the host would share it with the container.
+	unit1, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit1.AssignToMachine(host)
+	c.Assert(err, jc.Satisfies, state.IsAntiAffinityError)
+
+	// So would a unit in another container on the host, which
+	// is not created.
+	err = s.State.AssignUnitWithPlacement(unit1, &instance.Placement{
+		Scope:     string(instance.LXD),
+		Directive: host.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot place unit "wordpress/1" in a container: application "wordpress" has anti-affinity and unit "wordpress/0" is already on host machine 0`)
+	containers, err := host.Containers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containers, gc.HasLen, 1)
+}
+
+func (s *AssignSuite) TestAssignUnitCleanSkipsAntiAffinityHosts(c *gc.C) {
+	err := s.wordpress.SetAntiAffinity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit0, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit0.AssignToMachine(host)
+	c.Assert(err, jc.ErrorIsNil)
+	// A clean container on the host is not used.
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit1, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit1, state.AssignClean)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit1.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.TopParentId(machineId), gc.Not(gc.Equals), host.Id())
+}
+
 func (s *AssignSuite) TestPrincipals(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -189,6 +189,10 @@ func (st *State) cleanupMachinesForDyingModel() (err error) {
 	// This won't miss machines, because a Dying model cannot have
 	// machines added to it. But we do have to remove the machines themselves
 	// via individual transactions, because they could be in any state at all.
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	machines, err := st.AllMachines()
 	if err != nil {
 		return errors.Trace(err)
@@ -204,6 +208,14 @@ func (st *State) cleanupMachinesForDyingModel() (err error) {
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if model.KeepInstances() && !m.KeepInstance() && m.Life() != Dead {
+			// The model is being destroyed without stopping
+			// its instances, so the provisioner must release
+			// the machine without stopping its instance.
+			if err := m.SetKeepInstance(true); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		destroy := m.ForceDestroy
 		if manual {
 			// Manually added machines should never be force-
@@ -213,6 +213,24 @@ func (s *CleanupSuite) TestCleanupModelMachines(c *gc.C) {
 	assertLife(c, stateMachine, state.Alive)
 }
 
+func (s *CleanupSuite) TestCleanupModelMachinesKeepingInstances(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDoesNotNeedCleanup(c)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.DestroyKeepingInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNeedsCleanup(c)
+	s.assertCleanupRuns(c)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Life(), gc.Not(gc.Equals), state.Alive)
+	c.Assert(machine.KeepInstance(), jc.IsTrue)
+}
+
 func (s *CleanupSuite) TestCleanupModelApplications(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 
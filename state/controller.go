@@ -33,3 +33,41 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// UpdateControllerConfig allows changing some of the configuration
+// for the controller. Changes passed in updateAttrs will be applied
+// to the current config, and keys in removeAttrs will be unset (and
+// so revert to their defaults). Only a subset of keys can be changed
+// after bootstrapping, see controller.AllowedUpdateConfigAttributes.
+func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	if err := checkUpdateControllerConfig(updateAttrs, removeAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := readSettings(st, controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return errors.Annotate(err, "controller config")
+	}
+	for _, r := range removeAttrs {
+		settings.Delete(r)
+	}
+	settings.Update(updateAttrs)
+	if err := jujucontroller.Validate(settings.Map()); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = settings.Write()
+	return errors.Annotate(err, "cannot update controller config")
+}
+
+func checkUpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	for k := range updateAttrs {
+		if !jujucontroller.AllowedUpdateConfigAttributes.Contains(k) {
+			return errors.Errorf("can't change %q after bootstrap", k)
+		}
+	}
+	for _, r := range removeAttrs {
+		if !jujucontroller.AllowedUpdateConfigAttributes.Contains(r) {
+			return errors.Errorf("can't change %q after bootstrap", r)
+		}
+	}
+	return nil
+}
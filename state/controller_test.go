@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type ControllerConfigSuite struct {
@@ -26,10 +27,16 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 	optional := map[string]bool{
 		controller.IdentityURL:         true,
 		controller.IdentityPublicKey:   true,
+		controller.OIDCIssuerURL:       true,
+		controller.OIDCClientID:        true,
+		controller.OIDCKeySet:          true,
+		controller.OIDCUsernameClaim:   true,
+		controller.OIDCGroupsClaim:     true,
 		controller.AutocertURLKey:      true,
 		controller.AutocertDNSNameKey:  true,
 		controller.AllowModelAccessKey: true,
 		controller.MongoMemoryProfile:  true,
+		controller.APIAllowKey:         true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -47,3 +54,45 @@ func (s *ControllerConfigSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg["controller-uuid"], gc.Equals, m.ControllerUUID())
 }
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfig(c *gc.C) {
+	w := s.State.WatchControllerConfig()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIAllowKey: "10.0.0.0/8",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.APIAllowKey})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigRejectsImmutable(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIPort: 1234,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.CACertKey})
+	c.Assert(err, gc.ErrorMatches, `can't change "ca-cert" after bootstrap`)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigInvalid(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIAllowKey: "not-a-cidr",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid api-allow in configuration: invalid CIDR address: not-a-cidr`)
+}
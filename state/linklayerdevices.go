@@ -181,6 +181,14 @@ func (dev *LinkLayerDevice) ParentName() string {
 	return dev.doc.ParentName
 }
 
+// ParentNameAndMachineID returns the name of this device's parent device and
+// the ID of the machine the parent device is on, or empty strings when no
+// parent device is set. Unlike ParentName(), the returned name is never a
+// global key.
+func (dev *LinkLayerDevice) ParentNameAndMachineID() (string, string) {
+	return dev.parentDeviceNameAndMachineID()
+}
+
 func (dev *LinkLayerDevice) parentDeviceNameAndMachineID() (string, string) {
 	if dev.doc.ParentName == "" {
 		// No parent set, so no ID and name to return.
@@ -1155,6 +1155,61 @@ func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesRefusesToAddContaine
 	s.assertNoDevicesOnMachine(c, s.containerMachine)
 }
 
+func (s *linkLayerDevicesStateSuite) setMacvlanContainerNetworking(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"container-networking-method": "macvlan",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesRefusesToAddContainerChildDeviceWithLoopbackParentWithMacvlan(c *gc.C) {
+	s.setMacvlanContainerNetworking(c)
+	s.setMultipleDevicesSucceedsAndCheckAllAdded(c, []state.LinkLayerDeviceArgs{{
+		Name: "loopback",
+		Type: state.LoopbackDevice,
+	}})
+	s.addContainerMachine(c)
+
+	containerDeviceArgs := state.LinkLayerDeviceArgs{
+		Name:       "eth0",
+		Type:       state.EthernetDevice,
+		ParentName: "m#0#d#loopback",
+	}
+	err := s.containerMachine.SetLinkLayerDevices(containerDeviceArgs)
+	expectedError := `cannot set .* to machine "0/lxd/0": ` +
+		`invalid device "eth0": ` +
+		`parent device "loopback" on host machine "0" cannot be of type "loopback" with macvlan container networking`
+	c.Check(err, gc.ErrorMatches, expectedError)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	s.assertNoDevicesOnMachine(c, s.containerMachine)
+}
+
+func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesAllowsContainerChildDeviceWithNonBridgeParentWithMacvlan(c *gc.C) {
+	// Macvlan container networking attaches container devices directly
+	// to host devices other than bridges.
+	s.setMacvlanContainerNetworking(c)
+	hostDevicesArgs := []state.LinkLayerDeviceArgs{{
+		Name: "ethernet",
+		Type: state.EthernetDevice,
+	}, {
+		Name: "vlan",
+		Type: state.VLAN_8021QDevice,
+	}, {
+		Name: "bond",
+		Type: state.BondDevice,
+	}}
+	hostDevices := s.setMultipleDevicesSucceedsAndCheckAllAdded(c, hostDevicesArgs)
+	s.addContainerMachine(c)
+
+	for i, hostDevice := range hostDevices {
+		containerDeviceArgs, err := state.DefineEthernetDeviceOnParent(fmt.Sprintf("eth%d", i), hostDevice)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.containerMachine.SetLinkLayerDevices(containerDeviceArgs)
+		c.Check(err, jc.ErrorIsNil)
+	}
+	s.assertAllLinkLayerDevicesOnMachineMatchCount(c, s.containerMachine, len(hostDevices))
+}
+
 func (s *linkLayerDevicesStateSuite) addContainerMachine(c *gc.C) {
 	// Add a container machine with s.machine as its host.
 	containerTemplate := state.MachineTemplate{
@@ -1179,6 +1234,9 @@ func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesAllowsParentBridgeDe
 	c.Check(childDevice.Name(), gc.Equals, "eth0")
 	c.Check(childDevice.ParentName(), gc.Equals, "m#0#d#br-eth1.250")
 	c.Check(childDevice.MachineID(), gc.Equals, s.containerMachine.Id())
+	parentName, parentMachineID := childDevice.ParentNameAndMachineID()
+	c.Check(parentName, gc.Equals, "br-eth1.250")
+	c.Check(parentMachineID, gc.Equals, s.machine.Id())
 	parentOfChildDevice, err := childDevice.ParentDevice()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(parentOfChildDevice, jc.DeepEquals, parentDevice)
@@ -116,6 +116,14 @@ type machineDoc struct {
 	PasswordHash  string
 	Clean         bool
 
+	// Maintenance is true while the machine is in maintenance, during
+	// which no units or containers may be placed on it.
+	Maintenance bool `bson:"maintenance,omitempty"`
+
+	// KeepInstance is true if the machine's cloud instance should be
+	// left running when the machine is removed from the model.
+	KeepInstance bool `bson:"keep-instance,omitempty"`
+
 	// Volumes contains the names of volumes attached to the machine.
 	Volumes []string `bson:"volumes,omitempty"`
 	// Filesystems contains the names of filesystems attached to the machine.
@@ -306,6 +314,190 @@ func (m *Machine) SetHasVote(hasVote bool) error {
 	return nil
 }
 
+// KeepInstance returns whether the machine's cloud instance should be
+// left running when the machine is removed from the model.
+func (m *Machine) KeepInstance() bool {
+	return m.doc.KeepInstance
+}
+
+// SetKeepInstance sets whether the machine's cloud instance should be
+// left running when the machine is removed from the model. The
+// provisioner releases a machine with keep-instance set without
+// stopping its instance.
+func (m *Machine) SetKeepInstance(keepInstance bool) error {
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"keep-instance", keepInstance}}}},
+	}}
+	if err := m.st.runTransaction(ops); err != nil {
+		return fmt.Errorf("cannot set keep-instance of machine %v: %v", m, onAbort(err, ErrDead))
+	}
+	m.doc.KeepInstance = keepInstance
+	return nil
+}
+
+// InMaintenance returns whether the machine is in maintenance.
+func (m *Machine) InMaintenance() bool {
+	return m.doc.Maintenance
+}
+
+// SetMaintenance puts the machine into maintenance, or takes it out
+// again. No units or containers may be placed on a machine while it is
+// in maintenance; those already there are unaffected.
+func (m *Machine) SetMaintenance(maintenance bool) error {
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"maintenance", maintenance}}}},
+	}}
+	if err := m.st.runTransaction(ops); err != nil {
+		return fmt.Errorf("cannot set maintenance of machine %v: %v", m, onAbort(err, ErrDead))
+	}
+	m.doc.Maintenance = maintenance
+	return nil
+}
+
+// inMaintenanceError is returned when a unit or container cannot be
+// placed on a machine because the machine is in maintenance.
+type inMaintenanceError struct {
+	machineId string
+}
+
+func (e *inMaintenanceError) Error() string {
+	return fmt.Sprintf("machine %q is in maintenance", e.machineId)
+}
+
+// isInMaintenance reports whether the cause of err is an
+// inMaintenanceError.
+func isInMaintenance(err error) bool {
+	_, ok := errors.Cause(err).(*inMaintenanceError)
+	return ok
+}
+
+// checkNotInMaintenance returns an inMaintenanceError if the machine is
+// in maintenance.
+func checkNotInMaintenance(m *Machine) error {
+	if m.doc.Maintenance {
+		return &inMaintenanceError{m.Id()}
+	}
+	return nil
+}
+
+// notInMaintenanceDoc asserts that a machine is not in maintenance.
+var notInMaintenanceDoc = bson.D{{"maintenance", bson.D{{"$ne", true}}}}
+
+// EvacuatedUnit records the replacement of a unit evacuated from a
+// machine in maintenance.
+type EvacuatedUnit struct {
+	// Unit is the name of the evacuated unit, which is being destroyed.
+	Unit string
+
+	// Replacement is the name of the unit added in its place.
+	Replacement string
+}
+
+// EvacuateUnits replaces the stateless principal units on a machine in
+// maintenance, and in the containers it hosts, with new units of the
+// same applications on other machines, and destroys the originals.
+// Units with storage attached are considered stateful, and are left in
+// place. It returns the units that were replaced, and the names of
+// those left in place.
+func (m *Machine) EvacuateUnits() (evacuated []EvacuatedUnit, kept []string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot evacuate units from machine %v", m)
+	if !m.doc.Maintenance {
+		return nil, nil, errors.Errorf("machine is not in maintenance")
+	}
+	principals, err := m.principalsIncludingContainers()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for _, unitName := range principals {
+		unit, err := m.st.Unit(unitName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return evacuated, kept, errors.Trace(err)
+		}
+		if unit.Life() != Alive {
+			continue
+		}
+		attachments, err := m.st.UnitStorageAttachments(unit.UnitTag())
+		if err != nil {
+			return evacuated, kept, errors.Trace(err)
+		}
+		if len(attachments) > 0 {
+			kept = append(kept, unitName)
+			continue
+		}
+		replacement, err := replaceUnit(unit)
+		if err != nil {
+			return evacuated, kept, errors.Annotatef(err, "replacing unit %q", unitName)
+		}
+		evacuated = append(evacuated, EvacuatedUnit{
+			Unit:        unitName,
+			Replacement: replacement.Name(),
+		})
+	}
+	return evacuated, kept, nil
+}
+
+// principalsIncludingContainers returns the names of the principal
+// units deployed to the machine and to the containers nested within
+// it.
+func (m *Machine) principalsIncludingContainers() ([]string, error) {
+	principals := append([]string(nil), m.doc.Principals...)
+	containerIds, err := m.Containers()
+	if errors.IsNotFound(err) {
+		return principals, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for len(containerIds) > 0 {
+		container, err := m.st.Machine(containerIds[0])
+		containerIds = containerIds[1:]
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		principals = append(principals, container.doc.Principals...)
+		nested, err := container.Containers()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		containerIds = append(containerIds, nested...)
+	}
+	return principals, nil
+}
+
+// replaceUnit adds a unit of the given unit's application, assigns it
+// to a machine as deploy would, and then destroys the given unit.
+func replaceUnit(unit *Unit) (*Unit, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	replacement, err := app.AddUnit()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := unit.st.AssignUnit(replacement, AssignCleanEmpty); err != nil {
+		if destroyErr := replacement.Destroy(); destroyErr != nil {
+			logger.Errorf("cannot destroy unassigned unit %q: %v", replacement, destroyErr)
+		}
+		return nil, errors.Trace(err)
+	}
+	if err := unit.Destroy(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return replacement, nil
+}
+
 // SetStopMongoUntilVersion sets a version that is to be checked against
 // the agent config before deciding if mongo must be started on a
 // state server.
@@ -454,10 +646,14 @@ func (m *Machine) PasswordValid(password string) bool {
 
 // Destroy sets the machine lifecycle to Dying if it is Alive. It does
 // nothing otherwise. Destroy will fail if the machine has principal
-// units assigned, or if the machine has JobManageModel.
+// units assigned, if the machine has JobManageModel, or if the
+// machine is locked for a series upgrade.
 // If the machine has assigned units, Destroy will return
 // a HasAssignedUnitsError.
 func (m *Machine) Destroy() error {
+	if err := checkNotLockedForSeriesUpgrade(m); err != nil {
+		return errors.Trace(err)
+	}
 	return m.advanceLifecycle(Dying)
 }
 
@@ -844,6 +1040,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeConstraintsOp(m.st, m.globalKey()),
 		annotationRemoveOp(m.st, m.globalKey()),
 		removeRebootDocOp(m.st, m.globalKey()),
+		removeUpgradeSeriesLockOp(m.st, m.Id()),
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.st, m.globalKey()),
@@ -1192,6 +1389,53 @@ func (m *Machine) SetProvisioned(id instance.Id, nonce string, characteristics *
 	return fmt.Errorf("already set")
 }
 
+// ReplaceInstance forgets the machine's cloud instance, after the cloud
+// terminated it, so that the provisioner starts a new instance for the
+// machine and its units. The instance with the given id must still be
+// the machine's instance. The instance status is set to a transient
+// provisioning error, which the provisioner retries; the status data
+// records the terminated instance's id, so that the provisioner can
+// stop what remains of it.
+func (m *Machine) ReplaceInstance(id instance.Id) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replace instance of machine %q", m)
+
+	ops := []txn.Op{
+		{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"nonce", ""}}}},
+		}, {
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"instanceid", id}},
+			Remove: true,
+		},
+	}
+	if err := m.st.runTransaction(ops); err == txn.ErrAborted {
+		if alive, err := isAlive(m.st, machinesC, m.doc.DocID); err != nil {
+			return err
+		} else if !alive {
+			return errNotAlive
+		}
+		return errors.Errorf("instance %q is not the machine's instance", id)
+	} else if err != nil {
+		return err
+	}
+	m.doc.Nonce = ""
+
+	now := m.st.clock.Now()
+	return m.SetInstanceStatus(status.StatusInfo{
+		Status:  status.ProvisioningError,
+		Message: fmt.Sprintf("instance %q was terminated, starting a replacement", id),
+		Data: map[string]interface{}{
+			"transient":   true,
+			"instance-id": string(id),
+		},
+		Since: &now,
+	})
+}
+
 // SetInstanceInfo is used to provision a machine and in one steps set it's
 // instance id, nonce, hardware characteristics, add link-layer devices and set
 // their addresses as needed.
@@ -1297,6 +1541,10 @@ func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []addr
 	if Origin(addr.Origin) != OriginProvider && Origin(newAddr.Origin) == OriginProvider {
 		return newAddr, true
 	}
+	if addr.Origin == newAddr.Origin && addr.Scope == newAddr.Scope && isIPVersionChange(addr, newAddr) {
+		// The model's preferred IP version has changed.
+		return newAddr, true
+	}
 	if !checkScope(addr) {
 		// If addr.Origin is machine and newAddr.Origin is provider we will
 		// have already caught that, and for the inverse we don't want to
@@ -1308,6 +1556,16 @@ func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []addr
 	return addr, false
 }
 
+// isIPVersionChange reports whether the addresses are IP addresses
+// of different versions.
+func isIPVersionChange(addr, newAddr address) bool {
+	isIP := func(addr address) bool {
+		addrType := network.AddressType(addr.AddressType)
+		return addrType == network.IPv4Address || addrType == network.IPv6Address
+	}
+	return isIP(addr) && isIP(newAddr) && addr.AddressType != newAddr.AddressType
+}
+
 // PrivateAddress returns a private address for the machine. If no address is
 // available it returns an error that satisfies network.IsNoAddressError().
 func (m *Machine) PrivateAddress() (network.Address, error) {
@@ -1361,7 +1619,7 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPublicAddressOps(selector network.AddressSelector, providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef("machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v", m.Id(), publicAddress, providerAddresses, machineAddresses)
 	// Always prefer an exact match if available.
@@ -1370,7 +1628,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := selector.SelectPublicAddress(networkAddresses(addresses))
 		return addr
 	}
 
@@ -1384,7 +1642,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, newAddr, true
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPrivateAddressOps(selector network.AddressSelector, providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1392,7 +1650,7 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := selector.SelectInternalAddress(networkAddresses(addresses), false)
 		return addr
 	}
 
@@ -1456,11 +1714,15 @@ func (m *Machine) SetMachineAddresses(addresses ...network.Address) (err error)
 // only predicated on the machine not being Dead; concurrent address
 // changes are ignored.
 func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fieldName string) error {
+	selector, err := m.st.addressSelector()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	addressesToSet := make([]network.Address, len(addresses))
 	copy(addressesToSet, addresses)
 
 	// Update addresses now.
-	network.SortAddresses(addressesToSet)
+	selector.SortAddresses(addressesToSet)
 	origin := OriginProvider
 	if fieldName == "machineaddresses" {
 		origin = OriginMachine
@@ -1470,7 +1732,6 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 	var (
 		newPrivate, newPublic         address
 		changedPrivate, changedPublic bool
-		err                           error
 	)
 	machine := m
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1500,8 +1761,8 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 		}
 
 		var setPrivateAddressOps, setPublicAddressOps []txn.Op
-		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses)
-		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses)
+		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(selector, providerAddresses, machineAddresses)
+		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(selector, providerAddresses, machineAddresses)
 		ops = append(ops, setPrivateAddressOps...)
 		ops = append(ops, setPublicAddressOps...)
 		return ops, nil
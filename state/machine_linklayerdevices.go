@@ -15,6 +15,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 )
 
@@ -348,7 +349,7 @@ func (m *Machine) validateLinkLayerDeviceParent(args *LinkLayerDeviceArgs) error
 		return errors.NotValidf("ParentName %q on non-host machine %q", args.ParentName, hostMachineID)
 	}
 
-	err = m.verifyHostMachineParentDeviceExistsAndIsABridgeDevice(hostMachineID, parentDeviceName)
+	err = m.verifyHostMachineParentDeviceExistsAndCanBeParent(hostMachineID, parentDeviceName)
 	return errors.Trace(err)
 }
 
@@ -362,7 +363,21 @@ func parseLinkLayerDeviceParentNameAsGlobalKey(parentName string) (hostMachineID
 	return hostMachineID, parentDeviceName, nil
 }
 
-func (m *Machine) verifyHostMachineParentDeviceExistsAndIsABridgeDevice(hostMachineID, parentDeviceName string) error {
+// macvlanParentDeviceTypes are the types of host device that container
+// devices can be attached to, besides bridges, with macvlan container
+// networking.
+var macvlanParentDeviceTypes = map[LinkLayerDeviceType]bool{
+	EthernetDevice:   true,
+	BondDevice:       true,
+	VLAN_8021QDevice: true,
+}
+
+// verifyHostMachineParentDeviceExistsAndCanBeParent checks that the given
+// host machine device exists and can be the parent of a container device.
+// Container devices must be attached to a bridge on the host, unless the
+// model uses macvlan container networking, which attaches them directly
+// to a host NIC, bond or VLAN device instead.
+func (m *Machine) verifyHostMachineParentDeviceExistsAndCanBeParent(hostMachineID, parentDeviceName string) error {
 	hostMachine, err := m.st.Machine(hostMachineID)
 	if errors.IsNotFound(err) || err == nil && hostMachine.Life() != Alive {
 		return errors.Errorf("host machine %q of parent device %q not found or not alive", hostMachineID, parentDeviceName)
@@ -377,13 +392,27 @@ func (m *Machine) verifyHostMachineParentDeviceExistsAndIsABridgeDevice(hostMach
 		return errors.Trace(err)
 	}
 
-	if parentDevice.Type() != BridgeDevice {
+	if parentDevice.Type() == BridgeDevice {
+		return nil
+	}
+	modelConfig, err := m.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelConfig.ContainerNetworkingMethod() != config.ContainerNetworkingMacvlan {
 		errorMessage := fmt.Sprintf(
 			"parent device %q on host machine %q must be of type %q, not type %q",
 			parentDeviceName, hostMachineID, BridgeDevice, parentDevice.Type(),
 		)
 		return errors.NewNotValid(nil, errorMessage)
 	}
+	if !macvlanParentDeviceTypes[parentDevice.Type()] {
+		errorMessage := fmt.Sprintf(
+			"parent device %q on host machine %q cannot be of type %q with macvlan container networking",
+			parentDeviceName, hostMachineID, parentDevice.Type(),
+		)
+		return errors.NewNotValid(nil, errorMessage)
+	}
 	return nil
 }
 
@@ -1092,15 +1121,30 @@ func DefineEthernetDeviceOnBridge(name string, hostBridge *LinkLayerDevice) (Lin
 	if hostBridge.Type() != BridgeDevice {
 		return LinkLayerDeviceArgs{}, errors.Errorf("hostBridge must be a Bridge Device not %q", hostBridge.Type())
 	}
+	return defineEthernetDeviceOnParent(name, hostBridge), nil
+}
+
+// DefineEthernetDeviceOnParent returns the arguments for a container
+// ethernet device attached directly to the given host device, as done
+// by macvlan container networking. The host device must be a bridge,
+// ethernet, bond or VLAN device.
+func DefineEthernetDeviceOnParent(name string, hostDevice *LinkLayerDevice) (LinkLayerDeviceArgs, error) {
+	if hostDevice.Type() != BridgeDevice && !macvlanParentDeviceTypes[hostDevice.Type()] {
+		return LinkLayerDeviceArgs{}, errors.Errorf("hostDevice cannot be of type %q", hostDevice.Type())
+	}
+	return defineEthernetDeviceOnParent(name, hostDevice), nil
+}
+
+func defineEthernetDeviceOnParent(name string, hostDevice *LinkLayerDevice) LinkLayerDeviceArgs {
 	return LinkLayerDeviceArgs{
 		Name:        name,
 		Type:        EthernetDevice,
 		MACAddress:  generateMACAddress(),
-		MTU:         hostBridge.MTU(),
+		MTU:         hostDevice.MTU(),
 		IsUp:        true,
 		IsAutoStart: true,
-		ParentName:  hostBridge.globalKey(),
-	}, nil
+		ParentName:  hostDevice.globalKey(),
+	}
 }
 
 // MACAddressTemplate is used to generate a unique MAC address for a
@@ -1232,3 +1276,25 @@ func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string](Machin
 
 	return results
 }
+
+// addressInSpace returns an address of the machine that is in a subnet of
+// the given space, or an error satisfying errors.IsNotFound() when the
+// machine has no such address.
+func (m *Machine) addressInSpace(spaceName string) (network.Address, error) {
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	for _, addr := range addresses {
+		subnet, err := addr.Subnet()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return network.Address{}, errors.Trace(err)
+		}
+		if subnet.SpaceName() == spaceName {
+			return addr.NetworkAddress(), nil
+		}
+	}
+	return network.Address{}, errors.NotFoundf("address of machine %q in space %q", m.doc.Id, spaceName)
+}
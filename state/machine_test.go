@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type MachineSuite struct {
@@ -1004,6 +1005,51 @@ func (s *MachineSuite) TestMachineSetProvisionedWhenNotAlive(c *gc.C) {
 	})
 }
 
+func (s *MachineSuite) TestMachineReplaceInstance(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.ReplaceInstance("umbrella/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsFalse)
+	statusInfo, err := s.machine.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.ProvisioningError)
+	c.Assert(statusInfo.Message, gc.Equals, `instance "umbrella/0" was terminated, starting a replacement`)
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+		"transient":   true,
+		"instance-id": "umbrella/0",
+	})
+
+	// The machine can be provisioned again.
+	err = s.machine.SetProvisioned("umbrella/1", "another_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineSuite) TestMachineReplaceInstanceWrongInstance(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.ReplaceInstance("umbrella/1")
+	c.Assert(err, gc.ErrorMatches, `cannot replace instance of machine "1": instance "umbrella/1" is not the machine's instance`)
+	instanceId, err := s.machine.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceId, gc.Equals, instance.Id("umbrella/0"))
+}
+
+func (s *MachineSuite) TestMachineReplaceInstanceWhenNotAlive(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	testWhenDying(c, s.machine, notAliveErr, notAliveErr, func() error {
+		return s.machine.ReplaceInstance("umbrella/0")
+	})
+}
+
 func (s *MachineSuite) TestMachineSetInstanceStatus(c *gc.C) {
 	// Machine needs to be provisioned first.
 	err := s.machine.SetProvisioned("umbrella/0", "fake_nonce", nil)
@@ -1873,6 +1919,45 @@ func (s *MachineSuite) TestPrivateAddress(c *gc.C) {
 	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
 }
 
+func (s *MachineSuite) TestPublicAddressFollowsModelPreferIPv6(c *gc.C) {
+	addresses := network.NewAddresses("8.8.8.8", "2001:db8::1")
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+
+	// The preference of another model does not matter.
+	otherState := s.Factory.MakeModel(c, &factory.ModelParams{
+		ConfigAttrs: coretesting.Attrs{"prefer-ipv6": true},
+	})
+	defer otherState.Close()
+	otherMachine, err := otherState.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = otherMachine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = otherMachine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::1")
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+
+	// Changing the model's preference reselects the address.
+	err = s.State.UpdateModelConfig(map[string]interface{}{"prefer-ipv6": true}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::1")
+}
+
 func (s *MachineSuite) TestPublicAddressBetterMatch(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2378,3 +2463,154 @@ func (s *MachineSuite) TestMachineAddDifferentAction(c *gc.C) {
 	_, err = m.AddAction("benchmark", nil)
 	c.Assert(err, gc.ErrorMatches, `cannot add action "benchmark" to a machine; only predefined actions allowed`)
 }
+
+func (s *MachineSuite) TestSetKeepInstance(c *gc.C) {
+	c.Assert(s.machine.KeepInstance(), jc.IsFalse)
+
+	err := s.machine.SetKeepInstance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.KeepInstance(), jc.IsTrue)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.KeepInstance(), jc.IsTrue)
+
+	err = s.machine.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.KeepInstance(), jc.IsTrue)
+}
+
+func (s *MachineSuite) TestSetKeepInstanceDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetKeepInstance(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set keep-instance of machine 1: not found or dead`)
+}
+
+func (s *MachineSuite) TestSetMaintenance(c *gc.C) {
+	c.Assert(s.machine.InMaintenance(), jc.IsFalse)
+
+	err := s.machine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.InMaintenance(), jc.IsTrue)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.InMaintenance(), jc.IsTrue)
+
+	err = s.machine.SetMaintenance(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.InMaintenance(), jc.IsFalse)
+}
+
+func (s *MachineSuite) TestSetMaintenanceDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetMaintenance(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set maintenance of machine 1: not found or dead`)
+}
+
+func (s *MachineSuite) TestMachineInMaintenanceRefusesUnits(c *gc.C) {
+	err := s.machine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "mysql/0" to machine 1: machine "1" is in maintenance`)
+
+	// Clean machines in maintenance are not chosen either.
+	err = s.State.AssignUnit(unit, state.AssignClean)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Not(gc.Equals), s.machine.Id())
+}
+
+func (s *MachineSuite) TestMachineInMaintenanceRefusesContainers(c *gc.C) {
+	err := s.machine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: machine "1" is in maintenance`)
+}
+
+func (s *MachineSuite) TestEvacuateUnits(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	stateless, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = stateless.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageBlock := s.AddTestingServiceWithStorage(c, "storage-block", s.AddTestingCharm(c, "storage-block"),
+		map[string]state.StorageConstraints{
+			"data": makeStorageCons("loop", 1024, 1),
+		})
+	stateful, err := storageBlock.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = stateful.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.machine.EvacuateUnits()
+	c.Assert(err, gc.ErrorMatches, `cannot evacuate units from machine 1: machine is not in maintenance`)
+
+	err = s.machine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	evacuated, kept, err := s.machine.EvacuateUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(evacuated, jc.DeepEquals, []state.EvacuatedUnit{{
+		Unit:        "wordpress/0",
+		Replacement: "wordpress/1",
+	}})
+	c.Assert(kept, jc.DeepEquals, []string{"storage-block/0"})
+
+	err = stateless.Refresh()
+	if err == nil {
+		c.Assert(stateless.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	replacement, err := s.State.Unit("wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := replacement.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Not(gc.Equals), s.machine.Id())
+}
+
+func (s *MachineSuite) TestEvacuateUnitsInContainers(c *gc.C) {
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetMaintenance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	evacuated, kept, err := s.machine.EvacuateUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(evacuated, jc.DeepEquals, []state.EvacuatedUnit{{
+		Unit:        "wordpress/0",
+		Replacement: "wordpress/1",
+	}})
+	c.Assert(kept, gc.HasLen, 0)
+
+	replacement, err := s.State.Unit("wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := replacement.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Not(gc.Equals), s.machine.Id())
+	c.Assert(machineId, gc.Not(gc.Equals), container.Id())
+}
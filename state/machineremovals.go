@@ -38,9 +38,35 @@ func (m *Machine) markForRemovalOps() ([]txn.Op, error) {
 		// No assert here - it's ok if the machine has already been
 		// marked. The id will prevent duplicates.
 	}}
+	if m.doc.KeepInstance {
+		keptOps, err := m.keptInstanceOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, keptOps...)
+	}
 	return ops, nil
 }
 
+// keptInstanceOps returns the operations required to record that the
+// machine's instance is being kept, so that it is not later mistaken
+// for an unknown instance and stopped.
+func (m *Machine) keptInstanceOps() ([]txn.Op, error) {
+	instId, err := m.InstanceId()
+	if errors.IsNotProvisioned(err) {
+		// There's no instance to keep.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:      modelsC,
+		Id:     m.st.ModelUUID(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"kept-instances", string(instId)}}}},
+	}}, nil
+}
+
 // MarkForRemoval requests that this machine be removed after any
 // needed provider-level cleanup is done.
 func (m *Machine) MarkForRemoval() (err error) {
@@ -8,6 +8,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/worker/workertest"
@@ -70,6 +71,32 @@ func (s *MachineRemovalSuite) TestMarkForRemovalAssertsMachineStillExists(c *gc.
 	c.Assert(err, gc.ErrorMatches, "cannot remove machine 0: machine 0 not found")
 }
 
+func (s *MachineRemovalSuite) TestMarkForRemovalRecordsKeptInstance(c *gc.C) {
+	m1 := s.makeMachine(c, false)
+	err := m1.SetProvisioned("inst-1", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m1.SetKeepInstance(true)
+	c.Assert(err, jc.ErrorIsNil)
+	deadenMachine(c, m1)
+	m2 := s.makeMachine(c, false)
+	err = m2.SetProvisioned("inst-2", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	deadenMachine(c, m2)
+
+	err = m1.MarkForRemoval()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m2.MarkForRemoval()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CompleteMachineRemovals(m1.Id(), m2.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the instance of the machine keeping it is recorded, and
+	// the record outlives the machine.
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.KeptInstances(), jc.DeepEquals, []instance.Id{"inst-1"})
+}
+
 func (s *MachineRemovalSuite) TestCompleteMachineRemovalsRequiresMark(c *gc.C) {
 	m1 := s.makeMachine(c, true)
 	m2 := s.makeMachine(c, true)
@@ -368,6 +368,59 @@ func (s *MigrationExportSuite) TestApplicationsWithVirtConstraint(c *gc.C) {
 	s.assertMigrateApplications(c, constraints.MustParse("arch=amd64 mem=8G virt-type=kvm"))
 }
 
+func (s *MigrationExportSuite) TestApplicationWithExposeSettings(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The description has no place for the expose settings,
+	// so they are exported in the model extras.
+	extras, err := s.State.ExportExtras()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extras, gc.NotNil)
+}
+
+func (s *MigrationExportSuite) TestExportExtrasEmpty(c *gc.C) {
+	s.Factory.MakeApplication(c, nil)
+	extras, err := s.State.ExportExtras()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extras, gc.IsNil)
+}
+
+func (s *MigrationExportSuite) TestApplicationWithResourceTags(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.UpdateResourceTags(map[string]string{"team": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The description has no place for the resource tags,
+	// so they are exported in the model extras.
+	extras, err := s.State.ExportExtras()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extras, gc.NotNil)
+}
+
+func (s *MigrationExportSuite) TestApplicationWithAntiAffinity(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetAntiAffinity(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The description has no place for anti-affinity either.
+	extras, err := s.State.ExportExtras()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extras, gc.NotNil)
+}
+
 func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constraints.Value) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Settings: map[string]interface{}{
@@ -526,6 +579,36 @@ func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	c.Assert(opened[0].UnitName(), gc.Equals, unit.Name())
 }
 
+func (s *MigrationExportSuite) TestUnitsEgressRules(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.OpenEgress("tcp", 443, 443, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model description has no place for egress
+	// rules, so they are carried in the model extras.
+	extras, err := s.State.ExportExtras()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extras, gc.NotNil)
+}
+
+func (s *MigrationExportSuite) TestInstanceLifecycleConstraint(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("mem=4G instance-lifecycle=spot"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Constraints().Memory(), gc.Equals, uint64(4*1024))
+
+	// The description has no place for the instance lifecycle,
+	// so it is exported in the model extras.
+	extras, err := s.State.ExportExtras()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extras, gc.NotNil)
+}
+
 func (s *MigrationExportSuite) TestEndpointBindings(c *gc.C) {
 	s.Factory.MakeSpace(c, &factory.SpaceParams{
 		Name: "one", ProviderID: network.Id("provider"), IsPublic: true})
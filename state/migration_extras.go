@@ -0,0 +1,341 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// modelExtrasVersion is the version of the format the model extras
+// are exported in. ImportExtras refuses extras of a later version, as
+// it would not know how to apply them.
+const modelExtrasVersion = 1
+
+// modelExtras holds the parts of a model that the model description
+// has no place for, so that they can be migrated alongside it.
+type modelExtras struct {
+	Version      int                          `json:"version"`
+	Applications map[string]applicationExtras `json:"applications,omitempty"`
+	OpenedPorts  []openedPortExtras           `json:"opened-ports,omitempty"`
+	Egress       []egressExtras               `json:"egress,omitempty"`
+
+	// InstanceLifecycles holds the instance-lifecycle constraints
+	// of the model, applications and machines, keyed by the global
+	// keys of their constraints.
+	InstanceLifecycles map[string]string `json:"instance-lifecycles,omitempty"`
+}
+
+func (x *modelExtras) empty() bool {
+	return len(x.Applications) == 0 && len(x.OpenedPorts) == 0 && len(x.Egress) == 0 &&
+		len(x.InstanceLifecycles) == 0
+}
+
+// applicationExtras holds the settings of an application that the
+// model description has no place for.
+type applicationExtras struct {
+	ExposedEndpoints map[string]exposedEndpointExtras `json:"exposed-endpoints,omitempty"`
+	ResourceTags     map[string]string                `json:"resource-tags,omitempty"`
+	AntiAffinity     bool                             `json:"anti-affinity,omitempty"`
+}
+
+func (x *applicationExtras) empty() bool {
+	return len(x.ExposedEndpoints) == 0 && len(x.ResourceTags) == 0 && !x.AntiAffinity
+}
+
+type exposedEndpointExtras struct {
+	ExposeToSpaces []string `json:"to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"to-cidrs,omitempty"`
+}
+
+// openedPortExtras records the endpoint a unit opened a port range on
+// a machine for.
+type openedPortExtras struct {
+	Machine  string `json:"machine"`
+	Subnet   string `json:"subnet,omitempty"`
+	Unit     string `json:"unit"`
+	FromPort int    `json:"from-port"`
+	ToPort   int    `json:"to-port"`
+	Protocol string `json:"protocol"`
+	Endpoint string `json:"endpoint"`
+}
+
+// egressExtras records an egress rule a unit allowed on a machine.
+type egressExtras struct {
+	Machine          string   `json:"machine"`
+	Subnet           string   `json:"subnet,omitempty"`
+	Unit             string   `json:"unit"`
+	FromPort         int      `json:"from-port"`
+	ToPort           int      `json:"to-port"`
+	Protocol         string   `json:"protocol"`
+	DestinationCIDRs []string `json:"destination-cidrs,omitempty"`
+}
+
+// ExportExtras returns the parts of the model that the model
+// description has no place for, serialized so that they can be
+// migrated alongside it. It returns nil if the model has none.
+func (st *State) ExportExtras() ([]byte, error) {
+	extras := modelExtras{Version: modelExtrasVersion}
+
+	applications, closer := st.db().GetCollection(applicationsC)
+	defer closer()
+	var appDocs []applicationDoc
+	if err := applications.Find(nil).All(&appDocs); err != nil {
+		return nil, errors.Annotate(err, "applications")
+	}
+	for _, doc := range appDocs {
+		app := exportApplicationExtras(doc)
+		if app.empty() {
+			continue
+		}
+		if extras.Applications == nil {
+			extras.Applications = make(map[string]applicationExtras)
+		}
+		extras.Applications[doc.Name] = app
+	}
+
+	openedPorts, closer := st.db().GetCollection(openedPortsC)
+	defer closer()
+	var portsDocs []portsDoc
+	if err := openedPorts.Find(nil).All(&portsDocs); err != nil {
+		return nil, errors.Annotate(err, "opened ports")
+	}
+	for _, doc := range portsDocs {
+		for _, p := range doc.Ports {
+			if p.Endpoint == "" {
+				continue
+			}
+			extras.OpenedPorts = append(extras.OpenedPorts, openedPortExtras{
+				Machine:  doc.MachineID,
+				Subnet:   doc.SubnetID,
+				Unit:     p.UnitName,
+				FromPort: p.FromPort,
+				ToPort:   p.ToPort,
+				Protocol: p.Protocol,
+				Endpoint: p.Endpoint,
+			})
+		}
+		for _, r := range doc.Egress {
+			extras.Egress = append(extras.Egress, egressExtras{
+				Machine:          doc.MachineID,
+				Subnet:           doc.SubnetID,
+				Unit:             r.UnitName,
+				FromPort:         r.FromPort,
+				ToPort:           r.ToPort,
+				Protocol:         r.Protocol,
+				DestinationCIDRs: r.DestinationCIDRs,
+			})
+		}
+	}
+
+	constraintsColl, closer := st.db().GetCollection(constraintsC)
+	defer closer()
+	var consDocs []struct {
+		DocID             string  `bson:"_id"`
+		InstanceLifecycle *string `bson:"instancelifecycle"`
+	}
+	if err := constraintsColl.Find(nil).All(&consDocs); err != nil {
+		return nil, errors.Annotate(err, "constraints")
+	}
+	for _, doc := range consDocs {
+		if doc.InstanceLifecycle == nil || *doc.InstanceLifecycle == "" {
+			continue
+		}
+		if extras.InstanceLifecycles == nil {
+			extras.InstanceLifecycles = make(map[string]string)
+		}
+		extras.InstanceLifecycles[st.localID(doc.DocID)] = *doc.InstanceLifecycle
+	}
+
+	if extras.empty() {
+		return nil, nil
+	}
+	data, err := json.Marshal(extras)
+	return data, errors.Trace(err)
+}
+
+func exportApplicationExtras(doc applicationDoc) applicationExtras {
+	var app applicationExtras
+	for name, ep := range doc.ExposedEndpoints {
+		if app.ExposedEndpoints == nil {
+			app.ExposedEndpoints = make(map[string]exposedEndpointExtras)
+		}
+		app.ExposedEndpoints[name] = exposedEndpointExtras{
+			ExposeToSpaces: ep.ExposeToSpaces,
+			ExposeToCIDRs:  ep.ExposeToCIDRs,
+		}
+	}
+	app.ResourceTags = doc.ResourceTags
+	app.AntiAffinity = doc.AntiAffinity
+	return app
+}
+
+// ImportExtras applies the parts of a model being imported by a
+// migration that the model description has no place for, as returned
+// by ExportExtras on the source controller.
+func (st *State) ImportExtras(data []byte) error {
+	var extras modelExtras
+	if err := json.Unmarshal(data, &extras); err != nil {
+		return errors.Annotate(err, "cannot read model extras")
+	}
+	if extras.Version > modelExtrasVersion {
+		return errors.NotSupportedf("model extras version %d", extras.Version)
+	}
+
+	var ops []txn.Op
+	for name, app := range extras.Applications {
+		ops = append(ops, importApplicationExtrasOps(name, app)...)
+	}
+	portsOps, err := st.importPortsExtrasOps(extras.OpenedPorts, extras.Egress)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, portsOps...)
+	for key, lifecycle := range extras.InstanceLifecycles {
+		ops = append(ops, txn.Op{
+			C:      constraintsC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"instancelifecycle", lifecycle}}}},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.New("model extras refer to missing applications, constraints or changed ports")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func importApplicationExtrasOps(name string, app applicationExtras) []txn.Op {
+	var update bson.D
+	if len(app.ExposedEndpoints) > 0 {
+		exposed := make(map[string]ExposedEndpoint, len(app.ExposedEndpoints))
+		for name, ep := range app.ExposedEndpoints {
+			exposed[name] = ExposedEndpoint{
+				ExposeToSpaces: ep.ExposeToSpaces,
+				ExposeToCIDRs:  ep.ExposeToCIDRs,
+			}
+		}
+		update = append(update, bson.DocElem{"exposed-endpoints", exposed})
+	}
+	if len(app.ResourceTags) > 0 {
+		update = append(update, bson.DocElem{"resource-tags", app.ResourceTags})
+	}
+	if app.AntiAffinity {
+		update = append(update, bson.DocElem{"anti-affinity", true})
+	}
+	if len(update) == 0 {
+		return nil
+	}
+	return []txn.Op{{
+		C:      applicationsC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", update}},
+	}}
+}
+
+// importPortsExtrasOps returns the operations that set the endpoints
+// of the given port ranges, which the model description imported them
+// without, and that allow the given egress.
+func (st *State) importPortsExtrasOps(openedPorts []openedPortExtras, egress []egressExtras) ([]txn.Op, error) {
+	// Group the port ranges and egress rules by the documents
+	// they are held in, so that each document is written once.
+	type portsKey struct {
+		machine, subnet string
+	}
+	var keys []portsKey
+	portsByKey := make(map[portsKey][]openedPortExtras)
+	egressByKey := make(map[portsKey][]egressExtras)
+	addKey := func(key portsKey) {
+		_, hasPorts := portsByKey[key]
+		_, hasEgress := egressByKey[key]
+		if !hasPorts && !hasEgress {
+			keys = append(keys, key)
+		}
+	}
+	for _, p := range openedPorts {
+		key := portsKey{p.Machine, p.Subnet}
+		addKey(key)
+		portsByKey[key] = append(portsByKey[key], p)
+	}
+	for _, r := range egress {
+		key := portsKey{r.Machine, r.Subnet}
+		addKey(key)
+		egressByKey[key] = append(egressByKey[key], r)
+	}
+
+	var ops []txn.Op
+	for _, key := range keys {
+		ports, err := getOrCreatePorts(st, key.machine, key.subnet)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		newPorts := append([]PortRange{}, ports.doc.Ports...)
+		for _, p := range portsByKey[key] {
+			portRange := PortRange{
+				UnitName: p.Unit,
+				FromPort: p.FromPort,
+				ToPort:   p.ToPort,
+				Protocol: p.Protocol,
+			}
+			found := false
+			for i, existing := range newPorts {
+				if existing.samePorts(portRange) {
+					newPorts[i].Endpoint = p.Endpoint
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, errors.NotFoundf("port range %v on machine %q", portRange, key.machine)
+			}
+		}
+		newEgress := append([]EgressRule{}, ports.doc.Egress...)
+		for _, r := range egressByKey[key] {
+			newEgress = append(newEgress, EgressRule{
+				UnitName:         r.Unit,
+				FromPort:         r.FromPort,
+				ToPort:           r.ToPort,
+				Protocol:         r.Protocol,
+				DestinationCIDRs: r.DestinationCIDRs,
+			})
+		}
+
+		if ports.areNew {
+			// Only egress is allowed on the machine, so the
+			// model description had no ports document for it.
+			doc := ports.doc
+			doc.Ports = newPorts
+			doc.Egress = newEgress
+			ops = append(ops, assertMachineNotDeadAndSubnetNotDeadWhenSetOps(st, &doc)...)
+			ops = append(ops, txn.Op{
+				C:      openedPortsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+			continue
+		}
+		update := bson.D{{"ports", newPorts}}
+		if len(newEgress) > 0 {
+			update = append(update, bson.DocElem{"egress", newEgress})
+		}
+		ops = append(ops, assertMachineNotDeadAndSubnetNotDeadWhenSetOps(st, &ports.doc)...)
+		ops = append(ops, txn.Op{
+			C:      openedPortsC,
+			Id:     ports.doc.DocID,
+			Assert: bson.D{{"txn-revno", ports.doc.TxnRevno}},
+			Update: bson.D{{"$set", update}},
+		})
+	}
+	return ops, nil
+}
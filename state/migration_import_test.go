@@ -69,6 +69,15 @@ func (s *MigrationImportSuite) importModel(c *gc.C) (*state.Model, *state.State)
 	s.AddCleanup(func(c *gc.C) {
 		c.Check(newSt.Close(), jc.ErrorIsNil)
 	})
+
+	// The parts of the model the description cannot hold
+	// follow the model, as they do in a migration.
+	extras, err := s.State.ExportExtras()
+	c.Assert(err, jc.ErrorIsNil)
+	if extras != nil {
+		err = newSt.ImportExtras(extras)
+		c.Assert(err, jc.ErrorIsNil)
+	}
 	return newModel, newSt
 }
 
@@ -690,6 +699,128 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestUnitsOpenPortsOnEndpoint(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.OpenPortsOnEndpoint("server", "tcp", 3306, 3306)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := imported.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.SameContents, []state.PortRange{{
+		UnitName: unit.Name(),
+		FromPort: 3306,
+		ToPort:   3306,
+		Protocol: "tcp",
+		Endpoint: "server",
+	}, {
+		UnitName: unit.Name(),
+		FromPort: 8080,
+		ToPort:   8080,
+		Protocol: "tcp",
+	}})
+}
+
+func (s *MigrationImportSuite) TestUnitsEgressRules(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.OpenEgress("tcp", 443, 443, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.Factory.MakeUnit(c, nil)
+	err = other.OpenEgress("udp", 53, 53)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	for _, u := range []*state.Unit{unit, other} {
+		rules, err := u.EgressRules()
+		c.Assert(err, jc.ErrorIsNil)
+		imported, err := newSt.Unit(u.Name())
+		c.Assert(err, jc.ErrorIsNil)
+		importedRules, err := imported.EgressRules()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(importedRules, jc.DeepEquals, rules)
+	}
+}
+
+func (s *MigrationImportSuite) TestApplicationExposeSettings(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	exposed := map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"server":           {ExposeToSpaces: []string{"dmz"}},
+	}
+	s.Factory.MakeSpace(c, &factory.SpaceParams{Name: "dmz"})
+	err := application.MergeExposeSettings(exposed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.IsExposed(), jc.IsTrue)
+	c.Assert(imported.ExposedEndpoints(), jc.DeepEquals, exposed)
+}
+
+func (s *MigrationImportSuite) TestApplicationResourceTags(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.UpdateResourceTags(map[string]string{"team": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	tags, ok := imported.ResourceTags()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(tags, jc.DeepEquals, map[string]string{"team": "db"})
+}
+
+func (s *MigrationImportSuite) TestApplicationAntiAffinity(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetAntiAffinity(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.AntiAffinity(), jc.IsTrue)
+}
+
+func (s *MigrationImportSuite) TestInstanceLifecycleConstraints(c *gc.C) {
+	modelCons := constraints.MustParse("mem=4G instance-lifecycle=spot")
+	err := s.State.SetModelConstraints(modelCons)
+	c.Assert(err, jc.ErrorIsNil)
+	appCons := constraints.MustParse("instance-lifecycle=spot")
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Constraints: appCons,
+	})
+
+	_, newSt := s.importModel(c)
+
+	importedCons, err := newSt.ModelConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedCons, jc.DeepEquals, modelCons)
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	importedCons, err = imported.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedCons, jc.DeepEquals, appCons)
+}
+
+func (s *MigrationImportSuite) TestImportExtrasLaterVersion(c *gc.C) {
+	err := s.State.ImportExtras([]byte(`{"version": 99}`))
+	c.Assert(err, gc.ErrorMatches, `model extras version 99 not supported`)
+}
+
 func (s *MigrationImportSuite) TestSpaces(c *gc.C) {
 	space := s.Factory.MakeSpace(c, &factory.SpaceParams{
 		Name: "one", ProviderID: network.Id("provider"), IsPublic: true})
@@ -25,6 +25,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		modelUsersC,
 		modelUserLastConnectionC,
 		permissionsC,
+		// Role grants are transferred alongside the serialized model.
+		roleGrantsC,
 		settingsC,
 		sequenceC,
 		sshHostKeysC,
@@ -79,6 +81,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// machine removals.
 		cleanupsC,
 		machineRemovalsC,
+		// Precheck ensures that no machine is locked for a series
+		// upgrade.
+		upgradeSeriesLocksC,
 		// The autocert cache is non-critical. After migration
 		// you'll just need to acquire new certificates.
 		autocertCacheC,
@@ -101,6 +106,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
+		// Roles are controller global. Those granted within the model
+		// are transferred along with the role grants.
+		rolesC,
+		// Groups of users are controller global. Those granted access to
+		// the model are transferred alongside the serialized model.
+		userGroupsC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
 		// Metrics aren't migrated.
@@ -228,6 +239,15 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"LatestAvailableTools",
 		"SLA",
 		"MeterStatus",
+		// KeepInstances is only set on models being destroyed,
+		// which cannot be migrated.
+		"KeepInstances",
+		// KeptInstances are no longer part of the model; their
+		// juju tags are removed when they are kept.
+		"KeptInstances",
+		// ResourceTagKeys only tracks tags that may need removing
+		// from the model's existing cloud resources.
+		"ResourceTagKeys",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -290,6 +310,10 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Machines in maintenance are refused by export precheck.
+		"Maintenance",
+		// KeepInstance is only set on machines being removed.
+		"KeepInstance",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -350,6 +374,11 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// ExposedEndpoints, ResourceTags and AntiAffinity cannot
+		// be described yet, so they are carried in the model extras.
+		"ExposedEndpoints",
+		"ResourceTags",
+		"AntiAffinity",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -406,7 +435,9 @@ func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
 		// TxnRevno isn't migrated.
 		"TxnRevno",
 	)
-	s.AssertExportedFields(c, portsDoc{}, fields)
+	// Egress rules are carried in the model extras.
+	ignored := set.NewStrings("Egress")
+	s.AssertExportedFields(c, portsDoc{}, fields.Union(ignored))
 }
 
 func (s *MigrationSuite) TestMeterStatusDocFields(c *gc.C) {
@@ -526,6 +557,9 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		// The instance lifecycle is exported in the model
+		// extras.
+		"InstanceLifecycle",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -17,6 +17,7 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/status"
@@ -89,6 +90,18 @@ type modelDoc struct {
 
 	// MeterStatus is the current meter status of the model.
 	MeterStatus modelMeterStatusdoc `bson:"meter-status"`
+
+	// KeepInstances is true if the cloud instances of the model's
+	// machines should be left running when the model is destroyed.
+	KeepInstances bool `bson:"keep-instances,omitempty"`
+
+	// KeptInstances holds the ids of the cloud instances that were
+	// left running when their machines were removed from the model.
+	KeptInstances []string `bson:"kept-instances,omitempty"`
+
+	// ResourceTagKeys holds the names of the user-specified tags
+	// that have been set on the model's cloud resources.
+	ResourceTagKeys []string `bson:"resource-tag-keys,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -532,6 +545,59 @@ func (m *Model) CloudCredential() (names.CloudCredentialTag, bool) {
 	return names.CloudCredentialTag{}, false
 }
 
+// KeepInstances returns whether the cloud instances of the model's
+// machines are left running when the model is destroyed.
+func (m *Model) KeepInstances() bool {
+	return m.doc.KeepInstances
+}
+
+// KeptInstances returns the ids of the cloud instances that were left
+// running when their machines were removed from the model. They must
+// not be stopped when harvesting instances, nor when the model's cloud
+// resources are torn down.
+func (m *Model) KeptInstances() []instance.Id {
+	ids := make([]instance.Id, len(m.doc.KeptInstances))
+	for i, id := range m.doc.KeptInstances {
+		ids[i] = instance.Id(id)
+	}
+	return ids
+}
+
+// ResourceTagKeys returns the names of the user-specified tags that
+// have been set on the model's cloud resources. Any of them that are
+// no longer specified must be removed from the resources.
+func (m *Model) ResourceTagKeys() []string {
+	keys := make([]string, len(m.doc.ResourceTagKeys))
+	copy(keys, m.doc.ResourceTagKeys)
+	return keys
+}
+
+// AddResourceTagKeys records that user-specified tags with the given
+// names are to be set on the model's cloud resources.
+func (m *Model) AddResourceTagKeys(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	st, closeState, err := m.getState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeState()
+
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{
+			{"resource-tag-keys", bson.D{{"$each", keys}}},
+		}}},
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot record resource tag names")
+	}
+	return m.Refresh()
+}
+
 // MigrationMode returns whether the model is active or being migrated.
 func (m *Model) MigrationMode() MigrationMode {
 	return m.doc.MigrationMode
@@ -819,7 +885,20 @@ func (m *Model) Destroy() error {
 	if m.isControllerModel() {
 		ensureNoHostedModels = true
 	}
-	return m.destroy(ensureNoHostedModels)
+	return m.destroy(ensureNoHostedModels, false)
+}
+
+// DestroyKeepingInstances sets the model's lifecycle to Dying, as
+// Destroy does, but leaves the cloud instances of the model's machines
+// running. The machines are removed from the model without their
+// instances being stopped, and the model's cloud resources are not
+// torn down.
+func (m *Model) DestroyKeepingInstances() error {
+	ensureNoHostedModels := false
+	if m.isControllerModel() {
+		ensureNoHostedModels = true
+	}
+	return m.destroy(ensureNoHostedModels, true)
 }
 
 // DestroyIncludingHosted sets the model's lifecycle to Dying, preventing
@@ -827,10 +906,10 @@ func (m *Model) Destroy() error {
 // hosting other models, they will also be destroyed.
 func (m *Model) DestroyIncludingHosted() error {
 	ensureNoHostedModels := false
-	return m.destroy(ensureNoHostedModels)
+	return m.destroy(ensureNoHostedModels, false)
 }
 
-func (m *Model) destroy(ensureNoHostedModels bool) (err error) {
+func (m *Model) destroy(ensureNoHostedModels, keepInstances bool) (err error) {
 	defer errors.DeferredAnnotatef(&err, "failed to destroy model")
 
 	st, closeState, err := m.getState()
@@ -851,7 +930,7 @@ func (m *Model) destroy(ensureNoHostedModels bool) (err error) {
 			}
 		}
 
-		ops, err := m.destroyOps(ensureNoHostedModels, false)
+		ops, err := m.destroyOps(ensureNoHostedModels, false, keepInstances)
 		if err == errModelNotAlive {
 			return nil, jujutxn.ErrNoOperations
 		} else if err != nil {
@@ -883,8 +962,10 @@ func IsHasHostedModelsError(err error) bool {
 // destruction, or an error indicating why it can't.
 //
 // If ensureNoHostedModels is true, then destroyOps will
-// fail if there are any non-Dead hosted models
-func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool) ([]txn.Op, error) {
+// fail if there are any non-Dead hosted models. If keepInstances
+// is true, the model is flagged so that its machines' instances
+// are left running.
+func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty, keepInstances bool) ([]txn.Op, error) {
 	if m.Life() != Alive {
 		return nil, errModelNotAlive
 	}
@@ -949,7 +1030,7 @@ func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool) ([]txn.Op, er
 			}
 			// See if the model is empty, and if it is,
 			// get the ops required to destroy it.
-			ops, err := model.destroyOps(false, true)
+			ops, err := model.destroyOps(false, true, false)
 			switch err {
 			case errModelNotAlive:
 				dying++
@@ -989,6 +1070,11 @@ func (m *Model) destroyOps(ensureNoHostedModels, ensureEmpty bool) ([]txn.Op, er
 			"time-of-death", timeOfDying,
 		})
 	}
+	if keepInstances {
+		modelUpdateValues = append(modelUpdateValues, bson.DocElem{
+			"keep-instances", true,
+		})
+	}
 
 	ops := []txn.Op{{
 		C:      modelsC,
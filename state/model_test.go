@@ -214,6 +214,22 @@ func (s *ModelSuite) TestSetMigrationMode(c *gc.C) {
 	c.Assert(env.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *ModelSuite) TestAddResourceTagKeys(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ResourceTagKeys(), gc.HasLen, 0)
+
+	err = model.AddResourceTagKeys([]string{"team", "owner"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.AddResourceTagKeys([]string{"team", "cost-centre"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ResourceTagKeys(), jc.SameContents, []string{"team", "owner", "cost-centre"})
+
+	model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ResourceTagKeys(), jc.SameContents, []string{"team", "owner", "cost-centre"})
+}
+
 func (s *ModelSuite) TestSLA(c *gc.C) {
 	cfg, _ := s.createTestModelConfig(c)
 	owner := names.NewUserTag("test@remote")
@@ -412,6 +428,21 @@ func (s *ModelSuite) TestDestroyOtherModel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ModelSuite) TestDestroyKeepingInstances(c *gc.C) {
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	factory.NewFactory(st2).MakeMachine(c, nil)
+
+	model, err := st2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.KeepInstances(), jc.IsFalse)
+	err = model.DestroyKeepingInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Equals, state.Dying)
+	c.Assert(model.KeepInstances(), jc.IsTrue)
+}
+
 func (s *ModelSuite) TestDestroyControllerNonEmptyModelFails(c *gc.C) {
 	st2 := s.Factory.MakeModel(c, nil)
 	defer st2.Close()
@@ -345,7 +345,15 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	if err := modelSettings.write(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if validCfg.PreferIPv6() != oldConfig.PreferIPv6() {
+		if err := st.updatePreferredAddresses(); err != nil {
+			return errors.Annotate(err, "updating preferred machine addresses")
+		}
+	}
+	return nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -160,7 +160,17 @@ func removeModelUserOps(modelUUID string, user names.UserTag) []txn.Op {
 // removeModelUser removes a user from the database.
 func (st *State) removeModelUser(user names.UserTag) error {
 	ops := removeModelUserOps(st.ModelUUID(), user)
-	err := st.runTransaction(ops)
+	roleOps, err := st.removeRoleGrantsForUserOps(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, roleOps...)
+	appOps, err := st.removeApplicationAccessForUserOps(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, appOps...)
+	err = st.runTransaction(ops)
 	if err == txn.ErrAborted {
 		err = errors.NewNotFound(nil, fmt.Sprintf("model user %q does not exist", user.Id()))
 	}
@@ -207,34 +217,50 @@ func (st *State) allUserModels() ([]*UserModel, error) {
 }
 
 // ModelsForUser returns a list of models that the user
-// is able to access.
+// is able to access, either directly or through the groups
+// they are a member of.
 func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
+	groups, err := st.UserGroupsFor(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subjects := append([]names.UserTag{user}, groups...)
+
 	// Consider the controller permissions overriding Model permission, for
 	// this case the only relevant one is superuser.
 	// The mgo query below wont work for superuser case because it needs at
 	// least one model user per model.
-	access, err := st.UserAccess(user, st.controllerTag)
-	if err == nil && access.Access == permission.SuperuserAccess {
-		return st.allUserModels()
-	}
-	if err != nil && !errors.IsNotFound(err) {
-		return nil, errors.Trace(err)
+	subjectIds := make([]string, len(subjects))
+	for i, subject := range subjects {
+		access, err := st.UserAccess(subject, st.controllerTag)
+		if err == nil && access.Access == permission.SuperuserAccess {
+			return st.allUserModels()
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		subjectIds[i] = subject.Id()
 	}
-	// Since there are no groups at this stage, the simplest way to get all
-	// the models that a particular user can see is to look through the
-	// model user collection. A raw collection is required to support
+	// The simplest way to get all the models that a particular user
+	// can see is to look through the model user collection for the
+	// user and their groups. A raw collection is required to support
 	// queries across multiple models.
 	modelUsers, userCloser := st.getRawCollection(modelUsersC)
 	defer userCloser()
 
 	var userSlice []userAccessDoc
-	err = modelUsers.Find(bson.D{{"user", user.Id()}}).Select(bson.D{{"object-uuid", 1}, {"_id", 1}}).All(&userSlice)
+	err = modelUsers.Find(bson.D{{"user", bson.D{{"$in", subjectIds}}}}).Select(bson.D{{"object-uuid", 1}, {"_id", 1}}).All(&userSlice)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []*UserModel
+	seen := make(map[string]bool)
 	for _, doc := range userSlice {
+		if seen[doc.ObjectUUID] {
+			continue
+		}
+		seen[doc.ObjectUUID] = true
 		modelTag := names.NewModelTag(doc.ObjectUUID)
 		model, err := st.GetModel(modelTag)
 		if err != nil {
@@ -5,11 +5,13 @@ package state
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
 	"github.com/juju/errors"
 	statetxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -37,6 +39,11 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint holds the name of the unit's endpoint the ports
+	// are opened for. It is empty if they are opened for all
+	// of the unit's endpoints.
+	Endpoint string `bson:",omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -118,8 +125,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
-	if prA == prB {
+	// to open the same port multiple times. The same unit may also move
+	// a port range to a different endpoint.
+	if prA.samePorts(prB) {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
@@ -131,19 +139,101 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	return nil
 }
 
+// samePorts reports whether the two port ranges are the same
+// range of ports opened by the same unit, regardless of the
+// endpoints they are opened for.
+func (prA PortRange) samePorts(prB PortRange) bool {
+	prA.Endpoint, prB.Endpoint = "", ""
+	return prA == prB
+}
+
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if p.Endpoint != "" {
+		return fmt.Sprintf("%d-%d/%s (%q endpoint %q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName, p.Endpoint)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
-// portsDoc represents the state of ports opened on machines for networks
+// EgressRule represents a range of ports to which one unit
+// allows outgoing traffic, optionally restricted to the
+// given destinations.
+type EgressRule struct {
+	UnitName         string
+	FromPort         int
+	ToPort           int
+	Protocol         string
+	DestinationCIDRs []string `bson:",omitempty"`
+}
+
+// NewEgressRule creates a new egress rule and validates it.
+func NewEgressRule(unitName string, fromPort, toPort int, protocol string, destinationCIDRs ...string) (EgressRule, error) {
+	r := EgressRule{
+		UnitName: unitName,
+		FromPort: fromPort,
+		ToPort:   toPort,
+		Protocol: strings.ToLower(protocol),
+	}
+	if len(destinationCIDRs) > 0 {
+		r.DestinationCIDRs = destinationCIDRs
+	}
+	if err := r.Validate(); err != nil {
+		return EgressRule{}, err
+	}
+	return r, nil
+}
+
+// Validate checks if the egress rule is valid.
+func (r EgressRule) Validate() error {
+	if err := r.portRange().Validate(); err != nil {
+		return err
+	}
+	for _, cidr := range r.DestinationCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid destination %q", cidr)
+		}
+	}
+	return nil
+}
+
+func (r EgressRule) portRange() PortRange {
+	return PortRange{
+		UnitName: r.UnitName,
+		FromPort: r.FromPort,
+		ToPort:   r.ToPort,
+		Protocol: r.Protocol,
+	}
+}
+
+// equals reports whether the two rules are the same.
+func (r EgressRule) equals(other EgressRule) bool {
+	if r.portRange() != other.portRange() {
+		return false
+	}
+	cidrs := set.NewStrings(r.DestinationCIDRs...)
+	otherCIDRs := set.NewStrings(other.DestinationCIDRs...)
+	return cidrs.Difference(otherCIDRs).IsEmpty() && otherCIDRs.Difference(cidrs).IsEmpty()
+}
+
+// String returns the egress rule as a string.
+func (r EgressRule) String() string {
+	to := ""
+	if len(r.DestinationCIDRs) > 0 {
+		to = " to " + strings.Join(r.DestinationCIDRs, ",")
+	}
+	return fmt.Sprintf("%d-%d/%s%s (%q)", r.FromPort, r.ToPort, strings.ToLower(r.Protocol), to, r.UnitName)
+}
+
+// portsDoc represents the state of ports opened on machines for
+// networks, and of the outgoing traffic allowed from them.
 type portsDoc struct {
-	DocID     string      `bson:"_id"`
-	ModelUUID string      `bson:"model-uuid"`
-	MachineID string      `bson:"machine-id"`
-	SubnetID  string      `bson:"subnet-id"`
-	Ports     []PortRange `bson:"ports"`
-	TxnRevno  int64       `bson:"txn-revno"`
+	DocID     string       `bson:"_id"`
+	ModelUUID string       `bson:"model-uuid"`
+	MachineID string       `bson:"machine-id"`
+	SubnetID  string       `bson:"subnet-id"`
+	Ports     []PortRange  `bson:"ports"`
+	Egress    []EgressRule `bson:"egress,omitempty"`
+	TxnRevno  int64        `bson:"txn-revno"`
 }
 
 // Ports represents the state of ports on a machine.
@@ -216,7 +306,8 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 		}
 
 		// Check for conflicts with existing ports.
-		for _, existingPorts := range p.doc.Ports {
+		moved := false
+		for _, existingPorts := range ports.doc.Ports {
 			if err := existingPorts.CheckConflicts(portRange); err != nil {
 				return nil, errors.Trace(err)
 			} else if existingPorts == portRange {
@@ -225,13 +316,27 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 				// and hence its txn-revno and trigger unnecessary
 				// watcher notifications.
 				return nil, statetxn.ErrNoOperations
+			} else if existingPorts.samePorts(portRange) {
+				moved = true
 			}
 		}
 
 		ops := []txn.Op{
 			assertModelActiveOp(p.st.ModelUUID()),
 		}
-		if ports.areNew {
+		if moved {
+			// The unit is opening the range for a different
+			// endpoint, which replaces the range it opened.
+			newPorts := []PortRange{}
+			for _, existingPorts := range ports.doc.Ports {
+				if existingPorts.samePorts(portRange) {
+					existingPorts = portRange
+				}
+				newPorts = append(newPorts, existingPorts)
+			}
+			assert := bson.D{{"txn-revno", ports.doc.TxnRevno}}
+			ops = append(ops, setPortsDocOps(p.st, ports.doc, assert, newPorts...)...)
+		} else if ports.areNew {
 			// Create a new document.
 			assert := txn.DocMissing
 			ops = append(ops, addPortsDocOps(p.st, &ports.doc, assert, portRange)...)
@@ -248,6 +353,12 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 	}
 	// Mark object as created.
 	p.areNew = false
+	for i, existingPorts := range p.doc.Ports {
+		if existingPorts.samePorts(portRange) {
+			p.doc.Ports[i] = portRange
+			return nil
+		}
+	}
 	p.doc.Ports = append(p.doc.Ports, portRange)
 	return nil
 }
@@ -293,7 +404,9 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			// Closing a range closes it for whichever
+			// endpoint it was opened for.
+			if existingPortsDef.samePorts(portRange) {
 				found = true
 				continue
 			}
@@ -306,7 +419,7 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 		if !found {
 			return nil, statetxn.ErrNoOperations
 		}
-		if len(newPorts) == 0 {
+		if len(newPorts) == 0 && len(ports.doc.Egress) == 0 {
 			// All ports closed, so remove the ports doc instead.
 			return p.removeOps(), nil
 		} else {
@@ -321,6 +434,169 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 	return nil
 }
 
+// OpenEgress adds the specified egress rule to the list of rules
+// maintained by this document.
+func (p *Ports) OpenEgress(rule EgressRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot allow egress %s", rule)
+
+	if err = rule.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ports := Ports{st: p.st, doc: p.doc, areNew: p.areNew}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := checkModelActive(p.st); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := p.verifySubnetAliveWhenSet(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err = ports.Refresh(); errors.IsNotFound(err) {
+				ports.areNew = true
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			} else {
+				ports.areNew = false
+			}
+		}
+		for _, existing := range ports.doc.Egress {
+			if existing.equals(rule) {
+				// Allowing the same egress for the same unit
+				// again does not change the document.
+				return nil, statetxn.ErrNoOperations
+			}
+		}
+
+		ops := []txn.Op{
+			assertModelActiveOp(p.st.ModelUUID()),
+			{
+				C:      unitsC,
+				Id:     p.st.docID(rule.UnitName),
+				Assert: notDeadDoc,
+			},
+		}
+		if ports.areNew {
+			// Create a new document with no opened ports.
+			doc := ports.doc
+			doc.Ports = []PortRange{}
+			doc.Egress = []EgressRule{rule}
+			ops = append(ops, assertMachineNotDeadAndSubnetNotDeadWhenSetOps(p.st, &doc)...)
+			ops = append(ops, txn.Op{
+				C:      openedPortsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+		} else {
+			assert := bson.D{{"txn-revno", ports.doc.TxnRevno}}
+			egress := append([]EgressRule{}, ports.doc.Egress...)
+			ops = append(ops, setEgressDocOps(p.st, ports.doc, assert, append(egress, rule))...)
+		}
+		return ops, nil
+	}
+	if err = p.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	p.areNew = false
+	p.doc.Egress = append(p.doc.Egress, rule)
+	return nil
+}
+
+// CloseEgress removes the specified egress rule from the list of
+// rules maintained by this document.
+func (p *Ports) CloseEgress(rule EgressRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot deny egress %s", rule)
+
+	if err = rule.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	var newEgress []EgressRule
+	ports := Ports{st: p.st, doc: p.doc, areNew: p.areNew}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err = ports.Refresh(); errors.IsNotFound(err) {
+				// No longer exists, nothing to do.
+				return nil, statetxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		newEgress = newEgress[0:0]
+
+		found := false
+		for _, existing := range ports.doc.Egress {
+			if existing.equals(rule) {
+				found = true
+				continue
+			}
+			newEgress = append(newEgress, existing)
+		}
+		if !found {
+			return nil, statetxn.ErrNoOperations
+		}
+		if len(newEgress) == 0 && len(ports.doc.Ports) == 0 {
+			// Nothing left, so remove the ports doc instead.
+			return p.removeOps(), nil
+		}
+		assert := bson.D{{"txn-revno", ports.doc.TxnRevno}}
+		return setEgressDocOps(p.st, ports.doc, assert, newEgress), nil
+	}
+	if err = p.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	p.doc.Egress = newEgress
+	return nil
+}
+
+// EgressForUnit returns the egress rules associated with the
+// specified unitName that are maintained on this document.
+func (p *Ports) EgressForUnit(unitName string) []EgressRule {
+	rules := []EgressRule{}
+	for _, rule := range p.doc.Egress {
+		if rule.UnitName == unitName {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// AllEgressRules returns the egress rules allowed by all units
+// on the machine. Rules for the same port range are merged.
+func (p *Ports) AllEgressRules() []network.EgressRule {
+	destinations := make(map[network.PortRange]set.Strings)
+	var order []network.PortRange
+	for _, rule := range p.doc.Egress {
+		portRange := network.PortRange{
+			FromPort: rule.FromPort,
+			ToPort:   rule.ToPort,
+			Protocol: rule.Protocol,
+		}
+		cidrs, ok := destinations[portRange]
+		if !ok {
+			cidrs = set.NewStrings()
+			destinations[portRange] = cidrs
+			order = append(order, portRange)
+		}
+		if len(rule.DestinationCIDRs) == 0 {
+			cidrs.Add("0.0.0.0/0")
+		}
+		for _, cidr := range rule.DestinationCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+	result := make([]network.EgressRule, len(order))
+	for i, portRange := range order {
+		result[i] = network.EgressRule{
+			PortRange:        portRange,
+			DestinationCIDRs: destinations[portRange].SortedValues(),
+		}
+	}
+	network.SortEgressRules(result)
+	return result
+}
+
 // PortsForUnit returns the ports associated with specified unitName that are
 // maintained on this document (i.e. are open on this unit's assigned machine).
 func (p *Ports) PortsForUnit(unitName string) []PortRange {
@@ -347,6 +623,14 @@ func (p *Ports) Refresh() error {
 	return nil
 }
 
+// PortRanges returns the port ranges maintained on this document,
+// along with the units and endpoints they are opened for.
+func (p *Ports) PortRanges() []PortRange {
+	result := make([]PortRange, len(p.doc.Ports))
+	copy(result, p.doc.Ports)
+	return result
+}
+
 // AllPortRanges returns a map with network.PortRange as keys and unit
 // names as values.
 func (p *Ports) AllPortRanges() map[network.PortRange]string {
@@ -406,6 +690,21 @@ func (m *Machine) AllPorts() ([]*Ports, error) {
 	return results, nil
 }
 
+// EgressRules returns the egress rules allowed by all the units
+// on this machine.
+func (m *Machine) EgressRules() ([]network.EgressRule, error) {
+	allPorts, err := m.AllPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []network.EgressRule
+	for _, ports := range allPorts {
+		result = append(result, ports.AllEgressRules()...)
+	}
+	network.SortEgressRules(result)
+	return result, nil
+}
+
 // addPortsDocOps returns the ops for adding a number of port ranges
 // to a new ports document. portsAssert allows specifying an assert
 // statement for on the openedPorts collection op.
@@ -465,6 +764,11 @@ func updatePortsDocOpsFunc(st *State, pDoc portsDoc, portsAssert interface{}, po
 var setPortsDocOps = setPortsDocOpsFunc
 
 func setPortsDocOpsFunc(st *State, pDoc portsDoc, portsAssert interface{}, ports ...PortRange) []txn.Op {
+	if ports == nil {
+		// Ports must remain an array, so that ports can
+		// be added to it later.
+		ports = []PortRange{}
+	}
 	ops := assertMachineNotDeadAndSubnetNotDeadWhenSetOps(st, &pDoc)
 	return append(ops, txn.Op{
 		C:      openedPortsC,
@@ -474,6 +778,19 @@ func setPortsDocOpsFunc(st *State, pDoc portsDoc, portsAssert interface{}, ports
 	})
 }
 
+// setEgressDocOps returns the ops for setting the given egress rules
+// on an existing ports document. portsAssert allows specifying an
+// assert statement on the openedPorts collection op.
+func setEgressDocOps(st *State, pDoc portsDoc, portsAssert interface{}, egress []EgressRule) []txn.Op {
+	ops := assertMachineNotDeadAndSubnetNotDeadWhenSetOps(st, &pDoc)
+	return append(ops, txn.Op{
+		C:      openedPortsC,
+		Id:     pDoc.DocID,
+		Assert: portsAssert,
+		Update: bson.D{{"$set", bson.D{{"egress", egress}}}},
+	})
+}
+
 // removeOps returns the ops for removing the ports document from
 // state.
 func (p *Ports) removeOps() []txn.Op {
@@ -518,9 +835,22 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 				keepPorts = append(keepPorts, unitRange)
 			}
 		}
-		if len(keepPorts) > 0 {
+		var keepEgress []EgressRule
+		for _, rule := range ports.doc.Egress {
+			if rule.UnitName != unit.Name() {
+				keepEgress = append(keepEgress, rule)
+			}
+		}
+		if len(keepPorts) > 0 || len(keepEgress) > 0 {
 			assert := bson.D{{"txn-revno", ports.doc.TxnRevno}}
 			ops = append(ops, setPortsDocOps(st, ports.doc, assert, keepPorts...)...)
+			if len(keepEgress) != len(ports.doc.Egress) {
+				ops = append(ops, txn.Op{
+					C:      openedPortsC,
+					Id:     ports.doc.DocID,
+					Update: bson.D{{"$set", bson.D{{"egress", keepEgress}}}},
+				})
+			}
 		} else {
 			// No other ports left, remove the doc.
 			ops = append(ops, ports.removeOps()...)
@@ -299,6 +299,50 @@ func (s *PortsDocSuite) TestAllPortRanges(c *gc.C) {
 	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], gc.Equals, s.unit1.Name())
 }
 
+func (s *PortsDocSuite) TestOpenPortsOnEndpoint(c *gc.C) {
+	err := s.unit1.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit1.OpenPorts("tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := state.GetOrCreatePorts(s.State, s.machine.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.DeepEquals, []state.PortRange{{
+		UnitName: s.unit1.Name(), FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "url",
+	}, {
+		UnitName: s.unit1.Name(), FromPort: 443, ToPort: 443, Protocol: "tcp",
+	}})
+
+	// Opening a range again for a different endpoint moves it.
+	err = s.unit1.OpenPortsOnEndpoint("db", "tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ports.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.DeepEquals, []state.PortRange{{
+		UnitName: s.unit1.Name(), FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "url",
+	}, {
+		UnitName: s.unit1.Name(), FromPort: 443, ToPort: 443, Protocol: "tcp", Endpoint: "db",
+	}})
+
+	// Other units still conflict.
+	err = s.unit2.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/1" endpoint "url"\) for unit "wordpress/1": .*conflict`)
+
+	// Closing a range closes it whatever its endpoint.
+	err = s.unit1.ClosePorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ports.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.DeepEquals, []state.PortRange{{
+		UnitName: s.unit1.Name(), FromPort: 443, ToPort: 443, Protocol: "tcp", Endpoint: "db",
+	}})
+}
+
+func (s *PortsDocSuite) TestOpenPortsOnUnknownEndpoint(c *gc.C) {
+	err := s.unit1.OpenPortsOnEndpoint("missing", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports .*: application "wordpress" has no "missing" relation`)
+}
+
 func (s *PortsDocSuite) TestOpenInvalidRange(c *gc.C) {
 	portRange := state.PortRange{
 		FromPort: 400,
@@ -359,6 +403,100 @@ func (s *PortsDocSuite) TestRemovePortsDoc(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `ports for machine "0", subnet "0.1.2.0/24" not found`)
 }
 
+func (s *PortsDocSuite) TestOpenAndCloseEgress(c *gc.C) {
+	rule1, err := state.NewEgressRule(s.unit1.Name(), 443, 443, "TCP", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	rule2, err := state.NewEgressRule(s.unit2.Name(), 53, 53, "udp")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.portsWithoutSubnet.OpenEgress(rule1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.OpenEgress(rule2)
+	c.Assert(err, jc.ErrorIsNil)
+	// Opening the same rule again is ignored.
+	err = s.portsWithoutSubnet.OpenEgress(rule1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := state.GetPorts(s.State, s.machine.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.EgressForUnit(s.unit1.Name()), jc.DeepEquals, []state.EgressRule{rule1})
+	c.Assert(ports.AllEgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+
+	err = s.portsWithoutSubnet.CloseEgress(rule1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.CloseEgress(rule2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// With no ports opened and no egress allowed,
+	// the document is removed.
+	_, err = state.GetPorts(s.State, s.machine.Id(), "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *PortsDocSuite) TestClosePortsKeepsEgress(c *gc.C) {
+	portRange := state.PortRange{
+		FromPort: 80,
+		ToPort:   80,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+	}
+	err := s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	rule, err := state.NewEgressRule(s.unit1.Name(), 443, 443, "tcp")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.OpenEgress(rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.portsWithoutSubnet.ClosePorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	egress, err := s.machine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+
+	// Ports can still be opened afterwards.
+	err = s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *PortsDocSuite) TestOpenEgressInvalidDestination(c *gc.C) {
+	_, err := state.NewEgressRule(s.unit1.Name(), 443, 443, "tcp", "10.0/8")
+	c.Assert(err, gc.ErrorMatches, `invalid destination "10.0/8"`)
+}
+
+func (s *PortsDocSuite) TestWatchMachineOpenedPorts(c *gc.C) {
+	f := factory.NewFactory(s.State)
+	otherMachine := f.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	otherUnit := f.MakeUnit(c, &factory.UnitParams{Application: s.service, Machine: otherMachine})
+
+	w := s.machine.WatchOpenedPorts()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Ports opened on another machine are not reported.
+	err := otherUnit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Ports opened on the machine, on any subnet, are.
+	err = s.portsOnSubnet.OpenPorts(state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	err = s.unit2.OpenPort("udp", 53)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *PortsDocSuite) TestWatchPorts(c *gc.C) {
 	// No port ranges open initially, no changes.
 	w := s.State.WatchOpenedPorts()
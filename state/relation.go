@@ -352,6 +352,39 @@ func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
 	return r.unit(unitName, principal, isPrincipal, checkUnitLife)
 }
 
+// UnitsInScope returns the names, sorted, of the units of the named
+// application that are in scope in the relation and not departing.
+func (r *Relation) UnitsInScope(applicationname string) ([]string, error) {
+	ep, err := r.Endpoint(applicationname)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relationScopes, closer := r.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	var docs []relationScopeDoc
+	sel := bson.D{
+		{"key", bson.D{{"$regex", "^" + r.globalScope() + "#"}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get units in scope of %q", r)
+	}
+	var unitNames []string
+	for _, doc := range docs {
+		parts := strings.Split(doc.Key, "#")
+		if parts[len(parts)-2] != string(ep.Role) {
+			continue
+		}
+		unitName := doc.unitName()
+		if strings.HasPrefix(unitName, applicationname+"/") {
+			unitNames = append(unitNames, unitName)
+		}
+	}
+	sort.Strings(unitNames)
+	return unitNames, nil
+}
+
 // IsCrossModel returns whether this relation is a cross-model
 // relation.
 func (r *Relation) IsCrossModel() (bool, error) {
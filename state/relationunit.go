@@ -16,6 +16,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
 )
 
@@ -416,8 +417,9 @@ func (ru *RelationUnit) ReadSettings(uname string) (m map[string]interface{}, er
 // `private-address` in the settings for the this unit in the context
 // of this relation. Generally this will be the cloud-local address of
 // the unit, but if this is a cross-model relation then it will be the
-// public address. If this is cross-model and there's no public
-// address for the unit, return an error.
+// public address, unless the unit's endpoint is bound to a space the
+// unit's machine has an address in. If this is cross-model and there's
+// no public address for the unit, return an error.
 func (ru *RelationUnit) SettingsAddress() (network.Address, error) {
 	unit, err := ru.st.Unit(ru.unitName)
 	if err != nil {
@@ -426,9 +428,16 @@ func (ru *RelationUnit) SettingsAddress() (network.Address, error) {
 	if crossmodel, err := ru.relation.IsCrossModel(); err != nil {
 		return network.Address{}, errors.Trace(err)
 	} else if !crossmodel {
-		return unit.PrivateAddress()
+		return ru.boundAddress(unit)
 	}
 
+	// The space an endpoint in a cross-model relation is bound to
+	// is the one the remote model is expected to reach it in.
+	if address, err := ru.spaceAddress(unit); err == nil {
+		return address, nil
+	} else if !errors.IsNotFound(err) {
+		return network.Address{}, errors.Trace(err)
+	}
 	address, err := unit.PublicAddress()
 	if err != nil {
 		// TODO(wallyworld) - it's ok to return a private address sometimes
@@ -446,6 +455,45 @@ func (ru *RelationUnit) SettingsAddress() (network.Address, error) {
 	return address, nil
 }
 
+// boundAddress returns the address of the unit's machine in the space the
+// unit's endpoint is bound to. The unit's private address is returned when
+// the endpoint is bound to the default space, or the machine has no address
+// in the bound space.
+func (ru *RelationUnit) boundAddress(unit *Unit) (network.Address, error) {
+	address, err := ru.spaceAddress(unit)
+	if errors.IsNotFound(err) {
+		return unit.PrivateAddress()
+	}
+	return address, errors.Trace(err)
+}
+
+// spaceAddress returns the address of the unit's machine in the space
+// the unit's endpoint is bound to. It returns a NotFound error when the
+// endpoint is bound to the default space, or the machine has no address
+// in the bound space.
+func (ru *RelationUnit) spaceAddress(unit *Unit) (network.Address, error) {
+	spaceName, err := unit.GetSpaceForBinding(ru.endpoint.Name)
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	if spaceName == environs.DefaultSpaceName {
+		return network.Address{}, errors.NotFoundf("space binding of endpoint %q", ru.endpoint.Name)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	machine, err := ru.st.Machine(machineId)
+	if err != nil {
+		return network.Address{}, errors.Trace(err)
+	}
+	address, err := machine.addressInSpace(spaceName)
+	if errors.IsNotFound(err) {
+		logger.Warningf("no address in space %q for unit %q in relation %q", spaceName, unit.Name(), ru.relation)
+	}
+	return address, errors.Trace(err)
+}
+
 // unitKey returns a string, based on the relation and the supplied unit name,
 // which is used as a key for that unit within this relation in the settings,
 // presence, and relationScopes collections.
@@ -188,6 +188,32 @@ func (s *RelationUnitSuite) TestRemoteUnitErrors(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `application "mysql1" is not a member of "wordpress:db mysql:server"`)
 }
 
+func (s *RelationUnitSuite) TestUnitsInScope(c *gc.C) {
+	prr := newRemoteProReqRelation(c, &s.ConnSuite)
+	err := prr.pru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitNames, err := prr.rel.UnitsInScope("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitNames, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	unitNames, err = prr.rel.UnitsInScope("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitNames, jc.DeepEquals, []string{"wordpress/0"})
+
+	err = prr.pru0.PrepareLeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	unitNames, err = prr.rel.UnitsInScope("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitNames, jc.DeepEquals, []string{"mysql/1"})
+
+	_, err = prr.rel.UnitsInScope("riak")
+	c.Assert(err, gc.ErrorMatches, `application "riak" is not a member of "wordpress:db mysql:server"`)
+}
+
 func (s *RelationUnitSuite) TestProReqSettings(c *gc.C) {
 	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
 	s.testProReqSettings(c, prr.pru0, prr.pru1, prr.rru0, prr.rru1)
@@ -831,6 +857,43 @@ func (s *RelationUnitSuite) TestSettingsAddress(c *gc.C) {
 	c.Assert(address, gc.DeepEquals, network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal))
 }
 
+func (s *RelationUnitSuite) TestSettingsAddressBoundSpace(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.pu0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.pu0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.10.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth1",
+		Type: state.EthernetDevice,
+		IsUp: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth1",
+		CIDRAddress:  "10.10.0.5/24",
+		ConfigMethod: state.StaticAddress,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.psvc.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	address, err := prr.pru0.SettingsAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.DeepEquals, network.NewAddress("10.10.0.5"))
+}
+
 func (s *RelationUnitSuite) TestSettingsAddressRemoteRelation(c *gc.C) {
 	prr := newRemoteProReqRelation(c, &s.ConnSuite)
 	err := prr.ru0.AssignToNewMachine()
@@ -851,6 +914,43 @@ func (s *RelationUnitSuite) TestSettingsAddressRemoteRelation(c *gc.C) {
 	c.Assert(address, gc.DeepEquals, network.NewScopedAddress("4.3.2.1", network.ScopePublic))
 }
 
+func (s *RelationUnitSuite) TestSettingsAddressRemoteRelationBoundSpace(c *gc.C) {
+	prr := newRemoteProReqRelation(c, &s.ConnSuite)
+	err := prr.ru0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.ru0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(
+		network.NewScopedAddress("4.3.2.1", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.10.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth1",
+		Type: state.EthernetDevice,
+		IsUp: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth1",
+		CIDRAddress:  "10.10.0.5/24",
+		ConfigMethod: state.StaticAddress,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rsvc.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	address, err := prr.rru0.SettingsAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.DeepEquals, network.NewAddress("10.10.0.5"))
+}
+
 func (s *RelationUnitSuite) TestSettingsAddressRemoteRelationNoPublicAddr(c *gc.C) {
 	prr := newRemoteProReqRelation(c, &s.ConnSuite)
 	err := prr.ru0.AssignToNewMachine()
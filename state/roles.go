@@ -0,0 +1,298 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// roleDoc records the definition of a role within the controller.
+type roleDoc struct {
+	Name         string   `bson:"_id"`
+	Capabilities []string `bson:"capabilities"`
+}
+
+func (d roleDoc) toRole() permission.Role {
+	capabilities := make([]permission.Capability, len(d.Capabilities))
+	for i, c := range d.Capabilities {
+		capabilities[i] = permission.Capability(c)
+	}
+	return permission.Role{
+		Name:         d.Name,
+		Capabilities: capabilities,
+	}
+}
+
+// roleGrantDoc records that a user has been granted a role on a
+// model.
+type roleGrantDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	UserName  string `bson:"user"`
+	Role      string `bson:"role"`
+}
+
+// RoleGrant describes a role granted to a user on a model.
+type RoleGrant struct {
+	User names.UserTag
+	Role permission.Role
+}
+
+func createRoleOp(role permission.Role) txn.Op {
+	capabilities := make([]string, len(role.Capabilities))
+	for i, c := range role.Capabilities {
+		capabilities[i] = string(c)
+	}
+	return txn.Op{
+		C:      rolesC,
+		Id:     role.Name,
+		Assert: txn.DocMissing,
+		Insert: &roleDoc{
+			Name:         role.Name,
+			Capabilities: capabilities,
+		},
+	}
+}
+
+func roleGrantID(user names.UserTag, role string) string {
+	return userAccessID(user) + "#" + role
+}
+
+// AddRole defines a new role within the controller.
+func (st *State) AddRole(role permission.Role) error {
+	if err := role.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{createRoleOp(role)}
+	if err := st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.AlreadyExistsf("role %q", role.Name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Role returns the definition of the named role.
+func (st *State) Role(name string) (permission.Role, error) {
+	roles, closer := st.db().GetCollection(rolesC)
+	defer closer()
+
+	var doc roleDoc
+	err := roles.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return permission.Role{}, errors.NotFoundf("role %q", name)
+	} else if err != nil {
+		return permission.Role{}, errors.Annotatef(err, "cannot get role %q", name)
+	}
+	return doc.toRole(), nil
+}
+
+// AllRoles returns the definitions of all roles in the controller,
+// sorted by name.
+func (st *State) AllRoles() ([]permission.Role, error) {
+	roles, closer := st.db().GetCollection(rolesC)
+	defer closer()
+
+	var docs []roleDoc
+	if err := roles.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get roles")
+	}
+	result := make([]permission.Role, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.toRole()
+	}
+	return result, nil
+}
+
+// GrantRole grants the named role to a user on the model. The user
+// must already have access to the model.
+func (st *State) GrantRole(user names.UserTag, role string) error {
+	ops := []txn.Op{{
+		C:      rolesC,
+		Id:     role,
+		Assert: txn.DocExists,
+	}, {
+		C:      modelUsersC,
+		Id:     userAccessID(user),
+		Assert: txn.DocExists,
+	}, {
+		C:      roleGrantsC,
+		Id:     roleGrantID(user, role),
+		Assert: txn.DocMissing,
+		Insert: &roleGrantDoc{
+			UserName: userAccessID(user),
+			Role:     role,
+		},
+	}}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.Role(role); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if _, err := st.modelUser(st.ModelUUID(), user); err != nil {
+				return nil, errors.Trace(err)
+			}
+			return nil, errors.AlreadyExistsf("role %q for user %q", role, user.Id())
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
+
+// RevokeRole revokes the named role from a user on the model.
+func (st *State) RevokeRole(user names.UserTag, role string) error {
+	ops := []txn.Op{{
+		C:      roleGrantsC,
+		Id:     roleGrantID(user, role),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("role %q for user %q", role, user.Id())
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UserRoles returns the roles granted to the user on the target,
+// which must be a model for any roles to be found.
+func (st *State) UserRoles(user names.UserTag, target names.Tag) ([]permission.Role, error) {
+	modelTag, ok := target.(names.ModelTag)
+	if !ok {
+		return nil, nil
+	}
+	grants, closer := st.db().GetCollectionFor(modelTag.Id(), roleGrantsC)
+	defer closer()
+
+	var docs []roleGrantDoc
+	if err := grants.Find(bson.D{{"user", userAccessID(user)}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get roles for user %q", user.Id())
+	}
+	return st.grantedRoles(docs)
+}
+
+// AllRoleGrants returns all of the roles granted to users on the
+// model.
+func (st *State) AllRoleGrants() ([]RoleGrant, error) {
+	grants, closer := st.db().GetCollection(roleGrantsC)
+	defer closer()
+
+	var docs []roleGrantDoc
+	if err := grants.Find(nil).Sort("user", "role").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get role grants")
+	}
+	roles, err := st.grantedRoles(docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]RoleGrant, len(docs))
+	for i, doc := range docs {
+		result[i] = RoleGrant{
+			User: names.NewUserTag(doc.UserName),
+			Role: roles[i],
+		}
+	}
+	return result, nil
+}
+
+// grantedRoles returns the role definitions for the given grants, in
+// the same order.
+func (st *State) grantedRoles(docs []roleGrantDoc) ([]permission.Role, error) {
+	result := make([]permission.Role, len(docs))
+	for i, doc := range docs {
+		role, err := st.Role(doc.Role)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = role
+	}
+	return result, nil
+}
+
+// removeRoleGrantsForUserOps returns the operations required to
+// remove all of the roles granted to the user on the model.
+func (st *State) removeRoleGrantsForUserOps(user names.UserTag) ([]txn.Op, error) {
+	grants, closer := st.db().GetCollection(roleGrantsC)
+	defer closer()
+
+	var docs []roleGrantDoc
+	userName := userAccessID(user)
+	if err := grants.Find(bson.D{{"user", userName}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get roles for user %q", user.Id())
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      roleGrantsC,
+			Id:     st.localID(doc.DocID),
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// ImportRoleGrants grants roles to users on a model being imported
+// by a migration, defining any roles not yet known to the controller.
+// It is an error for a role to be defined with different capabilities
+// in this controller.
+func (st *State) ImportRoleGrants(grants []RoleGrant) error {
+	ops, err := st.importRoleGrantOps(grants)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.runTransaction(ops))
+}
+
+func (st *State) importRoleGrantOps(grants []RoleGrant) ([]txn.Op, error) {
+	var ops []txn.Op
+	defined := make(map[string]bool)
+	for _, grant := range grants {
+		role := grant.Role
+		if !defined[role.Name] {
+			existing, err := st.Role(role.Name)
+			if errors.IsNotFound(err) {
+				if err := role.Validate(); err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, createRoleOp(role))
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			} else if !sameCapabilities(existing, role) {
+				return nil, errors.Errorf("role %q has different capabilities in this controller", role.Name)
+			}
+			defined[role.Name] = true
+		}
+		ops = append(ops, txn.Op{
+			C:      roleGrantsC,
+			Id:     roleGrantID(grant.User, role.Name),
+			Assert: txn.DocMissing,
+			Insert: &roleGrantDoc{
+				UserName: userAccessID(grant.User),
+				Role:     role.Name,
+			},
+		})
+	}
+	return ops, nil
+}
+
+func sameCapabilities(a, b permission.Role) bool {
+	if len(a.Capabilities) != len(b.Capabilities) {
+		return false
+	}
+	for _, c := range a.Capabilities {
+		if !b.HasCapability(c) {
+			return false
+		}
+	}
+	return true
+}
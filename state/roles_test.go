@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type RoleSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RoleSuite{})
+
+var operatorRole = permission.Role{
+	Name: "operator",
+	Capabilities: []permission.Capability{
+		permission.ActionsCapability,
+		permission.SSHCapability,
+	},
+}
+
+func (s *RoleSuite) TestAddRole(c *gc.C) {
+	err := s.State.AddRole(operatorRole)
+	c.Assert(err, jc.ErrorIsNil)
+
+	role, err := s.State.Role("operator")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, jc.DeepEquals, operatorRole)
+}
+
+func (s *RoleSuite) TestAddRoleInvalid(c *gc.C) {
+	err := s.State.AddRole(permission.Role{Name: "operator"})
+	c.Assert(err, gc.ErrorMatches, `role "operator" with no capabilities not valid`)
+}
+
+func (s *RoleSuite) TestAddRoleAlreadyExists(c *gc.C) {
+	err := s.State.AddRole(operatorRole)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddRole(operatorRole)
+	c.Assert(err, gc.ErrorMatches, `role "operator" already exists`)
+}
+
+func (s *RoleSuite) TestRoleNotFound(c *gc.C) {
+	_, err := s.State.Role("operator")
+	c.Assert(err, gc.ErrorMatches, `role "operator" not found`)
+}
+
+func (s *RoleSuite) TestAllRoles(c *gc.C) {
+	deployer := permission.Role{
+		Name:         "deployer",
+		Capabilities: []permission.Capability{permission.DeployCapability},
+	}
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	c.Assert(s.State.AddRole(deployer), jc.ErrorIsNil)
+
+	roles, err := s.State.AllRoles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, jc.DeepEquals, []permission.Role{deployer, operatorRole})
+}
+
+func (s *RoleSuite) TestGrantRole(c *gc.C) {
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+
+	err := s.State.GrantRole(user.UserTag(), "operator")
+	c.Assert(err, jc.ErrorIsNil)
+
+	roles, err := s.State.UserRoles(user.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, jc.DeepEquals, []permission.Role{operatorRole})
+
+	grants, err := s.State.AllRoleGrants()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.RoleGrant{{
+		User: user.UserTag(),
+		Role: operatorRole,
+	}})
+}
+
+func (s *RoleSuite) TestGrantRoleAlreadyGranted(c *gc.C) {
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	c.Assert(s.State.GrantRole(user.UserTag(), "operator"), jc.ErrorIsNil)
+
+	err := s.State.GrantRole(user.UserTag(), "operator")
+	c.Assert(err, gc.ErrorMatches, `role "operator" for user "bob" already exists`)
+}
+
+func (s *RoleSuite) TestGrantRoleUnknownRole(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := s.State.GrantRole(user.UserTag(), "operator")
+	c.Assert(err, gc.ErrorMatches, `role "operator" not found`)
+}
+
+func (s *RoleSuite) TestGrantRoleUserWithoutModelAccess(c *gc.C) {
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	err := s.State.GrantRole(user.UserTag(), "operator")
+	c.Assert(err, gc.ErrorMatches, `model user "bob" not found`)
+}
+
+func (s *RoleSuite) TestRevokeRole(c *gc.C) {
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	c.Assert(s.State.GrantRole(user.UserTag(), "operator"), jc.ErrorIsNil)
+
+	err := s.State.RevokeRole(user.UserTag(), "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	roles, err := s.State.UserRoles(user.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, gc.HasLen, 0)
+
+	err = s.State.RevokeRole(user.UserTag(), "operator")
+	c.Assert(err, gc.ErrorMatches, `role "operator" for user "bob" not found`)
+}
+
+func (s *RoleSuite) TestRemoveModelUserRevokesRoles(c *gc.C) {
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	c.Assert(s.State.GrantRole(user.UserTag(), "operator"), jc.ErrorIsNil)
+
+	err := s.State.RemoveUserAccess(user.UserTag(), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	grants, err := s.State.AllRoleGrants()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, gc.HasLen, 0)
+}
+
+func (s *RoleSuite) TestUserRolesOtherModel(c *gc.C) {
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	c.Assert(s.State.GrantRole(user.UserTag(), "operator"), jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	roles, err := s.State.UserRoles(user.UserTag(), st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, gc.HasLen, 0)
+
+	roles, err = s.State.UserRoles(user.UserTag(), s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, gc.HasLen, 0)
+}
+
+func (s *RoleSuite) TestImportRoleGrants(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	bob := names.NewUserTag("bob@external")
+	err := st.ImportRoleGrants([]state.RoleGrant{{User: bob, Role: operatorRole}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	role, err := s.State.Role("operator")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, jc.DeepEquals, operatorRole)
+	grants, err := st.AllRoleGrants()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.RoleGrant{{User: bob, Role: operatorRole}})
+}
+
+func (s *RoleSuite) TestImportRoleGrantsConflictingRole(c *gc.C) {
+	c.Assert(s.State.AddRole(operatorRole), jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	conflicting := permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{permission.DeployCapability},
+	}
+	err := st.ImportRoleGrants([]state.RoleGrant{{User: names.NewUserTag("bob"), Role: conflicting}})
+	c.Assert(err, gc.ErrorMatches, `role "operator" has different capabilities in this controller`)
+}
@@ -1410,6 +1410,24 @@ func (st *State) addMachineWithPlacement(unit *Unit, placement *instance.Placeme
 			Constraints: *unitCons,
 		}
 		if data.machineId != "" {
+			// The host must have access to every space the unit
+			// requires, or the container cannot be connected to them.
+			host, err := st.Machine(data.machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			spaces, err := unit.requiredSpaces()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := validateMachineSpaces(host, spaces); err != nil {
+				return nil, errors.Annotatef(err, "cannot place unit %q in a container", unit.Name())
+			}
+			// Check before creating a container the unit could
+			// not be assigned to.
+			if _, err := unit.antiAffinityOps(data.machineId); err != nil {
+				return nil, errors.Annotatef(err, "cannot place unit %q in a container", unit.Name())
+			}
 			return st.AddMachineInsideMachine(template, data.machineId, data.containerType)
 		}
 		return st.AddMachineInsideNewMachine(template, template, data.containerType)
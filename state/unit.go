@@ -22,6 +22,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/presence"
@@ -979,6 +980,41 @@ func (u *Unit) ClosePortsOnSubnet(subnetID, protocol string, fromPort, toPort in
 	return machinePorts.ClosePorts(ports)
 }
 
+// OpenPortsOnEndpoint opens the given port range and protocol for the
+// unit, for the named endpoint only. An exposed application's ports
+// opened for an endpoint may only be reached from the sources that
+// endpoint is exposed to. If the unit already has the range open, it
+// is moved to the endpoint. An empty endpoint opens the range for all
+// of the unit's endpoints, as OpenPorts does.
+func (u *Unit) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	if endpoint == "" {
+		return u.OpenPorts(protocol, fromPort, toPort)
+	}
+	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
+	if err != nil {
+		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
+	}
+	ports.Endpoint = endpoint
+	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q", ports, u)
+
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := app.Endpoint(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getOrCreatePorts(u.st, machineID, "")
+	if err != nil {
+		return errors.Annotate(err, "cannot get or create ports")
+	}
+	return machinePorts.OpenPorts(ports)
+}
+
 // OpenPorts opens the given port range and protocol for the unit, if it does
 // not conflict with another already opened range on the unit's assigned
 // machine.
@@ -1070,6 +1106,73 @@ func (u *Unit) OpenedPorts() ([]network.PortRange, error) {
 	return u.OpenedPortsOnSubnet("")
 }
 
+// OpenEgress allows outgoing traffic from the unit's assigned machine
+// to the given port range and protocol. If destinationCIDRs are
+// specified, the traffic is only allowed to those destinations.
+func (u *Unit) OpenEgress(protocol string, fromPort, toPort int, destinationCIDRs ...string) (err error) {
+	rule, err := NewEgressRule(u.Name(), fromPort, toPort, protocol, destinationCIDRs...)
+	if err != nil {
+		return errors.Annotatef(err, "invalid egress rule %v-%v/%v", fromPort, toPort, protocol)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot allow egress %v for unit %q", rule, u)
+
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getOrCreatePorts(u.st, machineID, "")
+	if err != nil {
+		return errors.Annotate(err, "cannot get or create ports")
+	}
+	return machinePorts.OpenEgress(rule)
+}
+
+// CloseEgress removes an egress rule previously added with OpenEgress.
+func (u *Unit) CloseEgress(protocol string, fromPort, toPort int, destinationCIDRs ...string) (err error) {
+	rule, err := NewEgressRule(u.Name(), fromPort, toPort, protocol, destinationCIDRs...)
+	if err != nil {
+		return errors.Annotatef(err, "invalid egress rule %v-%v/%v", fromPort, toPort, protocol)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot deny egress %v for unit %q", rule, u)
+
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getOrCreatePorts(u.st, machineID, "")
+	if err != nil {
+		return errors.Annotate(err, "cannot get or create ports")
+	}
+	return machinePorts.CloseEgress(rule)
+}
+
+// EgressRules returns the egress rules added by the unit.
+func (u *Unit) EgressRules() ([]network.EgressRule, error) {
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getPorts(u.st, machineID, "")
+	result := []network.EgressRule{}
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "failed getting egress rules for unit %q", u)
+	}
+	for _, rule := range machinePorts.EgressForUnit(u.Name()) {
+		result = append(result, network.EgressRule{
+			PortRange: network.PortRange{
+				Protocol: rule.Protocol,
+				FromPort: rule.FromPort,
+				ToPort:   rule.ToPort,
+			},
+			DestinationCIDRs: rule.DestinationCIDRs,
+		})
+	}
+	network.SortEgressRules(result)
+	return result, nil
+}
+
 // CharmURL returns the charm URL this unit is currently using.
 func (u *Unit) CharmURL() (*charm.URL, bool) {
 	if u.doc.CharmURL == nil {
@@ -1308,6 +1411,10 @@ func (u *Unit) assignToMachine(m *Machine, unused bool) (err error) {
 // - unitNotAliveErr when the unit is not alive.
 // - alreadyAssignedErr when the unit has already been assigned
 // - inUseErr when the machine already has a unit assigned (if unused is true)
+// - notInSpacesError when the machine has no address in a space the unit requires
+// - lockedForSeriesUpgradeError when the machine is locked for a series upgrade
+// - inMaintenanceError when the machine is in maintenance
+// - antiAffinityError when another unit of the application is on the same host
 func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if u.Life() != Alive {
 		return nil, unitNotAliveErr
@@ -1321,6 +1428,16 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if unused && !m.doc.Clean {
 		return nil, inUseErr
 	}
+	if err := checkNotLockedForSeriesUpgrade(m); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkNotInMaintenance(m); err != nil {
+		return nil, errors.Trace(err)
+	}
+	antiAffinityOps, err := u.antiAffinityOps(m.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageParams, err := u.machineStorageParams()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1334,6 +1451,13 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	spaces, err := u.requiredSpaces()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateMachineSpaces(m, spaces); err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := u.st.machineStorageOps(
 		&m.doc, storageParams,
 	)
@@ -1362,7 +1486,7 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 			{{"machineid", m.Id()}},
 		},
 	}}...)
-	massert := isAliveDoc
+	massert := append(isAliveDoc, notInMaintenanceDoc...)
 	if unused {
 		massert = append(massert, bson.D{{"clean", bson.D{{"$ne", false}}}}...)
 	}
@@ -1378,7 +1502,9 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 		Update: bson.D{{"$addToSet", bson.D{{"principals", u.doc.Name}}}, {"$set", bson.D{{"clean", false}}}},
 	},
 		removeStagedAssignmentOp(u.doc.DocID),
+		assertNoUpgradeSeriesLockOp(u.st, m.Id()),
 	}
+	ops = append(ops, antiAffinityOps...)
 	ops = append(ops, storageOps...)
 	return ops, nil
 }
@@ -1416,6 +1542,77 @@ func validateUnitMachineAssignment(
 	return nil
 }
 
+// notInSpacesError is returned when a machine has no address in some
+// of the spaces a unit assigned to it requires.
+type notInSpacesError struct {
+	machineId string
+	spaces    []string
+}
+
+func (e *notInSpacesError) Error() string {
+	return fmt.Sprintf("machine %q has no address in space(s) %s",
+		e.machineId, network.QuoteSpaces(e.spaces))
+}
+
+// isNotInSpaces reports whether the cause of err is a notInSpacesError.
+func isNotInSpaces(err error) bool {
+	_, ok := errors.Cause(err).(*notInSpacesError)
+	return ok
+}
+
+// requiredSpaces returns the names of the spaces a machine hosting the
+// unit must have access to: the positive spaces in the unit's
+// constraints, and the spaces the application's endpoints are bound to.
+func (u *Unit) requiredSpaces() (set.Strings, error) {
+	spaces := set.NewStrings()
+	cons, err := u.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, space := range cons.IncludeSpaces() {
+		spaces.Add(space)
+	}
+	app, err := u.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bindings, err := app.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, space := range bindings {
+		if space != environs.DefaultSpaceName {
+			spaces.Add(space)
+		}
+	}
+	return spaces, nil
+}
+
+// validateMachineSpaces returns a notInSpacesError if the machine has no
+// address in one or more of the given spaces. A machine without any
+// known addresses has not yet reported its network configuration, and
+// so cannot be rejected.
+func validateMachineSpaces(m *Machine, spaces set.Strings) error {
+	if spaces.IsEmpty() {
+		return nil
+	}
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+	machineSpaces, err := m.AllSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if missing := spaces.Difference(machineSpaces); !missing.IsEmpty() {
+		return &notInSpacesError{m.Id(), missing.SortedValues()}
+	}
+	return nil
+}
+
 // validateDynamicMachineStorageParams validates that the provided machine
 // storage parameters are compatible with the specified machine.
 func validateDynamicMachineStorageParams(m *Machine, params *machineStorageParams) error {
@@ -1583,6 +1780,13 @@ func (u *Unit) assignToNewMachineOps(
 		if !mparent.Clean() {
 			return nil, nil, machineNotCleanErr
 		}
+		spaces, err := u.requiredSpaces()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if err := validateMachineSpaces(mparent, spaces); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
 		containers, err := mparent.Containers()
 		if err != nil {
 			return nil, nil, err
@@ -1662,15 +1866,35 @@ func (u *Unit) AssignToNewMachineOrContainer() (err error) {
 	}
 	machinesCollection, closer := u.st.db().GetCollection(machinesC)
 	defer closer()
-	var host machineDoc
-	if err := machinesCollection.Find(query).One(&host); err == mgo.ErrNotFound {
-		// No existing clean, empty machine so create a new one. The
-		// container constraint will be used by AssignToNewMachine to
-		// create the required container.
-		return u.AssignToNewMachine()
-	} else if err != nil {
+	var hosts []*machineDoc
+	if err := machinesCollection.Find(query).All(&hosts); err != nil {
 		return err
 	}
+	spaces, err := u.requiredSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var host *machineDoc
+	for _, mdoc := range hosts {
+		m := newMachine(u.st, mdoc)
+		err := validateMachineSpaces(m, spaces)
+		if err == nil {
+			err = checkNotLockedForSeriesUpgrade(m)
+		}
+		if isNotInSpaces(err) || isLockedForSeriesUpgrade(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		host = mdoc
+		break
+	}
+	if host == nil {
+		// No existing clean, empty machine with access to the unit's
+		// spaces, and not upgrading its series, so create a new one. The container constraint will be
+		// used by AssignToNewMachine to create the required container.
+		return u.AssignToNewMachine()
+	}
 
 	var m *Machine
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1996,6 +2220,7 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 		{"series", u.doc.Series},
 		{"jobs", []MachineJob{JobHostUnits}},
 		{"clean", true},
+		{"maintenance", bson.D{{"$ne", true}}},
 		{"machineid", bson.D{{"$nin", machinesWithContainers}}},
 	}
 	// Add the container filter term if necessary.
@@ -2198,6 +2423,9 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 		if err == nil {
 			return m, ops, nil
 		}
+		if isNotInSpaces(err) || isLockedForSeriesUpgrade(err) || isInMaintenance(err) || IsAntiAffinityError(err) {
+			continue
+		}
 		switch errors.Cause(err) {
 		case inUseErr, machineNotAliveErr:
 		default:
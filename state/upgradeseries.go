@@ -0,0 +1,423 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/upgradeseries"
+)
+
+// upgradeSeriesLockDoc records that a machine is locked for an in-place
+// series upgrade, and how far through the upgrade the machine and each
+// of the units deployed to it have got.
+type upgradeSeriesLockDoc struct {
+	DocID         string                          `bson:"_id"`
+	Id            string                          `bson:"machine-id"`
+	ModelUUID     string                          `bson:"model-uuid"`
+	FromSeries    string                          `bson:"from-series"`
+	ToSeries      string                          `bson:"to-series"`
+	MachineStatus upgradeseries.Status            `bson:"machine-status"`
+	UnitStatuses  map[string]upgradeseries.Status `bson:"unit-statuses"`
+}
+
+// lockedForSeriesUpgradeError is returned when an operation is refused
+// because the machine it concerns is being upgraded to a new series.
+type lockedForSeriesUpgradeError struct {
+	machineId string
+}
+
+func (e *lockedForSeriesUpgradeError) Error() string {
+	return fmt.Sprintf("machine %q is locked for series upgrade", e.machineId)
+}
+
+// isLockedForSeriesUpgrade reports whether the cause of err is a
+// lockedForSeriesUpgradeError.
+func isLockedForSeriesUpgrade(err error) bool {
+	_, ok := errors.Cause(err).(*lockedForSeriesUpgradeError)
+	return ok
+}
+
+// checkNotLockedForSeriesUpgrade returns a lockedForSeriesUpgradeError
+// if the machine is locked for a series upgrade.
+func checkNotLockedForSeriesUpgrade(m *Machine) error {
+	locked, err := m.IsLockedForSeriesUpgrade()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if locked {
+		return &lockedForSeriesUpgradeError{m.Id()}
+	}
+	return nil
+}
+
+// assertNoUpgradeSeriesLockOp returns a txn.Op that asserts the machine
+// with the given id is not locked for a series upgrade.
+func assertNoUpgradeSeriesLockOp(st *State, machineId string) txn.Op {
+	return txn.Op{
+		C:      upgradeSeriesLocksC,
+		Id:     st.docID(machineId),
+		Assert: txn.DocMissing,
+	}
+}
+
+func removeUpgradeSeriesLockOp(st *State, machineId string) txn.Op {
+	return txn.Op{
+		C:      upgradeSeriesLocksC,
+		Id:     st.docID(machineId),
+		Remove: true,
+	}
+}
+
+// CreateUpgradeSeriesLock locks the machine for an in-place upgrade to
+// the given series. While the machine is locked, no unit can be assigned
+// to it, no container can be added to it and it cannot be destroyed.
+// Every unit deployed to the machine is recorded in the lock so that the
+// progress of its series upgrade hooks can be tracked.
+func (m *Machine) CreateUpgradeSeriesLock(toSeries string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot lock machine %q for series upgrade", m.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, errors.New("machine is not alive")
+		}
+		if m.IsManager() {
+			return nil, errors.New("machine is a controller")
+		}
+		if toSeries == m.doc.Series {
+			return nil, errors.Errorf("machine is already running series %q", toSeries)
+		}
+		locked, err := m.IsLockedForSeriesUpgrade()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if locked {
+			return nil, errors.New("machine is already locked")
+		}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitStatuses := make(map[string]upgradeseries.Status, len(units))
+		for _, unit := range units {
+			if err := checkUnitSupportsSeries(unit, toSeries); err != nil {
+				return nil, errors.Trace(err)
+			}
+			unitStatuses[unit.Name()] = upgradeseries.PrepareStarted
+		}
+		// The units deployed to the machine must not change while the
+		// lock is created.
+		principalsUnchanged := bson.D{{"principals", m.doc.Principals}}
+		if len(m.doc.Principals) == 0 {
+			principalsUnchanged = bson.D{{"$or", []bson.D{
+				{{"principals", bson.D{{"$size", 0}}}},
+				{{"principals", bson.D{{"$exists", false}}}},
+			}}}
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: append(bson.D{{"life", Alive}, {"series", m.doc.Series}}, principalsUnchanged...),
+		}, {
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &upgradeSeriesLockDoc{
+				Id:            m.Id(),
+				FromSeries:    m.doc.Series,
+				ToSeries:      toSeries,
+				MachineStatus: upgradeseries.PrepareStarted,
+				UnitStatuses:  unitStatuses,
+			},
+		}}, nil
+	}
+	return m.st.run(buildTxn)
+}
+
+// checkUnitSupportsSeries returns an error if the unit's charm does
+// not support the given series.
+func checkUnitSupportsSeries(unit *Unit, series string) error {
+	ch, err := unit.charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkCharmSupportsSeries(ch, series, false); err != nil {
+		return errors.Annotatef(err, "unit %q", unit.Name())
+	}
+	return nil
+}
+
+// RemoveUpgradeSeriesLock removes the series upgrade lock of the
+// machine, if any.
+func (m *Machine) RemoveUpgradeSeriesLock() error {
+	ops := []txn.Op{removeUpgradeSeriesLockOp(m.st, m.Id())}
+	if err := m.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove series upgrade lock for machine %q", m.Id())
+	}
+	return nil
+}
+
+// AbortUpgradeSeries removes the series upgrade lock of the machine,
+// cancelling the upgrade. An upgrade can only be aborted while the
+// units are preparing for it; once the machine has completed preparing,
+// its unit agents are stopped and the upgrade must be completed.
+func (m *Machine) AbortUpgradeSeries() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot abort series upgrade of machine %q", m.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		lock, err := m.getUpgradeSeriesLock()
+		if errors.IsNotFound(err) {
+			return nil, errors.New("machine is not locked for series upgrade")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if lock.MachineStatus != upgradeseries.PrepareStarted {
+			return nil, errors.Errorf("machine has completed preparing: preparation status is %q", lock.MachineStatus)
+		}
+		return []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"machine-status", upgradeseries.PrepareStarted}},
+			Remove: true,
+		}}, nil
+	}
+	return m.st.run(buildTxn)
+}
+
+func (m *Machine) getUpgradeSeriesLock() (*upgradeSeriesLockDoc, error) {
+	locks, closer := m.st.db().GetCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	var lock upgradeSeriesLockDoc
+	err := locks.FindId(m.doc.DocID).One(&lock)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("series upgrade lock for machine %q", m.Id())
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get series upgrade lock for machine %q", m.Id())
+	}
+	return &lock, nil
+}
+
+// IsLockedForSeriesUpgrade reports whether the machine is locked for an
+// in-place series upgrade.
+func (m *Machine) IsLockedForSeriesUpgrade() (bool, error) {
+	_, err := m.getUpgradeSeriesLock()
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// UpgradeSeriesTarget returns the series the machine is being upgraded
+// to. It returns a NotFound error if the machine is not locked for a
+// series upgrade.
+func (m *Machine) UpgradeSeriesTarget() (string, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return lock.ToSeries, nil
+}
+
+// UpgradeSeriesStatus returns how far through its series upgrade the
+// machine has got, or upgradeseries.NotStarted if it is not locked for
+// a series upgrade.
+func (m *Machine) UpgradeSeriesStatus() (upgradeseries.Status, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if errors.IsNotFound(err) {
+		return upgradeseries.NotStarted, nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return lock.MachineStatus, nil
+}
+
+// SetUpgradeSeriesStatus records how far through its series upgrade the
+// machine has got.
+func (m *Machine) SetUpgradeSeriesStatus(status upgradeseries.Status) error {
+	if err := status.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      upgradeSeriesLocksC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"machine-status", status}}}},
+	}}
+	err := m.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("series upgrade lock for machine %q", m.Id())
+	}
+	return errors.Trace(err)
+}
+
+// UpgradeSeriesUnitStatuses returns how far through the series upgrade
+// of the machine each unit deployed to it has got, keyed by unit name.
+func (m *Machine) UpgradeSeriesUnitStatuses() (map[string]upgradeseries.Status, error) {
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return lock.UnitStatuses, nil
+}
+
+// SetUpgradeSeriesUnitStatus records how far through the series upgrade
+// of the machine the named unit has got.
+func (m *Machine) SetUpgradeSeriesUnitStatus(unitName string, status upgradeseries.Status) error {
+	if err := status.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	lock, err := m.getUpgradeSeriesLock()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := lock.UnitStatuses[unitName]; !ok {
+		return errors.NotFoundf("unit %q in series upgrade lock for machine %q", unitName, m.Id())
+	}
+	key := fmt.Sprintf("unit-statuses.%s", unitName)
+	ops := []txn.Op{{
+		C:      upgradeSeriesLocksC,
+		Id:     m.doc.DocID,
+		Assert: bson.D{{key, bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$set", bson.D{{key, status}}}},
+	}}
+	err = m.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("series upgrade lock for machine %q", m.Id())
+	}
+	return errors.Trace(err)
+}
+
+// CompleteUpgradeSeries records that the operating system of the
+// machine has been upgraded to the series it is locked for. The series
+// of the machine and of the units deployed to it are updated, and the
+// units are due to run their post-series-upgrade hooks. The machine
+// must have completed preparing for the upgrade.
+func (m *Machine) CompleteUpgradeSeries() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete series upgrade of machine %q", m.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		lock, err := m.getUpgradeSeriesLock()
+		if errors.IsNotFound(err) {
+			return nil, errors.New("machine is not locked for series upgrade")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if lock.MachineStatus == upgradeseries.CompleteStarted {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if lock.MachineStatus != upgradeseries.PrepareCompleted {
+			return nil, errors.Errorf("machine is not ready: preparation status is %q", lock.MachineStatus)
+		}
+		unitStatuses := make(bson.D, 0, len(lock.UnitStatuses))
+		for unitName := range lock.UnitStatuses {
+			unitStatuses = append(unitStatuses, bson.DocElem{
+				"unit-statuses." + unitName, upgradeseries.CompleteStarted,
+			})
+		}
+		ops := []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"machine-status", upgradeseries.PrepareCompleted}},
+			Update: bson.D{{"$set", append(bson.D{
+				{"machine-status", upgradeseries.CompleteStarted},
+			}, unitStatuses...)}},
+		}, {
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+			Update: bson.D{{"$set", bson.D{{"series", lock.ToSeries}}}},
+		}}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     unit.doc.DocID,
+				Assert: notDeadDoc,
+				Update: bson.D{{"$set", bson.D{{"series", lock.ToSeries}}}},
+			})
+		}
+		return ops, nil
+	}
+	return m.st.run(buildTxn)
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher that notifies
+// of changes to the series upgrade lock of the machine.
+func (m *Machine) WatchUpgradeSeriesNotifications() NotifyWatcher {
+	return newEntityWatcher(m.st, upgradeSeriesLocksC, m.doc.DocID)
+}
+
+// assignedMachine returns the machine the unit, or its principal, is
+// assigned to.
+func (u *Unit) assignedMachine() (*Machine, error) {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u.st.Machine(machineId)
+}
+
+// UpgradeSeriesStatus returns how far through the series upgrade of its
+// machine the unit has got, or upgradeseries.NotStarted if the machine
+// is not locked for a series upgrade.
+func (u *Unit) UpgradeSeriesStatus() (upgradeseries.Status, error) {
+	machine, err := u.assignedMachine()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	statuses, err := machine.UpgradeSeriesUnitStatuses()
+	if errors.IsNotFound(err) {
+		return upgradeseries.NotStarted, nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	status, ok := statuses[u.Name()]
+	if !ok {
+		// The unit was deployed after the machine was locked.
+		return upgradeseries.NotStarted, nil
+	}
+	return status, nil
+}
+
+// SetUpgradeSeriesStatus records how far through the series upgrade of
+// its machine the unit has got.
+func (u *Unit) SetUpgradeSeriesStatus(status upgradeseries.Status) error {
+	machine, err := u.assignedMachine()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.SetUpgradeSeriesUnitStatus(u.Name(), status)
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher that notifies
+// of changes to the series upgrade lock of the unit's machine.
+func (u *Unit) WatchUpgradeSeriesNotifications() (NotifyWatcher, error) {
+	machine, err := u.assignedMachine()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine.WatchUpgradeSeriesNotifications(), nil
+}
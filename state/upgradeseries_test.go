@@ -0,0 +1,271 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/upgradeseries"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	ConnSuite
+
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("trusty", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "metered-multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "trusty", "multi-series", ch)
+	s.unit, err = app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLock(c *gc.C) {
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+	machineStatus, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus, gc.Equals, upgradeseries.NotStarted)
+
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err = s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsTrue)
+	target, err := s.machine.UpgradeSeriesTarget()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.Equals, "xenial")
+	machineStatus, err = s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus, gc.Equals, upgradeseries.PrepareStarted)
+	unitStatuses, err := s.machine.UpgradeSeriesUnitStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatuses, jc.DeepEquals, map[string]upgradeseries.Status{
+		"multi-series/0": upgradeseries.PrepareStarted,
+	})
+	unitStatus, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus, gc.Equals, upgradeseries.PrepareStarted)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockTwice(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "0" for series upgrade: machine is already locked`)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockSameSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("trusty")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "0" for series upgrade: machine is already running series "trusty"`)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockUnsupportedSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("precise")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "0" for series upgrade: `+
+		`unit "multi-series/0": only these series are supported: xenial, trusty`)
+
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "1" for series upgrade: `+
+		`unit "wordpress/0": charm "local:quantal/quantal-wordpress-3" only supports series "quantal"`)
+}
+
+func (s *UpgradeSeriesSuite) TestCreateUpgradeSeriesLockController(c *gc.C) {
+	controller, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = controller.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot lock machine "1" for series upgrade: machine is a controller`)
+}
+
+func (s *UpgradeSeriesSuite) TestLockedMachineRefusesUnits(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "multi-series/1" to machine 0: machine "0" is locked for series upgrade`)
+}
+
+func (s *UpgradeSeriesSuite) TestLockedMachineRefusesContainers(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "trusty",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: machine "0" is locked for series upgrade`)
+}
+
+func (s *UpgradeSeriesSuite) TestLockedMachineCannotBeDestroyed(c *gc.C) {
+	err := s.unit.UnassignFromMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Destroy()
+	c.Assert(err, gc.ErrorMatches, `machine "0" is locked for series upgrade`)
+
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestSetUpgradeSeriesStatuses(c *gc.C) {
+	err := s.machine.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.unit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	unitStatus, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus, gc.Equals, upgradeseries.PrepareCompleted)
+
+	err = s.machine.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	machineStatus, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus, gc.Equals, upgradeseries.PrepareCompleted)
+
+	err = s.machine.SetUpgradeSeriesStatus("bad")
+	c.Assert(err, gc.ErrorMatches, `upgrade series status "bad" not valid`)
+	err = s.machine.SetUpgradeSeriesUnitStatus("mysql/0", upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestCompleteUpgradeSeries(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot complete series upgrade of machine "0": `+
+		`machine is not ready: preparation status is "prepare started"`)
+
+	err = s.machine.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "xenial")
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Series(), gc.Equals, "xenial")
+
+	machineStatus, err := s.machine.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus, gc.Equals, upgradeseries.CompleteStarted)
+	unitStatus, err := s.unit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus, gc.Equals, upgradeseries.CompleteStarted)
+
+	// Completing again is a no-op.
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestCompleteUpgradeSeriesNotLocked(c *gc.C) {
+	err := s.machine.CompleteUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot complete series upgrade of machine "0": machine is not locked for series upgrade`)
+}
+
+func (s *UpgradeSeriesSuite) TestRemoveUpgradeSeriesLock(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+
+	// Removing a missing lock is fine.
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestAbortUpgradeSeries(c *gc.C) {
+	err := s.machine.AbortUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot abort series upgrade of machine "0": machine is not locked for series upgrade`)
+
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.AbortUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "trusty")
+}
+
+func (s *UpgradeSeriesSuite) TestAbortUpgradeSeriesPrepared(c *gc.C) {
+	err := s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.AbortUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot abort series upgrade of machine "0": `+
+		`machine has completed preparing: preparation status is "prepare completed"`)
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsTrue)
+}
+
+func (s *UpgradeSeriesSuite) TestWatchUpgradeSeriesNotifications(c *gc.C) {
+	w, err := s.unit.WatchUpgradeSeriesNotifications()
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.machine.CreateUpgradeSeriesLock("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.unit.SetUpgradeSeriesStatus(upgradeseries.PrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -147,6 +147,19 @@ func (st *State) RemoveUser(tag names.UserTag) error {
 			Assert: txn.DocExists,
 			Update: bson.M{"$set": bson.M{"deleted": true}},
 		}}
+		// The user no longer belongs to any group.
+		groups, err := st.findUserGroups(bson.D{{"members", userAccessID(tag)}})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, group := range groups {
+			ops = append(ops, txn.Op{
+				C:      userGroupsC,
+				Id:     group.doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$pull", bson.D{{"members", userAccessID(tag)}}}},
+			})
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -71,6 +71,10 @@ func (st *State) addUserAccess(spec UserAccessSpec, target userAccessTarget) (pe
 		}
 	}
 
+	if err := st.checkGroupExists(spec.User); err != nil {
+		return permission.UserAccess{}, errors.Trace(err)
+	}
+
 	// Ensure local createdBy user exists.
 	if spec.CreatedBy.IsLocal() {
 		if _, err := st.User(spec.CreatedBy); err != nil {
@@ -151,6 +155,8 @@ func (st *State) UserPermission(subject names.UserTag, target names.Tag) (permis
 		return access.Access, nil
 	case names.ApplicationOfferTagKind:
 		return st.GetOfferAccess(target.(names.ApplicationOfferTag), subject)
+	case names.ApplicationTagKind:
+		return st.GetApplicationAccess(target.(names.ApplicationTag), subject)
 	default:
 		return "", errors.NotValidf("%q as a target", target.Kind())
 	}
@@ -0,0 +1,343 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// userGroupDoc records a group of users defined within the controller.
+// Access is granted to a group as if it were the external user named
+// by its tag (see permission.GroupTag), and held by all its members.
+type userGroupDoc struct {
+	DocID       string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Members     []string  `bson:"members"`
+	CreatedBy   string    `bson:"createdby"`
+	DateCreated time.Time `bson:"datecreated"`
+}
+
+// UserGroup represents a group of users in the controller.
+type UserGroup struct {
+	st  *State
+	doc userGroupDoc
+}
+
+// Name returns the name of the group.
+func (g *UserGroup) Name() string {
+	return g.doc.Name
+}
+
+// Tag returns the tag that stands for the group
+// when granting access to it.
+func (g *UserGroup) Tag() names.UserTag {
+	return permission.GroupTag(g.doc.Name)
+}
+
+// CreatedBy returns the name of the user that created the group.
+func (g *UserGroup) CreatedBy() string {
+	return g.doc.CreatedBy
+}
+
+// DateCreated returns when the group was created, in UTC.
+func (g *UserGroup) DateCreated() time.Time {
+	return g.doc.DateCreated.UTC()
+}
+
+// Members returns the users that belong to the group, sorted by name.
+func (g *UserGroup) Members() []names.UserTag {
+	members := make([]string, len(g.doc.Members))
+	copy(members, g.doc.Members)
+	sort.Strings(members)
+	result := make([]names.UserTag, len(members))
+	for i, member := range members {
+		result[i] = names.NewUserTag(member)
+	}
+	return result
+}
+
+// Refresh refreshes the contents of the group from the database.
+func (g *UserGroup) Refresh() error {
+	return errors.Trace(g.st.getUserGroup(g.doc.Name, &g.doc))
+}
+
+// AddMembers adds the given users to the group. Local users must
+// exist, and groups may not be members of other groups. Users that
+// are already members are ignored.
+func (g *UserGroup) AddMembers(users ...names.UserTag) error {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		if err := g.st.checkGroupMember(user); err != nil {
+			return errors.Trace(err)
+		}
+		ids[i] = userAccessID(user)
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"members", bson.D{{"$each", ids}}}}}},
+	}}
+	if err := g.st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("group %q", g.doc.Name)
+		}
+		return errors.Annotatef(err, "cannot add members to group %q", g.doc.Name)
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// RemoveMembers removes the given users from the group. Users that
+// are not members are ignored.
+func (g *UserGroup) RemoveMembers(users ...names.UserTag) error {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = userAccessID(user)
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pullAll", bson.D{{"members", ids}}}},
+	}}
+	if err := g.st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("group %q", g.doc.Name)
+		}
+		return errors.Annotatef(err, "cannot remove members from group %q", g.doc.Name)
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// checkGroupMember returns an error if the given user
+// cannot be made a member of a group.
+func (st *State) checkGroupMember(user names.UserTag) error {
+	if permission.IsGroup(user) {
+		return errors.Errorf("%q is a group, groups cannot be members of groups", user.Id())
+	}
+	if user.IsLocal() {
+		if _, err := st.User(user); err != nil {
+			return errors.Annotatef(err, "user %q does not exist locally", user.Name())
+		}
+	}
+	return nil
+}
+
+// checkGroupExists returns an error if the given tag stands for
+// a group of users in the controller that does not exist.
+func (st *State) checkGroupExists(tag names.UserTag) error {
+	if tag.Domain() != permission.GroupDomain {
+		return nil
+	}
+	_, err := st.UserGroup(tag.Name())
+	return errors.Trace(err)
+}
+
+// AddUserGroup adds a group of users with the given name
+// to the controller. The group starts with no members.
+func (st *State) AddUserGroup(name, creator string) (*UserGroup, error) {
+	if !names.IsValidUserName(name) {
+		return nil, errors.Errorf("invalid group name %q", name)
+	}
+	group := &UserGroup{
+		st: st,
+		doc: userGroupDoc{
+			DocID:       strings.ToLower(name),
+			Name:        name,
+			Members:     []string{},
+			CreatedBy:   creator,
+			DateCreated: st.NowToTheSecond(),
+		},
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     group.doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &group.doc,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.AlreadyExistsf("group %q", name)
+		}
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// UserGroup returns the group of users with the given name.
+func (st *State) UserGroup(name string) (*UserGroup, error) {
+	group := &UserGroup{st: st}
+	if err := st.getUserGroup(name, &group.doc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+func (st *State) getUserGroup(name string, doc *userGroupDoc) error {
+	groups, closer := st.db().GetCollection(userGroupsC)
+	defer closer()
+
+	err := groups.FindId(strings.ToLower(name)).One(doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("group %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot get group %q", name)
+	}
+	return nil
+}
+
+// AllUserGroups returns all of the groups of users
+// in the controller, sorted by name.
+func (st *State) AllUserGroups() ([]*UserGroup, error) {
+	return st.findUserGroups(nil)
+}
+
+// UserGroupsFor returns the tags of the groups that the given
+// user is a member of, sorted by name.
+func (st *State) UserGroupsFor(user names.UserTag) ([]names.UserTag, error) {
+	groups, err := st.findUserGroups(bson.D{{"members", userAccessID(user)}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get groups for user %q", user.Id())
+	}
+	var result []names.UserTag
+	for _, group := range groups {
+		result = append(result, group.Tag())
+	}
+	return result, nil
+}
+
+func (st *State) findUserGroups(query bson.D) ([]*UserGroup, error) {
+	groups, closer := st.db().GetCollection(userGroupsC)
+	defer closer()
+
+	var docs []userGroupDoc
+	if err := groups.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get groups")
+	}
+	result := make([]*UserGroup, len(docs))
+	for i, doc := range docs {
+		result[i] = &UserGroup{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// UserGroupMembership describes a group of users and its members,
+// as transferred by a model migration.
+type UserGroupMembership struct {
+	Name      string
+	CreatedBy string
+	Members   []names.UserTag
+}
+
+// ModelUserGroups returns the groups of users that have been
+// granted access to the model, sorted by name.
+func (st *State) ModelUserGroups() ([]UserGroupMembership, error) {
+	modelUsers, closer := st.db().GetCollection(modelUsersC)
+	defer closer()
+
+	var docs []userAccessDoc
+	if err := modelUsers.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get model users")
+	}
+	var groupNames []string
+	for _, doc := range docs {
+		tag := names.NewUserTag(doc.UserName)
+		if tag.Domain() == permission.GroupDomain {
+			groupNames = append(groupNames, strings.ToLower(tag.Name()))
+		}
+	}
+	if len(groupNames) == 0 {
+		return nil, nil
+	}
+	groups, err := st.findUserGroups(bson.D{{"_id", bson.D{{"$in", groupNames}}}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]UserGroupMembership, len(groups))
+	for i, group := range groups {
+		result[i] = UserGroupMembership{
+			Name:      group.Name(),
+			CreatedBy: group.CreatedBy(),
+			Members:   group.Members(),
+		}
+	}
+	return result, nil
+}
+
+// ImportUserGroups ensures that the groups of users transferred
+// by a model migration exist in the controller, creating them with
+// the given members. Local users that do not exist in this controller
+// are skipped. A group that already exists must have the same members
+// (less any skipped users); groups of the same name with different
+// members are not merged, and no groups are created in that case.
+func (st *State) ImportUserGroups(groups []UserGroupMembership) error {
+	var toCreate []UserGroupMembership
+	for _, g := range groups {
+		var known []names.UserTag
+		for _, member := range g.Members {
+			if member.IsLocal() {
+				_, err := st.User(member)
+				if _, ok := err.(DeletedUserError); ok || errors.IsNotFound(err) {
+					logger.Warningf("not adding unknown user %q to group %q", member.Id(), g.Name)
+					continue
+				} else if err != nil {
+					return errors.Trace(err)
+				}
+			}
+			known = append(known, member)
+		}
+		group, err := st.UserGroup(g.Name)
+		if errors.IsNotFound(err) {
+			g.Members = known
+			toCreate = append(toCreate, g)
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if !sameMembers(group.Members(), known) {
+			return errors.Errorf(
+				"group %q already exists in this controller with different members",
+				g.Name,
+			)
+		}
+	}
+	for _, g := range toCreate {
+		group, err := st.AddUserGroup(g.Name, g.CreatedBy)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(g.Members) == 0 {
+			continue
+		}
+		if err := group.AddMembers(g.Members...); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// sameMembers reports whether the two lists of group
+// members hold the same users.
+func sameMembers(a, b []names.UserTag) bool {
+	ids := make(set.Strings)
+	for _, user := range a {
+		ids.Add(userAccessID(user))
+	}
+	other := make(set.Strings)
+	for _, user := range b {
+		other.Add(userAccessID(user))
+	}
+	return ids.Difference(other).IsEmpty() && other.Difference(ids).IsEmpty()
+}
@@ -0,0 +1,237 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UserGroupSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserGroupSuite{})
+
+func (s *UserGroupSuite) TestAddUserGroup(c *gc.C) {
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "ops")
+	c.Assert(group.Tag(), gc.Equals, names.NewUserTag("ops@group"))
+	c.Assert(group.CreatedBy(), gc.Equals, "admin")
+	c.Assert(group.Members(), gc.HasLen, 0)
+
+	group, err = s.State.UserGroup("OPS")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "ops")
+}
+
+func (s *UserGroupSuite) TestAddUserGroupInvalidName(c *gc.C) {
+	_, err := s.State.AddUserGroup("not/valid", "admin")
+	c.Assert(err, gc.ErrorMatches, `invalid group name "not/valid"`)
+}
+
+func (s *UserGroupSuite) TestAddUserGroupAlreadyExists(c *gc.C) {
+	_, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddUserGroup("Ops", "admin")
+	c.Assert(err, gc.ErrorMatches, `group "Ops" already exists`)
+}
+
+func (s *UserGroupSuite) TestUserGroupNotFound(c *gc.C) {
+	_, err := s.State.UserGroup("ops")
+	c.Assert(err, gc.ErrorMatches, `group "ops" not found`)
+}
+
+func (s *UserGroupSuite) TestAddRemoveMembers(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	bob := names.NewUserTag("bob")
+	mary := names.NewUserTag("mary@external")
+	err = group.AddMembers(bob, mary, bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{bob, mary})
+
+	err = group.RemoveMembers(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{mary})
+}
+
+func (s *UserGroupSuite) TestAddMembersUnknownLocalUser(c *gc.C) {
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(names.NewUserTag("bob"))
+	c.Assert(err, gc.ErrorMatches, `user "bob" does not exist locally: user "bob" not found`)
+}
+
+func (s *UserGroupSuite) TestAddMembersGroup(c *gc.C) {
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(names.NewUserTag("dev@group"))
+	c.Assert(err, gc.ErrorMatches, `"dev@group" is a group, groups cannot be members of groups`)
+}
+
+func (s *UserGroupSuite) TestUserGroupsFor(c *gc.C) {
+	bob := names.NewUserTag("bob@external")
+	for _, name := range []string{"ops", "dev", "qa"} {
+		group, err := s.State.AddUserGroup(name, "admin")
+		c.Assert(err, jc.ErrorIsNil)
+		if name != "qa" {
+			err = group.AddMembers(bob)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+	groups, err := s.State.UserGroupsFor(names.NewUserTag("Bob@external"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, []names.UserTag{
+		names.NewUserTag("dev@group"),
+		names.NewUserTag("ops@group"),
+	})
+
+	all, err := s.State.AllUserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 3)
+	c.Assert(all[2].Name(), gc.Equals, "qa")
+}
+
+func (s *UserGroupSuite) TestGrantAccessToGroup(c *gc.C) {
+	_, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddModelUser(s.State.ModelUUID(), state.UserAccessSpec{
+		User:      permission.GroupTag("ops"),
+		CreatedBy: s.Owner,
+		Access:    permission.WriteAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserPermission(permission.GroupTag("ops"), s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	groups, err := s.State.ModelUserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, []state.UserGroupMembership{{
+		Name:      "ops",
+		CreatedBy: "admin",
+		Members:   []names.UserTag{},
+	}})
+}
+
+func (s *UserGroupSuite) TestGrantAccessToUnknownGroup(c *gc.C) {
+	_, err := s.State.AddControllerUser(state.UserAccessSpec{
+		User:      permission.GroupTag("ops"),
+		CreatedBy: s.Owner,
+		Access:    permission.LoginAccess,
+	})
+	c.Assert(err, gc.ErrorMatches, `group "ops" not found`)
+}
+
+func (s *UserGroupSuite) TestImportUserGroups(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(names.NewUserTag("bob"), names.NewUserTag("mary@external"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ImportUserGroups([]state.UserGroupMembership{{
+		Name:      "ops",
+		CreatedBy: "admin",
+		Members: []names.UserTag{
+			names.NewUserTag("mary@external"),
+			names.NewUserTag("bob"),
+			names.NewUserTag("unknown"),
+		},
+	}, {
+		Name:      "dev",
+		CreatedBy: "someone",
+		Members:   []names.UserTag{names.NewUserTag("bob"), names.NewUserTag("unknown")},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = group.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{
+		names.NewUserTag("bob"),
+		names.NewUserTag("mary@external"),
+	})
+	dev, err := s.State.UserGroup("dev")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.CreatedBy(), gc.Equals, "someone")
+	c.Assert(dev.Members(), jc.DeepEquals, []names.UserTag{names.NewUserTag("bob")})
+}
+
+func (s *UserGroupSuite) TestImportUserGroupsDifferentMembers(c *gc.C) {
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(names.NewUserTag("mary@external"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ImportUserGroups([]state.UserGroupMembership{{
+		Name:      "dev",
+		CreatedBy: "someone",
+		Members:   []names.UserTag{names.NewUserTag("bob@external")},
+	}, {
+		Name:      "ops",
+		CreatedBy: "admin",
+		Members:   []names.UserTag{names.NewUserTag("bob@external")},
+	}})
+	c.Assert(err, gc.ErrorMatches, `group "ops" already exists in this controller with different members`)
+
+	// The existing group is unchanged, and no groups were created.
+	err = group.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{names.NewUserTag("mary@external")})
+	_, err = s.State.UserGroup("dev")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UserGroupSuite) TestRemoveUserLeavesGroups(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMembers(names.NewUserTag("bob"), names.NewUserTag("mary@external"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUser(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = group.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{names.NewUserTag("mary@external")})
+	groups, err := s.State.UserGroupsFor(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 0)
+}
+
+func (s *UserGroupSuite) TestModelsForUserThroughGroup(c *gc.C) {
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	bob := names.NewUserTag("bob@external")
+	err = group.AddMembers(bob)
+	c.Assert(err, jc.ErrorIsNil)
+
+	models, err := s.State.ModelsForUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 0)
+
+	_, err = s.State.AddModelUser(s.State.ModelUUID(), state.UserAccessSpec{
+		User:      group.Tag(),
+		CreatedBy: s.Owner,
+		Access:    permission.ReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	models, err = s.State.ModelsForUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+	c.Assert(models[0].UUID(), gc.Equals, s.State.ModelUUID())
+	c.Assert(models[0].User, gc.Equals, bob)
+}
@@ -1413,7 +1413,9 @@ func (st *State) WatchFilesystemAttachment(m names.MachineTag, f names.Filesyste
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's service configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
-// valid only while the unit's charm URL is not changed.
+// valid only while the unit's charm URL is not changed. The watcher also
+// fires when the service's endpoint bindings change, as the results of
+// network-get may then differ.
 // TODO(fwereade): this could be much smarter; if it were, uniter.Filter
 // could be somewhat simpler.
 func (u *Unit) WatchConfigSettings() (NotifyWatcher, error) {
@@ -1421,7 +1423,11 @@ func (u *Unit) WatchConfigSettings() (NotifyWatcher, error) {
 		return nil, fmt.Errorf("unit charm not set")
 	}
 	settingsKey := applicationSettingsKey(u.doc.Application, u.doc.CharmURL)
-	return newEntityWatcher(u.st, settingsC, u.st.docID(settingsKey)), nil
+	return newDocWatcher(u.st, []docKey{{
+		settingsC, u.st.docID(settingsKey),
+	}, {
+		endpointBindingsC, u.st.docID(applicationGlobalKey(u.doc.Application)),
+	}}), nil
 }
 
 // WatchMeterStatus returns a watcher observing changes that affect the meter status
@@ -2585,6 +2591,81 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchOpenedPorts returns a NotifyWatcher which triggers whenever
+// ports or egress rules are opened or closed on the machine, on
+// any subnet.
+func (m *Machine) WatchOpenedPorts() NotifyWatcher {
+	// Every ports document key for the machine starts with
+	// the key for the unknown subnet.
+	prefix := portsGlobalKey(m.Id(), "")
+	filter := func(key interface{}) bool {
+		if id, ok := key.(string); ok {
+			if id, err := m.st.strictLocalID(id); err == nil {
+				return strings.HasPrefix(id, prefix)
+			}
+		}
+		return false
+	}
+	return newNotifyCollWatcher(m.st, openedPortsC, filter)
+}
+
+// WatchApplicationsExposure returns a NotifyWatcher which triggers
+// whenever any application in the model changes, including when it
+// is exposed or unexposed or its expose settings change.
+func (st *State) WatchApplicationsExposure() NotifyWatcher {
+	return newNotifyCollWatcher(st, applicationsC, isLocalID(st))
+}
+
+// WatchMachinesAddresses returns a NotifyWatcher which triggers
+// whenever any machine in the model changes, including when machines
+// are added or removed or their addresses change.
+func (st *State) WatchMachinesAddresses() NotifyWatcher {
+	return newNotifyCollWatcher(st, machinesC, isLocalID(st))
+}
+
+// WatchMachinesProvisioning returns a NotifyWatcher which triggers
+// whenever the instance data of any machine in the model changes,
+// including when a machine is provisioned.
+func (st *State) WatchMachinesProvisioning() NotifyWatcher {
+	return newNotifyCollWatcher(st, instanceDataC, isLocalID(st))
+}
+
+// WatchEndpointBindings returns a NotifyWatcher which triggers
+// whenever the endpoint bindings of any application in the model
+// change.
+func (st *State) WatchEndpointBindings() NotifyWatcher {
+	return newNotifyCollWatcher(st, endpointBindingsC, isLocalID(st))
+}
+
+// WatchRelationScopes returns a NotifyWatcher which triggers
+// whenever a unit enters, prepares to leave or leaves the scope of
+// any relation in the model.
+func (st *State) WatchRelationScopes() NotifyWatcher {
+	return newNotifyCollWatcher(st, relationScopesC, isLocalID(st))
+}
+
+// WatchRelationSettings returns a NotifyWatcher which triggers
+// whenever the settings of any unit in any relation in the model
+// change.
+func (st *State) WatchRelationSettings() NotifyWatcher {
+	filter := func(id interface{}) bool {
+		key, ok := id.(string)
+		if !ok {
+			return false
+		}
+		localID, err := st.strictLocalID(key)
+		return err == nil && strings.HasPrefix(localID, "r#")
+	}
+	return newNotifyCollWatcher(st, settingsC, filter)
+}
+
+// WatchApplicationsResourceTags returns a NotifyWatcher which triggers
+// whenever any application in the model changes, including when its
+// resource tags change or units are added to or removed from it.
+func (st *State) WatchApplicationsResourceTags() NotifyWatcher {
+	return newNotifyCollWatcher(st, applicationsC, isLocalID(st))
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
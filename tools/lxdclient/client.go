@@ -223,6 +223,9 @@ func newRawClient(remote Remote) (*lxd.Client, error) {
 		url, err := url.Parse(host)
 		if err != nil || url.Scheme == "" {
 			if _, _, err := net.SplitHostPort(host); err != nil {
+				// JoinHostPort adds the brackets an IPv6
+				// address needs, so drop any given.
+				host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
 				host = net.JoinHostPort(host, lxdshared.DefaultPort)
 			}
 		}
@@ -368,17 +371,6 @@ It looks like your lxdbr0 has not yet been configured. Please configure it via:
 and then bootstrap again.`, err)
 }
 
-func ipv6BridgeConfigError(filename string) error {
-	return errors.Errorf(`%s has IPv6 enabled.
-Juju doesn't currently support IPv6.
-
-IPv6 can be disabled by running:
-
-       sudo dpkg-reconfigure -p medium lxd
-
-and then bootstrap again.`, filename)
-}
-
 func checkLXDBridgeConfiguration(conf string) error {
 	foundSubnetConfig := false
 	for _, line := range strings.Split(conf, "\n") {
@@ -407,9 +399,10 @@ func checkLXDBridgeConfiguration(conf string) error {
 				foundSubnetConfig = true
 			}
 		} else if strings.HasPrefix(line, "LXD_IPV6_ADDR=") {
+			// IPv6-only and dual-stack bridges are supported.
 			contents := strings.Trim(line[len("LXD_IPV6_ADDR="):], " \"")
 			if len(contents) > 0 {
-				return ipv6BridgeConfigError(LXDBridgeFile)
+				foundSubnetConfig = true
 			}
 		}
 	}
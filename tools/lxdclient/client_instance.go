@@ -6,8 +6,11 @@
 package lxdclient
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -336,6 +339,33 @@ func (client *instanceClient) AttachDisk(instanceName, deviceName string, disk D
 	return nil
 }
 
+// AttachNIC attaches a network device to a running instance.
+func (client *instanceClient) AttachNIC(instanceName, deviceName string, nic Device) error {
+	var props []string
+	for k, v := range nic {
+		if k == "type" {
+			continue
+		}
+		props = append(props, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(props)
+	resp, err := client.raw.ContainerDeviceAdd(instanceName, deviceName, "nic", props)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// WriteFile writes the given data to the file at the given path in an
+// instance, owned by root.
+func (client *instanceClient) WriteFile(instanceName, path string, data []byte, mode os.FileMode) error {
+	err := client.raw.PushFile(instanceName, path, 0, 0, fmt.Sprintf("%04o", mode), bytes.NewReader(data))
+	return errors.Trace(err)
+}
+
 // RemoveDevice removes a device from an instance.
 func (client *instanceClient) RemoveDevice(instanceName, deviceName string) error {
 	resp, err := client.raw.ContainerDeviceDelete(instanceName, deviceName)
@@ -232,6 +232,32 @@ func (s *devicesSuite) TestAttachDiskAsyncError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "async error")
 }
 
+func (s *devicesSuite) TestAttachNIC(c *gc.C) {
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.AttachNIC("instance", "eth1", lxdclient.Device{
+		"type":    "nic",
+		"nictype": "bridged",
+		"name":    "eth1",
+		"parent":  "br-eth1",
+		"hwaddr":  "00:16:3e:00:00:01",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerDeviceAdd", []interface{}{"instance", "eth1", "nic", []string{
+			"hwaddr=00:16:3e:00:00:01", "name=eth1", "nictype=bridged", "parent=br-eth1",
+		}}},
+		{"WaitForSuccess", []interface{}{""}},
+	})
+}
+
+func (s *devicesSuite) TestAttachNICAsyncError(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("async error"))
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.AttachNIC("instance", "eth1", lxdclient.Device{})
+	c.Assert(err, gc.ErrorMatches, "async error")
+}
+
 func (s *devicesSuite) TestRemoveDevice(c *gc.C) {
 	client := lxdclient.NewInstanceClient(s.Client)
 	err := client.RemoveDevice("instance", "device")
@@ -48,18 +48,20 @@ type creator interface {
 	ProfileConfig(profile string) (*api.Profile, error)
 }
 
+// checkBridgeConfig checks that a bridge managed by LXD has an IPv4
+// or an IPv6 subnet, or both, from which containers get addresses.
 func checkBridgeConfig(client rawNetworkClient, bridge string) error {
 	n, err := client.NetworkGet(bridge)
 	if err != nil {
 		return err
 	}
-	ipv6AddressConfig := n.Config["ipv6.address"]
-	if n.Managed && ipv6AddressConfig != "none" && ipv6AddressConfig != "" {
-		return errors.Errorf(`juju doesn't support ipv6. Please disable LXD's IPV6:
+	if n.Managed && n.Config["ipv4.address"] == "none" && n.Config["ipv6.address"] == "none" {
+		return errors.Errorf(`%s has neither IPv4 nor IPv6 enabled. Please enable either or both:
 
-	$ lxc network set %s ipv6.address none
+	$ lxc network set %s ipv4.address auto
+	$ lxc network set %s ipv6.address auto
 
-and rebootstrap`, bridge)
+and rebootstrap`, bridge, bridge, bridge)
 	}
 
 	return nil
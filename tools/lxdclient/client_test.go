@@ -15,6 +15,7 @@ import (
 	jujuos "github.com/juju/utils/os"
 	proxyutils "github.com/juju/utils/proxy"
 	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/utils/proxy"
@@ -184,15 +185,69 @@ LXD_IPV6_ADDR="2001:470:b368:4242::1"
 `
 
 	err = checkLXDBridgeConfiguration(ipv6)
-	c.Assert(err.Error(), gc.Equals, LXDBridgeFile+` has IPv6 enabled.
-Juju doesn't currently support IPv6.
+	c.Assert(err, jc.ErrorIsNil)
+
+	dualStack := `
+USE_LXD_BRIDGE="true"
+LXD_BRIDGE="lxdbr0"
+LXD_IPV4_ADDR="10.0.4.1"
+LXD_IPV6_ADDR="2001:470:b368:4242::1"
+`
+	err = checkLXDBridgeConfiguration(dualStack)
+	c.Assert(err, jc.ErrorIsNil)
+}
 
-IPv6 can be disabled by running:
+func (cs *ConnectSuite) TestCheckBridgeConfig(c *gc.C) {
+	for i, test := range []struct {
+		about   string
+		managed bool
+		config  map[string]string
+		err     string
+	}{{
+		about:   "IPv4 only",
+		managed: true,
+		config:  map[string]string{"ipv4.address": "10.0.4.1/24", "ipv6.address": "none"},
+	}, {
+		about:   "IPv6 only",
+		managed: true,
+		config:  map[string]string{"ipv4.address": "none", "ipv6.address": "fd42:1::1/64"},
+	}, {
+		about:   "dual-stack",
+		managed: true,
+		config:  map[string]string{"ipv4.address": "10.0.4.1/24", "ipv6.address": "fd42:1::1/64"},
+	}, {
+		about:   "neither IPv4 nor IPv6",
+		managed: true,
+		config:  map[string]string{"ipv4.address": "none", "ipv6.address": "none"},
+		err:     "lxdbr0 has neither IPv4 nor IPv6 enabled.(.|\n)*",
+	}, {
+		about:  "not managed by LXD",
+		config: map[string]string{"ipv4.address": "none", "ipv6.address": "none"},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		client := &fakeNetworkClient{network: api.Network{
+			Managed: test.managed,
+			Config:  test.config,
+		}}
+		err := checkBridgeConfig(client, "lxdbr0")
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
 
-       sudo dpkg-reconfigure -p medium lxd
+type fakeNetworkClient struct {
+	network api.Network
+}
 
-and then bootstrap again.`)
+func (f *fakeNetworkClient) NetworkCreate(name string, config map[string]string) error {
+	return errors.NotImplementedf("NetworkCreate")
+}
 
+func (f *fakeNetworkClient) NetworkGet(name string) (api.Network, error) {
+	return f.network, nil
 }
 
 func (cs *ConnectSuite) TestRemoteConnectError(c *gc.C) {
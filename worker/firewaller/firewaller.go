@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"reflect"
 	"strings"
 	"time"
 
@@ -34,6 +35,10 @@ type FirewallerAPI interface {
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
+	WatchModelFirewallRules() (watcher.NotifyWatcher, error)
+	ModelFirewallRules() ([]network.IngressRule, error)
+	WatchSubnets() (watcher.StringsWatcher, error)
+	WatchMachinesProvisioning() (watcher.NotifyWatcher, error)
 }
 
 // RemoteFirewallerAPI exposes remote firewaller functionality to a worker.
@@ -54,6 +59,13 @@ type EnvironFirewaller interface {
 	environs.Firewaller
 }
 
+// EnvironModelFirewaller defines methods to allow the worker to
+// manage the firewall rules that apply to the whole model, such as
+// SSH access to its machines.
+type EnvironModelFirewaller interface {
+	environs.ModelFirewaller
+}
+
 // EnvironInstances defines methods to allow the worker to perform
 // operations on instances in a Juju cloud environment.
 type EnvironInstances interface {
@@ -69,6 +81,11 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	// EnvironModelFirewaller is optional; if it is nil, the
+	// firewall rules that apply to the whole model are left
+	// as set up by the provider.
+	EnvironModelFirewaller EnvironModelFirewaller
+
 	NewRemoteFirewallerAPIFunc func(modelUUID string) (RemoteFirewallerAPICloser, error)
 
 	Clock clock.Clock
@@ -97,7 +114,9 @@ func (config Config) Validate() error {
 	return nil
 }
 
-type portRanges map[network.PortRange]bool
+// portRanges maps the port ranges opened by a unit to the endpoints
+// they were opened for. An empty endpoint stands for all endpoints.
+type portRanges map[network.PortRange]string
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -109,8 +128,14 @@ type Firewaller struct {
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
 
+	environModelFirewaller    EnvironModelFirewaller
+	modelFirewallRulesWatcher watcher.NotifyWatcher
+	provisioningWatcher       watcher.NotifyWatcher
+	modelFirewallPending      bool
+
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetsWatcher       watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -141,6 +166,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		remoteRelationsApi:         cfg.RemoteRelationsApi,
 		environFirewaller:          cfg.EnvironFirewaller,
 		environInstances:           cfg.EnvironInstances,
+		environModelFirewaller:     cfg.EnvironModelFirewaller,
 		newRemoteFirewallerAPIFunc: cfg.NewRemoteFirewallerAPIFunc,
 		modelUUID:                  cfg.ModelUUID,
 		machineds:                  make(map[names.MachineTag]*machineData),
@@ -204,6 +230,16 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.subnetsWatcher, err = fw.firewallerApi.WatchSubnets()
+	if errors.IsNotSupported(err) {
+		logger.Infof("not watching subnets: %v", err)
+		fw.subnetsWatcher = &stubWatcher{changes: make(watcher.StringsChannel)}
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	} else if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	if featureflag.Enabled(feature.CrossModelRelations) {
 		fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 		if err != nil {
@@ -216,6 +252,28 @@ func (fw *Firewaller) setUp() error {
 		fw.remoteRelationsWatcher = &stubWatcher{changes: make(watcher.StringsChannel)}
 	}
 
+	if fw.environModelFirewaller != nil {
+		fw.modelFirewallRulesWatcher, err = fw.firewallerApi.WatchModelFirewallRules()
+		if errors.IsNotSupported(err) {
+			logger.Infof("not managing model firewall rules: %v", err)
+			fw.environModelFirewaller = nil
+		} else if err != nil {
+			return errors.Annotatef(err, "failed to start model firewall rules watcher")
+		} else if err := fw.catacomb.Add(fw.modelFirewallRulesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if fw.environModelFirewaller != nil {
+		fw.provisioningWatcher, err = fw.firewallerApi.WatchMachinesProvisioning()
+		if errors.IsNotSupported(err) {
+			logger.Infof("not watching machine provisioning: %v", err)
+		} else if err != nil {
+			return errors.Annotatef(err, "failed to start machine provisioning watcher")
+		} else if err := fw.catacomb.Add(fw.provisioningWatcher); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	logger.Debugf("started watching opened port ranges for the environment")
 	return nil
 }
@@ -226,6 +284,14 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var modelFirewallRulesChange watcher.NotifyChannel
+	if fw.modelFirewallRulesWatcher != nil {
+		modelFirewallRulesChange = fw.modelFirewallRulesWatcher.Changes()
+	}
+	var provisioningChange watcher.NotifyChannel
+	if fw.provisioningWatcher != nil {
+		provisioningChange = fw.provisioningWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -264,6 +330,13 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-fw.subnetsWatcher.Changes():
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			if err := fw.subnetsChanged(); err != nil {
+				return errors.Trace(err)
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -273,6 +346,22 @@ func (fw *Firewaller) loop() error {
 					return err
 				}
 			}
+		case _, ok := <-modelFirewallRulesChange:
+			if !ok {
+				return errors.New("model firewall rules watcher closed")
+			}
+			if err := fw.reconcileModelFirewall(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-provisioningChange:
+			if !ok {
+				return errors.New("machine provisioning watcher closed")
+			}
+			if fw.modelFirewallPending {
+				if err := fw.reconcileModelFirewall(); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		case change := <-fw.remoteRelationsChange:
 			if err := fw.remoteRelationChanged(change); err != nil {
 				return errors.Trace(err)
@@ -283,6 +372,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -294,6 +384,37 @@ func (fw *Firewaller) loop() error {
 	}
 }
 
+// subnetsChanged refreshes the expose settings of the exposed
+// applications, as the CIDRs of the spaces they are exposed to
+// follow the subnets in those spaces.
+func (fw *Firewaller) subnetsChanged() error {
+	var unitds []*unitData
+	for _, applicationd := range fw.applicationids {
+		if !applicationd.exposed || len(applicationd.exposedEndpoints) == 0 {
+			continue
+		}
+		exposed, exposedEndpoints, err := applicationd.application.ExposeInfo()
+		if params.IsCodeNotFound(err) {
+			// The application's watcher will notice its removal.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if exposed == applicationd.exposed && reflect.DeepEqual(exposedEndpoints, applicationd.exposedEndpoints) {
+			continue
+		}
+		applicationd.exposed = exposed
+		applicationd.exposedEndpoints = exposedEndpoints
+		for _, unitd := range applicationd.unitds {
+			unitds = append(unitds, unitd)
+		}
+	}
+	if err := fw.flushUnits(unitds); err != nil {
+		return errors.Annotate(err, "cannot change firewall ports")
+	}
+	return nil
+}
+
 func (fw *Firewaller) remoteRelationChanged(change *remoteRelationChange) error {
 	logger.Debugf("process remote relation change for %v", change.relationTag)
 	relData, ok := fw.relationIngress[change.relationTag]
@@ -433,22 +554,23 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		exposedEndpoints: exposedEndpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedEndpoints)
 		},
 	})
 	if err != nil {
@@ -491,6 +613,48 @@ func (fw *Firewaller) reconcileGlobal() error {
 	return nil
 }
 
+// reconcileModelFirewall ensures that the firewall rules that apply
+// to the whole model match those required by the model and controller
+// configuration.
+func (fw *Firewaller) reconcileModelFirewall() error {
+	wantedRules, err := fw.firewallerApi.ModelFirewallRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if filterer, ok := fw.environModelFirewaller.(environs.ModelIngressRulesFilterer); ok {
+		wantedRules = filterer.FilterModelIngressRules(wantedRules)
+	}
+	currentRules, err := fw.environModelFirewaller.ModelIngressRules()
+	if errors.IsNotFound(err) {
+		// The provider sets up the model's firewall rules when
+		// the first machine is started; they will be reconciled
+		// when a machine is next provisioned.
+		logger.Debugf("model firewall not yet set up: %v", err)
+		fw.modelFirewallPending = true
+		return nil
+	} else if errors.IsNotSupported(err) {
+		logger.Debugf("not managing model firewall rules: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	fw.modelFirewallPending = false
+	toOpen, toClose := diffRanges(currentRules, wantedRules)
+	if len(toOpen) > 0 {
+		if err := fw.environModelFirewaller.OpenModelPorts(toOpen); err != nil {
+			return errors.Annotate(err, "cannot open model firewall ports")
+		}
+		logger.Infof("opened model firewall ports %v", toOpen)
+	}
+	if len(toClose) > 0 {
+		if err := fw.environModelFirewaller.CloseModelPorts(toClose); err != nil {
+			return errors.Annotate(err, "cannot close model firewall ports")
+		}
+		logger.Infof("closed model firewall ports %v", toClose)
+	}
+	return nil
+}
+
 // reconcileInstances compares the initially started watcher for machines,
 // units and appications with the opened and closed ports of the instances and
 // opens and closes the appropriate ports for each instance.
@@ -607,13 +771,22 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	if subnetTag.Id() == "" {
+		// Egress rules are held alongside the ports
+		// that are not specific to any subnet.
+		if err := fw.flushMachineEgress(machined, m); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	ports, err := m.OpenedPortEndpoints(subnetTag)
 	if err != nil {
 		return err
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
+	for portRange, opener := range ports {
+		unitTag := opener.Unit
 		unitd, ok := machined.unitds[unitTag]
 		if !ok {
 			// It is common to receive port change notification before
@@ -627,7 +800,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		ranges[portRange] = opener.Endpoint
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -709,32 +882,57 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
+			applicationd := unitd.applicationd
+			relationCIDRs := set.NewStrings()
+			if !applicationd.exposed {
 				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+				if err := fw.updateForRemoteRelationIngress(applicationd.application.Tag(), relationCIDRs); err != nil {
 					return nil, errors.Trace(err)
 				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
+				logger.Debugf("CIDRS for %v: %v", unitTag, relationCIDRs.Values())
 			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
+			for portRange, endpoint := range portRanges {
+				cidrs := relationCIDRs
+				// If the unit is exposed, allow access from the sources
+				// its application is exposed to through the endpoint
+				// the port range was opened for.
+				if applicationd.exposed {
+					cidrs = exposedCIDRs(applicationd.exposedEndpoints, endpoint)
+				}
+				if cidrs.Size() == 0 {
+					continue
+				}
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+				if err != nil {
+					return nil, errors.Trace(err)
 				}
+				want = append(want, rule)
 			}
 		}
 	}
 	return want, nil
 }
 
+// exposedCIDRs returns the CIDRs from which a port range opened for
+// the given endpoint of an exposed application with the given endpoint
+// expose settings may be reached. A port range opened for all endpoints
+// is reachable from the sources of all the exposed endpoints; one opened
+// for a single endpoint only from the sources that endpoint, or all
+// endpoints, are exposed to.
+func exposedCIDRs(exposedEndpoints map[string]params.ExposedEndpoint, endpoint string) set.Strings {
+	if len(exposedEndpoints) == 0 {
+		return set.NewStrings("0.0.0.0/0")
+	}
+	cidrs := set.NewStrings()
+	for name, ep := range exposedEndpoints {
+		if endpoint != "" && name != "" && name != endpoint {
+			continue
+		}
+		cidrs = cidrs.Union(set.NewStrings(ep.ExposeToCIDRs...))
+	}
+	return cidrs
+}
+
 func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, cidrs set.Strings) error {
 	logger.Debugf("finding ingress rules for %v", appTag)
 	// Now create the rules for any remote relations of which the
@@ -840,6 +1038,74 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// flushMachineEgress ensures that the outgoing traffic allowed from
+// the machine's instance matches the egress rules of its units.
+// Egress rules are only applied in instance firewall mode, and only
+// on instances that support them.
+func (fw *Firewaller) flushMachineEgress(machined *machineData, m *firewaller.Machine) error {
+	if fw.globalMode {
+		return nil
+	}
+	wantedRules, err := m.EgressRules()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not managing egress rules for %q: %v", machined.tag, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if machined.egressRules != nil && egressRulesEqual(machined.egressRules, wantedRules) {
+		return nil
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		// The rules will be applied when the
+		// units' egress rules next change.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	instances, err := fw.environInstances.Instances([]instance.Id{instanceId})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineId := machined.tag.Id()
+	egressFirewaller, ok := instances[0].(instance.EgressFirewaller)
+	var currentRules []network.EgressRule
+	if ok {
+		currentRules, err = egressFirewaller.EgressRules(machineId)
+		if errors.IsNotSupported(err) {
+			ok = false
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !ok {
+		if len(wantedRules) > 0 {
+			logger.Warningf("cannot restrict outgoing traffic from %q: not supported by the provider", machined.tag)
+		}
+		machined.egressRules = wantedRules
+		return nil
+	}
+	toOpen, toClose := diffEgressRules(currentRules, wantedRules)
+	if len(toOpen) > 0 {
+		if err := egressFirewaller.OpenEgress(machineId, toOpen); err != nil {
+			return errors.Annotatef(err, "cannot allow egress on %q", machined.tag)
+		}
+		logger.Infof("allowed egress %v on %q", toOpen, machined.tag)
+	}
+	if len(toClose) > 0 {
+		if err := egressFirewaller.CloseEgress(machineId, toClose); err != nil {
+			return errors.Annotatef(err, "cannot deny egress on %q", machined.tag)
+		}
+		logger.Infof("denied egress %v on %q", toClose, machined.tag)
+	}
+	if wantedRules == nil {
+		wantedRules = []network.EgressRule{}
+	}
+	machined.egressRules = wantedRules
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -937,6 +1203,9 @@ type machineData struct {
 	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
+	// egress rules defined by units on this machine,
+	// as last applied to its instance
+	egressRules []network.EgressRule
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -984,23 +1253,27 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and endpoint
+// expose settings for one specific application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd     *applicationData
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb         catacomb.Catacomb
+	fw               *Firewaller
+	application      *firewaller.Application
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
+	unitds           map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag
+// and endpoint expose settings for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedEndpoints map[string]params.ExposedEndpoint) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1025,19 +1298,20 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
+			change, changeEndpoints, err := ad.application.ExposeInfo()
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			if change == exposed && reflect.DeepEqual(changeEndpoints, exposedEndpoints) {
 				continue
 			}
 
 			exposed = change
+			exposedEndpoints = changeEndpoints
 			select {
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.exposedChange <- &exposedChange{ad, change, changeEndpoints}:
 			}
 		}
 	}
@@ -1129,6 +1403,34 @@ func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClos
 	return toOpen, toClose
 }
 
+// diffEgressRules returns the egress rules to open and to close in
+// order to change currentRules into wantedRules.
+func diffEgressRules(currentRules, wantedRules []network.EgressRule) (toOpen, toClose []network.EgressRule) {
+	// Egress rules are compared in the same way as ingress rules,
+	// with destinations in place of sources.
+	asIngress := func(rules []network.EgressRule) []network.IngressRule {
+		result := make([]network.IngressRule, len(rules))
+		for i, rule := range rules {
+			result[i] = network.IngressRule{PortRange: rule.PortRange, SourceCIDRs: rule.DestinationCIDRs}
+		}
+		return result
+	}
+	asEgress := func(rules []network.IngressRule) []network.EgressRule {
+		var result []network.EgressRule
+		for _, rule := range rules {
+			result = append(result, network.EgressRule{PortRange: rule.PortRange, DestinationCIDRs: rule.SourceCIDRs})
+		}
+		return result
+	}
+	ingressToOpen, ingressToClose := diffRanges(asIngress(currentRules), asIngress(wantedRules))
+	return asEgress(ingressToOpen), asEgress(ingressToClose)
+}
+
+func egressRulesEqual(a, b []network.EgressRule) bool {
+	toOpen, toClose := diffEgressRules(a, b)
+	return len(toOpen) == 0 && len(toClose) == 0
+}
+
 // relationLifeChanged manages the workers to process ingress changes for
 // the specified relation.
 func (fw *Firewaller) relationLifeChanged(tag names.RelationTag) error {
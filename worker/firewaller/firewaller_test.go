@@ -5,8 +5,11 @@ package firewaller_test
 
 import (
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -240,6 +243,51 @@ func (s *InstanceModeSuite) TestNotExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) assertEgress(c *gc.C, inst instance.Instance, machineId string, expected []network.EgressRule) {
+	s.BackingState.StartSync()
+	egressFirewaller, ok := inst.(instance.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		got, err := egressFirewaller.EgressRules(machineId)
+		c.Assert(err, jc.ErrorIsNil)
+		if reflect.DeepEqual(got, expected) {
+			return
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+		}
+	}
+}
+
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	// Egress rules apply whether or not the application is exposed.
+	err := u.OpenEgress("tcp", 443, 443, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenEgress("udp", 53, 53)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, inst, m.Id(), []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+
+	err = u.CloseEgress("tcp", 443, 443, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, inst, m.Id(), []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+
+	err = u.CloseEgress("udp", 53, 53)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, inst, m.Id(), nil)
+}
+
 func (s *InstanceModeSuite) TestExposedApplication(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -490,6 +538,107 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24"),
+	})
+
+	// The sources of all exposed endpoints are combined.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	// Exposing to everyone replaces the settings.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
+func (s *InstanceModeSuite) TestExposeEndpointPorts(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPortsOnEndpoint("logging-dir", "tcp", 514, 514)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Each endpoint's sources reach only the ports opened for it;
+	// ports opened for all endpoints are reachable from all of them.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.1.0/24"),
+	})
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"logging-dir": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24"),
+		network.MustNewIngressRule("tcp", 514, 514, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestExposeToSpaceFollowsSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+
+	// A subnet added to the space opens the ports to its CIDR.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/16", SpaceName: "dmz"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.1.0.0/16"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -835,6 +984,233 @@ func (s *GlobalModeSuite) TestStartStop(c *gc.C) {
 	statetesting.AssertKillAndWait(c, fw)
 }
 
+// mockModelFirewaller records the changes made to the
+// firewall rules that apply to the whole model.
+type mockModelFirewaller struct {
+	mu     sync.Mutex
+	rules  []network.IngressRule
+	opened []network.IngressRule
+	closed []network.IngressRule
+
+	// notSetUp, if true, makes ModelIngressRules report
+	// that the model's firewall does not exist yet.
+	notSetUp bool
+}
+
+func (m *mockModelFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.opened = append(m.opened, rules...)
+	m.rules = append(m.rules, rules...)
+	return nil
+}
+
+func (m *mockModelFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = append(m.closed, rules...)
+	var remaining []network.IngressRule
+	for _, existing := range m.rules {
+		keep := true
+		for _, rule := range rules {
+			if reflect.DeepEqual(existing, rule) {
+				keep = false
+			}
+		}
+		if keep {
+			remaining = append(remaining, existing)
+		}
+	}
+	m.rules = remaining
+	return nil
+}
+
+func (m *mockModelFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.notSetUp {
+		return nil, errors.NotFoundf("model firewall")
+	}
+	return append([]network.IngressRule(nil), m.rules...), nil
+}
+
+func (m *mockModelFirewaller) changes() (opened, closed []network.IngressRule) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.opened, m.closed
+}
+
+func (m *mockModelFirewaller) setUp() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notSetUp = false
+}
+
+func (s *GlobalModeSuite) TestModelFirewallRules(c *gc.C) {
+	apiPort := s.ControllerConfig.APIPort()
+	sshRule := network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0", "::/0")
+	apiRule := network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0", "::/0")
+	modelFirewaller := &mockModelFirewaller{
+		rules: []network.IngressRule{sshRule, apiRule},
+	}
+	cfg := firewaller.Config{
+		ModelUUID:              s.State.ModelUUID(),
+		Mode:                   config.FwGlobal,
+		EnvironFirewaller:      s.Environ,
+		EnvironInstances:       s.Environ,
+		EnvironModelFirewaller: modelFirewaller,
+		FirewallerAPI:          s.firewaller,
+		RemoteRelationsApi:     s.remoteRelations,
+		NewRemoteFirewallerAPIFunc: func(modelUUID string) (firewaller.RemoteFirewallerAPICloser, error) {
+			return s.remotefirewaller, nil
+		},
+	}
+	fw, err := firewaller.NewFirewaller(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"ssh-allow": "10.0.0.0/8"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.BackingState.StartSync()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		opened, closed := modelFirewaller.changes()
+		if len(opened) == 0 {
+			continue
+		}
+		c.Assert(opened, jc.DeepEquals, []network.IngressRule{
+			network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+		})
+		c.Assert(closed, jc.DeepEquals, []network.IngressRule{sshRule})
+		return
+	}
+	c.Fatalf("model firewall rules not changed")
+}
+
+func (s *GlobalModeSuite) TestModelFirewallRulesAfterProvisioning(c *gc.C) {
+	apiPort := s.ControllerConfig.APIPort()
+	sshRule := network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0", "::/0")
+	apiRule := network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0", "::/0")
+	modelFirewaller := &mockModelFirewaller{
+		rules:    []network.IngressRule{sshRule, apiRule},
+		notSetUp: true,
+	}
+	cfg := firewaller.Config{
+		ModelUUID:              s.State.ModelUUID(),
+		Mode:                   config.FwGlobal,
+		EnvironFirewaller:      s.Environ,
+		EnvironInstances:       s.Environ,
+		EnvironModelFirewaller: modelFirewaller,
+		FirewallerAPI:          s.firewaller,
+		RemoteRelationsApi:     s.remoteRelations,
+		NewRemoteFirewallerAPIFunc: func(modelUUID string) (firewaller.RemoteFirewallerAPICloser, error) {
+			return s.remotefirewaller, nil
+		},
+	}
+	fw, err := firewaller.NewFirewaller(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	// The configuration changes before the provider has
+	// set up the model's firewall, so nothing can be changed.
+	err = s.State.UpdateModelConfig(map[string]interface{}{"ssh-allow": "10.0.0.0/8"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BackingState.StartSync()
+	time.Sleep(coretesting.ShortWait)
+	opened, closed := modelFirewaller.changes()
+	c.Assert(opened, gc.HasLen, 0)
+	c.Assert(closed, gc.HasLen, 0)
+
+	// Provisioning the first machine sets up the model's
+	// firewall, which is then reconciled.
+	modelFirewaller.setUp()
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.startInstance(c, m)
+
+	s.BackingState.StartSync()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		opened, closed := modelFirewaller.changes()
+		if len(opened) == 0 {
+			continue
+		}
+		c.Assert(opened, jc.DeepEquals, []network.IngressRule{
+			network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+		})
+		c.Assert(closed, jc.DeepEquals, []network.IngressRule{sshRule})
+		return
+	}
+	c.Fatalf("model firewall rules not changed")
+}
+
+// ipv4ModelFirewaller is a mockModelFirewaller
+// that can only apply IPv4 source CIDRs.
+type ipv4ModelFirewaller struct {
+	mockModelFirewaller
+}
+
+func (m *ipv4ModelFirewaller) FilterModelIngressRules(rules []network.IngressRule) []network.IngressRule {
+	var result []network.IngressRule
+	for _, rule := range rules {
+		var cidrs []string
+		for _, cidr := range rule.SourceCIDRs {
+			if !strings.Contains(cidr, ":") {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+		if len(cidrs) > 0 {
+			result = append(result, network.MustNewIngressRule(
+				rule.Protocol, rule.FromPort, rule.ToPort, cidrs...,
+			))
+		}
+	}
+	return result
+}
+
+func (s *GlobalModeSuite) TestModelFirewallRulesFiltered(c *gc.C) {
+	apiPort := s.ControllerConfig.APIPort()
+	sshRule := network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0")
+	apiRule := network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0")
+	modelFirewaller := &ipv4ModelFirewaller{mockModelFirewaller{
+		rules: []network.IngressRule{sshRule, apiRule},
+	}}
+	cfg := firewaller.Config{
+		ModelUUID:              s.State.ModelUUID(),
+		Mode:                   config.FwGlobal,
+		EnvironFirewaller:      s.Environ,
+		EnvironInstances:       s.Environ,
+		EnvironModelFirewaller: modelFirewaller,
+		FirewallerAPI:          s.firewaller,
+		RemoteRelationsApi:     s.remoteRelations,
+		NewRemoteFirewallerAPIFunc: func(modelUUID string) (firewaller.RemoteFirewallerAPICloser, error) {
+			return s.remotefirewaller, nil
+		},
+	}
+	fw, err := firewaller.NewFirewaller(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	// The default rules allow access from ::/0 as well, which
+	// the provider cannot apply, so nothing changes when the
+	// rules are reconciled again.
+	err = s.State.UpdateModelConfig(map[string]interface{}{"ssh-allow": "10.0.0.0/8,::/0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.BackingState.StartSync()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		opened, closed := modelFirewaller.changes()
+		if len(opened) == 0 {
+			continue
+		}
+		c.Assert(opened, jc.DeepEquals, []network.IngressRule{
+			network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+		})
+		c.Assert(closed, jc.DeepEquals, []network.IngressRule{sshRule})
+		return
+	}
+	c.Fatalf("model firewall rules not changed")
+}
+
 func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	// Start firewaller and open ports.
 	fw := s.newFirewaller(c)
@@ -86,7 +86,9 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		return nil, errors.Trace(err)
 	}
 	mode := environ.Config().FirewallMode()
-	if mode == config.FwNone {
+	if mode == config.FwNone || mode == config.FwHost {
+		// In host mode, each machine agent runs a host
+		// firewaller instead.
 		logger.Infof("stopping firewaller (not required)")
 		return nil, dependency.ErrUninstall
 	}
@@ -110,13 +112,18 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		return nil, errors.Trace(err)
 	}
 
+	// Not all providers can manage the firewall rules
+	// that apply to the whole model.
+	modelFirewaller, _ := environ.(environs.ModelFirewaller)
+
 	w, err := cfg.NewFirewallerWorker(Config{
-		ModelUUID:          agent.CurrentConfig().Model().Id(),
-		RemoteRelationsApi: remoteRelationsAPI,
-		FirewallerAPI:      firewallerAPI,
-		EnvironFirewaller:  environ,
-		EnvironInstances:   environ,
-		Mode:               mode,
+		ModelUUID:                  agent.CurrentConfig().Model().Id(),
+		RemoteRelationsApi:         remoteRelationsAPI,
+		FirewallerAPI:              firewallerAPI,
+		EnvironFirewaller:          environ,
+		EnvironInstances:           environ,
+		EnvironModelFirewaller:     modelFirewaller,
+		Mode:                       mode,
 		NewRemoteFirewallerAPIFunc: remoteFirewallerAPIFunc(apiConnForModelFunc),
 	})
 	if err != nil {
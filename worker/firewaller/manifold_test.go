@@ -27,10 +27,18 @@ type ManifoldSuite struct {
 var _ = gc.Suite(&ManifoldSuite{})
 
 func (s *ManifoldSuite) TestManifoldFirewallModeNone(c *gc.C) {
+	s.assertUninstalled(c, config.FwNone)
+}
+
+func (s *ManifoldSuite) TestManifoldFirewallModeHost(c *gc.C) {
+	s.assertUninstalled(c, config.FwHost)
+}
+
+func (s *ManifoldSuite) assertUninstalled(c *gc.C, mode string) {
 	ctx := &mockDependencyContext{
 		env: &mockEnviron{
 			config: coretesting.CustomModelConfig(c, coretesting.Attrs{
-				"firewall-mode": config.FwNone,
+				"firewall-mode": mode,
 			}),
 		},
 	}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// Backend applies ingress rules to the firewall of the host.
+type Backend interface {
+	// Apply replaces the rules of the host firewall with ones
+	// that only allow the given incoming traffic.
+	Apply(rules []network.IngressRule) error
+}
+
+// RunFunc runs the named command with the given arguments,
+// passing stdin to its standard input.
+type RunFunc func(stdin, name string, args ...string) error
+
+// NewBackend returns a Backend for the firewall tools installed
+// on the host: nftables if available, otherwise iptables. It
+// returns an error satisfying errors.IsNotFound if neither is
+// installed.
+func NewBackend() (Backend, error) {
+	if _, err := exec.LookPath("nft"); err == nil {
+		return NewNftablesBackend(runCommand), nil
+	}
+	if _, err := exec.LookPath("iptables-restore"); err == nil {
+		return NewIPTablesBackend(runCommand), nil
+	}
+	return nil, errors.NotFoundf("nft or iptables-restore")
+}
+
+// NewNftablesBackend returns a Backend that programs the host
+// firewall with nft, using the given function to run it.
+func NewNftablesBackend(run RunFunc) Backend {
+	return &nftablesBackend{run: run}
+}
+
+type nftablesBackend struct {
+	run RunFunc
+}
+
+// Apply is part of the Backend interface.
+func (b *nftablesBackend) Apply(rules []network.IngressRule) error {
+	// The ruleset is loaded atomically, so the host is never
+	// left with only some of the rules.
+	return errors.Trace(b.run(RenderNftables(rules), "nft", "-f", "-"))
+}
+
+// NewIPTablesBackend returns a Backend that programs the host
+// firewall with iptables and ip6tables, using the given function
+// to run them.
+func NewIPTablesBackend(run RunFunc) Backend {
+	return &iptablesBackend{run: run}
+}
+
+type iptablesBackend struct {
+	run RunFunc
+}
+
+// Apply is part of the Backend interface.
+func (b *iptablesBackend) Apply(rules []network.IngressRule) error {
+	for _, ipv6 := range []bool{false, true} {
+		command := "iptables"
+		if ipv6 {
+			command = "ip6tables"
+		}
+		if err := b.run(RenderIPTables(rules, ipv6), command+"-restore", "--noflush"); err != nil {
+			return errors.Trace(err)
+		}
+		// Jump to the chain from INPUT, unless already done.
+		if err := b.run("", command, "-C", "INPUT", "-j", iptablesChain); err == nil {
+			continue
+		}
+		if err := b.run("", command, "-I", "INPUT", "-j", iptablesChain); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// runCommand is the RunFunc used to run commands on the host.
+func runCommand(stdin, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Annotatef(err, "running %s: %s", name, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
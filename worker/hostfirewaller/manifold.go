@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a
+// Manifold will depend, and the functions it uses.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	NewFacade  func(base.APICaller) (Facade, error)
+	NewBackend func() (Backend, error)
+	NewWorker  func(Config) (worker.Worker, error)
+}
+
+// Validate is called by start to check for bad configuration.
+func (cfg ManifoldConfig) Validate() error {
+	if cfg.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if cfg.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if cfg.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if cfg.NewBackend == nil {
+		return errors.NotValidf("nil NewBackend")
+	}
+	if cfg.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold.
+func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(cfg.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(cfg.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag, ok := agent.CurrentConfig().Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected a machine tag, got %v", agent.CurrentConfig().Tag())
+	}
+
+	facade, err := cfg.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The firewall mode of a model cannot change, so it
+	// only needs to be checked once.
+	modelConfig, err := facade.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if modelConfig.FirewallMode() != config.FwHost {
+		return nil, dependency.ErrUninstall
+	}
+	backend, err := cfg.NewBackend()
+	if errors.IsNotFound(err) {
+		logger.Warningf("cannot program host firewall: %v", err)
+		return nil, dependency.ErrUninstall
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := cfg.NewWorker(Config{
+		Facade:  facade,
+		Tag:     tag,
+		Backend: backend,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Manifold returns a dependency.Manifold that runs a host firewaller.
+func Manifold(cfg ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			cfg.AgentName,
+			cfg.APICallerName,
+		},
+		Start: cfg.start,
+	}
+}
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return hostfirewaller.NewFacade(apiCaller), nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/juju/juju/network"
+)
+
+const (
+	// nftablesTable is the name of the nftables table, in the inet
+	// family, that holds the host firewall rules.
+	nftablesTable = "juju"
+
+	// iptablesChain is the name of the iptables and ip6tables chain,
+	// jumped to from INPUT, that holds the host firewall rules.
+	iptablesChain = "juju-ingress"
+)
+
+// containerBridges are the bridges of the local networks of containers
+// on the host, to which the host serves DHCP and DNS.
+var containerBridges = []string{
+	network.DefaultLXCBridge,
+	network.DefaultLXDBridge,
+	network.DefaultKVMBridge,
+}
+
+// RenderNftables returns an nftables ruleset, to be loaded with
+// "nft -f", that replaces the juju table with one dropping incoming
+// traffic that the given rules do not allow. Replies to outgoing
+// connections, loopback traffic, ICMP and DHCP replies are always
+// allowed, as are DHCP and DNS requests from containers on the host's
+// container bridges.
+func RenderNftables(rules []network.IngressRule) string {
+	var buf bytes.Buffer
+	// Declaring the table before deleting it means the
+	// deletion succeeds even when the table does not exist.
+	fmt.Fprintf(&buf, "table inet %s\n", nftablesTable)
+	fmt.Fprintf(&buf, "delete table inet %s\n", nftablesTable)
+	fmt.Fprintf(&buf, "table inet %s {\n", nftablesTable)
+	fmt.Fprintf(&buf, "\tchain input {\n")
+	fmt.Fprintf(&buf, "\t\ttype filter hook input priority 0; policy drop;\n")
+	fmt.Fprintf(&buf, "\t\tct state established,related accept\n")
+	fmt.Fprintf(&buf, "\t\tct state invalid drop\n")
+	fmt.Fprintf(&buf, "\t\tiif lo accept\n")
+	fmt.Fprintf(&buf, "\t\tip protocol icmp accept\n")
+	fmt.Fprintf(&buf, "\t\tip6 nexthdr icmpv6 accept\n")
+	fmt.Fprintf(&buf, "\t\tudp sport 67 udp dport 68 accept\n")
+	fmt.Fprintf(&buf, "\t\tudp sport 547 udp dport 546 accept\n")
+	bridges := fmt.Sprintf(`iifname { "%s" }`, strings.Join(containerBridges, `", "`))
+	fmt.Fprintf(&buf, "\t\t%s udp sport 68 udp dport 67 accept\n", bridges)
+	fmt.Fprintf(&buf, "\t\t%s udp sport 546 udp dport 547 accept\n", bridges)
+	fmt.Fprintf(&buf, "\t\t%s udp dport 53 accept\n", bridges)
+	fmt.Fprintf(&buf, "\t\t%s tcp dport 53 accept\n", bridges)
+	for _, rule := range rules {
+		for _, cidr := range sourceCIDRs(rule) {
+			family, icmp := "ip", "ip protocol icmp"
+			if network.IsIPv6CIDR(cidr) {
+				family, icmp = "ip6", "ip6 nexthdr icmpv6"
+			}
+			match := icmp
+			if rule.Protocol != "icmp" {
+				match = fmt.Sprintf("%s dport %s", rule.Protocol, portSpec(rule.PortRange, "-"))
+			}
+			fmt.Fprintf(&buf, "\t\t%s saddr %s %s accept\n", family, cidr, match)
+		}
+	}
+	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "}\n")
+	return buf.String()
+}
+
+// RenderIPTables returns the input, to be loaded with
+// "iptables-restore --noflush" or, if ipv6 is true, with
+// "ip6tables-restore --noflush", that replaces the rules in the
+// juju-ingress chain with ones dropping incoming traffic that the
+// given rules do not allow, other than DHCP and DNS requests from
+// containers on the host's container bridges. Only the given rules
+// for the matching IP version are included. The chain must be jumped to from the
+// INPUT chain for the rules to take effect.
+func RenderIPTables(rules []network.IngressRule, ipv6 bool) string {
+	icmp, dhcpServer, dhcpClient := "icmp", 67, 68
+	if ipv6 {
+		icmp, dhcpServer, dhcpClient = "ipv6-icmp", 547, 546
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "*filter\n")
+	fmt.Fprintf(&buf, ":%s - [0:0]\n", iptablesChain)
+	fmt.Fprintf(&buf, "-F %s\n", iptablesChain)
+	fmt.Fprintf(&buf, "-A %s -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT\n", iptablesChain)
+	fmt.Fprintf(&buf, "-A %s -m conntrack --ctstate INVALID -j DROP\n", iptablesChain)
+	fmt.Fprintf(&buf, "-A %s -i lo -j ACCEPT\n", iptablesChain)
+	fmt.Fprintf(&buf, "-A %s -p %s -j ACCEPT\n", iptablesChain, icmp)
+	fmt.Fprintf(&buf, "-A %s -p udp --sport %d --dport %d -j ACCEPT\n", iptablesChain, dhcpServer, dhcpClient)
+	for _, bridge := range containerBridges {
+		fmt.Fprintf(&buf, "-A %s -i %s -p udp --sport %d --dport %d -j ACCEPT\n", iptablesChain, bridge, dhcpClient, dhcpServer)
+		fmt.Fprintf(&buf, "-A %s -i %s -p udp --dport 53 -j ACCEPT\n", iptablesChain, bridge)
+		fmt.Fprintf(&buf, "-A %s -i %s -p tcp --dport 53 -j ACCEPT\n", iptablesChain, bridge)
+	}
+	for _, rule := range rules {
+		for _, cidr := range sourceCIDRs(rule) {
+			if network.IsIPv6CIDR(cidr) != ipv6 {
+				continue
+			}
+			if rule.Protocol == "icmp" {
+				fmt.Fprintf(&buf, "-A %s -s %s -p %s -j ACCEPT\n", iptablesChain, cidr, icmp)
+				continue
+			}
+			fmt.Fprintf(&buf, "-A %s -s %s -p %s --dport %s -j ACCEPT\n",
+				iptablesChain, cidr, rule.Protocol, portSpec(rule.PortRange, ":"))
+		}
+	}
+	fmt.Fprintf(&buf, "-A %s -j DROP\n", iptablesChain)
+	fmt.Fprintf(&buf, "COMMIT\n")
+	return buf.String()
+}
+
+// sourceCIDRs returns the sources the rule allows traffic from.
+// A rule without sources allows traffic from anywhere.
+func sourceCIDRs(rule network.IngressRule) []string {
+	if len(rule.SourceCIDRs) == 0 {
+		return []string{"0.0.0.0/0", "::/0"}
+	}
+	return rule.SourceCIDRs
+}
+
+// portSpec returns the port or the range of ports,
+// separated by sep, covered by the port range.
+func portSpec(portRange network.PortRange, sep string) string {
+	if portRange.FromPort == portRange.ToPort {
+		return fmt.Sprint(portRange.FromPort)
+	}
+	return fmt.Sprintf("%d%s%d", portRange.FromPort, sep, portRange.ToPort)
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/hostfirewaller"
+)
+
+type rulesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&rulesSuite{})
+
+var testRules = []network.IngressRule{
+	network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8", "2001:db8::/32"),
+	network.MustNewIngressRule("tcp", 8000, 8080),
+	network.MustNewIngressRule("udp", 53, 53, "192.168.0.0/16"),
+	network.MustNewIngressRule("icmp", -1, -1, "172.16.0.0/12"),
+}
+
+func (s *rulesSuite) TestRenderNftables(c *gc.C) {
+	c.Assert(hostfirewaller.RenderNftables(testRules), gc.Equals, `
+table inet juju
+delete table inet juju
+table inet juju {
+	chain input {
+		type filter hook input priority 0; policy drop;
+		ct state established,related accept
+		ct state invalid drop
+		iif lo accept
+		ip protocol icmp accept
+		ip6 nexthdr icmpv6 accept
+		udp sport 67 udp dport 68 accept
+		udp sport 547 udp dport 546 accept
+		iifname { "lxcbr0", "lxdbr0", "virbr0" } udp sport 68 udp dport 67 accept
+		iifname { "lxcbr0", "lxdbr0", "virbr0" } udp sport 546 udp dport 547 accept
+		iifname { "lxcbr0", "lxdbr0", "virbr0" } udp dport 53 accept
+		iifname { "lxcbr0", "lxdbr0", "virbr0" } tcp dport 53 accept
+		ip saddr 10.0.0.0/8 tcp dport 22 accept
+		ip6 saddr 2001:db8::/32 tcp dport 22 accept
+		ip saddr 0.0.0.0/0 tcp dport 8000-8080 accept
+		ip6 saddr ::/0 tcp dport 8000-8080 accept
+		ip saddr 192.168.0.0/16 udp dport 53 accept
+		ip saddr 172.16.0.0/12 ip protocol icmp accept
+	}
+}
+`[1:])
+}
+
+func (s *rulesSuite) TestRenderNftablesControllerAndRelatedMachines(c *gc.C) {
+	// The rules of a controller at 10.0.0.1 in a model whose other
+	// machines, at 10.0.0.2 and 10.0.0.3, host related units.
+	modelCIDRs := []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.3/32"}
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1, modelCIDRs...),
+		network.MustNewIngressRule("tcp", 1, 65535, modelCIDRs...),
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 17070, 17070, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 17070, 17070, "10.0.0.1/32"),
+		network.MustNewIngressRule("tcp", 37017, 37017, "10.0.0.1/32"),
+		network.MustNewIngressRule("udp", 1, 65535, modelCIDRs...),
+	}
+	rendered := hostfirewaller.RenderNftables(rules)
+	c.Assert(rendered, jc.Contains, `
+		ip saddr 10.0.0.1/32 ip protocol icmp accept
+		ip saddr 10.0.0.2/32 ip protocol icmp accept
+		ip saddr 10.0.0.3/32 ip protocol icmp accept
+		ip saddr 10.0.0.1/32 tcp dport 1-65535 accept
+		ip saddr 10.0.0.2/32 tcp dport 1-65535 accept
+		ip saddr 10.0.0.3/32 tcp dport 1-65535 accept
+		ip saddr 0.0.0.0/0 tcp dport 22 accept
+		ip saddr 0.0.0.0/0 tcp dport 17070 accept
+		ip saddr 10.0.0.1/32 tcp dport 17070 accept
+		ip saddr 10.0.0.1/32 tcp dport 37017 accept
+		ip saddr 10.0.0.1/32 udp dport 1-65535 accept
+		ip saddr 10.0.0.2/32 udp dport 1-65535 accept
+		ip saddr 10.0.0.3/32 udp dport 1-65535 accept
+	}
+`)
+	c.Assert(hostfirewaller.RenderIPTables(rules, false), jc.Contains, `
+-A juju-ingress -s 10.0.0.2/32 -p tcp --dport 1:65535 -j ACCEPT
+`[1:])
+}
+
+func (s *rulesSuite) TestRenderNftablesNoRules(c *gc.C) {
+	rendered := hostfirewaller.RenderNftables(nil)
+	c.Assert(rendered, jc.Contains, "policy drop;")
+	c.Assert(rendered, gc.Not(jc.Contains), "saddr")
+}
+
+func (s *rulesSuite) TestRenderIPTables(c *gc.C) {
+	c.Assert(hostfirewaller.RenderIPTables(testRules, false), gc.Equals, `
+*filter
+:juju-ingress - [0:0]
+-F juju-ingress
+-A juju-ingress -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+-A juju-ingress -m conntrack --ctstate INVALID -j DROP
+-A juju-ingress -i lo -j ACCEPT
+-A juju-ingress -p icmp -j ACCEPT
+-A juju-ingress -p udp --sport 67 --dport 68 -j ACCEPT
+-A juju-ingress -i lxcbr0 -p udp --sport 68 --dport 67 -j ACCEPT
+-A juju-ingress -i lxcbr0 -p udp --dport 53 -j ACCEPT
+-A juju-ingress -i lxcbr0 -p tcp --dport 53 -j ACCEPT
+-A juju-ingress -i lxdbr0 -p udp --sport 68 --dport 67 -j ACCEPT
+-A juju-ingress -i lxdbr0 -p udp --dport 53 -j ACCEPT
+-A juju-ingress -i lxdbr0 -p tcp --dport 53 -j ACCEPT
+-A juju-ingress -i virbr0 -p udp --sport 68 --dport 67 -j ACCEPT
+-A juju-ingress -i virbr0 -p udp --dport 53 -j ACCEPT
+-A juju-ingress -i virbr0 -p tcp --dport 53 -j ACCEPT
+-A juju-ingress -s 10.0.0.0/8 -p tcp --dport 22 -j ACCEPT
+-A juju-ingress -s 0.0.0.0/0 -p tcp --dport 8000:8080 -j ACCEPT
+-A juju-ingress -s 192.168.0.0/16 -p udp --dport 53 -j ACCEPT
+-A juju-ingress -s 172.16.0.0/12 -p icmp -j ACCEPT
+-A juju-ingress -j DROP
+COMMIT
+`[1:])
+}
+
+func (s *rulesSuite) TestRenderIPTablesIPv6(c *gc.C) {
+	c.Assert(hostfirewaller.RenderIPTables(testRules, true), gc.Equals, `
+*filter
+:juju-ingress - [0:0]
+-F juju-ingress
+-A juju-ingress -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+-A juju-ingress -m conntrack --ctstate INVALID -j DROP
+-A juju-ingress -i lo -j ACCEPT
+-A juju-ingress -p ipv6-icmp -j ACCEPT
+-A juju-ingress -p udp --sport 547 --dport 546 -j ACCEPT
+-A juju-ingress -i lxcbr0 -p udp --sport 546 --dport 547 -j ACCEPT
+-A juju-ingress -i lxcbr0 -p udp --dport 53 -j ACCEPT
+-A juju-ingress -i lxcbr0 -p tcp --dport 53 -j ACCEPT
+-A juju-ingress -i lxdbr0 -p udp --sport 546 --dport 547 -j ACCEPT
+-A juju-ingress -i lxdbr0 -p udp --dport 53 -j ACCEPT
+-A juju-ingress -i lxdbr0 -p tcp --dport 53 -j ACCEPT
+-A juju-ingress -i virbr0 -p udp --sport 546 --dport 547 -j ACCEPT
+-A juju-ingress -i virbr0 -p udp --dport 53 -j ACCEPT
+-A juju-ingress -i virbr0 -p tcp --dport 53 -j ACCEPT
+-A juju-ingress -s 2001:db8::/32 -p tcp --dport 22 -j ACCEPT
+-A juju-ingress -s ::/0 -p tcp --dport 8000:8080 -j ACCEPT
+-A juju-ingress -j DROP
+COMMIT
+`[1:])
+}
+
+func (s *rulesSuite) TestIPTablesBackend(c *gc.C) {
+	var stub testing.Stub
+	run := func(stdin, name string, args ...string) error {
+		stub.AddCall(name, args)
+		if len(args) > 0 && args[0] == "-C" {
+			// The jump is only in place for IPv6.
+			if name == "iptables" {
+				return errors.New("no such rule")
+			}
+		}
+		return nil
+	}
+	backend := hostfirewaller.NewIPTablesBackend(run)
+	err := backend.Apply(testRules)
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{
+		{"iptables-restore", []interface{}{[]string{"--noflush"}}},
+		{"iptables", []interface{}{[]string{"-C", "INPUT", "-j", "juju-ingress"}}},
+		{"iptables", []interface{}{[]string{"-I", "INPUT", "-j", "juju-ingress"}}},
+		{"ip6tables-restore", []interface{}{[]string{"--noflush"}}},
+		{"ip6tables", []interface{}{[]string{"-C", "INPUT", "-j", "juju-ingress"}}},
+	})
+}
+
+func (s *rulesSuite) TestNftablesBackend(c *gc.C) {
+	var stub testing.Stub
+	run := func(stdin, name string, args ...string) error {
+		stub.AddCall(name, stdin, args)
+		return stub.NextErr()
+	}
+	stub.SetErrors(errors.New("nft failed"))
+	backend := hostfirewaller.NewNftablesBackend(run)
+	err := backend.Apply(testRules)
+	c.Assert(err, gc.ErrorMatches, "nft failed")
+	stub.CheckCalls(c, []testing.StubCall{
+		{"nft", []interface{}{hostfirewaller.RenderNftables(testRules), []string{"-f", "-"}}},
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the worker that programs the
+// firewall of a machine from its ingress rules, when the model's
+// firewall-mode is "host". It is used on clouds without security
+// groups, such as MAAS and manual machines.
+package hostfirewaller
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.hostfirewaller")
+
+// Facade exposes the controller functionality the worker needs.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	WatchIngressRules(names.MachineTag) (watcher.NotifyWatcher, error)
+	IngressRules(names.MachineTag) ([]network.IngressRule, error)
+}
+
+// Config holds the configuration and dependencies for a host
+// firewaller worker.
+type Config struct {
+	Facade  Facade
+	Tag     names.MachineTag
+	Backend Backend
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional host firewaller.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Tag == (names.MachineTag{}) {
+		return errors.NotValidf("empty Tag")
+	}
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that keeps the firewall of the host
+// in line with the ingress rules of the configured machine.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &hostFirewaller{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// hostFirewaller implements watcher.NotifyHandler.
+type hostFirewaller struct {
+	config Config
+
+	// applied holds the rules last applied to the host
+	// firewall, or nil if none have been applied yet.
+	applied []network.IngressRule
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *hostFirewaller) SetUp() (watcher.NotifyWatcher, error) {
+	return h.config.Facade.WatchIngressRules(h.config.Tag)
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *hostFirewaller) Handle(_ <-chan struct{}) error {
+	rules, err := h.config.Facade.IngressRules(h.config.Tag)
+	if err != nil {
+		return errors.Annotate(err, "cannot get ingress rules")
+	}
+	if rules == nil {
+		rules = []network.IngressRule{}
+	}
+	if reflect.DeepEqual(rules, h.applied) {
+		return nil
+	}
+	if err := h.config.Backend.Apply(rules); err != nil {
+		return errors.Annotate(err, "cannot apply ingress rules")
+	}
+	logger.Infof("applied ingress rules to host firewall: %v", rules)
+	h.applied = rules
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *hostFirewaller) TearDown() error {
+	// The rules are left in place, so that the host is not
+	// opened up while the worker restarts.
+	return nil
+}
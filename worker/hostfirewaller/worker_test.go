@@ -0,0 +1,185 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/hostfirewaller"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+
+	facade  *mockFacade
+	backend *mockBackend
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	w := &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	s.facade = &mockFacade{watcher: w}
+	s.backend = &mockBackend{applied: make(chan []network.IngressRule, 10)}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := hostfirewaller.NewWorker(hostfirewaller.Config{
+		Facade:  s.facade,
+		Tag:     names.NewMachineTag("0"),
+		Backend: s.backend,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *workerSuite) assertApplied(c *gc.C, expect []network.IngressRule) {
+	select {
+	case rules := <-s.backend.applied:
+		c.Assert(rules, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for rules to be applied")
+	}
+}
+
+func (s *workerSuite) assertNotApplied(c *gc.C) {
+	select {
+	case rules := <-s.backend.applied:
+		c.Fatalf("unexpected rules applied: %v", rules)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := hostfirewaller.NewWorker(hostfirewaller.Config{
+		Tag:     names.NewMachineTag("0"),
+		Backend: s.backend,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = hostfirewaller.NewWorker(hostfirewaller.Config{
+		Facade:  s.facade,
+		Backend: s.backend,
+	})
+	c.Assert(err, gc.ErrorMatches, "empty Tag not valid")
+	_, err = hostfirewaller.NewWorker(hostfirewaller.Config{
+		Facade: s.facade,
+		Tag:    names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+}
+
+func (s *workerSuite) TestAppliesChangedRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+	}
+	s.facade.setRules(rules)
+	s.facade.watcher.changes <- struct{}{}
+
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+	s.assertApplied(c, rules)
+
+	// The same rules are not applied again.
+	s.facade.watcher.changes <- struct{}{}
+	s.assertNotApplied(c)
+
+	rules = append(rules, network.MustNewIngressRule("tcp", 80, 80))
+	s.facade.setRules(rules)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertApplied(c, rules)
+
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestAppliesNoRules(c *gc.C) {
+	s.facade.watcher.changes <- struct{}{}
+
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+	s.assertApplied(c, []network.IngressRule{})
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestApplyError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	s.facade.watcher.changes <- struct{}{}
+
+	w := s.startWorker(c)
+	s.assertApplied(c, []network.IngressRule{})
+	err := w.Wait()
+	c.Assert(err, gc.ErrorMatches, "cannot apply ingress rules: boom")
+}
+
+type mockFacade struct {
+	hostfirewaller.Facade
+
+	watcher *mockNotifyWatcher
+
+	mu    sync.Mutex
+	rules []network.IngressRule
+}
+
+func (f *mockFacade) setRules(rules []network.IngressRule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = rules
+}
+
+func (f *mockFacade) ModelConfig() (*config.Config, error) {
+	return nil, errors.New("unexpected call")
+}
+
+func (f *mockFacade) WatchIngressRules(names.MachineTag) (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *mockFacade) IngressRules(names.MachineTag) ([]network.IngressRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rules, nil
+}
+
+type mockBackend struct {
+	applied chan []network.IngressRule
+	err     error
+}
+
+func (b *mockBackend) Apply(rules []network.IngressRule) error {
+	b.applied <- rules
+	return b.err
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/clock"
@@ -193,6 +194,66 @@ func (s *machineSuite) TestLongPollIntervalWhenHasAllInstanceInfo(c *gc.C) {
 	clock.CheckCall(c, 0, "After", LongPoll)
 }
 
+func (s *machineSuite) TestReplacesTerminatedPreemptibleInstance(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: terminatedInstanceInfoGetter(c, "i1234", nil),
+	}
+	m := &testMachine{
+		tag:         names.NewMachineTag("99"),
+		instanceId:  "i1234",
+		life:        params.Alive,
+		preemptible: true,
+	}
+	_, err := pollInstanceInfo(context, m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.replaced, jc.IsTrue)
+}
+
+func (s *machineSuite) TestReplacesMissingPreemptibleInstance(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: terminatedInstanceInfoGetter(c, "i1234", errors.NotFoundf("instance i1234")),
+	}
+	m := &testMachine{
+		tag:         names.NewMachineTag("99"),
+		instanceId:  "i1234",
+		life:        params.Alive,
+		preemptible: true,
+	}
+	_, err := pollInstanceInfo(context, m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.replaced, jc.IsTrue)
+}
+
+func (s *machineSuite) TestKeepsTerminatedInstance(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: terminatedInstanceInfoGetter(c, "i1234", nil),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		life:       params.Alive,
+	}
+	_, err := pollInstanceInfo(context, m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.replaced, jc.IsFalse)
+	c.Assert(m.instStatusInfo, gc.Equals, "terminated")
+}
+
+func (s *machineSuite) TestKeepsTerminatedInstanceOfDyingMachine(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: terminatedInstanceInfoGetter(c, "i1234", nil),
+	}
+	m := &testMachine{
+		tag:         names.NewMachineTag("99"),
+		instanceId:  "i1234",
+		life:        params.Dying,
+		preemptible: true,
+	}
+	_, err := pollInstanceInfo(context, m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.replaced, jc.IsFalse)
+}
+
 func testRunMachine(
 	c *gc.C,
 	addrs []network.Address,
@@ -357,6 +418,13 @@ func instanceInfoGetter(
 	}
 }
 
+func terminatedInstanceInfoGetter(c *gc.C, expectId instance.Id, err error) func(id instance.Id) (instanceInfo, error) {
+	return func(id instance.Id) (instanceInfo, error) {
+		c.Check(id, gc.Equals, expectId)
+		return instanceInfo{nil, instance.InstanceStatus{Status: status.Empty, Message: "terminated"}}, err
+	}
+}
+
 type testMachineContext struct {
 	killErr         error
 	getInstanceInfo func(instance.Id) (instanceInfo, error)
@@ -396,6 +464,8 @@ type testMachine struct {
 	life            params.Life
 	addresses       []network.Address
 	setAddressCount int
+	preemptible     bool
+	replaced        bool
 }
 
 func (m *testMachine) Tag() names.MachineTag {
@@ -445,6 +515,18 @@ func (m *testMachine) IsManual() (bool, error) {
 	return strings.HasPrefix(string(m.instanceId), "manual:"), nil
 }
 
+func (m *testMachine) IsPreemptible() (bool, error) {
+	return m.preemptible, nil
+}
+
+func (m *testMachine) ReplaceInstance() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.instanceId = ""
+	m.replaced = true
+	return nil
+}
+
 func (m *testMachine) InstanceStatus() (params.StatusResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -46,6 +46,8 @@ type machine interface {
 	Life() params.Life
 	Status() (params.StatusResult, error)
 	IsManual() (bool, error)
+	IsPreemptible() (bool, error)
+	ReplaceInstance() error
 }
 
 type instanceInfo struct {
@@ -264,6 +266,15 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 		if params.IsCodeNotImplemented(err) {
 			return instanceInfo{}, err
 		}
+		if errors.IsNotFound(err) {
+			// The cloud may already have removed a terminated
+			// spot or preemptible instance altogether.
+			if replaced, err := replaceTerminatedInstance(m, instId); err != nil {
+				return instanceInfo{}, err
+			} else if replaced {
+				return instanceInfo{}, nil
+			}
+		}
 		logger.Warningf("cannot get instance info for instance %q: %v", instId, err)
 		return instInfo, nil
 	}
@@ -287,6 +298,14 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 		}
 
 	}
+	if instInfo.status.Status == status.Empty {
+		// The instance is stopping or has stopped.
+		if replaced, err := replaceTerminatedInstance(m, instId); err != nil {
+			return instanceInfo{}, err
+		} else if replaced {
+			return instanceInfo{}, nil
+		}
+	}
 	if m.Life() != params.Dead {
 		providerAddresses, err := m.ProviderAddresses()
 		if err != nil {
@@ -303,6 +322,28 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 	return instInfo, nil
 }
 
+// replaceTerminatedInstance asks for a replacement of the given
+// instance, which the cloud has terminated, if the machine runs on a
+// spot or preemptible instance. It reports whether the instance is
+// being replaced.
+func replaceTerminatedInstance(m machine, instId instance.Id) (bool, error) {
+	if m.Life() != params.Alive {
+		return false, nil
+	}
+	preemptible, err := m.IsPreemptible()
+	if err != nil {
+		return false, errors.Annotatef(err, "cannot check whether machine %q is preemptible", m)
+	}
+	if !preemptible {
+		return false, nil
+	}
+	logger.Infof("machine %q instance %q was terminated, replacing it", m.Id(), instId)
+	if err := m.ReplaceInstance(); err != nil {
+		return false, errors.Annotatef(err, "cannot replace instance %q of machine %q", instId, m)
+	}
+	return true, nil
+}
+
 // addressesEqual compares the addresses of the machine and the instance information.
 func addressesEqual(a0, a1 []network.Address) bool {
 	if len(a0) != len(a1) {
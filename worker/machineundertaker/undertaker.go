@@ -22,6 +22,7 @@ type Facade interface {
 	WatchMachineRemovals() (watcher.NotifyWatcher, error)
 	AllMachineRemovals() ([]names.MachineTag, error)
 	GetProviderInterfaceInfo(names.MachineTag) ([]network.ProviderInterfaceInfo, error)
+	KeepInstance(names.MachineTag) (bool, error)
 	CompleteRemoval(names.MachineTag) error
 }
 
@@ -87,7 +88,7 @@ func (u *Undertaker) Handle(<-chan struct{}) error {
 
 // MaybeReleaseAddresses releases any addresses that have been
 // allocated to this machine by the provider (if the provider supports
-// that).
+// that), unless the machine's instance is being kept.
 func (u *Undertaker) MaybeReleaseAddresses(machine names.MachineTag) error {
 	if u.Releaser == nil {
 		// This environ doesn't support releasing addresses.
@@ -97,6 +98,16 @@ func (u *Undertaker) MaybeReleaseAddresses(machine names.MachineTag) error {
 		// At the moment, only containers need their addresses releasing.
 		return nil
 	}
+	keep, err := u.API.KeepInstance(machine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if keep {
+		// The instance is still running, and still using its
+		// addresses.
+		logger.Debugf("%s is keeping its instance; not releasing addresses", machine)
+		return nil
+	}
 	interfaceInfos, err := u.API.GetProviderInterfaceInfo(machine)
 	if err != nil {
 		return errors.Trace(err)
@@ -164,6 +164,27 @@ func (*undertakerSuite) TestMaybeReleaseAddresses_Success(c *gc.C) {
 	)
 }
 
+func (*undertakerSuite) TestMaybeReleaseAddresses_KeepInstance(c *gc.C) {
+	api := fakeAPI{
+		Stub: &testing.Stub{},
+		interfaces: map[string][]network.ProviderInterfaceInfo{
+			"4/lxd/4": []network.ProviderInterfaceInfo{
+				{InterfaceName: "chloe"},
+			},
+		},
+		keep: map[string]bool{"4/lxd/4": true},
+	}
+	releaser := fakeReleaser{Stub: &testing.Stub{}}
+	u := machineundertaker.Undertaker{
+		API:      &api,
+		Releaser: &releaser,
+	}
+	err := u.MaybeReleaseAddresses(names.NewMachineTag("4/lxd/4"))
+	c.Assert(err, jc.ErrorIsNil)
+	api.CheckCallNames(c, "KeepInstance")
+	releaser.CheckCallNames(c)
+}
+
 func (*undertakerSuite) TestHandle_CompletesRemoval(c *gc.C) {
 	api := fakeAPI{
 		Stub:     &testing.Stub{},
@@ -288,6 +309,7 @@ type fakeAPI struct {
 	watcher    *mockNotifyWatcher
 	removals   []string
 	interfaces map[string][]network.ProviderInterfaceInfo
+	keep       map[string]bool
 }
 
 func (a *fakeAPI) WatchMachineRemovals() (watcher.NotifyWatcher, error) {
@@ -309,6 +331,11 @@ func (a *fakeAPI) GetProviderInterfaceInfo(machine names.MachineTag) ([]network.
 	return a.interfaces[machine.Id()], a.Stub.NextErr()
 }
 
+func (a *fakeAPI) KeepInstance(machine names.MachineTag) (bool, error) {
+	a.Stub.AddCall("KeepInstance", machine)
+	return a.keep[machine.Id()], a.Stub.NextErr()
+}
+
 func (a *fakeAPI) CompleteRemoval(machine names.MachineTag) error {
 	a.Stub.AddCall("CompleteRemoval", machine)
 	return a.Stub.NextErr()
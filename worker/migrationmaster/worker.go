@@ -406,6 +406,30 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	if err != nil {
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
+	if len(serialized.RoleGrants) > 0 {
+		err = targetClient.ImportRoleGrants(modelUUID, serialized.RoleGrants)
+		if err != nil {
+			return errors.Annotate(err, "failed to import role grants into target controller")
+		}
+	}
+	if len(serialized.UserGroups) > 0 {
+		err = targetClient.ImportUserGroups(modelUUID, serialized.UserGroups)
+		if err != nil {
+			return errors.Annotate(err, "failed to import user groups into target controller")
+		}
+	}
+	if len(serialized.ApplicationGrants) > 0 {
+		err = targetClient.ImportApplicationGrants(modelUUID, serialized.ApplicationGrants)
+		if err != nil {
+			return errors.Annotate(err, "failed to import application grants into target controller")
+		}
+	}
+	if serialized.Extras != nil {
+		err = targetClient.ImportExtras(modelUUID, serialized.Extras)
+		if err != nil {
+			return errors.Annotate(err, "failed to import model extras into target controller")
+		}
+	}
 
 	w.setInfoStatus("uploading model binaries into target controller")
 	wrapper := &uploadWrapper{targetClient, modelUUID}
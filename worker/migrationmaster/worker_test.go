@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource/resourcetesting"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
@@ -516,6 +517,88 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestImportRoleGrantsNotSupported(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.exportedRoleGrants = []coremigration.RoleGrant{{
+		User: names.NewUserTag("bob"),
+		Role: permission.Role{
+			Name:         "operator",
+			Capabilities: []permission.Capability{permission.ActionsCapability},
+		},
+	}}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestImportUserGroupsNotSupported(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.exportedUserGroups = []coremigration.UserGroup{{
+		Name:      "ops",
+		CreatedBy: "admin",
+		Members:   []names.UserTag{names.NewUserTag("bob")},
+	}}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestImportApplicationGrantsNotSupported(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.exportedApplicationGrants = []coremigration.ApplicationGrant{{
+		Application: "mysql",
+		User:        names.NewUserTag("bob"),
+		Access:      permission.ReadAccess,
+	}}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestImportExtrasNotSupported(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.exportedExtras = []byte(`{"version":1}`)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -1011,7 +1094,12 @@ type stubMasterFacade struct {
 	minionReports         []coremigration.MinionReports
 	minionReportsErr      error
 
-	exportedResources []coremigration.SerializedModelResource
+	exportedResources  []coremigration.SerializedModelResource
+	exportedRoleGrants []coremigration.RoleGrant
+	exportedUserGroups []coremigration.UserGroup
+
+	exportedApplicationGrants []coremigration.ApplicationGrant
+	exportedExtras            []byte
 }
 
 func (f *stubMasterFacade) triggerWatcher() {
@@ -1112,7 +1200,12 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 		Tools: map[version.Binary]string{
 			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
 		},
-		Resources: f.exportedResources,
+		Resources:  f.exportedResources,
+		RoleGrants: f.exportedRoleGrants,
+		UserGroups: f.exportedUserGroups,
+
+		ApplicationGrants: f.exportedApplicationGrants,
+		Extras:            f.exportedExtras,
 	}, nil
 }
 
@@ -113,6 +113,14 @@ func (f *fakeAPI) GetContainerInterfaceInfo(tag names.MachineTag) ([]network.Int
 	return []network.InterfaceInfo{f.fakeInterfaceInfo}, nil
 }
 
+func (f *fakeAPI) PrepareAddedContainerInterfaceInfo(tag names.MachineTag) ([]network.InterfaceInfo, error) {
+	f.MethodCall(f, "PrepareAddedContainerInterfaceInfo", tag)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return []network.InterfaceInfo{f.fakeInterfaceInfo}, nil
+}
+
 func (f *fakeAPI) ReleaseContainerAddresses(tag names.MachineTag) error {
 	f.MethodCall(f, "ReleaseContainerAddresses", tag)
 	if err := f.NextErr(); err != nil {
@@ -0,0 +1,148 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
+)
+
+// zonePlacementPrefix is the prefix of placement directives that
+// start an instance in a particular availability zone.
+const zonePlacementPrefix = "zone="
+
+// zonedBroker is implemented by brokers that start instances in
+// availability zones.
+type zonedBroker interface {
+	AvailabilityZones() ([]common.AvailabilityZone, error)
+}
+
+// capacityFallback finds somewhere else to start an instance, after
+// the cloud lacked the capacity for it. It records the availability
+// zones and instance types that lacked capacity, so that they are not
+// tried again for the same machine. Neither a placement directive nor
+// an instance type constraint given by the user is ever overridden.
+type capacityFallback struct {
+	broker        environs.InstanceBroker
+	chooseZone    bool
+	chooseType    bool
+	zones         set.Strings
+	instanceTypes set.Strings
+}
+
+func newCapacityFallback(broker environs.InstanceBroker, args environs.StartInstanceParams) *capacityFallback {
+	return &capacityFallback{
+		broker:        broker,
+		chooseZone:    args.Placement == "",
+		chooseType:    !args.Constraints.HasInstanceType(),
+		zones:         set.NewStrings(),
+		instanceTypes: set.NewStrings(),
+	}
+}
+
+// next updates args so that the instance is started in another
+// availability zone, or with another instance type, after the given
+// error. Other availability zones are tried first. next returns a
+// description of the change for the machine's status, or "" if the
+// error is not a capacity error or there is nowhere else to try.
+func (f *capacityFallback) next(args *environs.StartInstanceParams, err error) (string, error) {
+	capacityErr, ok := errors.Cause(err).(*environs.CapacityError)
+	if !ok {
+		return "", nil
+	}
+	for _, zone := range capacityErr.Zones {
+		f.zones.Add(zone)
+	}
+	if capacityErr.InstanceType != "" {
+		f.instanceTypes.Add(capacityErr.InstanceType)
+	}
+
+	zone, err := f.nextZone(args)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if zone != "" {
+		args.Placement = zonePlacementPrefix + zone
+		return fmt.Sprintf("in availability zone %q", zone), nil
+	}
+	instanceType, err := f.nextInstanceType(args)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if instanceType != "" {
+		args.Constraints.InstanceType = &instanceType
+		return fmt.Sprintf("with instance type %q", instanceType), nil
+	}
+	return "", nil
+}
+
+// nextZone returns an available zone that has not yet lacked
+// capacity for the instance, or "" if there is none.
+func (f *capacityFallback) nextZone(args *environs.StartInstanceParams) (string, error) {
+	if !f.chooseZone || f.zones.IsEmpty() {
+		return "", nil
+	}
+	broker, ok := f.broker.(zonedBroker)
+	if !ok {
+		return "", nil
+	}
+	zones, err := broker.AvailabilityZones()
+	if errors.IsNotImplemented(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Annotate(err, "cannot get availability zones")
+	}
+	// The instance must stay in a zone that has
+	// a subnet in the spaces it requires.
+	var subnetZones set.Strings
+	if len(args.SubnetsToZones) > 0 {
+		subnetZones = set.NewStrings()
+		for _, zoneNames := range args.SubnetsToZones {
+			subnetZones = subnetZones.Union(set.NewStrings(zoneNames...))
+		}
+	}
+	for _, zone := range zones {
+		name := zone.Name()
+		if !zone.Available() || f.zones.Contains(name) {
+			continue
+		}
+		if subnetZones != nil && !subnetZones.Contains(name) {
+			continue
+		}
+		return name, nil
+	}
+	return "", nil
+}
+
+// nextInstanceType returns the cheapest instance type that satisfies
+// the instance's constraints and has not yet lacked capacity for it,
+// or "" if there is none.
+func (f *capacityFallback) nextInstanceType(args *environs.StartInstanceParams) (string, error) {
+	if !f.chooseType || f.instanceTypes.IsEmpty() {
+		return "", nil
+	}
+	fetcher, ok := f.broker.(environs.InstanceTypesFetcher)
+	if !ok {
+		return "", nil
+	}
+	cons := args.Constraints
+	cons.InstanceType = nil
+	instanceTypes, err := fetcher.InstanceTypes(cons)
+	if errors.IsNotSupported(err) || errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Annotate(err, "cannot get instance types")
+	}
+	for _, instanceType := range instanceTypes.InstanceTypes {
+		if !f.instanceTypes.Contains(instanceType.Name) {
+			return instanceType.Name, nil
+		}
+	}
+	return "", nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/mutex"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/series"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
@@ -31,6 +32,7 @@ import (
 
 var (
 	systemNetworkInterfacesFile = "/etc/network/interfaces"
+	systemNetplanDirectory      = "/etc/netplan"
 	activateBridgesTimeout      = 5 * time.Minute
 )
 
@@ -215,7 +217,16 @@ func observeNetwork() ([]params.NetworkConfig, error) {
 	return getObservedNetworkConfig(common.DefaultNetworkConfigSource())
 }
 
+// defaultBridger returns a Bridger for the host machine, using netplan
+// or interfaces(5) depending on its series.
 func defaultBridger() (network.Bridger, error) {
+	hostSeries, err := series.HostSeries()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine host series")
+	}
+	if network.UsesNetplan(hostSeries) {
+		return network.DefaultNetplanBridger(activateBridgesTimeout, systemNetplanDirectory)
+	}
 	return network.DefaultEtcNetworkInterfacesBridger(activateBridgesTimeout, systemNetworkInterfacesFile)
 }
 
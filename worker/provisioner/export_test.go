@@ -30,8 +30,8 @@ var (
 	GetContainerInitialiser  = &getContainerInitialiser
 	GetToolsFinder           = &getToolsFinder
 	ResolvConf               = &resolvConf
-	RetryStrategyDelay       = &retryStrategyDelay
-	RetryStrategyCount       = &retryStrategyCount
+	RetryStrategyMaxDelay    = &retryStrategyMaxDelay
+	NextRetryDelay           = nextRetryDelay
 	GetObservedNetworkConfig = &getObservedNetworkConfig
 )
 
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/environs"
@@ -88,6 +89,14 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, errors.Trace(err)
 	}
 	network := container.BridgeNetworkConfig(bridgeDevice, 0, interfaces)
+	if config.ContainerNetworkingMethod == container.MacvlanNetwork {
+		if len(preparedInfo) > 0 {
+			network = container.MacvlanNetworkConfig(0, interfaces)
+		} else {
+			lxdLogger.Warningf("no network config prepared for container %q, using bridge %q instead of macvlan",
+				containerMachineID, bridgeDevice)
+		}
+	}
 
 	// The provisioner worker will provide all tools it knows about
 	// (after applying explicitly specified constraints), which may
@@ -155,16 +164,59 @@ func (broker *lxdBroker) AllInstances() (result []instance.Instance, err error)
 
 // MaintainInstance ensures the container's host has the required iptables and
 // routing rules to make the container visible to both the host and other
-// machines on the same subnet.
+// machines on the same subnet. When the container manager can give running
+// containers further interfaces, it also bridges the host and attaches the
+// container to the spaces the container has since been required to join.
 func (broker *lxdBroker) MaintainInstance(args environs.StartInstanceParams) error {
 	machineID := args.InstanceConfig.MachineId
 
-	// There's no InterfaceInfo we expect to get below.
-	_, err := prepareOrGetContainerInterfaceInfo(
-		broker.api,
-		machineID,
-		false, // maintain, do not allocate.
-		lxdLogger,
-	)
-	return err
+	adder, ok := broker.manager.(container.InterfaceAdder)
+	if !ok {
+		// There's no InterfaceInfo we expect to get below.
+		_, err := prepareOrGetContainerInterfaceInfo(
+			broker.api,
+			machineID,
+			false, // maintain, do not allocate.
+			lxdLogger,
+		)
+		return err
+	}
+
+	containerTag := names.NewMachineTag(machineID)
+	err := broker.prepareHost(containerTag, lxdLogger)
+	if err == nil {
+		err = broker.addContainerInterfaces(adder, containerTag)
+	}
+	if errors.IsNotSupported(err) || params.IsCodeNotSupported(err) {
+		lxdLogger.Debugf("not adding interfaces to container %q: %v", machineID, err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// addContainerInterfaces gives the running container the interfaces it
+// needs for the spaces it has been required to join since it was started.
+func (broker *lxdBroker) addContainerInterfaces(adder container.InterfaceAdder, containerTag names.MachineTag) error {
+	addedInfo, err := broker.api.PrepareAddedContainerInterfaceInfo(containerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(addedInfo) == 0 {
+		return nil
+	}
+	lxdLogger.Debugf("adding interfaces %+v to container %q", addedInfo, containerTag.Id())
+
+	config, err := broker.api.ContainerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	network := container.BridgeNetworkConfig("", 0, addedInfo)
+	if config.ContainerNetworkingMethod == container.MacvlanNetwork {
+		network = container.MacvlanNetworkConfig(0, addedInfo)
+	}
+	hostname, err := broker.manager.Namespace().Hostname(containerTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(adder.AddInterfaces(instance.Id(hostname), network))
 }
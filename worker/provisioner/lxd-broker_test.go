@@ -138,6 +138,26 @@ func (s *lxdBrokerSuite) TestStartInstancePopulatesFallbackNetworkInfo(c *gc.C)
 	c.Assert(err, gc.ErrorMatches, "container address allocation not supported")
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceWithMacvlan(c *gc.C) {
+	s.api.fakeContainerConfig.ContainerNetworkingMethod = "macvlan"
+	s.api.fakeInterfaceInfo.ParentInterfaceName = "eth0"
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	patchResolvConf(s, c)
+
+	result, err := s.startInstance(c, broker, "1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NetworkInfo, gc.HasLen, 1)
+	c.Check(result.NetworkInfo[0].ParentInterfaceName, gc.Equals, "eth0")
+
+	s.manager.CheckCallNames(c, "CreateContainer")
+	networkConfig := s.manager.Calls()[0].Args[3].(*container.NetworkConfig)
+	c.Check(networkConfig.NetworkType, gc.Equals, container.MacvlanNetwork)
+	c.Check(networkConfig.Device, gc.Equals, "")
+	c.Check(networkConfig.Interfaces, jc.DeepEquals, result.NetworkInfo)
+}
+
 func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	broker, brokerErr := s.newLXDBroker(c)
 	c.Assert(brokerErr, jc.ErrorIsNil)
@@ -153,6 +173,53 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need tools for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) TestMaintainInstanceAddsInterfaces(c *gc.C) {
+	s.api.fakeInterfaceInfo.ParentInterfaceName = "br-eth1"
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+	containerTag := names.NewMachineTag("1-lxd-0")
+
+	err := broker.MaintainInstance(environs.StartInstanceParams{
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{{
+		FuncName: "PrepareHost",
+		Args:     []interface{}{containerTag},
+	}, {
+		FuncName: "PrepareAddedContainerInterfaceInfo",
+		Args:     []interface{}{containerTag},
+	}, {
+		FuncName: "ContainerConfig",
+	}})
+
+	hostname, err := s.manager.Namespace().Hostname("1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCalls(c, []gitjujutesting.StubCall{{
+		FuncName: "AddInterfaces",
+		Args: []interface{}{
+			instance.Id(hostname),
+			container.BridgeNetworkConfig("", 0, []network.InterfaceInfo{s.api.fakeInterfaceInfo}),
+		},
+	}})
+}
+
+func (s *lxdBrokerSuite) TestMaintainInstanceNotSupported(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+	s.api.SetErrors(
+		nil, // PrepareHost succeeds
+		errors.NotSupportedf("adding container interfaces by this controller"),
+	)
+
+	err := broker.MaintainInstance(environs.StartInstanceParams{
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "PrepareHost", "PrepareAddedContainerInterfaceInfo")
+	s.manager.CheckNoCalls(c)
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -183,6 +250,11 @@ func (m *fakeContainerManager) Namespace() instance.Namespace {
 	return ns
 }
 
+func (m *fakeContainerManager) AddInterfaces(id instance.Id, network *container.NetworkConfig) error {
+	m.MethodCall(m, "AddInterfaces", id, network)
+	return m.NextErr()
+}
+
 func (m *fakeContainerManager) IsInitialized() bool {
 	m.MethodCall(m, "IsInitialized")
 	m.PopNoErr()
@@ -28,10 +28,9 @@ var logger = loggo.GetLogger("juju.provisioner")
 var _ Provisioner = (*environProvisioner)(nil)
 var _ Provisioner = (*containerProvisioner)(nil)
 
-var (
-	retryStrategyDelay = 10 * time.Second
-	retryStrategyCount = 10
-)
+// retryStrategyMaxDelay is the longest the provisioner waits
+// between attempts to start an instance.
+var retryStrategyMaxDelay = 5 * time.Minute
 
 // Provisioner represents a running provisioner worker.
 type Provisioner interface {
@@ -68,7 +67,8 @@ type provisioner struct {
 }
 
 // RetryStrategy defines the retry behavior when encountering a retryable
-// error during provisioning.
+// error during provisioning. The delay between attempts starts at
+// retryDelay, and doubles with each further attempt.
 //
 // TODO(katco): 2016-08-09: lp:1611427
 type RetryStrategy struct {
@@ -85,6 +85,22 @@ func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	}
 }
 
+// retryStrategy returns the retry strategy configured for the model
+// with the given config.
+func retryStrategy(modelCfg *config.Config) RetryStrategy {
+	return NewRetryStrategy(modelCfg.ProvisionerRetryDelay(), modelCfg.ProvisionerRetryCount())
+}
+
+// nextRetryDelay returns the delay before the attempt to start an
+// instance that follows one delayed by the given duration.
+func nextRetryDelay(delay time.Duration) time.Duration {
+	delay *= 2
+	if delay > retryStrategyMaxDelay {
+		delay = retryStrategyMaxDelay
+	}
+	return delay
+}
+
 // configObserver is implemented so that tests can see
 // when the environment configuration changes.
 type configObserver struct {
@@ -160,7 +176,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		retryStrategy(modelCfg),
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -236,6 +252,7 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategy(modelConfig))
 		}
 	}
 }
@@ -315,6 +332,22 @@ func (p *containerProvisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	// Containers need new devices bridged to them when the endpoints
+	// of their units are bound to other spaces. Older controllers
+	// cannot report such changes, so the containers are left alone.
+	var bindingsChanges watcher.NotifyChannel
+	bindingsWatcher, err := p.st.WatchEndpointBindings()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching endpoint bindings: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := p.catacomb.Add(bindingsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		bindingsChanges = bindingsWatcher.Changes()
+	}
+
 	for {
 		select {
 		case <-p.catacomb.Dying():
@@ -329,6 +362,12 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategy(modelConfig))
+		case _, ok := <-bindingsChanges:
+			if !ok {
+				return errors.New("endpoint bindings watch closed")
+			}
+			task.MaintainMachines()
 		}
 	}
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -44,11 +45,20 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// SetRetryStrategy sets how the provisioner task retries
+	// starting instances that fail to start.
+	SetRetryStrategy(strategy RetryStrategy)
+
+	// MaintainMachines asks the provisioner task to maintain the
+	// instances of all the provisioned containers it knows of.
+	MaintainMachines()
 }
 
 type MachineGetter interface {
 	Machine(names.MachineTag) (*apiprovisioner.Machine, error)
 	MachinesWithTransientErrors() ([]*apiprovisioner.Machine, []params.StatusResult, error)
+	KeptInstances() ([]instance.Id, error)
 }
 
 // ToolsFinder is an interface used for finding tools to run on
@@ -91,7 +101,10 @@ func NewProvisionerTask(
 		auth:                       auth,
 		harvestMode:                harvestMode,
 		harvestModeChan:            make(chan config.HarvestMode, 1),
+		retryStrategyChan:          make(chan RetryStrategy, 1),
+		maintainChan:               make(chan struct{}, 1),
 		machines:                   make(map[string]*apiprovisioner.Machine),
+		retries:                    make(map[string]*startRetry),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
 	}
@@ -119,11 +132,28 @@ type provisionerTask struct {
 	imageStream                string
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
+	retryStrategyChan          chan RetryStrategy
 	retryStartInstanceStrategy RetryStrategy
+	maintainChan               chan struct{}
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
 	machines map[string]*apiprovisioner.Machine
+	// machine id -> scheduled retry of starting its instance
+	retries map[string]*startRetry
+}
+
+// startRetry records a machine whose instance failed to start, and
+// when starting it is next to be attempted. Retries are scheduled
+// rather than waited for, so that one failing machine does not hold
+// up the provisioning of the others.
+type startRetry struct {
+	machine      *apiprovisioner.Machine
+	params       environs.StartInstanceParams
+	fallback     *capacityFallback
+	attemptsLeft int
+	delay        time.Duration
+	nextAttempt  time.Time
 }
 
 // Kill implements worker.Worker.Kill.
@@ -143,11 +173,17 @@ func (task *provisionerTask) loop() error {
 	// map. Otherwise we will potentially see all legitimate instances
 	// as unknown.
 	var harvestModeChan chan config.HarvestMode
+	// Likewise, there are no machines to maintain until then.
+	var maintainChan chan struct{}
 
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
 	// away, we know there will be some changes right off the bat.
 	for {
+		var retryAfter <-chan time.Time
+		if next, ok := task.nextRetryTime(); ok {
+			retryAfter = time.After(next.Sub(time.Now()))
+		}
 		select {
 		case <-task.catacomb.Dying():
 			logger.Infof("Shutting down provisioner task %s", task.machineTag)
@@ -163,6 +199,7 @@ func (task *provisionerTask) loop() error {
 			// We've seen a set of changes. Enable modification of
 			// harvesting mode.
 			harvestModeChan = task.harvestModeChan
+			maintainChan = task.maintainChan
 		case harvestMode := <-harvestModeChan:
 			if harvestMode == task.harvestMode {
 				break
@@ -175,6 +212,14 @@ func (task *provisionerTask) loop() error {
 					return errors.Annotate(err, "failed to process machines after safe mode disabled")
 				}
 			}
+		case strategy := <-task.retryStrategyChan:
+			task.retryStartInstanceStrategy = strategy
+		case <-maintainChan:
+			task.maintainKnownMachines()
+		case <-retryAfter:
+			if err := task.startDueRetries(); err != nil {
+				return errors.Annotate(err, "failed to retry starting machines")
+			}
 		case <-task.retryChanges:
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
@@ -191,6 +236,40 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// SetRetryStrategy implements ProvisionerTask.SetRetryStrategy().
+func (task *provisionerTask) SetRetryStrategy(strategy RetryStrategy) {
+	select {
+	case task.retryStrategyChan <- strategy:
+	case <-task.catacomb.Dying():
+	}
+}
+
+// MaintainMachines implements ProvisionerTask.MaintainMachines().
+func (task *provisionerTask) MaintainMachines() {
+	select {
+	case task.maintainChan <- struct{}{}:
+	case <-task.catacomb.Dying():
+	}
+}
+
+// maintainKnownMachines maintains the instances of the alive, provisioned
+// containers the task knows of.
+func (task *provisionerTask) maintainKnownMachines() {
+	var maintain []*apiprovisioner.Machine
+	for id, machine := range task.machines {
+		if state.ContainerTypeFromId(id) == "" || machine.Life() != params.Alive {
+			continue
+		}
+		if _, err := machine.InstanceId(); err != nil {
+			continue
+		}
+		maintain = append(maintain, machine)
+	}
+	if err := task.maintainMachines(maintain); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	machines, statusResults, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -204,6 +283,13 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 			continue
 		}
 		machine := machines[i]
+		if id, ok := statusResult.Data["instance-id"].(string); ok {
+			// The machine's instance was terminated by the cloud, and
+			// is being replaced. Stop what remains of it first.
+			if err := task.broker.StopInstances(instance.Id(id)); err != nil {
+				logger.Warningf("cannot stop terminated instance %q of machine %q: %v", id, statusResult.Id, err)
+			}
+		}
 		if err := machine.SetStatus(status.Pending, "", nil); err != nil {
 			logger.Errorf("cannot reset status of machine %q: %v", statusResult.Id, err)
 			continue
@@ -232,8 +318,11 @@ func (task *provisionerTask) processMachines(ids []string) error {
 		return err
 	}
 
-	// Stop all machines that are dead
-	stopping := task.instancesForMachines(dead)
+	// Stop all machines that are dead, unless their instances
+	// are to be kept.
+	stoppingMachines, keepingMachines := task.splitKeepingInstances(dead)
+	stopping := task.instancesForMachines(stoppingMachines)
+	task.untagInstances(task.instancesForMachines(keepingMachines))
 
 	// Find running instances that have no machines associated
 	unknown, err := task.findUnknownInstances(stopping)
@@ -275,6 +364,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 
 	// Remove any dead machines from state.
 	for _, machine := range dead {
+		delete(task.retries, machine.Id())
 		logger.Infof("removing dead machine %q", machine)
 		if err := machine.MarkForRemoval(); err != nil {
 			logger.Errorf("failed to remove dead machine %q", machine)
@@ -449,6 +539,15 @@ func (task *provisionerTask) findUnknownInstances(stopping []instance.Instance)
 	for _, inst := range stopping {
 		delete(instances, inst.Id())
 	}
+	// Instances kept when their machines were removed are no longer
+	// known to the model, but they must be left running.
+	kept, err := task.machineGetter.KeptInstances()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get kept instances")
+	}
+	for _, id := range kept {
+		delete(instances, id)
+	}
 	var unknown []instance.Instance
 	for _, inst := range instances {
 		unknown = append(unknown, inst)
@@ -474,6 +573,52 @@ func (task *provisionerTask) instancesForMachines(machines []*apiprovisioner.Mac
 	return instances
 }
 
+// splitKeepingInstances splits the given machines into those whose
+// instances should be stopped when they are removed, and those whose
+// instances should be kept. A machine whose keep-instance flag cannot
+// be read is assumed not to keep its instance, so that instances are
+// not leaked.
+func (task *provisionerTask) splitKeepingInstances(machines []*apiprovisioner.Machine) (stopping, keeping []*apiprovisioner.Machine) {
+	for _, machine := range machines {
+		keep, err := machine.KeepInstance()
+		if err != nil {
+			logger.Warningf("cannot read keep-instance of machine %q: %v", machine, err)
+		} else if keep {
+			logger.Infof("machine %q is dead, but its instance will be kept", machine)
+			keeping = append(keeping, machine)
+			continue
+		}
+		stopping = append(stopping, machine)
+	}
+	return stopping, keeping
+}
+
+// untagInstances removes the juju model and controller tags from the
+// given instances, where the broker supports tagging, so that they are
+// no longer destroyed along with the model or controller.
+func (task *provisionerTask) untagInstances(instances []instance.Instance) {
+	if len(instances) == 0 {
+		return
+	}
+	tagger, ok := task.broker.(environs.InstanceTagger)
+	if !ok {
+		logger.Warningf(
+			"cannot remove juju tags from kept instances %v: not supported by the provider",
+			instanceIds(instances),
+		)
+		return
+	}
+	untag := map[string]string{
+		tags.JujuModel:      "",
+		tags.JujuController: "",
+	}
+	for _, inst := range instances {
+		if err := tagger.TagInstance(inst.Id(), untag); err != nil {
+			logger.Warningf("cannot remove juju tags from kept instance %q: %v", inst.Id(), err)
+		}
+	}
+}
+
 func (task *provisionerTask) stopInstances(instances []instance.Instance) error {
 	// Although calling StopInstance with an empty slice should produce no change in the
 	// provider, environs like dummy do not consider this a noop.
@@ -623,17 +768,19 @@ func constructStartInstanceParams(
 	}
 
 	return environs.StartInstanceParams{
-		ControllerUUID:    controllerUUID,
-		Constraints:       provisioningInfo.Constraints,
-		Tools:             possibleTools,
-		InstanceConfig:    instanceConfig,
-		Placement:         provisioningInfo.Placement,
-		DistributionGroup: machine.DistributionGroup,
-		Volumes:           volumes,
-		SubnetsToZones:    subnetsToZones,
-		EndpointBindings:  endpointBindings,
-		ImageMetadata:     possibleImageMetadata,
-		StatusCallback:    machine.SetInstanceStatus,
+		ControllerUUID:     controllerUUID,
+		Constraints:        provisioningInfo.Constraints,
+		Tools:              possibleTools,
+		InstanceConfig:     instanceConfig,
+		Placement:          provisioningInfo.Placement,
+		DistributionGroup:  machine.DistributionGroup,
+		AntiAffinityGroups: provisioningInfo.AntiAffinityGroups,
+		Volumes:            volumes,
+		SubnetsToZones:     subnetsToZones,
+		EndpointBindings:   endpointBindings,
+		ImageMetadata:      possibleImageMetadata,
+		APIAllow:           controller.Config(provisioningInfo.ControllerConfig).APIAllow(),
+		StatusCallback:     machine.SetInstanceStatus,
 	}, nil
 }
 
@@ -658,10 +805,22 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 			return task.catacomb.ErrDying()
 		default:
 		}
+		if _, ok := task.retries[m.Id()]; ok {
+			// Starting the machine is already scheduled to be retried.
+			continue
+		}
 
 		pInfo, err := m.ProvisioningInfo()
 		if err != nil {
-			return task.setErrorStatus("fetching provisioning info for machine %q: %v", m, err)
+			// The machine cannot be provisioned as it stands (for
+			// example, a container whose host has no address in the
+			// spaces it requires, or is in maintenance). Record that
+			// in its status and carry on with the other machines; it
+			// can be retried with retry-provisioning.
+			if err := task.setErrorStatus("fetching provisioning info for machine %q: %v", m, err); err != nil {
+				return errors.Trace(err)
+			}
+			continue
 		}
 
 		instanceCfg, err := task.constructInstanceConfig(m, task.auth, pInfo)
@@ -703,6 +862,48 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 	return nil
 }
 
+// nextRetryTime returns when starting a machine is next to be
+// retried, and whether there is any retry scheduled.
+func (task *provisionerTask) nextRetryTime() (time.Time, bool) {
+	var next time.Time
+	for _, retry := range task.retries {
+		if next.IsZero() || retry.nextAttempt.Before(next) {
+			next = retry.nextAttempt
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// startDueRetries retries starting the machines whose next attempt
+// is due. Machines that are no longer alive are not retried; they
+// are dealt with when their life changes.
+func (task *provisionerTask) startDueRetries() error {
+	now := time.Now()
+	var due []*startRetry
+	for _, retry := range task.retries {
+		if !retry.nextAttempt.After(now) {
+			due = append(due, retry)
+		}
+	}
+	for _, retry := range due {
+		machine := retry.machine
+		if err := machine.Refresh(); params.IsCodeNotFound(err) {
+			delete(task.retries, machine.Id())
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot refresh machine %v", machine)
+		}
+		if machine.Life() != params.Alive {
+			delete(task.retries, machine.Id())
+			continue
+		}
+		if err := task.attemptStartMachine(retry); err != nil {
+			return errors.Annotatef(err, "cannot start machine %v", machine)
+		}
+	}
+	return nil
+}
+
 func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisioner.Machine, err error) error {
 	logger.Errorf(message, machine, err)
 	if err := machine.SetInstanceStatus(status.ProvisioningError, err.Error(), nil); err != nil {
@@ -717,36 +918,69 @@ func (task *provisionerTask) startMachine(
 	provisioningInfo *params.ProvisioningInfo,
 	startInstanceParams environs.StartInstanceParams,
 ) error {
-	var result *environs.StartInstanceResult
 	// TODO (jam): 2017-01-19 Should we be setting this earlier in the cycle?
 	if err := machine.SetInstanceStatus(status.Provisioning, "starting", nil); err != nil {
 		logger.Errorf("%v", err)
 	}
-	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; attemptsLeft-- {
-		attemptResult, err := task.broker.StartInstance(startInstanceParams)
+	return task.attemptStartMachine(&startRetry{
+		machine:      machine,
+		params:       startInstanceParams,
+		fallback:     newCapacityFallback(task.broker, startInstanceParams),
+		attemptsLeft: task.retryStartInstanceStrategy.retryCount,
+		delay:        task.retryStartInstanceStrategy.retryDelay,
+	})
+}
+
+// attemptStartMachine tries to start an instance for the machine
+// being retried. A failed attempt is retried straight away if the
+// cloud lacked the capacity for the instance and it can be started
+// somewhere else. Otherwise the next attempt is scheduled after a
+// delay that doubles every time, and attemptStartMachine returns so
+// that other machines can be provisioned in the meantime.
+func (task *provisionerTask) attemptStartMachine(retry *startRetry) error {
+	machine := retry.machine
+	delete(task.retries, machine.Id())
+	var result *environs.StartInstanceResult
+	for {
+		attemptResult, err := task.broker.StartInstance(retry.params)
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		} else if retry.attemptsLeft <= 0 {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved, but don't return
 			// an error; just keep going with the other machines.
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
 		}
 
+		alternative, err2 := retry.fallback.next(&retry.params, err)
+		if err2 != nil {
+			logger.Errorf("cannot find alternative for machine %q: %v", machine, err2)
+		}
+		if alternative != "" {
+			retryMsg := fmt.Sprintf("failed to start instance (%s), retrying %s (%d more attempts)",
+				err.Error(), alternative, retry.attemptsLeft)
+			logger.Warningf(retryMsg)
+			if err2 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err2 != nil {
+				logger.Errorf("%v", err2)
+			}
+			retry.attemptsLeft--
+			continue
+		}
+
 		retryMsg := fmt.Sprintf("failed to start instance (%s), retrying in %v (%d more attempts)",
-			err.Error(), task.retryStartInstanceStrategy.retryDelay, attemptsLeft)
+			err.Error(), retry.delay, retry.attemptsLeft)
 		logger.Warningf(retryMsg)
 		if err2 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err2 != nil {
 			logger.Errorf("%v", err2)
 		}
-
-		select {
-		case <-task.catacomb.Dying():
-			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
-		}
+		retry.attemptsLeft--
+		retry.nextAttempt = time.Now().Add(retry.delay)
+		retry.delay = nextRetryDelay(retry.delay)
+		task.retries[machine.Id()] = retry
+		return nil
 	}
+	startInstanceParams := retry.params
 
 	networkConfig := networkingcommon.NetworkConfigFromInterfaceInfo(result.NetworkInfo)
 	volumes := volumesToAPIserver(result.Volumes)
@@ -36,6 +36,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -449,6 +450,21 @@ func (s *ProvisionerSuite) TestSimple(c *gc.C) {
 	s.waitForRemovalMark(c, m)
 }
 
+func (s *ProvisionerSuite) TestKeepInstance(c *gc.C) {
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m)
+
+	// The machine is removed when it is Dead, but its instance is kept.
+	c.Assert(m.SetKeepInstance(true), jc.ErrorIsNil)
+	c.Assert(m.EnsureDead(), gc.IsNil)
+	s.waitForRemovalMark(c, m)
+	s.checkNoOperations(c)
+}
+
 func (s *ProvisionerSuite) TestConstraints(c *gc.C) {
 	// Create a machine with non-standard constraints.
 	m, err := s.addMachine()
@@ -563,8 +579,11 @@ func (s *ProvisionerSuite) waitUntilMachineNotPending(c *gc.C, m *state.Machine)
 
 func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithInjectedCreationError(c *gc.C) {
 	// Set the retry delay to 0, and retry count to 2 to keep tests short
-	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
-	s.PatchValue(provisioner.RetryStrategyCount, 2)
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"provisioner-retry-delay": "0s",
+		"provisioner-retry-count": 2,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
 
 	// create the error injection channel
 	errorInjectionChannel := make(chan error, 3)
@@ -597,8 +616,11 @@ func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithInjectedCreatio
 
 func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetryableCreationError(c *gc.C) {
 	// Set the retry delay to 0, and retry count to 2 to keep tests short
-	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
-	s.PatchValue(provisioner.RetryStrategyCount, 2)
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"provisioner-retry-delay": "0s",
+		"provisioner-retry-count": 2,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
 
 	// create the error injection channel
 	errorInjectionChannel := make(chan error, 1)
@@ -621,6 +643,23 @@ func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetrya
 	s.checkStartInstance(c, m)
 }
 
+func (s *ProvisionerSuite) TestProvisionerReplacesTerminatedInstance(c *gc.C) {
+	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	inst := s.checkStartInstance(c, m)
+
+	// The instance poller asks for a replacement when
+	// the cloud terminates a spot or preemptible instance.
+	err = m.ReplaceInstance(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStopInstances(c, inst)
+	s.checkStartInstance(c, m)
+}
+
 func (s *ProvisionerSuite) TestProvisionerStopRetryingIfDying(c *gc.C) {
 	// Create the error injection channel and inject
 	// a retryable error
@@ -1090,6 +1129,10 @@ func (*mockMachineGetter) MachinesWithTransientErrors() ([]*apiprovisioner.Machi
 	return nil, nil, fmt.Errorf("error")
 }
 
+func (*mockMachineGetter) KeptInstances() ([]instance.Id, error) {
+	return nil, fmt.Errorf("error")
+}
+
 func (s *ProvisionerSuite) TestMachineErrorsRetainInstances(c *gc.C) {
 	task := s.newProvisionerTask(c, config.HarvestAll, s.Environ, s.provisioner, mockToolsFinder{})
 	defer stop(c, task)
@@ -1253,6 +1296,34 @@ func (s *ProvisionerSuite) TestHarvestAllReapsAllTheThings(c *gc.C) {
 	s.waitForRemovalMark(c, m0)
 }
 
+func (s *ProvisionerSuite) TestHarvestAllLeavesKeptInstances(c *gc.C) {
+	task := s.newProvisionerTask(c,
+		config.HarvestDestroyed,
+		s.Environ,
+		s.provisioner,
+		mockToolsFinder{},
+	)
+	defer stop(c, task)
+
+	// Remove a machine, keeping its instance.
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	i0 := s.checkStartInstance(c, m0)
+	c.Assert(m0.SetKeepInstance(true), jc.ErrorIsNil)
+	c.Assert(m0.EnsureDead(), gc.IsNil)
+	s.waitForRemovalMark(c, m0)
+	s.checkNoOperations(c)
+	err = s.State.CompleteMachineRemovals(m0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A later pass harvesting unknown instances must not mistake the
+	// kept instance for one.
+	i1 := s.startUnknownInstance(c, "999")
+	task.SetHarvestMode(config.HarvestAll)
+	s.checkStopSomeInstances(c, []instance.Instance{i1}, []instance.Instance{i0})
+	s.checkNoOperations(c)
+}
+
 func (s *ProvisionerSuite) TestProvisionerRetriesTransientErrors(c *gc.C) {
 	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
 	e := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
@@ -1322,6 +1393,128 @@ func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	}
 }
 
+func (s *ProvisionerSuite) TestProvisionerRetriesInOtherZoneOnCapacityError(c *gc.C) {
+	broker := &mockZonedBroker{
+		Environ: s.Environ,
+		zones: []common.AvailabilityZone{
+			&mockAvailabilityZone{"zone1", true},
+			&mockAvailabilityZone{"zone2", false},
+			&mockAvailabilityZone{"zone3", true},
+		},
+		exhausted: []string{"zone1"},
+	}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+	task.SetRetryStrategy(provisioner.NewRetryStrategy(time.Hour, 1))
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	// The retry in another zone happens straight away,
+	// rather than after the retry delay.
+	s.checkStartInstance(c, m)
+	c.Assert(broker.placements, jc.DeepEquals, []string{"", "zone=zone3"})
+}
+
+func (s *ProvisionerSuite) TestProvisionerSkipsAllExhaustedZones(c *gc.C) {
+	broker := &mockZonedBroker{
+		Environ: s.Environ,
+		zones: []common.AvailabilityZone{
+			&mockAvailabilityZone{"zone1", true},
+			&mockAvailabilityZone{"zone2", true},
+			&mockAvailabilityZone{"zone3", true},
+		},
+		// The broker tried two zones before giving up.
+		exhausted: []string{"zone1", "zone2"},
+	}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+	task.SetRetryStrategy(provisioner.NewRetryStrategy(time.Hour, 1))
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m)
+	c.Assert(broker.placements, jc.DeepEquals, []string{"", "zone=zone3"})
+}
+
+func (s *ProvisionerSuite) TestProvisionerRetryDoesNotBlockOtherMachines(c *gc.C) {
+	broker := &mockFailingBroker{Environ: s.Environ, failingId: "1"}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+	task.SetRetryStrategy(provisioner.NewRetryStrategy(time.Hour, 1))
+
+	// Wait for starting the first machine to be scheduled for a retry.
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); ; {
+		s.BackingState.StartSync()
+		statusInfo, err := m1.InstanceStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		if strings.Contains(statusInfo.Message, "retrying in 1h0m0s") {
+			break
+		}
+		if !a.Next() {
+			c.Fatalf("machine %v was not scheduled for a retry", m1)
+		}
+	}
+
+	// The second machine is started while the first waits.
+	m2, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m2)
+	_, err = m1.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *ProvisionerSuite) TestNextRetryDelay(c *gc.C) {
+	s.PatchValue(provisioner.RetryStrategyMaxDelay, time.Minute)
+	c.Assert(provisioner.NextRetryDelay(10*time.Second), gc.Equals, 20*time.Second)
+	c.Assert(provisioner.NextRetryDelay(40*time.Second), gc.Equals, time.Minute)
+}
+
+type mockZonedBroker struct {
+	environs.Environ
+	zones      []common.AvailabilityZone
+	exhausted  []string
+	placements []string
+}
+
+func (b *mockZonedBroker) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	return b.zones, nil
+}
+
+func (b *mockZonedBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	b.placements = append(b.placements, args.Placement)
+	if args.Placement == "" {
+		return nil, environs.NewCapacityError(errors.New("no capacity"), b.exhausted, "")
+	}
+	return b.Environ.StartInstance(args)
+}
+
+type mockFailingBroker struct {
+	environs.Environ
+	failingId string
+}
+
+func (b *mockFailingBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	if args.InstanceConfig.MachineId == b.failingId {
+		return nil, errors.New("some error")
+	}
+	return b.Environ.StartInstance(args)
+}
+
+type mockAvailabilityZone struct {
+	name      string
+	available bool
+}
+
+func (z *mockAvailabilityZone) Name() string {
+	return z.name
+}
+
+func (z *mockAvailabilityZone) Available() bool {
+	return z.available
+}
+
 type mockBroker struct {
 	environs.Environ
 	retryCount map[string]int
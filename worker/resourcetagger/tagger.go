@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.resourcetagger")
+
+// Facade defines the interface we require from the resource tagger
+// facade.
+type Facade interface {
+	WatchResourceTags() (watcher.NotifyWatcher, error)
+	ModelResourceTags() (params.ModelResourceTags, error)
+}
+
+// Tagger is responsible for keeping the tags on a model's existing
+// cloud resources up to date, as the model's resource-tags and its
+// applications' resource tags change.
+//
+// Each of the environ taggers is optional; resources of a kind the
+// environ cannot tag are left alone. Failing to tag one resource does
+// not stop the others being tagged.
+type Tagger struct {
+	API                 Facade
+	InstanceTagger      environs.InstanceTagger
+	VolumeTagger        environs.VolumeTagger
+	ModelResourceTagger environs.ModelResourceTagger
+}
+
+// NewWorker returns a resource tagger worker that will watch for
+// changes to the resource tags of the model and its applications, and
+// retag the model's cloud resources accordingly.
+func NewWorker(api Facade, env environs.Environ) (worker.Worker, error) {
+	tagger := &Tagger{API: api}
+	tagger.InstanceTagger, _ = env.(environs.InstanceTagger)
+	tagger.VolumeTagger, _ = env.(environs.VolumeTagger)
+	tagger.ModelResourceTagger, _ = env.(environs.ModelResourceTagger)
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: tagger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// SetUp (part of watcher.NotifyHandler) starts watching for changes
+// to resource tags.
+func (t *Tagger) SetUp() (watcher.NotifyWatcher, error) {
+	logger.Infof("setting up resource tagger")
+	return t.API.WatchResourceTags()
+}
+
+// Handle (part of watcher.NotifyHandler) sets the current tags on
+// the model's instances, volumes and shared resources, removing the
+// tags given empty values.
+func (t *Tagger) Handle(<-chan struct{}) error {
+	if t.InstanceTagger == nil && t.VolumeTagger == nil && t.ModelResourceTagger == nil {
+		logger.Debugf("environ does not support tagging existing resources")
+		return nil
+	}
+	resourceTags, err := t.API.ModelResourceTags()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.InstanceTagger != nil {
+		for _, inst := range resourceTags.Instances {
+			err := t.InstanceTagger.TagInstance(instance.Id(inst.ProviderId), inst.Tags)
+			logTagError(err, "instance", inst.ProviderId)
+		}
+	}
+	if t.VolumeTagger != nil {
+		for _, volume := range resourceTags.Volumes {
+			err := t.VolumeTagger.TagVolume(volume.ProviderId, volume.Tags)
+			logTagError(err, "volume", volume.ProviderId)
+		}
+	}
+	if t.ModelResourceTagger != nil {
+		err := t.ModelResourceTagger.TagModelResources(resourceTags.ModelTags)
+		logTagError(err, "model resources", "")
+	}
+	return nil
+}
+
+func logTagError(err error, kind, id string) {
+	if err == nil {
+		return
+	}
+	what := kind
+	if id != "" {
+		what += " " + id
+	}
+	if errors.IsNotSupported(err) {
+		logger.Debugf("cannot tag %s: %v", what, err)
+		return
+	}
+	logger.Errorf("failed to tag %s: %v", what, err)
+}
+
+// TearDown (part of watcher.NotifyHandler) is an opportunity to stop
+// or release any resources created in SetUp other than the watcher,
+// which watcher.NotifyWorker takes care of for us.
+func (t *Tagger) TearDown() error {
+	logger.Infof("tearing down resource tagger")
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/resourcetagger"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type taggerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&taggerSuite{})
+
+var modelResourceTags = params.ModelResourceTags{
+	ModelTags: map[string]string{"team": "ops"},
+	Instances: []params.ResourceTags{{
+		ProviderId: "i-1",
+		Tags:       map[string]string{"team": "web"},
+	}, {
+		ProviderId: "i-2",
+		// Tags with empty values are to be removed.
+		Tags: map[string]string{"team": "ops", "owner": ""},
+	}},
+	Volumes: []params.ResourceTags{{
+		ProviderId: "vol-1",
+		Tags:       map[string]string{"team": "db"},
+	}},
+}
+
+// Check that the handler is wired up to the NotifyWorker first.
+
+func (s *taggerSuite) TestErrorWatching(c *gc.C) {
+	api := s.makeAPIWithWatcher()
+	api.SetErrors(errors.New("blam"))
+	w, err := resourcetagger.NewWorker(api, &fakeEnviron{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "blam")
+	api.CheckCallNames(c, "WatchResourceTags")
+}
+
+func (s *taggerSuite) TestErrorGettingResourceTags(c *gc.C) {
+	api := s.makeAPIWithWatcher()
+	api.SetErrors(nil, errors.New("explodo"))
+	w, err := resourcetagger.NewWorker(api, &fakeEnviron{Stub: &testing.Stub{}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "explodo")
+	api.CheckCallNames(c, "WatchResourceTags", "ModelResourceTags")
+}
+
+// The rest of the tests use the Tagger directly, so that everything
+// happens in the same goroutine.
+
+func (*taggerSuite) TestHandleTagsResources(c *gc.C) {
+	api := &fakeAPI{Stub: &testing.Stub{}, resourceTags: modelResourceTags}
+	env := &fakeEnviron{Stub: &testing.Stub{}}
+	t := resourcetagger.Tagger{
+		API:                 api,
+		InstanceTagger:      env,
+		VolumeTagger:        env,
+		ModelResourceTagger: env,
+	}
+	err := t.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckCalls(c, []testing.StubCall{
+		{"TagInstance", []interface{}{instance.Id("i-1"), map[string]string{"team": "web"}}},
+		{"TagInstance", []interface{}{instance.Id("i-2"), map[string]string{"team": "ops", "owner": ""}}},
+		{"TagVolume", []interface{}{"vol-1", map[string]string{"team": "db"}}},
+		{"TagModelResources", []interface{}{map[string]string{"team": "ops"}}},
+	})
+}
+
+func (*taggerSuite) TestHandleContinuesAfterTagError(c *gc.C) {
+	api := &fakeAPI{Stub: &testing.Stub{}, resourceTags: modelResourceTags}
+	env := &fakeEnviron{Stub: &testing.Stub{}}
+	env.SetErrors(errors.New("boom"), errors.NotSupportedf("tagging"))
+	t := resourcetagger.Tagger{
+		API:                 api,
+		InstanceTagger:      env,
+		VolumeTagger:        env,
+		ModelResourceTagger: env,
+	}
+	err := t.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckCallNames(c, "TagInstance", "TagInstance", "TagVolume", "TagModelResources")
+}
+
+func (*taggerSuite) TestHandlePartialSupport(c *gc.C) {
+	api := &fakeAPI{Stub: &testing.Stub{}, resourceTags: modelResourceTags}
+	env := &fakeEnviron{Stub: &testing.Stub{}}
+	t := resourcetagger.Tagger{
+		API:            api,
+		InstanceTagger: env,
+	}
+	err := t.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	env.CheckCallNames(c, "TagInstance", "TagInstance")
+}
+
+func (*taggerSuite) TestHandleNoTaggers(c *gc.C) {
+	api := &fakeAPI{Stub: &testing.Stub{}, resourceTags: modelResourceTags}
+	t := resourcetagger.Tagger{API: api}
+	err := t.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	api.CheckNoCalls(c)
+}
+
+func (s *taggerSuite) makeAPIWithWatcher() *fakeAPI {
+	return &fakeAPI{
+		Stub:    &testing.Stub{},
+		watcher: s.newMockNotifyWatcher(),
+	}
+}
+
+func (s *taggerSuite) newMockNotifyWatcher() *mockNotifyWatcher {
+	m := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	go func() {
+		defer m.tomb.Done()
+		defer m.tomb.Kill(nil)
+		<-m.tomb.Dying()
+	}()
+	s.AddCleanup(func(c *gc.C) {
+		err := worker.Stop(m)
+		c.Check(err, jc.ErrorIsNil)
+	})
+	m.Change()
+	return m
+}
+
+type fakeEnviron struct {
+	environs.Environ
+	*testing.Stub
+}
+
+func (e *fakeEnviron) TagInstance(id instance.Id, tags map[string]string) error {
+	e.MethodCall(e, "TagInstance", id, tags)
+	return e.NextErr()
+}
+
+func (e *fakeEnviron) TagVolume(id string, tags map[string]string) error {
+	e.MethodCall(e, "TagVolume", id, tags)
+	return e.NextErr()
+}
+
+func (e *fakeEnviron) TagModelResources(tags map[string]string) error {
+	e.MethodCall(e, "TagModelResources", tags)
+	return e.NextErr()
+}
+
+type fakeAPI struct {
+	resourcetagger.Facade
+
+	*testing.Stub
+	watcher      *mockNotifyWatcher
+	resourceTags params.ModelResourceTags
+}
+
+func (a *fakeAPI) WatchResourceTags() (watcher.NotifyWatcher, error) {
+	a.Stub.AddCall("WatchResourceTags")
+	return a.watcher, a.Stub.NextErr()
+}
+
+func (a *fakeAPI) ModelResourceTags() (params.ModelResourceTags, error) {
+	a.Stub.AddCall("ModelResourceTags")
+	return a.resourceTags, a.Stub.NextErr()
+}
+
+type mockNotifyWatcher struct {
+	watcher.NotifyWatcher
+
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func (m *mockNotifyWatcher) Kill() {
+	m.tomb.Kill(nil)
+}
+
+func (m *mockNotifyWatcher) Wait() error {
+	return m.tomb.Wait()
+}
+
+func (m *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return m.changes
+}
+
+func (m *mockNotifyWatcher) Change() {
+	m.changes <- struct{}{}
+}
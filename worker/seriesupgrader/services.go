@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package seriesupgrader
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/shell"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/service/systemd"
+)
+
+// NewServiceManager returns a ServiceManager that manages the agent
+// services of the machine agent with the given configuration.
+func NewServiceManager(agentConfig agent.Config) ServiceManager {
+	return &serviceManager{
+		machineId:     agentConfig.Tag().Id(),
+		dataDir:       agentConfig.DataDir(),
+		logDir:        agentConfig.LogDir(),
+		containerType: agentConfig.Value(agent.ContainerType),
+	}
+}
+
+type serviceManager struct {
+	machineId     string
+	dataDir       string
+	logDir        string
+	containerType string
+}
+
+func unitServiceName(unitName string) string {
+	return "jujud-" + names.NewUnitTag(unitName).String()
+}
+
+// StopUnitAgents is part of the ServiceManager interface.
+func (m *serviceManager) StopUnitAgents(unitNames []string) error {
+	for _, unitName := range unitNames {
+		svc, err := service.DiscoverService(unitServiceName(unitName), common.Conf{})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := svc.Stop(); err != nil {
+			return errors.Annotatef(err, "stopping agent of unit %q", unitName)
+		}
+	}
+	return nil
+}
+
+// StartUnitAgents is part of the ServiceManager interface.
+func (m *serviceManager) StartUnitAgents(unitNames []string) error {
+	for _, unitName := range unitNames {
+		svc, err := service.DiscoverService(unitServiceName(unitName), common.Conf{})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := svc.Start(); err != nil {
+			return errors.Annotatef(err, "starting agent of unit %q", unitName)
+		}
+	}
+	return nil
+}
+
+// WriteAgentServices is part of the ServiceManager interface. Only
+// upgrades to series using systemd can change the init system, so
+// that is the only init system services are written for.
+func (m *serviceManager) WriteAgentServices(unitNames []string, toSeries string) error {
+	hostSeries, err := series.HostSeries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fromInitSystem, err := service.VersionInitSystem(hostSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	toInitSystem, err := service.VersionInitSystem(toSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if fromInitSystem == toInitSystem {
+		logger.Debugf("init system %q is unchanged; agent services need not be rewritten", toInitSystem)
+		return nil
+	}
+	if toInitSystem != service.InitSystemSystemd {
+		return errors.NotSupportedf("upgrading from init system %q to %q", fromInitSystem, toInitSystem)
+	}
+
+	dataDir, err := paths.DataDir(toSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	renderer, err := shell.NewRenderer("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineInfo := service.NewMachineAgentInfo(m.machineId, m.dataDir, m.logDir)
+	confs := map[string]common.Conf{
+		"jujud-" + names.NewMachineTag(m.machineId).String(): service.AgentConf(machineInfo, renderer),
+	}
+	for _, unitName := range unitNames {
+		unitInfo := service.NewUnitAgentInfo(unitName, m.dataDir, m.logDir)
+		confs[unitServiceName(unitName)] = service.ContainerAgentConf(unitInfo, renderer, m.containerType)
+	}
+	for name, conf := range confs {
+		svc, err := systemd.NewService(name, conf, dataDir)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := svc.WriteService(); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("wrote %s service %q", toInitSystem, name)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package seriesupgrader
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	apiupgradeseries "github.com/juju/juju/api/upgradeseries"
+)
+
+// NewFacade returns a Facade for the machine with the given tag.
+func NewFacade(apiCaller base.APICaller, tag names.MachineTag) (Facade, error) {
+	return apiupgradeseries.NewClient(apiCaller, tag), nil
+}
+
+// NewWorker returns a seriesupgrader Worker backed by config.
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package seriesupgrader provides the worker that carries out the
+// machine agent's part of an in-place series upgrade.
+package seriesupgrader
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/upgradeseries"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.seriesupgrader")
+
+// Facade exposes the series upgrade functionality of the controller
+// to a Worker, on behalf of a single machine.
+type Facade interface {
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	MachineStatus() (upgradeseries.Status, error)
+	SetMachineStatus(upgradeseries.Status) error
+	TargetSeries() (string, error)
+	UnitStatuses() (map[string]upgradeseries.Status, error)
+	FinishUpgradeSeries() error
+}
+
+// ServiceManager manipulates the init system services of the agents
+// running on the machine.
+type ServiceManager interface {
+	// StopUnitAgents stops the services of the agents of the given
+	// units.
+	StopUnitAgents(unitNames []string) error
+
+	// WriteAgentServices writes the services of the machine agent and
+	// the agents of the given units for the init system used by the
+	// given series, if it differs from the one the host runs.
+	WriteAgentServices(unitNames []string, series string) error
+
+	// StartUnitAgents starts the services of the agents of the given
+	// units.
+	StartUnitAgents(unitNames []string) error
+}
+
+// Config defines the parameters of the seriesupgrader worker.
+type Config struct {
+	Facade   Facade
+	Services ServiceManager
+}
+
+// Validate returns an error if Config cannot drive a seriesupgrader.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Services == nil {
+		return errors.NotValidf("nil Services")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &seriesUpgrader{config: config},
+	})
+	return w, errors.Trace(err)
+}
+
+// seriesUpgrader waits for the units on the machine to prepare for a
+// series upgrade, then stops their agents and rewrites the agent
+// services for the new series. Once the upgrade is completed, it
+// starts the unit agents again and unlocks the machine when the units
+// have run their post-series-upgrade hooks.
+type seriesUpgrader struct {
+	config Config
+
+	// unitAgentsStarted records whether the unit agents have been
+	// started since the series upgrade was completed.
+	unitAgentsStarted bool
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (w *seriesUpgrader) SetUp() (watcher.NotifyWatcher, error) {
+	return w.config.Facade.WatchUpgradeSeriesNotifications()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (w *seriesUpgrader) Handle(_ <-chan struct{}) error {
+	status, err := w.config.Facade.MachineStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch status {
+	case upgradeseries.PrepareStarted:
+		w.unitAgentsStarted = false
+		return errors.Trace(w.handlePrepareStarted())
+	case upgradeseries.CompleteStarted:
+		return errors.Trace(w.handleCompleteStarted())
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (w *seriesUpgrader) TearDown() error {
+	return nil
+}
+
+// handlePrepareStarted stops the unit agents and rewrites the agent
+// services once every unit has run its pre-series-upgrade hook.
+func (w *seriesUpgrader) handlePrepareStarted() error {
+	statuses, err := w.config.Facade.UnitStatuses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allReached(statuses, upgradeseries.PrepareCompleted) {
+		return nil
+	}
+	unitNames := sortedUnitNames(statuses)
+	series, err := w.config.Facade.TargetSeries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("units %v prepared for upgrade to %s; stopping unit agents", unitNames, series)
+	if err := w.config.Services.StopUnitAgents(unitNames); err != nil {
+		return errors.Annotate(err, "stopping unit agents")
+	}
+	if err := w.config.Services.WriteAgentServices(unitNames, series); err != nil {
+		return errors.Annotate(err, "writing agent services")
+	}
+	return errors.Trace(w.config.Facade.SetMachineStatus(upgradeseries.PrepareCompleted))
+}
+
+// handleCompleteStarted starts the unit agents so that they can run
+// their post-series-upgrade hooks, and finishes the series upgrade
+// once they all have.
+func (w *seriesUpgrader) handleCompleteStarted() error {
+	statuses, err := w.config.Facade.UnitStatuses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !w.unitAgentsStarted {
+		unitNames := sortedUnitNames(statuses)
+		logger.Infof("series upgrade completed; starting unit agents %v", unitNames)
+		if err := w.config.Services.StartUnitAgents(unitNames); err != nil {
+			return errors.Annotate(err, "starting unit agents")
+		}
+		w.unitAgentsStarted = true
+	}
+	if !allReached(statuses, upgradeseries.Completed) {
+		return nil
+	}
+	if err := w.config.Facade.SetMachineStatus(upgradeseries.Completed); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("series upgrade finished")
+	return errors.Trace(w.config.Facade.FinishUpgradeSeries())
+}
+
+// allReached returns whether every unit has reached the given status.
+func allReached(statuses map[string]upgradeseries.Status, status upgradeseries.Status) bool {
+	for _, unitStatus := range statuses {
+		if unitStatus != status {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedUnitNames(statuses map[string]upgradeseries.Status) []string {
+	unitNames := make([]string, 0, len(statuses))
+	for unitName := range statuses {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+	return unitNames
+}
@@ -0,0 +1,194 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package seriesupgrader_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/upgradeseries"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/seriesupgrader"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	stub     *jujutesting.Stub
+	facade   *stubFacade
+	services *stubServices
+	config   seriesupgrader.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.facade = &stubFacade{
+		stub:   s.stub,
+		series: "xenial",
+	}
+	s.services = &stubServices{stub: s.stub}
+	s.config = seriesupgrader.Config{
+		Facade:   s.facade,
+		Services: s.services,
+	}
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.Services = nil
+	_, err := seriesupgrader.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Services not valid")
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+// runWorker runs the worker until it has made the given number of
+// calls, then stops it.
+func (s *Suite) runWorker(c *gc.C, expectedCalls int) {
+	w, err := seriesupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.stub.Calls()) >= expectedCalls {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d calls; got %v", expectedCalls, s.stub.Calls())
+}
+
+func (s *Suite) TestNotUpgrading(c *gc.C) {
+	s.facade.machineStatus = upgradeseries.NotStarted
+	s.runWorker(c, 2)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "MachineStatus")
+}
+
+func (s *Suite) TestPrepareWaitsForUnits(c *gc.C) {
+	s.facade.machineStatus = upgradeseries.PrepareStarted
+	s.facade.unitStatuses = map[string]upgradeseries.Status{
+		"mysql/0":     upgradeseries.PrepareCompleted,
+		"wordpress/0": upgradeseries.PrepareStarted,
+	}
+	s.runWorker(c, 3)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "MachineStatus", "UnitStatuses")
+}
+
+func (s *Suite) TestPrepare(c *gc.C) {
+	s.facade.machineStatus = upgradeseries.PrepareStarted
+	s.facade.unitStatuses = map[string]upgradeseries.Status{
+		"wordpress/0": upgradeseries.PrepareCompleted,
+		"mysql/0":     upgradeseries.PrepareCompleted,
+	}
+	s.runWorker(c, 7)
+	unitNames := []string{"mysql/0", "wordpress/0"}
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchUpgradeSeriesNotifications", nil},
+		{"MachineStatus", nil},
+		{"UnitStatuses", nil},
+		{"TargetSeries", nil},
+		{"StopUnitAgents", []interface{}{unitNames}},
+		{"WriteAgentServices", []interface{}{unitNames, "xenial"}},
+		{"SetMachineStatus", []interface{}{upgradeseries.PrepareCompleted}},
+	})
+}
+
+func (s *Suite) TestCompleteWaitsForUnits(c *gc.C) {
+	s.facade.machineStatus = upgradeseries.CompleteStarted
+	s.facade.unitStatuses = map[string]upgradeseries.Status{
+		"mysql/0": upgradeseries.CompleteStarted,
+	}
+	s.runWorker(c, 4)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchUpgradeSeriesNotifications", nil},
+		{"MachineStatus", nil},
+		{"UnitStatuses", nil},
+		{"StartUnitAgents", []interface{}{[]string{"mysql/0"}}},
+	})
+}
+
+func (s *Suite) TestComplete(c *gc.C) {
+	s.facade.machineStatus = upgradeseries.CompleteStarted
+	s.facade.unitStatuses = map[string]upgradeseries.Status{
+		"mysql/0": upgradeseries.Completed,
+	}
+	s.runWorker(c, 6)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchUpgradeSeriesNotifications", nil},
+		{"MachineStatus", nil},
+		{"UnitStatuses", nil},
+		{"StartUnitAgents", []interface{}{[]string{"mysql/0"}}},
+		{"SetMachineStatus", []interface{}{upgradeseries.Completed}},
+		{"FinishUpgradeSeries", nil},
+	})
+}
+
+type notAWatcher struct {
+	workertest.NotAWatcher
+}
+
+func (w notAWatcher) Changes() watcher.NotifyChannel {
+	return w.NotAWatcher.Changes()
+}
+
+type stubFacade struct {
+	stub          *jujutesting.Stub
+	machineStatus upgradeseries.Status
+	unitStatuses  map[string]upgradeseries.Status
+	series        string
+}
+
+func (f *stubFacade) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("WatchUpgradeSeriesNotifications")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return notAWatcher{workertest.NewFakeWatcher(1, 1)}, nil
+}
+
+func (f *stubFacade) MachineStatus() (upgradeseries.Status, error) {
+	f.stub.AddCall("MachineStatus")
+	return f.machineStatus, f.stub.NextErr()
+}
+
+func (f *stubFacade) SetMachineStatus(status upgradeseries.Status) error {
+	f.stub.AddCall("SetMachineStatus", status)
+	return f.stub.NextErr()
+}
+
+func (f *stubFacade) TargetSeries() (string, error) {
+	f.stub.AddCall("TargetSeries")
+	return f.series, f.stub.NextErr()
+}
+
+func (f *stubFacade) UnitStatuses() (map[string]upgradeseries.Status, error) {
+	f.stub.AddCall("UnitStatuses")
+	return f.unitStatuses, f.stub.NextErr()
+}
+
+func (f *stubFacade) FinishUpgradeSeries() error {
+	f.stub.AddCall("FinishUpgradeSeries")
+	return f.stub.NextErr()
+}
+
+type stubServices struct {
+	stub *jujutesting.Stub
+}
+
+func (s *stubServices) StopUnitAgents(unitNames []string) error {
+	s.stub.AddCall("StopUnitAgents", unitNames)
+	return s.stub.NextErr()
+}
+
+func (s *stubServices) WriteAgentServices(unitNames []string, series string) error {
+	s.stub.AddCall("WriteAgentServices", unitNames, series)
+	return s.stub.NextErr()
+}
+
+func (s *stubServices) StartUnitAgents(unitNames []string) error {
+	s.stub.AddCall("StartUnitAgents", unitNames)
+	return s.stub.NextErr()
+}
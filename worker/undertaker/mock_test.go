@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/undertaker"
@@ -71,6 +72,15 @@ func (mock *mockEnviron) Destroy() error {
 	return mock.stub.NextErr()
 }
 
+type mockKeepingEnviron struct {
+	*mockEnviron
+}
+
+func (mock *mockKeepingEnviron) DestroyKeepingInstances(keep []instance.Id) error {
+	mock.stub.MethodCall(mock, "DestroyKeepingInstances", keep)
+	return mock.stub.NextErr()
+}
+
 type mockWatcher struct {
 	worker.Worker
 	changes chan struct{}
@@ -84,6 +94,10 @@ type fixture struct {
 	info   params.UndertakerModelInfoResult
 	errors []error
 	dirty  bool
+
+	// keepingDestroyer is true if the environ can be destroyed
+	// while keeping some of its instances.
+	keepingDestroyer bool
 }
 
 func (fix fixture) cleanup(c *gc.C, w worker.Worker) {
@@ -96,9 +110,12 @@ func (fix fixture) cleanup(c *gc.C, w worker.Worker) {
 
 func (fix fixture) run(c *gc.C, test func(worker.Worker)) *testing.Stub {
 	stub := &testing.Stub{}
-	environ := &mockEnviron{
+	var environ environs.Environ = &mockEnviron{
 		stub: stub,
 	}
+	if fix.keepingDestroyer {
+		environ = &mockKeepingEnviron{environ.(*mockEnviron)}
+	}
 	facade := &mockFacade{
 		stub: stub,
 		info: fix.info,
@@ -7,14 +7,18 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
+var logger = loggo.GetLogger("juju.worker.undertaker")
+
 // Facade covers the parts of the api/undertaker.UndertakerClient that we
 // need for the worker. It's more than a little raw, but we'll survive.
 type Facade interface {
@@ -125,14 +129,20 @@ func (u *Undertaker) run() error {
 	}
 
 	// Now the model is known to be hosted and dead, we can tidy up any
-	// provider resources it might have used.
-	if err := u.setStatus(
-		status.Destroying, "tearing down cloud environment",
-	); err != nil {
-		return errors.Trace(err)
-	}
-	if err := u.config.Environ.Destroy(); err != nil {
-		return errors.Trace(err)
+	// provider resources it might have used, unless the model was
+	// destroyed keeping its instances. Destroying the environ would
+	// terminate them, so its resources are left for the user.
+	if modelInfo.KeepInstances {
+		logger.Infof("model %q is keeping its instances; not tearing down cloud environment", modelInfo.Name)
+	} else {
+		if err := u.setStatus(
+			status.Destroying, "tearing down cloud environment",
+		); err != nil {
+			return errors.Trace(err)
+		}
+		if err := u.destroyEnviron(modelInfo.KeptInstances); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Finally, remove the model.
@@ -142,6 +152,33 @@ func (u *Undertaker) run() error {
 	return nil
 }
 
+// destroyEnviron tears down the cloud environment, leaving running the
+// instances kept when their machines were removed from the model.
+func (u *Undertaker) destroyEnviron(keptInstances []string) error {
+	environ := u.config.Environ
+	if len(keptInstances) == 0 {
+		return environ.Destroy()
+	}
+	if destroyer, ok := environ.(environs.InstanceKeepingDestroyer); ok {
+		ids := make([]instance.Id, len(keptInstances))
+		for i, id := range keptInstances {
+			ids[i] = instance.Id(id)
+		}
+		return destroyer.DestroyKeepingInstances(ids)
+	}
+	if _, ok := environ.(environs.InstanceTagger); ok {
+		// The juju tags were removed from the kept instances when
+		// their machines were removed, so they are not destroyed
+		// along with the environ.
+		return environ.Destroy()
+	}
+	logger.Warningf(
+		"cannot tear down cloud environment without stopping kept instances %v; leaving it",
+		keptInstances,
+	)
+	return nil
+}
+
 func (u *Undertaker) setStatus(modelStatus status.Status, message string) error {
 	return u.config.Facade.SetStatus(modelStatus, message, nil)
 }
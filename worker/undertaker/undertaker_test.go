@@ -10,6 +10,7 @@ import (
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/workertest"
 )
@@ -67,6 +68,53 @@ func (s *UndertakerSuite) TestDyingDeadRemoved(c *gc.C) {
 	)
 }
 
+func (s *UndertakerSuite) TestKeepInstancesSkipsDestroy(c *gc.C) {
+	s.fix.info.Result.KeepInstances = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"WatchModelResources",
+		"ProcessDyingModel",
+		"RemoveModel",
+	)
+}
+
+func (s *UndertakerSuite) TestKeptInstancesDestroysKeepingThem(c *gc.C) {
+	s.fix.info.Result.KeptInstances = []string{"i-kept"}
+	s.fix.keepingDestroyer = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"WatchModelResources",
+		"ProcessDyingModel",
+		"SetStatus",
+		"DestroyKeepingInstances",
+		"RemoveModel",
+	)
+	stub.CheckCall(c, 5, "DestroyKeepingInstances", []instance.Id{"i-kept"})
+}
+
+func (s *UndertakerSuite) TestKeptInstancesNotSupportedSkipsDestroy(c *gc.C) {
+	s.fix.info.Result.KeptInstances = []string{"i-kept"}
+	stub := s.fix.run(c, func(w worker.Worker) {
+		workertest.CheckKilled(c, w)
+	})
+	stub.CheckCallNames(c,
+		"ModelInfo",
+		"SetStatus",
+		"WatchModelResources",
+		"ProcessDyingModel",
+		"SetStatus",
+		"RemoveModel",
+	)
+}
+
 func (s *UndertakerSuite) TestSetStatusDestroying(c *gc.C) {
 	stub := s.fix.run(c, func(w worker.Worker) {
 		workertest.CheckKilled(c, w)
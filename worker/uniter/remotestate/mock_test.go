@@ -6,11 +6,13 @@ package remotestate_test
 import (
 	"sync"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
@@ -191,6 +193,8 @@ type mockUnit struct {
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	upgradeSeriesWatcher  *mockNotifyWatcher
+	upgradeSeriesStatus   upgradeseries.Status
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -233,6 +237,17 @@ func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
 
+func (u *mockUnit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	if u.upgradeSeriesWatcher == nil {
+		return nil, errors.NotSupportedf("series upgrades by this controller")
+	}
+	return u.upgradeSeriesWatcher, nil
+}
+
+func (u *mockUnit) UpgradeSeriesStatus() (upgradeseries.Status, error) {
+	return u.upgradeSeriesStatus, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 )
 
 // Snapshot is a snapshot of the remote state of the unit.
@@ -61,6 +62,10 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// UpgradeSeriesStatus is how far through the series
+	// upgrade of its machine the unit has got.
+	UpgradeSeriesStatus upgradeseries.Status
+
 	// Actions is the list of pending actions to
 	// be peformed by this unit.
 	Actions []string
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/watcher"
 )
 
@@ -32,6 +33,8 @@ type Unit interface {
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	UpgradeSeriesStatus() (upgradeseries.Status, error)
 }
 
 type Application interface {
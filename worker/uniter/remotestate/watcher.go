@@ -257,6 +257,23 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenUpgradeSeriesChange bool
+	var upgradeSeriesChanges watcher.NotifyChannel
+	upgradeSeriesw, err := w.unit.WatchUpgradeSeriesNotifications()
+	switch {
+	case errors.IsNotSupported(err):
+		// The controller does not support series upgrades,
+		// so there is nothing to watch.
+	case err != nil:
+		return errors.Trace(err)
+	default:
+		if err := w.catacomb.Add(upgradeSeriesw); err != nil {
+			return errors.Trace(err)
+		}
+		upgradeSeriesChanges = upgradeSeriesw.Changes()
+		requiredEvents++
+	}
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -385,6 +402,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenStorageChange)
 
+		case _, ok := <-upgradeSeriesChanges:
+			logger.Debugf("got upgrade series change: ok=%t", ok)
+			if !ok {
+				return errors.New("upgrade series watcher closed")
+			}
+			if err := w.upgradeSeriesStatusChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenUpgradeSeriesChange)
+
 		case <-waitMinion:
 			logger.Debugf("got leadership change: minion")
 			if err := w.leadershipChanged(false); err != nil {
@@ -525,6 +552,19 @@ func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	return nil
 }
 
+// upgradeSeriesStatusChanged is called when the series upgrade of the
+// unit's machine changes.
+func (w *RemoteStateWatcher) upgradeSeriesStatusChanged() error {
+	status, err := w.unit.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.UpgradeSeriesStatus = status
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	w.mu.Lock()
 	w.current.Leader = isLeader
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -53,6 +54,8 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			configSettingsWatcher: newMockNotifyWatcher(),
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
+			upgradeSeriesWatcher:  newMockNotifyWatcher(),
+			upgradeSeriesStatus:   upgradeseries.NotStarted,
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -107,6 +110,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
 	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.service.relationsWatcher.changes <- []string{}
@@ -120,6 +124,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.configSettingsWatcher.changes <- struct{}{}
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
+	st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
@@ -142,6 +147,7 @@ func (s *WatcherSuite) TestSnapshot(c *gc.C) {
 		ConfigVersion:         2, // config settings and addresses
 		LeaderSettingsVersion: 1,
 		Leader:                true,
+		UpgradeSeriesStatus:   upgradeseries.NotStarted,
 	})
 }
 
@@ -183,10 +189,46 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	s.st.unit.service.relationsWatcher.changes <- []string{}
 	assertOneChange()
 
+	s.st.unit.upgradeSeriesStatus = upgradeseries.PrepareStarted
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, upgradeseries.PrepareStarted)
+
 	s.clock.Advance(statusTickDuration + 1)
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestUpgradeSeriesNotSupported(c *gc.C) {
+	// Stop the watcher started against a controller that
+	// supports series upgrades.
+	s.watcher.Kill()
+	err := s.watcher.Wait()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.st.unit.upgradeSeriesWatcher = nil
+	s.watcher, err = remotestate.NewWatcher(remotestate.WatcherConfig{
+		State:               s.st,
+		LeadershipTracker:   s.leadership,
+		UnitTag:             s.st.unit.tag,
+		UpdateStatusChannel: func() <-chan time.Time { return s.clock.After(statusTickDuration) },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The initial event is not held up waiting for an
+	// upgrade series change.
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	s.st.unit.addressesWatcher.changes <- struct{}{}
+	s.st.unit.configSettingsWatcher.changes <- struct{}{}
+	s.st.unit.storageWatcher.changes <- []string{}
+	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.service.serviceWatcher.changes <- struct{}{}
+	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
+	s.st.unit.service.relationsWatcher.changes <- []string{}
+	s.leadership.claimTicket.ch <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, upgradeseries.Status(""))
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -256,6 +257,19 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.Install})
 	}
 
+	// The machine's series upgrade waits on the unit running its
+	// series upgrade hooks, so run them before anything else.
+	switch remoteState.UpgradeSeriesStatus {
+	case upgradeseries.PrepareStarted:
+		if localState.UpgradeSeriesStatus != upgradeseries.PrepareCompleted {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PreSeriesUpgrade})
+		}
+	case upgradeseries.CompleteStarted:
+		if localState.UpgradeSeriesStatus != upgradeseries.Completed {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PostSeriesUpgrade})
+		}
+	}
+
 	if charmModified(localState, remoteState) {
 		return opFactory.NewUpgrade(remoteState.CharmURL)
 	}
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/upgradeseries"
 	"github.com/juju/juju/worker/uniter"
 	uniteractions "github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/hook"
@@ -252,3 +253,49 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
+
+func (s *resolverSuite) TestUpgradeSeriesHooks(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	for i, test := range []struct {
+		remote upgradeseries.Status
+		local  upgradeseries.Status
+		op     string
+	}{{
+		remote: upgradeseries.NotStarted,
+	}, {
+		remote: upgradeseries.PrepareStarted,
+		op:     "run pre-series-upgrade hook",
+	}, {
+		remote: upgradeseries.PrepareStarted,
+		local:  upgradeseries.PrepareCompleted,
+	}, {
+		remote: upgradeseries.PrepareCompleted,
+		local:  upgradeseries.PrepareCompleted,
+	}, {
+		remote: upgradeseries.CompleteStarted,
+		local:  upgradeseries.PrepareCompleted,
+		op:     "run post-series-upgrade hook",
+	}, {
+		remote: upgradeseries.CompleteStarted,
+		local:  upgradeseries.Completed,
+	}} {
+		c.Logf("test %d: remote %q, local %q", i, test.remote, test.local)
+		s.remoteState.UpgradeSeriesStatus = test.remote
+		localState.UpgradeSeriesStatus = test.local
+		op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+		if test.op == "" {
+			c.Check(err, gc.Equals, resolver.ErrNoOperation)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(op.String(), gc.Equals, test.op)
+	}
+}
@@ -169,6 +169,10 @@ type HookContext struct {
 	// closed when the current hook is committed.
 	pendingPorts map[PortRange]PortRangeInfo
 
+	// pendingEgress contains the egress rules to be opened or closed
+	// when the current hook is committed, keyed by their string form.
+	pendingEgress map[string]egressChange
+
 	// machinePorts contains cached information about all opened port
 	// ranges on the unit's assigned machine, mapped to the unit that
 	// opened each range and the relevant relation.
@@ -440,8 +444,13 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenPortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+// OpenPortsOnEndpoint implements jujuc.Context.
+func (ctx *HookContext) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, endpoint,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -455,6 +464,29 @@ func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error
 	)
 }
 
+// OpenEgress implements jujuc.Context.
+func (ctx *HookContext) OpenEgress(rule network.EgressRule) error {
+	return ctx.changeEgress(rule, true)
+}
+
+// CloseEgress implements jujuc.Context.
+func (ctx *HookContext) CloseEgress(rule network.EgressRule) error {
+	return ctx.changeEgress(rule, false)
+}
+
+func (ctx *HookContext) changeEgress(rule network.EgressRule, open bool) error {
+	rule.Protocol = strings.ToLower(rule.Protocol)
+	if err := rule.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	// Egress changes are accumulated before the context is flushed.
+	if ctx.pendingEgress == nil {
+		ctx.pendingEgress = make(map[string]egressChange)
+	}
+	ctx.pendingEgress[rule.String()] = egressChange{rule: rule, open: open}
+	return nil
+}
+
 func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	var unitRanges []network.PortRange
 	for portRange, relUnit := range ctx.machinePorts {
@@ -684,7 +716,15 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		if writeChanges {
 			var e error
 			var op string
-			if rangeInfo.ShouldOpen {
+			if rangeInfo.ShouldOpen && rangeInfo.Endpoint != "" {
+				e = ctx.unit.OpenPortsOnEndpoint(
+					rangeInfo.Endpoint,
+					rangeKey.Ports.Protocol,
+					rangeKey.Ports.FromPort,
+					rangeKey.Ports.ToPort,
+				)
+				op = "open"
+			} else if rangeInfo.ShouldOpen {
 				e = ctx.unit.OpenPorts(
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
@@ -709,6 +749,27 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	for _, change := range ctx.pendingEgress {
+		if writeChanges {
+			var e error
+			var op string
+			if change.open {
+				e = ctx.unit.OpenEgress(change.rule)
+				op = "open"
+			} else {
+				e = ctx.unit.CloseEgress(change.rule)
+				op = "close"
+			}
+			if e != nil {
+				e = errors.Annotatef(e, "cannot %s egress %v", op, change.rule)
+				logger.Errorf("%v", e)
+				if ctxErr == nil {
+					ctxErr = e
+				}
+			}
+		}
+	}
+
 	// add storage to unit dynamically
 	if len(ctx.storageAddConstraints) > 0 && writeChanges {
 		err := ctx.unit.AddStorage(ctx.storageAddConstraints)
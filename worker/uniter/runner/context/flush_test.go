@@ -168,6 +168,35 @@ func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	c.Assert(unitRanges, jc.DeepEquals, expectUnitRanges)
 }
 
+func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingEgress(c *gc.C) {
+	err := s.unit.OpenEgress("udp", 53, 53)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.context(c)
+	err = ctx.OpenEgress(network.MustNewEgressRule("TCP", 443, 443, "10.0.0.0/8"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.CloseEgress(network.MustNewEgressRule("udp", 53, 53))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.OpenEgress(network.MustNewEgressRule("tcp", 0, 80))
+	c.Assert(err, gc.ErrorMatches, `invalid port range 0-80/tcp`)
+
+	// Ensure the egress rules are not changed on the unit yet.
+	egress, err := s.unit.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	egress, err = s.unit.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+}
+
 func (s *FlushContextSuite) TestRunHookAddStorageOnFailure(c *gc.C) {
 	ctx := s.context(c)
 	c.Assert(ctx.UnitName(), gc.Equals, "u/0")
@@ -19,6 +19,10 @@ import (
 type PortRangeInfo struct {
 	ShouldOpen  bool
 	RelationTag names.RelationTag
+
+	// Endpoint holds the endpoint a range pending to be opened is
+	// opened for, or is empty if it is opened for all endpoints.
+	Endpoint string
 }
 
 // PortRange contains a port range and a relation id. Used as key to
@@ -28,6 +32,13 @@ type PortRange struct {
 	RelationId int
 }
 
+// egressChange holds an egress rule and whether it
+// is to be opened or closed.
+type egressChange struct {
+	rule network.EgressRule
+	open bool
+}
+
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
 	// Validate the given range.
 	newRange := network.PortRange{
@@ -44,6 +55,7 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 func tryOpenPorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -65,12 +77,11 @@ func tryOpenPorts(
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
 	if isKnown {
-		if !rangeInfo.ShouldOpen {
-			// If the same range is already pending to be closed, just
-			// mark is pending to be opened.
-			rangeInfo.ShouldOpen = true
-			pendingPorts[rangeKey] = rangeInfo
-		}
+		// If the same range is already pending to be closed, just
+		// mark is pending to be opened, for the latest endpoint.
+		rangeInfo.ShouldOpen = true
+		rangeInfo.Endpoint = endpoint
+		pendingPorts[rangeKey] = rangeInfo
 		return nil
 	}
 
@@ -87,9 +98,14 @@ func tryOpenPorts(
 		}
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
-				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				if endpoint == "" {
+					// The same unit trying to open the same range
+					// is just ignored.
+					return nil
+				}
+				// The range may be opened for another endpoint,
+				// so let the controller move it if need be.
+				break
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -109,6 +125,7 @@ func tryOpenPorts(
 
 	rangeInfo = pendingPorts[rangeKey]
 	rangeInfo.ShouldOpen = true
+	rangeInfo.Endpoint = endpoint
 	pendingPorts[rangeKey] = rangeInfo
 	return nil
 }
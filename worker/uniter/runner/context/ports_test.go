@@ -137,6 +137,7 @@ type portsTest struct {
 	about         string
 	proto         string
 	ports         []int
+	endpoint      string
 	machinePorts  map[network.PortRange]params.RelationUnit
 	pendingPorts  map[context.PortRange]context.PortRangeInfo
 	expectErr     string
@@ -192,6 +193,22 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:         "open a range conflicting with the same unit (ignored)",
 		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
 		expectPending: map[context.PortRange]context.PortRangeInfo{},
+	}, {
+		about:        "open a range opened by the same unit for an endpoint",
+		machinePorts: makeMachinePorts("u/0", "tcp", 10, 20),
+		endpoint:     "website",
+		expectPending: map[context.PortRange]context.PortRangeInfo{{
+			Ports:      network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"},
+			RelationId: -1,
+		}: {ShouldOpen: true, Endpoint: "website"}},
+	}, {
+		about:        "open a range pending to be opened for another endpoint",
+		pendingPorts: makePendingPorts("tcp", 10, 20, true),
+		endpoint:     "website",
+		expectPending: map[context.PortRange]context.PortRangeInfo{{
+			Ports:      network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"},
+			RelationId: -1,
+		}: {ShouldOpen: true, Endpoint: "website"}},
 	}, {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
@@ -205,6 +222,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
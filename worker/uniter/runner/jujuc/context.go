@@ -149,11 +149,24 @@ type ContextNetworking interface {
 	// executing unit's service is exposed.
 	OpenPorts(protocol string, fromPort, toPort int) error
 
+	// OpenPortsOnEndpoint marks the supplied port range for opening
+	// when the executing unit's service is exposed, reachable only
+	// through the named endpoint.
+	OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error
+
 	// ClosePorts ensures the supplied port range is closed even when
 	// the executing unit's service is exposed (unless it is opened
 	// separately by a co- located unit).
 	ClosePorts(protocol string, fromPort, toPort int) error
 
+	// OpenEgress marks the supplied egress rule for allowing outgoing
+	// traffic from the executing unit's machine.
+	OpenEgress(rule network.EgressRule) error
+
+	// CloseEgress removes an egress rule previously allowed by the
+	// executing unit.
+	CloseEgress(rule network.EgressRule) error
+
 	// OpenedPorts returns all port ranges currently opened by this
 	// unit on its assigned machine. The result is sorted first by
 	// protocol, then by number.
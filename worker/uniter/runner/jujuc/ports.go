@@ -5,6 +5,7 @@ package jujuc
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,6 +13,8 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/network"
 )
 
 const (
@@ -111,6 +114,11 @@ type portCommand struct {
 	FromPort   int
 	ToPort     int
 	formatFlag string // deprecated
+
+	// hasEndpoint is true for commands that
+	// accept the --endpoint flag.
+	hasEndpoint bool
+	Endpoint    string
 }
 
 func (c *portCommand) Info() *cmd.Info {
@@ -119,6 +127,9 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	if c.hasEndpoint {
+		f.StringVar(&c.Endpoint, "endpoint", "", "open the ports for the named endpoint only")
+	}
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -148,13 +159,24 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+If --endpoint is given, the port range may only be reached from the
+sources that endpoint is exposed to. Otherwise it may be reached from
+the sources of every exposed endpoint. Opening a range that the unit
+has already opened for another endpoint moves it to the given one.
+`,
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
-		info: openPortInfo,
+		info:        openPortInfo,
+		hasEndpoint: true,
 		action: func(c *portCommand) error {
+			if c.Endpoint != "" {
+				return ctx.OpenPortsOnEndpoint(c.Endpoint, c.Protocol, c.FromPort, c.ToPort)
+			}
 			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
 		},
 	}, nil
@@ -174,3 +196,75 @@ func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 		},
 	}, nil
 }
+
+const egressFormat = portFormat + " [<destination CIDR> ...]"
+
+// egressCommand implements the open-egress and close-egress commands.
+type egressCommand struct {
+	cmd.CommandBase
+	info   *cmd.Info
+	action func(*egressCommand) error
+	Rule   network.EgressRule
+}
+
+func (c *egressCommand) Info() *cmd.Info {
+	return c.info
+}
+
+func (c *egressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no port or range specified")
+	}
+
+	portRange, err := parseArguments(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	destinations := args[1:]
+	for _, cidr := range destinations {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("expected destination CIDR; got %q", cidr)
+		}
+	}
+	c.Rule, err = network.NewEgressRule(portRange.protocol, portRange.fromPort, portRange.toPort, destinations...)
+	return errors.Trace(err)
+}
+
+func (c *egressCommand) Run(ctx *cmd.Context) error {
+	return c.action(c)
+}
+
+var openEgressInfo = &cmd.Info{
+	Name:    "open-egress",
+	Args:    egressFormat,
+	Purpose: "allow outgoing traffic to a port or range",
+	Doc: `
+Once any unit on a machine has allowed outgoing traffic, the machine
+may only send traffic to the ports and destinations allowed by its
+units, where the cloud supports it. If no destinations are given,
+traffic to the port or range is allowed to any destination.`[1:],
+}
+
+func NewOpenEgressCommand(ctx Context) (cmd.Command, error) {
+	return &egressCommand{
+		info: openEgressInfo,
+		action: func(c *egressCommand) error {
+			return ctx.OpenEgress(c.Rule)
+		},
+	}, nil
+}
+
+var closeEgressInfo = &cmd.Info{
+	Name:    "close-egress",
+	Args:    egressFormat,
+	Purpose: "stop allowing outgoing traffic to a port or range",
+}
+
+func NewCloseEgressCommand(ctx Context) (cmd.Command, error) {
+	return &egressCommand{
+		info: closeEgressInfo,
+		action: func(c *egressCommand) error {
+			return ctx.CloseEgress(c.Rule)
+		},
+	}, nil
+}
@@ -82,6 +82,23 @@ func (s *PortsSuite) TestOpenClose(c *gc.C) {
 	}
 }
 
+func (s *PortsSuite) TestOpenOnEndpoint(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--endpoint", "website", "80"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	hctx.info.CheckPorts(c, makeRanges("80/tcp"))
+	s.Stub.CheckCall(c, 0, "OpenPortsOnEndpoint", "website", "tcp", 80, 80)
+
+	com, err = jujuc.NewCommand(hctx, cmdString("close-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"--endpoint", "website", "80"})
+	c.Assert(err, gc.ErrorMatches, "flag provided but not defined: --endpoint")
+}
+
 var badPortsTests = []struct {
 	args []string
 	err  string
@@ -126,6 +143,11 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+If --endpoint is given, the port range may only be reached from the
+sources that endpoint is exposed to. Otherwise it may be reached from
+the sources of every exposed endpoint. Opening a range that the unit
+has already opened for another endpoint moves it to the given one.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -138,6 +160,53 @@ ensure a port or range is always closed
 `[1:])
 }
 
+func (s *PortsSuite) TestOpenCloseEgress(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, t := range []struct {
+		cmd    []string
+		expect []network.EgressRule
+	}{{
+		[]string{"open-egress", "443", "10.0.0.0/8"},
+		[]network.EgressRule{network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")},
+	}, {
+		[]string{"open-egress", "53/udp"},
+		[]network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+			network.MustNewEgressRule("udp", 53, 53),
+		},
+	}, {
+		[]string{"close-egress", "443/tcp", "10.0.0.0/8"},
+		[]network.EgressRule{network.MustNewEgressRule("udp", 53, 53)},
+	}} {
+		com, err := jujuc.NewCommand(hctx, cmdString(t.cmd[0]))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.cmd[1:])
+		c.Assert(code, gc.Equals, 0)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+		hctx.info.CheckEgress(c, t.expect)
+	}
+}
+
+func (s *PortsSuite) TestEgressBadArgs(c *gc.C) {
+	for _, name := range []string{"open-egress", "close-egress"} {
+		for _, t := range []struct {
+			args []string
+			err  string
+		}{
+			{nil, "no port or range specified"},
+			{[]string{"80/http"}, `protocol must be "tcp" or "udp"; got "http"`},
+			{[]string{"443", "10.0/8"}, `expected destination CIDR; got "10.0/8"`},
+		} {
+			hctx := s.GetHookContext(c, -1, "")
+			com, err := jujuc.NewCommand(hctx, cmdString(name))
+			c.Assert(err, jc.ErrorIsNil)
+			err = cmdtesting.InitCommand(com, t.args)
+			c.Assert(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
 // Since the deprecation warning gets output during Run, we really need
 // some valid commands to run
 var portsFormatDeprectaionTests = []struct {
@@ -56,11 +56,22 @@ func (*RestrictedContext) OpenPorts(protocol string, fromPort, toPort int) error
 	return ErrRestrictedContext
 }
 
+// OpenPortsOnEndpoint implements jujuc.Context.
+func (*RestrictedContext) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // ClosePorts implements jujuc.Context.
 func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
 }
 
+// OpenEgress implements jujuc.Context.
+func (*RestrictedContext) OpenEgress(rule network.EgressRule) error { return ErrRestrictedContext }
+
+// CloseEgress implements jujuc.Context.
+func (*RestrictedContext) CloseEgress(rule network.EgressRule) error { return ErrRestrictedContext }
+
 // OpenedPorts implements jujuc.Context.
 func (*RestrictedContext) OpenedPorts() []network.PortRange { return nil }
 
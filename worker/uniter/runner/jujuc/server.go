@@ -43,9 +43,11 @@ func RegisterCommand(name string, f creator) {
 
 // baseCommands maps Command names to creators.
 var baseCommands = map[string]creator{
+	"close-egress" + cmdSuffix:            NewCloseEgressCommand,
 	"close-port" + cmdSuffix:              NewClosePortCommand,
 	"config-get" + cmdSuffix:              NewConfigGetCommand,
 	"juju-log" + cmdSuffix:                NewJujuLogCommand,
+	"open-egress" + cmdSuffix:             NewOpenEgressCommand,
 	"open-port" + cmdSuffix:               NewOpenPortCommand,
 	"opened-ports" + cmdSuffix:            NewOpenedPortsCommand,
 	"relation-get" + cmdSuffix:            NewRelationGetCommand,
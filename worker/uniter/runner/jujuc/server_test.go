@@ -251,9 +251,11 @@ var newCommandTests = []struct {
 	name string
 	err  string
 }{
+	{"close-egress", ""},
 	{"close-port", ""},
 	{"config-get", ""},
 	{"juju-log", ""},
+	{"open-egress", ""},
 	{"open-port", ""},
 	{"opened-ports", ""},
 	{"relation-get", ""},
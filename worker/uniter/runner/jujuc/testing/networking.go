@@ -17,6 +17,7 @@ type NetworkInterface struct {
 	PublicAddress      string
 	PrivateAddress     string
 	Ports              []network.PortRange
+	Egress             []network.EgressRule
 	NetworkInfoResults map[string]params.NetworkInfoResult
 }
 
@@ -51,6 +52,27 @@ func (ni *NetworkInterface) RemovePorts(protocol string, from, to int) {
 	network.SortPortRanges(ni.Ports)
 }
 
+// CheckEgress checks the current egress rules.
+func (ni *NetworkInterface) CheckEgress(c *gc.C, expected []network.EgressRule) {
+	c.Check(ni.Egress, jc.DeepEquals, expected)
+}
+
+// AddEgress adds the specified egress rule.
+func (ni *NetworkInterface) AddEgress(rule network.EgressRule) {
+	ni.Egress = append(ni.Egress, rule)
+	network.SortEgressRules(ni.Egress)
+}
+
+// RemoveEgress removes the specified egress rule.
+func (ni *NetworkInterface) RemoveEgress(rule network.EgressRule) {
+	for i, existing := range ni.Egress {
+		if existing.String() == rule.String() {
+			ni.Egress = append(ni.Egress[:i], ni.Egress[i+1:]...)
+			break
+		}
+	}
+}
+
 // ContextNetworking is a test double for jujuc.ContextNetworking.
 type ContextNetworking struct {
 	contextBase
@@ -84,6 +106,17 @@ func (c *ContextNetworking) OpenPorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenPortsOnEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenPortsOnEndpoint(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("OpenPortsOnEndpoint", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddPorts(protocol, from, to)
+	return nil
+}
+
 // ClosePorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	c.stub.AddCall("ClosePorts", protocol, from, to)
@@ -95,6 +128,28 @@ func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenEgress implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenEgress(rule network.EgressRule) error {
+	c.stub.AddCall("OpenEgress", rule)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddEgress(rule)
+	return nil
+}
+
+// CloseEgress implements jujuc.ContextNetworking.
+func (c *ContextNetworking) CloseEgress(rule network.EgressRule) error {
+	c.stub.AddCall("CloseEgress", rule)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.RemoveEgress(rule)
+	return nil
+}
+
 // OpenedPorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) OpenedPorts() []network.PortRange {
 	c.stub.AddCall("OpenedPorts")